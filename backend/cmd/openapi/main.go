@@ -0,0 +1,35 @@
+// Command openapi writes the OpenAPI document served at /swagger/doc.json to
+// a file, so it can be checked in or handed to client generators.
+//
+//	go run ./cmd/openapi -out docs/openapi.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"crypto-indicator-dashboard/internal/presentation/docs"
+)
+
+func main() {
+	out := flag.String("out", "openapi.json", "output file, or - for stdout")
+	flag.Parse()
+
+	data, err := json.MarshalIndent(docs.Spec(), "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to render OpenAPI spec:", err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+
+	if *out == "-" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write OpenAPI spec:", err)
+		os.Exit(1)
+	}
+}
@@ -2,15 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto-indicator-dashboard/internal/application/services"
 	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
 	"crypto-indicator-dashboard/internal/presentation/handlers"
-	"crypto-indicator-dashboard/internal/presentation/middleware"
-	"crypto-indicator-dashboard/models"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 	
 	"github.com/gin-gonic/gin"
 )
@@ -32,120 +31,53 @@ func main() {
 
 	// Run database migrations if database is available
 	if deps.DB != nil {
-		if err := models.AutoMigrate(deps.DB); err != nil {
+		if applied, err := database.NewMigrator(deps.DB, deps.Logger).Migrate(context.Background()); err != nil {
 			deps.Logger.Error("Database migration failed", "error", err)
 		} else {
-			deps.Logger.Info("Database migrations completed successfully")
+			deps.Logger.Info("Database migrations applied", "count", applied)
 		}
 	}
 
+	// Load stored indicator configs now that migrations have created their table
+	if deps.IndicatorConfigService != nil {
+		if err := deps.IndicatorConfigService.Load(context.Background()); err != nil {
+			deps.Logger.Warn("Failed to load indicator configs, using defaults", "error", err)
+		}
+	}
+
+	// Start background jobs
+	if err := deps.Scheduler.Start(context.Background()); err != nil {
+		deps.Logger.Error("Failed to start job scheduler", "error", err)
+	}
+
 	// Set Gin mode based on environment
 	if cfg.Server.IsProduction() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Create Gin router
-	router := gin.New()
-
-	// Add middleware
-	router.Use(middleware.ErrorLogging(deps.Logger))
-	router.Use(middleware.RequestLogging(deps.Logger))
-	router.Use(middleware.CORS(cfg))
-	
-	// Rate limiting (100 requests per minute)
-	rateLimiter := middleware.NewRateLimiter(100, deps.Logger)
-	router.Use(rateLimiter.RateLimit())
-
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"message":   "Crypto Indicator Dashboard API",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"version":   "2.0.0",
-		})
-	})
-
-	// Initialize handlers
-	portfolioHandler := handlers.NewPortfolioHandler(deps.PortfolioUseCase, deps.Logger)
-	indicatorHandler := handlers.NewIndicatorHandler(deps)
+	// Market data handler, shared with the cache warmup below
 	marketDataHandler := handlers.NewMarketDataHandler(
 		deps.MarketDataService,
 		deps.CoinMarketCapClient,
 		deps.TradingViewScraper,
+		deps.SymbolMetadataService,
+		deps.Cache,
+		deps.Config.External.MarketSummaryCacheTTL,
 		deps.Logger,
 	)
 
-	// API routes
-	apiV1 := router.Group("/api/v1")
-	{
-		// Portfolio routes
-		portfolios := apiV1.Group("/portfolios")
-		{
-			portfolios.POST("", portfolioHandler.CreatePortfolio)
-			portfolios.GET("", portfolioHandler.GetUserPortfolios)
-			portfolios.GET("/:id", portfolioHandler.GetPortfolio)
-			portfolios.GET("/:id/summary", portfolioHandler.GetPortfolioSummary)
-			portfolios.POST("/:id/holdings", portfolioHandler.AddHolding)
-			portfolios.PUT("/:id/holdings/:holdingId", portfolioHandler.UpdateHolding)
-			portfolios.DELETE("/:id/holdings/:holdingId", portfolioHandler.RemoveHolding)
-		}
-
-		// Register indicator routes using the new handler
-		indicatorHandler.RegisterRoutes(apiV1)
-
-		// Register market data routes using proper handler
-		marketDataHandler.RegisterRoutes(apiV1)
-
-		// Market cycle
-		apiV1.GET("/market/cycle", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"message": "Market cycle endpoint - new implementation coming soon",
-			})
-		})
-
-		// Macro indicators (placeholder endpoints to prevent frontend errors)
-		macro := apiV1.Group("/macro")
-		{
-			macro.GET("/inflation", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{
-					"success": true,
-					"data": gin.H{
-						"value":       "3.2%",
-						"change":      "+0.1%",
-						"risk_level":  "medium",
-						"status":      "Macro indicators coming soon",
-						"last_updated": time.Now(),
-					},
-				})
-			})
-			
-			macro.GET("/interest-rates", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{
-					"success": true,
-					"data": gin.H{
-						"value":       "5.25%",
-						"change":      "Unchanged",
-						"risk_level":  "medium", 
-						"status":      "Macro indicators coming soon",
-						"last_updated": time.Now(),
-					},
-				})
-			})
-		}
+	router, err := newRouter(cfg, deps, marketDataHandler)
+	if err != nil {
+		panic("Failed to build router: " + err.Error())
+	}
 
-		// Portfolio risk endpoint (placeholder to prevent frontend errors)
-		apiV1.GET("/portfolio/risk", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"success": true,
-				"data": gin.H{
-					"risk_level":     "medium",
-					"risk_score":     45,
-					"recommendation": "Portfolio risk analysis coming soon",
-					"last_updated":   time.Now(),
-				},
-			})
+	// Fill caches before taking traffic; failures only cost the first requests their latency
+	if cfg.Indicators.WarmCachesOnStartup {
+		targets := append(deps.CacheWarmupTargets(), services.CacheWarmupTarget{
+			Name: "market_summary",
+			Warm: marketDataHandler.WarmSummaryCache,
 		})
+		services.WarmCaches(context.Background(), targets, cfg.Indicators.CacheWarmupTimeout, deps.Logger)
 	}
 
 	// Create HTTP server
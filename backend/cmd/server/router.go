@@ -0,0 +1,182 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/infrastructure/scheduler"
+	"crypto-indicator-dashboard/internal/presentation/handlers"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
+	"crypto-indicator-dashboard/pkg/buildinfo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRouter builds the HTTP router with its middleware and every route
+func newRouter(cfg *config.Config, deps *config.Dependencies, marketDataHandler *handlers.MarketDataHandler) (*gin.Engine, error) {
+	// Create Gin router
+	router := gin.New()
+
+	// Add middleware
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing())
+	router.Use(middleware.ErrorLogging(deps.Logger))
+	router.Use(middleware.RequestLogging(deps.Logger))
+	router.Use(middleware.CORS(cfg))
+	router.Use(middleware.MaxBodyBytes(cfg.Server.MaxBodyBytes))
+	router.Use(middleware.CacheControl(
+		middleware.CacheRule{Prefix: "/api/v1/indicators", MaxAge: cfg.Server.IndicatorCacheMaxAge},
+		middleware.CacheRule{Prefix: "/api/v1/charts", MaxAge: cfg.Server.ChartCacheMaxAge},
+		middleware.CacheRule{Prefix: "/swagger", MaxAge: cfg.Server.DocsCacheMaxAge},
+	))
+
+	// Rate limiting (100 requests per minute), except for monitoring probes
+	rateLimiter := middleware.NewRateLimiter(100, deps.Logger)
+	router.Use(rateLimiter.RateLimit(cfg.Server.RateLimitExemptPaths...))
+
+	// Health check endpoint
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status":    "healthy",
+			"message":   "Crypto Indicator Dashboard API",
+			"timestamp": time.Now().Format(time.RFC3339),
+			"version":   buildinfo.Get().Version,
+		})
+	})
+	handlers.NewVersionHandler().RegisterRoutes(router)
+	// TradingView canary state; only the canary map is published so process internals
+	// such as the command line and memstats stay private
+	router.GET("/metrics", func(c *gin.Context) {
+		body := `{"tradingview_canary": ` + scheduler.TradingViewCanaryMetricsJSON() + `}`
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(body))
+	})
+
+	// API documentation
+	docsHandler, err := handlers.NewDocsHandler(cfg.Server.SwaggerUIAssetsURL)
+	if err != nil {
+		return nil, err
+	}
+	docsHandler.RegisterRoutes(router)
+
+	// Initialize handlers
+	portfolioHandler := handlers.NewPortfolioHandler(deps.PortfolioUseCase, deps.Logger)
+	indicatorHandler := handlers.NewIndicatorHandler(deps)
+	annotationHandler := handlers.NewAnnotationHandler(deps.AnnotationRepo, deps.Logger)
+
+	// Per-user routes require an API key; public indicator reads stay open
+	requireAPIKey := middleware.APIKeyAuth(deps.APIKeyRepo, deps.Logger)
+	// Operator routes under /admin additionally require a key with the admin scope
+	requireAdmin := []gin.HandlerFunc{requireAPIKey, middleware.RequireScope(entities.APIKeyScopeAdmin)}
+
+	// API routes
+	apiV1 := router.Group("/api/v1")
+	{
+		// Portfolio routes
+		portfolios := apiV1.Group("/portfolios", requireAPIKey)
+		{
+			portfolios.POST("", portfolioHandler.CreatePortfolio)
+			portfolios.GET("", portfolioHandler.GetUserPortfolios)
+			portfolios.GET("/:id", portfolioHandler.GetPortfolio)
+			portfolios.GET("/:id/summary", portfolioHandler.GetPortfolioSummary)
+			portfolios.POST("/:id/holdings", portfolioHandler.AddHolding)
+			portfolios.PUT("/:id/holdings", portfolioHandler.UpdateHoldings)
+			portfolios.DELETE("/:id/holdings", portfolioHandler.ClearHoldings)
+			portfolios.PUT("/:id/holdings/:holdingId", portfolioHandler.UpdateHolding)
+			portfolios.DELETE("/:id/holdings/:holdingId", portfolioHandler.RemoveHolding)
+			portfolios.POST("/:id/holdings/:holdingId/transactions", portfolioHandler.RecordTransaction)
+			portfolios.POST("/:id/holdings/:holdingId/sell", portfolioHandler.SellHolding)
+		}
+
+		// Indicator reads are public; recalculation hits upstream providers and bulk
+		// ingestion writes the shared indicators, so both are for operators only
+		indicatorHandler.RegisterRoutes(apiV1, requireAdmin...)
+
+		// Chart annotations are public to read; changes require an API key
+		annotationHandler.RegisterRoutes(apiV1, requireAPIKey)
+
+		// Provider health history for operators
+		handlers.NewProviderHealthHandler(deps.ProviderHealthRepo, deps.Logger).RegisterRoutes(apiV1, requireAdmin...)
+		handlers.NewProviderRawHandler(deps.RawProviderProxy, deps.Logger).RegisterRoutes(apiV1, requireAdmin...)
+
+		// Trailing-peak drawdown alerts on the user's portfolios
+		handlers.NewPortfolioAlertHandler(deps.PortfolioAlertService, deps.Logger).RegisterRoutes(apiV1, requireAPIKey)
+
+		// Per-user DCA strategies and fixed vs smart DCA backtests
+		handlers.NewDCAHandler(deps.DCARepo, deps.SmartDCAService, deps.Logger).RegisterRoutes(apiV1, requireAPIKey)
+
+		// Composite risk score, with weights adjustable by operators at runtime
+		handlers.NewCompositeWeightHandler(deps.CompositeWeightService, deps.Logger).RegisterRoutes(apiV1, requireAdmin...)
+
+		// Indicator descriptions and bands, tunable by analysts at runtime
+		handlers.NewIndicatorConfigHandler(deps.IndicatorConfigService, deps.Logger).RegisterRoutes(apiV1, requireAdmin...)
+
+		// Targeted cache invalidation for operators
+		handlers.NewCacheAdminHandler(deps.Cache, deps.Logger).RegisterRoutes(apiV1, requireAdmin...)
+
+		// Register market data routes using proper handler
+		marketDataHandler.RegisterRoutes(apiV1)
+		handlers.NewMarketStreamHandler(
+			deps.TickerStream,
+			deps.Config.Server.MarketStreamHeartbeat,
+			deps.Config.Server.MarketStreamMaxPerClient,
+			deps.Logger,
+		).RegisterRoutes(apiV1)
+
+		// Blockchain network statistics
+		handlers.NewNetworkHandler(deps.NetworkMetricsService, deps.Logger).RegisterRoutes(apiV1)
+
+		// Market cycle
+		apiV1.GET("/market/cycle", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{
+				"message": "Market cycle endpoint - new implementation coming soon",
+			})
+		})
+
+		// Macro indicators (placeholder endpoints to prevent frontend errors)
+		macro := apiV1.Group("/macro")
+		{
+			macro.GET("/inflation", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{
+					"success": true,
+					"data": gin.H{
+						"value":        "3.2%",
+						"change":       "+0.1%",
+						"risk_level":   "medium",
+						"status":       "Macro indicators coming soon",
+						"last_updated": time.Now(),
+					},
+				})
+			})
+
+			macro.GET("/interest-rates", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{
+					"success": true,
+					"data": gin.H{
+						"value":        "5.25%",
+						"change":       "Unchanged",
+						"risk_level":   "medium",
+						"status":       "Macro indicators coming soon",
+						"last_updated": time.Now(),
+					},
+				})
+			})
+		}
+
+		// Portfolio risk endpoint (placeholder to prevent frontend errors)
+		apiV1.GET("/portfolio/risk", requireAPIKey, func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{
+				"success": true,
+				"data": gin.H{
+					"risk_level":     "medium",
+					"risk_score":     45,
+					"recommendation": "Portfolio risk analysis coming soon",
+					"last_updated":   time.Now(),
+				},
+			})
+		})
+	}
+
+	return router, nil
+}
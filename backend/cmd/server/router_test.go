@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/application/usecases"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/presentation/docs"
+	"crypto-indicator-dashboard/internal/presentation/handlers"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/models"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutesMatchSpec fails when a route is registered without being documented in the
+// OpenAPI operations table, or documented without being registered
+func TestRoutesMatchSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	deps := &config.Dependencies{Config: cfg, Logger: logger.New("test")}
+	marketDataHandler := handlers.NewMarketDataHandler(nil, nil, nil, nil, nil, 0, deps.Logger)
+
+	router, err := newRouter(cfg, deps, marketDataHandler)
+	require.NoError(t, err)
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		// The docs routes serve the spec itself
+		if strings.HasPrefix(route.Path, "/swagger") {
+			continue
+		}
+		registered[route.Method+" "+openAPIPath(route.Path)] = true
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range docs.Spec()["paths"].(map[string]interface{}) {
+		for method := range item.(map[string]interface{}) {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	for route := range registered {
+		assert.True(t, documented[route], "%s is registered but missing from the OpenAPI operations", route)
+	}
+	for route := range documented {
+		assert.True(t, registered[route], "%s is documented but not registered", route)
+	}
+}
+
+// openAPIPath converts gin's :param and *param segments to OpenAPI {param} syntax
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// staticAPIKeys authenticates a fixed set of API keys, by key
+type staticAPIKeys map[string]*entities.APIKey
+
+func (k staticAPIKeys) Create(ctx context.Context, apiKey *entities.APIKey) error {
+	return nil
+}
+
+func (k staticAPIKeys) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	for key, apiKey := range k {
+		if entities.HashAPIKey(key) == keyHash {
+			return apiKey, nil
+		}
+	}
+	return nil, errors.NotFound("API key")
+}
+
+func (k staticAPIKeys) TouchLastUsed(ctx context.Context, id uint) error {
+	return nil
+}
+
+// serve sends a request with apiKey through router
+func serve(router *gin.Engine, method, path, apiKey, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set(middleware.APIKeyHeader, apiKey)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPortfolioRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.PortfolioTablesDDL...)
+	require.NoError(t, testDB.DB.Create(&models.Portfolio{ID: 1, UserID: "alice", Name: "Main"}).Error)
+	require.NoError(t, testDB.DB.Create(&models.PortfolioHolding{ID: 1, PortfolioID: 1, Symbol: "BTC", Amount: 1, AveragePrice: 30000}).Error)
+
+	deps := &config.Dependencies{
+		Config:     cfg,
+		Logger:     logger.New("test"),
+		APIKeyRepo: staticAPIKeys{"alice-key": {ID: 1, UserID: "alice", Active: true}},
+	}
+	marketDataHandler := handlers.NewMarketDataHandler(nil, nil, nil, nil, nil, 0, deps.Logger)
+
+	// Without portfolio storage the routes answer 503 rather than failing on a nil use case
+	router, err := newRouter(cfg, deps, marketDataHandler)
+	require.NoError(t, err)
+	w := serve(router, http.MethodPut, "/api/v1/portfolios/1/holdings", "alice-key", `[{"holding_id": 1, "amount": 2, "average_price": 31000}]`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, w.Body.String())
+
+	deps.PortfolioUseCase = usecases.NewPortfolioUseCase(database.NewPortfolioRepository(database.NewDBProvider(testDB.DB, nil)), nil, nil)
+	router, err = newRouter(cfg, deps, marketDataHandler)
+	require.NoError(t, err)
+
+	w = serve(router, http.MethodPut, "/api/v1/portfolios/1/holdings", "alice-key", `[{"holding_id": 1, "amount": 2, "average_price": 31000}]`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var holding models.PortfolioHolding
+	require.NoError(t, testDB.DB.First(&holding, 1).Error)
+	assert.Equal(t, 2.0, holding.Amount)
+
+	w = serve(router, http.MethodPost, "/api/v1/portfolios/1/holdings/1/sell", "alice-key", `{"quantity": 0.5, "price": 40000}`)
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serve(router, http.MethodPut, "/api/v1/portfolios/1/holdings", "", `[{"holding_id": 1, "amount": 3, "average_price": 31000}]`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+}
+
+func TestIndicatorWriteRoutesRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	deps := &config.Dependencies{
+		Config: cfg,
+		Logger: logger.New("test"),
+		APIKeyRepo: staticAPIKeys{
+			"user-key":  {ID: 1, UserID: "alice", Active: true},
+			"admin-key": {ID: 2, UserID: "ops", Scopes: entities.APIKeyScopeAdmin, Active: true},
+		},
+	}
+	router, err := newRouter(cfg, deps, handlers.NewMarketDataHandler(nil, nil, nil, nil, nil, 0, deps.Logger))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"recalculate", "/api/v1/indicators/mvrv/recalculate", ""},
+		{"bulk ingest", "/api/v1/indicators/bulk", `[{"name": "mvrv", "type": "onchain", "value": 2.1, "timestamp": "2024-01-01T00:00:00Z"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodPost, tt.path, "user-key", tt.body)
+			assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+			// An admin key gets past the guard; nothing is configured behind it here
+			w = serve(router, http.MethodPost, tt.path, "admin-key", tt.body)
+			assert.NotEqual(t, http.StatusForbidden, w.Code, w.Body.String())
+			assert.NotEqual(t, http.StatusUnauthorized, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+)
+
+// dcaSymbolPattern matches ticker symbols such as BTC or 1INCH
+var dcaSymbolPattern = regexp.MustCompile(`^[A-Z0-9]{2,10}$`)
+
+// CreateDCAStrategyRequest represents a request to create a DCA strategy
+type CreateDCAStrategyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Symbol    string     `json:"symbol" binding:"required"`
+	Amount    float64    `json:"amount" binding:"required,gt=0"`
+	Frequency string     `json:"frequency" binding:"required,oneof=daily weekly monthly"`
+	StartDate time.Time  `json:"start_date" binding:"required"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+}
+
+// Validate validates the create DCA strategy request, reporting every invalid field as
+// "field: problem" separated by "; "
+func (r *CreateDCAStrategyRequest) Validate() error {
+	var problems []string
+	if strings.TrimSpace(r.Name) == "" {
+		problems = append(problems, "name: is required")
+	} else if len(r.Name) > 100 {
+		problems = append(problems, "name: must have at most 100 characters")
+	}
+	if !dcaSymbolPattern.MatchString(strings.ToUpper(r.Symbol)) {
+		problems = append(problems, "symbol: must be 2 to 10 letters or digits")
+	}
+	if r.Amount <= 0 {
+		problems = append(problems, "amount: must be greater than 0")
+	}
+	if !entities.IsDCAFrequency(r.Frequency) {
+		problems = append(problems, "frequency: must be one of: daily weekly monthly")
+	}
+	if r.StartDate.IsZero() {
+		problems = append(problems, "start_date: is required")
+	}
+	if r.EndDate != nil && !r.StartDate.IsZero() && !r.EndDate.After(r.StartDate) {
+		problems = append(problems, "end_date: must be after start_date")
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ToEntity converts the request to an active strategy owned by userID
+func (r *CreateDCAStrategyRequest) ToEntity(userID string) *entities.DCAStrategy {
+	return &entities.DCAStrategy{
+		UserID:    userID,
+		Name:      strings.TrimSpace(r.Name),
+		Symbol:    strings.ToUpper(r.Symbol),
+		Amount:    r.Amount,
+		Frequency: r.Frequency,
+		StartDate: r.StartDate,
+		EndDate:   r.EndDate,
+		IsActive:  true,
+	}
+}
+
+// DCABacktestRequest runs a purchase schedule with a fixed amount and with smart DCA
+// multipliers. Each point carries the price and indicator readings of one purchase; up to
+// 7300 points, about 20 years of daily buys, are accepted.
+type DCABacktestRequest struct {
+	Amount float64                     `json:"amount" binding:"required,gt=0"`
+	Points []entities.DCABacktestPoint `json:"points" binding:"required,min=1,max=7300"`
+}
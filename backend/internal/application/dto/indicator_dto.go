@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"errors"
 	"fmt"
 	"time"
 	"crypto-indicator-dashboard/internal/domain/entities"
@@ -13,6 +14,7 @@ type BaseIndicatorResponse struct {
 	RiskLevel string    `json:"risk_level"`
 	Status    string    `json:"status"`
 	Timestamp time.Time `json:"timestamp"`
+	entities.IndicatorChanges
 }
 
 // MVRVResponse represents MVRV indicator response
@@ -91,7 +93,7 @@ func NewFearGreedResponse(result *entities.FearGreedResult) *FearGreedResponse {
 		changeStr = fmt.Sprintf("%d", result.Change24h)
 	}
 	
-	return &FearGreedResponse{
+	response := &FearGreedResponse{
 		BaseIndicatorResponse: BaseIndicatorResponse{
 			Value:     fmt.Sprintf("%d", result.CurrentValue),
 			Change:    changeStr,
@@ -102,12 +104,18 @@ func NewFearGreedResponse(result *entities.FearGreedResult) *FearGreedResponse {
 		Details: map[string]interface{}{
 			"classification":         result.Classification,
 			"change_7d":             result.Change7d,
-			"components":            result.Components,
 			"trading_recommendation": result.TradingRecommendation,
 			"data_source":           result.DataSource,
 			"next_update":           result.NextUpdate,
 		},
 	}
+	
+	// Components are only available from providers that publish a breakdown
+	if len(result.Components) > 0 {
+		response.Details["components"] = result.Components
+	}
+	
+	return response
 }
 
 // BubbleRiskResponse represents bubble risk response
@@ -241,4 +249,97 @@ func NewChartDataResponse(indicator string, data map[string]interface{}) *ChartD
 		Indicator: indicator,
 		Data:      data,
 	}
-}
\ No newline at end of file
+}
+
+// MaxBulkIndicators is the maximum number of indicators accepted in one bulk request
+const MaxBulkIndicators = 1000
+
+// IndicatorPayload represents a single precomputed indicator value pushed by an external pipeline
+type IndicatorPayload struct {
+	Name      string                 `json:"name"`
+	Type      string                 `json:"type"`
+	Value     *float64               `json:"value"`
+	Timestamp time.Time              `json:"timestamp"`
+	Metadata  map[string]interface{} `json:"metadata"`
+}
+
+// Validate validates the indicator payload
+func (p *IndicatorPayload) Validate() error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(p.Name) > 100 {
+		return errors.New("name must be less than 100 characters")
+	}
+	if p.Type == "" {
+		return errors.New("type is required")
+	}
+	if p.Value == nil {
+		return errors.New("value is required")
+	}
+	if p.Timestamp.IsZero() {
+		return errors.New("timestamp is required")
+	}
+	if p.Timestamp.After(time.Now().Add(5 * time.Minute)) {
+		return errors.New("timestamp must not be in the future")
+	}
+	return nil
+}
+
+// ToEntity converts the payload to an indicator entity
+func (p *IndicatorPayload) ToEntity() entities.Indicator {
+	return entities.Indicator{
+		Name:      p.Name,
+		Type:      p.Type,
+		Value:     *p.Value,
+		Source:    entities.DataSourceBulkIngest,
+		Metadata:  p.Metadata,
+		Timestamp: p.Timestamp,
+	}
+}
+
+// AnnotationRequest represents a request to create or replace a chart annotation
+type AnnotationRequest struct {
+	Timestamp time.Time `json:"timestamp"`
+	Label     string    `json:"label"`
+	Type      string    `json:"type"`
+}
+
+// Validate validates the annotation request
+func (r *AnnotationRequest) Validate() error {
+	if r.Timestamp.IsZero() {
+		return errors.New("timestamp is required")
+	}
+	if r.Label == "" {
+		return errors.New("label is required")
+	}
+	if len(r.Label) > 200 {
+		return errors.New("label must be less than 200 characters")
+	}
+	if !entities.IsValidAnnotationType(r.Type) {
+		return fmt.Errorf("type must be one of %s, %s, %s, %s or %s",
+			entities.AnnotationTypeHalving, entities.AnnotationTypeNews, entities.AnnotationTypeRegulation,
+			entities.AnnotationTypeMacro, entities.AnnotationTypeOther)
+	}
+	return nil
+}
+
+// ToEntity converts the request to an annotation entity
+func (r *AnnotationRequest) ToEntity() *entities.IndicatorAnnotation {
+	return &entities.IndicatorAnnotation{
+		Timestamp: r.Timestamp,
+		Label:     r.Label,
+		Type:      r.Type,
+	}
+}
+
+// CompositeWeightsRequest replaces the composite risk score weights, keyed by component
+type CompositeWeightsRequest struct {
+	Weights map[string]float64 `json:"weights" binding:"required"`
+}
+
+// IndicatorConfigRequest replaces an indicator's description and bands
+type IndicatorConfigRequest struct {
+	Description string                   `json:"description"`
+	Bands       []entities.IndicatorBand `json:"bands" binding:"required"`
+}
@@ -8,15 +8,11 @@ import (
 
 // CreatePortfolioRequest represents a request to create a portfolio
 type CreatePortfolioRequest struct {
-	UserID string `json:"user_id" binding:"required"`
-	Name   string `json:"name" binding:"required,min=1,max=100"`
+	Name string `json:"name" binding:"required,min=1,max=100"`
 }
 
 // Validate validates the create portfolio request
 func (r *CreatePortfolioRequest) Validate() error {
-	if r.UserID == "" {
-		return errors.New("user ID is required")
-	}
 	if r.Name == "" {
 		return errors.New("portfolio name is required")
 	}
@@ -72,6 +68,77 @@ func (r *UpdateHoldingRequest) Validate() error {
 	return nil
 }
 
+// HoldingTransactionRequest represents a buy or sell against a holding
+type HoldingTransactionRequest struct {
+	PortfolioID uint       `json:"-"`
+	HoldingID   uint       `json:"-"`
+	Side        string     `json:"side" binding:"required,oneof=buy sell"`
+	Quantity    float64    `json:"quantity" binding:"required,gt=0"`
+	Price       float64    `json:"price" binding:"required,gt=0"`
+	ExecutedAt  *time.Time `json:"executed_at,omitempty"`
+}
+
+// Validate validates the holding transaction request
+func (r *HoldingTransactionRequest) Validate() error {
+	if r.PortfolioID == 0 {
+		return errors.New("portfolio ID is required")
+	}
+	if r.HoldingID == 0 {
+		return errors.New("holding ID is required")
+	}
+	if r.Side != entities.TransactionSideBuy && r.Side != entities.TransactionSideSell {
+		return errors.New("side must be buy or sell")
+	}
+	if r.Quantity <= 0 {
+		return errors.New("quantity must be greater than 0")
+	}
+	if r.Price <= 0 {
+		return errors.New("price must be greater than 0")
+	}
+	return nil
+}
+
+// HoldingTransactionResponse represents a recorded transaction and the resulting holding
+type HoldingTransactionResponse struct {
+	Transaction entities.HoldingTransaction `json:"transaction"`
+	Holding     HoldingResponse             `json:"holding"`
+	Lots        []entities.HoldingLot       `json:"lots"`
+}
+
+// SellHoldingRequest represents a sale of part or all of a holding at average cost
+type SellHoldingRequest struct {
+	PortfolioID uint       `json:"-"`
+	HoldingID   uint       `json:"-"`
+	Quantity    float64    `json:"quantity" binding:"required,gt=0"`
+	Price       float64    `json:"price" binding:"required,gt=0"`
+	ExecutedAt  *time.Time `json:"executed_at,omitempty"`
+}
+
+// Validate validates the sell holding request
+func (r *SellHoldingRequest) Validate() error {
+	if r.PortfolioID == 0 {
+		return errors.New("portfolio ID is required")
+	}
+	if r.HoldingID == 0 {
+		return errors.New("holding ID is required")
+	}
+	if r.Quantity <= 0 {
+		return errors.New("quantity must be greater than 0")
+	}
+	if r.Price <= 0 {
+		return errors.New("price must be greater than 0")
+	}
+	return nil
+}
+
+// SellHoldingResponse represents a recorded sale and the holding it left
+type SellHoldingResponse struct {
+	Transaction entities.HoldingTransaction `json:"transaction"`
+	Holding     HoldingResponse             `json:"holding"`
+	// Removed reports that the sale emptied the holding and it was deleted
+	Removed bool `json:"removed"`
+}
+
 // PortfolioResponse represents a portfolio response
 type PortfolioResponse struct {
 	ID          uint                `json:"id"`
@@ -114,6 +181,7 @@ type HoldingResponse struct {
 	Value        float64   `json:"value"`
 	PnL          float64   `json:"pnl"`
 	PnLPercent   float64   `json:"pnl_percent"`
+	RealizedPnL  float64   `json:"realized_pnl"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -130,6 +198,7 @@ func NewHoldingResponse(holding *entities.PortfolioHolding) *HoldingResponse {
 		Value:        holding.Value,
 		PnL:          holding.PnL,
 		PnLPercent:   holding.PnLPercent,
+		RealizedPnL:  holding.RealizedPnL,
 		CreatedAt:    holding.CreatedAt,
 		UpdatedAt:    holding.UpdatedAt,
 	}
@@ -189,4 +258,10 @@ func NewPortfolioSummaryResponse(summary *entities.PortfolioSummary) *PortfolioS
 		AllocationByAsset: summary.AllocationByAsset,
 		RiskMetrics:       summary.RiskMetrics,
 	}
-}
\ No newline at end of file
+}
+
+// CreateDrawdownAlertRequest represents a request to alert when a portfolio falls a
+// percentage below its trailing peak
+type CreateDrawdownAlertRequest struct {
+	ThresholdPercent float64 `json:"threshold_percent" binding:"required,gt=0,lt=100"`
+}
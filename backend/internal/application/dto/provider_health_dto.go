@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"time"
+)
+
+// ProviderHealthResponse reports provider health over a recent window
+type ProviderHealthResponse struct {
+	WindowHours float64                         `json:"window_hours"`
+	Since       time.Time                       `json:"since"`
+	Providers   []entities.ProviderHealthStatus `json:"providers"`
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	altSeasonIndicatorName = "altcoin_season_index"
+	altSeasonCacheKey      = "altcoin_season_snapshot"
+	altSeasonCacheTTL      = 30 * time.Minute
+
+	// altSeasonCalcVersion is stored with each index value; bump it when the coin
+	// selection or scoring changes
+	altSeasonCalcVersion uint = 1
+
+	// altSeasonTopCoins is the number of coins ranked after BTC that make up the index
+	altSeasonTopCoins = 50
+	// altSeasonListingLimit leaves room for BTC and stablecoins in the listings request
+	altSeasonListingLimit = 100
+
+	// AltSeasonThreshold is the index above which the market is in altcoin season
+	AltSeasonThreshold = 75.0
+	// BitcoinSeasonThreshold is the index below which the market is in bitcoin season
+	BitcoinSeasonThreshold = 25.0
+)
+
+// Altcoin season classifications
+const (
+	SeasonAltcoin = "altcoin_season"
+	SeasonBitcoin = "bitcoin_season"
+	SeasonNeutral = "neutral"
+)
+
+// altSeasonStablecoins are excluded even when CoinMarketCap does not tag them
+var altSeasonStablecoins = map[string]bool{
+	"USDT": true, "USDC": true, "DAI": true, "FDUSD": true, "TUSD": true, "USDE": true, "PYUSD": true, "USDD": true,
+}
+
+// CoinMarketCapListingsClient is the subset of the CoinMarketCap client used for top-coin listings
+type CoinMarketCapListingsClient interface {
+	GetListingsLatest(ctx context.Context, limit int, convert string) (*external.ListingsLatestResponse, error)
+}
+
+// altSeasonSnapshot is the cached result of an index calculation
+type altSeasonSnapshot struct {
+	Index         float64   `json:"index"`
+	BTCChange90d  float64   `json:"btc_change_90d"`
+	Outperforming int       `json:"outperforming"`
+	Eligible      int       `json:"eligible"`
+	Excluded      []string  `json:"excluded"`
+	CalculatedAt  time.Time `json:"calculated_at"`
+	// SourceUpdatedAt is the newest last_updated among the listings the index was computed from
+	SourceUpdatedAt time.Time `json:"source_updated_at"`
+}
+
+// altSeasonServiceImpl implements the IndicatorService interface for the Altcoin Season Index
+type altSeasonServiceImpl struct {
+	recomputeGuarded
+
+	listingsClient CoinMarketCapListingsClient
+	indicatorRepo  repositories.IndicatorRepository
+	cache          services.CacheService
+	logger         logger.Logger
+}
+
+// NewAltSeasonService creates a new Altcoin Season Index service
+func NewAltSeasonService(
+	listingsClient CoinMarketCapListingsClient,
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.IndicatorService {
+	s := &altSeasonServiceImpl{
+		listingsClient: listingsClient,
+		indicatorRepo:  indicatorRepo,
+		cache:          cache,
+		logger:         logger,
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, altSeasonIndicatorName, altSeasonCacheTTL, logger)
+	return s
+}
+
+// Calculate computes the percentage of the top 50 altcoins that outperformed BTC over 90 days
+func (s *altSeasonServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	log.Info("Starting altcoin season index calculation")
+
+	// The listings are always fetched so a changed source is never hidden by the cache;
+	// the index is only recomputed when their last_updated moves
+	response, err := s.listingsClient.GetListingsLatest(ctx, altSeasonListingLimit, "USD")
+	if err != nil {
+		return nil, errors.External("CoinMarketCap", "failed to calculate altcoin season index", err)
+	}
+	sourceUpdated := listingsLastUpdated(response.Data)
+
+	fresh := false
+	var snapshot altSeasonSnapshot
+	compute := func() (interface{}, error) {
+		fresh = true
+		return computeAltSeasonSnapshot(response.Data, sourceUpdated)
+	}
+
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, sourceCacheKey(altSeasonCacheKey, sourceUpdated), &snapshot, altSeasonCacheTTL, compute)
+	} else {
+		var value interface{}
+		if value, err = compute(); err == nil {
+			snapshot = *value.(*altSeasonSnapshot)
+		}
+	}
+	if err != nil {
+		return nil, errors.External("CoinMarketCap", "failed to calculate altcoin season index", err)
+	}
+
+	classification, riskLevel, status := classifyAltSeason(snapshot.Index)
+	indicator := &entities.Indicator{
+		Name:        altSeasonIndicatorName,
+		Type:        "market",
+		Value:       snapshot.Index,
+		Change:      fmt.Sprintf("%d/%d", snapshot.Outperforming, snapshot.Eligible),
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: "Percentage of the top 50 altcoins that outperformed Bitcoin over the last 90 days",
+		Source:      entities.DataSourceCoinMarketCap,
+		Confidence:  float64(snapshot.Eligible) / altSeasonTopCoins,
+		CalcVersion: altSeasonCalcVersion,
+		Timestamp:   snapshot.CalculatedAt,
+		Metadata: map[string]interface{}{
+			"classification": classification,
+			"btc_change_90d": snapshot.BTCChange90d,
+			"outperforming":  snapshot.Outperforming,
+			"eligible":       snapshot.Eligible,
+			"excluded":       snapshot.Excluded,
+			"threshold":      AltSeasonThreshold,
+		},
+	}
+	if !snapshot.SourceUpdatedAt.IsZero() {
+		indicator.Metadata["source_updated_at"] = snapshot.SourceUpdatedAt
+	}
+
+	// Only persist newly computed values, not results reused for unchanged source data
+	if fresh && s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save altcoin season indicator to database", "error", err)
+		}
+	}
+
+	return indicator, nil
+}
+
+// GetHistoricalData retrieves stored altcoin season index values
+func (s *altSeasonServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical altcoin season data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, altSeasonIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored index if it is fresh, otherwise recalculates it
+func (s *altSeasonServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, altSeasonIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > altSeasonCacheTTL {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
+
+// computeAltSeasonSnapshot computes the index from the listings fetched for this calculation
+func computeAltSeasonSnapshot(listings []external.CryptoPriceData, sourceUpdated time.Time) (*altSeasonSnapshot, error) {
+	snapshot, err := calculateAltSeasonIndex(listings)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.CalculatedAt = time.Now()
+	snapshot.SourceUpdatedAt = sourceUpdated
+	return snapshot, nil
+}
+
+// listingsLastUpdated returns the newest last_updated among the listings and their USD
+// quotes, or the zero time when CoinMarketCap reported none
+func listingsLastUpdated(listings []external.CryptoPriceData) time.Time {
+	var latest time.Time
+	for _, coin := range listings {
+		if coin.LastUpdated.After(latest) {
+			latest = coin.LastUpdated
+		}
+		if updated := coin.Quote["USD"].LastUpdated; updated.After(latest) {
+			latest = updated
+		}
+	}
+	return latest
+}
+
+// calculateAltSeasonIndex compares the 90d USD change of the top 50 non-stablecoin
+// altcoins against BTC. Coins without 90d data are left out of the denominator.
+func calculateAltSeasonIndex(listings []external.CryptoPriceData) (*altSeasonSnapshot, error) {
+	var btcChange *float64
+	for _, coin := range listings {
+		if strings.EqualFold(coin.Symbol, "BTC") {
+			btcChange = coin.Quote["USD"].PercentChange90d
+			break
+		}
+	}
+	if btcChange == nil {
+		return nil, fmt.Errorf("no 90d BTC performance in listings")
+	}
+
+	snapshot := &altSeasonSnapshot{BTCChange90d: *btcChange, Excluded: []string{}}
+	considered := 0
+	for _, coin := range listings {
+		if considered == altSeasonTopCoins {
+			break
+		}
+		if strings.EqualFold(coin.Symbol, "BTC") || isStablecoin(coin) {
+			continue
+		}
+		considered++
+
+		change := coin.Quote["USD"].PercentChange90d
+		if change == nil {
+			snapshot.Excluded = append(snapshot.Excluded, coin.Symbol)
+			continue
+		}
+		snapshot.Eligible++
+		if *change > *btcChange {
+			snapshot.Outperforming++
+		}
+	}
+
+	if snapshot.Eligible == 0 {
+		return nil, fmt.Errorf("no altcoins with 90d performance in listings")
+	}
+
+	snapshot.Index = float64(snapshot.Outperforming) / float64(snapshot.Eligible) * 100
+	return snapshot, nil
+}
+
+// isStablecoin reports whether a listing is a stablecoin by tag or known symbol
+func isStablecoin(coin external.CryptoPriceData) bool {
+	if altSeasonStablecoins[strings.ToUpper(coin.Symbol)] {
+		return true
+	}
+	for _, tag := range coin.Tags {
+		if tag == "stablecoin" {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyAltSeason maps an index value to a classification, risk level and status
+func classifyAltSeason(index float64) (classification, riskLevel, status string) {
+	switch {
+	case index > AltSeasonThreshold:
+		return SeasonAltcoin, "high", "Altcoin season - altcoins broadly outperforming Bitcoin"
+	case index < BitcoinSeasonThreshold:
+		return SeasonBitcoin, "low", "Bitcoin season - Bitcoin outperforming most altcoins"
+	default:
+		return SeasonNeutral, "medium", "Neutral - no clear altcoin or Bitcoin season"
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// listing builds a CoinMarketCap listing; a nil change90d means no 90d history
+func listing(symbol string, change90d *float64, tags ...string) external.CryptoPriceData {
+	return external.CryptoPriceData{
+		Symbol: symbol,
+		Tags:   tags,
+		Quote:  map[string]external.Quote{"USD": {PercentChange90d: change90d}},
+	}
+}
+
+func pct(v float64) *float64 { return &v }
+
+func TestCalculateAltSeasonIndex(t *testing.T) {
+	listings := []external.CryptoPriceData{
+		listing("BTC", pct(20)),
+		listing("ETH", pct(35)),               // Outperforms
+		listing("USDT", pct(0), "stablecoin"), // Stablecoin is skipped
+		listing("SOL", pct(-5)),               // Underperforms
+		listing("XRP", nil),                   // No 90d data, left out of the denominator
+		listing("ADA", pct(20)),               // Equal is not outperforming
+		listing("DOGE", pct(60)),              // Outperforms
+		listing("USDC", pct(0.01)),            // Untagged stablecoin is skipped
+	}
+
+	snapshot, err := calculateAltSeasonIndex(listings)
+
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, snapshot.BTCChange90d)
+	assert.Equal(t, 4, snapshot.Eligible)
+	assert.Equal(t, 2, snapshot.Outperforming)
+	assert.Equal(t, []string{"XRP"}, snapshot.Excluded)
+	assert.InDelta(t, 50.0, snapshot.Index, 1e-9)
+}
+
+func TestCalculateAltSeasonIndex_TopFiftyOnly(t *testing.T) {
+	listings := []external.CryptoPriceData{listing("BTC", pct(10))}
+	for i := 0; i < 50; i++ {
+		listings = append(listings, listing(fmt.Sprintf("ALT%d", i), pct(50)))
+	}
+	// Ranked below the top 50, so ignored
+	for i := 0; i < 10; i++ {
+		listings = append(listings, listing(fmt.Sprintf("LOW%d", i), pct(-50)))
+	}
+
+	snapshot, err := calculateAltSeasonIndex(listings)
+
+	require.NoError(t, err)
+	assert.Equal(t, 50, snapshot.Eligible)
+	assert.Equal(t, 100.0, snapshot.Index)
+}
+
+func TestCalculateAltSeasonIndex_MissingData(t *testing.T) {
+	_, err := calculateAltSeasonIndex([]external.CryptoPriceData{listing("ETH", pct(10))})
+	assert.Error(t, err, "should fail without BTC performance")
+
+	_, err = calculateAltSeasonIndex([]external.CryptoPriceData{listing("BTC", pct(10)), listing("ETH", nil)})
+	assert.Error(t, err, "should fail without any eligible altcoins")
+}
+
+func TestClassifyAltSeason(t *testing.T) {
+	tests := []struct {
+		index          float64
+		classification string
+		riskLevel      string
+	}{
+		{90, SeasonAltcoin, "high"},
+		{75, SeasonNeutral, "medium"},
+		{50, SeasonNeutral, "medium"},
+		{10, SeasonBitcoin, "low"},
+	}
+
+	for _, tt := range tests {
+		classification, riskLevel, status := classifyAltSeason(tt.index)
+		assert.Equal(t, tt.classification, classification, "index %v", tt.index)
+		assert.Equal(t, tt.riskLevel, riskLevel, "index %v", tt.index)
+		assert.NotEmpty(t, status)
+	}
+}
+
+func TestAltSeasonService_Calculate(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	listings := func(updated time.Time, solChange float64) *external.ListingsLatestResponse {
+		data := []external.CryptoPriceData{
+			listing("BTC", pct(10)),
+			listing("ETH", pct(40)),
+			listing("SOL", pct(solChange)),
+			listing("BNB", pct(12)),
+			listing("ADA", pct(-3)),
+		}
+		data[1].LastUpdated = updated
+		return &external.ListingsLatestResponse{Data: data}
+	}
+
+	client := &testutil.MockCoinMarketCapClient{}
+	client.On("GetListingsLatest", mock.Anything, altSeasonListingLimit, "USD").Return(listings(updatedAt, 25), nil).Twice()
+
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("Create", ctx, mock.Anything).Return(nil)
+
+	service := NewAltSeasonService(client, repo, cache.NewCacheService(nil, log), log)
+
+	indicator, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 75.0, indicator.Value, 1e-9)
+	assert.Equal(t, "altcoin_season_index", indicator.Name)
+	assert.Equal(t, SeasonNeutral, indicator.Metadata["classification"])
+	assert.Equal(t, 3, indicator.Metadata["outperforming"])
+	assert.Equal(t, 4, indicator.Metadata["eligible"])
+	assert.Equal(t, altSeasonCalcVersion, indicator.CalcVersion)
+	assert.Equal(t, updatedAt, indicator.Metadata["source_updated_at"])
+	repo.AssertNumberOfCalls(t, "Create", 1)
+
+	// Unchanged source data reuses the result without recomputing or re-saving
+	cached, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, indicator.Value, cached.Value, 1e-9)
+	assert.Equal(t, indicator.Timestamp, cached.Timestamp)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+
+	// Newer source data is recomputed straight away, well within the cache TTL
+	client.On("GetListingsLatest", mock.Anything, altSeasonListingLimit, "USD").Return(listings(updatedAt.Add(time.Minute), 5), nil).Once()
+	updated, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 50.0, updated.Value, 1e-9)
+	repo.AssertNumberOfCalls(t, "Create", 2)
+
+	client.AssertExpectations(t)
+}
+
+func TestListingsLastUpdated(t *testing.T) {
+	coinUpdated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	quoteUpdated := coinUpdated.Add(30 * time.Second)
+
+	btc := listing("BTC", pct(1))
+	btc.LastUpdated = coinUpdated
+	eth := listing("ETH", pct(1))
+	eth.Quote["USD"] = external.Quote{LastUpdated: quoteUpdated}
+
+	assert.Equal(t, quoteUpdated, listingsLastUpdated([]external.CryptoPriceData{btc, eth}))
+	assert.True(t, listingsLastUpdated([]external.CryptoPriceData{listing("BTC", pct(1))}).IsZero())
+}
+
+func TestAltSeasonService_Calculate_UpstreamError(t *testing.T) {
+	log := logger.New("test")
+
+	client := &testutil.MockCoinMarketCapClient{}
+	client.On("GetListingsLatest", mock.Anything, altSeasonListingLimit, "USD").Return(nil, fmt.Errorf("rate limited"))
+
+	service := NewAltSeasonService(client, nil, cache.NewCacheService(nil, log), log)
+
+	_, err := service.Calculate(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+	"fmt"
 	"testing"
 	"time"
 
@@ -14,7 +16,7 @@ func BenchmarkMVRVService(b *testing.B) {
 	// Setup test environment
 	mockIndicatorRepo := &testutil.MockIndicatorRepository{}
 	mockMarketRepo := &testutil.MockMarketDataRepository{}
-	mockCache := testutil.NewMockInfrastructureCacheService()
+	mockCache := testutil.NewMockCacheService()
 	testDB := testutil.NewTestDB(&testing.T{})
 	defer testDB.Cleanup()
 
@@ -187,4 +189,29 @@ func BenchmarkConcurrentOperations(b *testing.B) {
 			}
 		})
 	})
-}
\ No newline at end of file
+}
+// BenchmarkFallbackPriceFetch benchmarks per-symbol fallback pricing at different concurrency limits
+func BenchmarkFallbackPriceFetch(b *testing.B) {
+	symbols := []string{"BTC", "ETH", "BNB", "SOL", "ADA", "XRP", "DOT", "AVAX", "MATIC", "LINK"}
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+
+	for _, concurrency := range []int{1, 4, 10} {
+		b.Run(fmt.Sprintf("Concurrency%d", concurrency), func(b *testing.B) {
+			cfg := DefaultPriceSourceConfig()
+			cfg.Concurrency = concurrency
+			service := &marketDataServiceImpl{
+				repo:          repo,
+				priceFallback: &slowPriceFallback{delay: time.Millisecond},
+				priceConfig:   cfg,
+				logger:        logger.New("test"),
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = service.fetchCryptoPricesFromFallback(context.Background(), symbols)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// CacheWarmupTarget is one cache to fill before the server takes traffic, such as an
+// indicator service's latest value
+type CacheWarmupTarget struct {
+	Name string
+	Warm func(ctx context.Context) error
+}
+
+// WarmCaches runs every target once, concurrently, and waits until they finish or timeout
+// passes. Failures are logged and skipped so a broken upstream never holds up startup; the
+// names of targets that failed or did not finish in time are returned.
+func WarmCaches(ctx context.Context, targets []CacheWarmupTarget, timeout time.Duration, log logger.Logger) []string {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	var (
+		mu       sync.Mutex
+		failed   []string
+		finished bool
+		wg       sync.WaitGroup
+	)
+	pending := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		pending[target.Name] = struct{}{}
+	}
+
+	done := make(chan struct{})
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target CacheWarmupTarget) {
+			defer wg.Done()
+			err := target.Warm(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if finished {
+				// Reported as timed out already
+				return
+			}
+			delete(pending, target.Name)
+			if err != nil {
+				failed = append(failed, target.Name)
+				log.Warn("Cache warm-up failed", "target", target.Name, "error", err)
+			}
+		}(target)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	finished = true
+	for name := range pending {
+		failed = append(failed, name)
+		log.Warn("Cache warm-up did not finish in time", "target", name, "timeout", timeout)
+	}
+
+	sort.Strings(failed)
+
+	log.Info("Cache warm-up finished",
+		"targets", len(targets),
+		"failed", len(failed),
+		"duration", time.Since(start),
+	)
+	return failed
+}
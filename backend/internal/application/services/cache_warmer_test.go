@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmCaches(t *testing.T) {
+	var calls [3]int32
+	target := func(i int, err error) CacheWarmupTarget {
+		return CacheWarmupTarget{
+			Name: fmt.Sprintf("target_%d", i),
+			Warm: func(ctx context.Context) error {
+				atomic.AddInt32(&calls[i], 1)
+				return err
+			},
+		}
+	}
+
+	failed := WarmCaches(context.Background(), []CacheWarmupTarget{
+		target(0, nil),
+		target(1, fmt.Errorf("upstream down")),
+		target(2, nil),
+	}, time.Second, logger.New("test"))
+
+	for i := range calls {
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls[i]), "target %d", i)
+	}
+	assert.Equal(t, []string{"target_1"}, failed)
+}
+
+func TestWarmCaches_Timeout(t *testing.T) {
+	fast := CacheWarmupTarget{Name: "fast", Warm: func(ctx context.Context) error { return nil }}
+	slow := CacheWarmupTarget{Name: "slow", Warm: func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return ctx.Err()
+	}}
+
+	start := time.Now()
+	failed := WarmCaches(context.Background(), []CacheWarmupTarget{fast, slow}, 20*time.Millisecond, logger.New("test"))
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []string{"slow"}, failed)
+}
+
+func TestWarmCaches_NoTargets(t *testing.T) {
+	assert.Empty(t, WarmCaches(context.Background(), nil, time.Second, logger.New("test")))
+}
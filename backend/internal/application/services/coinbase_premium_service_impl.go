@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	coinbasePremiumIndicatorName = "coinbase_premium"
+	coinbasePremiumCacheKey      = "coinbase_premium_snapshot"
+	coinbasePremiumCacheTTL      = 3 * time.Minute
+	coinbasePremiumMarketLimit   = 200
+
+	// coinbasePremiumCalcVersion is stored with each premium; bump it when the market
+	// selection or weighting changes
+	coinbasePremiumCalcVersion uint = 1
+
+	// coinbasePremiumNeutralBand is the premium percentage treated as noise around zero
+	coinbasePremiumNeutralBand = 0.05
+)
+
+// Premium classifications
+const (
+	PremiumPositive = "positive"
+	PremiumNegative = "negative"
+	PremiumNeutral  = "neutral"
+)
+
+// coinbaseExchangeIDs are the CoinCap exchange IDs used for Coinbase
+var coinbaseExchangeIDs = map[string]bool{"coinbase": true, "gdax": true}
+
+// premiumQuoteSymbols are the dollar quotes included in the global average
+var premiumQuoteSymbols = map[string]bool{"USD": true, "USDT": true, "USDC": true}
+
+// CoinCapMarketsClient is the subset of the CoinCap client used for exchange market data
+type CoinCapMarketsClient interface {
+	GetMarkets(ctx context.Context, assetID string, limit int) (*external.MarketsResponse, error)
+}
+
+// coinbasePremiumSnapshot is the cached result of a premium calculation
+type coinbasePremiumSnapshot struct {
+	CoinbasePrice float64   `json:"coinbase_price"`
+	GlobalPrice   float64   `json:"global_price"`
+	Premium       float64   `json:"premium"`
+	MarketsUsed   int       `json:"markets_used"`
+	CalculatedAt  time.Time `json:"calculated_at"`
+}
+
+// coinbasePremiumServiceImpl implements the IndicatorService interface for the Coinbase Premium Index
+type coinbasePremiumServiceImpl struct {
+	recomputeGuarded
+
+	marketsClient CoinCapMarketsClient
+	indicatorRepo repositories.IndicatorRepository
+	cache         services.CacheService
+	logger        logger.Logger
+}
+
+// NewCoinbasePremiumService creates a new Coinbase Premium Index service
+func NewCoinbasePremiumService(
+	marketsClient CoinCapMarketsClient,
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.IndicatorService {
+	s := &coinbasePremiumServiceImpl{
+		marketsClient: marketsClient,
+		indicatorRepo: indicatorRepo,
+		cache:         cache,
+		logger:        logger,
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, coinbasePremiumIndicatorName, coinbasePremiumCacheTTL, logger)
+	return s
+}
+
+// Calculate computes the premium of Coinbase BTC/USD over the volume-weighted global BTC price
+func (s *coinbasePremiumServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	log.Info("Starting Coinbase premium calculation")
+
+	fresh := false
+	var snapshot coinbasePremiumSnapshot
+	fetch := func() (interface{}, error) {
+		fresh = true
+		return s.fetchSnapshot(ctx)
+	}
+
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, coinbasePremiumCacheKey, &snapshot, coinbasePremiumCacheTTL, fetch)
+	} else {
+		var value interface{}
+		if value, err = fetch(); err == nil {
+			snapshot = *value.(*coinbasePremiumSnapshot)
+		}
+	}
+	if err != nil {
+		return nil, errors.External("CoinCap", "failed to calculate Coinbase premium", err)
+	}
+
+	classification, riskLevel, status := classifyPremium(snapshot.Premium)
+	indicator := &entities.Indicator{
+		Name:        coinbasePremiumIndicatorName,
+		Type:        "market",
+		Value:       snapshot.Premium,
+		Change:      fmt.Sprintf("%+.3f%%", snapshot.Premium),
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: "Coinbase BTC/USD price premium over the global average, a proxy for US institutional demand",
+		Source:      entities.DataSourceCoinCap,
+		Confidence:  premiumConfidence(snapshot.MarketsUsed),
+		CalcVersion: coinbasePremiumCalcVersion,
+		Timestamp:   snapshot.CalculatedAt,
+		Metadata: map[string]interface{}{
+			"coinbase_price": snapshot.CoinbasePrice,
+			"global_price":   snapshot.GlobalPrice,
+			"markets_used":   snapshot.MarketsUsed,
+			"classification": classification,
+		},
+	}
+
+	// Only persist newly computed values, not cache hits
+	if fresh && s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save Coinbase premium indicator to database", "error", err)
+		}
+	}
+
+	return indicator, nil
+}
+
+// GetHistoricalData retrieves stored Coinbase premium values
+func (s *coinbasePremiumServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical Coinbase premium data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, coinbasePremiumIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored premium if it is fresh, otherwise recalculates it
+func (s *coinbasePremiumServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, coinbasePremiumIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > coinbasePremiumCacheTTL {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
+
+// fetchSnapshot fetches BTC exchange markets and computes the current premium
+func (s *coinbasePremiumServiceImpl) fetchSnapshot(ctx context.Context) (*coinbasePremiumSnapshot, error) {
+	response, err := s.marketsClient.GetMarkets(ctx, "bitcoin", coinbasePremiumMarketLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	coinbasePrice, globalPrice, marketsUsed, err := calculatePremiumPrices(response.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coinbasePremiumSnapshot{
+		CoinbasePrice: coinbasePrice,
+		GlobalPrice:   globalPrice,
+		Premium:       (coinbasePrice - globalPrice) / globalPrice * 100,
+		MarketsUsed:   marketsUsed,
+		CalculatedAt:  time.Now(),
+	}, nil
+}
+
+// calculatePremiumPrices returns the Coinbase BTC/USD price and the volume-weighted
+// average USD price across the other dollar-quoted BTC markets
+func calculatePremiumPrices(markets []external.Market) (coinbasePrice, globalPrice float64, marketsUsed int, err error) {
+	var weightedSum, totalVolume float64
+
+	for _, market := range markets {
+		if !strings.EqualFold(market.BaseSymbol, "BTC") {
+			continue
+		}
+		quote := strings.ToUpper(market.QuoteSymbol)
+		price, _ := strconv.ParseFloat(market.PriceUSD, 64)
+		if price <= 0 {
+			continue
+		}
+
+		if coinbaseExchangeIDs[strings.ToLower(market.ExchangeID)] {
+			if quote == "USD" {
+				coinbasePrice = price
+			}
+			continue
+		}
+
+		volume, _ := strconv.ParseFloat(market.VolumeUSD24Hr, 64)
+		if !premiumQuoteSymbols[quote] || volume <= 0 {
+			continue
+		}
+		weightedSum += price * volume
+		totalVolume += volume
+		marketsUsed++
+	}
+
+	if coinbasePrice == 0 {
+		return 0, 0, 0, fmt.Errorf("no Coinbase BTC/USD market found")
+	}
+	if totalVolume == 0 {
+		return 0, 0, 0, fmt.Errorf("no global BTC markets with volume found")
+	}
+
+	return coinbasePrice, weightedSum / totalVolume, marketsUsed, nil
+}
+
+// classifyPremium maps a premium percentage to a classification, risk level and status
+func classifyPremium(premium float64) (classification, riskLevel, status string) {
+	switch {
+	case premium > coinbasePremiumNeutralBand:
+		return PremiumPositive, "low", "Positive premium - US buying pressure"
+	case premium < -coinbasePremiumNeutralBand:
+		return PremiumNegative, "high", "Negative premium - US selling pressure"
+	default:
+		return PremiumNeutral, "medium", "Neutral premium - balanced demand"
+	}
+}
+
+// premiumConfidence scales confidence with the number of markets in the global average
+func premiumConfidence(marketsUsed int) float64 {
+	return math.Min(0.9, 0.5+0.05*float64(marketsUsed))
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func btcMarket(exchange, quote, price, volume string) external.Market {
+	return external.Market{
+		ExchangeID:    exchange,
+		BaseSymbol:    "BTC",
+		BaseID:        "bitcoin",
+		QuoteSymbol:   quote,
+		PriceUSD:      price,
+		VolumeUSD24Hr: volume,
+	}
+}
+
+func TestCalculatePremiumPrices(t *testing.T) {
+	markets := []external.Market{
+		btcMarket("coinbase", "USD", "60300", "500000000"),
+		btcMarket("coinbase", "EUR", "60100", "50000000"), // Non-USD Coinbase market is ignored
+		btcMarket("binance", "USDT", "60000", "3000000000"),
+		btcMarket("kraken", "USD", "60100", "1000000000"),
+		btcMarket("bitfinex", "EUR", "59000", "900000000"), // Non-dollar quote is ignored
+		btcMarket("okx", "USDC", "0", "100000000"),         // Missing price is ignored
+		{ExchangeID: "binance", BaseSymbol: "ETH", QuoteSymbol: "USDT", PriceUSD: "3000", VolumeUSD24Hr: "1"},
+	}
+
+	coinbasePrice, globalPrice, marketsUsed, err := calculatePremiumPrices(markets)
+
+	require.NoError(t, err)
+	assert.Equal(t, 60300.0, coinbasePrice)
+	// (60000*3e9 + 60100*1e9) / 4e9
+	assert.InDelta(t, 60025.0, globalPrice, 1e-9)
+	assert.Equal(t, 2, marketsUsed)
+}
+
+func TestCalculatePremiumPrices_MissingMarkets(t *testing.T) {
+	_, _, _, err := calculatePremiumPrices([]external.Market{btcMarket("binance", "USDT", "60000", "1000")})
+	assert.Error(t, err, "should fail without a Coinbase market")
+
+	_, _, _, err = calculatePremiumPrices([]external.Market{btcMarket("coinbase", "USD", "60000", "1000")})
+	assert.Error(t, err, "should fail without global markets")
+}
+
+func TestClassifyPremium(t *testing.T) {
+	tests := []struct {
+		premium        float64
+		classification string
+		riskLevel      string
+	}{
+		{0.25, PremiumPositive, "low"},
+		{0.01, PremiumNeutral, "medium"},
+		{-0.01, PremiumNeutral, "medium"},
+		{-0.3, PremiumNegative, "high"},
+	}
+
+	for _, tt := range tests {
+		classification, riskLevel, status := classifyPremium(tt.premium)
+		assert.Equal(t, tt.classification, classification, "premium %v", tt.premium)
+		assert.Equal(t, tt.riskLevel, riskLevel, "premium %v", tt.premium)
+		assert.NotEmpty(t, status)
+	}
+}
+
+func TestCoinbasePremiumService_Calculate(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+
+	client := &testutil.MockCoinCapClient{}
+	client.On("GetMarkets", mock.Anything, "bitcoin", coinbasePremiumMarketLimit).Return(&external.MarketsResponse{
+		Data: []external.Market{
+			btcMarket("coinbase", "USD", "60300", "500000000"),
+			btcMarket("binance", "USDT", "60000", "3000000000"),
+			btcMarket("kraken", "USD", "60100", "1000000000"),
+		},
+	}, nil).Once()
+
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+	service := NewCoinbasePremiumService(client, repo, cache.NewCacheService(nil, log), log)
+
+	indicator, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+
+	// (60300 - 60025) / 60025 * 100
+	assert.InDelta(t, 0.458142, indicator.Value, 1e-6)
+	assert.Equal(t, "coinbase_premium", indicator.Name)
+	assert.Equal(t, PremiumPositive, indicator.Metadata["classification"])
+	assert.Equal(t, "low", indicator.RiskLevel)
+	assert.Equal(t, coinbasePremiumCalcVersion, indicator.CalcVersion)
+
+	// A second call within the cache window reuses the snapshot without refetching or re-saving
+	cached, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, indicator.Value, cached.Value, 1e-9)
+
+	client.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestCoinbasePremiumService_Calculate_UpstreamError(t *testing.T) {
+	log := logger.New("test")
+
+	client := &testutil.MockCoinCapClient{}
+	client.On("GetMarkets", mock.Anything, "bitcoin", coinbasePremiumMarketLimit).Return(nil, fmt.Errorf("connection refused"))
+
+	service := NewCoinbasePremiumService(client, nil, cache.NewCacheService(nil, log), log)
+
+	_, err := service.Calculate(context.Background(), nil)
+	assert.Error(t, err)
+}
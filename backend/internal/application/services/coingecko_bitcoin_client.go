@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// coinGeckoBitcoinClient requests current Bitcoin market data from CoinGecko. The MVRV and
+// realized price services share it.
+type coinGeckoBitcoinClient struct {
+	httpClient *http.Client
+	baseURL    string
+	logger     logger.Logger
+}
+
+func newCoinGeckoBitcoinClient(baseURL string, logger logger.Logger) *coinGeckoBitcoinClient {
+	return &coinGeckoBitcoinClient{
+		httpClient: external.NewHTTPClient(30 * time.Second),
+		baseURL:    baseURL,
+		logger:     logger,
+	}
+}
+
+// coinGeckoRateLimitError reports a 429 from CoinGecko and the delay it asked for
+type coinGeckoRateLimitError struct {
+	retryAfter time.Duration
+}
+
+func (e *coinGeckoRateLimitError) Error() string {
+	return fmt.Sprintf("CoinGecko rate limit exceeded, retry after %v", e.retryAfter)
+}
+
+// requestBitcoinData fetches current Bitcoin market data from CoinGecko, bypassing the cache.
+// A rate limited request is retried after the delay CoinGecko asks for, at most
+// mvrvRateLimitRetries times and only while the delay is within mvrvMaxRateLimitWait, so a
+// transient limit does not degrade the indicator to its fallback.
+func (c *coinGeckoBitcoinClient) requestBitcoinData(ctx context.Context) (*CoinGeckoBitcoinData, error) {
+	for attempt := 1; ; attempt++ {
+		data, err := c.requestBitcoinDataOnce(ctx)
+
+		var limited *coinGeckoRateLimitError
+		if !stderrors.As(err, &limited) || attempt > mvrvRateLimitRetries || limited.retryAfter > mvrvMaxRateLimitWait {
+			return data, err
+		}
+
+		c.logger.WithContext(ctx).Warn("CoinGecko rate limited, retrying",
+			"attempt", attempt,
+			"retry_after", limited.retryAfter)
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(limited.retryAfter):
+		}
+	}
+}
+
+// requestBitcoinDataOnce makes a single CoinGecko request for current Bitcoin market data
+func (c *coinGeckoBitcoinClient) requestBitcoinDataOnce(ctx context.Context) (*CoinGeckoBitcoinData, error) {
+	log := c.logger.WithContext(ctx)
+
+	url := c.baseURL + mvrvCoinGeckoPath + "?localization=false&tickers=false&market_data=true&community_data=false&developer_data=false&sparkline=false"
+
+	log.Debug("Making HTTP request to CoinGecko")
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "CryptoIndicatorDashboard/1.0")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait, ok := external.RetryAfter(resp.Header, time.Now())
+		if !ok {
+			wait = mvrvRateLimitWait
+		}
+		return nil, &coinGeckoRateLimitError{retryAfter: wait}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Received data from API", "bytes", len(body))
+
+	var freshData CoinGeckoBitcoinData
+	if err := json.Unmarshal(body, &freshData); err != nil {
+		log.Error("JSON unmarshal error", "error", err)
+		return nil, err
+	}
+
+	log.Debug("Parsed API data",
+		"price", freshData.MarketData.CurrentPrice.USD,
+		"market_cap", freshData.MarketData.MarketCap.USD)
+
+	return &freshData, nil
+}
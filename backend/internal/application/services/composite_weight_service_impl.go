@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// compositeWeightServiceImpl implements the CompositeWeightService interface
+type compositeWeightServiceImpl struct {
+	repo          repositories.CompositeWeightRepository
+	indicatorRepo repositories.IndicatorRepository
+	logger        logger.Logger
+}
+
+// compositeIndicatorNames maps composite components whose stored indicator has a
+// different name; the others are stored under the component name
+var compositeIndicatorNames = map[string]string{
+	"alt_season": altSeasonIndicatorName,
+}
+
+// compositeRiskLevelScores places each indicator risk level on the 0-100 composite scale
+var compositeRiskLevelScores = map[string]float64{
+	"extreme_low":  0,
+	"low":          25,
+	"medium":       50,
+	"high":         75,
+	"extreme_high": 100,
+}
+
+// NewCompositeWeightService creates a new composite weight service. The composite score
+// reads the latest component values from indicatorRepo.
+func NewCompositeWeightService(repo repositories.CompositeWeightRepository, indicatorRepo repositories.IndicatorRepository, logger logger.Logger) services.CompositeWeightService {
+	return &compositeWeightServiceImpl{
+		repo:          repo,
+		indicatorRepo: indicatorRepo,
+		logger:        logger,
+	}
+}
+
+// GetWeights returns the latest stored weights, falling back to the defaults
+func (s *compositeWeightServiceImpl) GetWeights(ctx context.Context) (*entities.CompositeWeightConfig, error) {
+	config, err := s.repo.GetLatest(ctx)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return &entities.CompositeWeightConfig{Weights: copyWeights(entities.DefaultCompositeWeights)}, nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// UpdateWeights stores weights as the new revision once they pass validation
+func (s *compositeWeightServiceImpl) UpdateWeights(ctx context.Context, weights map[string]float64) (*entities.CompositeWeightConfig, error) {
+	if err := validateCompositeWeights(weights); err != nil {
+		return nil, errors.Validation("Invalid composite weights", err.Error())
+	}
+
+	config := &entities.CompositeWeightConfig{Weights: copyWeights(weights)}
+	if err := s.repo.Create(ctx, config); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Updated composite weights", "revision", config.ID, "weights", config.Weights)
+	return config, nil
+}
+
+// GetCompositeScore combines the latest stored value of each weighted component, scored by
+// its risk level, using the weights in effect. Components without a stored value or with an
+// unrecognised risk level are left out and the remaining weights are renormalised.
+func (s *compositeWeightServiceImpl) GetCompositeScore(ctx context.Context) (*entities.CompositeScore, error) {
+	config, err := s.GetWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(config.Weights))
+	for name, weight := range config.Weights {
+		if weight > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := &entities.CompositeScore{
+		WeightsRevision: config.ID,
+		Components:      []entities.CompositeComponentScore{},
+		Calculated:      time.Now(),
+	}
+
+	var weighted, totalWeight float64
+	for _, name := range names {
+		indicatorName := name
+		if stored, ok := compositeIndicatorNames[name]; ok {
+			indicatorName = stored
+		}
+
+		indicator, err := s.indicatorRepo.GetLatest(ctx, indicatorName)
+		if err != nil {
+			if !errors.IsType(err, errors.ErrorTypeNotFound) {
+				return nil, err
+			}
+			result.Missing = append(result.Missing, name)
+			continue
+		}
+
+		score, ok := compositeRiskLevelScores[indicator.RiskLevel]
+		if !ok {
+			s.logger.WithContext(ctx).Warn("Skipping composite component with unknown risk level", "component", name, "risk_level", indicator.RiskLevel)
+			result.Missing = append(result.Missing, name)
+			continue
+		}
+
+		weight := config.Weights[name]
+		result.Components = append(result.Components, entities.CompositeComponentScore{
+			Name:      name,
+			RiskLevel: indicator.RiskLevel,
+			Score:     score,
+			Weight:    weight,
+			Timestamp: indicator.Timestamp,
+		})
+		weighted += score * weight
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return nil, errors.NotFound("composite score components")
+	}
+	result.Score = weighted / totalWeight
+	return result, nil
+}
+
+// validateCompositeWeights requires known components with non-negative weights,
+// at least one of which is positive
+func validateCompositeWeights(weights map[string]float64) error {
+	if len(weights) == 0 {
+		return fmt.Errorf("at least one weight is required")
+	}
+
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	total := 0.0
+	for _, name := range names {
+		weight := weights[name]
+		if !entities.IsCompositeComponent(name) {
+			return fmt.Errorf("unknown component %q, expected one of %s", name, strings.Join(entities.CompositeComponents, ", "))
+		}
+		if weight < 0 {
+			return fmt.Errorf("weight for %s must not be negative", name)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("at least one weight must be positive")
+	}
+	return nil
+}
+
+// copyWeights returns a copy of weights so callers can't alter shared maps
+func copyWeights(weights map[string]float64) map[string]float64 {
+	copied := make(map[string]float64, len(weights))
+	for name, weight := range weights {
+		copied[name] = weight
+	}
+	return copied
+}
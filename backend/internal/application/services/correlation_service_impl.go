@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// minCorrelationSamples is the minimum number of aligned days needed for a correlation
+const minCorrelationSamples = 3
+
+// correlationServiceImpl implements the CorrelationService interface
+type correlationServiceImpl struct {
+	indicatorRepo repositories.IndicatorRepository
+	logger        logger.Logger
+}
+
+// NewCorrelationService creates a new correlation service implementation
+func NewCorrelationService(indicatorRepo repositories.IndicatorRepository, logger logger.Logger) services.CorrelationService {
+	return &correlationServiceImpl{
+		indicatorRepo: indicatorRepo,
+		logger:        logger,
+	}
+}
+
+// CalculateCorrelation computes the Pearson correlation between two indicators over a period
+func (s *correlationServiceImpl) CalculateCorrelation(ctx context.Context, indicatorA, indicatorB, period string) (*entities.CorrelationResult, error) {
+	log := s.logger.WithContext(ctx)
+	log.Debug("Calculating indicator correlation", "indicator_a", indicatorA, "indicator_b", indicatorB, "period", period)
+
+	if indicatorA == "" || indicatorB == "" {
+		return nil, errors.Validation("Both indicators must be specified")
+	}
+
+	if !entities.IsKnownPeriod(period) {
+		return nil, errors.Validation("Invalid period", "supported periods: 7d, 30d, 90d, 1y")
+	}
+	window := entities.PeriodRange(period, time.Now(), nil)
+	from, to := window.From, window.To
+
+	seriesA, err := s.indicatorRepo.GetHistoricalData(ctx, indicatorA, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get historical data for "+indicatorA)
+	}
+
+	seriesB, err := s.indicatorRepo.GetHistoricalData(ctx, indicatorB, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get historical data for "+indicatorB)
+	}
+
+	aligned := alignDailySeries(seriesA, seriesB)
+	if len(aligned) < minCorrelationSamples {
+		return nil, errors.Validation("Insufficient overlapping data for correlation",
+			"need at least 3 common days of data for both indicators")
+	}
+
+	valuesA := make([]float64, len(aligned))
+	valuesB := make([]float64, len(aligned))
+	for i, point := range aligned {
+		valuesA[i] = point.ValueA
+		valuesB[i] = point.ValueB
+	}
+
+	coefficient, ok := pearsonCorrelation(valuesA, valuesB)
+	if !ok {
+		return nil, errors.Validation("Correlation is undefined for a constant series")
+	}
+
+	log.Info("Calculated indicator correlation",
+		"indicator_a", indicatorA,
+		"indicator_b", indicatorB,
+		"coefficient", coefficient,
+		"samples", len(aligned))
+
+	return &entities.CorrelationResult{
+		IndicatorA:   indicatorA,
+		IndicatorB:   indicatorB,
+		Period:       period,
+		Coefficient:  coefficient,
+		SampleSize:   len(aligned),
+		Series:       aligned,
+		CalculatedAt: time.Now(),
+	}, nil
+}
+
+// bucketDaily averages indicator values into UTC daily buckets
+func bucketDaily(series []entities.Indicator) map[time.Time]float64 {
+	sums := make(map[time.Time]float64)
+	counts := make(map[time.Time]int)
+	for _, indicator := range series {
+		day := indicator.Timestamp.UTC().Truncate(24 * time.Hour)
+		sums[day] += indicator.Value
+		counts[day]++
+	}
+
+	buckets := make(map[time.Time]float64, len(sums))
+	for day, sum := range sums {
+		buckets[day] = sum / float64(counts[day])
+	}
+	return buckets
+}
+
+// alignDailySeries buckets both series to daily values and keeps the days present in both
+func alignDailySeries(seriesA, seriesB []entities.Indicator) []entities.CorrelationPoint {
+	bucketsA := bucketDaily(seriesA)
+	bucketsB := bucketDaily(seriesB)
+
+	aligned := make([]entities.CorrelationPoint, 0, len(bucketsA))
+	for day, valueA := range bucketsA {
+		if valueB, exists := bucketsB[day]; exists {
+			aligned = append(aligned, entities.CorrelationPoint{
+				Date:   day,
+				ValueA: valueA,
+				ValueB: valueB,
+			})
+		}
+	}
+
+	sort.Slice(aligned, func(i, j int) bool {
+		return aligned[i].Date.Before(aligned[j].Date)
+	})
+	return aligned
+}
+
+// pearsonCorrelation returns the Pearson coefficient of two equal-length samples.
+// The second return value is false when either sample has zero variance.
+func pearsonCorrelation(x, y []float64) (float64, bool) {
+	n := len(x)
+	if n == 0 || n != len(y) {
+		return 0, false
+	}
+
+	var meanX, meanY float64
+	for i := 0; i < n; i++ {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var covariance, varianceX, varianceY float64
+	for i := 0; i < n; i++ {
+		dx := x[i] - meanX
+		dy := y[i] - meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+
+	if varianceX == 0 || varianceY == 0 {
+		return 0, false
+	}
+
+	return covariance / math.Sqrt(varianceX*varianceY), true
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticSeries builds daily indicator points going back from today, with perDay
+// intraday samples per day. valueFn receives the number of days before today.
+func syntheticSeries(name string, days, perDay int, valueFn func(day int) float64) []entities.Indicator {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	series := make([]entities.Indicator, 0, days*perDay)
+	for day := 0; day < days; day++ {
+		for sample := 0; sample < perDay; sample++ {
+			series = append(series, entities.Indicator{
+				Name:      name,
+				Value:     valueFn(day),
+				Timestamp: today.AddDate(0, 0, -day).Add(time.Duration(sample) * time.Hour),
+			})
+		}
+	}
+	return series
+}
+
+func TestCalculateCorrelation(t *testing.T) {
+	wave := func(day int) float64 { return 50 + 20*math.Sin(float64(day)*0.3) + float64(day)*0.1 }
+
+	tests := []struct {
+		name          string
+		seriesA       []entities.Indicator
+		seriesB       []entities.Indicator
+		expectedCoeff float64
+		expectedSize  int
+	}{
+		{
+			name:          "Perfectly correlated",
+			seriesA:       syntheticSeries("mvrv", 30, 1, wave),
+			seriesB:       syntheticSeries("fear_greed", 30, 1, func(day int) float64 { return 2*wave(day) + 5 }),
+			expectedCoeff: 1.0,
+			expectedSize:  30,
+		},
+		{
+			name:          "Perfectly anti-correlated",
+			seriesA:       syntheticSeries("mvrv", 30, 1, wave),
+			seriesB:       syntheticSeries("fear_greed", 30, 1, func(day int) float64 { return 100 - wave(day) }),
+			expectedCoeff: -1.0,
+			expectedSize:  30,
+		},
+		{
+			name:          "Mismatched sampling is bucketed daily",
+			seriesA:       syntheticSeries("mvrv", 30, 6, wave),
+			seriesB:       syntheticSeries("fear_greed", 20, 1, func(day int) float64 { return 3 * wave(day) }),
+			expectedCoeff: 1.0,
+			expectedSize:  20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &testutil.MockIndicatorRepository{}
+			repo.On("GetHistoricalData", mock.Anything, "mvrv", mock.Anything, mock.Anything).Return(tt.seriesA, nil)
+			repo.On("GetHistoricalData", mock.Anything, "fear_greed", mock.Anything, mock.Anything).Return(tt.seriesB, nil)
+
+			service := NewCorrelationService(repo, logger.New("test"))
+
+			result, err := service.CalculateCorrelation(context.Background(), "mvrv", "fear_greed", "90d")
+
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expectedCoeff, result.Coefficient, 1e-9)
+			assert.Equal(t, tt.expectedSize, result.SampleSize)
+			assert.Len(t, result.Series, tt.expectedSize)
+			for i := 1; i < len(result.Series); i++ {
+				assert.True(t, result.Series[i-1].Date.Before(result.Series[i].Date))
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalculateCorrelation_Errors(t *testing.T) {
+	t.Run("Invalid period", func(t *testing.T) {
+		service := NewCorrelationService(&testutil.MockIndicatorRepository{}, logger.New("test"))
+
+		_, err := service.CalculateCorrelation(context.Background(), "mvrv", "fear_greed", "5y")
+
+		require.Error(t, err)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+	})
+
+	t.Run("Insufficient overlap", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("GetHistoricalData", mock.Anything, "mvrv", mock.Anything, mock.Anything).
+			Return(syntheticSeries("mvrv", 2, 1, func(day int) float64 { return float64(day) }), nil)
+		repo.On("GetHistoricalData", mock.Anything, "fear_greed", mock.Anything, mock.Anything).
+			Return(syntheticSeries("fear_greed", 2, 1, func(day int) float64 { return float64(day) }), nil)
+		service := NewCorrelationService(repo, logger.New("test"))
+
+		_, err := service.CalculateCorrelation(context.Background(), "mvrv", "fear_greed", "30d")
+
+		require.Error(t, err)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+	})
+
+	t.Run("Constant series", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("GetHistoricalData", mock.Anything, "mvrv", mock.Anything, mock.Anything).
+			Return(syntheticSeries("mvrv", 10, 1, func(day int) float64 { return 1.5 }), nil)
+		repo.On("GetHistoricalData", mock.Anything, "fear_greed", mock.Anything, mock.Anything).
+			Return(syntheticSeries("fear_greed", 10, 1, func(day int) float64 { return float64(day) }), nil)
+		service := NewCorrelationService(repo, logger.New("test"))
+
+		_, err := service.CalculateCorrelation(context.Background(), "mvrv", "fear_greed", "30d")
+
+		require.Error(t, err)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+	})
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// defaultDominanceWeight is the reliability of a source without a configured weight
+	defaultDominanceWeight = 0.8
+	// defaultDominanceMaxAge is the reading age at which freshness reaches its floor
+	defaultDominanceMaxAge = time.Hour
+	// defaultDominanceAgreementTolerance is the spread, in percentage points, used to
+	// judge agreement when averaging is disabled
+	defaultDominanceAgreementTolerance = 2.0
+
+	// uncorroboratedDominanceAgreement is the agreement of a reading no other source checked
+	uncorroboratedDominanceAgreement = 0.9
+	minDominanceAgreement            = 0.5
+	minDominanceFreshness            = 0.5
+	// fallbackDominanceFreshness caps the freshness of last-known-good and configured
+	// fallback readings, whatever their timestamp says
+	fallbackDominanceFreshness = 0.5
+)
+
+// dominanceConfidence is the confidence in a dominance value and the factors behind it.
+// Value is Reliability × Agreement × Freshness, each in [0, 1]:
+//   - Reliability combines the weights of the sources the value came from; averaged
+//     sources corroborate each other, so two agreeing sources beat either alone
+//   - Agreement falls as the two most preferred readings drift apart, relative to the
+//     averaging threshold; a lone reading gets uncorroboratedDominanceAgreement
+//   - Freshness falls linearly with reading age until MaxAge, and fallback data is capped
+type dominanceConfidence struct {
+	Value       float64
+	Reliability float64
+	Agreement   float64
+	Freshness   float64
+	Reasons     []string
+}
+
+// scoreDominanceConfidence scores a dominance value taken from readings, which are
+// ordered by preference and non-empty. averaged reports whether the first two readings
+// were averaged rather than the first used alone.
+func scoreDominanceConfidence(cfg DominanceSourceConfig, readings []dominanceReading, averaged bool, now time.Time) dominanceConfidence {
+	used := readings[:1]
+	if averaged && len(readings) > 1 {
+		used = readings[:2]
+	}
+
+	var c dominanceConfidence
+
+	unreliable := 1.0
+	for _, reading := range used {
+		unreliable *= 1 - cfg.Weight(reading.source)
+	}
+	c.Reliability = 1 - unreliable
+	if averaged {
+		c.Reasons = append(c.Reasons, fmt.Sprintf("averaged %s and %s", used[0].label, used[1].label))
+	} else {
+		c.Reasons = append(c.Reasons, fmt.Sprintf("using %s alone", used[0].label))
+	}
+
+	if len(readings) < 2 {
+		c.Agreement = uncorroboratedDominanceAgreement
+		c.Reasons = append(c.Reasons, "no second source to compare against")
+	} else {
+		tolerance := cfg.AveragingThreshold
+		if tolerance <= 0 {
+			tolerance = defaultDominanceAgreementTolerance
+		}
+		spread := math.Abs(readings[0].value - readings[1].value)
+		c.Agreement = math.Max(minDominanceAgreement, 1-0.2*spread/tolerance)
+		c.Reasons = append(c.Reasons, fmt.Sprintf("%s and %s differ by %.2f points", readings[0].label, readings[1].label, spread))
+	}
+
+	total := 0.0
+	for _, reading := range used {
+		freshness, reason := dominanceFreshness(cfg, reading, now)
+		total += freshness
+		if reason != "" {
+			c.Reasons = append(c.Reasons, reason)
+		}
+	}
+	c.Freshness = total / float64(len(used))
+
+	c.Value = math.Min(math.Max(c.Reliability*c.Agreement*c.Freshness, 0), 1)
+	return c
+}
+
+// dominanceFreshness scores how current a reading is, with a reason when it is not fully
+// fresh. Readings without a timestamp were fetched live and count as fresh.
+func dominanceFreshness(cfg DominanceSourceConfig, reading dominanceReading, now time.Time) (float64, string) {
+	if reading.changeData == nil {
+		return 1, ""
+	}
+
+	freshness := 1.0
+	reason := ""
+	if !reading.changeData.LastUpdated.IsZero() {
+		age := now.Sub(reading.changeData.LastUpdated)
+		if age > 0 {
+			decay := (1 - minDominanceFreshness) * float64(age) / float64(cfg.maxAge())
+			freshness = math.Max(minDominanceFreshness, 1-decay)
+			if freshness < 1 {
+				reason = fmt.Sprintf("%s reading is %s old", reading.label, age.Round(time.Second))
+			}
+		}
+	}
+	if reading.changeData.IsFallback() && freshness > fallbackDominanceFreshness {
+		freshness = fallbackDominanceFreshness
+		reason = fmt.Sprintf("%s is fallback data", reading.label)
+	}
+	return freshness, reason
+}
+
+// metadata returns the factors and reasoning behind the confidence for API responses
+func (c dominanceConfidence) metadata() map[string]interface{} {
+	return map[string]interface{}{
+		"confidence_factors": map[string]float64{
+			"reliability": c.Reliability,
+			"agreement":   c.Agreement,
+			"freshness":   c.Freshness,
+		},
+		"confidence_reasons": c.Reasons,
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+)
+
+// Dominance source identifiers accepted in DominanceSourceConfig.Sources
+const (
+	DominanceSourceCoinMarketCap = "coinmarketcap"
+	DominanceSourceTradingView   = "tradingview"
+)
+
+// DominanceSourceConfig controls how Bitcoin dominance sources are queried and combined
+type DominanceSourceConfig struct {
+	// Sources lists the dominance sources in order of preference
+	Sources []string
+	// AveragingThreshold is the maximum difference in percentage points between the
+	// two most preferred readings for them to be averaged. Zero disables averaging.
+	AveragingThreshold float64
+	// MinConfidence rejects results whose confidence is below this value
+	MinConfidence float64
+	// Weights is the reliability (0-1) of each source by name; unlisted sources use the
+	// default weights
+	Weights map[string]float64
+	// MaxAge is the reading age at which freshness has fallen to its floor. Zero uses
+	// defaultDominanceMaxAge.
+	MaxAge time.Duration
+}
+
+// DefaultDominanceSourceConfig returns the default CoinMarketCap-first configuration
+func DefaultDominanceSourceConfig() DominanceSourceConfig {
+	return DominanceSourceConfig{
+		Sources:            []string{DominanceSourceCoinMarketCap, DominanceSourceTradingView},
+		AveragingThreshold: 2.0,
+		MinConfidence:      0,
+		Weights:            defaultDominanceWeights(),
+		MaxAge:             defaultDominanceMaxAge,
+	}
+}
+
+// defaultDominanceWeights returns the reliability of each known dominance source
+func defaultDominanceWeights() map[string]float64 {
+	return map[string]float64{
+		DominanceSourceCoinMarketCap: 0.9,
+		DominanceSourceTradingView:   0.85,
+	}
+}
+
+// Weight returns the reliability of a source, falling back to the default weight and then
+// to defaultDominanceWeight for unknown sources
+func (c DominanceSourceConfig) Weight(source string) float64 {
+	if w, ok := c.Weights[source]; ok && w > 0 {
+		return math.Min(w, 1)
+	}
+	if w, ok := defaultDominanceWeights()[source]; ok {
+		return w
+	}
+	return defaultDominanceWeight
+}
+
+// maxAge returns MaxAge, or the default when it is not set
+func (c DominanceSourceConfig) maxAge() time.Duration {
+	if c.MaxAge > 0 {
+		return c.MaxAge
+	}
+	return defaultDominanceMaxAge
+}
+
+// dominanceReading is a single dominance value obtained from one source
+type dominanceReading struct {
+	source string
+	// label is the data source the value actually came from, which for TradingView may be
+	// CoinGecko or fallback data
+	label      entities.DataSource
+	value      float64
+	changeData *external.BitcoinDominanceData
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	// dominanceTrendWindow is the stored history the trend is fitted over
+	dominanceTrendWindow = 30 * 24 * time.Hour
+
+	// DominanceAltSeasonTrigger is the dominance below which alt season conditions apply
+	DominanceAltSeasonTrigger = 42.0
+	// DominanceStrongLevel is the dominance above which Bitcoin clearly leads the market
+	DominanceStrongLevel = 65.0
+)
+
+// Dominance trend strengths
+const (
+	TrendStrengthWeak     = "weak"
+	TrendStrengthModerate = "moderate"
+	TrendStrengthStrong   = "strong"
+)
+
+// dominanceServiceImpl implements the DominanceService interface from stored dominance history
+type dominanceServiceImpl struct {
+	repo   repositories.MarketDataRepository
+	logger logger.Logger
+}
+
+// NewDominanceService creates a dominance service that analyses the readings stored by the
+// market data service
+func NewDominanceService(repo repositories.MarketDataRepository, logger logger.Logger) services.DominanceService {
+	return &dominanceServiceImpl{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetDominanceAnalysis reports the latest dominance with its changes and the trend of the
+// last 30 days of readings
+func (s *dominanceServiceImpl) GetDominanceAnalysis(ctx context.Context) (*entities.DominanceResult, error) {
+	history, err := s.history(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := history[len(history)-1]
+	current := latest.CurrentDominance
+	trend, strength := classifyDominanceTrend(history)
+	riskLevel, status := dominanceRisk(current)
+
+	s.logger.WithContext(ctx).Debug("Analysed Bitcoin dominance",
+		"dominance", current,
+		"readings", len(history),
+		"trend", trend,
+		"trend_strength", strength,
+	)
+
+	return &entities.DominanceResult{
+		CurrentDominance: current,
+		Change24h:        latest.Change24h,
+		Change7d:         dominanceChangeSince(history, latest.CreatedAt.Add(-7*24*time.Hour)),
+		Change30d:        dominanceChangeSince(history, latest.CreatedAt.Add(-dominanceTrendWindow)),
+		Trend:            trend,
+		TrendStrength:    strength,
+		RiskLevel:        riskLevel,
+		Status:           status,
+		AltSeasonSignal:  s.DetectAltSeason(ctx, current),
+		CriticalLevels:   dominanceCriticalLevels(),
+		LastUpdated:      latest.LastUpdated,
+	}, nil
+}
+
+// GetDominanceChart returns the last 30 days of stored readings, oldest first
+func (s *dominanceServiceImpl) GetDominanceChart(ctx context.Context) (map[string]interface{}, error) {
+	history, err := s.history(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]int64, len(history))
+	values := make([]float64, len(history))
+	for i, reading := range history {
+		timestamps[i] = reading.CreatedAt.Unix() * 1000
+		values[i] = reading.CurrentDominance
+	}
+
+	latest := history[len(history)-1]
+	return map[string]interface{}{
+		"timestamps":   timestamps,
+		"values":       values,
+		"last_updated": latest.LastUpdated,
+		"current":      latest.CurrentDominance,
+		"levels":       dominanceCriticalLevels(),
+	}, nil
+}
+
+// DetectAltSeason reports whether dominance is below the alt season trigger
+func (s *dominanceServiceImpl) DetectAltSeason(ctx context.Context, dominance float64) bool {
+	return dominance < DominanceAltSeasonTrigger
+}
+
+// history loads the readings stored within the trend window, oldest first
+func (s *dominanceServiceImpl) history(ctx context.Context) ([]entities.BitcoinDominance, error) {
+	now := time.Now()
+	history, err := s.repo.GetDominanceHistory(ctx, now.Add(-dominanceTrendWindow), now)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, errors.NotFound("dominance_data")
+	}
+	return history, nil
+}
+
+// classifyDominanceTrend fits a least squares line through the readings and classifies its
+// slope, projected over 30 days, using the same directions as BitcoinDominance.GetDominanceTrend.
+// Moves under one percentage point a month are stable; the strength grows at 3 and 6 points.
+func classifyDominanceTrend(history []entities.BitcoinDominance) (string, string) {
+	if len(history) < 2 {
+		return "stable", TrendStrengthWeak
+	}
+
+	origin := history[0].CreatedAt
+	n := float64(len(history))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, reading := range history {
+		x := reading.CreatedAt.Sub(origin).Hours() / 24
+		y := reading.CurrentDominance
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		// Every reading has the same timestamp
+		return "stable", TrendStrengthWeak
+	}
+	slopePerDay := (n*sumXY - sumX*sumY) / denominator
+	monthlyChange := slopePerDay * 30
+
+	trend := "stable"
+	switch {
+	case monthlyChange >= 1:
+		trend = "increasing"
+	case monthlyChange <= -1:
+		trend = "decreasing"
+	}
+
+	strength := TrendStrengthWeak
+	switch magnitude := math.Abs(monthlyChange); {
+	case magnitude >= 6:
+		strength = TrendStrengthStrong
+	case magnitude >= 3:
+		strength = TrendStrengthModerate
+	}
+	return trend, strength
+}
+
+// dominanceChangeSince returns the change in percentage points from the first reading at or
+// after since to the latest reading, so a short history reports the change over what is stored
+func dominanceChangeSince(history []entities.BitcoinDominance, since time.Time) float64 {
+	latest := history[len(history)-1]
+	for _, reading := range history {
+		if !reading.CreatedAt.Before(since) {
+			return latest.CurrentDominance - reading.CurrentDominance
+		}
+	}
+	return 0
+}
+
+// dominanceRisk returns the risk level and status for a dominance value
+func dominanceRisk(dominance float64) (string, string) {
+	switch {
+	case dominance < DominanceAltSeasonTrigger:
+		return "high", "HIGH: Dominance below alt season trigger - Speculative capital rotating into altcoins"
+	case dominance > DominanceStrongLevel:
+		return "low", "LOW: Strong Bitcoin dominance - Capital concentrated in Bitcoin"
+	default:
+		return "medium", "MEDIUM: Neutral dominance level - Monitor for trends"
+	}
+}
+
+func dominanceCriticalLevels() map[string]float64 {
+	return map[string]float64{
+		"alt_season_trigger": DominanceAltSeasonTrigger,
+		"strong_dominance":   DominanceStrongLevel,
+	}
+}
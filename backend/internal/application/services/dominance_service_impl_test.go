@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// dominanceHistory builds one reading a day for days days, oldest first, moving by
+// perDay percentage points each day
+func dominanceHistory(days int, start, perDay float64) []entities.BitcoinDominance {
+	origin := time.Now().AddDate(0, 0, -days+1)
+	history := make([]entities.BitcoinDominance, days)
+	for i := range history {
+		history[i] = entities.BitcoinDominance{
+			CurrentDominance: start + perDay*float64(i),
+			Change24h:        perDay,
+			CreatedAt:        origin.AddDate(0, 0, i),
+			LastUpdated:      origin.AddDate(0, 0, i),
+		}
+	}
+	return history
+}
+
+func newTestDominanceService(history []entities.BitcoinDominance) *dominanceServiceImpl {
+	repo := new(testutil.MockMarketDataRepository)
+	repo.On("GetDominanceHistory", mock.Anything, mock.Anything, mock.Anything).Return(history, nil)
+	return NewDominanceService(repo, logger.New("test")).(*dominanceServiceImpl)
+}
+
+func TestClassifyDominanceTrend(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  []entities.BitcoinDominance
+		trend    string
+		strength string
+	}{
+		{"Rising strongly over 30 days", dominanceHistory(30, 50, 0.3), "increasing", TrendStrengthStrong},
+		{"Rising moderately over 30 days", dominanceHistory(30, 50, 0.12), "increasing", TrendStrengthModerate},
+		{"Rising weakly over 30 days", dominanceHistory(30, 50, 0.05), "increasing", TrendStrengthWeak},
+		{"Flat over 30 days", dominanceHistory(30, 55, 0), "stable", TrendStrengthWeak},
+		{"Falling moderately over 30 days", dominanceHistory(30, 60, -0.15), "decreasing", TrendStrengthModerate},
+		{"Falling strongly over 7 days", dominanceHistory(7, 60, -0.5), "decreasing", TrendStrengthStrong},
+		{"Flat over 7 days", dominanceHistory(7, 55, 0.01), "stable", TrendStrengthWeak},
+		{"Single reading", dominanceHistory(1, 55, 0), "stable", TrendStrengthWeak},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trend, strength := classifyDominanceTrend(tt.history)
+			assert.Equal(t, tt.trend, trend)
+			assert.Equal(t, tt.strength, strength)
+		})
+	}
+
+	// Noise around a flat line does not read as a trend
+	noisy := dominanceHistory(30, 55, 0)
+	for i := range noisy {
+		if i%2 == 0 {
+			noisy[i].CurrentDominance += 1.5
+		}
+	}
+	trend, _ := classifyDominanceTrend(noisy)
+	assert.Equal(t, "stable", trend)
+}
+
+func TestDominanceService_GetDominanceAnalysis(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("falling dominance", func(t *testing.T) {
+		result, err := newTestDominanceService(dominanceHistory(30, 50, -0.3)).GetDominanceAnalysis(ctx)
+		require.NoError(t, err)
+
+		assert.InDelta(t, 41.3, result.CurrentDominance, 1e-9)
+		assert.InDelta(t, -0.3, result.Change24h, 1e-9)
+		assert.InDelta(t, -2.1, result.Change7d, 1e-9)
+		assert.InDelta(t, -8.7, result.Change30d, 1e-9)
+		assert.Equal(t, "decreasing", result.Trend)
+		assert.Equal(t, TrendStrengthStrong, result.TrendStrength)
+		assert.True(t, result.AltSeasonSignal)
+		assert.Equal(t, "high", result.RiskLevel)
+		assert.Equal(t, DominanceAltSeasonTrigger, result.CriticalLevels["alt_season_trigger"])
+	})
+
+	t.Run("rising dominance", func(t *testing.T) {
+		result, err := newTestDominanceService(dominanceHistory(30, 62, 0.15)).GetDominanceAnalysis(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "increasing", result.Trend)
+		assert.Equal(t, TrendStrengthModerate, result.TrendStrength)
+		assert.False(t, result.AltSeasonSignal)
+		assert.Equal(t, "low", result.RiskLevel)
+	})
+
+	t.Run("flat dominance", func(t *testing.T) {
+		result, err := newTestDominanceService(dominanceHistory(30, 55, 0)).GetDominanceAnalysis(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "stable", result.Trend)
+		assert.Equal(t, TrendStrengthWeak, result.TrendStrength)
+		assert.Zero(t, result.Change30d)
+		assert.Equal(t, "medium", result.RiskLevel)
+	})
+
+	t.Run("no stored readings", func(t *testing.T) {
+		_, err := newTestDominanceService([]entities.BitcoinDominance{}).GetDominanceAnalysis(ctx)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+	})
+}
+
+func TestDominanceService_GetDominanceChart(t *testing.T) {
+	history := dominanceHistory(10, 55, 0.1)
+	chart, err := newTestDominanceService(history).GetDominanceChart(context.Background())
+	require.NoError(t, err)
+
+	values := chart["values"].([]float64)
+	require.Len(t, values, len(history))
+	assert.Equal(t, 55.0, values[0])
+	assert.InDelta(t, 55.9, chart["current"].(float64), 1e-9)
+	assert.Len(t, chart["timestamps"].([]int64), len(history))
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	etfFlowIndicatorName = "etf_flow"
+	etfFlowCacheKey      = "etf_flows"
+	etfFlowCacheTTL      = 1 * time.Hour
+
+	// etfFlowCalcVersion is stored with each flow reading; bump it when the bands change
+	etfFlowCalcVersion uint = 1
+)
+
+// ETF flow bands
+const (
+	ETFFlowBandStrongOutflow = "strong_outflow"
+	ETFFlowBandOutflow       = "outflow"
+	ETFFlowBandNeutral       = "neutral"
+	ETFFlowBandInflow        = "inflow"
+	ETFFlowBandStrongInflow  = "strong_inflow"
+)
+
+// etfFlowServiceImpl implements the IndicatorService interface for daily net flows into
+// spot Bitcoin ETFs and trusts such as Grayscale's GBTC
+type etfFlowServiceImpl struct {
+	recomputeGuarded
+
+	provider      services.ETFFlowProvider
+	indicatorRepo repositories.IndicatorRepository
+	cache         services.CacheService
+	logger        logger.Logger
+}
+
+// NewETFFlowService creates a new ETF flow service backed by the given flow provider. A nil
+// provider uses the unconfigured provider.
+func NewETFFlowService(
+	provider services.ETFFlowProvider,
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.IndicatorService {
+	if provider == nil {
+		provider = NewUnconfiguredETFFlowProvider()
+	}
+	s := &etfFlowServiceImpl{
+		provider:      provider,
+		indicatorRepo: indicatorRepo,
+		cache:         cache,
+		logger:        logger,
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, etfFlowIndicatorName, etfFlowCacheTTL, logger)
+	return s
+}
+
+// Calculate computes the current ETF flow reading. Without a configured provider it returns
+// a neutral reading marked as unconfigured, which is neither cached nor stored.
+func (s *etfFlowServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	if !s.provider.Configured() {
+		log.Debug("ETF flow provider not configured, returning neutral reading")
+		flows, err := s.provider.GetETFFlows(ctx)
+		if err != nil {
+			return nil, errors.Internal("unconfigured ETF flow provider failed", err)
+		}
+		return s.indicatorFromFlows(flows, false), nil
+	}
+
+	log.Info("Starting ETF flow calculation", "provider", s.provider.Name())
+
+	fresh := false
+	var flows entities.ETFFlows
+	fetch := func() (interface{}, error) {
+		fresh = true
+		return s.provider.GetETFFlows(ctx)
+	}
+
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, etfFlowCacheKey, &flows, etfFlowCacheTTL, fetch)
+	} else {
+		var value interface{}
+		if value, err = fetch(); err == nil {
+			flows = *value.(*entities.ETFFlows)
+		}
+	}
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, err
+		}
+		return nil, errors.External(s.provider.Name(), "failed to fetch ETF flows", err)
+	}
+
+	indicator := s.indicatorFromFlows(&flows, true)
+
+	// Only persist newly computed values, not cache hits
+	if fresh && s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save ETF flow indicator to database", "error", err)
+		}
+	}
+
+	return indicator, nil
+}
+
+// indicatorFromFlows builds the indicator for a flow reading
+func (s *etfFlowServiceImpl) indicatorFromFlows(flows *entities.ETFFlows, configured bool) *entities.Indicator {
+	band, riskLevel, status := classifyETFFlow(flows.NetFlow)
+
+	confidence := 0.8
+	metadata := map[string]interface{}{
+		"inflow":     flows.Inflow,
+		"outflow":    flows.Outflow,
+		"net_flow":   flows.NetFlow,
+		"funds":      flows.Funds,
+		"band":       band,
+		"provider":   s.provider.Name(),
+		"configured": configured,
+	}
+	if !configured {
+		confidence = 0
+		status = "No ETF flow provider configured - showing a neutral placeholder"
+	}
+
+	return &entities.Indicator{
+		Name:        etfFlowIndicatorName,
+		Type:        "flows",
+		Value:       flows.NetFlow,
+		Change:      fmt.Sprintf("%+.1fM", flows.NetFlow/1e6),
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: "Daily net flows into spot Bitcoin ETFs and trusts",
+		Source:      providerDataSource(s.provider.Name()),
+		Confidence:  confidence,
+		CalcVersion: etfFlowCalcVersion,
+		Timestamp:   flows.Timestamp,
+		Metadata:    metadata,
+	}
+}
+
+// GetHistoricalData retrieves stored ETF flow readings
+func (s *etfFlowServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical ETF flow data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, etfFlowIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored ETF flow reading if it is fresh, otherwise recalculates it.
+// Nothing is stored without a configured provider, so that case always calculates.
+func (s *etfFlowServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil || !s.provider.Configured() {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, etfFlowIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > etfFlowCacheTTL {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
+
+// classifyETFFlow maps a daily net flow in USD to a band, risk level and status. Heavy
+// inflows mark institutional demand chasing price, heavy outflows capitulation.
+func classifyETFFlow(netFlow float64) (band, riskLevel, status string) {
+	const million = 1e6
+	switch {
+	case netFlow <= -500*million:
+		return ETFFlowBandStrongOutflow, "low", "Heavy ETF outflows - institutional capitulation"
+	case netFlow <= -50*million:
+		return ETFFlowBandOutflow, "low", "Net ETF outflows - institutional demand cooling"
+	case math.Abs(netFlow) < 50*million:
+		return ETFFlowBandNeutral, "medium", "ETF flows roughly balanced"
+	case netFlow < 500*million:
+		return ETFFlowBandInflow, "medium", "Net ETF inflows - steady institutional demand"
+	default:
+		return ETFFlowBandStrongInflow, "high", "Heavy ETF inflows - institutional demand chasing price"
+	}
+}
+
+// unconfiguredETFFlowProvider stands in until a real flow source is wired up and reports
+// balanced, zero flows
+type unconfiguredETFFlowProvider struct {
+	now func() time.Time
+}
+
+// NewUnconfiguredETFFlowProvider creates an ETF flow provider that returns a neutral reading
+func NewUnconfiguredETFFlowProvider() services.ETFFlowProvider {
+	return &unconfiguredETFFlowProvider{now: time.Now}
+}
+
+// Name returns the provider name used as the indicator source
+func (p *unconfiguredETFFlowProvider) Name() string {
+	return "unconfigured"
+}
+
+// Configured reports false; this provider has no data source
+func (p *unconfiguredETFFlowProvider) Configured() bool {
+	return false
+}
+
+// GetETFFlows returns zero flows stamped with the current time
+func (p *unconfiguredETFFlowProvider) GetETFFlows(ctx context.Context) (*entities.ETFFlows, error) {
+	return &entities.ETFFlows{Timestamp: p.now().UTC()}, nil
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticETFFlowProvider is a configured provider returning fixed flows
+type staticETFFlowProvider struct {
+	flows *entities.ETFFlows
+	err   error
+	calls int
+}
+
+func (p *staticETFFlowProvider) Name() string { return "static" }
+
+func (p *staticETFFlowProvider) Configured() bool { return true }
+
+func (p *staticETFFlowProvider) GetETFFlows(ctx context.Context) (*entities.ETFFlows, error) {
+	p.calls++
+	return p.flows, p.err
+}
+
+func TestClassifyETFFlow(t *testing.T) {
+	tests := []struct {
+		netFlow   float64
+		band      string
+		riskLevel string
+	}{
+		{-900e6, ETFFlowBandStrongOutflow, "low"},
+		{-500e6, ETFFlowBandStrongOutflow, "low"},
+		{-120e6, ETFFlowBandOutflow, "low"},
+		{0, ETFFlowBandNeutral, "medium"},
+		{49e6, ETFFlowBandNeutral, "medium"},
+		{50e6, ETFFlowBandInflow, "medium"},
+		{500e6, ETFFlowBandStrongInflow, "high"},
+	}
+
+	for _, tt := range tests {
+		band, riskLevel, status := classifyETFFlow(tt.netFlow)
+		assert.Equal(t, tt.band, band, "net flow %v", tt.netFlow)
+		assert.Equal(t, tt.riskLevel, riskLevel, "net flow %v", tt.netFlow)
+		assert.NotEmpty(t, status)
+	}
+}
+
+func TestETFFlowService_Calculate(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("configured provider is stored", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(i *entities.Indicator) bool {
+			return i.Name == etfFlowIndicatorName
+		})).Return(nil)
+		provider := &staticETFFlowProvider{flows: &entities.ETFFlows{
+			Inflow: 800e6, Outflow: 150e6, NetFlow: 650e6, Funds: 11, Timestamp: at,
+		}}
+
+		indicator, err := NewETFFlowService(provider, repo, nil, logger.New("test")).Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 650e6, indicator.Value)
+		assert.Equal(t, "+650.0M", indicator.Change)
+		assert.Equal(t, "high", indicator.RiskLevel)
+		assert.Equal(t, ETFFlowBandStrongInflow, indicator.Metadata["band"])
+		assert.Equal(t, true, indicator.Metadata["configured"])
+		assert.Equal(t, 11, indicator.Metadata["funds"])
+		assert.Equal(t, entities.DataSourceUnknown, indicator.Source, "providers the dashboard doesn't know are recorded as unknown")
+		assert.Equal(t, at, indicator.Timestamp)
+		assert.Equal(t, etfFlowCalcVersion, indicator.CalcVersion)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("provider failure is external", func(t *testing.T) {
+		provider := &staticETFFlowProvider{err: assert.AnError}
+
+		_, err := NewETFFlowService(provider, nil, nil, logger.New("test")).Calculate(ctx, nil)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeExternal))
+	})
+}
+
+func TestETFFlowService_Unconfigured(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil provider returns a neutral unconfigured reading", func(t *testing.T) {
+		// No expectations: an unconfigured reading must never be stored or read back
+		repo := &testutil.MockIndicatorRepository{}
+		service := NewETFFlowService(nil, repo, nil, logger.New("test"))
+
+		indicator, err := service.GetLatest(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0.0, indicator.Value)
+		assert.Equal(t, "medium", indicator.RiskLevel)
+		assert.Equal(t, ETFFlowBandNeutral, indicator.Metadata["band"])
+		assert.Equal(t, false, indicator.Metadata["configured"])
+		assert.Equal(t, "unconfigured", indicator.Metadata["provider"])
+		assert.Equal(t, 0.0, indicator.Confidence)
+		assert.Contains(t, indicator.Status, "No ETF flow provider configured")
+		assert.False(t, indicator.Timestamp.IsZero())
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "GetLatest", mock.Anything, mock.Anything)
+	})
+
+	t.Run("provider reports itself unconfigured", func(t *testing.T) {
+		provider := NewUnconfiguredETFFlowProvider()
+		assert.False(t, provider.Configured())
+
+		flows, err := provider.GetETFFlows(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, flows.NetFlow)
+		assert.Zero(t, flows.Inflow)
+		assert.Zero(t, flows.Outflow)
+	})
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	exchangeFlowIndicatorName = "exchange_flow"
+	exchangeFlowCacheKey      = "exchange_flows"
+	exchangeFlowCacheTTL      = 1 * time.Hour
+
+	// exchangeFlowCalcVersion is stored with each flow reading; bump it when the bands change
+	exchangeFlowCalcVersion uint = 1
+)
+
+// Exchange flow bands
+const (
+	ExchangeFlowBandStrongAccumulation = "strong_accumulation"
+	ExchangeFlowBandAccumulation       = "accumulation"
+	ExchangeFlowBandNeutral            = "neutral"
+	ExchangeFlowBandDistribution       = "distribution"
+	ExchangeFlowBandStrongDistribution = "strong_distribution"
+)
+
+// exchangeFlowServiceImpl implements the IndicatorService interface for daily BTC net flows
+// into exchanges
+type exchangeFlowServiceImpl struct {
+	recomputeGuarded
+
+	provider      services.ExchangeFlowProvider
+	indicatorRepo repositories.IndicatorRepository
+	cache         services.CacheService
+	logger        logger.Logger
+}
+
+// NewExchangeFlowService creates a new exchange flow service backed by the given flow
+// provider. A nil provider uses the unconfigured provider.
+func NewExchangeFlowService(
+	provider services.ExchangeFlowProvider,
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.IndicatorService {
+	if provider == nil {
+		provider = NewUnconfiguredExchangeFlowProvider()
+	}
+	s := &exchangeFlowServiceImpl{
+		provider:      provider,
+		indicatorRepo: indicatorRepo,
+		cache:         cache,
+		logger:        logger,
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, exchangeFlowIndicatorName, exchangeFlowCacheTTL, logger)
+	return s
+}
+
+// Calculate computes the current exchange flow reading. Without a configured provider it
+// returns a neutral reading marked as unconfigured, which is neither cached nor stored.
+func (s *exchangeFlowServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	if !s.provider.Configured() {
+		log.Debug("Exchange flow provider not configured, returning neutral reading")
+		flows, err := s.provider.GetExchangeFlows(ctx)
+		if err != nil {
+			return nil, errors.Internal("unconfigured exchange flow provider failed", err)
+		}
+		return s.indicatorFromFlows(flows, false), nil
+	}
+
+	log.Info("Starting exchange flow calculation", "provider", s.provider.Name())
+
+	fresh := false
+	var flows entities.ExchangeFlows
+	fetch := func() (interface{}, error) {
+		fresh = true
+		return s.provider.GetExchangeFlows(ctx)
+	}
+
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, exchangeFlowCacheKey, &flows, exchangeFlowCacheTTL, fetch)
+	} else {
+		var value interface{}
+		if value, err = fetch(); err == nil {
+			flows = *value.(*entities.ExchangeFlows)
+		}
+	}
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, err
+		}
+		return nil, errors.External(s.provider.Name(), "failed to fetch exchange flows", err)
+	}
+
+	indicator := s.indicatorFromFlows(&flows, true)
+
+	// Only persist newly computed values, not cache hits
+	if fresh && s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save exchange flow indicator to database", "error", err)
+		}
+	}
+
+	return indicator, nil
+}
+
+// indicatorFromFlows builds the indicator for a flow reading
+func (s *exchangeFlowServiceImpl) indicatorFromFlows(flows *entities.ExchangeFlows, configured bool) *entities.Indicator {
+	band, riskLevel, status := classifyExchangeFlow(flows.NetFlow)
+
+	confidence := 0.8
+	metadata := map[string]interface{}{
+		"inflow":     flows.Inflow,
+		"outflow":    flows.Outflow,
+		"net_flow":   flows.NetFlow,
+		"band":       band,
+		"provider":   s.provider.Name(),
+		"configured": configured,
+	}
+	if flows.Reserve > 0 {
+		metadata["reserve"] = flows.Reserve
+	}
+	if !configured {
+		confidence = 0
+		status = "No exchange flow provider configured - showing a neutral placeholder"
+	}
+
+	return &entities.Indicator{
+		Name:        exchangeFlowIndicatorName,
+		Type:        "flows",
+		Value:       flows.NetFlow,
+		Change:      fmt.Sprintf("%+.0f BTC", flows.NetFlow),
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: "Daily net BTC flow into exchanges (inflow minus outflow)",
+		Source:      providerDataSource(s.provider.Name()),
+		Confidence:  confidence,
+		CalcVersion: exchangeFlowCalcVersion,
+		Timestamp:   flows.Timestamp,
+		Metadata:    metadata,
+	}
+}
+
+// GetHistoricalData retrieves stored exchange flow readings
+func (s *exchangeFlowServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical exchange flow data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, exchangeFlowIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored exchange flow reading if it is fresh, otherwise recalculates
+// it. Nothing is stored without a configured provider, so that case always calculates.
+func (s *exchangeFlowServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil || !s.provider.Configured() {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, exchangeFlowIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > exchangeFlowCacheTTL {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
+
+// classifyExchangeFlow maps a daily net exchange flow in BTC to a band, risk level and
+// status. Coins leaving exchanges go to self custody (accumulation); coins arriving are
+// usually headed for sale (distribution).
+func classifyExchangeFlow(netFlow float64) (band, riskLevel, status string) {
+	switch {
+	case netFlow <= -5000:
+		return ExchangeFlowBandStrongAccumulation, "low", "Heavy exchange outflows - strong accumulation"
+	case netFlow <= -1000:
+		return ExchangeFlowBandAccumulation, "low", "Net exchange outflows - coins moving to self custody"
+	case math.Abs(netFlow) < 1000:
+		return ExchangeFlowBandNeutral, "medium", "Exchange flows roughly balanced"
+	case netFlow < 5000:
+		return ExchangeFlowBandDistribution, "high", "Net exchange inflows - coins moving to sell"
+	default:
+		return ExchangeFlowBandStrongDistribution, "high", "Heavy exchange inflows - strong distribution"
+	}
+}
+
+// unconfiguredExchangeFlowProvider stands in until a real flow source is wired up and
+// reports balanced, zero flows
+type unconfiguredExchangeFlowProvider struct {
+	now func() time.Time
+}
+
+// NewUnconfiguredExchangeFlowProvider creates an exchange flow provider that returns a
+// neutral reading
+func NewUnconfiguredExchangeFlowProvider() services.ExchangeFlowProvider {
+	return &unconfiguredExchangeFlowProvider{now: time.Now}
+}
+
+// Name returns the provider name used as the indicator source
+func (p *unconfiguredExchangeFlowProvider) Name() string {
+	return "unconfigured"
+}
+
+// Configured reports false; this provider has no data source
+func (p *unconfiguredExchangeFlowProvider) Configured() bool {
+	return false
+}
+
+// GetExchangeFlows returns zero flows stamped with the current time
+func (p *unconfiguredExchangeFlowProvider) GetExchangeFlows(ctx context.Context) (*entities.ExchangeFlows, error) {
+	return &entities.ExchangeFlows{Timestamp: p.now().UTC()}, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticExchangeFlowProvider is a configured provider returning fixed flows
+type staticExchangeFlowProvider struct {
+	flows *entities.ExchangeFlows
+	err   error
+}
+
+func (p *staticExchangeFlowProvider) Name() string { return "static" }
+
+func (p *staticExchangeFlowProvider) Configured() bool { return true }
+
+func (p *staticExchangeFlowProvider) GetExchangeFlows(ctx context.Context) (*entities.ExchangeFlows, error) {
+	return p.flows, p.err
+}
+
+func TestClassifyExchangeFlow(t *testing.T) {
+	tests := []struct {
+		netFlow   float64
+		band      string
+		riskLevel string
+	}{
+		{-12000, ExchangeFlowBandStrongAccumulation, "low"},
+		{-5000, ExchangeFlowBandStrongAccumulation, "low"},
+		{-1000, ExchangeFlowBandAccumulation, "low"},
+		{-999, ExchangeFlowBandNeutral, "medium"},
+		{0, ExchangeFlowBandNeutral, "medium"},
+		{999, ExchangeFlowBandNeutral, "medium"},
+		{1000, ExchangeFlowBandDistribution, "high"},
+		{5000, ExchangeFlowBandStrongDistribution, "high"},
+	}
+
+	for _, tt := range tests {
+		band, riskLevel, status := classifyExchangeFlow(tt.netFlow)
+		assert.Equal(t, tt.band, band, "net flow %v", tt.netFlow)
+		assert.Equal(t, tt.riskLevel, riskLevel, "net flow %v", tt.netFlow)
+		assert.NotEmpty(t, status)
+	}
+}
+
+func TestExchangeFlowService_Calculate(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("configured provider is stored", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(i *entities.Indicator) bool {
+			return i.Name == exchangeFlowIndicatorName
+		})).Return(nil)
+		provider := &staticExchangeFlowProvider{flows: &entities.ExchangeFlows{
+			Inflow: 21000, Outflow: 28500, NetFlow: -7500, Reserve: 2.3e6, Timestamp: at,
+		}}
+
+		indicator, err := NewExchangeFlowService(provider, repo, nil, logger.New("test")).Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, -7500.0, indicator.Value)
+		assert.Equal(t, "-7500 BTC", indicator.Change)
+		assert.Equal(t, "low", indicator.RiskLevel)
+		assert.Equal(t, ExchangeFlowBandStrongAccumulation, indicator.Metadata["band"])
+		assert.Equal(t, true, indicator.Metadata["configured"])
+		assert.Equal(t, 2.3e6, indicator.Metadata["reserve"])
+		assert.Equal(t, entities.DataSourceUnknown, indicator.Source, "providers the dashboard doesn't know are recorded as unknown")
+		assert.Equal(t, at, indicator.Timestamp)
+		assert.Equal(t, exchangeFlowCalcVersion, indicator.CalcVersion)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("provider failure is external", func(t *testing.T) {
+		provider := &staticExchangeFlowProvider{err: assert.AnError}
+
+		_, err := NewExchangeFlowService(provider, nil, nil, logger.New("test")).Calculate(ctx, nil)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeExternal))
+	})
+}
+
+func TestExchangeFlowService_Unconfigured(t *testing.T) {
+	ctx := context.Background()
+
+	// No expectations: an unconfigured reading must never be stored or read back
+	repo := &testutil.MockIndicatorRepository{}
+	service := NewExchangeFlowService(nil, repo, nil, logger.New("test"))
+
+	indicator, err := service.GetLatest(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.0, indicator.Value)
+	assert.Equal(t, "medium", indicator.RiskLevel)
+	assert.Equal(t, ExchangeFlowBandNeutral, indicator.Metadata["band"])
+	assert.Equal(t, false, indicator.Metadata["configured"])
+	assert.Equal(t, "unconfigured", indicator.Metadata["provider"])
+	assert.NotContains(t, indicator.Metadata, "reserve")
+	assert.Equal(t, 0.0, indicator.Confidence)
+	assert.Contains(t, indicator.Status, "No exchange flow provider configured")
+	assert.False(t, indicator.Timestamp.IsZero())
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "GetLatest", mock.Anything, mock.Anything)
+}
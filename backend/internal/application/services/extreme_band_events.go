@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// EventPublishing is implemented by indicator services that publish an event when their
+// indicator enters an extreme band
+type EventPublishing interface {
+	SetEventPublisher(publisher services.EventPublisher)
+}
+
+// loggingEventPublisher is the default EventPublisher: it only logs events
+type loggingEventPublisher struct {
+	logger logger.Logger
+}
+
+// NewLoggingEventPublisher creates an EventPublisher that logs each event and delivers
+// it nowhere else
+func NewLoggingEventPublisher(logger logger.Logger) services.EventPublisher {
+	return &loggingEventPublisher{logger: logger}
+}
+
+// Publish logs the event
+func (p *loggingEventPublisher) Publish(ctx context.Context, event entities.IndicatorEvent) error {
+	p.logger.WithContext(ctx).Info("Indicator event",
+		"type", event.Type,
+		"indicator", event.Indicator,
+		"risk_level", event.RiskLevel,
+		"previous_risk_level", event.PreviousRiskLevel,
+		"value", event.Value)
+	return nil
+}
+
+// extremeBandTracker remembers each indicator's last risk level and publishes an
+// IndicatorEventExtremeBandEntered event when a calculated value moves into an extreme
+// band. Values that stay in the same extreme band publish nothing. The first value seen
+// after startup publishes when it is extreme, since an earlier transition may have been
+// missed while the process was down.
+type extremeBandTracker struct {
+	mu        sync.Mutex
+	publisher services.EventPublisher
+	last      map[string]string
+	logger    logger.Logger
+}
+
+func newExtremeBandTracker(publisher services.EventPublisher, logger logger.Logger) *extremeBandTracker {
+	if publisher == nil {
+		publisher = NewLoggingEventPublisher(logger)
+	}
+	return &extremeBandTracker{
+		publisher: publisher,
+		last:      make(map[string]string),
+		logger:    logger,
+	}
+}
+
+// setPublisher replaces the publisher, keeping the remembered risk levels
+func (t *extremeBandTracker) setPublisher(publisher services.EventPublisher) {
+	if publisher == nil {
+		publisher = NewLoggingEventPublisher(t.logger)
+	}
+	t.mu.Lock()
+	t.publisher = publisher
+	t.mu.Unlock()
+}
+
+// observe records indicator's risk level, publishing an event on entry into an extreme band.
+// Publish failures are logged, never returned, so alerting cannot fail a calculation.
+func (t *extremeBandTracker) observe(ctx context.Context, indicator *entities.Indicator) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	previous, seen := t.last[indicator.Name]
+	t.last[indicator.Name] = indicator.RiskLevel
+	publisher := t.publisher
+	t.mu.Unlock()
+
+	if !entities.IsExtremeRiskLevel(indicator.RiskLevel) || (seen && previous == indicator.RiskLevel) {
+		return
+	}
+
+	event := entities.IndicatorEvent{
+		Type:              entities.IndicatorEventExtremeBandEntered,
+		Indicator:         indicator.Name,
+		Value:             indicator.Value,
+		RiskLevel:         indicator.RiskLevel,
+		PreviousRiskLevel: previous,
+		Status:            indicator.Status,
+		Timestamp:         indicator.Timestamp,
+	}
+	if err := publisher.Publish(ctx, event); err != nil {
+		t.logger.WithContext(ctx).Warn("Failed to publish indicator event",
+			"type", event.Type, "indicator", event.Indicator, "error", err)
+	}
+}
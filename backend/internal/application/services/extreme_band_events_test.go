@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher keeps every published event
+type recordingPublisher struct {
+	events []entities.IndicatorEvent
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event entities.IndicatorEvent) error {
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func TestExtremeBandTracker_PublishesOnEntry(t *testing.T) {
+	ctx := context.Background()
+	publisher := &recordingPublisher{}
+	tracker := newExtremeBandTracker(publisher, logger.New("test"))
+
+	observe := func(riskLevel string) {
+		tracker.observe(ctx, &entities.Indicator{Name: "mvrv", RiskLevel: riskLevel})
+	}
+
+	observe("high")
+	assert.Empty(t, publisher.events)
+
+	observe("extreme_high")
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, entities.IndicatorEventExtremeBandEntered, publisher.events[0].Type)
+	assert.Equal(t, "mvrv", publisher.events[0].Indicator)
+	assert.Equal(t, "extreme_high", publisher.events[0].RiskLevel)
+	assert.Equal(t, "high", publisher.events[0].PreviousRiskLevel)
+
+	// Staying in the band publishes nothing
+	observe("extreme_high")
+	observe("extreme_high")
+	assert.Len(t, publisher.events, 1)
+
+	// Leaving and re-entering publishes again, as does a swing between extremes
+	observe("medium")
+	observe("extreme_high")
+	observe("extreme_low")
+	require.Len(t, publisher.events, 3)
+	assert.Equal(t, "extreme_low", publisher.events[2].RiskLevel)
+	assert.Equal(t, "extreme_high", publisher.events[2].PreviousRiskLevel)
+
+	// Indicators are tracked separately; a first extreme value publishes
+	tracker.observe(ctx, &entities.Indicator{Name: "rhodl", RiskLevel: "extreme_high"})
+	require.Len(t, publisher.events, 4)
+	assert.Empty(t, publisher.events[3].PreviousRiskLevel)
+}
+
+func TestExtremeBandTracker_PublishErrorIsIgnored(t *testing.T) {
+	tracker := newExtremeBandTracker(&recordingPublisher{err: errors.New("broker down")}, logger.New("test"))
+	assert.NotPanics(t, func() {
+		tracker.observe(context.Background(), &entities.Indicator{Name: "mvrv", RiskLevel: "extreme_low"})
+	})
+
+	var unset *extremeBandTracker
+	assert.NotPanics(t, func() {
+		unset.observe(context.Background(), &entities.Indicator{Name: "mvrv", RiskLevel: "extreme_low"})
+	})
+}
+
+func TestVolumeAnomalyService_PublishesExtremeBandEntry(t *testing.T) {
+	ctx := context.Background()
+	trailing := []float64{20e9, 21e9, 19e9, 22e9, 18e9, 20e9, 21e9, 19e9, 20e9, 20e9}
+	service, _ := newVolumeAnomalyTestService(20.5e9, storedVolumes(trailing...))
+	publisher := &recordingPublisher{}
+	service.SetEventPublisher(publisher)
+
+	quotes := &staticQuotesClient{}
+	service.quotesClient = quotes
+	for _, volume := range []float64{20.5e9, 60e9, 62e9, 61e9} {
+		quotes.volume = volume
+		_, err := service.Calculate(ctx, nil)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, volumeAnomalyIndicatorName, publisher.events[0].Indicator)
+	assert.Equal(t, "extreme_high", publisher.events[0].RiskLevel)
+	assert.Greater(t, publisher.events[0].Value, 3.0)
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	fearGreedIndicatorName = "fear_greed"
+	fearGreedCacheKey      = "fear_greed_readings"
+	fearGreedCacheTTL      = 10 * time.Minute
+
+	// fearGreedCalcVersion is stored with each reading; bump it when the stored form changes
+	fearGreedCalcVersion uint = 1
+
+	// fearGreedHistoryDays covers the 7d change and the 30-day chart
+	fearGreedHistoryDays = 30
+)
+
+// Fear & Greed classifications, matching Alternative.me's bands
+const (
+	FearGreedExtremeFear  = "Extreme Fear"
+	FearGreedFear         = "Fear"
+	FearGreedNeutral      = "Neutral"
+	FearGreedGreed        = "Greed"
+	FearGreedExtremeGreed = "Extreme Greed"
+)
+
+// fearGreedServiceImpl implements the FearGreedService interface on top of a FearGreedProvider
+type fearGreedServiceImpl struct {
+	provider      services.FearGreedProvider
+	indicatorRepo repositories.IndicatorRepository
+	cache         services.CacheService
+	logger        logger.Logger
+}
+
+// NewFearGreedService creates a new Fear & Greed service backed by the given provider. With
+// an indicator repository each new reading is stored, components included in its metadata.
+func NewFearGreedService(
+	provider services.FearGreedProvider,
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.FearGreedService {
+	return &fearGreedServiceImpl{
+		provider:      provider,
+		indicatorRepo: indicatorRepo,
+		cache:         cache,
+		logger:        logger,
+	}
+}
+
+// GetFearGreedAnalysis returns the current index with 24h/7d changes. Components are
+// only included when the provider supplies them.
+func (s *fearGreedServiceImpl) GetFearGreedAnalysis(ctx context.Context) (*entities.FearGreedResult, error) {
+	readings, err := s.readings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := readings[0]
+	classification := current.Classification
+	if classification == "" {
+		classification = classifyFearGreed(current.Value)
+	}
+	riskLevel, status, recommendation := assessFearGreed(current.Value)
+
+	result := &entities.FearGreedResult{
+		CurrentValue:          current.Value,
+		Classification:        classification,
+		RiskLevel:             riskLevel,
+		Status:                status,
+		TradingRecommendation: recommendation,
+		DataSource:            s.dataSource(),
+		NextUpdate:            current.NextUpdate,
+		LastUpdated:           current.Timestamp,
+	}
+	if len(readings) > 1 {
+		result.Change24h = current.Value - readings[1].Value
+	}
+	if len(readings) > 7 {
+		result.Change7d = current.Value - readings[7].Value
+	}
+	if len(current.Components) > 0 {
+		result.Components = current.Components
+	}
+
+	return result, nil
+}
+
+// GetFearGreedChart returns the daily index history in chart format, oldest first
+func (s *fearGreedServiceImpl) GetFearGreedChart(ctx context.Context) (map[string]interface{}, error) {
+	readings, err := s.readings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]int64, len(readings))
+	values := make([]int, len(readings))
+	for i, reading := range readings {
+		j := len(readings) - 1 - i
+		timestamps[j] = reading.Timestamp.Unix() * 1000
+		values[j] = reading.Value
+	}
+
+	return map[string]interface{}{
+		"timestamps":   timestamps,
+		"values":       values,
+		"last_updated": readings[0].Timestamp,
+		"current":      readings[0].Value,
+		"data_source":  s.dataSource(),
+		"levels": map[string]int{
+			"extreme_fear":  25,
+			"fear":          45,
+			"greed":         75,
+			"extreme_greed": 90,
+		},
+	}, nil
+}
+
+// GetComponentHistory rebuilds each component's series from the readings stored within the
+// window. Readings without components are skipped, so the history is empty for providers
+// that only publish the headline index.
+func (s *fearGreedServiceImpl) GetComponentHistory(ctx context.Context, window entities.TimeRange) (*entities.FearGreedComponentHistory, error) {
+	if s.indicatorRepo == nil {
+		return fearGreedComponentHistory(nil), nil
+	}
+
+	records, err := s.indicatorRepo.GetHistoricalData(ctx, fearGreedIndicatorName, window.From, window.To)
+	if err != nil {
+		return nil, err
+	}
+	return fearGreedComponentHistory(records), nil
+}
+
+// AnalyzeSentiment returns the classification for an index value
+func (s *fearGreedServiceImpl) AnalyzeSentiment(ctx context.Context, value int) string {
+	return classifyFearGreed(value)
+}
+
+// dataSource returns the canonical data source of the provider's readings
+func (s *fearGreedServiceImpl) dataSource() entities.DataSource {
+	return providerDataSource(s.provider.Name())
+}
+
+// readings fetches recent readings from the provider, newest first, through the cache
+func (s *fearGreedServiceImpl) readings(ctx context.Context) ([]entities.FearGreedReading, error) {
+	fresh := false
+	var readings []entities.FearGreedReading
+	fetch := func() (interface{}, error) {
+		fresh = true
+		return s.provider.GetReadings(ctx, fearGreedHistoryDays)
+	}
+
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, fearGreedCacheKey, &readings, fearGreedCacheTTL, fetch)
+	} else {
+		var value interface{}
+		if value, err = fetch(); err == nil {
+			readings = value.([]entities.FearGreedReading)
+		}
+	}
+	if err != nil {
+		return nil, errors.External(s.provider.Name(), "failed to fetch Fear & Greed index", err)
+	}
+	if len(readings) == 0 {
+		return nil, errors.External(s.provider.Name(), "no Fear & Greed readings available", nil)
+	}
+
+	if fresh && s.indicatorRepo != nil {
+		s.store(ctx, readings[0])
+	}
+
+	return readings, nil
+}
+
+// store saves a reading unless it is already the latest stored one; providers publish daily
+// while the cache refreshes every few minutes. Failures are logged, not returned.
+func (s *fearGreedServiceImpl) store(ctx context.Context, reading entities.FearGreedReading) {
+	log := s.logger.WithContext(ctx)
+	latest, err := s.indicatorRepo.GetLatest(ctx, fearGreedIndicatorName)
+	if err == nil && latest.Timestamp.Equal(reading.Timestamp) {
+		return
+	}
+	if err != nil && !errors.IsType(err, errors.ErrorTypeNotFound) {
+		log.Warn("Failed to read latest stored Fear & Greed reading", "error", err)
+		return
+	}
+
+	classification := reading.Classification
+	if classification == "" {
+		classification = classifyFearGreed(reading.Value)
+	}
+	riskLevel, status, _ := assessFearGreed(reading.Value)
+
+	metadata := map[string]interface{}{
+		"classification": classification,
+	}
+	if len(reading.Components) > 0 {
+		metadata["components"] = reading.Components
+	}
+
+	indicator := &entities.Indicator{
+		Name:        fearGreedIndicatorName,
+		Type:        "sentiment",
+		Value:       float64(reading.Value),
+		Change:      classification,
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: "Crypto Fear & Greed index (0 = extreme fear, 100 = extreme greed)",
+		Source:      s.dataSource(),
+		Confidence:  0.9,
+		CalcVersion: fearGreedCalcVersion,
+		Timestamp:   reading.Timestamp,
+		Metadata:    metadata,
+	}
+	if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+		log.Warn("Failed to save Fear & Greed reading to database", "error", err)
+	}
+}
+
+// fearGreedComponentHistory aligns the components of stored readings into one series per
+// component, oldest first
+func fearGreedComponentHistory(records []entities.Indicator) *entities.FearGreedComponentHistory {
+	history := &entities.FearGreedComponentHistory{
+		Timestamps: []int64{},
+		Values:     []int{},
+		Components: map[string][]*int{},
+	}
+
+	sorted := make([]entities.Indicator, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	for _, record := range sorted {
+		components := storedComponents(record.Metadata)
+		if len(components) == 0 {
+			continue
+		}
+
+		n := len(history.Timestamps)
+		history.Timestamps = append(history.Timestamps, record.Timestamp.UnixMilli())
+		history.Values = append(history.Values, int(record.Value))
+		for name, value := range components {
+			series, ok := history.Components[name]
+			if !ok {
+				// Backfill readings from before the component first appeared
+				series = make([]*int, n)
+			}
+			value := value
+			history.Components[name] = append(series, &value)
+		}
+		// Pad components this reading lacked
+		for name, series := range history.Components {
+			if len(series) == n {
+				history.Components[name] = append(series, nil)
+			}
+		}
+	}
+
+	return history
+}
+
+// storedComponents reads components from indicator metadata, both as set in memory and in
+// the generic JSON form loaded back from storage
+func storedComponents(metadata map[string]interface{}) map[string]int {
+	switch components := metadata["components"].(type) {
+	case map[string]int:
+		return components
+	case map[string]interface{}:
+		result := make(map[string]int, len(components))
+		for name, raw := range components {
+			if value, ok := raw.(float64); ok {
+				result[name] = int(value)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// classifyFearGreed maps an index value to its sentiment band
+func classifyFearGreed(value int) string {
+	switch {
+	case value < 25:
+		return FearGreedExtremeFear
+	case value < 45:
+		return FearGreedFear
+	case value <= 55:
+		return FearGreedNeutral
+	case value <= 75:
+		return FearGreedGreed
+	default:
+		return FearGreedExtremeGreed
+	}
+}
+
+// assessFearGreed maps an index value to a risk level, status and contrarian recommendation
+func assessFearGreed(value int) (riskLevel, status, recommendation string) {
+	switch {
+	case value < 25:
+		return "low", "Extreme fear - Historically a buying opportunity", "Consider accumulating"
+	case value < 45:
+		return "low", "Fear territory - Sentiment is cautious", "Consider dollar-cost averaging"
+	case value <= 55:
+		return "medium", "Neutral sentiment", "Hold current positions"
+	case value <= 75:
+		return "high", "Greed territory - Consider taking profits", "Consider taking some profits"
+	default:
+		return "high", "Extreme greed - Distribution zone", "Consider reducing exposure"
+	}
+}
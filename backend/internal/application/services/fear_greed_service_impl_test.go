@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFearGreedProvider returns fixed readings and counts calls
+type fakeFearGreedProvider struct {
+	name     string
+	readings []entities.FearGreedReading
+	err      error
+	calls    int
+}
+
+func (p *fakeFearGreedProvider) Name() string { return p.name }
+
+func (p *fakeFearGreedProvider) GetReadings(ctx context.Context, limit int) ([]entities.FearGreedReading, error) {
+	p.calls++
+	return p.readings, p.err
+}
+
+// dailyReadings builds newest-first readings; components are attached to the newest one only
+func dailyReadings(values []int, components map[string]int) []entities.FearGreedReading {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	readings := make([]entities.FearGreedReading, len(values))
+	for i, value := range values {
+		readings[i] = entities.FearGreedReading{Value: value, Timestamp: today.AddDate(0, 0, -i)}
+	}
+	readings[0].Components = components
+	return readings
+}
+
+func TestFearGreedService_IndexOnlyProvider(t *testing.T) {
+	log := logger.New("test")
+	provider := &fakeFearGreedProvider{
+		name:     "Alternative.me",
+		readings: dailyReadings([]int{72, 67, 60, 58, 55, 50, 48, 40}, nil),
+	}
+	service := NewFearGreedService(provider, nil, cache.NewCacheService(nil, log), log)
+
+	result, err := service.GetFearGreedAnalysis(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 72, result.CurrentValue)
+	assert.Equal(t, 5, result.Change24h)
+	assert.Equal(t, 32, result.Change7d)
+	assert.Equal(t, FearGreedGreed, result.Classification, "derived when the provider omits it")
+	assert.Equal(t, "high", result.RiskLevel)
+	assert.Equal(t, entities.DataSourceAlternativeMe, result.DataSource)
+	assert.Nil(t, result.Components, "no components should be invented for an index-only provider")
+}
+
+func TestFearGreedService_ComponentsProvider(t *testing.T) {
+	log := logger.New("test")
+	components := map[string]int{"volatility": 30, "momentum": 20, "social": 25}
+	readings := dailyReadings([]int{20, 28}, components)
+	readings[0].Classification = "Extreme Fear"
+	provider := &fakeFearGreedProvider{name: "RichProvider", readings: readings}
+	service := NewFearGreedService(provider, nil, cache.NewCacheService(nil, log), log)
+
+	result, err := service.GetFearGreedAnalysis(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 20, result.CurrentValue)
+	assert.Equal(t, -8, result.Change24h)
+	assert.Equal(t, 0, result.Change7d, "not enough history for a 7d change")
+	assert.Equal(t, "Extreme Fear", result.Classification)
+	assert.Equal(t, "low", result.RiskLevel)
+	assert.Equal(t, components, result.Components)
+
+	// Chart data is served from the cached readings, oldest first
+	chart, err := service.GetFearGreedChart(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int{28, 20}, chart["values"])
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestFearGreedService_ProviderErrors(t *testing.T) {
+	log := logger.New("test")
+
+	failing := &fakeFearGreedProvider{name: "Alternative.me", err: fmt.Errorf("timeout")}
+	_, err := NewFearGreedService(failing, nil, nil, log).GetFearGreedAnalysis(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeExternal))
+
+	empty := &fakeFearGreedProvider{name: "Alternative.me", readings: []entities.FearGreedReading{}}
+	_, err = NewFearGreedService(empty, nil, nil, log).GetFearGreedAnalysis(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeExternal))
+}
+
+func TestFearGreedService_StoresNewReadings(t *testing.T) {
+	log := logger.New("test")
+	components := map[string]int{"volatility": 30, "momentum": 20}
+	readings := dailyReadings([]int{20, 28}, components)
+
+	t.Run("new reading is stored with its components", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("GetLatest", mock.Anything, fearGreedIndicatorName).
+			Return(&entities.Indicator{Timestamp: readings[1].Timestamp}, nil)
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(i *entities.Indicator) bool {
+			return i.Name == fearGreedIndicatorName && i.Value == 20 &&
+				i.Timestamp.Equal(readings[0].Timestamp) &&
+				assert.ObjectsAreEqual(components, i.Metadata["components"]) &&
+				i.CalcVersion == fearGreedCalcVersion
+		})).Return(nil).Once()
+
+		provider := &fakeFearGreedProvider{name: "RichProvider", readings: readings}
+		_, err := NewFearGreedService(provider, repo, nil, log).GetFearGreedAnalysis(context.Background())
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("already stored reading is skipped", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("GetLatest", mock.Anything, fearGreedIndicatorName).
+			Return(&entities.Indicator{Timestamp: readings[0].Timestamp}, nil)
+
+		provider := &fakeFearGreedProvider{name: "RichProvider", readings: readings}
+		_, err := NewFearGreedService(provider, repo, nil, log).GetFearGreedAnalysis(context.Background())
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFearGreedService_ComponentHistory(t *testing.T) {
+	log := logger.New("test")
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	window := entities.TimeRange{From: day.AddDate(0, 0, -30), To: day}
+
+	// Metadata as it comes back from storage, decoded into generic JSON values
+	stored := func(offset, value int, components map[string]interface{}) entities.Indicator {
+		metadata := map[string]interface{}{"classification": "Fear"}
+		if components != nil {
+			metadata["components"] = components
+		}
+		return entities.Indicator{
+			Name: fearGreedIndicatorName, Value: float64(value),
+			Timestamp: day.AddDate(0, 0, offset), Metadata: metadata,
+		}
+	}
+	records := []entities.Indicator{
+		stored(-4, 40, map[string]interface{}{"volatility": 35.0, "momentum": 45.0}),
+		stored(-3, 42, nil), // Index-only reading
+		stored(-2, 50, map[string]interface{}{"volatility": 48.0, "momentum": 52.0, "social": 50.0}),
+		stored(-1, 61, map[string]interface{}{"volatility": 60.0, "social": 66.0}),
+	}
+
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("GetHistoricalData", mock.Anything, fearGreedIndicatorName, window.From, window.To).Return(records, nil)
+
+	history, err := NewFearGreedService(&fakeFearGreedProvider{}, repo, nil, log).GetComponentHistory(context.Background(), window)
+	require.NoError(t, err)
+
+	intp := func(v int) *int { return &v }
+	assert.Equal(t, []int64{
+		day.AddDate(0, 0, -4).UnixMilli(),
+		day.AddDate(0, 0, -2).UnixMilli(),
+		day.AddDate(0, 0, -1).UnixMilli(),
+	}, history.Timestamps)
+	assert.Equal(t, []int{40, 50, 61}, history.Values)
+	assert.Equal(t, map[string][]*int{
+		"volatility": {intp(35), intp(48), intp(60)},
+		"momentum":   {intp(45), intp(52), nil},
+		"social":     {nil, intp(50), intp(66)},
+	}, history.Components)
+
+	t.Run("index-only history is empty", func(t *testing.T) {
+		history := fearGreedComponentHistory([]entities.Indicator{stored(-1, 30, nil)})
+		assert.Empty(t, history.Timestamps)
+		assert.Empty(t, history.Components)
+	})
+
+	t.Run("without storage", func(t *testing.T) {
+		history, err := NewFearGreedService(&fakeFearGreedProvider{}, nil, nil, log).GetComponentHistory(context.Background(), window)
+		require.NoError(t, err)
+		assert.Empty(t, history.Timestamps)
+	})
+}
+
+func TestClassifyFearGreed(t *testing.T) {
+	tests := []struct {
+		value    int
+		expected string
+	}{
+		{10, FearGreedExtremeFear},
+		{30, FearGreedFear},
+		{50, FearGreedNeutral},
+		{70, FearGreedGreed},
+		{90, FearGreedExtremeGreed},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, classifyFearGreed(tt.value), "value %d", tt.value)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// changeHorizon is a lookback offset and how far from it a stored value may be
+type changeHorizon struct {
+	offset    time.Duration
+	tolerance time.Duration
+	assign    func(changes *entities.IndicatorChanges, change float64)
+}
+
+var changeHorizons = []changeHorizon{
+	{
+		offset:    24 * time.Hour,
+		tolerance: 3 * time.Hour,
+		assign:    func(c *entities.IndicatorChanges, change float64) { c.Change24h = &change },
+	},
+	{
+		offset:    7 * 24 * time.Hour,
+		tolerance: 12 * time.Hour,
+		assign:    func(c *entities.IndicatorChanges, change float64) { c.Change7d = &change },
+	},
+	{
+		offset:    30 * 24 * time.Hour,
+		tolerance: 24 * time.Hour,
+		assign:    func(c *entities.IndicatorChanges, change float64) { c.Change30d = &change },
+	},
+}
+
+// indicatorChangeServiceImpl implements the IndicatorChangeService interface
+type indicatorChangeServiceImpl struct {
+	indicatorRepo repositories.IndicatorRepository
+	logger        logger.Logger
+}
+
+// NewIndicatorChangeService creates a new indicator change service implementation
+func NewIndicatorChangeService(indicatorRepo repositories.IndicatorRepository, logger logger.Logger) services.IndicatorChangeService {
+	return &indicatorChangeServiceImpl{
+		indicatorRepo: indicatorRepo,
+		logger:        logger,
+	}
+}
+
+// CalculateChanges returns current minus the stored value closest to each
+// 24h/7d/30d offset before at. Horizons without a value inside their
+// tolerance window are left nil.
+func (s *indicatorChangeServiceImpl) CalculateChanges(ctx context.Context, name string, current float64, at time.Time) (*entities.IndicatorChanges, error) {
+	changes := &entities.IndicatorChanges{}
+
+	for _, horizon := range changeHorizons {
+		target := at.Add(-horizon.offset)
+		history, err := s.indicatorRepo.GetHistoricalData(ctx, name, target.Add(-horizon.tolerance), target.Add(horizon.tolerance))
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get historical data for "+name)
+		}
+
+		past, ok := closestTo(history, target)
+		if !ok {
+			continue
+		}
+		horizon.assign(changes, current-past.Value)
+	}
+
+	s.logger.WithContext(ctx).Debug("Calculated indicator changes", "name", name,
+		"has_24h", changes.Change24h != nil,
+		"has_7d", changes.Change7d != nil,
+		"has_30d", changes.Change30d != nil)
+
+	return changes, nil
+}
+
+// closestTo returns the indicator whose timestamp is nearest to target
+func closestTo(history []entities.Indicator, target time.Time) (entities.Indicator, bool) {
+	var best entities.Indicator
+	var bestDistance time.Duration
+	found := false
+
+	for _, indicator := range history {
+		distance := indicator.Timestamp.Sub(target)
+		if distance < 0 {
+			distance = -distance
+		}
+		if !found || distance < bestDistance {
+			best = indicator
+			bestDistance = distance
+			found = true
+		}
+	}
+	return best, found
+}
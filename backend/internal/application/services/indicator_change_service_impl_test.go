@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectHorizon stubs the history lookup for one horizon's tolerance window
+func expectHorizon(repo *testutil.MockIndicatorRepository, at time.Time, offset, tolerance time.Duration, history []entities.Indicator) {
+	target := at.Add(-offset)
+	repo.On("GetHistoricalData", mock.Anything, "mvrv", target.Add(-tolerance), target.Add(tolerance)).Return(history, nil)
+}
+
+func TestCalculateChanges(t *testing.T) {
+	at := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Values at every offset", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		expectHorizon(repo, at, 24*time.Hour, 3*time.Hour, []entities.Indicator{
+			{Name: "mvrv", Value: 1.0, Timestamp: at.Add(-26 * time.Hour)},
+			{Name: "mvrv", Value: 2.0, Timestamp: at.Add(-23 * time.Hour)},
+		})
+		expectHorizon(repo, at, 7*24*time.Hour, 12*time.Hour, []entities.Indicator{
+			{Name: "mvrv", Value: 1.5, Timestamp: at.AddDate(0, 0, -7)},
+		})
+		expectHorizon(repo, at, 30*24*time.Hour, 24*time.Hour, []entities.Indicator{
+			{Name: "mvrv", Value: 3.0, Timestamp: at.AddDate(0, 0, -30).Add(6 * time.Hour)},
+		})
+		service := NewIndicatorChangeService(repo, logger.New("test"))
+
+		changes, err := service.CalculateChanges(context.Background(), "mvrv", 2.5, at)
+
+		require.NoError(t, err)
+		require.NotNil(t, changes.Change24h)
+		require.NotNil(t, changes.Change7d)
+		require.NotNil(t, changes.Change30d)
+		// The sample one hour from the 24h target wins over the one two hours away
+		assert.InDelta(t, 0.5, *changes.Change24h, 1e-9)
+		assert.InDelta(t, 1.0, *changes.Change7d, 1e-9)
+		assert.InDelta(t, -0.5, *changes.Change30d, 1e-9)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Missing history is omitted", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		expectHorizon(repo, at, 24*time.Hour, 3*time.Hour, []entities.Indicator{
+			{Name: "mvrv", Value: 2.0, Timestamp: at.Add(-24 * time.Hour)},
+		})
+		expectHorizon(repo, at, 7*24*time.Hour, 12*time.Hour, []entities.Indicator{})
+		expectHorizon(repo, at, 30*24*time.Hour, 24*time.Hour, []entities.Indicator{})
+		service := NewIndicatorChangeService(repo, logger.New("test"))
+
+		changes, err := service.CalculateChanges(context.Background(), "mvrv", 2.0, at)
+
+		require.NoError(t, err)
+		require.NotNil(t, changes.Change24h)
+		assert.Equal(t, 0.0, *changes.Change24h)
+		assert.Nil(t, changes.Change7d)
+		assert.Nil(t, changes.Change30d)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("GetHistoricalData", mock.Anything, "mvrv", mock.Anything, mock.Anything).
+			Return([]entities.Indicator(nil), errors.Internal("database unavailable", nil))
+		service := NewIndicatorChangeService(repo, logger.New("test"))
+
+		_, err := service.CalculateChanges(context.Background(), "mvrv", 2.0, at)
+
+		require.Error(t, err)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeInternal))
+	})
+}
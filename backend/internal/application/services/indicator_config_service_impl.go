@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// indicatorRiskLevels are the risk levels a band may report
+var indicatorRiskLevels = []string{"extreme_low", "low", "medium", "high", "extreme_high"}
+
+// IndicatorConfigurable is implemented by indicator services whose bands come from
+// indicator configs
+type IndicatorConfigurable interface {
+	SetIndicatorConfigs(provider services.IndicatorConfigProvider)
+}
+
+// indicatorConfigServiceImpl implements the IndicatorConfigService interface. Stored configs
+// are held in memory so indicator calculations can read them without a query; an update
+// on one instance reaches the others when they next call Load.
+type indicatorConfigServiceImpl struct {
+	repo   repositories.IndicatorConfigRepository
+	logger logger.Logger
+
+	mu     sync.RWMutex
+	stored map[string]entities.IndicatorConfig
+}
+
+// NewIndicatorConfigService creates a new indicator config service
+func NewIndicatorConfigService(repo repositories.IndicatorConfigRepository, logger logger.Logger) services.IndicatorConfigService {
+	return &indicatorConfigServiceImpl{
+		repo:   repo,
+		logger: logger,
+		stored: make(map[string]entities.IndicatorConfig),
+	}
+}
+
+// Load replaces the in-memory configs with the stored ones. Stored configs for indicators
+// that are no longer configurable are ignored.
+func (s *indicatorConfigServiceImpl) Load(ctx context.Context) error {
+	configs, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[string]entities.IndicatorConfig, len(configs))
+	for _, config := range configs {
+		if _, ok := entities.DefaultIndicatorConfig(config.Name); !ok {
+			s.logger.Warn("Ignoring stored config for unknown indicator", "name", config.Name)
+			continue
+		}
+		stored[config.Name] = config
+	}
+
+	s.mu.Lock()
+	s.stored = stored
+	s.mu.Unlock()
+
+	s.logger.Info("Loaded indicator configs", "stored", len(stored))
+	return nil
+}
+
+// IndicatorConfig returns the stored config for name, falling back to its default
+func (s *indicatorConfigServiceImpl) IndicatorConfig(name string) (entities.IndicatorConfig, bool) {
+	s.mu.RLock()
+	config, ok := s.stored[name]
+	s.mu.RUnlock()
+	if ok {
+		return config, true
+	}
+	return entities.DefaultIndicatorConfig(name)
+}
+
+// List returns the config in effect for every configurable indicator, ordered by name
+func (s *indicatorConfigServiceImpl) List(ctx context.Context) ([]entities.IndicatorConfig, error) {
+	names := entities.ConfigurableIndicators()
+	sort.Strings(names)
+
+	configs := make([]entities.IndicatorConfig, 0, len(names))
+	for _, name := range names {
+		config, _ := s.IndicatorConfig(name)
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// Update stores config as name's config once it passes validation. An empty description
+// keeps the default one.
+func (s *indicatorConfigServiceImpl) Update(ctx context.Context, name string, config entities.IndicatorConfig) (*entities.IndicatorConfig, error) {
+	defaults, ok := entities.DefaultIndicatorConfig(name)
+	if !ok {
+		return nil, errors.NotFound("indicator config " + name)
+	}
+	if err := validateIndicatorBands(config.Bands); err != nil {
+		return nil, errors.Validation("Invalid indicator config", err.Error())
+	}
+
+	config.Name = name
+	if config.Description == "" {
+		config.Description = defaults.Description
+	}
+	if err := s.repo.Save(ctx, &config); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.stored[name] = config
+	s.mu.Unlock()
+
+	s.logger.WithContext(ctx).Info("Updated indicator config", "name", name, "thresholds", config.Thresholds())
+	return &config, nil
+}
+
+// validateIndicatorBands requires uniquely labelled bands with known risk levels and
+// distinct lower bounds, exactly one of which is unbounded below
+func validateIndicatorBands(bands []entities.IndicatorBand) error {
+	if len(bands) == 0 {
+		return fmt.Errorf("at least one band is required")
+	}
+
+	labels := make(map[string]bool, len(bands))
+	mins := make(map[float64]string, len(bands))
+	unbounded := 0
+	for _, band := range bands {
+		if band.Label == "" {
+			return fmt.Errorf("every band needs a label")
+		}
+		if labels[band.Label] {
+			return fmt.Errorf("duplicate band label %q", band.Label)
+		}
+		labels[band.Label] = true
+
+		if !isIndicatorRiskLevel(band.RiskLevel) {
+			return fmt.Errorf("band %s has unknown risk level %q, expected one of %s", band.Label, band.RiskLevel, strings.Join(indicatorRiskLevels, ", "))
+		}
+
+		if band.Min == nil {
+			unbounded++
+			continue
+		}
+		if other, ok := mins[*band.Min]; ok {
+			return fmt.Errorf("bands %s and %s share the lower bound %v", other, band.Label, *band.Min)
+		}
+		mins[*band.Min] = band.Label
+	}
+	if unbounded != 1 {
+		return fmt.Errorf("exactly one band must omit min to cover the lowest values, found %d", unbounded)
+	}
+	return nil
+}
+
+// isIndicatorRiskLevel reports whether level is a risk level bands may use
+func isIndicatorRiskLevel(level string) bool {
+	for _, known := range indicatorRiskLevels {
+		if known == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIndicatorConfigRepository stores indicator configs in a map
+type memoryIndicatorConfigRepository struct {
+	configs map[string]entities.IndicatorConfig
+}
+
+func (r *memoryIndicatorConfigRepository) List(ctx context.Context) ([]entities.IndicatorConfig, error) {
+	configs := make([]entities.IndicatorConfig, 0, len(r.configs))
+	for _, config := range r.configs {
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+func (r *memoryIndicatorConfigRepository) Save(ctx context.Context, config *entities.IndicatorConfig) error {
+	if r.configs == nil {
+		r.configs = make(map[string]entities.IndicatorConfig)
+	}
+	r.configs[config.Name] = *config
+	return nil
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestIndicatorConfigService_LoadAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryIndicatorConfigRepository{configs: map[string]entities.IndicatorConfig{
+		"mvrv": {Name: "mvrv", Bands: []entities.IndicatorBand{
+			{Label: "hot", Min: floatPtr(5), RiskLevel: "extreme_high"},
+			{Label: "cold", RiskLevel: "low"},
+		}},
+		"retired": {Name: "retired", Bands: []entities.IndicatorBand{{Label: "any", RiskLevel: "low"}}},
+	}}
+
+	service := NewIndicatorConfigService(repo, logger.New("test"))
+	defaults, ok := service.IndicatorConfig("mvrv")
+	require.True(t, ok)
+	assert.Len(t, defaults.Bands, 7)
+
+	require.NoError(t, service.Load(ctx))
+	loaded, ok := service.IndicatorConfig("mvrv")
+	require.True(t, ok)
+	assert.Equal(t, "hot", loaded.Classify(6).Label)
+	assert.Equal(t, "cold", loaded.Classify(4.9).Label)
+
+	_, ok = service.IndicatorConfig("retired")
+	assert.False(t, ok, "stored configs for unknown indicators are ignored")
+
+	updated, err := service.Update(ctx, "mvrv", entities.IndicatorConfig{Bands: []entities.IndicatorBand{
+		{Label: "hot", Min: floatPtr(4), RiskLevel: "extreme_high"},
+		{Label: "cold", RiskLevel: "low"},
+	}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, updated.Description, "an empty description keeps the default")
+	assert.Equal(t, 4.0, *repo.configs["mvrv"].Bands[0].Min)
+
+	current, _ := service.IndicatorConfig("mvrv")
+	assert.Equal(t, "hot", current.Classify(4.5).Label)
+}
+
+func TestIndicatorConfigService_UpdateValidation(t *testing.T) {
+	ctx := context.Background()
+	service := NewIndicatorConfigService(&memoryIndicatorConfigRepository{}, logger.New("test"))
+
+	invalid := map[string][]entities.IndicatorBand{
+		"no bands":           nil,
+		"missing label":      {{RiskLevel: "low"}},
+		"duplicate label":    {{Label: "a", Min: floatPtr(1), RiskLevel: "low"}, {Label: "a", RiskLevel: "low"}},
+		"unknown risk":       {{Label: "a", RiskLevel: "severe"}},
+		"no floor band":      {{Label: "a", Min: floatPtr(1), RiskLevel: "low"}},
+		"two floor bands":    {{Label: "a", RiskLevel: "low"}, {Label: "b", RiskLevel: "high"}},
+		"shared lower bound": {{Label: "a", Min: floatPtr(1), RiskLevel: "low"}, {Label: "b", Min: floatPtr(1), RiskLevel: "high"}, {Label: "c", RiskLevel: "low"}},
+	}
+	for name, bands := range invalid {
+		_, err := service.Update(ctx, "mvrv", entities.IndicatorConfig{Bands: bands})
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation), name)
+	}
+
+	_, err := service.Update(ctx, "unknown", entities.IndicatorConfig{Bands: []entities.IndicatorBand{{Label: "a", RiskLevel: "low"}}})
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+}
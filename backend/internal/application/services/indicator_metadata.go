@@ -0,0 +1,22 @@
+package services
+
+import (
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/errors"
+)
+
+// validateIndicatorMetadata checks an indicator's metadata against its registered schema
+// before it is persisted, so stored metadata stays readable by its consumers
+func validateIndicatorMetadata(indicator *entities.Indicator) error {
+	if err := indicator.ValidateMetadata(); err != nil {
+		return errors.Validation("indicator metadata does not match its schema", indicator.Name+": "+err.Error())
+	}
+	return nil
+}
+
+// providerDataSource returns the canonical data source for a pluggable provider's name;
+// providers the dashboard doesn't know are recorded as unknown
+func providerDataSource(name string) entities.DataSource {
+	source, _ := entities.NormalizeDataSource(name)
+	return source
+}
@@ -2,22 +2,59 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/internal/domain/repositories"
 	"crypto-indicator-dashboard/internal/domain/services"
 	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/errors"
 	"crypto-indicator-dashboard/pkg/logger"
 )
 
+// PriceFallbackClient is the subset of the Binance client used when CoinMarketCap quotes fail
+type PriceFallbackClient interface {
+	GetPrice(ctx context.Context, symbol string) (float64, error)
+	HealthCheck(ctx context.Context) (time.Duration, error)
+}
+
+// priceFallbackQuote is the quote asset paired with each symbol on the fallback exchange
+const priceFallbackQuote = "USDT"
+
+// marketMetricsMaxAge is how old stored market metrics may get before they are refetched
+const marketMetricsMaxAge = 10 * time.Minute
+
 // marketDataServiceImpl implements the MarketDataService interface
 type marketDataServiceImpl struct {
 	repo              repositories.MarketDataRepository
 	coinMarketCapClient *external.CoinMarketCapClient
 	tradingViewScraper  *external.TradingViewScraper
+	priceFallback       PriceFallbackClient
+	metadata            services.SymbolMetadataService
 	cacheService      services.CacheService
+	dominanceConfig   DominanceSourceConfig
+	priceConfig       PriceSourceConfig
 	logger            logger.Logger
+
+	// rateLimitedUntil is when CoinMarketCap's last rate limit lifts; quotes are not requested before then
+	rateLimitMu      sync.Mutex
+	rateLimitedUntil time.Time
+}
+
+// MarketDataOptions holds the optional parts of the market data service
+type MarketDataOptions struct {
+	// PriceFallback serves prices while CoinMarketCap is unavailable
+	PriceFallback PriceFallbackClient
+	// Metadata adds rank, slug and logo to price responses
+	Metadata services.SymbolMetadataService
+	// Dominance chooses the dominance sources; nil uses DefaultDominanceSourceConfig()
+	Dominance *DominanceSourceConfig
+	// Prices chooses between first-available and aggregated pricing; nil uses
+	// DefaultPriceSourceConfig()
+	Prices *PriceSourceConfig
 }
 
 // NewMarketDataService creates a new market data service implementation
@@ -27,18 +64,44 @@ func NewMarketDataService(
 	tradingViewScraper *external.TradingViewScraper,
 	cacheService services.CacheService,
 	logger logger.Logger,
+	opts MarketDataOptions,
 ) services.MarketDataService {
+	dominanceConfig := DefaultDominanceSourceConfig()
+	if opts.Dominance != nil {
+		dominanceConfig = *opts.Dominance
+	}
+	if len(dominanceConfig.Sources) == 0 {
+		dominanceConfig.Sources = DefaultDominanceSourceConfig().Sources
+	}
+
+	priceConfig := DefaultPriceSourceConfig()
+	if opts.Prices != nil {
+		priceConfig = *opts.Prices
+	}
+	if priceConfig.Mode == "" {
+		priceConfig.Mode = PriceSourceModeFirstAvailable
+	}
+	if priceConfig.Mode == PriceSourceModeAggregated && len(priceConfig.Sources) == 0 {
+		logger.Warn("Aggregated pricing configured without price sources, using first available")
+		priceConfig.Mode = PriceSourceModeFirstAvailable
+	}
+
 	return &marketDataServiceImpl{
 		repo:                repo,
 		coinMarketCapClient: coinMarketCapClient,
 		tradingViewScraper:  tradingViewScraper,
+		priceFallback:       opts.PriceFallback,
+		metadata:            opts.Metadata,
 		cacheService:        cacheService,
+		dominanceConfig:     dominanceConfig,
+		priceConfig:         priceConfig,
 		logger:              logger,
 	}
 }
 
-// GetCryptoPrices retrieves current cryptocurrency prices from CoinMarketCap
-func (s *marketDataServiceImpl) GetCryptoPrices(ctx context.Context, symbols []string) (map[string]*entities.CryptoPrice, error) {
+// GetCryptoPrices retrieves current cryptocurrency prices from CoinMarketCap.
+// CoinMarketCap omits symbols it doesn't recognise, so those are reported as unresolved.
+func (s *marketDataServiceImpl) GetCryptoPrices(ctx context.Context, symbols []string) (map[string]*entities.CryptoPrice, []string, error) {
 	cacheKey := fmt.Sprintf("crypto_prices_%v", symbols)
 	
 	// Try to get from cache first
@@ -48,18 +111,93 @@ func (s *marketDataServiceImpl) GetCryptoPrices(ctx context.Context, symbols []s
 	}); err != nil {
 		s.logger.Error("Failed to get crypto prices from cache", "error", err, "symbols", symbols)
 		// Fallback to direct API call
-		return s.fetchCryptoPricesFromAPI(ctx, symbols)
+		prices, err := s.fetchCryptoPricesFromAPI(ctx, symbols)
+		if err != nil {
+			return nil, nil, err
+		}
+		s.enrichWithMetadata(ctx, prices)
+		return prices, unresolvedSymbols(symbols, prices), nil
 	}
 	
-	return cachedPrices, nil
+	s.enrichWithMetadata(ctx, cachedPrices)
+	return cachedPrices, unresolvedSymbols(symbols, cachedPrices), nil
+}
+
+// unresolvedSymbols returns the requested symbols missing from prices, upper-cased and
+// in request order
+func unresolvedSymbols(symbols []string, prices map[string]*entities.CryptoPrice) []string {
+	found := make(map[string]bool, len(prices))
+	for symbol := range prices {
+		found[strings.ToUpper(symbol)] = true
+	}
+
+	unresolved := []string{}
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		if !found[symbol] {
+			found[symbol] = true
+			unresolved = append(unresolved, symbol)
+		}
+	}
+	return unresolved
+}
+
+// enrichWithMetadata adds rank, slug and logo to prices. Metadata is cosmetic, so
+// lookup failures are logged and the prices are returned as they are.
+func (s *marketDataServiceImpl) enrichWithMetadata(ctx context.Context, prices map[string]*entities.CryptoPrice) {
+	if s.metadata == nil || len(prices) == 0 {
+		return
+	}
+
+	symbols := make([]string, 0, len(prices))
+	for symbol := range prices {
+		symbols = append(symbols, symbol)
+	}
+
+	metadata, err := s.metadata.GetMetadata(ctx, symbols)
+	if err != nil {
+		s.logger.Warn("Symbol metadata unavailable, returning prices without it", "error", err)
+		return
+	}
+
+	for symbol, price := range prices {
+		meta, ok := metadata[strings.ToUpper(symbol)]
+		if !ok {
+			continue
+		}
+		if price.Name == "" {
+			price.Name = meta.Name
+		}
+		price.Rank = meta.Rank
+		price.Slug = meta.Slug
+		price.LogoURL = meta.LogoURL
+	}
 }
 
-// fetchCryptoPricesFromAPI fetches prices directly from CoinMarketCap API
+// fetchCryptoPricesFromAPI fetches prices directly from CoinMarketCap API, or from every
+// configured price source in aggregated mode
 func (s *marketDataServiceImpl) fetchCryptoPricesFromAPI(ctx context.Context, symbols []string) (map[string]*entities.CryptoPrice, error) {
+	if s.priceConfig.Mode == PriceSourceModeAggregated {
+		return s.fetchAggregatedPrices(ctx, symbols)
+	}
+
 	s.logger.Info("Fetching crypto prices from CoinMarketCap API", "symbols", symbols)
 	
-	response, err := s.coinMarketCapClient.GetLatestQuotes(symbols, "USD")
+	if resetTime, limited := s.coinMarketCapBackoff(); limited {
+		if s.priceFallback != nil {
+			s.logger.Info("CoinMarketCap rate limited, using Binance", "reset_time", resetTime, "symbols", symbols)
+			return s.fetchCryptoPricesFromFallback(ctx, symbols)
+		}
+		return nil, errors.NewRateLimitError("CoinMarketCap", resetTime)
+	}
+
+	response, err := s.coinMarketCapClient.GetLatestQuotes(ctx, symbols, "USD")
 	if err != nil {
+		s.recordRateLimit(err)
+		if s.priceFallback != nil {
+			s.logger.Warn("CoinMarketCap quotes unavailable, falling back to Binance", "error", err, "symbols", symbols)
+			return s.fetchCryptoPricesFromFallback(ctx, symbols)
+		}
 		return nil, fmt.Errorf("failed to fetch quotes from CoinMarketCap: %w", err)
 	}
 	
@@ -77,7 +215,7 @@ func (s *marketDataServiceImpl) fetchCryptoPricesFromAPI(ctx context.Context, sy
 				PercentChange7d:  usdQuote.PercentChange7d,
 				PercentChange30d: usdQuote.PercentChange30d,
 				LastUpdated:      usdQuote.LastUpdated,
-				DataSource:       "CoinMarketCap",
+				DataSource:       entities.DataSourceCoinMarketCap,
 			}
 			prices[symbol] = price
 			
@@ -92,6 +230,63 @@ func (s *marketDataServiceImpl) fetchCryptoPricesFromAPI(ctx context.Context, sy
 	return prices, nil
 }
 
+// coinMarketCapBackoff reports whether CoinMarketCap is still rate limited and until when
+func (s *marketDataServiceImpl) coinMarketCapBackoff() (time.Time, bool) {
+	s.rateLimitMu.Lock()
+	defer s.rateLimitMu.Unlock()
+	return s.rateLimitedUntil, time.Now().Before(s.rateLimitedUntil)
+}
+
+// recordRateLimit starts a backoff when err is a CoinMarketCap rate limit error
+func (s *marketDataServiceImpl) recordRateLimit(err error) {
+	resetTime, ok := errors.RateLimitResetTime(err)
+	if !ok {
+		return
+	}
+
+	s.rateLimitMu.Lock()
+	defer s.rateLimitMu.Unlock()
+	if resetTime.After(s.rateLimitedUntil) {
+		s.rateLimitedUntil = resetTime
+	}
+	s.logger.Warn("CoinMarketCap rate limited, backing off", "reset_time", resetTime)
+}
+
+// fetchCryptoPricesFromFallback fetches spot prices against USDT from Binance, one pair per
+// request with up to priceConfig.Concurrency requests in flight.
+// Only the price is available; symbols without a USDT pair are skipped.
+func (s *marketDataServiceImpl) fetchCryptoPricesFromFallback(ctx context.Context, symbols []string) (map[string]*entities.CryptoPrice, error) {
+	values, errs := fetchPerSymbol(ctx, symbols, s.priceConfig.Concurrency, func(ctx context.Context, symbol string) (float64, error) {
+		return s.priceFallback.GetPrice(ctx, symbol+priceFallbackQuote)
+	})
+	for symbol, err := range errs {
+		s.logger.Warn("Binance price unavailable", "error", err, "symbol", symbol)
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("failed to fetch prices from Binance for %v: %s", symbols, joinSymbolErrors(errs))
+	}
+
+	now := time.Now()
+	prices := make(map[string]*entities.CryptoPrice, len(values))
+	for symbol, value := range values {
+		price := &entities.CryptoPrice{
+			Symbol:      symbol,
+			Price:       value,
+			LastUpdated: now,
+			DataSource:  entities.DataSourceBinance,
+		}
+		prices[symbol] = price
+
+		if err := s.repo.StorePriceData(ctx, price); err != nil {
+			s.logger.Warn("Failed to store price data", "error", err, "symbol", symbol)
+		}
+	}
+
+	s.logger.Info("Successfully fetched fallback crypto prices", "count", len(prices), "symbols", symbols)
+	return prices, nil
+}
+
 // GetBitcoinDominance retrieves Bitcoin dominance from multiple sources
 func (s *marketDataServiceImpl) GetBitcoinDominance(ctx context.Context) (*entities.BitcoinDominance, error) {
 	cacheKey := "bitcoin_dominance"
@@ -109,65 +304,34 @@ func (s *marketDataServiceImpl) GetBitcoinDominance(ctx context.Context) (*entit
 	return cachedDominance, nil
 }
 
-// fetchBitcoinDominanceFromSources fetches Bitcoin dominance from multiple sources
+// fetchBitcoinDominanceFromSources fetches Bitcoin dominance from the configured sources
 func (s *marketDataServiceImpl) fetchBitcoinDominanceFromSources(ctx context.Context) (*entities.BitcoinDominance, error) {
-	s.logger.Info("Fetching Bitcoin dominance from multiple sources")
+	s.logger.Info("Fetching Bitcoin dominance from multiple sources", "sources", s.dominanceConfig.Sources)
 	
-	var primaryDominance, secondaryDominance float64
-	var primarySource, secondarySource string
-	var primaryErr, secondaryErr error
+	var readings []dominanceReading
+	var sourceErrors []string
 	
-	// Try CoinMarketCap first
-	primaryDominance, primaryErr = s.coinMarketCapClient.GetBitcoinDominance()
-	if primaryErr == nil {
-		primarySource = "CoinMarketCap"
-		s.logger.Info("Got Bitcoin dominance from CoinMarketCap", "dominance", primaryDominance)
+	// Query sources in order of preference
+	for _, source := range s.dominanceConfig.Sources {
+		reading, err := s.fetchDominanceReading(ctx, source)
+		if err != nil {
+			s.logger.Warn("Failed to get Bitcoin dominance from source", "source", source, "error", err)
+			sourceErrors = append(sourceErrors, fmt.Sprintf("%s_error=%v", source, err))
+			continue
+		}
+		s.logger.Info("Got Bitcoin dominance from source", "source", reading.label, "dominance", reading.value)
+		readings = append(readings, *reading)
 	}
 	
-	// Try TradingView as secondary source
-	tvData, secondaryErr := s.tradingViewScraper.GetBitcoinDominanceWithFallback()
-	if secondaryErr == nil {
-		secondaryDominance = tvData.CurrentDominance
-		secondarySource = "TradingView"
-		s.logger.Info("Got Bitcoin dominance from TradingView", "dominance", secondaryDominance)
+	if len(readings) == 0 {
+		return nil, fmt.Errorf("failed to fetch Bitcoin dominance from any source: %s", strings.Join(sourceErrors, ", "))
 	}
 	
 	// Determine which source to use
-	var finalDominance float64
-	var finalSource string
-	var confidence float64 = 1.0
-	
-	if primaryErr == nil && secondaryErr == nil {
-		// Both sources available - compare and use average if close
-		diff := abs(primaryDominance - secondaryDominance)
-		if diff < 2.0 { // If difference is less than 2%, average them
-			finalDominance = (primaryDominance + secondaryDominance) / 2
-			finalSource = "CoinMarketCap + TradingView (averaged)"
-			confidence = 0.95
-			s.logger.Info("Using averaged Bitcoin dominance", 
-				"cmc_dominance", primaryDominance,
-				"tv_dominance", secondaryDominance,
-				"final_dominance", finalDominance)
-		} else {
-			// Large difference, prefer CoinMarketCap
-			finalDominance = primaryDominance
-			finalSource = primarySource
-			confidence = 0.8
-			s.logger.Warn("Large difference between dominance sources", 
-				"cmc_dominance", primaryDominance,
-				"tv_dominance", secondaryDominance,
-				"using", finalSource)
-		}
-	} else if primaryErr == nil {
-		finalDominance = primaryDominance
-		finalSource = primarySource
-		confidence = 0.9
-	} else if secondaryErr == nil {
-		finalDominance = secondaryDominance
-		finalSource = secondarySource
-		confidence = 0.85
-	} else {
-		return nil, fmt.Errorf("failed to fetch Bitcoin dominance from any source: cmc_error=%v, tv_error=%v", primaryErr, secondaryErr)
+	finalDominance, finalSource, sourceDetail, confidence := s.selectDominance(readings)
+	if confidence.Value < s.dominanceConfig.MinConfidence {
+		return nil, fmt.Errorf("Bitcoin dominance confidence %.2f from %s is below minimum %.2f",
+			confidence.Value, finalSource, s.dominanceConfig.MinConfidence)
 	}
 	
 	// Create dominance entity
@@ -178,33 +342,111 @@ func (s *marketDataServiceImpl) fetchBitcoinDominanceFromSources(ctx context.Con
 		ChangePercent24h:   0,  // Would need historical data
 		LastUpdated:        time.Now(),
 		DataSource:         finalSource,
-		Confidence:         confidence,
+		DataSourceDetail:   sourceDetail,
+		Confidence:         confidence.Value,
+		Metadata:           confidence.metadata(),
 	}
 	
 	// If we have TradingView data with change information, use it
-	if secondaryErr == nil && tvData.ChangePercent24h != 0 {
-		dominance.ChangePercent24h = tvData.ChangePercent24h
-		dominance.Change24h = tvData.Change24h
-		dominance.PreviousDominance = tvData.PreviousDominance
+	for _, reading := range readings {
+		if reading.changeData != nil && reading.changeData.ChangePercent24h != 0 {
+			dominance.ChangePercent24h = reading.changeData.ChangePercent24h
+			dominance.Change24h = reading.changeData.Change24h
+			dominance.PreviousDominance = reading.changeData.PreviousDominance
+			break
+		}
 	}
 	
-	// Store in database for historical tracking
-	if err := s.repo.StoreDominanceData(ctx, dominance); err != nil {
+	// Store in database for historical tracking, unless only fallback data was available;
+	// re-storing it would make a stale value look freshly fetched
+	if allFallbackReadings(readings) {
+		s.logger.Warn("Serving fallback Bitcoin dominance, not storing it", "source", finalSource)
+	} else if err := s.repo.StoreDominanceData(ctx, dominance); err != nil {
 		s.logger.Warn("Failed to store dominance data", "error", err)
 	}
 	
 	s.logger.Info("Successfully determined Bitcoin dominance", 
 		"dominance", finalDominance,
 		"source", finalSource,
-		"confidence", confidence)
+		"confidence", confidence.Value,
+		"confidence_reasons", confidence.Reasons)
 	
 	return dominance, nil
 }
 
+// fetchDominanceReading fetches Bitcoin dominance from a single named source
+func (s *marketDataServiceImpl) fetchDominanceReading(ctx context.Context, source string) (*dominanceReading, error) {
+	switch source {
+	case DominanceSourceCoinMarketCap:
+		value, err := s.coinMarketCapClient.GetBitcoinDominance(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &dominanceReading{source: source, label: entities.DataSourceCoinMarketCap, value: value}, nil
+	case DominanceSourceTradingView:
+		tvData, err := s.tradingViewScraper.GetBitcoinDominanceWithFallback(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The scraper may answer from CoinGecko or fallback data, so label by its actual source
+		return &dominanceReading{source: source, label: tvData.DataSource, value: tvData.CurrentDominance, changeData: tvData}, nil
+	default:
+		return nil, fmt.Errorf("unknown dominance source: %s", source)
+	}
+}
+
+// selectDominance combines the available readings according to the dominance source config
+// and scores the result, returning the value, its data source and, for an averaged value,
+// a detail naming the sources averaged. Readings must be ordered by preference and non-empty.
+func (s *marketDataServiceImpl) selectDominance(readings []dominanceReading) (float64, entities.DataSource, string, dominanceConfidence) {
+	preferred := readings[0]
+	if len(readings) == 1 {
+		return preferred.value, preferred.label, "", scoreDominanceConfidence(s.dominanceConfig, readings, false, time.Now())
+	}
+	
+	// Averaging disabled - trust the preferred source
+	if s.dominanceConfig.AveragingThreshold <= 0 {
+		return preferred.value, preferred.label, "", scoreDominanceConfidence(s.dominanceConfig, readings, false, time.Now())
+	}
+	
+	secondary := readings[1]
+	if abs(preferred.value-secondary.value) < s.dominanceConfig.AveragingThreshold {
+		finalDominance := (preferred.value + secondary.value) / 2
+		s.logger.Info("Using averaged Bitcoin dominance", 
+			"preferred_source", preferred.label,
+			"preferred_dominance", preferred.value,
+			"secondary_source", secondary.label,
+			"secondary_dominance", secondary.value,
+			"final_dominance", finalDominance)
+		return finalDominance, entities.DataSourceAggregated, fmt.Sprintf("%s + %s (averaged)", preferred.label, secondary.label),
+			scoreDominanceConfidence(s.dominanceConfig, readings, true, time.Now())
+	}
+	
+	// Large difference, prefer the first configured source
+	s.logger.Warn("Large difference between dominance sources", 
+		"preferred_source", preferred.label,
+		"preferred_dominance", preferred.value,
+		"secondary_source", secondary.label,
+		"secondary_dominance", secondary.value,
+		"using", preferred.label)
+	return preferred.value, preferred.label, "", scoreDominanceConfidence(s.dominanceConfig, readings, false, time.Now())
+}
+
+// allFallbackReadings reports whether every reading came from a source's fallback data
+func allFallbackReadings(readings []dominanceReading) bool {
+	for _, reading := range readings {
+		if reading.changeData == nil || !reading.changeData.IsFallback() {
+			return false
+		}
+	}
+	return true
+}
+
 // GetMultipleCryptoPrices is a convenience method for getting common crypto prices
 func (s *marketDataServiceImpl) GetMultipleCryptoPrices(ctx context.Context) (map[string]*entities.CryptoPrice, error) {
 	commonSymbols := []string{"BTC", "ETH", "BNB", "SOL", "ADA", "XRP", "DOT", "AVAX", "MATIC", "LINK"}
-	return s.GetCryptoPrices(ctx, commonSymbols)
+	prices, _, err := s.GetCryptoPrices(ctx, commonSymbols)
+	return prices, err
 }
 
 // GetTopCryptoPrices gets prices for top N cryptocurrencies by market cap
@@ -215,49 +457,159 @@ func (s *marketDataServiceImpl) GetTopCryptoPrices(ctx context.Context, count in
 	if count < len(symbols) {
 		symbols = symbols[:count]
 	}
-	return s.GetCryptoPrices(ctx, symbols)
+	prices, _, err := s.GetCryptoPrices(ctx, symbols)
+	return prices, err
 }
 
-// RefreshAllMarketData refreshes all market data from external sources
-func (s *marketDataServiceImpl) RefreshAllMarketData(ctx context.Context) error {
+// RefreshAllMarketData refreshes all market data from external sources. Every part is
+// attempted so one failing source doesn't leave the others stale; the refresh only fails
+// when nothing could be refreshed.
+func (s *marketDataServiceImpl) RefreshAllMarketData(ctx context.Context) (*entities.MarketDataRefresh, error) {
 	s.logger.Info("Refreshing all market data")
-	
-	// Refresh crypto prices
-	_, err := s.GetMultipleCryptoPrices(ctx)
+
+	tasks := []struct {
+		name    string
+		refresh func() error
+	}{
+		{"prices", func() error {
+			_, err := s.GetMultipleCryptoPrices(ctx)
+			return err
+		}},
+		{"dominance", func() error {
+			_, err := s.GetBitcoinDominance(ctx)
+			return err
+		}},
+		{"market_metrics", func() error {
+			_, err := s.RefreshMarketMetrics(ctx)
+			return err
+		}},
+	}
+
+	result := &entities.MarketDataRefresh{Refreshed: []string{}}
+	var failures []error
+	for _, task := range tasks {
+		if err := task.refresh(); err != nil {
+			s.logger.Error("Failed to refresh market data", "part", task.name, "error", err)
+			if result.Failed == nil {
+				result.Failed = make(map[string]string)
+			}
+			result.Failed[task.name] = err.Error()
+			failures = append(failures, fmt.Errorf("%s: %w", task.name, err))
+			continue
+		}
+		result.Refreshed = append(result.Refreshed, task.name)
+	}
+
+	if len(result.Refreshed) == 0 {
+		return result, fmt.Errorf("failed to refresh market data: %w", stderrors.Join(failures...))
+	}
+
+	if result.Partial() {
+		s.logger.Warn("Partially refreshed market data", "refreshed", result.Refreshed, "failed", result.Failed)
+	} else {
+		s.logger.Info("Successfully refreshed all market data")
+	}
+	return result, nil
+}
+
+// RefreshMarketMetrics fetches CoinMarketCap global metrics and stores them
+func (s *marketDataServiceImpl) RefreshMarketMetrics(ctx context.Context) (*entities.MarketMetrics, error) {
+	response, err := s.coinMarketCapClient.GetGlobalMetrics(ctx, "USD")
 	if err != nil {
-		s.logger.Error("Failed to refresh crypto prices", "error", err)
-		return fmt.Errorf("failed to refresh crypto prices: %w", err)
+		s.recordRateLimit(err)
+		if _, limited := errors.RateLimitResetTime(err); limited {
+			return nil, err
+		}
+		return nil, errors.External("CoinMarketCap", "failed to fetch global metrics", err)
 	}
-	
-	// Refresh Bitcoin dominance
-	_, err = s.GetBitcoinDominance(ctx)
+
+	metrics := marketMetricsFromGlobal(&response.Data)
+	if err := s.repo.SaveMarketMetrics(ctx, metrics); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Stored market metrics",
+		"total_market_cap", metrics.TotalMarketCap,
+		"bitcoin_dominance", metrics.BitcoinDominance)
+	return metrics, nil
+}
+
+// GetLatestMarketMetrics returns the stored market metrics, refreshing them when none are
+// stored or they are older than marketMetricsMaxAge. A failed refresh falls back to the
+// stale row.
+func (s *marketDataServiceImpl) GetLatestMarketMetrics(ctx context.Context) (*entities.MarketMetrics, error) {
+	latest, err := s.repo.GetLatestMarketMetrics(ctx)
+	if err != nil && !errors.IsType(err, errors.ErrorTypeNotFound) {
+		return nil, err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) <= marketMetricsMaxAge {
+		return latest, nil
+	}
+
+	refreshed, err := s.RefreshMarketMetrics(ctx)
 	if err != nil {
-		s.logger.Error("Failed to refresh Bitcoin dominance", "error", err)
-		return fmt.Errorf("failed to refresh Bitcoin dominance: %w", err)
+		if latest != nil {
+			s.logger.Warn("Failed to refresh market metrics, serving stored values", "error", err)
+			return latest, nil
+		}
+		return nil, err
 	}
-	
-	s.logger.Info("Successfully refreshed all market data")
-	return nil
+	return refreshed, nil
 }
 
-// HealthCheck performs health checks on all external data sources
-func (s *marketDataServiceImpl) HealthCheck(ctx context.Context) map[string]error {
-	results := make(map[string]error)
-	
-	// Check CoinMarketCap
-	if err := s.coinMarketCapClient.HealthCheck(); err != nil {
-		results["coinmarketcap"] = err
-	} else {
-		results["coinmarketcap"] = nil
+// marketMetricsFromGlobal maps CoinMarketCap global metrics into MarketMetrics using USD totals
+func marketMetricsFromGlobal(data *external.GlobalMetricsData) *entities.MarketMetrics {
+	metrics := &entities.MarketMetrics{
+		BitcoinDominance:       data.BtcDominance,
+		EthereumDominance:      data.EthDominance,
+		ActiveCryptocurrencies: data.ActiveCryptocurrencies,
+		ActiveExchanges:        data.ActiveExchanges,
+		LastUpdated:            data.LastUpdated,
+		DataSource:             entities.DataSourceCoinMarketCap,
 	}
-	
-	// Check TradingView scraper
-	if err := s.tradingViewScraper.HealthCheck(); err != nil {
-		results["tradingview"] = err
-	} else {
-		results["tradingview"] = nil
+
+	if quote, ok := data.Quote["USD"]; ok {
+		metrics.TotalMarketCap = quote.TotalMarketCap
+		metrics.TotalVolume24h = quote.TotalVolume24h
+		metrics.MarketCapChange24h = quote.TotalMarketCapYesterdayPercentageChange
+		metrics.VolumeChange24h = quote.TotalVolume24hYesterdayPercentageChange
 	}
-	
+
+	return metrics
+}
+
+// HealthCheck checks every configured external data source concurrently, so one slow
+// provider neither delays nor inflates the others' latency
+func (s *marketDataServiceImpl) HealthCheck(ctx context.Context) map[string]entities.ProviderHealthResult {
+	checks := make(map[string]func(context.Context) (time.Duration, error))
+	if s.coinMarketCapClient != nil {
+		checks["coinmarketcap"] = s.coinMarketCapClient.HealthCheck
+	}
+	if s.priceFallback != nil {
+		checks["binance"] = s.priceFallback.HealthCheck
+	}
+	if s.tradingViewScraper != nil {
+		checks["tradingview"] = s.tradingViewScraper.HealthCheck
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]entities.ProviderHealthResult, len(checks))
+	)
+	for provider, check := range checks {
+		wg.Add(1)
+		go func(provider string, check func(context.Context) (time.Duration, error)) {
+			defer wg.Done()
+			latency, err := check(ctx)
+
+			mu.Lock()
+			results[provider] = entities.ProviderHealthResult{Latency: latency, Err: err}
+			mu.Unlock()
+		}(provider, check)
+	}
+	wg.Wait()
+
 	return results
 }
 
@@ -0,0 +1,606 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newDominanceTestService(cfg DominanceSourceConfig) *marketDataServiceImpl {
+	return &marketDataServiceImpl{
+		dominanceConfig: cfg,
+		logger:          logger.New("test"),
+	}
+}
+
+func TestSelectDominance(t *testing.T) {
+	cmc := func(value float64) dominanceReading {
+		return dominanceReading{source: DominanceSourceCoinMarketCap, label: entities.DataSourceCoinMarketCap, value: value}
+	}
+	tv := func(value float64) dominanceReading {
+		return dominanceReading{source: DominanceSourceTradingView, label: entities.DataSourceTradingView, value: value}
+	}
+
+	tests := []struct {
+		name               string
+		config             DominanceSourceConfig
+		readings           []dominanceReading
+		expectedDominance  float64
+		expectedSource     entities.DataSource
+		expectedDetail     string
+		expectedConfidence float64
+	}{
+		{
+			name:               "Default config averages close readings",
+			config:             DefaultDominanceSourceConfig(),
+			readings:           []dominanceReading{cmc(59.0), tv(60.0)},
+			expectedDominance:  59.5,
+			expectedSource:     entities.DataSourceAggregated,
+			expectedDetail:     "coinmarketcap + tradingview (averaged)",
+			expectedConfidence: 0.8865,
+		},
+		{
+			name:               "Default config prefers CoinMarketCap on large difference",
+			config:             DefaultDominanceSourceConfig(),
+			readings:           []dominanceReading{cmc(55.0), tv(60.0)},
+			expectedDominance:  55.0,
+			expectedSource:     entities.DataSourceCoinMarketCap,
+			expectedConfidence: 0.45,
+		},
+		{
+			name: "TradingView preferred on large difference",
+			config: DominanceSourceConfig{
+				Sources:            []string{DominanceSourceTradingView, DominanceSourceCoinMarketCap},
+				AveragingThreshold: 2.0,
+			},
+			readings:           []dominanceReading{tv(60.0), cmc(55.0)},
+			expectedDominance:  60.0,
+			expectedSource:     entities.DataSourceTradingView,
+			expectedConfidence: 0.425,
+		},
+		{
+			name: "TradingView preferred averages close readings",
+			config: DominanceSourceConfig{
+				Sources:            []string{DominanceSourceTradingView, DominanceSourceCoinMarketCap},
+				AveragingThreshold: 2.0,
+			},
+			readings:           []dominanceReading{tv(60.0), cmc(59.0)},
+			expectedDominance:  59.5,
+			expectedSource:     entities.DataSourceAggregated,
+			expectedDetail:     "tradingview + coinmarketcap (averaged)",
+			expectedConfidence: 0.8865,
+		},
+		{
+			name: "Averaging disabled uses preferred source",
+			config: DominanceSourceConfig{
+				Sources:            []string{DominanceSourceCoinMarketCap, DominanceSourceTradingView},
+				AveragingThreshold: 0,
+			},
+			readings:           []dominanceReading{cmc(59.0), tv(59.5)},
+			expectedDominance:  59.0,
+			expectedSource:     entities.DataSourceCoinMarketCap,
+			expectedConfidence: 0.855,
+		},
+		{
+			name: "Averaging disabled with TradingView preferred",
+			config: DominanceSourceConfig{
+				Sources:            []string{DominanceSourceTradingView, DominanceSourceCoinMarketCap},
+				AveragingThreshold: 0,
+			},
+			readings:           []dominanceReading{tv(59.5), cmc(59.0)},
+			expectedDominance:  59.5,
+			expectedSource:     entities.DataSourceTradingView,
+			expectedConfidence: 0.8075,
+		},
+		{
+			name:               "Single reading",
+			config:             DefaultDominanceSourceConfig(),
+			readings:           []dominanceReading{tv(61.0)},
+			expectedDominance:  61.0,
+			expectedSource:     entities.DataSourceTradingView,
+			expectedConfidence: 0.765,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newDominanceTestService(tt.config)
+
+			dominance, source, detail, confidence := service.selectDominance(tt.readings)
+
+			assert.InDelta(t, tt.expectedDominance, dominance, 0.0001)
+			assert.Equal(t, tt.expectedSource, source)
+			assert.Equal(t, tt.expectedDetail, detail)
+			assert.InDelta(t, tt.expectedConfidence, confidence.Value, 0.0001)
+		})
+	}
+}
+
+func TestScoreDominanceConfidence(t *testing.T) {
+	now := time.Now()
+	cfg := DefaultDominanceSourceConfig()
+	reading := func(source string, value float64, age time.Duration, dataSource entities.DataSource) dominanceReading {
+		return dominanceReading{
+			source: source,
+			label:  dataSource,
+			value:  value,
+			changeData: &external.BitcoinDominanceData{
+				CurrentDominance: value,
+				LastUpdated:      now.Add(-age),
+				DataSource:       dataSource,
+			},
+		}
+	}
+	cmc := reading(DominanceSourceCoinMarketCap, 59.0, 0, entities.DataSourceCoinMarketCap)
+
+	agreeing := scoreDominanceConfidence(cfg, []dominanceReading{cmc, reading(DominanceSourceTradingView, 59.1, 0, entities.DataSourceTradingView)}, true, now)
+	alone := scoreDominanceConfidence(cfg, []dominanceReading{cmc}, false, now)
+	diverging := scoreDominanceConfidence(cfg, []dominanceReading{cmc, reading(DominanceSourceTradingView, 64.0, 0, entities.DataSourceTradingView)}, false, now)
+
+	assert.Greater(t, agreeing.Value, alone.Value, "agreeing sources beat a lone source")
+	assert.Greater(t, alone.Value, diverging.Value, "diverging sources are worse than a lone source")
+	assert.Equal(t, minDominanceAgreement, diverging.Agreement)
+
+	t.Run("stale readings lose confidence", func(t *testing.T) {
+		fresh := scoreDominanceConfidence(cfg, []dominanceReading{reading(DominanceSourceTradingView, 59.0, time.Minute, entities.DataSourceTradingView)}, false, now)
+		stale := scoreDominanceConfidence(cfg, []dominanceReading{reading(DominanceSourceTradingView, 59.0, 3*time.Hour, entities.DataSourceTradingView)}, false, now)
+
+		assert.Greater(t, fresh.Value, stale.Value)
+		assert.Equal(t, minDominanceFreshness, stale.Freshness)
+		assert.Contains(t, stale.Reasons[len(stale.Reasons)-1], "old")
+	})
+
+	t.Run("fallback data is capped", func(t *testing.T) {
+		fallback := scoreDominanceConfidence(cfg, []dominanceReading{reading(DominanceSourceTradingView, 59.0, 0, entities.DataSourceFallback)}, false, now)
+
+		assert.Equal(t, fallbackDominanceFreshness, fallback.Freshness)
+		assert.Less(t, fallback.Value, alone.Value)
+	})
+
+	t.Run("configured weights", func(t *testing.T) {
+		custom := DefaultDominanceSourceConfig()
+		custom.Weights = map[string]float64{DominanceSourceCoinMarketCap: 0.5}
+
+		scored := scoreDominanceConfidence(custom, []dominanceReading{cmc}, false, now)
+
+		assert.InDelta(t, 0.5, scored.Reliability, 0.0001)
+		assert.InDelta(t, 0.85, custom.Weight(DominanceSourceTradingView), 0.0001, "unlisted sources keep their default weight")
+	})
+
+	t.Run("metadata explains the score", func(t *testing.T) {
+		metadata := agreeing.metadata()
+
+		factors, ok := metadata["confidence_factors"].(map[string]float64)
+		require.True(t, ok)
+		assert.InDelta(t, agreeing.Value, factors["reliability"]*factors["agreement"]*factors["freshness"], 0.0001)
+		assert.NotEmpty(t, metadata["confidence_reasons"])
+	})
+}
+
+func TestFetchBitcoinDominanceFromSources_Config(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":{"error_code":0},"data":{"btc_dominance":58.5}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	cmcClient := external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log)
+
+	t.Run("Only configured sources are queried", func(t *testing.T) {
+		repo := &testutil.MockMarketDataRepository{}
+		repo.On("StoreDominanceData", mock.Anything, mock.Anything).Return(nil)
+
+		service := NewMarketDataService(repo, cmcClient, nil, testutil.NewMockCacheService(), log, MarketDataOptions{
+			Dominance: &DominanceSourceConfig{
+				Sources:            []string{DominanceSourceCoinMarketCap},
+				AveragingThreshold: 2.0,
+			},
+		}).(*marketDataServiceImpl)
+
+		dominance, err := service.fetchBitcoinDominanceFromSources(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 58.5, dominance.CurrentDominance)
+		assert.Equal(t, entities.DataSourceCoinMarketCap, dominance.DataSource)
+		assert.Empty(t, dominance.DataSourceDetail)
+		assert.InDelta(t, 0.81, dominance.Confidence, 0.0001)
+		assert.Contains(t, dominance.Metadata, "confidence_reasons")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Result below min confidence is rejected", func(t *testing.T) {
+		repo := &testutil.MockMarketDataRepository{}
+
+		service := NewMarketDataService(repo, cmcClient, nil, testutil.NewMockCacheService(), log, MarketDataOptions{
+			Dominance: &DominanceSourceConfig{
+				Sources:       []string{DominanceSourceCoinMarketCap},
+				MinConfidence: 0.95,
+			},
+		}).(*marketDataServiceImpl)
+
+		_, err := service.fetchBitcoinDominanceFromSources(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "below minimum")
+		repo.AssertNotCalled(t, "StoreDominanceData", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fallback dominance is served but not stored", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+
+		scraper := external.NewTradingViewScraperWithURLs(failing.URL, failing.URL, log)
+		scraper.SetFallback(nil, 57.0)
+		repo := &testutil.MockMarketDataRepository{}
+
+		service := NewMarketDataService(repo, cmcClient, scraper, testutil.NewMockCacheService(), log, MarketDataOptions{
+			Dominance: &DominanceSourceConfig{
+				Sources: []string{DominanceSourceTradingView},
+			},
+		}).(*marketDataServiceImpl)
+
+		dominance, err := service.fetchBitcoinDominanceFromSources(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 57.0, dominance.CurrentDominance)
+		assert.Equal(t, entities.DataSourceFallback, dominance.DataSource)
+		repo.AssertNotCalled(t, "StoreDominanceData", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown source fails", func(t *testing.T) {
+		service := NewMarketDataService(&testutil.MockMarketDataRepository{}, cmcClient, nil, testutil.NewMockCacheService(), log, MarketDataOptions{
+			Dominance: &DominanceSourceConfig{
+				Sources: []string{"unknown"},
+			},
+		}).(*marketDataServiceImpl)
+
+		_, err := service.fetchBitcoinDominanceFromSources(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown dominance source")
+	})
+}
+
+// stubPriceFallback serves fixed prices keyed by trading pair
+type stubPriceFallback map[string]float64
+
+func (s stubPriceFallback) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	if price, ok := s[symbol]; ok {
+		return price, nil
+	}
+	return 0, fmt.Errorf("unknown symbol %s", symbol)
+}
+
+func (s stubPriceFallback) HealthCheck(ctx context.Context) (time.Duration, error) {
+	return 0, nil
+}
+
+func TestFetchCryptoPricesFromAPI_BinanceFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"status":{"error_code":500,"error_message":"upstream down"}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	cmcClient := external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log)
+
+	t.Run("Prices come from the fallback when CoinMarketCap fails", func(t *testing.T) {
+		repo := &testutil.MockMarketDataRepository{}
+		repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+		fallback := stubPriceFallback{"BTCUSDT": 67712.34, "ETHUSDT": 3512.5}
+
+		service := NewMarketDataService(repo, cmcClient, nil, testutil.NewMockCacheService(), log,
+			MarketDataOptions{PriceFallback: fallback}).(*marketDataServiceImpl)
+
+		prices, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"BTC", "ETH", "USDT"})
+
+		require.NoError(t, err)
+		require.Len(t, prices, 2, "symbols without a USDT pair are skipped")
+		assert.Equal(t, 67712.34, prices["BTC"].Price)
+		assert.Equal(t, entities.DataSourceBinance, prices["BTC"].DataSource)
+		assert.Equal(t, 3512.5, prices["ETH"].Price)
+		repo.AssertNumberOfCalls(t, "StorePriceData", 2)
+	})
+
+	t.Run("Error without a fallback", func(t *testing.T) {
+		service := NewMarketDataService(&testutil.MockMarketDataRepository{}, cmcClient, nil, testutil.NewMockCacheService(), log,
+			MarketDataOptions{}).(*marketDataServiceImpl)
+
+		_, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"BTC"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CoinMarketCap")
+	})
+
+	t.Run("Error when the fallback has no prices either", func(t *testing.T) {
+		service := NewMarketDataService(&testutil.MockMarketDataRepository{}, cmcClient, nil, testutil.NewMockCacheService(), log,
+			MarketDataOptions{PriceFallback: stubPriceFallback{}}).(*marketDataServiceImpl)
+
+		_, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"BTC"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Binance")
+	})
+}
+
+func TestFetchCryptoPricesFromAPI_RateLimitBackoff(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"status":{"error_code":1008,"error_message":"rate limit"}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	cmcClient := external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log)
+
+	t.Run("Rate limit is surfaced and CoinMarketCap is not retried until reset", func(t *testing.T) {
+		requests = 0
+		service := NewMarketDataService(&testutil.MockMarketDataRepository{}, cmcClient, nil, testutil.NewMockCacheService(), log,
+			MarketDataOptions{}).(*marketDataServiceImpl)
+
+		for i := 0; i < 2; i++ {
+			_, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"BTC"})
+
+			resetTime, ok := errors.RateLimitResetTime(err)
+			require.True(t, ok, "call %d should return a rate limit error", i)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), resetTime, 2*time.Second)
+		}
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("Fallback serves prices during the backoff", func(t *testing.T) {
+		requests = 0
+		repo := &testutil.MockMarketDataRepository{}
+		repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+		service := NewMarketDataService(repo, cmcClient, nil, testutil.NewMockCacheService(), log,
+			MarketDataOptions{PriceFallback: stubPriceFallback{"BTCUSDT": 67712.34}}).(*marketDataServiceImpl)
+
+		for i := 0; i < 2; i++ {
+			prices, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"BTC"})
+
+			require.NoError(t, err)
+			assert.Equal(t, entities.DataSourceBinance, prices["BTC"].DataSource)
+		}
+		assert.Equal(t, 1, requests)
+	})
+}
+
+func TestGetCryptoPrices_UnresolvedSymbols(t *testing.T) {
+	// CoinMarketCap silently omits symbols it doesn't know
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":{"error_code":0},"data":{
+			"BTC":{"name":"Bitcoin","symbol":"BTC","quote":{"USD":{"price":67712.34}}},
+			"ETH":{"name":"Ethereum","symbol":"ETH","quote":{"USD":{"price":3512.5}}}}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+	service := NewMarketDataService(repo, external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log),
+		nil, cache.NewCacheService(nil, log), log, MarketDataOptions{})
+
+	// The second call is served from cache and must report the same gap
+	for i := 0; i < 2; i++ {
+		prices, unresolved, err := service.GetCryptoPrices(context.Background(), []string{"BTC", "NOTACOIN", "ETH"})
+
+		require.NoError(t, err)
+		assert.Len(t, prices, 2)
+		assert.Contains(t, prices, "BTC")
+		assert.Contains(t, prices, "ETH")
+		assert.Equal(t, []string{"NOTACOIN"}, unresolved)
+	}
+}
+
+func TestRefreshAllMarketData_PartialFailure(t *testing.T) {
+	// Quotes work but global metrics, the source of both dominance and market metrics, fail
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/global-metrics/quotes/latest" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"status":{"error_code":500,"error_message":"upstream down"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":{"error_code":0},"data":{
+			"BTC":{"name":"Bitcoin","symbol":"BTC","quote":{"USD":{"price":67712.34}}}}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+	dominanceConfig := DefaultDominanceSourceConfig()
+	dominanceConfig.Sources = []string{DominanceSourceCoinMarketCap}
+	service := NewMarketDataService(repo, external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log),
+		nil, cache.NewCacheService(nil, log), log, MarketDataOptions{Dominance: &dominanceConfig})
+
+	result, err := service.RefreshAllMarketData(context.Background())
+
+	require.NoError(t, err, "a partial refresh is not an error")
+	assert.True(t, result.Partial())
+	assert.Equal(t, []string{"prices"}, result.Refreshed)
+	assert.Contains(t, result.Failed, "dominance")
+	assert.Contains(t, result.Failed, "market_metrics")
+	assert.NotContains(t, result.Failed, "prices")
+}
+
+func TestRefreshAllMarketData_TotalFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"status":{"error_code":500,"error_message":"upstream down"}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	dominanceConfig := DefaultDominanceSourceConfig()
+	dominanceConfig.Sources = []string{DominanceSourceCoinMarketCap}
+	service := NewMarketDataService(&testutil.MockMarketDataRepository{}, external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log),
+		nil, cache.NewCacheService(nil, log), log, MarketDataOptions{Dominance: &dominanceConfig})
+
+	result, err := service.RefreshAllMarketData(context.Background())
+
+	require.Error(t, err)
+	assert.Empty(t, result.Refreshed)
+	assert.Len(t, result.Failed, 3)
+	for _, part := range []string{"prices", "dominance", "market_metrics"} {
+		assert.Contains(t, err.Error(), part)
+	}
+}
+
+// globalMetricsPayload is a trimmed CoinMarketCap /global-metrics/quotes/latest response
+const globalMetricsPayload = `{
+	"status": {"timestamp": "2024-05-01T12:00:00.000Z", "error_code": 0, "error_message": null},
+	"data": {
+		"active_cryptocurrencies": 9876,
+		"total_cryptocurrencies": 31000,
+		"active_market_pairs": 81234,
+		"active_exchanges": 765,
+		"total_exchanges": 8900,
+		"eth_dominance": 16.2345,
+		"btc_dominance": 54.1234,
+		"quote": {
+			"USD": {
+				"total_market_cap": 2345678901234.5,
+				"total_volume_24h": 87654321098.7,
+				"total_market_cap_yesterday_percentage_change": -1.25,
+				"total_volume_24h_yesterday_percentage_change": 12.5,
+				"altcoin_market_cap": 1076543210987.6,
+				"last_updated": "2024-05-01T11:59:00.000Z"
+			}
+		},
+		"last_updated": "2024-05-01T11:59:00.000Z"
+	}
+}`
+
+func TestRefreshMarketMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/global-metrics/quotes/latest", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, globalMetricsPayload)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	var stored *entities.MarketMetrics
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("SaveMarketMetrics", mock.Anything, mock.AnythingOfType("*entities.MarketMetrics")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entities.MarketMetrics) }).
+		Return(nil).Once()
+
+	service := NewMarketDataService(repo, external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log),
+		nil, testutil.NewMockCacheService(), log, MarketDataOptions{})
+
+	metrics, err := service.RefreshMarketMetrics(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Same(t, stored, metrics)
+
+	assert.Equal(t, 2345678901234.5, stored.TotalMarketCap)
+	assert.Equal(t, 87654321098.7, stored.TotalVolume24h)
+	assert.Equal(t, 54.1234, stored.BitcoinDominance)
+	assert.Equal(t, 16.2345, stored.EthereumDominance)
+	assert.Equal(t, 9876, stored.ActiveCryptocurrencies)
+	assert.Equal(t, 765, stored.ActiveExchanges)
+	assert.Equal(t, -1.25, stored.MarketCapChange24h)
+	assert.Equal(t, 12.5, stored.VolumeChange24h)
+	assert.Equal(t, time.Date(2024, 5, 1, 11, 59, 0, 0, time.UTC), stored.LastUpdated.UTC())
+	assert.Equal(t, entities.DataSourceCoinMarketCap, stored.DataSource)
+	repo.AssertExpectations(t)
+}
+
+func TestGetLatestMarketMetrics(t *testing.T) {
+	log := logger.New("test")
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"status":{"error_code":500,"error_message":"upstream down"}}`)
+	}))
+	defer server.Close()
+	cmcClient := external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log)
+
+	newService := func(latest *entities.MarketMetrics, err error) services.MarketDataService {
+		repo := &testutil.MockMarketDataRepository{}
+		repo.On("GetLatestMarketMetrics", mock.Anything).Return(latest, err)
+		return NewMarketDataService(repo, cmcClient, nil, testutil.NewMockCacheService(), log, MarketDataOptions{})
+	}
+
+	t.Run("Fresh stored metrics are served without fetching", func(t *testing.T) {
+		requests = 0
+		stored := &entities.MarketMetrics{TotalMarketCap: 2.3e12, CreatedAt: time.Now().Add(-time.Minute)}
+
+		metrics, err := newService(stored, nil).GetLatestMarketMetrics(context.Background())
+
+		require.NoError(t, err)
+		assert.Same(t, stored, metrics)
+		assert.Zero(t, requests)
+	})
+
+	t.Run("Stale metrics are served when the refresh fails", func(t *testing.T) {
+		requests = 0
+		stored := &entities.MarketMetrics{TotalMarketCap: 2.3e12, CreatedAt: time.Now().Add(-time.Hour)}
+
+		metrics, err := newService(stored, nil).GetLatestMarketMetrics(context.Background())
+
+		require.NoError(t, err)
+		assert.Same(t, stored, metrics)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("Error when nothing is stored and the refresh fails", func(t *testing.T) {
+		_, err := newService(nil, errors.NotFound("market_metrics")).GetLatestMarketMetrics(context.Background())
+
+		require.Error(t, err)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeExternal))
+	})
+}
+
+func TestHealthCheck_MeasuresLatency(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"status":{"error_code":500,"error_message":"upstream down"}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	slow := &slowPriceFallback{delay: 50 * time.Millisecond}
+	service := NewMarketDataService(&testutil.MockMarketDataRepository{}, external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log),
+		nil, testutil.NewMockCacheService(), log, MarketDataOptions{PriceFallback: slow})
+
+	results := service.HealthCheck(context.Background())
+
+	require.Len(t, results, 2, "unconfigured providers are not checked")
+
+	binance := results["binance"]
+	assert.NoError(t, binance.Err)
+	assert.GreaterOrEqual(t, binance.Latency, slow.delay)
+	assert.GreaterOrEqual(t, binance.LatencyMs(), 50.0)
+
+	cmc := results["coinmarketcap"]
+	assert.Error(t, cmc.Err, "failed checks still report their latency")
+	assert.Positive(t, cmc.Latency)
+	assert.Less(t, cmc.Latency, slow.delay, "a slow provider does not inflate the others' latency")
+}
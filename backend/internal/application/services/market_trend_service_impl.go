@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	marketTrendIndicatorName = "market_trend"
+	// marketTrendAssetCount is how many top assets by market cap the trend averages over,
+	// matching the default market summary
+	marketTrendAssetCount = 10
+	// marketTrendMaxAge is how long a stored classification is served before GetLatest
+	// recalculates it; the scheduled job stores one a day
+	marketTrendMaxAge = 24 * time.Hour
+
+	// marketTrendCalcVersion is stored with each classification; bump it when the asset
+	// count or thresholds change
+	marketTrendCalcVersion uint = 1
+)
+
+// TopPricesSource provides current prices of the top assets by market cap.
+// MarketDataService satisfies it.
+type TopPricesSource interface {
+	GetTopCryptoPrices(ctx context.Context, count int) (map[string]*entities.CryptoPrice, error)
+}
+
+// marketTrendServiceImpl implements the IndicatorService interface for the market trend:
+// the top assets' average 24h change, classified as bullish, bearish or sideways
+type marketTrendServiceImpl struct {
+	recomputeGuarded
+
+	prices        TopPricesSource
+	indicatorRepo repositories.IndicatorRepository
+	logger        logger.Logger
+}
+
+// NewMarketTrendService creates a new market trend service
+func NewMarketTrendService(
+	prices TopPricesSource,
+	indicatorRepo repositories.IndicatorRepository,
+	logger logger.Logger,
+) services.IndicatorService {
+	s := &marketTrendServiceImpl{
+		prices:        prices,
+		indicatorRepo: indicatorRepo,
+		logger:        logger,
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, marketTrendIndicatorName, marketTrendMaxAge, logger)
+	return s
+}
+
+// Calculate classifies the current market trend and stores the classification
+func (s *marketTrendServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	log.Info("Starting market trend calculation", "assets", marketTrendAssetCount)
+
+	prices, err := s.prices.GetTopCryptoPrices(ctx, marketTrendAssetCount)
+	if err != nil {
+		return nil, errors.External("market data", "failed to fetch prices for market trend", err)
+	}
+	avgChange, ok := entities.AverageChange24h(prices)
+	if !ok {
+		return nil, errors.External("market data", "no prices available for market trend", nil)
+	}
+
+	symbols := make([]string, 0, len(prices))
+	for symbol := range prices {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	trend := entities.ClassifyMarketTrend(avgChange)
+	riskLevel := "medium"
+	switch trend {
+	case entities.MarketTrendBullish:
+		riskLevel = "high"
+	case entities.MarketTrendBearish:
+		riskLevel = "low"
+	}
+
+	indicator := &entities.Indicator{
+		Name:        marketTrendIndicatorName,
+		Type:        "market",
+		Value:       avgChange,
+		StringValue: trend,
+		Change:      fmt.Sprintf("%+.2f%%", avgChange),
+		RiskLevel:   riskLevel,
+		Status:      fmt.Sprintf("Market %s: top %d assets average %+.2f%% over 24h", trend, len(prices), avgChange),
+		Description: fmt.Sprintf("Average 24h change of the top %d assets: bullish above +%.0f%%, bearish below -%.0f%%", marketTrendAssetCount, entities.MarketTrendThreshold, entities.MarketTrendThreshold),
+		Source:      entities.DataSourceMarketData,
+		Confidence:  0.8,
+		CalcVersion: marketTrendCalcVersion,
+		Timestamp:   time.Now(),
+		Metadata: map[string]interface{}{
+			"trend":          trend,
+			"avg_change_24h": avgChange,
+			"threshold":      entities.MarketTrendThreshold,
+			"asset_count":    len(prices),
+			"symbols":        symbols,
+		},
+	}
+
+	if s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save market trend indicator to database", "error", err)
+		}
+	}
+
+	return indicator, nil
+}
+
+// GetHistoricalData retrieves stored market trend classifications
+func (s *marketTrendServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical market trend data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, marketTrendIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored classification if it is under a day old, otherwise
+// recalculates it
+func (s *marketTrendServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, marketTrendIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > marketTrendMaxAge {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
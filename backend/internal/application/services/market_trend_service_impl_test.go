@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticTopPrices returns fixed top-asset prices
+type staticTopPrices struct {
+	prices map[string]*entities.CryptoPrice
+	err    error
+}
+
+func (s *staticTopPrices) GetTopCryptoPrices(ctx context.Context, count int) (map[string]*entities.CryptoPrice, error) {
+	return s.prices, s.err
+}
+
+// pricesWithChanges builds top-asset prices with the given 24h changes
+func pricesWithChanges(changes map[string]float64) map[string]*entities.CryptoPrice {
+	prices := make(map[string]*entities.CryptoPrice, len(changes))
+	for symbol, change := range changes {
+		prices[symbol] = &entities.CryptoPrice{Symbol: symbol, PercentChange24h: change}
+	}
+	return prices
+}
+
+func TestClassifyMarketTrend(t *testing.T) {
+	tests := []struct {
+		avgChange float64
+		trend     string
+	}{
+		{8.5, entities.MarketTrendBullish},
+		{3.01, entities.MarketTrendBullish},
+		{3, entities.MarketTrendSideways},
+		{0, entities.MarketTrendSideways},
+		{-3, entities.MarketTrendSideways},
+		{-3.01, entities.MarketTrendBearish},
+		{-12, entities.MarketTrendBearish},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.trend, entities.ClassifyMarketTrend(tt.avgChange), "average change %v", tt.avgChange)
+	}
+}
+
+func TestMarketTrendService_Calculate(t *testing.T) {
+	tests := []struct {
+		name      string
+		changes   map[string]float64
+		trend     string
+		avgChange float64
+	}{
+		{"bullish", map[string]float64{"BTC": 5, "ETH": 7, "SOL": 0}, entities.MarketTrendBullish, 4},
+		{"bearish", map[string]float64{"BTC": -4, "ETH": -6}, entities.MarketTrendBearish, -5},
+		{"sideways", map[string]float64{"BTC": 2, "ETH": -1}, entities.MarketTrendSideways, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &testutil.MockIndicatorRepository{}
+			repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Indicator")).Return(nil)
+			service := NewMarketTrendService(&staticTopPrices{prices: pricesWithChanges(tt.changes)}, repo, logger.New("test"))
+
+			indicator, err := service.Calculate(context.Background(), nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, marketTrendIndicatorName, indicator.Name)
+			assert.Equal(t, tt.trend, indicator.StringValue)
+			assert.InDelta(t, tt.avgChange, indicator.Value, 1e-9)
+			assert.Equal(t, tt.trend, indicator.Metadata["trend"])
+			assert.InDelta(t, tt.avgChange, indicator.Metadata["avg_change_24h"], 1e-9)
+			assert.Equal(t, len(tt.changes), indicator.Metadata["asset_count"])
+			repo.AssertCalled(t, "Create", mock.Anything, indicator)
+		})
+	}
+}
+
+func TestMarketTrendService_CalculateWithoutPrices(t *testing.T) {
+	repo := &testutil.MockIndicatorRepository{}
+	ctx := context.Background()
+
+	service := NewMarketTrendService(&staticTopPrices{err: errors.New("upstream down")}, repo, logger.New("test"))
+	_, err := service.Calculate(ctx, nil)
+	assert.Error(t, err)
+
+	service = NewMarketTrendService(&staticTopPrices{prices: map[string]*entities.CryptoPrice{}}, repo, logger.New("test"))
+	_, err = service.Calculate(ctx, nil)
+	assert.Error(t, err)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,48 @@
+package services
+
+import "crypto-indicator-dashboard/internal/domain/entities"
+
+// InsufficientDataMetadataKey flags an indicator computed from fewer data points than its
+// statistics need. Its value is kept but should be treated as provisional.
+const InsufficientDataMetadataKey = "insufficient_data"
+
+// insufficientDataConfidence caps the confidence of indicators computed from too few points,
+// matching the confidence given to fallback values
+const insufficientDataConfidence = 0.3
+
+// MinPointsGuard flags indicator values computed from fewer than MinPoints data points.
+// A MinPoints of 0 or less disables the guard.
+type MinPointsGuard struct {
+	MinPoints int
+}
+
+// requireMinPoints creates a guard requiring at least n data points
+func requireMinPoints(n int) MinPointsGuard {
+	return MinPointsGuard{MinPoints: n}
+}
+
+// apply reports whether points meets the minimum. When it doesn't, indicator is flagged
+// insufficient_data, records the point counts and has its confidence capped, rather than
+// passing off a noisy value as a confident one.
+func (g MinPointsGuard) apply(indicator *entities.Indicator, points int) bool {
+	if g.MinPoints <= 0 || points >= g.MinPoints {
+		return true
+	}
+
+	if indicator.Metadata == nil {
+		indicator.Metadata = make(map[string]interface{})
+	}
+	indicator.Metadata[InsufficientDataMetadataKey] = true
+	indicator.Metadata["data_points"] = points
+	indicator.Metadata["min_data_points"] = g.MinPoints
+	if indicator.Confidence > insufficientDataConfidence {
+		indicator.Confidence = insufficientDataConfidence
+	}
+	return false
+}
+
+// MinPointsGuarded is implemented by indicator services whose statistics need a minimum
+// number of data points
+type MinPointsGuarded interface {
+	SetMinDataPoints(n int)
+}
@@ -5,66 +5,113 @@ import (
 	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/internal/domain/repositories"
 	"crypto-indicator-dashboard/internal/domain/services"
-	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
 	"crypto-indicator-dashboard/pkg/errors"
 	"crypto-indicator-dashboard/pkg/logger"
-	"encoding/json"
-	"fmt"
-	"io"
 	"math"
-	"net/http"
+	"strconv"
 	"time"
 )
 
+const (
+	mvrvIndicatorName = "mvrv"
+	// mvrvMaxAge is how old the stored MVRV may get before GetLatest recalculates it
+	mvrvMaxAge = time.Hour
+	// mvrvHistoryWindow is the span of price history CalculateAt models the Z-Score over
+	mvrvHistoryWindow = 365 * 24 * time.Hour
+	// mvrvDefaultFallbackZScore is served when CoinGecko fails before any MVRV has been stored
+	mvrvDefaultFallbackZScore = 0.5
+	// mvrvCalcVersion identifies the MVRV calculation on stored values and in provenance;
+	// bump it when the modelling changes so stored values can be told apart
+	mvrvCalcVersion uint = 1
+	// mvrvCoinGeckoPath is the CoinGecko endpoint current BTC market data is read from
+	mvrvCoinGeckoPath = "/api/v3/coins/bitcoin"
+	// mvrvRateLimitRetries bounds how often a rate limited CoinGecko request is retried
+	mvrvRateLimitRetries = 2
+	// mvrvRateLimitWait is the retry delay when a 429 carries no Retry-After header
+	mvrvRateLimitWait = time.Second
+	// mvrvMaxRateLimitWait is the longest Retry-After honored; longer limits fall back at once
+	mvrvMaxRateLimitWait = 5 * time.Second
+	// mvrvMinDataPoints is the fewest daily points a Z-Score is trusted from
+	mvrvMinDataPoints = 30
+)
+
+// mvrvLegacyThresholdKeys maps the built-in band labels to the zscore_thresholds keys
+// published before the bands became configurable, so the metadata keeps its shape
+var mvrvLegacyThresholdKeys = map[string]string{
+	"below_average": "extreme_low",
+	"fair_value":    "low",
+	"above_average": "neutral_low",
+	"medium":        "neutral_high",
+}
+
+// mvrvCalculationVersion is mvrvCalcVersion as recorded in provenance
+var mvrvCalculationVersion = strconv.FormatUint(uint64(mvrvCalcVersion), 10)
+
 // mvrvServiceImpl implements the IndicatorService interface for MVRV calculations
 type mvrvServiceImpl struct {
+	recomputeGuarded
+
 	indicatorRepo  repositories.IndicatorRepository
 	marketDataRepo repositories.MarketDataRepository
-	cache          cache.CacheService
-	httpClient     *http.Client
+	cache          services.CacheService
+	coinGecko      *coinGeckoBitcoinClient
 	logger         logger.Logger
-	baseURL        string // Configurable base URL for testing
+	outliers       OutlierRejectionConfig
+	events         *extremeBandTracker
+	configs        services.IndicatorConfigProvider
+	minPoints      MinPointsGuard
 }
 
 // NewMVRVService creates a new MVRV service implementation
 func NewMVRVService(
 	indicatorRepo repositories.IndicatorRepository,
 	marketDataRepo repositories.MarketDataRepository,
-	cache cache.CacheService,
+	cache services.CacheService,
 	logger logger.Logger,
 ) services.IndicatorService {
-	return NewMVRVServiceWithBaseURL(indicatorRepo, marketDataRepo, cache, logger, "https://api.coingecko.com")
+	return NewMVRVServiceWithBaseURL(indicatorRepo, marketDataRepo, cache, logger, external.CoinGeckoBaseURL)
 }
 
 // NewMVRVServiceWithBaseURL creates a new MVRV service with configurable base URL (for testing)
 func NewMVRVServiceWithBaseURL(
 	indicatorRepo repositories.IndicatorRepository,
 	marketDataRepo repositories.MarketDataRepository,
-	cache cache.CacheService,
+	cache services.CacheService,
 	logger logger.Logger,
 	baseURL string,
 ) services.IndicatorService {
-	return &mvrvServiceImpl{
+	s := &mvrvServiceImpl{
 		indicatorRepo:  indicatorRepo,
 		marketDataRepo: marketDataRepo,
 		cache:          cache,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger:  logger,
-		baseURL: baseURL,
+		coinGecko: newCoinGeckoBitcoinClient(baseURL, logger),
+		logger:    logger,
+		events:    newExtremeBandTracker(nil, logger),
+		minPoints: requireMinPoints(mvrvMinDataPoints),
 	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, mvrvIndicatorName, mvrvMaxAge, logger)
+	return s
 }
 
-// Calculate computes the MVRV Z-Score indicator
+// Calculate computes the MVRV Z-Score indicator. It returns the context's error as soon as
+// the context is done, before fetching, before falling back and before saving, so a
+// cancelled request does not keep working or store a result nobody waits for.
 func (s *mvrvServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	if err := s.checkCancelled(ctx, "fetch"); err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("Starting MVRV Z-Score calculation")
 
 	// Try to fetch real Bitcoin data
 	btcData, err := s.fetchBitcoinData(ctx)
 	if err != nil {
+		if cancelErr := s.checkCancelled(ctx, "fallback"); cancelErr != nil {
+			return nil, cancelErr
+		}
 		s.logger.Error("Failed to fetch Bitcoin data", "error", err)
-		return s.getFallbackMVRVResult(), nil
+		return s.getFallbackMVRVResult(ctx, err), nil
 	}
 
 	s.logger.Info("Successfully fetched Bitcoin data", 
@@ -76,68 +123,109 @@ func (s *mvrvServiceImpl) Calculate(ctx context.Context, params map[string]inter
 	s.logger.Info("Generated historical data points", "count", len(historicalData))
 
 	// Calculate current MVRV metrics
-	currentMVRV := s.calculateCurrentMVRV(btcData, historicalData)
+	currentMVRV := currentMVRVData(btcData, historicalData)
 	s.logger.Info("Current metrics calculated", 
 		"price", currentMVRV.Price, 
 		"mvrv_ratio", currentMVRV.MVRVRatio, 
 		"z_score", currentMVRV.MVRVZScore)
 
-	// Assess risk level based on Z-Score
-	riskLevel, status := s.assessMVRVRisk(currentMVRV.MVRVZScore)
-
-	// Create indicator entity
-	indicator := &entities.Indicator{
-		Name:        "mvrv",
-		Type:        "market",
-		Value:       currentMVRV.MVRVZScore,
-		Status:      status,
-		RiskLevel:   riskLevel,
-		Confidence:  0.85, // High confidence for MVRV calculations
-		Timestamp:   time.Now(),
-		Metadata: map[string]interface{}{
-			"mvrv_ratio":       currentMVRV.MVRVRatio,
-			"market_cap":       currentMVRV.MarketCap,
-			"realized_cap":     currentMVRV.RealizedCap,
-			"price":            currentMVRV.Price,
-			"z_score":          currentMVRV.MVRVZScore,
-			"historical_data":  historicalData,
-			"zscore_thresholds": s.getZScoreThresholds(),
+	indicator := s.newMVRVIndicator(currentMVRV, historicalData, time.Now())
+	indicator.SetProvenance(entities.IndicatorProvenance{
+		Sources: []string{s.coinGecko.baseURL + mvrvCoinGeckoPath},
+		Inputs: map[string]interface{}{
+			"price":              btcData.MarketData.CurrentPrice.USD,
+			"market_cap":         btcData.MarketData.MarketCap.USD,
+			"circulating_supply": btcData.MarketData.CirculatingSupply,
+			"history_points":     len(historicalData),
 		},
+		CalculationVersion: mvrvCalculationVersion,
+	})
+
+	if err := s.checkCancelled(ctx, "save"); err != nil {
+		return nil, err
 	}
 
-	// Save to database if available
+	// Save to database if available, skipping metadata that would break stored history readers
 	if s.indicatorRepo != nil {
-		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+		if err := validateIndicatorMetadata(indicator); err != nil {
+			s.logger.Error("Not saving MVRV indicator with invalid metadata", "error", err)
+		} else if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
 			s.logger.Warn("Failed to save MVRV indicator to database", "error", err)
 		}
 	}
 
+	s.events.observe(ctx, indicator)
 	return indicator, nil
 }
 
-// GetHistoricalData retrieves historical MVRV data
-func (s *mvrvServiceImpl) GetHistoricalData(ctx context.Context, period string) ([]entities.Indicator, error) {
-	s.logger.Debug("Retrieving historical MVRV data", "period", period)
-
-	var from time.Time
-	switch period {
-	case "7d":
-		from = time.Now().AddDate(0, 0, -7)
-	case "30d":
-		from = time.Now().AddDate(0, 0, -30)
-	case "90d":
-		from = time.Now().AddDate(0, 0, -90)
-	case "1y":
-		from = time.Now().AddDate(-1, 0, 0)
-	default:
-		from = time.Now().AddDate(0, 0, -30)
+// checkCancelled returns the context's error, logging the step that was skipped, once the
+// context is done
+func (s *mvrvServiceImpl) checkCancelled(ctx context.Context, step string) error {
+	err := ctx.Err()
+	if err != nil {
+		s.logger.WithContext(ctx).Info("MVRV calculation cancelled", "before", step, "error", err)
+	}
+	return err
+}
+
+// CalculateAt computes the MVRV Z-Score as of a past date from stored BTC price history
+// and persists it with that timestamp. On-chain realized cap is not stored, so the realized
+// price at each day is approximated by the running average price over the trailing year.
+func (s *mvrvServiceImpl) CalculateAt(ctx context.Context, at time.Time, params map[string]interface{}) (*entities.Indicator, error) {
+	if at.After(time.Now()) {
+		return nil, errors.Validation("calculation date cannot be in the future", at.Format(time.RFC3339))
+	}
+	if s.marketDataRepo == nil {
+		return nil, errors.Internal("BTC price history is not available", nil)
+	}
+
+	s.logger.Info("Starting historical MVRV Z-Score calculation", "at", at)
+
+	history, err := s.marketDataRepo.GetPriceHistory(ctx, "BTC", at.Add(-mvrvHistoryWindow), at)
+	if err != nil {
+		return nil, err
+	}
+
+	historicalData := s.buildMVRVDataFromPrices(history)
+	if len(historicalData) == 0 {
+		return nil, errors.NotFound("BTC price history before " + at.Format("2006-01-02"))
 	}
 
+	indicator := s.newMVRVIndicator(&historicalData[len(historicalData)-1], historicalData, at)
+	indicator.Source = entities.DataSourcePriceHistory
+	indicator.Metadata["as_of"] = at
+	indicator.SetProvenance(entities.IndicatorProvenance{
+		Sources: []string{string(entities.DataSourcePriceHistory)},
+		Inputs: map[string]interface{}{
+			"price":        historicalData[len(historicalData)-1].Price,
+			"price_points": len(historicalData),
+			"from":         at.Add(-mvrvHistoryWindow),
+			"to":           at,
+		},
+		CalculationVersion: mvrvCalculationVersion,
+	})
+
+	if s.indicatorRepo != nil {
+		if err := validateIndicatorMetadata(indicator); err != nil {
+			return nil, err
+		}
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			return nil, errors.Internal("failed to store historical MVRV indicator", err)
+		}
+	}
+
+	return indicator, nil
+}
+
+// GetHistoricalData retrieves historical MVRV data
+func (s *mvrvServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical MVRV data", "from", window.From, "to", window.To)
+
 	if s.indicatorRepo == nil {
 		return []entities.Indicator{}, nil
 	}
 
-	return s.indicatorRepo.GetHistoricalData(ctx, "mvrv", from, time.Now())
+	return s.indicatorRepo.GetHistoricalData(ctx, "mvrv", window.From, window.To)
 }
 
 // GetLatest retrieves the most recent MVRV calculation
@@ -148,86 +236,161 @@ func (s *mvrvServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, e
 		return s.Calculate(ctx, nil)
 	}
 
-	indicator, err := s.indicatorRepo.GetLatest(ctx, "mvrv")
+	indicator, err := s.indicatorRepo.GetLatest(ctx, mvrvIndicatorName)
 	if err != nil {
 		if errors.IsType(err, errors.ErrorTypeNotFound) {
 			// Calculate fresh if not found
-			return s.Calculate(ctx, nil)
+			return s.recalculate(ctx, nil)
 		}
 		return nil, err
 	}
 
 	// Check if data is stale (older than 1 hour)
-	if time.Since(indicator.Timestamp) > time.Hour {
+	if time.Since(indicator.Timestamp) > mvrvMaxAge {
 		s.logger.Info("MVRV data is stale, recalculating")
-		return s.Calculate(ctx, nil)
+		return s.recalculate(ctx, indicator)
 	}
 
 	return indicator, nil
 }
 
+// SetOutlierRejection sets how outlying MVRV ratios are handled when computing the mean
+// and standard deviation Z-Scores are measured against
+func (s *mvrvServiceImpl) SetOutlierRejection(cfg OutlierRejectionConfig) {
+	s.outliers = cfg
+}
+
+// SetIndicatorConfigs sets where the Z-Score bands and description are read from; without
+// it the built-in defaults apply
+func (s *mvrvServiceImpl) SetIndicatorConfigs(provider services.IndicatorConfigProvider) {
+	s.configs = provider
+}
+
+// SetMinDataPoints sets the fewest history points a Z-Score is trusted from; 0 disables
+// the check
+func (s *mvrvServiceImpl) SetMinDataPoints(n int) {
+	s.minPoints = requireMinPoints(n)
+}
+
+// SetEventPublisher sets where extreme band entry events are published
+func (s *mvrvServiceImpl) SetEventPublisher(publisher services.EventPublisher) {
+	s.events.setPublisher(publisher)
+}
+
 // fetchBitcoinData gets current Bitcoin market data from CoinGecko with caching
 func (s *mvrvServiceImpl) fetchBitcoinData(ctx context.Context) (*CoinGeckoBitcoinData, error) {
 	cacheKey := "bitcoin_market_data"
 	var btcData CoinGeckoBitcoinData
+	log := s.logger.WithContext(ctx)
 
-	s.logger.Debug("Fetching Bitcoin data from CoinGecko")
+	log.Debug("Fetching Bitcoin data from CoinGecko")
 
 	// Try to get from cache first (5 minute cache)
-	err := s.cache.GetOrSet(ctx, cacheKey, &btcData, func() (interface{}, error) {
-		url := s.baseURL + "/api/v3/coins/bitcoin?localization=false&tickers=false&market_data=true&community_data=false&developer_data=false&sparkline=false"
+	err := s.cache.GetOrSet(ctx, cacheKey, &btcData, 5*time.Minute, func() (interface{}, error) {
+		return s.coinGecko.requestBitcoinData(ctx)
+	})
 
-		s.logger.Debug("Making HTTP request to CoinGecko")
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		req.Header.Set("User-Agent", "CryptoIndicatorDashboard/1.0")
+	log.Debug("Final Bitcoin data", 
+		"price", btcData.MarketData.CurrentPrice.USD, 
+		"market_cap", btcData.MarketData.MarketCap.USD)
 
-		resp, err := s.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
+	return &btcData, nil
+}
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode)
-		}
+// newMVRVIndicator builds the MVRV indicator entity for the given metrics, timestamped at
+func (s *mvrvServiceImpl) newMVRVIndicator(current *MVRVData, historicalData []MVRVData, at time.Time) *entities.Indicator {
+	// Assess risk level based on Z-Score
+	config := s.indicatorConfig()
+	band := config.Classify(current.MVRVZScore)
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+	indicator := &entities.Indicator{
+		Name:        "mvrv",
+		Type:        "market",
+		Value:       current.MVRVZScore,
+		Status:      band.Status,
+		RiskLevel:   band.RiskLevel,
+		Description: config.Description,
+		Confidence:  0.85, // High confidence for MVRV calculations
+		CalcVersion: mvrvCalcVersion,
+		Timestamp:   at,
+		Metadata: map[string]interface{}{
+			"mvrv_ratio":       current.MVRVRatio,
+			"market_cap":       current.MarketCap,
+			"realized_cap":     current.RealizedCap,
+			"price":            current.Price,
+			"z_score":          current.MVRVZScore,
+			"historical_data":  historicalData,
+			"zscore_thresholds": zScoreThresholds(config),
+		},
+	}
+
+	// Bands only exist when there was enough history to compute Z-Scores from
+	if bands, ok := s.calculateRatioBands(historicalData); ok {
+		indicator.Metadata["ratio_bands"] = bands
+		if s.outliers.Enabled() {
+			indicator.Metadata["outlier_rejection"] = string(s.outliers.Mode)
+			indicator.Metadata["outliers_trimmed"] = bands.OutliersTrimmed
 		}
+	}
 
-		s.logger.Debug("Received data from API", "bytes", len(body))
+	if !s.minPoints.apply(indicator, len(historicalData)) {
+		s.logger.Warn("MVRV Z-Score computed from too little history",
+			"points", len(historicalData), "min_points", s.minPoints.MinPoints)
+	}
 
-		var freshData CoinGeckoBitcoinData
-		if err := json.Unmarshal(body, &freshData); err != nil {
-			s.logger.Error("JSON unmarshal error", "error", err)
-			return nil, err
+	return indicator
+}
+
+// buildMVRVDataFromPrices converts stored BTC prices (oldest first) into MVRV data points,
+// using the running average price as the realized price
+func (s *mvrvServiceImpl) buildMVRVDataFromPrices(prices []entities.CryptoPrice) []MVRVData {
+	data := make([]MVRVData, 0, len(prices))
+	priceSum := 0.0
+	for _, p := range prices {
+		if p.Price <= 0 {
+			continue
 		}
+		priceSum += p.Price
+		realizedPrice := priceSum / float64(len(data)+1)
 
-		s.logger.Debug("Parsed API data", 
-			"price", freshData.MarketData.CurrentPrice.USD, 
-			"market_cap", freshData.MarketData.MarketCap.USD)
+		date := p.LastUpdated
+		if date.IsZero() {
+			date = p.CreatedAt
+		}
 
-		return freshData, nil
-	}, 5*time.Minute)
+		supply := 0.0
+		if p.MarketCap > 0 {
+			supply = p.MarketCap / p.Price
+		}
 
-	if err != nil {
-		return nil, err
+		data = append(data, MVRVData{
+			Date:        date,
+			Price:       p.Price,
+			MarketCap:   p.MarketCap,
+			RealizedCap: realizedPrice * supply,
+			MVRVRatio:   p.Price / realizedPrice,
+			CircSupply:  supply,
+		})
 	}
 
-	s.logger.Debug("Final Bitcoin data", 
-		"price", btcData.MarketData.CurrentPrice.USD, 
-		"market_cap", btcData.MarketData.MarketCap.USD)
-
-	return &btcData, nil
+	s.calculateZScores(data)
+	return data
 }
 
-// generateHistoricalMVRVData creates simulated historical MVRV data
+// generateHistoricalMVRVData creates simulated historical MVRV data with Z-Scores
 func (s *mvrvServiceImpl) generateHistoricalMVRVData(currentData *CoinGeckoBitcoinData) []MVRVData {
+	data := simulateMVRVHistory(currentData)
+	s.calculateZScores(data)
+	return data
+}
+
+// simulateMVRVHistory models a year of daily prices and realized caps from the current market
+// data, leaving the Z-Scores unset. Its last point is today's realized cap estimate.
+func simulateMVRVHistory(currentData *CoinGeckoBitcoinData) []MVRVData {
 	var data []MVRVData
 	currentPrice := currentData.MarketData.CurrentPrice.USD
 	currentMarketCap := currentData.MarketData.MarketCap.USD
@@ -285,14 +448,11 @@ func (s *mvrvServiceImpl) generateHistoricalMVRVData(currentData *CoinGeckoBitco
 		})
 	}
 
-	// Calculate Z-Scores for all data points
-	s.calculateZScores(data)
-
 	return data
 }
 
-// calculateCurrentMVRV computes the current MVRV metrics
-func (s *mvrvServiceImpl) calculateCurrentMVRV(btcData *CoinGeckoBitcoinData, historicalData []MVRVData) *MVRVData {
+// currentMVRVData computes the current MVRV metrics
+func currentMVRVData(btcData *CoinGeckoBitcoinData, historicalData []MVRVData) *MVRVData {
 	if len(historicalData) == 0 {
 		// Calculate real current MVRV using live Bitcoin data
 		currentPrice := btcData.MarketData.CurrentPrice.USD
@@ -325,12 +485,10 @@ func (s *mvrvServiceImpl) calculateCurrentMVRV(btcData *CoinGeckoBitcoinData, hi
 	return &current
 }
 
-// calculateZScores computes Z-Scores for MVRV ratios
-func (s *mvrvServiceImpl) calculateZScores(data []MVRVData) {
-	if len(data) < 2 {
-		return
-	}
-
+// calculateRatioBands computes the mean and standard deviation of the valid MVRV ratios in
+// data, the statistics Z-Scores are measured against, after outlier rejection when it is
+// enabled. It reports false when fewer than two ratios remain.
+func (s *mvrvServiceImpl) calculateRatioBands(data []MVRVData) (MVRVBands, bool) {
 	// Extract MVRV ratios and filter out invalid values
 	var ratios []float64
 	for _, d := range data {
@@ -339,7 +497,32 @@ func (s *mvrvServiceImpl) calculateZScores(data []MVRVData) {
 		}
 	}
 
+	ratios, outliers := rejectOutliers(ratios, s.outliers)
 	if len(ratios) < 2 {
+		return MVRVBands{}, false
+	}
+
+	mean := s.calculateMean(ratios)
+	stdDev := s.calculateStdDev(ratios, mean)
+	return MVRVBands{
+		Mean:            mean,
+		StdDev:          stdDev,
+		Minus2SD:        mean - 2*stdDev,
+		Minus1SD:        mean - stdDev,
+		Plus1SD:         mean + stdDev,
+		Plus2SD:         mean + 2*stdDev,
+		OutliersTrimmed: outliers,
+	}, true
+}
+
+// calculateZScores computes Z-Scores for MVRV ratios
+func (s *mvrvServiceImpl) calculateZScores(data []MVRVData) {
+	if len(data) < 2 {
+		return
+	}
+
+	bands, ok := s.calculateRatioBands(data)
+	if !ok {
 		// If we don't have enough valid ratios, use default values
 		for i := range data {
 			data[i].MVRVZScore = 0.0 // Neutral Z-score
@@ -347,9 +530,7 @@ func (s *mvrvServiceImpl) calculateZScores(data []MVRVData) {
 		return
 	}
 
-	// Calculate mean and standard deviation
-	mean := s.calculateMean(ratios)
-	stdDev := s.calculateStdDev(ratios, mean)
+	mean, stdDev := bands.Mean, bands.StdDev
 
 	// Calculate Z-Scores with safety checks
 	for i := range data {
@@ -396,69 +577,101 @@ func (s *mvrvServiceImpl) calculateStdDev(values []float64, mean float64) float6
 	return math.Sqrt(variance)
 }
 
-// assessMVRVRisk determines risk level based on Z-Score
+// assessMVRVRisk determines risk level and status from the Z-Score band it falls in
 func (s *mvrvServiceImpl) assessMVRVRisk(zScore float64) (string, string) {
-	var riskLevel, status string
-
-	switch {
-	case zScore >= 7.0:
-		riskLevel = "extreme_high"
-		status = "EXTREME: Historically top of cycle - Strong sell signal"
-	case zScore >= 3.0:
-		riskLevel = "high"
-		status = "HIGH: Approaching cycle top - Consider taking profits"
-	case zScore >= 1.5:
-		riskLevel = "medium"
-		status = "MEDIUM: Testing resistance - Monitor closely"
-	case zScore >= 0.5:
-		riskLevel = "low"
-		status = "LOW: Above average valuation - Neutral zone"
-	case zScore >= -0.5:
-		riskLevel = "low"
-		status = "LOW: Fair value range - Accumulation zone"
-	case zScore >= -1.5:
-		riskLevel = "low"
-		status = "LOW: Below average - Good buying opportunity"
-	default:
-		riskLevel = "extreme_low"
-		status = "EXTREME: Historically bottom of cycle - Strong buy signal"
-	}
-
-	return riskLevel, status
+	band := s.indicatorConfig().Classify(zScore)
+	return band.RiskLevel, band.Status
 }
 
-// getZScoreThresholds returns the Z-score thresholds
+// getZScoreThresholds returns the Z-Score band boundaries in effect
 func (s *mvrvServiceImpl) getZScoreThresholds() map[string]float64 {
-	return map[string]float64{
-		"extreme_low":  -1.5,
-		"low":          -0.5,
-		"neutral_low":   0.5,
-		"neutral_high":  1.5,
-		"high":          3.0,
-		"extreme_high":  7.0,
+	return zScoreThresholds(s.indicatorConfig())
+}
+
+// zScoreThresholds returns each band's lower bound keyed by band label, with the built-in
+// labels renamed to their mvrvLegacyThresholdKeys
+func zScoreThresholds(config entities.IndicatorConfig) map[string]float64 {
+	thresholds := config.Thresholds()
+	for label, key := range mvrvLegacyThresholdKeys {
+		if min, ok := thresholds[label]; ok {
+			delete(thresholds, label)
+			thresholds[key] = min
+		}
 	}
+	return thresholds
 }
 
-// getFallbackMVRVResult returns a fallback result when API is unavailable
-func (s *mvrvServiceImpl) getFallbackMVRVResult() *entities.Indicator {
-	return &entities.Indicator{
-		Name:      "mvrv",
-		Type:      "market",
-		Value:     0.5,
-		Status:    "Using fallback data - external API unavailable",
-		RiskLevel: "low",
-		Confidence: 0.3, // Low confidence for fallback data
-		Timestamp: time.Now(),
+// indicatorConfig returns the MVRV bands and description in effect
+func (s *mvrvServiceImpl) indicatorConfig() entities.IndicatorConfig {
+	if s.configs != nil {
+		if config, ok := s.configs.IndicatorConfig(mvrvIndicatorName); ok {
+			return config
+		}
+	}
+	config, _ := entities.DefaultIndicatorConfig(mvrvIndicatorName)
+	return config
+}
+
+// getFallbackMVRVResult returns the last stored MVRV when the API is unavailable, keeping its
+// timestamp so callers can see how old it is. Only when nothing has been stored does it fall
+// back to a neutral default Z-score.
+func (s *mvrvServiceImpl) getFallbackMVRVResult(ctx context.Context, cause error) *entities.Indicator {
+	reason := "CoinGecko request failed"
+	if cause != nil {
+		reason += ": " + cause.Error()
+	}
+
+	if s.indicatorRepo != nil {
+		stored, err := s.indicatorRepo.GetLatest(ctx, mvrvIndicatorName)
+		if err == nil {
+			fallback := *stored
+			fallback.Status = "Using last stored value - external API unavailable"
+			fallback.Metadata = make(map[string]interface{}, len(stored.Metadata)+2)
+			for key, value := range stored.Metadata {
+				fallback.Metadata[key] = value
+			}
+			fallback.Metadata["fallback"] = true
+			fallback.Metadata["fallback_source"] = "last_stored"
+
+			// Keep the stored value's sources and inputs, flagged as a fallback
+			provenance := entities.IndicatorProvenance{CalculationVersion: mvrvCalculationVersion}
+			if storedProvenance, ok := stored.Provenance(); ok {
+				provenance = *storedProvenance
+			}
+			provenance.Fallback = true
+			provenance.FallbackReason = reason
+			fallback.SetProvenance(provenance)
+			return &fallback
+		}
+		if !errors.IsType(err, errors.ErrorTypeNotFound) {
+			s.logger.Warn("Failed to load last stored MVRV for fallback", "error", err)
+		}
+	}
+
+	indicator := &entities.Indicator{
+		Name:        mvrvIndicatorName,
+		Type:        "market",
+		Value:       mvrvDefaultFallbackZScore,
+		Status:      "Using fallback data - external API unavailable",
+		RiskLevel:   "low",
+		Confidence:  0.3, // Low confidence for fallback data
+		Timestamp:   time.Now(),
+		CalcVersion: mvrvCalcVersion,
 		Metadata: map[string]interface{}{
-			"mvrv_ratio":       1.2,
-			"market_cap":       850000000000.0,
-			"realized_cap":     708333333333.0,
-			"price":            43000.0,
-			"z_score":          0.5,
+			"z_score":           mvrvDefaultFallbackZScore,
 			"zscore_thresholds": s.getZScoreThresholds(),
-			"fallback":         true,
+			"fallback":          true,
+			"fallback_source":   "default",
 		},
 	}
+	indicator.SetProvenance(entities.IndicatorProvenance{
+		Sources:            []string{},
+		Inputs:             map[string]interface{}{"z_score": mvrvDefaultFallbackZScore},
+		CalculationVersion: mvrvCalculationVersion,
+		Fallback:           true,
+		FallbackReason:     reason,
+	})
+	return indicator
 }
 
 // Data structures for API responses
@@ -472,6 +685,21 @@ type CoinGeckoBitcoinData struct {
 		} `json:"market_cap"`
 		CirculatingSupply float64 `json:"circulating_supply"`
 	} `json:"market_data"`
+	// LastUpdated is when CoinGecko last refreshed the coin's data
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// MVRVBands are the mean and standard deviation of the MVRV ratios Z-Scores are measured
+// against, and the ratios one and two standard deviations either side of the mean
+type MVRVBands struct {
+	Mean     float64 `json:"mean"`
+	StdDev   float64 `json:"std_dev"`
+	Minus2SD float64 `json:"minus_2sd"`
+	Minus1SD float64 `json:"minus_1sd"`
+	Plus1SD  float64 `json:"plus_1sd"`
+	Plus2SD  float64 `json:"plus_2sd"`
+	// OutliersTrimmed counts the ratios dropped or winsorized before computing the bands
+	OutliersTrimmed int `json:"-"`
 }
 
 type MVRVData struct {
@@ -3,10 +3,13 @@ package services
 import (
 	"context"
 	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
 	"crypto-indicator-dashboard/internal/testutil"
 	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,7 +27,7 @@ type MVRVServiceTestSuite struct {
 	service           *mvrvServiceImpl
 	mockIndicatorRepo *testutil.MockIndicatorRepository
 	mockMarketRepo    *testutil.MockMarketDataRepository
-	mockCache         *testutil.MockInfrastructureCacheService
+	mockCache         *testutil.MockCacheService
 	testData          *testutil.TestData
 	server            *httptest.Server
 }
@@ -32,7 +35,7 @@ type MVRVServiceTestSuite struct {
 func (suite *MVRVServiceTestSuite) SetupTest() {
 	suite.mockIndicatorRepo = &testutil.MockIndicatorRepository{}
 	suite.mockMarketRepo = &testutil.MockMarketDataRepository{}
-	suite.mockCache = testutil.NewMockInfrastructureCacheService()
+	suite.mockCache = testutil.NewMockCacheService()
 	suite.testData = testutil.NewTestData()
 
 	// Create test HTTP server
@@ -147,12 +150,47 @@ func (suite *MVRVServiceTestSuite) TestCalculate_Success() {
 	suite.mockIndicatorRepo.AssertExpectations(suite.T())
 }
 
+func (suite *MVRVServiceTestSuite) TestCalculate_PersistsProvenance() {
+	ctx := context.Background()
+
+	// Let the cache fetch from the mock CoinGecko server
+	suite.mockCache.On("GetOrSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Run(func(args mock.Arguments) {
+		value, err := args.Get(4).(func() (interface{}, error))()
+		require.NoError(suite.T(), err)
+		*args.Get(2).(*CoinGeckoBitcoinData) = *value.(*CoinGeckoBitcoinData)
+	})
+
+	// Persist through JSON as the repository's metadata serializer does
+	var stored entities.Indicator
+	suite.mockIndicatorRepo.On("Create", ctx, mock.AnythingOfType("*entities.Indicator")).Return(nil).Run(func(args mock.Arguments) {
+		raw, err := json.Marshal(args.Get(1))
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), json.Unmarshal(raw, &stored))
+	})
+
+	_, err := suite.service.Calculate(ctx, nil)
+	require.NoError(suite.T(), err)
+
+	provenance, ok := stored.Provenance()
+	require.True(suite.T(), ok, "stored MVRV should carry provenance")
+	assert.Equal(suite.T(), []string{suite.server.URL + "/api/v3/coins/bitcoin"}, provenance.Sources)
+	assert.Equal(suite.T(), mvrvCalculationVersion, provenance.CalculationVersion)
+	assert.Equal(suite.T(), mvrvCalcVersion, stored.CalcVersion, "stored MVRV should carry the current calculation version")
+	assert.False(suite.T(), provenance.Fallback)
+	assert.Equal(suite.T(), stored.Metadata["price"], provenance.Inputs["price"])
+	assert.Equal(suite.T(), stored.Metadata["market_cap"], provenance.Inputs["market_cap"])
+	assert.Contains(suite.T(), provenance.Inputs, "circulating_supply")
+}
+
 func (suite *MVRVServiceTestSuite) TestCalculate_APIFailure() {
 	ctx := context.Background()
 
 	// Mock cache miss
 	suite.mockCache.On("GetOrSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(fmt.Errorf("API unavailable"))
+	// Nothing stored yet, so the default fallback is served
+	suite.mockIndicatorRepo.On("GetLatest", ctx, "mvrv").Return(nil, errors.NotFound("Indicator"))
 
 	// Execute test
 	result, err := suite.service.Calculate(ctx, nil)
@@ -167,8 +205,155 @@ func (suite *MVRVServiceTestSuite) TestCalculate_APIFailure() {
 	assert.Equal(suite.T(), float64(0.3), result.Confidence) // Low confidence for fallback
 	assert.Contains(suite.T(), result.Metadata, "fallback")
 	assert.True(suite.T(), result.Metadata["fallback"].(bool))
+	assert.Equal(suite.T(), "default", result.Metadata["fallback_source"])
+	assert.NotContains(suite.T(), result.Metadata, "price")
+	provenance, ok := result.Provenance()
+	require.True(suite.T(), ok)
+	assert.True(suite.T(), provenance.Fallback)
+	assert.Contains(suite.T(), provenance.FallbackReason, "API unavailable")
 
 	// No database save expected for fallback - it returns the data directly
+	suite.mockIndicatorRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *MVRVServiceTestSuite) TestCalculate_CancelledBeforeSave() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Mark the data as cached so the mock cache does not call the fetcher after Run
+	suite.mockCache.On("Set", mock.Anything, "bitcoin_market_data", mock.Anything, mock.Anything).Return(nil)
+	require.NoError(suite.T(), suite.mockCache.Set(ctx, "bitcoin_market_data", true, time.Minute))
+
+	// The request is cancelled once the Bitcoin data has been fetched
+	suite.mockCache.On("GetOrSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Run(func(args mock.Arguments) {
+		body := `{"market_data":{"current_price":{"usd":43000},"market_cap":{"usd":850000000000},"circulating_supply":19800000}}`
+		require.NoError(suite.T(), json.Unmarshal([]byte(body), args.Get(2)))
+		cancel()
+	})
+
+	result, err := suite.service.Calculate(ctx, nil)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Nil(suite.T(), result)
+	suite.mockIndicatorRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *MVRVServiceTestSuite) TestCalculate_CancelledBeforeFetch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := suite.service.Calculate(ctx, nil)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Nil(suite.T(), result)
+	suite.mockCache.AssertNotCalled(suite.T(), "GetOrSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockIndicatorRepo.AssertNotCalled(suite.T(), "GetLatest", mock.Anything, mock.Anything)
+}
+
+// rateLimitedServer answers the first limitedRequests CoinGecko requests with 429 and the
+// given Retry-After, then serves the suite's mock data; requests counts every request
+func (suite *MVRVServiceTestSuite) rateLimitedServer(limitedRequests int, retryAfter string, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if *requests <= limitedRequests {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		suite.handleBitcoinDataRequest(w, r)
+	}))
+}
+
+func (suite *MVRVServiceTestSuite) TestCalculate_RateLimitedOnceRetries() {
+	ctx := context.Background()
+	requests := 0
+	server := suite.rateLimitedServer(1, "0", &requests)
+	defer server.Close()
+	suite.service.coinGecko.baseURL = server.URL
+
+	// The mock cache calls the fetcher again after Run, so count the requests of this fetch only
+	fetchRequests := 0
+	suite.mockCache.On("GetOrSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Run(func(args mock.Arguments) {
+		value, err := args.Get(4).(func() (interface{}, error))()
+		require.NoError(suite.T(), err)
+		fetchRequests = requests
+		*args.Get(2).(*CoinGeckoBitcoinData) = *value.(*CoinGeckoBitcoinData)
+	})
+	suite.mockIndicatorRepo.On("Create", ctx, mock.AnythingOfType("*entities.Indicator")).Return(nil)
+
+	result, err := suite.service.Calculate(ctx, nil)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, fetchRequests, "the rate limited request should be retried once")
+	assert.NotContains(suite.T(), result.Metadata, "fallback", "a retried request should give a real result")
+	assert.Equal(suite.T(), 43000.0, result.Metadata["price"])
+	assert.Greater(suite.T(), result.Confidence, 0.3)
+	suite.mockIndicatorRepo.AssertCalled(suite.T(), "Create", ctx, mock.AnythingOfType("*entities.Indicator"))
+}
+
+func (suite *MVRVServiceTestSuite) TestRequestBitcoinData_RateLimitRetriesAreBounded() {
+	ctx := context.Background()
+
+	suite.Run("persistent limit gives up after the retries", func() {
+		requests := 0
+		server := suite.rateLimitedServer(10, "0", &requests)
+		defer server.Close()
+		suite.service.coinGecko.baseURL = server.URL
+
+		_, err := suite.service.coinGecko.requestBitcoinData(ctx)
+		require.Error(suite.T(), err)
+		assert.Equal(suite.T(), 1+mvrvRateLimitRetries, requests)
+	})
+
+	suite.Run("long Retry-After is not waited for", func() {
+		requests := 0
+		server := suite.rateLimitedServer(1, "120", &requests)
+		defer server.Close()
+		suite.service.coinGecko.baseURL = server.URL
+
+		started := time.Now()
+		_, err := suite.service.coinGecko.requestBitcoinData(ctx)
+		require.Error(suite.T(), err)
+		assert.Equal(suite.T(), 1, requests)
+		assert.Less(suite.T(), time.Since(started), mvrvMaxRateLimitWait)
+	})
+}
+
+func (suite *MVRVServiceTestSuite) TestCalculate_APIFailure_UsesLastStored() {
+	ctx := context.Background()
+	storedAt := time.Now().Add(-6 * time.Hour)
+	stored := &entities.Indicator{
+		Name:       "mvrv",
+		Type:       "market",
+		Value:      2.4,
+		RiskLevel:  "medium",
+		Confidence: 0.85,
+		Timestamp:  storedAt,
+		Metadata:   map[string]interface{}{"mvrv_ratio": 2.1, "price": 67000.0, "z_score": 2.4},
+	}
+
+	suite.mockCache.On("GetOrSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("API unavailable"))
+	suite.mockIndicatorRepo.On("GetLatest", ctx, "mvrv").Return(stored, nil)
+
+	result, err := suite.service.Calculate(ctx, nil)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2.4, result.Value)
+	assert.Equal(suite.T(), "medium", result.RiskLevel)
+	assert.Equal(suite.T(), storedAt, result.Timestamp)
+	assert.Equal(suite.T(), 67000.0, result.Metadata["price"])
+	assert.Equal(suite.T(), true, result.Metadata["fallback"])
+	assert.Equal(suite.T(), "last_stored", result.Metadata["fallback_source"])
+	provenance, ok := result.Provenance()
+	require.True(suite.T(), ok)
+	assert.True(suite.T(), provenance.Fallback)
+	// The stored indicator itself is left untouched
+	assert.NotContains(suite.T(), stored.Metadata, "fallback")
+	assert.NotContains(suite.T(), stored.Metadata, entities.ProvenanceMetadataKey)
+	suite.mockIndicatorRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
 }
 
 func (suite *MVRVServiceTestSuite) TestGetLatest_DatabaseHit() {
@@ -244,7 +429,7 @@ func (suite *MVRVServiceTestSuite) TestGetHistoricalData_Success() {
 		return t.Before(to.Add(time.Minute)) && t.After(to.Add(-time.Minute))
 	})).Return(expectedData, nil)
 
-	result, err := suite.service.GetHistoricalData(ctx, period)
+	result, err := suite.service.GetHistoricalData(ctx, entities.PeriodRange(period, time.Now(), nil))
 
 	require.NoError(suite.T(), err)
 	assert.Len(suite.T(), result, 2)
@@ -304,6 +489,46 @@ func (suite *MVRVServiceTestSuite) TestCalculateZScores_Success() {
 	assert.InDelta(suite.T(), 0.0, meanZScore, 0.1, "Mean Z-Score should be close to 0")
 }
 
+func (suite *MVRVServiceTestSuite) TestCalculateRatioBands_BracketRatios() {
+	ratios := []float64{0.8, 1.1, 1.2, 1.4, 1.5, 1.6, 1.9, 2.4, 3.6}
+	data := make([]MVRVData, len(ratios))
+	for i, ratio := range ratios {
+		data[i] = MVRVData{Date: time.Now().AddDate(0, 0, i-len(ratios)), MVRVRatio: ratio}
+	}
+	suite.service.calculateZScores(data)
+
+	bands, ok := suite.service.calculateRatioBands(data)
+	require.True(suite.T(), ok)
+	assert.Less(suite.T(), bands.Minus2SD, bands.Minus1SD)
+	assert.Less(suite.T(), bands.Minus1SD, bands.Mean)
+	assert.Less(suite.T(), bands.Mean, bands.Plus1SD)
+	assert.Less(suite.T(), bands.Plus1SD, bands.Plus2SD)
+
+	outside1SD := 0
+	for _, d := range data {
+		// Bands and Z-Scores come from the same mean and standard deviation
+		assert.InDelta(suite.T(), d.MVRVRatio, bands.Mean+d.MVRVZScore*bands.StdDev, 1e-9)
+
+		within1SD := d.MVRVRatio >= bands.Minus1SD && d.MVRVRatio <= bands.Plus1SD
+		assert.Equal(suite.T(), math.Abs(d.MVRVZScore) <= 1, within1SD, "ratio %.2f, z %.2f", d.MVRVRatio, d.MVRVZScore)
+		within2SD := d.MVRVRatio >= bands.Minus2SD && d.MVRVRatio <= bands.Plus2SD
+		assert.Equal(suite.T(), math.Abs(d.MVRVZScore) <= 2, within2SD, "ratio %.2f, z %.2f", d.MVRVRatio, d.MVRVZScore)
+		if !within1SD {
+			outside1SD++
+		}
+	}
+	// The spread-out sample should exercise both sides of the inner band
+	assert.Greater(suite.T(), outside1SD, 0)
+	assert.Less(suite.T(), outside1SD, len(data))
+
+	indicator := suite.service.newMVRVIndicator(&data[len(data)-1], data, time.Now())
+	assert.Equal(suite.T(), bands, indicator.Metadata["ratio_bands"])
+	require.NoError(suite.T(), validateIndicatorMetadata(indicator))
+
+	withoutHistory := suite.service.newMVRVIndicator(&data[len(data)-1], nil, time.Now())
+	assert.NotContains(suite.T(), withoutHistory.Metadata, "ratio_bands")
+}
+
 func (suite *MVRVServiceTestSuite) TestCalculateZScores_EdgeCases() {
 	testCases := []struct {
 		name     string
@@ -392,7 +617,7 @@ func BenchmarkMVRVCalculate(b *testing.B) {
 	// Set up test dependencies
 	mockIndicatorRepo := &testutil.MockIndicatorRepository{}
 	mockMarketRepo := &testutil.MockMarketDataRepository{}
-	mockCache := testutil.NewMockInfrastructureCacheService()
+	mockCache := testutil.NewMockCacheService()
 	testDB := testutil.NewTestDB(&testing.T{})
 	defer testDB.Cleanup()
 
@@ -454,4 +679,238 @@ func isNaN(f float64) bool {
 
 func isInf(f float64) bool {
 	return f > 1e308 || f < -1e308
-}
\ No newline at end of file
+}
+
+// dailyBTCPrices returns days of BTC prices ending at end, oldest first, rising $100 a day
+func dailyBTCPrices(end time.Time, days int) []entities.CryptoPrice {
+	prices := make([]entities.CryptoPrice, days)
+	for i := range prices {
+		price := 20000.0 + float64(i)*100
+		prices[i] = entities.CryptoPrice{
+			Symbol:      "BTC",
+			Price:       price,
+			MarketCap:   price * 19_000_000,
+			LastUpdated: end.AddDate(0, 0, i-days+1),
+		}
+	}
+	return prices
+}
+
+func TestMVRVService_CalculateAt(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	log := testutil.NewTestDB(t).Logger
+
+	indicatorRepo := &testutil.MockIndicatorRepository{}
+	indicatorRepo.On("Create", ctx, mock.AnythingOfType("*entities.Indicator")).Return(nil)
+	marketRepo := &testutil.MockMarketDataRepository{}
+	marketRepo.On("GetPriceHistory", ctx, "BTC", at.Add(-mvrvHistoryWindow), at).Return(dailyBTCPrices(at, 365), nil)
+
+	service := NewMVRVService(indicatorRepo, marketRepo, testutil.NewMockCacheService(), log)
+	historical, ok := service.(services.HistoricalIndicatorService)
+	require.True(t, ok, "MVRV service should support historical calculation")
+
+	indicator, err := historical.CalculateAt(ctx, at, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, at, indicator.Timestamp)
+	assert.Equal(t, entities.DataSourcePriceHistory, indicator.Source)
+	assert.Equal(t, 56400.0, indicator.Metadata["price"])
+	// A steadily rising price sits above its running average
+	assert.Greater(t, indicator.Metadata["mvrv_ratio"].(float64), 1.0)
+	assert.Greater(t, indicator.Value, 1.0)
+
+	indicatorRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(stored *entities.Indicator) bool {
+		return stored.Name == "mvrv" && stored.Timestamp.Equal(at)
+	}))
+	marketRepo.AssertExpectations(t)
+}
+
+func TestMVRVService_CalculateAt_Errors(t *testing.T) {
+	ctx := context.Background()
+	log := testutil.NewTestDB(t).Logger
+
+	t.Run("Future dates are rejected", func(t *testing.T) {
+		service := NewMVRVService(nil, &testutil.MockMarketDataRepository{}, nil, log).(*mvrvServiceImpl)
+
+		_, err := service.CalculateAt(ctx, time.Now().Add(time.Hour), nil)
+
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+	})
+
+	t.Run("No price history", func(t *testing.T) {
+		at := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+		marketRepo := &testutil.MockMarketDataRepository{}
+		marketRepo.On("GetPriceHistory", ctx, "BTC", mock.Anything, at).Return([]entities.CryptoPrice{}, nil)
+		service := NewMVRVService(nil, marketRepo, nil, log).(*mvrvServiceImpl)
+
+		_, err := service.CalculateAt(ctx, at, nil)
+
+		assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+	})
+}
+
+func TestMVRVMetadataSchema(t *testing.T) {
+	service := &mvrvServiceImpl{logger: testutil.NewTestDB(t).Logger}
+	current := &MVRVData{Price: 43000, MarketCap: 850e9, RealizedCap: 700e9, MVRVRatio: 1.21, MVRVZScore: 0.8}
+
+	valid := service.newMVRVIndicator(current, nil, time.Now())
+	require.NoError(t, validateIndicatorMetadata(valid))
+
+	t.Run("missing field", func(t *testing.T) {
+		indicator := service.newMVRVIndicator(current, nil, time.Now())
+		delete(indicator.Metadata, "realized_cap")
+
+		err := validateIndicatorMetadata(indicator)
+		require.Error(t, err)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+		assert.Contains(t, err.(*errors.AppError).Details, "realized_cap is required")
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		indicator := service.newMVRVIndicator(current, nil, time.Now())
+		indicator.Metadata["z_score"] = "0.8"
+
+		err := validateIndicatorMetadata(indicator)
+		require.Error(t, err)
+		assert.Contains(t, err.(*errors.AppError).Details, "z_score must be a number, got string")
+	})
+}
+
+func TestMVRVService_OutlierRejection(t *testing.T) {
+	// A year of ratios oscillating around 1.5, then one corrupt extreme reading
+	data := make([]MVRVData, 0, 366)
+	for i := 0; i < 365; i++ {
+		data = append(data, MVRVData{MVRVRatio: 1.5 + 0.3*math.Sin(float64(i)/20)})
+	}
+	clean := &mvrvServiceImpl{}
+	cleanBands, ok := clean.calculateRatioBands(data)
+	require.True(t, ok)
+
+	data = append(data, MVRVData{MVRVRatio: 500})
+
+	untrimmed, ok := clean.calculateRatioBands(data)
+	require.True(t, ok)
+	assert.Zero(t, untrimmed.OutliersTrimmed)
+	assert.Greater(t, untrimmed.StdDev, 10*cleanBands.StdDev, "a single outlier dominates the untrimmed std dev")
+
+	for _, mode := range []OutlierMode{OutlierTrim, OutlierWinsorize} {
+		t.Run(string(mode), func(t *testing.T) {
+			service := &mvrvServiceImpl{}
+			service.SetOutlierRejection(OutlierRejectionConfig{Mode: mode})
+
+			bands, ok := service.calculateRatioBands(data)
+			require.True(t, ok)
+			assert.Equal(t, 1, bands.OutliersTrimmed)
+			assert.InDelta(t, cleanBands.StdDev, bands.StdDev, 0.05*cleanBands.StdDev)
+			assert.InDelta(t, cleanBands.Mean, bands.Mean, 0.01)
+
+			indicator := service.newMVRVIndicator(&data[len(data)-1], data, time.Now())
+			assert.Equal(t, 1, indicator.Metadata["outliers_trimmed"])
+			assert.Equal(t, string(mode), indicator.Metadata["outlier_rejection"])
+		})
+	}
+
+	// Without rejection the metadata stays as before
+	indicator := clean.newMVRVIndicator(&data[len(data)-1], data, time.Now())
+	assert.NotContains(t, indicator.Metadata, "outliers_trimmed")
+}
+
+func TestMVRVService_CustomThresholds(t *testing.T) {
+	configs := NewIndicatorConfigService(&memoryIndicatorConfigRepository{}, logger.New("test"))
+	service := &mvrvServiceImpl{}
+
+	// Defaults apply until a config is set
+	riskLevel, _ := service.assessMVRVRisk(4.0)
+	assert.Equal(t, "high", riskLevel)
+	riskLevel, _ = service.assessMVRVRisk(-1.0)
+	assert.Equal(t, "low", riskLevel)
+	// The default thresholds keep the keys published before bands were configurable
+	assert.Equal(t, map[string]float64{
+		"extreme_low":  -1.5,
+		"low":          -0.5,
+		"neutral_low":  0.5,
+		"neutral_high": 1.5,
+		"high":         3.0,
+		"extreme_high": 7.0,
+	}, service.getZScoreThresholds())
+
+	service.SetIndicatorConfigs(configs)
+	_, err := configs.Update(context.Background(), mvrvIndicatorName, entities.IndicatorConfig{
+		Description: "Tighter MVRV bands",
+		Bands: []entities.IndicatorBand{
+			{Label: "euphoria", Min: floatPtr(3.5), RiskLevel: "extreme_high", Status: "Euphoria"},
+			{Label: "neutral", Min: floatPtr(-0.75), RiskLevel: "medium", Status: "Neutral"},
+			{Label: "capitulation", RiskLevel: "extreme_low", Status: "Capitulation"},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		zScore float64
+		risk   string
+		status string
+	}{
+		{4.0, "extreme_high", "Euphoria"},
+		{3.5, "extreme_high", "Euphoria"},
+		{2.0, "medium", "Neutral"},
+		{-0.75, "medium", "Neutral"},
+		{-1.0, "extreme_low", "Capitulation"},
+	}
+	for _, tt := range tests {
+		riskLevel, status := service.assessMVRVRisk(tt.zScore)
+		assert.Equal(t, tt.risk, riskLevel, "z-score %v", tt.zScore)
+		assert.Equal(t, tt.status, status, "z-score %v", tt.zScore)
+	}
+
+	indicator := service.newMVRVIndicator(&MVRVData{MVRVZScore: 4.0}, nil, time.Now())
+	assert.Equal(t, "extreme_high", indicator.RiskLevel)
+	assert.Equal(t, "Tighter MVRV bands", indicator.Description)
+	assert.Equal(t, map[string]float64{"euphoria": 3.5, "neutral": -0.75}, indicator.Metadata["zscore_thresholds"])
+}
+
+func TestMVRVService_MinDataPoints(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	log := testutil.NewTestDB(t).Logger
+
+	calculate := func(t *testing.T, days int, configure func(*mvrvServiceImpl)) *entities.Indicator {
+		marketRepo := &testutil.MockMarketDataRepository{}
+		marketRepo.On("GetPriceHistory", ctx, "BTC", at.Add(-mvrvHistoryWindow), at).Return(dailyBTCPrices(at, days), nil)
+		service := NewMVRVService(nil, marketRepo, nil, log).(*mvrvServiceImpl)
+		if configure != nil {
+			configure(service)
+		}
+
+		indicator, err := service.CalculateAt(ctx, at, nil)
+		require.NoError(t, err)
+		return indicator
+	}
+
+	t.Run("Too little history is flagged with reduced confidence", func(t *testing.T) {
+		indicator := calculate(t, 10, nil)
+
+		assert.Equal(t, true, indicator.Metadata[InsufficientDataMetadataKey])
+		assert.Equal(t, 10, indicator.Metadata["data_points"])
+		assert.Equal(t, mvrvMinDataPoints, indicator.Metadata["min_data_points"])
+		assert.Equal(t, insufficientDataConfidence, indicator.Confidence)
+		require.NoError(t, validateIndicatorMetadata(indicator))
+	})
+
+	t.Run("Enough history keeps full confidence", func(t *testing.T) {
+		indicator := calculate(t, mvrvMinDataPoints, nil)
+
+		assert.NotContains(t, indicator.Metadata, InsufficientDataMetadataKey)
+		assert.Equal(t, 0.85, indicator.Confidence)
+	})
+
+	t.Run("The minimum is configurable", func(t *testing.T) {
+		raised := calculate(t, 60, func(s *mvrvServiceImpl) { s.SetMinDataPoints(90) })
+		assert.Equal(t, true, raised.Metadata[InsufficientDataMetadataKey])
+		assert.Equal(t, insufficientDataConfidence, raised.Confidence)
+
+		disabled := calculate(t, 10, func(s *mvrvServiceImpl) { s.SetMinDataPoints(0) })
+		assert.NotContains(t, disabled.Metadata, InsufficientDataMetadataKey)
+		assert.Equal(t, 0.85, disabled.Confidence)
+	})
+}
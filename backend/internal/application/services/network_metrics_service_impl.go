@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	bitcoinNetwork           = "bitcoin"
+	networkMetricsCacheKey   = "network_metrics_bitcoin"
+	networkMetricsCacheTTL   = 5 * time.Minute
+	networkMetricsDataSource = entities.DataSourceBlockchain
+)
+
+// NetworkSummaryClient is the subset of the Blockchain.com client used for network statistics
+type NetworkSummaryClient interface {
+	GetNetworkSummary(ctx context.Context) (map[string]interface{}, error)
+}
+
+// networkMetricsServiceImpl implements the NetworkMetricsService interface
+type networkMetricsServiceImpl struct {
+	client NetworkSummaryClient
+	repo   repositories.NetworkMetricsRepository
+	cache  services.CacheService
+	logger logger.Logger
+}
+
+// NewNetworkMetricsService creates a new network metrics service. repo may be nil, in
+// which case snapshots are served but not stored.
+func NewNetworkMetricsService(
+	client NetworkSummaryClient,
+	repo repositories.NetworkMetricsRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.NetworkMetricsService {
+	return &networkMetricsServiceImpl{
+		client: client,
+		repo:   repo,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// GetBitcoinNetworkMetrics returns Bitcoin network statistics, fetched at most once per
+// cache period. Each fetch is stored as a snapshot.
+func (s *networkMetricsServiceImpl) GetBitcoinNetworkMetrics(ctx context.Context) (*entities.NetworkMetrics, error) {
+	fetch := func() (interface{}, error) {
+		return s.fetchSnapshot(ctx)
+	}
+
+	var metrics entities.NetworkMetrics
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, networkMetricsCacheKey, &metrics, networkMetricsCacheTTL, fetch)
+	} else {
+		var value interface{}
+		if value, err = fetch(); err == nil {
+			metrics = *value.(*entities.NetworkMetrics)
+		}
+	}
+	if err != nil {
+		return nil, errors.External("Blockchain.com", "failed to fetch Bitcoin network metrics", err)
+	}
+
+	return &metrics, nil
+}
+
+// fetchSnapshot reads the network summary and stores it. A failed store is logged rather
+// than failing the request, since the snapshot itself is still current.
+func (s *networkMetricsServiceImpl) fetchSnapshot(ctx context.Context) (*entities.NetworkMetrics, error) {
+	summary, err := s.client.GetNetworkSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := networkMetricsFromSummary(summary)
+	if s.repo != nil {
+		if err := s.repo.Save(ctx, metrics); err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to store network metrics snapshot", "error", err)
+		}
+	}
+
+	return metrics, nil
+}
+
+// networkMetricsFromSummary maps a Blockchain.com network summary onto a snapshot
+func networkMetricsFromSummary(summary map[string]interface{}) *entities.NetworkMetrics {
+	timestamp := time.Now().UTC()
+	if updated := summaryNumber(summary, "last_updated"); updated > 0 {
+		timestamp = time.Unix(int64(updated), 0).UTC()
+	}
+
+	return &entities.NetworkMetrics{
+		Timestamp:       timestamp,
+		Network:         bitcoinNetwork,
+		HashRate:        summaryNumber(summary, "hash_rate"),
+		Difficulty:      summaryNumber(summary, "difficulty"),
+		BlockHeight:     int64(summaryNumber(summary, "block_height")),
+		TotalSupply:     summaryNumber(summary, "total_btc"),
+		MempoolSize:     int64(summaryNumber(summary, "mempool_size")),
+		TransactionRate: summaryNumber(summary, "transaction_rate"),
+		FeesTotal:       summaryNumber(summary, "total_fees_btc"),
+		DataSource:      networkMetricsDataSource,
+	}
+}
+
+// summaryNumber reads a numeric summary value, or 0 when it is missing
+func summaryNumber(summary map[string]interface{}, key string) float64 {
+	switch value := summary[key].(type) {
+	case float64:
+		return value
+	case int64:
+		return float64(value)
+	case int:
+		return float64(value)
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"math"
+	"sort"
+)
+
+// OutlierMode selects how values outside the IQR fences are handled before statistics
+// are computed from them
+type OutlierMode string
+
+const (
+	// OutlierRejectionOff uses every value as is
+	OutlierRejectionOff OutlierMode = ""
+	// OutlierTrim drops values outside the fences
+	OutlierTrim OutlierMode = "trim"
+	// OutlierWinsorize clamps values outside the fences to the nearest fence
+	OutlierWinsorize OutlierMode = "winsorize"
+)
+
+const (
+	// defaultIQRMultiplier is Tukey's fence distance, in interquartile ranges beyond the quartiles
+	defaultIQRMultiplier = 1.5
+	// minOutlierSample is the fewest values quartiles are estimated from; smaller samples
+	// are left untouched
+	minOutlierSample = 4
+)
+
+// OutlierRejectionConfig controls outlier handling in an indicator's statistics. Values
+// below Q1 - IQRMultiplier×IQR or above Q3 + IQRMultiplier×IQR are outliers.
+type OutlierRejectionConfig struct {
+	Mode OutlierMode
+	// IQRMultiplier sets the fence distance; zero uses 1.5
+	IQRMultiplier float64
+}
+
+// Enabled reports whether outliers are trimmed or winsorized
+func (c OutlierRejectionConfig) Enabled() bool {
+	return c.Mode == OutlierTrim || c.Mode == OutlierWinsorize
+}
+
+// OutlierRejecting is implemented by indicator services whose statistics can reject outliers
+type OutlierRejecting interface {
+	SetOutlierRejection(cfg OutlierRejectionConfig)
+}
+
+// rejectOutliers applies cfg to values, returning the values to compute statistics from
+// and how many were outliers. values is not modified.
+func rejectOutliers(values []float64, cfg OutlierRejectionConfig) ([]float64, int) {
+	if !cfg.Enabled() || len(values) < minOutlierSample {
+		return values, 0
+	}
+
+	multiplier := cfg.IQRMultiplier
+	if multiplier <= 0 {
+		multiplier = defaultIQRMultiplier
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+	iqr := q3 - q1
+	low, high := q1-multiplier*iqr, q3+multiplier*iqr
+
+	kept := make([]float64, 0, len(values))
+	outliers := 0
+	for _, v := range values {
+		if v >= low && v <= high {
+			kept = append(kept, v)
+			continue
+		}
+		outliers++
+		if cfg.Mode == OutlierWinsorize {
+			kept = append(kept, math.Min(math.Max(v, low), high))
+		}
+	}
+	return kept, outliers
+}
+
+// quantile returns the q-th quantile of sorted values, interpolating linearly between
+// the closest ranks
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (pos-float64(lower))*(sorted[upper]-sorted[lower])
+}
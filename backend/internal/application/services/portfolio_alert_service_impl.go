@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// loggingPortfolioAlertNotifier is the default PortfolioAlertNotifier: it only logs alerts
+type loggingPortfolioAlertNotifier struct {
+	logger logger.Logger
+}
+
+// NewLoggingPortfolioAlertNotifier creates a PortfolioAlertNotifier that logs each fired
+// alert and delivers it nowhere else
+func NewLoggingPortfolioAlertNotifier(logger logger.Logger) services.PortfolioAlertNotifier {
+	return &loggingPortfolioAlertNotifier{logger: logger}
+}
+
+// NotifyDrawdown logs the event
+func (n *loggingPortfolioAlertNotifier) NotifyDrawdown(ctx context.Context, event entities.PortfolioDrawdownEvent) error {
+	n.logger.WithContext(ctx).Warn("Portfolio drawdown alert",
+		"alert_id", event.AlertID,
+		"portfolio_id", event.PortfolioID,
+		"user_id", event.UserID,
+		"peak_value", event.PeakValue,
+		"value", event.Value,
+		"drawdown_percent", event.DrawdownPercent,
+		"threshold_percent", event.ThresholdPercent)
+	return nil
+}
+
+// portfolioAlertServiceImpl implements the PortfolioAlertService interface
+type portfolioAlertServiceImpl struct {
+	alertRepo     repositories.PortfolioAlertRepository
+	portfolioRepo repositories.PortfolioRepository
+	notifier      services.PortfolioAlertNotifier
+	logger        logger.Logger
+}
+
+// NewPortfolioAlertService creates a new portfolio alert service. A nil notifier logs
+// fired alerts.
+func NewPortfolioAlertService(
+	alertRepo repositories.PortfolioAlertRepository,
+	portfolioRepo repositories.PortfolioRepository,
+	notifier services.PortfolioAlertNotifier,
+	logger logger.Logger,
+) services.PortfolioAlertService {
+	if notifier == nil {
+		notifier = NewLoggingPortfolioAlertNotifier(logger)
+	}
+	return &portfolioAlertServiceImpl{
+		alertRepo:     alertRepo,
+		portfolioRepo: portfolioRepo,
+		notifier:      notifier,
+		logger:        logger,
+	}
+}
+
+// CreateDrawdownAlert validates the threshold and stores a new alert whose peak starts at
+// the portfolio's current value
+func (s *portfolioAlertServiceImpl) CreateDrawdownAlert(ctx context.Context, userID string, portfolioID uint, thresholdPercent float64) (*entities.PortfolioDrawdownAlert, error) {
+	if thresholdPercent <= 0 || thresholdPercent >= 100 {
+		return nil, errors.Validation("Invalid drawdown threshold", "threshold_percent must be greater than 0 and less than 100")
+	}
+
+	portfolio, err := s.ownedPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	alert := &entities.PortfolioDrawdownAlert{
+		PortfolioID:      portfolioID,
+		UserID:           userID,
+		ThresholdPercent: thresholdPercent,
+		PeakValue:        portfolio.TotalValue,
+		PeakAt:           &now,
+		IsActive:         true,
+	}
+	if err := s.alertRepo.CreateDrawdownAlert(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Created drawdown alert",
+		"alert_id", alert.ID, "portfolio_id", portfolioID, "threshold_percent", thresholdPercent)
+	return alert, nil
+}
+
+// GetDrawdownAlerts returns every alert on a portfolio the user owns
+func (s *portfolioAlertServiceImpl) GetDrawdownAlerts(ctx context.Context, userID string, portfolioID uint) ([]entities.PortfolioDrawdownAlert, error) {
+	if _, err := s.ownedPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+	return s.alertRepo.GetDrawdownAlerts(ctx, portfolioID, false)
+}
+
+// EvaluateDrawdown compares the portfolio's freshly refreshed value against each active
+// alert. A new high moves the peak up and re-arms a fired alert; a value at least
+// ThresholdPercent below the peak fires an armed alert. When the notification fails the
+// alert stays armed, so the next refresh retries it.
+func (s *portfolioAlertServiceImpl) EvaluateDrawdown(ctx context.Context, portfolio *entities.Portfolio) error {
+	log := s.logger.WithContext(ctx)
+
+	alerts, err := s.alertRepo.GetDrawdownAlerts(ctx, portfolio.ID, true)
+	if err != nil {
+		return err
+	}
+
+	value := portfolio.TotalValue
+	now := time.Now()
+	var failed int
+	for i := range alerts {
+		alert := &alerts[i]
+
+		switch {
+		case value > alert.PeakValue:
+			alert.PeakValue = value
+			alert.PeakAt = &now
+			alert.Triggered = false
+
+		case !alert.Triggered && alert.DrawdownPercent(value) >= alert.ThresholdPercent:
+			event := entities.PortfolioDrawdownEvent{
+				AlertID:          alert.ID,
+				PortfolioID:      portfolio.ID,
+				UserID:           alert.UserID,
+				ThresholdPercent: alert.ThresholdPercent,
+				PeakValue:        alert.PeakValue,
+				Value:            value,
+				DrawdownPercent:  alert.DrawdownPercent(value),
+				Timestamp:        now,
+			}
+			if err := s.notifier.NotifyDrawdown(ctx, event); err != nil {
+				failed++
+				log.Warn("Failed to dispatch drawdown alert", "alert_id", alert.ID, "error", err)
+				continue
+			}
+			alert.Triggered = true
+			alert.LastTriggered = &now
+
+		default:
+			continue
+		}
+
+		if err := s.alertRepo.UpdateDrawdownAlert(ctx, alert); err != nil {
+			failed++
+			log.Warn("Failed to store drawdown alert state", "alert_id", alert.ID, "error", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to evaluate %d of %d drawdown alerts", failed, len(alerts))
+	}
+	return nil
+}
+
+// ownedPortfolio returns the portfolio, or not found when it belongs to another user
+func (s *portfolioAlertServiceImpl) ownedPortfolio(ctx context.Context, userID string, portfolioID uint) (*entities.Portfolio, error) {
+	portfolio, err := s.portfolioRepo.GetByID(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+	if portfolio.UserID != userID {
+		return nil, errors.NotFound("Portfolio")
+	}
+	return portfolio, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPortfolioAlertRepository stores drawdown alerts in a slice
+type memoryPortfolioAlertRepository struct {
+	alerts []entities.PortfolioDrawdownAlert
+}
+
+func (r *memoryPortfolioAlertRepository) CreateDrawdownAlert(ctx context.Context, alert *entities.PortfolioDrawdownAlert) error {
+	alert.ID = uint(len(r.alerts) + 1)
+	r.alerts = append(r.alerts, *alert)
+	return nil
+}
+
+func (r *memoryPortfolioAlertRepository) GetDrawdownAlerts(ctx context.Context, portfolioID uint, activeOnly bool) ([]entities.PortfolioDrawdownAlert, error) {
+	var alerts []entities.PortfolioDrawdownAlert
+	for _, alert := range r.alerts {
+		if alert.PortfolioID == portfolioID && (alert.IsActive || !activeOnly) {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+func (r *memoryPortfolioAlertRepository) UpdateDrawdownAlert(ctx context.Context, alert *entities.PortfolioDrawdownAlert) error {
+	r.alerts[alert.ID-1] = *alert
+	return nil
+}
+
+// recordingAlertNotifier records drawdown events, failing while err is set
+type recordingAlertNotifier struct {
+	events []entities.PortfolioDrawdownEvent
+	err    error
+}
+
+func (n *recordingAlertNotifier) NotifyDrawdown(ctx context.Context, event entities.PortfolioDrawdownEvent) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.events = append(n.events, event)
+	return nil
+}
+
+func TestPortfolioAlertService_EvaluateDrawdown(t *testing.T) {
+	ctx := context.Background()
+	portfolio := &entities.Portfolio{ID: 1, UserID: "user-1", TotalValue: 1000}
+
+	portfolios := &testutil.MockPortfolioRepository{}
+	portfolios.On("GetByID", ctx, uint(1)).Return(portfolio, nil)
+	alerts := &memoryPortfolioAlertRepository{}
+	notifier := &recordingAlertNotifier{}
+	service := NewPortfolioAlertService(alerts, portfolios, notifier, logger.New("test"))
+
+	alert, err := service.CreateDrawdownAlert(ctx, "user-1", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, alert.PeakValue)
+
+	valueAt := func(value float64) {
+		t.Helper()
+		require.NoError(t, service.EvaluateDrawdown(ctx, &entities.Portfolio{ID: 1, TotalValue: value}))
+	}
+
+	// The peak trails the value up and is stored
+	valueAt(1200)
+	assert.Equal(t, 1200.0, alerts.alerts[0].PeakValue)
+
+	valueAt(1100) // 8.3% below the peak
+	assert.Empty(t, notifier.events)
+
+	valueAt(1080) // exactly 10% below the peak
+	require.Len(t, notifier.events, 1)
+	event := notifier.events[0]
+	assert.Equal(t, uint(1), event.PortfolioID)
+	assert.Equal(t, "user-1", event.UserID)
+	assert.Equal(t, 1200.0, event.PeakValue)
+	assert.Equal(t, 1080.0, event.Value)
+	assert.InDelta(t, 10.0, event.DrawdownPercent, 1e-9)
+	assert.True(t, alerts.alerts[0].Triggered)
+	assert.NotNil(t, alerts.alerts[0].LastTriggered)
+
+	// A deeper drawdown does not fire again
+	valueAt(900)
+	assert.Len(t, notifier.events, 1)
+	assert.Equal(t, 1200.0, alerts.alerts[0].PeakValue)
+
+	// A new peak re-arms the alert
+	valueAt(1250)
+	assert.False(t, alerts.alerts[0].Triggered)
+	assert.Equal(t, 1250.0, alerts.alerts[0].PeakValue)
+
+	valueAt(1120)
+	require.Len(t, notifier.events, 2)
+	assert.Equal(t, 1250.0, notifier.events[1].PeakValue)
+}
+
+func TestPortfolioAlertService_NotifyFailureKeepsAlertArmed(t *testing.T) {
+	ctx := context.Background()
+	alerts := &memoryPortfolioAlertRepository{alerts: []entities.PortfolioDrawdownAlert{
+		{ID: 1, PortfolioID: 1, UserID: "user-1", ThresholdPercent: 20, PeakValue: 1000, IsActive: true},
+	}}
+	notifier := &recordingAlertNotifier{err: fmt.Errorf("webhook down")}
+	service := NewPortfolioAlertService(alerts, &testutil.MockPortfolioRepository{}, notifier, logger.New("test"))
+
+	err := service.EvaluateDrawdown(ctx, &entities.Portfolio{ID: 1, TotalValue: 700})
+	assert.Error(t, err)
+	assert.False(t, alerts.alerts[0].Triggered)
+
+	notifier.err = nil
+	require.NoError(t, service.EvaluateDrawdown(ctx, &entities.Portfolio{ID: 1, TotalValue: 700}))
+	assert.Len(t, notifier.events, 1)
+	assert.True(t, alerts.alerts[0].Triggered)
+}
+
+func TestPortfolioAlertService_CreateDrawdownAlert(t *testing.T) {
+	ctx := context.Background()
+	portfolios := &testutil.MockPortfolioRepository{}
+	portfolios.On("GetByID", ctx, uint(1)).Return(&entities.Portfolio{ID: 1, UserID: "user-1"}, nil)
+	service := NewPortfolioAlertService(&memoryPortfolioAlertRepository{}, portfolios, nil, logger.New("test"))
+
+	for _, threshold := range []float64{0, -5, 100} {
+		_, err := service.CreateDrawdownAlert(ctx, "user-1", 1, threshold)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation), "threshold %v", threshold)
+	}
+
+	_, err := service.CreateDrawdownAlert(ctx, "someone-else", 1, 10)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+}
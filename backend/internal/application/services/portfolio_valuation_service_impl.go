@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// portfolioValuationServiceImpl implements the PortfolioValuationService interface
+type portfolioValuationServiceImpl struct {
+	portfolioRepo     repositories.PortfolioRepository
+	marketDataService services.MarketDataService
+	logger            logger.Logger
+}
+
+// NewPortfolioValuationService creates a new portfolio valuation service implementation
+func NewPortfolioValuationService(
+	portfolioRepo repositories.PortfolioRepository,
+	marketDataService services.MarketDataService,
+	logger logger.Logger,
+) services.PortfolioValuationService {
+	return &portfolioValuationServiceImpl{
+		portfolioRepo:     portfolioRepo,
+		marketDataService: marketDataService,
+		logger:            logger,
+	}
+}
+
+// RefreshValuations re-prices every holding at the current market price, recomputes
+// value and PnL, and persists the holdings and the portfolio total.
+// Holdings without a current price keep their previous valuation.
+func (s *portfolioValuationServiceImpl) RefreshValuations(ctx context.Context, portfolioID uint) (*entities.Portfolio, error) {
+	log := s.logger.WithContext(ctx)
+
+	if s.marketDataService == nil {
+		return nil, errors.New(errors.ErrorTypeExternal, "market data service is not available")
+	}
+
+	portfolio, err := s.portfolioRepo.GetByID(ctx, portfolioID)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get portfolio")
+	}
+	if len(portfolio.Holdings) == 0 {
+		return portfolio, nil
+	}
+
+	prices, _, err := s.marketDataService.GetCryptoPrices(ctx, holdingSymbols(portfolio.Holdings))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeExternal, "failed to fetch current prices")
+	}
+
+	var totalValue float64
+	for i := range portfolio.Holdings {
+		holding := &portfolio.Holdings[i]
+
+		price, ok := prices[strings.ToUpper(holding.Symbol)]
+		if !ok || price == nil || price.Price <= 0 {
+			log.Warn("No current price for holding, keeping previous valuation",
+				"portfolio_id", portfolioID, "symbol", holding.Symbol)
+			totalValue += holding.Value
+			continue
+		}
+
+		revalueHolding(holding, price.Price)
+		if err := s.portfolioRepo.UpdateHolding(ctx, holding); err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to update holding "+holding.Symbol)
+		}
+		totalValue += holding.Value
+	}
+
+	portfolio.TotalValue = totalValue
+	portfolio.LastUpdated = time.Now()
+	if err := s.portfolioRepo.Update(ctx, portfolio); err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to update portfolio total value")
+	}
+
+	log.Debug("Refreshed portfolio valuations",
+		"portfolio_id", portfolioID, "holdings", len(portfolio.Holdings), "total_value", totalValue)
+
+	return portfolio, nil
+}
+
+// revalueHolding recomputes a holding's value and PnL at the given price
+func revalueHolding(holding *entities.PortfolioHolding, price float64) {
+	cost := holding.Amount * holding.AveragePrice
+
+	holding.CurrentPrice = price
+	holding.Value = holding.Amount * price
+	holding.PnL = holding.Value - cost
+	holding.PnLPercent = 0
+	if cost > 0 {
+		holding.PnLPercent = holding.PnL / cost * 100
+	}
+}
+
+// holdingSymbols returns the distinct upper-cased symbols held in a portfolio
+func holdingSymbols(holdings []entities.PortfolioHolding) []string {
+	seen := make(map[string]bool, len(holdings))
+	symbols := make([]string, 0, len(holdings))
+	for _, holding := range holdings {
+		symbol := strings.ToUpper(holding.Symbol)
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func samplePortfolio() *entities.Portfolio {
+	return &entities.Portfolio{
+		ID:     1,
+		UserID: "user-1",
+		Name:   "Main",
+		Holdings: []entities.PortfolioHolding{
+			{ID: 10, PortfolioID: 1, Symbol: "BTC", Amount: 0.5, AveragePrice: 40000},
+			{ID: 11, PortfolioID: 1, Symbol: "eth", Amount: 10, AveragePrice: 3000},
+			{ID: 12, PortfolioID: 1, Symbol: "SOL", Amount: 100, AveragePrice: 50, Value: 4000},
+		},
+	}
+}
+
+func TestPortfolioValuationService_RefreshValuations(t *testing.T) {
+	ctx := context.Background()
+	repo := &testutil.MockPortfolioRepository{}
+	marketData := &testutil.MockMarketDataService{}
+
+	repo.On("GetByID", ctx, uint(1)).Return(samplePortfolio(), nil)
+	// SOL has no quote and keeps its previous valuation
+	marketData.On("GetCryptoPrices", ctx, []string{"BTC", "ETH", "SOL"}).Return(map[string]*entities.CryptoPrice{
+		"BTC": {Symbol: "BTC", Price: 60000},
+		"ETH": {Symbol: "ETH", Price: 2400},
+	}, []string{"SOL"}, nil)
+
+	updated := make(map[string]entities.PortfolioHolding)
+	repo.On("UpdateHolding", ctx, mock.AnythingOfType("*entities.PortfolioHolding")).
+		Run(func(args mock.Arguments) {
+			holding := args.Get(1).(*entities.PortfolioHolding)
+			updated[holding.Symbol] = *holding
+		}).
+		Return(nil)
+	repo.On("Update", ctx, mock.AnythingOfType("*entities.Portfolio")).Return(nil)
+
+	service := NewPortfolioValuationService(repo, marketData, logger.New("test"))
+	portfolio, err := service.RefreshValuations(ctx, 1)
+	require.NoError(t, err)
+
+	require.Len(t, updated, 2)
+
+	btc := updated["BTC"]
+	assert.Equal(t, 60000.0, btc.CurrentPrice)
+	assert.InDelta(t, 30000.0, btc.Value, 1e-9)
+	assert.InDelta(t, 10000.0, btc.PnL, 1e-9)
+	assert.InDelta(t, 50.0, btc.PnLPercent, 1e-9)
+
+	eth := updated["eth"]
+	assert.Equal(t, 2400.0, eth.CurrentPrice)
+	assert.InDelta(t, 24000.0, eth.Value, 1e-9)
+	assert.InDelta(t, -6000.0, eth.PnL, 1e-9)
+	assert.InDelta(t, -20.0, eth.PnLPercent, 1e-9)
+
+	assert.InDelta(t, 30000.0+24000.0+4000.0, portfolio.TotalValue, 1e-9)
+	assert.False(t, portfolio.LastUpdated.IsZero())
+
+	repo.AssertCalled(t, "Update", ctx, mock.MatchedBy(func(p *entities.Portfolio) bool {
+		return p.ID == 1 && p.TotalValue == 58000.0
+	}))
+	repo.AssertExpectations(t)
+	marketData.AssertExpectations(t)
+}
+
+func TestPortfolioValuationService_RefreshValuations_PriceFetchFails(t *testing.T) {
+	ctx := context.Background()
+	repo := &testutil.MockPortfolioRepository{}
+	marketData := &testutil.MockMarketDataService{}
+
+	repo.On("GetByID", ctx, uint(1)).Return(samplePortfolio(), nil)
+	marketData.On("GetCryptoPrices", ctx, mock.Anything).Return(nil, nil, fmt.Errorf("upstream unavailable"))
+
+	service := NewPortfolioValuationService(repo, marketData, logger.New("test"))
+	_, err := service.RefreshValuations(ctx, 1)
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "UpdateHolding", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+)
+
+// Price source modes accepted in PriceSourceConfig.Mode
+const (
+	// PriceSourceModeFirstAvailable uses CoinMarketCap and falls back to Binance when it fails
+	PriceSourceModeFirstAvailable = "first_available"
+	// PriceSourceModeAggregated queries every configured source and takes a weighted median
+	PriceSourceModeAggregated = "aggregated"
+)
+
+// Price source identifiers used for aggregation weights and metadata
+const (
+	PriceSourceCoinMarketCap = "coinmarketcap"
+	PriceSourceBinance       = "binance"
+	PriceSourceCoinCap       = "coincap"
+)
+
+// coinCapAssetLimit is how many CoinCap assets are scanned for requested symbols
+const coinCapAssetLimit = 200
+
+// defaultPriceFetchConcurrency bounds in-flight requests to providers quoting one symbol per request
+const defaultPriceFetchConcurrency = 4
+
+// PriceSource is a provider that can contribute USD spot prices to an aggregated quote
+type PriceSource interface {
+	// Name identifies the source in weights and price metadata
+	Name() string
+	// GetPrices returns prices keyed by upper-case symbol; unknown symbols are omitted
+	GetPrices(ctx context.Context, symbols []string) (map[string]float64, error)
+}
+
+// PriceSourceConfig controls how crypto prices are sourced
+type PriceSourceConfig struct {
+	// Mode is PriceSourceModeFirstAvailable (the default) or PriceSourceModeAggregated
+	Mode string
+	// Sources are queried concurrently in aggregated mode
+	Sources []PriceSource
+	// Weights is the confidence given to each source by name; unlisted sources weigh 1
+	Weights map[string]float64
+	// MaxDeviation is the largest fractional distance from the median of all quotes a
+	// quote may have before it is discarded as an outlier. Zero keeps every quote.
+	MaxDeviation float64
+	// Concurrency is the most requests sent at once to a provider that quotes one symbol
+	// per request, such as the Binance fallback
+	Concurrency int
+}
+
+// DefaultPriceSourceConfig returns the first-available configuration
+func DefaultPriceSourceConfig() PriceSourceConfig {
+	return PriceSourceConfig{
+		Mode: PriceSourceModeFirstAvailable,
+		Weights: map[string]float64{
+			PriceSourceCoinMarketCap: 1.0,
+			PriceSourceBinance:       0.9,
+			PriceSourceCoinCap:       0.8,
+		},
+		MaxDeviation: 0.02,
+		Concurrency:  defaultPriceFetchConcurrency,
+	}
+}
+
+// Weight returns the configured confidence for a source
+func (c PriceSourceConfig) Weight(source string) float64 {
+	if w, ok := c.Weights[source]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// fetchPerSymbol fetches each symbol's price with at most concurrency requests in flight.
+// Symbols are upper-cased and deduplicated; those that fail are left out of prices and
+// their errors returned keyed by symbol.
+func fetchPerSymbol(ctx context.Context, symbols []string, concurrency int, fetch func(ctx context.Context, symbol string) (float64, error)) (map[string]float64, map[string]error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan string)
+	prices := make(map[string]float64, len(symbols))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(symbols); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for symbol := range jobs {
+				price, err := fetch(ctx, symbol)
+				mu.Lock()
+				if err != nil {
+					errs[symbol] = err
+				} else {
+					prices[symbol] = price
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		if seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		if err := ctx.Err(); err != nil {
+			mu.Lock()
+			errs[symbol] = err
+			mu.Unlock()
+			continue
+		}
+		jobs <- symbol
+	}
+	close(jobs)
+	wg.Wait()
+
+	return prices, errs
+}
+
+// joinSymbolErrors renders per-symbol errors as "SYMBOL: error" in symbol order
+func joinSymbolErrors(errs map[string]error) string {
+	symbols := make([]string, 0, len(errs))
+	for symbol := range errs {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	parts := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		parts[i] = fmt.Sprintf("%s: %v", symbol, errs[symbol])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// priceQuote is one source's price for a symbol
+type priceQuote struct {
+	source string
+	price  float64
+	weight float64
+}
+
+// fetchAggregatedPrices queries every configured source concurrently and combines their
+// quotes per symbol. Sources that fail are skipped; the call fails only when none answer.
+func (s *marketDataServiceImpl) fetchAggregatedPrices(ctx context.Context, symbols []string) (map[string]*entities.CryptoPrice, error) {
+	sources := s.priceConfig.Sources
+	s.logger.Info("Fetching aggregated crypto prices", "symbols", symbols, "sources", len(sources))
+
+	results := make([]map[string]float64, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source PriceSource) {
+			defer wg.Done()
+			prices, err := source.GetPrices(ctx, symbols)
+			if err != nil {
+				s.logger.Warn("Price source unavailable for aggregation", "source", source.Name(), "error", err)
+				return
+			}
+			results[i] = prices
+		}(i, source)
+	}
+	wg.Wait()
+
+	quotes := make(map[string][]priceQuote)
+	for i, prices := range results {
+		name := sources[i].Name()
+		for symbol, price := range prices {
+			if price <= 0 {
+				continue
+			}
+			symbol = strings.ToUpper(symbol)
+			quotes[symbol] = append(quotes[symbol], priceQuote{source: name, price: price, weight: s.priceConfig.Weight(name)})
+		}
+	}
+
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("no price source returned prices for %v", symbols)
+	}
+
+	now := time.Now()
+	prices := make(map[string]*entities.CryptoPrice, len(quotes))
+	for symbol, symbolQuotes := range quotes {
+		kept, excluded := discardOutliers(symbolQuotes, s.priceConfig.MaxDeviation)
+
+		var keptWeight, totalWeight float64
+		contributing := make([]string, len(kept))
+		for i, q := range kept {
+			contributing[i] = q.source
+			keptWeight += q.weight
+		}
+		for _, q := range symbolQuotes {
+			totalWeight += q.weight
+		}
+		outliers := make([]string, len(excluded))
+		for i, q := range excluded {
+			outliers[i] = q.source
+		}
+		sort.Strings(contributing)
+		sort.Strings(outliers)
+
+		price := &entities.CryptoPrice{
+			Symbol:           symbol,
+			Price:            weightedMedian(kept),
+			LastUpdated:      now,
+			DataSource:       entities.DataSourceAggregated,
+			DataSourceDetail: strings.Join(contributing, " + ") + " (weighted median)",
+			Metadata: map[string]interface{}{
+				"sources":          contributing,
+				"excluded_sources": outliers,
+				"confidence":       keptWeight / totalWeight,
+			},
+		}
+		prices[symbol] = price
+
+		if len(excluded) > 0 {
+			s.logger.Warn("Discarded outlier prices", "symbol", symbol, "sources", outliers, "median", price.Price)
+		}
+
+		if err := s.repo.StorePriceData(ctx, price); err != nil {
+			s.logger.Warn("Failed to store price data", "error", err, "symbol", symbol)
+		}
+	}
+
+	s.logger.Info("Successfully aggregated crypto prices", "count", len(prices), "symbols", symbols)
+	return prices, nil
+}
+
+// discardOutliers splits quotes into those within maxDeviation of the weighted median of
+// all quotes and those beyond it. A non-positive maxDeviation keeps every quote.
+func discardOutliers(quotes []priceQuote, maxDeviation float64) (kept, excluded []priceQuote) {
+	if maxDeviation <= 0 || len(quotes) < 3 {
+		return quotes, nil
+	}
+
+	reference := weightedMedian(quotes)
+	for _, q := range quotes {
+		if math.Abs(q.price-reference)/reference > maxDeviation {
+			excluded = append(excluded, q)
+			continue
+		}
+		kept = append(kept, q)
+	}
+	return kept, excluded
+}
+
+// weightedMedian returns the price at which half the total weight lies on each side,
+// averaging the two middle prices when the split falls exactly between them
+func weightedMedian(quotes []priceQuote) float64 {
+	sorted := append([]priceQuote(nil), quotes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].price < sorted[j].price })
+
+	var total float64
+	for _, q := range sorted {
+		total += q.weight
+	}
+
+	half := total / 2
+	var cumulative float64
+	for i, q := range sorted {
+		cumulative += q.weight
+		if math.Abs(cumulative-half) < 1e-9 && i+1 < len(sorted) {
+			return (q.price + sorted[i+1].price) / 2
+		}
+		if cumulative > half {
+			return q.price
+		}
+	}
+	return sorted[len(sorted)-1].price
+}
+
+// coinMarketCapPriceSource serves CoinMarketCap USD quotes to aggregation
+type coinMarketCapPriceSource struct {
+	client *external.CoinMarketCapClient
+}
+
+// NewCoinMarketCapPriceSource creates a price source backed by CoinMarketCap quotes
+func NewCoinMarketCapPriceSource(client *external.CoinMarketCapClient) PriceSource {
+	return &coinMarketCapPriceSource{client: client}
+}
+
+func (p *coinMarketCapPriceSource) Name() string { return PriceSourceCoinMarketCap }
+
+func (p *coinMarketCapPriceSource) GetPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	response, err := p.client.GetLatestQuotes(ctx, symbols, "USD")
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]float64, len(response.Data))
+	for symbol, data := range response.Data {
+		if quote, ok := data.Quote["USD"]; ok {
+			prices[strings.ToUpper(symbol)] = quote.Price
+		}
+	}
+	return prices, nil
+}
+
+// binancePriceSource serves Binance USDT spot prices to aggregation
+type binancePriceSource struct {
+	client      PriceFallbackClient
+	concurrency int
+}
+
+// NewBinancePriceSource creates a price source backed by Binance USDT pairs. Binance quotes
+// one pair per request, so up to concurrency pairs are requested at once.
+func NewBinancePriceSource(client PriceFallbackClient, concurrency int) PriceSource {
+	return &binancePriceSource{client: client, concurrency: concurrency}
+}
+
+func (p *binancePriceSource) Name() string { return PriceSourceBinance }
+
+func (p *binancePriceSource) GetPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	prices, errs := fetchPerSymbol(ctx, symbols, p.concurrency, func(ctx context.Context, symbol string) (float64, error) {
+		return p.client.GetPrice(ctx, symbol+priceFallbackQuote)
+	})
+
+	if len(prices) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("no Binance prices: %s", joinSymbolErrors(errs))
+	}
+	return prices, nil
+}
+
+// coinCapPriceSource serves CoinCap asset prices to aggregation
+type coinCapPriceSource struct {
+	client *external.CoinCapClient
+}
+
+// NewCoinCapPriceSource creates a price source backed by CoinCap's top assets
+func NewCoinCapPriceSource(client *external.CoinCapClient) PriceSource {
+	return &coinCapPriceSource{client: client}
+}
+
+func (p *coinCapPriceSource) Name() string { return PriceSourceCoinCap }
+
+func (p *coinCapPriceSource) GetPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	response, err := p.client.GetAssets(ctx, coinCapAssetLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		wanted[strings.ToUpper(symbol)] = true
+	}
+
+	prices := make(map[string]float64, len(symbols))
+	for _, asset := range response.Data {
+		symbol := strings.ToUpper(asset.Symbol)
+		if !wanted[symbol] {
+			continue
+		}
+		// Assets are ranked by market cap, so keep the first match for a shared ticker
+		if _, seen := prices[symbol]; seen {
+			continue
+		}
+		price, err := strconv.ParseFloat(asset.PriceUSD, 64)
+		if err != nil {
+			continue
+		}
+		prices[symbol] = price
+	}
+	return prices, nil
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticPriceSource answers with fixed prices, or err when set
+type staticPriceSource struct {
+	name   string
+	prices map[string]float64
+	err    error
+}
+
+func (p *staticPriceSource) Name() string { return p.name }
+
+func (p *staticPriceSource) GetPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	return p.prices, p.err
+}
+
+func newAggregatedService(sources ...PriceSource) *marketDataServiceImpl {
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+
+	cfg := DefaultPriceSourceConfig()
+	cfg.Mode = PriceSourceModeAggregated
+	cfg.Sources = sources
+	cfg.Weights = nil
+
+	return NewMarketDataService(repo, nil, nil, testutil.NewMockCacheService(), logger.New("test"),
+		MarketDataOptions{Prices: &cfg}).(*marketDataServiceImpl)
+}
+
+func TestAggregatedPrices_ExcludesOutlier(t *testing.T) {
+	service := newAggregatedService(
+		&staticPriceSource{name: PriceSourceCoinMarketCap, prices: map[string]float64{"BTC": 60000}},
+		&staticPriceSource{name: PriceSourceBinance, prices: map[string]float64{"BTC": 60300}},
+		&staticPriceSource{name: PriceSourceCoinCap, prices: map[string]float64{"BTC": 75000}},
+	)
+
+	prices, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"BTC", "DOGE"})
+	require.NoError(t, err)
+	assert.NotContains(t, prices, "DOGE")
+
+	btc := prices["BTC"]
+	require.NotNil(t, btc)
+	// The outlier is dropped and the remaining equal-weight quotes split the median
+	assert.InDelta(t, 60150.0, btc.Price, 1e-9)
+	assert.Equal(t, entities.DataSourceAggregated, btc.DataSource)
+	assert.Equal(t, "binance + coinmarketcap (weighted median)", btc.DataSourceDetail)
+	assert.Equal(t, []string{PriceSourceBinance, PriceSourceCoinMarketCap}, btc.Metadata["sources"])
+	assert.Equal(t, []string{PriceSourceCoinCap}, btc.Metadata["excluded_sources"])
+	assert.InDelta(t, 2.0/3.0, btc.Metadata["confidence"].(float64), 1e-9)
+}
+
+func TestAggregatedPrices_SkipsFailedSources(t *testing.T) {
+	service := newAggregatedService(
+		&staticPriceSource{name: PriceSourceCoinMarketCap, err: fmt.Errorf("rate limited")},
+		&staticPriceSource{name: PriceSourceBinance, prices: map[string]float64{"ETH": 3000}},
+		&staticPriceSource{name: PriceSourceCoinCap, prices: map[string]float64{"ETH": 3010}},
+	)
+
+	prices, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"ETH"})
+	require.NoError(t, err)
+	assert.InDelta(t, 3005.0, prices["ETH"].Price, 1e-9)
+	assert.Equal(t, []string{PriceSourceBinance, PriceSourceCoinCap}, prices["ETH"].Metadata["sources"])
+
+	t.Run("Error when every source fails", func(t *testing.T) {
+		service := newAggregatedService(
+			&staticPriceSource{name: PriceSourceBinance, err: fmt.Errorf("down")},
+		)
+		_, err := service.fetchCryptoPricesFromAPI(context.Background(), []string{"ETH"})
+		assert.Error(t, err)
+	})
+}
+
+func TestWeightedMedian(t *testing.T) {
+	tests := []struct {
+		name     string
+		quotes   []priceQuote
+		expected float64
+	}{
+		{"single quote", []priceQuote{{price: 10, weight: 1}}, 10},
+		{"odd count", []priceQuote{{price: 30, weight: 1}, {price: 10, weight: 1}, {price: 20, weight: 1}}, 20},
+		{"even split averages", []priceQuote{{price: 10, weight: 1}, {price: 20, weight: 1}}, 15},
+		{"heavier source wins", []priceQuote{{price: 10, weight: 1}, {price: 20, weight: 0.5}, {price: 30, weight: 0.4}}, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, weightedMedian(tt.quotes), 1e-9)
+		})
+	}
+}
+
+// slowPriceFallback answers every pair after a delay and records the most requests it saw in flight
+type slowPriceFallback struct {
+	delay       time.Duration
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (s *slowPriceFallback) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	current := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for {
+		seen := atomic.LoadInt32(&s.maxInFlight)
+		if current <= seen || atomic.CompareAndSwapInt32(&s.maxInFlight, seen, current) {
+			break
+		}
+	}
+
+	time.Sleep(s.delay)
+	if symbol == "UNKNOWN"+priceFallbackQuote {
+		return 0, fmt.Errorf("invalid symbol")
+	}
+	return float64(len(symbol)), nil
+}
+
+func (s *slowPriceFallback) HealthCheck(ctx context.Context) (time.Duration, error) {
+	time.Sleep(s.delay)
+	return s.delay, nil
+}
+
+func testSymbols(n int) []string {
+	symbols := make([]string, n)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("sym%d", i)
+	}
+	return symbols
+}
+
+func TestFetchPerSymbol(t *testing.T) {
+	t.Run("All symbols resolve with bounded concurrency", func(t *testing.T) {
+		provider := &slowPriceFallback{delay: 5 * time.Millisecond}
+		symbols := testSymbols(20)
+
+		prices, errs := fetchPerSymbol(context.Background(), symbols, 3, func(ctx context.Context, symbol string) (float64, error) {
+			return provider.GetPrice(ctx, symbol+priceFallbackQuote)
+		})
+
+		assert.Empty(t, errs)
+		require.Len(t, prices, len(symbols))
+		for _, symbol := range symbols {
+			assert.Contains(t, prices, strings.ToUpper(symbol))
+		}
+		assert.LessOrEqual(t, atomic.LoadInt32(&provider.maxInFlight), int32(3))
+		assert.Greater(t, atomic.LoadInt32(&provider.maxInFlight), int32(1), "requests should overlap")
+	})
+
+	t.Run("Failures are reported per symbol", func(t *testing.T) {
+		provider := &slowPriceFallback{}
+
+		prices, errs := fetchPerSymbol(context.Background(), []string{"btc", "unknown", "BTC"}, 2, func(ctx context.Context, symbol string) (float64, error) {
+			return provider.GetPrice(ctx, symbol+priceFallbackQuote)
+		})
+
+		assert.Equal(t, map[string]float64{"BTC": 7}, prices, "symbols are upper-cased and deduplicated")
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs["UNKNOWN"], "invalid symbol")
+		assert.Equal(t, "UNKNOWN: invalid symbol", joinSymbolErrors(errs))
+	})
+
+	t.Run("Cancelled context stops new requests", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var calls int32
+
+		prices, errs := fetchPerSymbol(ctx, []string{"BTC", "ETH"}, 2, func(ctx context.Context, symbol string) (float64, error) {
+			atomic.AddInt32(&calls, 1)
+			return 1, nil
+		})
+
+		assert.Empty(t, prices)
+		assert.Len(t, errs, 2)
+		assert.Zero(t, atomic.LoadInt32(&calls))
+	})
+}
+
+func TestBinancePriceSource_Concurrency(t *testing.T) {
+	provider := &slowPriceFallback{delay: 5 * time.Millisecond}
+	source := NewBinancePriceSource(provider, 4)
+
+	prices, err := source.GetPrices(context.Background(), testSymbols(12))
+
+	require.NoError(t, err)
+	assert.Len(t, prices, 12)
+	assert.LessOrEqual(t, atomic.LoadInt32(&provider.maxInFlight), int32(4))
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	realizedPriceIndicatorName = "realized_price"
+	realizedPriceCacheKey      = "realized_price_snapshot"
+	realizedPriceCacheTTL      = 5 * time.Minute
+
+	// realizedPriceCalcVersion follows the realized cap model shared with MVRV
+	realizedPriceCalcVersion uint = 1
+)
+
+// realizedPriceSnapshot is the cached result of a realized price calculation
+type realizedPriceSnapshot struct {
+	Price             float64   `json:"price"`
+	RealizedPrice     float64   `json:"realized_price"`
+	MarketCap         float64   `json:"market_cap"`
+	RealizedCap       float64   `json:"realized_cap"`
+	CirculatingSupply float64   `json:"circulating_supply"`
+	CalculatedAt      time.Time `json:"calculated_at"`
+	// SourceUpdatedAt is CoinGecko's last_updated for the market data used
+	SourceUpdatedAt time.Time `json:"source_updated_at"`
+}
+
+// realizedPriceServiceImpl implements the IndicatorService interface for realized price and
+// realized cap, sharing the MVRV service's CoinGecko fetch and realized cap model
+type realizedPriceServiceImpl struct {
+	recomputeGuarded
+
+	coinGecko     *coinGeckoBitcoinClient
+	indicatorRepo repositories.IndicatorRepository
+	cache         services.CacheService
+	logger        logger.Logger
+	events        *extremeBandTracker
+}
+
+// NewRealizedPriceService creates a new realized price service
+func NewRealizedPriceService(
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.IndicatorService {
+	return NewRealizedPriceServiceWithBaseURL(indicatorRepo, cache, logger, external.CoinGeckoBaseURL)
+}
+
+// NewRealizedPriceServiceWithBaseURL creates a new realized price service with configurable base URL (for testing)
+func NewRealizedPriceServiceWithBaseURL(
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+	baseURL string,
+) services.IndicatorService {
+	s := &realizedPriceServiceImpl{
+		coinGecko:     newCoinGeckoBitcoinClient(baseURL, logger),
+		indicatorRepo: indicatorRepo,
+		cache:         cache,
+		logger:        logger,
+		events:        newExtremeBandTracker(nil, logger),
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, realizedPriceIndicatorName, realizedPriceCacheTTL, logger)
+	return s
+}
+
+// Calculate computes the current realized price and realized cap
+func (s *realizedPriceServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	log.Info("Starting realized price calculation")
+
+	// Market data is always fetched so a changed source is never hidden by the cache; the
+	// realized price is only recomputed when CoinGecko's last_updated moves
+	btcData, err := s.coinGecko.requestBitcoinData(ctx)
+	if err != nil {
+		return nil, errors.External("CoinGecko", "failed to calculate realized price", err)
+	}
+
+	fresh := false
+	var snapshot realizedPriceSnapshot
+	compute := func() (interface{}, error) {
+		fresh = true
+		return calculateRealizedPrice(btcData)
+	}
+
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, sourceCacheKey(realizedPriceCacheKey, btcData.LastUpdated), &snapshot, realizedPriceCacheTTL, compute)
+	} else {
+		var value interface{}
+		if value, err = compute(); err == nil {
+			snapshot = *value.(*realizedPriceSnapshot)
+		}
+	}
+	if err != nil {
+		return nil, errors.External("CoinGecko", "failed to calculate realized price", err)
+	}
+
+	ratio := snapshot.Price / snapshot.RealizedPrice
+	classification, riskLevel, status := classifyRealizedPrice(ratio)
+	indicator := &entities.Indicator{
+		Name:        realizedPriceIndicatorName,
+		Type:        "market",
+		Value:       snapshot.RealizedPrice,
+		Change:      fmt.Sprintf("%+.1f%%", (ratio-1)*100),
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: "Average price at which circulating BTC last moved (realized cap / supply)",
+		Source:      entities.DataSourceCoinGecko,
+		Confidence:  0.85, // Same realized cap model as MVRV
+		CalcVersion: realizedPriceCalcVersion,
+		Timestamp:   snapshot.CalculatedAt,
+		Metadata: map[string]interface{}{
+			"price":                   snapshot.Price,
+			"realized_price":          snapshot.RealizedPrice,
+			"market_cap":              snapshot.MarketCap,
+			"realized_cap":            snapshot.RealizedCap,
+			"circulating_supply":      snapshot.CirculatingSupply,
+			"price_to_realized_ratio": ratio,
+			"classification":          classification,
+		},
+	}
+	if !snapshot.SourceUpdatedAt.IsZero() {
+		indicator.Metadata["source_updated_at"] = snapshot.SourceUpdatedAt
+	}
+
+	// Only persist newly computed values, not results reused for unchanged source data
+	if fresh && s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save realized price indicator to database", "error", err)
+		}
+	}
+
+	s.events.observe(ctx, indicator)
+	return indicator, nil
+}
+
+// GetHistoricalData retrieves stored realized price values
+func (s *realizedPriceServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical realized price data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, realizedPriceIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored realized price if it is fresh, otherwise recalculates it
+func (s *realizedPriceServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, realizedPriceIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > realizedPriceCacheTTL {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
+
+// SetEventPublisher sets where extreme band entry events are published
+func (s *realizedPriceServiceImpl) SetEventPublisher(publisher services.EventPublisher) {
+	s.events.setPublisher(publisher)
+}
+
+// calculateRealizedPrice runs the MVRV realized cap model and divides by circulating supply
+func calculateRealizedPrice(btcData *CoinGeckoBitcoinData) (*realizedPriceSnapshot, error) {
+	current := currentMVRVData(btcData, simulateMVRVHistory(btcData))
+	if current.CircSupply <= 0 {
+		return nil, fmt.Errorf("circulating supply unavailable")
+	}
+	if current.RealizedCap <= 0 {
+		return nil, fmt.Errorf("realized cap unavailable")
+	}
+
+	return &realizedPriceSnapshot{
+		Price:             current.Price,
+		RealizedPrice:     current.RealizedCap / current.CircSupply,
+		MarketCap:         current.MarketCap,
+		RealizedCap:       current.RealizedCap,
+		CirculatingSupply: current.CircSupply,
+		CalculatedAt:      time.Now(),
+		SourceUpdatedAt:   btcData.LastUpdated,
+	}, nil
+}
+
+// classifyRealizedPrice maps the price to realized price ratio to a classification, risk level and status
+func classifyRealizedPrice(ratio float64) (classification, riskLevel, status string) {
+	switch {
+	case ratio < 1:
+		return "below_realized", "extreme_low", "Price below realized price - average holder at a loss, historically a cycle bottom zone"
+	case ratio < 1.5:
+		return "near_realized", "low", "Price near realized price - fair value accumulation zone"
+	case ratio < 2.5:
+		return "above_realized", "medium", "Price well above realized price - monitor for overheating"
+	case ratio < 3.5:
+		return "far_above_realized", "high", "Price far above realized price - consider taking profits"
+	default:
+		return "extreme_premium", "extreme_high", "Extreme premium to realized price - historically near cycle tops"
+	}
+}
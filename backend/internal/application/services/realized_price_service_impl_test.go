@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// coinGeckoBitcoinServer serves a fixed CoinGecko /coins/bitcoin payload
+func coinGeckoBitcoinServer(t *testing.T, price, marketCap, supply float64) *httptest.Server {
+	var data CoinGeckoBitcoinData
+	data.MarketData.CurrentPrice.USD = price
+	data.MarketData.MarketCap.USD = marketCap
+	data.MarketData.CirculatingSupply = supply
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/coins/bitcoin" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRealizedPriceService_MatchesMVRV(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+	server := coinGeckoBitcoinServer(t, 43000.0, 850000000000.0, 19800000.0)
+
+	// MVRV with its cache passing straight through to the fetcher
+	mvrvCache := testutil.NewMockCacheService()
+	mvrvCache.On("GetOrSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Run(func(args mock.Arguments) {
+		value, err := args.Get(4).(func() (interface{}, error))()
+		require.NoError(t, err)
+		*args.Get(2).(*CoinGeckoBitcoinData) = *value.(*CoinGeckoBitcoinData)
+	})
+	mvrvRepo := &testutil.MockIndicatorRepository{}
+	mvrvRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mvrv, err := NewMVRVServiceWithBaseURL(mvrvRepo, nil, mvrvCache, log, server.URL).Calculate(ctx, nil)
+	require.NoError(t, err)
+	require.NotContains(t, mvrv.Metadata, "fallback")
+
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	service := NewRealizedPriceServiceWithBaseURL(repo, cache.NewCacheService(nil, log), log, server.URL)
+
+	indicator, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "realized_price", indicator.Name)
+	assert.InDelta(t, mvrv.Metadata["realized_cap"].(float64), indicator.Metadata["realized_cap"].(float64), 1e-3)
+	assert.InDelta(t, mvrv.Metadata["realized_cap"].(float64)/19800000.0, indicator.Value, 1e-6)
+	assert.Equal(t, 43000.0, indicator.Metadata["price"])
+	assert.InDelta(t, 43000.0/indicator.Value, indicator.Metadata["price_to_realized_ratio"].(float64), 1e-9)
+	assert.Equal(t, realizedPriceCalcVersion, indicator.CalcVersion)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+
+	// A cache hit is not persisted again
+	_, err = service.Calculate(ctx, nil)
+	require.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestRealizedPriceService_ReusesResultForUnchangedSource(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+
+	var data CoinGeckoBitcoinData
+	data.MarketData.CurrentPrice.USD = 43000.0
+	data.MarketData.MarketCap.USD = 850000000000.0
+	data.MarketData.CirculatingSupply = 19800000.0
+	data.LastUpdated = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(data)
+	}))
+	t.Cleanup(server.Close)
+
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	service := NewRealizedPriceServiceWithBaseURL(repo, cache.NewCacheService(nil, log), log, server.URL)
+
+	first, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, data.LastUpdated, first.Metadata["source_updated_at"])
+
+	// CoinGecko is asked again, but the identical last_updated means no recompute
+	second, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, first.Timestamp, second.Timestamp)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+
+	data.MarketData.CurrentPrice.USD = 45000.0
+	data.LastUpdated = data.LastUpdated.Add(time.Minute)
+	third, err := service.Calculate(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 45000.0, third.Metadata["price"])
+	repo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestRealizedPriceService_MissingSupply(t *testing.T) {
+	server := coinGeckoBitcoinServer(t, 43000.0, 850000000000.0, 0)
+	service := NewRealizedPriceServiceWithBaseURL(nil, nil, logger.New("test"), server.URL)
+
+	_, err := service.Calculate(context.Background(), nil)
+	require.Error(t, err)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeExternal))
+}
+
+func TestClassifyRealizedPrice(t *testing.T) {
+	tests := []struct {
+		ratio     float64
+		riskLevel string
+	}{
+		{0.8, "extreme_low"},
+		{1.2, "low"},
+		{2.0, "medium"},
+		{3.0, "high"},
+		{4.0, "extreme_high"},
+	}
+
+	for _, tt := range tests {
+		classification, riskLevel, status := classifyRealizedPrice(tt.ratio)
+		assert.Equal(t, tt.riskLevel, riskLevel, "ratio %v", tt.ratio)
+		assert.NotEmpty(t, classification)
+		assert.NotEmpty(t, status)
+	}
+}
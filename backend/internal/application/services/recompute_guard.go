@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	recomputeLockKeyPrefix = "indicator_recompute_lock_"
+	// recomputePollInterval is how often callers without a stale value check for the fresh one
+	recomputePollInterval = 100 * time.Millisecond
+)
+
+// RecomputeGuardConfig controls how indicator services serialize stale recomputations
+type RecomputeGuardConfig struct {
+	// Lock is shared by every caller that may recompute; nil uses an in-process lock
+	Lock services.RecomputeLock
+	// LockTTL bounds how long a crashed or hung recomputation can block others
+	LockTTL time.Duration
+	// WaitTimeout is how long a caller with no stored value waits for another caller's
+	// recomputation before running its own
+	WaitTimeout time.Duration
+}
+
+// DefaultRecomputeGuardConfig returns an in-process lock with conservative timeouts
+func DefaultRecomputeGuardConfig() RecomputeGuardConfig {
+	return RecomputeGuardConfig{
+		LockTTL:     2 * time.Minute,
+		WaitTimeout: 10 * time.Second,
+	}
+}
+
+// RecomputeGuarded is implemented by indicator services whose GetLatest recomputes stale values
+type RecomputeGuarded interface {
+	SetRecomputeGuard(cfg RecomputeGuardConfig)
+}
+
+// indicatorCalculator is the part of services.IndicatorService a recomputation needs
+type indicatorCalculator interface {
+	Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error)
+}
+
+// recomputeGuarded is embedded by indicator services whose GetLatest recomputes stale
+// values. It provides their recalculate and SetRecomputeGuard.
+type recomputeGuarded struct {
+	guard         *recomputeGuard
+	calculator    indicatorCalculator
+	repo          repositories.IndicatorRepository
+	indicatorName string
+	maxAge        time.Duration
+}
+
+// newRecomputeGuarded recomputes the named indicator with calculator's Calculate once its
+// stored value is older than maxAge, behind an in-process lock until SetRecomputeGuard is called
+func newRecomputeGuarded(
+	calculator indicatorCalculator,
+	repo repositories.IndicatorRepository,
+	indicatorName string,
+	maxAge time.Duration,
+	logger logger.Logger,
+) recomputeGuarded {
+	return recomputeGuarded{
+		guard:         newRecomputeGuard(RecomputeGuardConfig{}, logger),
+		calculator:    calculator,
+		repo:          repo,
+		indicatorName: indicatorName,
+		maxAge:        maxAge,
+	}
+}
+
+// recalculate runs Calculate behind the recompute guard so concurrent stale reads share one run
+func (g *recomputeGuarded) recalculate(ctx context.Context, stale *entities.Indicator) (*entities.Indicator, error) {
+	return g.guard.recompute(ctx, g.repo, g.indicatorName, g.maxAge, stale, func(ctx context.Context) (*entities.Indicator, error) {
+		return g.calculator.Calculate(ctx, nil)
+	})
+}
+
+// SetRecomputeGuard replaces the lock and timeouts used for stale recomputations
+func (g *recomputeGuarded) SetRecomputeGuard(cfg RecomputeGuardConfig) {
+	g.guard = newRecomputeGuard(cfg, g.guard.logger)
+}
+
+// recomputeGuard lets one caller at a time run an indicator's Calculate when its stored
+// value is stale. The others get the stale value straight away, or wait for the new one
+// when there is nothing stored yet.
+type recomputeGuard struct {
+	lock        services.RecomputeLock
+	lockTTL     time.Duration
+	waitTimeout time.Duration
+	logger      logger.Logger
+}
+
+func newRecomputeGuard(cfg RecomputeGuardConfig, logger logger.Logger) *recomputeGuard {
+	defaults := DefaultRecomputeGuardConfig()
+	if cfg.Lock == nil {
+		cfg.Lock = cache.NewLocalRecomputeLock()
+	}
+	if cfg.LockTTL <= 0 {
+		cfg.LockTTL = defaults.LockTTL
+	}
+	if cfg.WaitTimeout <= 0 {
+		cfg.WaitTimeout = defaults.WaitTimeout
+	}
+
+	return &recomputeGuard{
+		lock:        cfg.Lock,
+		lockTTL:     cfg.LockTTL,
+		waitTimeout: cfg.WaitTimeout,
+		logger:      logger,
+	}
+}
+
+// recompute runs calculate for the named indicator unless another caller already is.
+// stale is the current stored value, or nil when none exists; maxAge is how old a stored
+// value may be before it needs recomputing.
+func (g *recomputeGuard) recompute(
+	ctx context.Context,
+	repo repositories.IndicatorRepository,
+	name string,
+	maxAge time.Duration,
+	stale *entities.Indicator,
+	calculate func(context.Context) (*entities.Indicator, error),
+) (*entities.Indicator, error) {
+	log := g.logger.WithContext(ctx)
+	key := recomputeLockKeyPrefix + name
+	deadline := time.Now().Add(g.waitTimeout)
+
+	for {
+		acquired, err := g.lock.TryAcquire(ctx, key, g.lockTTL)
+		if err != nil {
+			log.Warn("Recompute lock unavailable, recomputing without it", "indicator", name, "error", err)
+			return calculate(ctx)
+		}
+
+		if acquired {
+			defer func() {
+				if err := g.lock.Release(context.WithoutCancel(ctx), key); err != nil {
+					log.Warn("Failed to release recompute lock", "indicator", name, "error", err)
+				}
+			}()
+
+			// Another caller may have finished a recomputation between our read and the lock
+			if fresh, err := repo.GetLatest(ctx, name); err == nil && time.Since(fresh.Timestamp) <= maxAge {
+				return fresh, nil
+			}
+			return calculate(ctx)
+		}
+
+		if stale != nil {
+			log.Debug("Recompute already running, returning stale value", "indicator", name)
+			return stale, nil
+		}
+
+		if time.Now().After(deadline) {
+			log.Warn("Timed out waiting for recompute, calculating directly", "indicator", name)
+			return calculate(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(recomputePollInterval):
+		}
+
+		fresh, err := repo.GetLatest(ctx, name)
+		if err == nil {
+			return fresh, nil
+		}
+		if !errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// storedIndicatorRepo keeps the latest created indicator so concurrent GetLatest calls see
+// a recomputation as soon as it is saved
+type storedIndicatorRepo struct {
+	*testutil.MockIndicatorRepository
+	mu     sync.Mutex
+	latest *entities.Indicator
+}
+
+func (r *storedIndicatorRepo) GetLatest(ctx context.Context, name string) (*entities.Indicator, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.latest == nil {
+		return nil, errors.NotFound("Indicator")
+	}
+	latest := *r.latest
+	return &latest, nil
+}
+
+func (r *storedIndicatorRepo) Create(ctx context.Context, indicator *entities.Indicator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latest = indicator
+	return nil
+}
+
+// slowListingsClient holds each listings call long enough for every caller to pile up
+func slowListingsClient() *testutil.MockCoinMarketCapClient {
+	client := &testutil.MockCoinMarketCapClient{}
+	client.On("GetListingsLatest", mock.Anything, altSeasonListingLimit, "USD").
+		After(200*time.Millisecond).
+		Return(&external.ListingsLatestResponse{
+			Data: []external.CryptoPriceData{
+				listing("BTC", pct(10)),
+				listing("ETH", pct(40)),
+				listing("SOL", pct(5)),
+			},
+		}, nil)
+	return client
+}
+
+// getLatestConcurrently calls GetLatest from n goroutines at once
+func getLatestConcurrently(t *testing.T, service services.IndicatorService, n int) []*entities.Indicator {
+	results := make([]*entities.Indicator, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = service.GetLatest(context.Background())
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i, err := range errs {
+		require.NoError(t, err, "caller %d", i)
+	}
+	return results
+}
+
+func TestRecomputeGuard_StaleGetLatestCalculatesOnce(t *testing.T) {
+	log := logger.New("test")
+	client := slowListingsClient()
+	stale := &entities.Indicator{Name: altSeasonIndicatorName, Value: 20, Timestamp: time.Now().Add(-2 * altSeasonCacheTTL)}
+	repo := &storedIndicatorRepo{MockIndicatorRepository: &testutil.MockIndicatorRepository{}, latest: stale}
+
+	// No snapshot cache, so every Calculate would hit CoinMarketCap
+	service := NewAltSeasonService(client, repo, nil, log)
+
+	results := getLatestConcurrently(t, service, 20)
+
+	client.AssertNumberOfCalls(t, "GetListingsLatest", 1)
+
+	recomputed := 0
+	for _, indicator := range results {
+		if indicator.Value == stale.Value {
+			continue
+		}
+		recomputed++
+		assert.InDelta(t, 50.0, indicator.Value, 1e-9)
+	}
+	assert.Equal(t, 1, recomputed, "only the lock holder returns the new value; the rest get the stale one")
+}
+
+func TestRecomputeGuard_MissingValueWaitsForRecompute(t *testing.T) {
+	log := logger.New("test")
+	client := slowListingsClient()
+	repo := &storedIndicatorRepo{MockIndicatorRepository: &testutil.MockIndicatorRepository{}}
+
+	service := NewAltSeasonService(client, repo, nil, log)
+
+	results := getLatestConcurrently(t, service, 20)
+
+	client.AssertNumberOfCalls(t, "GetListingsLatest", 1)
+	for _, indicator := range results {
+		assert.InDelta(t, 50.0, indicator.Value, 1e-9, "waiters should pick up the recomputed value")
+	}
+}
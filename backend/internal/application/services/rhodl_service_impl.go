@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	rhodlIndicatorName = "rhodl"
+	rhodlCacheKey      = "rhodl_bands"
+	rhodlCacheTTL      = 1 * time.Hour
+
+	// rhodlCalcVersion is stored with each ratio; bump it when the band model changes
+	rhodlCalcVersion uint = 1
+)
+
+// RHODL risk bands
+const (
+	RHODLBandCycleBottom  = "cycle_bottom"
+	RHODLBandAccumulation = "accumulation"
+	RHODLBandNeutral      = "neutral"
+	RHODLBandOverheated   = "overheated"
+	RHODLBandCycleTop     = "cycle_top"
+)
+
+// priceHistoryBandApproximation describes how priceHistoryHODLBandProvider derives its bands
+const priceHistoryBandApproximation = "average BTC price over each band's window stands in for the band's realized price"
+
+// rhodlServiceImpl implements the IndicatorService interface for the Realized HODL ratio,
+// the realized value of the 1 week HODL band over the 1-2 year band
+type rhodlServiceImpl struct {
+	recomputeGuarded
+
+	provider      services.HODLBandProvider
+	indicatorRepo repositories.IndicatorRepository
+	cache         services.CacheService
+	logger        logger.Logger
+	events        *extremeBandTracker
+}
+
+// NewRHODLService creates a new RHODL ratio service backed by the given band provider
+func NewRHODLService(
+	provider services.HODLBandProvider,
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.IndicatorService {
+	s := &rhodlServiceImpl{
+		provider:      provider,
+		indicatorRepo: indicatorRepo,
+		cache:         cache,
+		logger:        logger,
+		events:        newExtremeBandTracker(nil, logger),
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, rhodlIndicatorName, rhodlCacheTTL, logger)
+	return s
+}
+
+// Calculate computes the current RHODL ratio from the provider's HODL bands
+func (s *rhodlServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	log.Info("Starting RHODL ratio calculation", "provider", s.provider.Name())
+
+	fresh := false
+	var bands entities.HODLBands
+	fetch := func() (interface{}, error) {
+		fresh = true
+		return s.provider.GetHODLBands(ctx)
+	}
+
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, rhodlCacheKey, &bands, rhodlCacheTTL, fetch)
+	} else {
+		var value interface{}
+		if value, err = fetch(); err == nil {
+			bands = *value.(*entities.HODLBands)
+		}
+	}
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, err
+		}
+		return nil, errors.External(s.provider.Name(), "failed to calculate RHODL ratio", err)
+	}
+	if bands.OneToTwoYears <= 0 {
+		return nil, errors.External(s.provider.Name(), "1-2 year HODL band is empty", nil)
+	}
+
+	ratio := bands.OneWeek / bands.OneToTwoYears
+	band, riskLevel, status := classifyRHODL(ratio)
+
+	confidence := 0.85
+	metadata := map[string]interface{}{
+		"one_week_band":         bands.OneWeek,
+		"one_to_two_years_band": bands.OneToTwoYears,
+		"band":                  band,
+		"approximation":         bands.Approximation != "",
+	}
+	if bands.Approximation != "" {
+		confidence = 0.5 // Proxy bands only track the direction of the real ratio
+		metadata["approximation_method"] = bands.Approximation
+	}
+
+	indicator := &entities.Indicator{
+		Name:        rhodlIndicatorName,
+		Type:        "onchain",
+		Value:       ratio,
+		Change:      fmt.Sprintf("%.2fx", ratio),
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: "Realized value of the 1 week HODL band relative to the 1-2 year band",
+		Source:      providerDataSource(s.provider.Name()),
+		Confidence:  confidence,
+		CalcVersion: rhodlCalcVersion,
+		Timestamp:   bands.Timestamp,
+		Metadata:    metadata,
+	}
+
+	// Only persist newly computed values, not cache hits
+	if fresh && s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save RHODL indicator to database", "error", err)
+		}
+	}
+
+	s.events.observe(ctx, indicator)
+	return indicator, nil
+}
+
+// GetHistoricalData retrieves stored RHODL ratio values
+func (s *rhodlServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical RHODL data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, rhodlIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored RHODL ratio if it is fresh, otherwise recalculates it
+func (s *rhodlServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, rhodlIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > rhodlCacheTTL {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
+
+// SetEventPublisher sets where extreme band entry events are published
+func (s *rhodlServiceImpl) SetEventPublisher(publisher services.EventPublisher) {
+	s.events.setPublisher(publisher)
+}
+
+// classifyRHODL maps the RHODL ratio to a risk band, risk level and status. A high ratio
+// means new money dominates realized value relative to long-term holders.
+func classifyRHODL(ratio float64) (band, riskLevel, status string) {
+	switch {
+	case ratio < 0.75:
+		return RHODLBandCycleBottom, "extreme_low", "Short-term realized value well below 1-2 year holders - historically a cycle bottom zone"
+	case ratio < 1.25:
+		return RHODLBandAccumulation, "low", "Short-term and 1-2 year realized value in balance - accumulation zone"
+	case ratio < 2.5:
+		return RHODLBandNeutral, "medium", "New money outweighs 1-2 year holders - mid-cycle"
+	case ratio < 4:
+		return RHODLBandOverheated, "high", "New money heavily outweighs 1-2 year holders - market overheating"
+	default:
+		return RHODLBandCycleTop, "extreme_high", "Extreme short-term realized value - historically near cycle tops"
+	}
+}
+
+// priceHistoryHODLBandProvider approximates HODL bands from stored BTC prices. Without UTXO
+// age data the realized price of each band is taken as the average price over its window,
+// which tracks the RHODL ratio's direction but not its on-chain magnitude.
+type priceHistoryHODLBandProvider struct {
+	marketDataRepo repositories.MarketDataRepository
+	now            func() time.Time
+}
+
+// NewPriceHistoryHODLBandProvider creates a HODL band provider that approximates the bands
+// from stored BTC price history
+func NewPriceHistoryHODLBandProvider(marketDataRepo repositories.MarketDataRepository) services.HODLBandProvider {
+	return &priceHistoryHODLBandProvider{
+		marketDataRepo: marketDataRepo,
+		now:            time.Now,
+	}
+}
+
+// Name returns the provider name used as the indicator source
+func (p *priceHistoryHODLBandProvider) Name() string {
+	return string(entities.DataSourcePriceHistory)
+}
+
+// GetHODLBands averages BTC prices over the last week and over one to two years ago
+func (p *priceHistoryHODLBandProvider) GetHODLBands(ctx context.Context) (*entities.HODLBands, error) {
+	now := p.now()
+	weekAgo := now.AddDate(0, 0, -7)
+	oneYearAgo := now.AddDate(-1, 0, 0)
+	twoYearsAgo := now.AddDate(-2, 0, 0)
+
+	history, err := p.marketDataRepo.GetPriceHistory(ctx, "BTC", twoYearsAgo, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var weekSum, longSum float64
+	var weekCount, longCount int
+	for _, price := range history {
+		at := price.LastUpdated
+		if at.IsZero() {
+			at = price.CreatedAt
+		}
+		if price.Price <= 0 {
+			continue
+		}
+		switch {
+		case !at.Before(weekAgo):
+			weekSum += price.Price
+			weekCount++
+		case !at.After(oneYearAgo):
+			longSum += price.Price
+			longCount++
+		}
+	}
+
+	if weekCount == 0 {
+		return nil, errors.NotFound("BTC price history for the last week")
+	}
+	if longCount == 0 {
+		return nil, errors.NotFound("BTC price history from one to two years ago")
+	}
+
+	return &entities.HODLBands{
+		OneWeek:       weekSum / float64(weekCount),
+		OneToTwoYears: longSum / float64(longCount),
+		Approximation: priceHistoryBandApproximation,
+		Timestamp:     now,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticHODLBandProvider returns fixed bands
+type staticHODLBandProvider struct {
+	bands *entities.HODLBands
+	err   error
+}
+
+func (p *staticHODLBandProvider) Name() string { return "static" }
+
+func (p *staticHODLBandProvider) GetHODLBands(ctx context.Context) (*entities.HODLBands, error) {
+	return p.bands, p.err
+}
+
+func TestClassifyRHODL(t *testing.T) {
+	tests := []struct {
+		ratio     float64
+		band      string
+		riskLevel string
+	}{
+		{0.5, RHODLBandCycleBottom, "extreme_low"},
+		{0.75, RHODLBandAccumulation, "low"},
+		{1.0, RHODLBandAccumulation, "low"},
+		{1.25, RHODLBandNeutral, "medium"},
+		{3.0, RHODLBandOverheated, "high"},
+		{4.0, RHODLBandCycleTop, "extreme_high"},
+		{12.0, RHODLBandCycleTop, "extreme_high"},
+	}
+
+	for _, tt := range tests {
+		band, riskLevel, status := classifyRHODL(tt.ratio)
+		assert.Equal(t, tt.band, band, "ratio %v", tt.ratio)
+		assert.Equal(t, tt.riskLevel, riskLevel, "ratio %v", tt.ratio)
+		assert.NotEmpty(t, status)
+	}
+}
+
+func TestRHODLService_Calculate(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("marks approximated bands", func(t *testing.T) {
+		repo := &testutil.MockIndicatorRepository{}
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(i *entities.Indicator) bool {
+			return i.Name == rhodlIndicatorName
+		})).Return(nil)
+		provider := &staticHODLBandProvider{bands: &entities.HODLBands{
+			OneWeek: 60000, OneToTwoYears: 20000, Approximation: "proxy", Timestamp: at,
+		}}
+
+		indicator, err := NewRHODLService(provider, repo, nil, logger.New("test")).Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.InDelta(t, 3.0, indicator.Value, 1e-9)
+		assert.Equal(t, "high", indicator.RiskLevel)
+		assert.Equal(t, RHODLBandOverheated, indicator.Metadata["band"])
+		assert.Equal(t, true, indicator.Metadata["approximation"])
+		assert.Equal(t, "proxy", indicator.Metadata["approximation_method"])
+		assert.Equal(t, 0.5, indicator.Confidence)
+		assert.Equal(t, at, indicator.Timestamp)
+		assert.Equal(t, rhodlCalcVersion, indicator.CalcVersion)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("on-chain bands are not marked", func(t *testing.T) {
+		provider := &staticHODLBandProvider{bands: &entities.HODLBands{OneWeek: 10, OneToTwoYears: 20, Timestamp: at}}
+
+		indicator, err := NewRHODLService(provider, nil, nil, logger.New("test")).Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, RHODLBandCycleBottom, indicator.Metadata["band"])
+		assert.Equal(t, false, indicator.Metadata["approximation"])
+		assert.NotContains(t, indicator.Metadata, "approximation_method")
+	})
+
+	t.Run("missing history is not found", func(t *testing.T) {
+		provider := &staticHODLBandProvider{err: errors.NotFound("BTC price history")}
+
+		_, err := NewRHODLService(provider, nil, nil, logger.New("test")).Calculate(ctx, nil)
+		assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+	})
+}
+
+func TestPriceHistoryHODLBandProvider(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	price := func(daysAgo int, value float64) entities.CryptoPrice {
+		return entities.CryptoPrice{Symbol: "BTC", Price: value, LastUpdated: now.AddDate(0, 0, -daysAgo)}
+	}
+
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("GetPriceHistory", mock.Anything, "BTC", now.AddDate(-2, 0, 0), now).Return([]entities.CryptoPrice{
+		price(700, 18000),
+		price(400, 22000),
+		price(200, 40000), // Between the bands, ignored
+		price(5, 58000),
+		price(1, 62000),
+	}, nil)
+
+	provider := NewPriceHistoryHODLBandProvider(repo).(*priceHistoryHODLBandProvider)
+	provider.now = func() time.Time { return now }
+
+	bands, err := provider.GetHODLBands(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 60000.0, bands.OneWeek)
+	assert.Equal(t, 20000.0, bands.OneToTwoYears)
+	assert.NotEmpty(t, bands.Approximation)
+	assert.Equal(t, now, bands.Timestamp)
+
+	empty := &testutil.MockMarketDataRepository{}
+	empty.On("GetPriceHistory", mock.Anything, "BTC", mock.Anything, mock.Anything).
+		Return([]entities.CryptoPrice{price(1, 62000)}, nil)
+	provider = NewPriceHistoryHODLBandProvider(empty).(*priceHistoryHODLBandProvider)
+	provider.now = func() time.Time { return now }
+
+	_, err = provider.GetHODLBands(ctx)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+}
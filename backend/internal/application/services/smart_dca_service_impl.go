@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// smartDCAServiceImpl implements the SmartDCAService interface
+type smartDCAServiceImpl struct {
+	config        entities.SmartDCAConfig
+	indicatorRepo repositories.IndicatorRepository
+	logger        logger.Logger
+}
+
+// NewSmartDCAService creates a smart DCA service with the given multiplier curves. The
+// current multiplier is read from the stored MVRV and Fear & Greed indicators.
+func NewSmartDCAService(
+	config entities.SmartDCAConfig,
+	indicatorRepo repositories.IndicatorRepository,
+	logger logger.Logger,
+) (services.SmartDCAService, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Validation("Invalid smart DCA configuration", err.Error())
+	}
+	return &smartDCAServiceImpl{
+		config:        config,
+		indicatorRepo: indicatorRepo,
+		logger:        logger,
+	}, nil
+}
+
+// Multiplier averages the curve multipliers of the available readings and clamps the result
+// to the configured bounds. Without any usable reading the base amount is bought.
+func (s *smartDCAServiceImpl) Multiplier(zScore *float64, fearGreed *int) float64 {
+	total, count := 0.0, 0
+	if zScore != nil && len(s.config.ZScoreCurve) > 0 {
+		total += interpolateMultiplier(s.config.ZScoreCurve, *zScore)
+		count++
+	}
+	if fearGreed != nil && len(s.config.FearGreedCurve) > 0 {
+		total += interpolateMultiplier(s.config.FearGreedCurve, float64(*fearGreed))
+		count++
+	}
+
+	multiplier := 1.0
+	if count > 0 {
+		multiplier = total / float64(count)
+	}
+	return math.Min(math.Max(multiplier, s.config.MinMultiplier), s.config.MaxMultiplier)
+}
+
+// CurrentMultiplier returns the multiplier for the latest stored readings; an indicator that
+// has never been stored is left out
+func (s *smartDCAServiceImpl) CurrentMultiplier(ctx context.Context) (*entities.SmartDCAMultiplier, error) {
+	result := &entities.SmartDCAMultiplier{Timestamp: time.Now()}
+
+	if s.indicatorRepo != nil {
+		mvrv, err := s.latestReading(ctx, mvrvIndicatorName)
+		if err != nil {
+			return nil, err
+		}
+		if mvrv != nil {
+			zScore := mvrv.Value
+			result.MVRVZScore = &zScore
+		}
+
+		fearGreed, err := s.latestReading(ctx, fearGreedIndicatorName)
+		if err != nil {
+			return nil, err
+		}
+		if fearGreed != nil {
+			value := int(math.Round(fearGreed.Value))
+			result.FearGreed = &value
+		}
+	}
+
+	result.Multiplier = s.Multiplier(result.MVRVZScore, result.FearGreed)
+	return result, nil
+}
+
+// latestReading returns the latest stored indicator, or nil when there is none
+func (s *smartDCAServiceImpl) latestReading(ctx context.Context, name string) (*entities.Indicator, error) {
+	indicator, err := s.indicatorRepo.GetLatest(ctx, name)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to load "+name+" for smart DCA")
+	}
+	return indicator, nil
+}
+
+// Backtest buys at every point, once with the base amount and once with the amount scaled by
+// the multiplier for that point's readings
+func (s *smartDCAServiceImpl) Backtest(ctx context.Context, amount float64, points []entities.DCABacktestPoint) (*entities.DCABacktestComparison, error) {
+	if amount <= 0 {
+		return nil, errors.Validation("Purchase amount must be positive")
+	}
+	if len(points) == 0 {
+		return nil, errors.Validation("At least one backtest point is required")
+	}
+	for _, point := range points {
+		if point.Price <= 0 {
+			return nil, errors.Validation("Backtest prices must be positive", point.Date.Format(time.RFC3339))
+		}
+	}
+
+	sorted := make([]entities.DCABacktestPoint, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	fixed, _ := simulateDCA(amount, sorted, func(entities.DCABacktestPoint) float64 { return amount })
+	smart, purchases := simulateDCA(amount, sorted, func(point entities.DCABacktestPoint) float64 {
+		zScore, fearGreed := point.MVRVZScore, point.FearGreed
+		return amount * s.Multiplier(&zScore, &fearGreed)
+	})
+
+	s.logger.WithContext(ctx).Debug("Ran smart DCA backtest",
+		"points", len(sorted),
+		"fixed_invested", fixed.TotalInvested,
+		"smart_invested", smart.TotalInvested,
+	)
+
+	return &entities.DCABacktestComparison{Fixed: fixed, Smart: smart, SmartPurchases: purchases}, nil
+}
+
+// interpolateMultiplier reads value off a curve with increasing thresholds
+func interpolateMultiplier(curve []entities.SmartDCACurvePoint, value float64) float64 {
+	if value <= curve[0].Threshold {
+		return curve[0].Multiplier
+	}
+	for i := 1; i < len(curve); i++ {
+		if value <= curve[i].Threshold {
+			lo, hi := curve[i-1], curve[i]
+			ratio := (value - lo.Threshold) / (hi.Threshold - lo.Threshold)
+			return lo.Multiplier + ratio*(hi.Multiplier-lo.Multiplier)
+		}
+	}
+	return curve[len(curve)-1].Multiplier
+}
+
+// simulateDCA buys amountFor(point) at each point, in order, and values the holdings at the
+// last price. Drawdown is measured on the value per dollar invested so new purchases do not
+// hide price declines. points must be non-empty and sorted by date.
+func simulateDCA(
+	baseAmount float64,
+	points []entities.DCABacktestPoint,
+	amountFor func(entities.DCABacktestPoint) float64,
+) (*entities.DCASimulation, []entities.DCAPurchase) {
+	first, last := points[0], points[len(points)-1]
+	sim := &entities.DCASimulation{
+		Amount:    baseAmount,
+		StartDate: first.Date,
+		EndDate:   last.Date,
+	}
+	purchases := make([]entities.DCAPurchase, 0, len(points))
+
+	var peakRatio, zScoreSum float64
+	var fearGreedSum int
+	bestPrice, worstPrice := math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		amount := amountFor(point)
+		if amount > 0 {
+			quantity := amount / point.Price
+			sim.TotalInvested += amount
+			sim.TotalQuantity += quantity
+			sim.PurchaseCount++
+			zScoreSum += point.MVRVZScore
+			fearGreedSum += point.FearGreed
+
+			if point.Price < bestPrice {
+				bestPrice, sim.BestPurchaseDate = point.Price, point.Date
+			}
+			if point.Price > worstPrice {
+				worstPrice, sim.WorstPurchaseDate = point.Price, point.Date
+			}
+
+			purchases = append(purchases, entities.DCAPurchase{
+				Date:        point.Date,
+				Amount:      amount,
+				Price:       point.Price,
+				Quantity:    quantity,
+				MVRVZScore:  point.MVRVZScore,
+				FearGreed:   point.FearGreed,
+				IsSimulated: true,
+			})
+		}
+
+		if sim.TotalInvested == 0 {
+			continue
+		}
+		ratio := sim.TotalQuantity * point.Price / sim.TotalInvested
+		if ratio > peakRatio {
+			peakRatio = ratio
+		}
+		if drawdownPct := (peakRatio - ratio) / peakRatio * 100; drawdownPct > sim.MaxDrawdownPct {
+			sim.MaxDrawdownPct = drawdownPct
+			sim.MaxDrawdown = (peakRatio - ratio) * sim.TotalInvested
+		}
+	}
+
+	sim.FinalValue = sim.TotalQuantity * last.Price
+	if sim.PurchaseCount > 0 {
+		sim.TotalReturn = sim.FinalValue - sim.TotalInvested
+		sim.TotalReturnPct = sim.TotalReturn / sim.TotalInvested * 100
+		sim.AvgMVRVAtPurchase = zScoreSum / float64(sim.PurchaseCount)
+		sim.AvgFearGreedAtPurchase = int(math.Round(float64(fearGreedSum) / float64(sim.PurchaseCount)))
+
+		if years := last.Date.Sub(first.Date).Hours() / 24 / 365; years > 0 && sim.FinalValue > 0 {
+			sim.AnnualizedReturn = (math.Pow(sim.FinalValue/sim.TotalInvested, 1/years) - 1) * 100
+		}
+	}
+
+	return sim, purchases
+}
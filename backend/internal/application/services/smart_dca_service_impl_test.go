@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSmartDCAService(t *testing.T, repo *testutil.MockIndicatorRepository) *smartDCAServiceImpl {
+	t.Helper()
+	service, err := NewSmartDCAService(entities.DefaultSmartDCAConfig, repo, logger.New("test"))
+	require.NoError(t, err)
+	return service.(*smartDCAServiceImpl)
+}
+
+func TestSmartDCAConfig_Validate(t *testing.T) {
+	assert.NoError(t, entities.DefaultSmartDCAConfig.Validate())
+
+	tests := []struct {
+		name   string
+		config entities.SmartDCAConfig
+	}{
+		{"No curves", entities.SmartDCAConfig{MinMultiplier: 0.5, MaxMultiplier: 2}},
+		{"Inverted bounds", entities.SmartDCAConfig{
+			ZScoreCurve:   []entities.SmartDCACurvePoint{{Threshold: 0, Multiplier: 1}},
+			MinMultiplier: 2, MaxMultiplier: 1,
+		}},
+		{"Multiplier above max", entities.SmartDCAConfig{
+			ZScoreCurve:   []entities.SmartDCACurvePoint{{Threshold: 0, Multiplier: 3}},
+			MinMultiplier: 0.5, MaxMultiplier: 2,
+		}},
+		{"Thresholds out of order", entities.SmartDCAConfig{
+			FearGreedCurve: []entities.SmartDCACurvePoint{{Threshold: 50, Multiplier: 1}, {Threshold: 20, Multiplier: 1.5}},
+			MinMultiplier:  0.5, MaxMultiplier: 2,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.config.Validate())
+			_, err := NewSmartDCAService(tt.config, nil, logger.New("test"))
+			assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+		})
+	}
+}
+
+func TestSmartDCAService_Multiplier(t *testing.T) {
+	s := newTestSmartDCAService(t, nil)
+	z := func(v float64) *float64 { return &v }
+	fg := func(v int) *int { return &v }
+
+	// Cheaper markets buy more, on either signal
+	assert.Greater(t, s.Multiplier(z(-1), nil), s.Multiplier(z(1), nil))
+	assert.Greater(t, s.Multiplier(z(1), nil), 1.0)
+	assert.Less(t, s.Multiplier(z(6), nil), 1.0)
+	assert.Greater(t, s.Multiplier(nil, fg(15)), s.Multiplier(nil, fg(80)))
+
+	// Curve points and interpolation between them
+	assert.InDelta(t, 1.5, s.Multiplier(z(0), nil), 1e-9)
+	assert.InDelta(t, 1.25, s.Multiplier(z(1), nil), 1e-9)
+	assert.InDelta(t, 1.0, s.Multiplier(nil, fg(50)), 1e-9)
+
+	// Both signals are averaged
+	assert.InDelta(t, 1.25, s.Multiplier(z(0), fg(50)), 1e-9)
+
+	// Extremes stay within the bounds, and no readings buy the base amount
+	assert.Equal(t, 2.0, s.Multiplier(z(-5), fg(0)))
+	assert.Equal(t, 0.25, s.Multiplier(z(12), fg(100)))
+	assert.Equal(t, 1.0, s.Multiplier(nil, nil))
+}
+
+func TestSmartDCAService_CurrentMultiplier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("uses stored readings", func(t *testing.T) {
+		repo := new(testutil.MockIndicatorRepository)
+		repo.On("GetLatest", mock.Anything, mvrvIndicatorName).Return(&entities.Indicator{Value: 6}, nil)
+		repo.On("GetLatest", mock.Anything, fearGreedIndicatorName).Return(&entities.Indicator{Value: 80}, nil)
+
+		result, err := newTestSmartDCAService(t, repo).CurrentMultiplier(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.MVRVZScore)
+		require.NotNil(t, result.FearGreed)
+		assert.Equal(t, 6.0, *result.MVRVZScore)
+		assert.Equal(t, 80, *result.FearGreed)
+		assert.Less(t, result.Multiplier, 1.0)
+	})
+
+	t.Run("missing indicator is left out", func(t *testing.T) {
+		repo := new(testutil.MockIndicatorRepository)
+		repo.On("GetLatest", mock.Anything, mvrvIndicatorName).Return(nil, errors.NotFound("indicator"))
+		repo.On("GetLatest", mock.Anything, fearGreedIndicatorName).Return(&entities.Indicator{Value: 10}, nil)
+
+		result, err := newTestSmartDCAService(t, repo).CurrentMultiplier(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, result.MVRVZScore)
+		assert.Equal(t, 2.0, result.Multiplier)
+	})
+
+	t.Run("repository failure", func(t *testing.T) {
+		repo := new(testutil.MockIndicatorRepository)
+		repo.On("GetLatest", mock.Anything, mvrvIndicatorName).Return(nil, errors.Internal("db down", nil))
+
+		_, err := newTestSmartDCAService(t, repo).CurrentMultiplier(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestSmartDCAService_Backtest(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSmartDCAService(t, nil)
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A cycle: expensive, crash to cheap, recovery
+	readings := []struct {
+		price     float64
+		zScore    float64
+		fearGreed int
+	}{
+		{60000, 6, 85}, {45000, 3, 60}, {30000, 1, 30}, {18000, -0.5, 10},
+		{16000, -0.6, 8}, {22000, 0.5, 35}, {30000, 1.5, 55}, {42000, 2.5, 70},
+	}
+	points := make([]entities.DCABacktestPoint, len(readings))
+	for i, r := range readings {
+		points[len(readings)-1-i] = entities.DCABacktestPoint{
+			Date:       start.AddDate(0, i, 0),
+			Price:      r.price,
+			MVRVZScore: r.zScore,
+			FearGreed:  r.fearGreed,
+		}
+	}
+
+	const amount = 100.0
+	result, err := s.Backtest(ctx, amount, points)
+	require.NoError(t, err)
+
+	assert.Equal(t, len(points), result.Fixed.PurchaseCount)
+	assert.InDelta(t, amount*float64(len(points)), result.Fixed.TotalInvested, 1e-9)
+	assert.Equal(t, start, result.Fixed.StartDate, "points are replayed in date order")
+
+	// Smart DCA spends within the configured bounds
+	cfg := entities.DefaultSmartDCAConfig
+	assert.GreaterOrEqual(t, result.Smart.TotalInvested, amount*cfg.MinMultiplier*float64(len(points)))
+	assert.LessOrEqual(t, result.Smart.TotalInvested, amount*cfg.MaxMultiplier*float64(len(points)))
+
+	// It buys more at the bottom than at the top and lowers the average cost
+	require.Len(t, result.SmartPurchases, len(points))
+	top, bottom := result.SmartPurchases[0], result.SmartPurchases[4]
+	assert.Less(t, top.Amount, amount)
+	assert.Greater(t, bottom.Amount, amount)
+	for _, purchase := range result.SmartPurchases {
+		assert.GreaterOrEqual(t, purchase.Amount, amount*cfg.MinMultiplier)
+		assert.LessOrEqual(t, purchase.Amount, amount*cfg.MaxMultiplier)
+		assert.True(t, purchase.IsSimulated)
+	}
+	fixedAvg := result.Fixed.TotalInvested / result.Fixed.TotalQuantity
+	smartAvg := result.Smart.TotalInvested / result.Smart.TotalQuantity
+	assert.Less(t, smartAvg, fixedAvg)
+	assert.Greater(t, result.Smart.TotalReturnPct, result.Fixed.TotalReturnPct)
+
+	assert.Equal(t, start.AddDate(0, 4, 0), result.Fixed.BestPurchaseDate)
+	assert.Equal(t, start, result.Fixed.WorstPurchaseDate)
+	assert.Greater(t, result.Fixed.MaxDrawdownPct, 0.0)
+}
+
+func TestSmartDCAService_BacktestValidation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSmartDCAService(t, nil)
+	point := entities.DCABacktestPoint{Date: time.Now(), Price: 30000}
+
+	_, err := s.Backtest(ctx, 0, []entities.DCABacktestPoint{point})
+	assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+
+	_, err = s.Backtest(ctx, 100, nil)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+
+	_, err = s.Backtest(ctx, 100, []entities.DCABacktestPoint{{Date: time.Now()}})
+	assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+}
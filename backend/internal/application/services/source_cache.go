@@ -0,0 +1,18 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// sourceCacheKey versions an indicator result's cache key by the last_updated timestamp of
+// the upstream data it was computed from. A result is then reused for exactly as long as
+// its source data is unchanged, and a newer source version is never hidden behind a cached
+// result; the TTL only bounds how long superseded versions are kept. Sources that report no
+// timestamp pass the zero time and keep the plain key, so their results expire by TTL alone.
+func sourceCacheKey(key string, sourceUpdated time.Time) string {
+	if sourceUpdated.IsZero() {
+		return key
+	}
+	return fmt.Sprintf("%s:%d", key, sourceUpdated.UTC().UnixMilli())
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	symbolMetadataCacheKeyPrefix = "symbol_metadata_"
+	// symbolMetadataCacheTTL is long because ranks, slugs and logos rarely change
+	symbolMetadataCacheTTL = 24 * time.Hour
+	// unknownSymbolCacheKeyPrefix marks symbols CoinMarketCap did not resolve, so repeated
+	// requests for them do not reach the API. The shorter TTL picks up new listings.
+	unknownSymbolCacheKeyPrefix = "symbol_metadata_unknown_"
+	unknownSymbolCacheTTL       = time.Hour
+	// knownSymbolsCacheKey holds every listed symbol, used to reject unknown symbols
+	// before any price lookup
+	knownSymbolsCacheKey = "known_symbols"
+)
+
+// CoinMarketCapMetadataClient is the subset of the CoinMarketCap client used for symbol metadata
+type CoinMarketCapMetadataClient interface {
+	GetCryptocurrencyMap(ctx context.Context, symbols []string) (*external.CryptocurrencyMapResponse, error)
+	GetCryptocurrencyInfo(ctx context.Context, ids []int) (*external.CryptocurrencyInfoResponse, error)
+}
+
+// symbolMetadataServiceImpl implements SymbolMetadataService with a per-symbol cache
+// in front of CoinMarketCap's map and info endpoints
+type symbolMetadataServiceImpl struct {
+	client CoinMarketCapMetadataClient
+	cache  services.CacheService
+	logger logger.Logger
+}
+
+// NewSymbolMetadataService creates a new symbol metadata service
+func NewSymbolMetadataService(
+	client CoinMarketCapMetadataClient,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.SymbolMetadataService {
+	return &symbolMetadataServiceImpl{
+		client: client,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// GetMetadata returns cached metadata and fetches any missing symbols in a single batch
+func (s *symbolMetadataServiceImpl) GetMetadata(ctx context.Context, symbols []string) (map[string]*entities.SymbolMetadata, error) {
+	result := make(map[string]*entities.SymbolMetadata, len(symbols))
+
+	var missing []string
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		if _, seen := result[symbol]; seen {
+			continue
+		}
+
+		if s.cache != nil {
+			var metadata entities.SymbolMetadata
+			if s.cache.Get(ctx, symbolMetadataCacheKeyPrefix+symbol, &metadata) == nil {
+				result[symbol] = &metadata
+				continue
+			}
+			var unknown bool
+			if s.cache.Get(ctx, unknownSymbolCacheKeyPrefix+symbol, &unknown) == nil {
+				continue
+			}
+		}
+		missing = append(missing, symbol)
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := s.fetchMetadata(ctx, missing)
+	if err != nil {
+		return nil, errors.External("CoinMarketCap", "failed to fetch symbol metadata", err)
+	}
+
+	for symbol, metadata := range fetched {
+		result[symbol] = metadata
+		if s.cache != nil {
+			if err := s.cache.Set(ctx, symbolMetadataCacheKeyPrefix+symbol, metadata, symbolMetadataCacheTTL); err != nil {
+				s.logger.WithContext(ctx).Warn("Failed to cache symbol metadata", "symbol", symbol, "error", err)
+			}
+		}
+	}
+
+	if s.cache != nil {
+		for _, symbol := range missing {
+			if _, ok := fetched[symbol]; ok {
+				continue
+			}
+			if err := s.cache.Set(ctx, unknownSymbolCacheKeyPrefix+symbol, true, unknownSymbolCacheTTL); err != nil {
+				s.logger.WithContext(ctx).Warn("Failed to cache unknown symbol", "symbol", symbol, "error", err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// UnknownSymbols returns the symbols, upper-cased and in request order, that are missing
+// from CoinMarketCap's full listing. The listing is cached for the metadata cache period.
+func (s *symbolMetadataServiceImpl) UnknownSymbols(ctx context.Context, symbols []string) ([]string, error) {
+	known, err := s.knownSymbols(ctx)
+	if err != nil {
+		return nil, errors.External("CoinMarketCap", "failed to fetch known symbols", err)
+	}
+
+	var unknown []string
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		if !known[symbol] {
+			unknown = append(unknown, symbol)
+		}
+	}
+	return unknown, nil
+}
+
+// knownSymbols returns the set of listed symbols
+func (s *symbolMetadataServiceImpl) knownSymbols(ctx context.Context) (map[string]bool, error) {
+	fetch := func() (interface{}, error) {
+		response, err := s.client.GetCryptocurrencyMap(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		symbols := make([]string, 0, len(response.Data))
+		for _, entry := range response.Data {
+			symbols = append(symbols, strings.ToUpper(entry.Symbol))
+		}
+		return symbols, nil
+	}
+
+	var symbols []string
+	if s.cache != nil {
+		if err := s.cache.GetOrSet(ctx, knownSymbolsCacheKey, &symbols, symbolMetadataCacheTTL, fetch); err != nil {
+			return nil, err
+		}
+	} else {
+		value, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		symbols = value.([]string)
+	}
+
+	known := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		known[symbol] = true
+	}
+	return known, nil
+}
+
+// fetchMetadata resolves symbols to ranks and slugs, then adds logos from the info endpoint.
+// A failed info lookup still returns the map data without logos.
+func (s *symbolMetadataServiceImpl) fetchMetadata(ctx context.Context, symbols []string) (map[string]*entities.SymbolMetadata, error) {
+	mapResponse, err := s.client.GetCryptocurrencyMap(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := selectMapEntries(mapResponse.Data)
+	if len(entries) == 0 {
+		return map[string]*entities.SymbolMetadata{}, nil
+	}
+
+	metadata := make(map[string]*entities.SymbolMetadata, len(entries))
+	ids := make([]int, 0, len(entries))
+	for symbol, entry := range entries {
+		metadata[symbol] = &entities.SymbolMetadata{
+			Symbol: symbol,
+			Name:   entry.Name,
+			Rank:   entry.Rank,
+			Slug:   entry.Slug,
+		}
+		ids = append(ids, entry.ID)
+	}
+
+	infoResponse, err := s.client.GetCryptocurrencyInfo(ctx, ids)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("Failed to fetch cryptocurrency logos", "error", err)
+		return metadata, nil
+	}
+
+	for symbol, entry := range entries {
+		if info, ok := infoResponse.Data[strconv.Itoa(entry.ID)]; ok {
+			metadata[symbol].LogoURL = info.Logo
+		}
+	}
+
+	return metadata, nil
+}
+
+// selectMapEntries picks one entry per symbol. Symbols can be shared by several coins,
+// so active coins win and then the best (lowest non-zero) rank.
+func selectMapEntries(entries []external.CryptocurrencyMapEntry) map[string]external.CryptocurrencyMapEntry {
+	selected := make(map[string]external.CryptocurrencyMapEntry)
+	for _, entry := range entries {
+		symbol := strings.ToUpper(entry.Symbol)
+		current, exists := selected[symbol]
+		if !exists || betterMapEntry(entry, current) {
+			selected[symbol] = entry
+		}
+	}
+	return selected
+}
+
+// betterMapEntry reports whether candidate should replace current for the same symbol
+func betterMapEntry(candidate, current external.CryptocurrencyMapEntry) bool {
+	if candidate.IsActive != current.IsActive {
+		return candidate.IsActive > current.IsActive
+	}
+	if candidate.Rank == 0 || current.Rank == 0 {
+		return current.Rank == 0 && candidate.Rank != 0
+	}
+	return candidate.Rank < current.Rank
+}
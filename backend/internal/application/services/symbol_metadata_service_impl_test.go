@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newMetadataClient returns a CoinMarketCap mock that knows BTC and ETH. The map response
+// includes an inactive, unranked BTC to exercise symbol collision handling.
+func newMetadataClient() *testutil.MockCoinMarketCapClient {
+	client := &testutil.MockCoinMarketCapClient{}
+	client.On("GetCryptocurrencyMap", mock.Anything, mock.Anything).Return(&external.CryptocurrencyMapResponse{
+		Data: []external.CryptocurrencyMapEntry{
+			{ID: 31469, Rank: 0, Name: "Bitcoin Token", Symbol: "BTC", Slug: "bitcoin-token", IsActive: 0},
+			{ID: 1, Rank: 1, Name: "Bitcoin", Symbol: "BTC", Slug: "bitcoin", IsActive: 1},
+			{ID: 1027, Rank: 2, Name: "Ethereum", Symbol: "ETH", Slug: "ethereum", IsActive: 1},
+		},
+	}, nil)
+	client.On("GetCryptocurrencyInfo", mock.Anything, mock.Anything).Return(&external.CryptocurrencyInfoResponse{
+		Data: map[string]external.CryptocurrencyInfo{
+			"1":    {ID: 1, Logo: "https://s2.coinmarketcap.com/static/img/coins/64x64/1.png"},
+			"1027": {ID: 1027, Logo: "https://s2.coinmarketcap.com/static/img/coins/64x64/1027.png"},
+		},
+	}, nil)
+	return client
+}
+
+func TestSymbolMetadataService_GetMetadata(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+	client := newMetadataClient()
+	service := NewSymbolMetadataService(client, cache.NewCacheService(nil, log), log)
+
+	metadata, err := service.GetMetadata(ctx, []string{"btc", "ETH", "UNKNOWN"})
+
+	require.NoError(t, err)
+	require.Len(t, metadata, 2, "unknown symbols are omitted")
+	assert.Equal(t, 1, metadata["BTC"].Rank, "the active, ranked coin wins a symbol collision")
+	assert.Equal(t, "bitcoin", metadata["BTC"].Slug)
+	assert.Equal(t, "https://s2.coinmarketcap.com/static/img/coins/64x64/1.png", metadata["BTC"].LogoURL)
+	assert.Equal(t, "Ethereum", metadata["ETH"].Name)
+
+	// Both symbols are cached now, and UNKNOWN is remembered as unresolved, so the second
+	// call does not reach CoinMarketCap
+	metadata, err = service.GetMetadata(ctx, []string{"BTC", "ETH", "UNKNOWN"})
+	require.NoError(t, err)
+	assert.Len(t, metadata, 2)
+	client.AssertNumberOfCalls(t, "GetCryptocurrencyMap", 1)
+	client.AssertNumberOfCalls(t, "GetCryptocurrencyInfo", 1)
+}
+
+func TestSymbolMetadataService_UnknownSymbols(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+	client := newMetadataClient()
+	service := NewSymbolMetadataService(client, cache.NewCacheService(nil, log), log)
+
+	unknown, err := service.UnknownSymbols(ctx, []string{"btc", "NOTACOIN", "ETH"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NOTACOIN"}, unknown)
+
+	unknown, err = service.UnknownSymbols(ctx, []string{"ETH"})
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+
+	// The full listing is fetched once and served from cache afterwards
+	client.AssertNumberOfCalls(t, "GetCryptocurrencyMap", 1)
+	client.AssertCalled(t, "GetCryptocurrencyMap", mock.Anything, []string(nil))
+}
+
+func TestSymbolMetadataService_InfoFailureKeepsRank(t *testing.T) {
+	log := logger.New("test")
+	client := &testutil.MockCoinMarketCapClient{}
+	client.On("GetCryptocurrencyMap", mock.Anything, mock.Anything).Return(&external.CryptocurrencyMapResponse{
+		Data: []external.CryptocurrencyMapEntry{{ID: 1, Rank: 1, Name: "Bitcoin", Symbol: "BTC", Slug: "bitcoin", IsActive: 1}},
+	}, nil)
+	client.On("GetCryptocurrencyInfo", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("rate limited"))
+	service := NewSymbolMetadataService(client, nil, log)
+
+	metadata, err := service.GetMetadata(context.Background(), []string{"BTC"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, metadata["BTC"].Rank)
+	assert.Empty(t, metadata["BTC"].LogoURL)
+}
+
+func TestGetCryptoPrices_MetadataEnrichment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":{"error_code":0},"data":{
+			"BTC":{"name":"Bitcoin","symbol":"BTC","quote":{"USD":{"price":67712.34}}},
+			"ETH":{"name":"Ethereum","symbol":"ETH","quote":{"USD":{"price":3512.5}}}}}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	log := logger.New("test")
+	cacheService := cache.NewCacheService(nil, log)
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+	metadataClient := newMetadataClient()
+
+	service := NewMarketDataService(
+		repo,
+		external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log),
+		nil,
+		cacheService,
+		log,
+		MarketDataOptions{
+			Metadata: NewSymbolMetadataService(metadataClient, cacheService, log),
+		},
+	)
+
+	for i := 0; i < 2; i++ {
+		prices, _, err := service.GetCryptoPrices(ctx, []string{"BTC", "ETH"})
+
+		require.NoError(t, err)
+		require.Contains(t, prices, "BTC")
+		assert.Equal(t, 67712.34, prices["BTC"].Price)
+		assert.Equal(t, 1, prices["BTC"].Rank)
+		assert.Equal(t, "bitcoin", prices["BTC"].Slug)
+		assert.Equal(t, "https://s2.coinmarketcap.com/static/img/coins/64x64/1027.png", prices["ETH"].LogoURL)
+	}
+
+	metadataClient.AssertNumberOfCalls(t, "GetCryptocurrencyMap", 1)
+	metadataClient.AssertNumberOfCalls(t, "GetCryptocurrencyInfo", 1)
+}
+
+func TestGetCryptoPrices_MetadataFailureReturnsPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":{"error_code":0},"data":{"BTC":{"name":"Bitcoin","symbol":"BTC","quote":{"USD":{"price":67712.34}}}}}`)
+	}))
+	defer server.Close()
+
+	log := logger.New("test")
+	cacheService := cache.NewCacheService(nil, log)
+	repo := &testutil.MockMarketDataRepository{}
+	repo.On("StorePriceData", mock.Anything, mock.Anything).Return(nil)
+	metadataClient := &testutil.MockCoinMarketCapClient{}
+	metadataClient.On("GetCryptocurrencyMap", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("upstream down"))
+
+	service := NewMarketDataService(
+		repo,
+		external.NewCoinMarketCapClientWithBaseURL("test-key", server.URL, log),
+		nil,
+		cacheService,
+		log,
+		MarketDataOptions{
+			Metadata: NewSymbolMetadataService(metadataClient, cacheService, log),
+		},
+	)
+
+	prices, _, err := service.GetCryptoPrices(context.Background(), []string{"BTC"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 67712.34, prices["BTC"].Price)
+	assert.Zero(t, prices["BTC"].Rank)
+}
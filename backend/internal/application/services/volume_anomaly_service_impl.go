@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	volumeAnomalyIndicatorName = "volume_anomaly"
+	volumeAnomalyCacheKey      = "volume_anomaly_quote"
+	volumeAnomalyCacheTTL      = 15 * time.Minute
+	volumeAnomalySymbol        = "BTC"
+
+	// volumeAnomalyCalcVersion is stored with each score; bump it when the lookback or
+	// severity thresholds change
+	volumeAnomalyCalcVersion uint = 1
+
+	// volumeAnomalyLookbackDays is the trailing window current volume is compared against
+	volumeAnomalyLookbackDays = 30
+	// volumeAnomalyMinSamples is the fewest trailing daily volumes a z-score is computed from
+	volumeAnomalyMinSamples = 7
+	// volumeAnomalyMinStdDevRatio floors the trailing standard deviation at this fraction of
+	// the mean, so a near-flat history doesn't turn small moves into huge z-scores
+	volumeAnomalyMinStdDevRatio = 0.01
+
+	// VolumeAnomalyThreshold is the absolute z-score from which volume is flagged as anomalous
+	VolumeAnomalyThreshold = 2.0
+)
+
+// Volume anomaly severity bands
+const (
+	VolumeSeverityInsufficientHistory = "insufficient_history"
+	VolumeSeverityDepressed           = "depressed"
+	VolumeSeverityNormal              = "normal"
+	VolumeSeverityElevated            = "elevated"
+	VolumeSeverityHigh                = "high"
+	VolumeSeverityExtreme             = "extreme"
+)
+
+// CoinMarketCapQuotesClient is the subset of the CoinMarketCap client used for latest quotes
+type CoinMarketCapQuotesClient interface {
+	GetLatestQuotes(ctx context.Context, symbols []string, convert string) (*external.LatestQuotesResponse, error)
+}
+
+// volumeSnapshot is the cached BTC quote a volume anomaly is calculated from
+type volumeSnapshot struct {
+	Volume24h float64   `json:"volume_24h"`
+	Price     float64   `json:"price"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// volumeAnomalyServiceImpl implements the IndicatorService interface for BTC volume anomalies,
+// the z-score of 24h volume against the trailing daily volumes stored by earlier calculations
+type volumeAnomalyServiceImpl struct {
+	recomputeGuarded
+
+	quotesClient  CoinMarketCapQuotesClient
+	indicatorRepo repositories.IndicatorRepository
+	cache         services.CacheService
+	logger        logger.Logger
+	events        *extremeBandTracker
+}
+
+// NewVolumeAnomalyService creates a new BTC volume anomaly service
+func NewVolumeAnomalyService(
+	quotesClient CoinMarketCapQuotesClient,
+	indicatorRepo repositories.IndicatorRepository,
+	cache services.CacheService,
+	logger logger.Logger,
+) services.IndicatorService {
+	s := &volumeAnomalyServiceImpl{
+		quotesClient:  quotesClient,
+		indicatorRepo: indicatorRepo,
+		cache:         cache,
+		logger:        logger,
+		events:        newExtremeBandTracker(nil, logger),
+	}
+	s.recomputeGuarded = newRecomputeGuarded(s, indicatorRepo, volumeAnomalyIndicatorName, volumeAnomalyCacheTTL, logger)
+	return s
+}
+
+// Calculate scores current BTC 24h volume against its trailing daily average. Until enough
+// history has been stored the score is 0 with low confidence.
+func (s *volumeAnomalyServiceImpl) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	log := s.logger.WithContext(ctx)
+	log.Info("Starting volume anomaly calculation", "symbol", volumeAnomalySymbol)
+
+	fresh := false
+	var snapshot volumeSnapshot
+	fetch := func() (interface{}, error) {
+		fresh = true
+		return s.fetchSnapshot(ctx)
+	}
+
+	var err error
+	if s.cache != nil {
+		err = s.cache.GetOrSet(ctx, volumeAnomalyCacheKey, &snapshot, volumeAnomalyCacheTTL, fetch)
+	} else {
+		var value interface{}
+		if value, err = fetch(); err == nil {
+			snapshot = *value.(*volumeSnapshot)
+		}
+	}
+	if err != nil {
+		return nil, errors.External("CoinMarketCap", "failed to calculate volume anomaly", err)
+	}
+
+	trailing, err := s.trailingDailyVolumes(ctx, snapshot.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"symbol":     volumeAnomalySymbol,
+		"volume_24h": snapshot.Volume24h,
+		"price":      snapshot.Price,
+		"samples":    len(trailing),
+		"threshold":  VolumeAnomalyThreshold,
+	}
+
+	var zScore float64
+	confidence := 0.2 // Cold start: too little history to judge
+	severity, riskLevel, status := VolumeSeverityInsufficientHistory, "medium",
+		fmt.Sprintf("Collecting volume history - %d of %d days needed", len(trailing), volumeAnomalyMinSamples)
+	if len(trailing) >= volumeAnomalyMinSamples {
+		mean, stdDev := meanStdDev(trailing)
+		stdDev = math.Max(stdDev, mean*volumeAnomalyMinStdDevRatio)
+		zScore = (snapshot.Volume24h - mean) / stdDev
+		severity, riskLevel, status = classifyVolumeAnomaly(zScore)
+		confidence = 0.85
+		metadata["trailing_mean"] = mean
+		metadata["trailing_stddev"] = stdDev
+		metadata["volume_ratio"] = snapshot.Volume24h / mean
+	}
+	metadata["severity"] = severity
+	metadata["anomaly"] = math.Abs(zScore) >= VolumeAnomalyThreshold
+
+	indicator := &entities.Indicator{
+		Name:        volumeAnomalyIndicatorName,
+		Type:        "market",
+		Value:       zScore,
+		Change:      fmt.Sprintf("%+.1fσ", zScore),
+		RiskLevel:   riskLevel,
+		Status:      status,
+		Description: fmt.Sprintf("Z-score of BTC 24h volume against its trailing %d day average", volumeAnomalyLookbackDays),
+		Source:      entities.DataSourceCoinMarketCap,
+		Confidence:  confidence,
+		CalcVersion: volumeAnomalyCalcVersion,
+		Timestamp:   snapshot.Timestamp,
+		Metadata:    metadata,
+	}
+
+	// Only persist newly computed values, not cache hits; stored volumes feed later z-scores
+	if fresh && s.indicatorRepo != nil {
+		if err := s.indicatorRepo.Create(ctx, indicator); err != nil {
+			log.Warn("Failed to save volume anomaly indicator to database", "error", err)
+		}
+	}
+
+	s.events.observe(ctx, indicator)
+	return indicator, nil
+}
+
+// GetHistoricalData retrieves stored volume anomaly scores
+func (s *volumeAnomalyServiceImpl) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	s.logger.Debug("Retrieving historical volume anomaly data", "from", window.From, "to", window.To)
+
+	if s.indicatorRepo == nil {
+		return []entities.Indicator{}, nil
+	}
+
+	return s.indicatorRepo.GetHistoricalData(ctx, volumeAnomalyIndicatorName, window.From, window.To)
+}
+
+// GetLatest returns the stored score if it is fresh, otherwise recalculates it
+func (s *volumeAnomalyServiceImpl) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	if s.indicatorRepo == nil {
+		return s.Calculate(ctx, nil)
+	}
+
+	indicator, err := s.indicatorRepo.GetLatest(ctx, volumeAnomalyIndicatorName)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return s.recalculate(ctx, nil)
+		}
+		return nil, err
+	}
+
+	if time.Since(indicator.Timestamp) > volumeAnomalyCacheTTL {
+		return s.recalculate(ctx, indicator)
+	}
+
+	return indicator, nil
+}
+
+// SetEventPublisher sets where extreme band entry events are published
+func (s *volumeAnomalyServiceImpl) SetEventPublisher(publisher services.EventPublisher) {
+	s.events.setPublisher(publisher)
+}
+
+// fetchSnapshot fetches the latest BTC quote
+func (s *volumeAnomalyServiceImpl) fetchSnapshot(ctx context.Context) (*volumeSnapshot, error) {
+	response, err := s.quotesClient.GetLatestQuotes(ctx, []string{volumeAnomalySymbol}, "USD")
+	if err != nil {
+		return nil, err
+	}
+
+	for symbol, data := range response.Data {
+		if !strings.EqualFold(symbol, volumeAnomalySymbol) {
+			continue
+		}
+		quote, ok := data.Quote["USD"]
+		if !ok || quote.Volume24h <= 0 {
+			break
+		}
+		return &volumeSnapshot{Volume24h: quote.Volume24h, Price: quote.Price, Timestamp: time.Now()}, nil
+	}
+	return nil, fmt.Errorf("no %s 24h volume in quotes", volumeAnomalySymbol)
+}
+
+// trailingDailyVolumes returns the 24h volume last stored on each UTC day of the lookback
+// window before at's day. Only one reading per day counts, so frequent recalculations don't
+// weigh a day more heavily.
+func (s *volumeAnomalyServiceImpl) trailingDailyVolumes(ctx context.Context, at time.Time) ([]float64, error) {
+	if s.indicatorRepo == nil {
+		return nil, nil
+	}
+
+	today := at.UTC().Truncate(24 * time.Hour)
+	history, err := s.indicatorRepo.GetHistoricalData(ctx, volumeAnomalyIndicatorName, today.AddDate(0, 0, -volumeAnomalyLookbackDays), today)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get volume history")
+	}
+
+	latest := make(map[time.Time]entities.Indicator)
+	for _, reading := range history {
+		if _, ok := reading.Metadata["volume_24h"].(float64); !ok {
+			continue
+		}
+		day := reading.Timestamp.UTC().Truncate(24 * time.Hour)
+		if !day.Before(today) {
+			continue
+		}
+		if kept, ok := latest[day]; !ok || reading.Timestamp.After(kept.Timestamp) {
+			latest[day] = reading
+		}
+	}
+
+	volumes := make([]float64, 0, len(latest))
+	for _, reading := range latest {
+		volumes = append(volumes, reading.Metadata["volume_24h"].(float64))
+	}
+	return volumes, nil
+}
+
+// meanStdDev returns the mean and population standard deviation of values
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// classifyVolumeAnomaly maps a volume z-score to a severity band, risk level and status
+func classifyVolumeAnomaly(zScore float64) (severity, riskLevel, status string) {
+	switch {
+	case zScore >= 3:
+		return VolumeSeverityExtreme, "extreme_high", "Extreme volume spike - capitulation or euphoria likely"
+	case zScore >= VolumeAnomalyThreshold:
+		return VolumeSeverityHigh, "high", "Unusually high volume - significant market activity"
+	case zScore >= 1:
+		return VolumeSeverityElevated, "medium", "Volume above its recent average"
+	case zScore <= -VolumeAnomalyThreshold:
+		return VolumeSeverityDepressed, "low", "Unusually low volume - thin liquidity"
+	default:
+		return VolumeSeverityNormal, "low", "Volume in line with its recent average"
+	}
+}
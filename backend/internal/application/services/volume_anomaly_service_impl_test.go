@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// staticQuotesClient returns a fixed BTC 24h volume
+type staticQuotesClient struct {
+	volume float64
+	err    error
+}
+
+func (c *staticQuotesClient) GetLatestQuotes(ctx context.Context, symbols []string, convert string) (*external.LatestQuotesResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &external.LatestQuotesResponse{Data: map[string]external.CryptoPriceData{
+		"BTC": {Symbol: "BTC", Quote: map[string]external.Quote{"USD": {Price: 65000, Volume24h: c.volume}}},
+	}}, nil
+}
+
+// storedVolumes builds one stored volume anomaly reading per day, ending yesterday
+func storedVolumes(volumes ...float64) []entities.Indicator {
+	yesterday := time.Now().UTC().Truncate(24 * time.Hour).Add(-12 * time.Hour)
+	history := make([]entities.Indicator, len(volumes))
+	for i, volume := range volumes {
+		history[i] = entities.Indicator{
+			Name:      volumeAnomalyIndicatorName,
+			Timestamp: yesterday.AddDate(0, 0, -(len(volumes) - 1 - i)),
+			Metadata:  map[string]interface{}{"volume_24h": volume},
+		}
+	}
+	return history
+}
+
+func newVolumeAnomalyTestService(volume float64, history []entities.Indicator) (*volumeAnomalyServiceImpl, *testutil.MockIndicatorRepository) {
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("GetHistoricalData", mock.Anything, volumeAnomalyIndicatorName, mock.Anything, mock.Anything).Return(history, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Indicator")).Return(nil)
+
+	service := NewVolumeAnomalyService(&staticQuotesClient{volume: volume}, repo, nil, logger.New("test"))
+	return service.(*volumeAnomalyServiceImpl), repo
+}
+
+func TestClassifyVolumeAnomaly(t *testing.T) {
+	tests := []struct {
+		zScore   float64
+		severity string
+	}{
+		{4.2, VolumeSeverityExtreme},
+		{3, VolumeSeverityExtreme},
+		{2.5, VolumeSeverityHigh},
+		{1.2, VolumeSeverityElevated},
+		{0, VolumeSeverityNormal},
+		{-1.5, VolumeSeverityNormal},
+		{-2, VolumeSeverityDepressed},
+	}
+
+	for _, tt := range tests {
+		severity, riskLevel, status := classifyVolumeAnomaly(tt.zScore)
+		assert.Equal(t, tt.severity, severity, "z-score %v", tt.zScore)
+		assert.NotEmpty(t, riskLevel)
+		assert.NotEmpty(t, status)
+	}
+}
+
+func TestVolumeAnomalyService_Calculate(t *testing.T) {
+	ctx := context.Background()
+	trailing := []float64{20e9, 21e9, 19e9, 22e9, 18e9, 20e9, 21e9, 19e9, 20e9, 20e9}
+
+	t.Run("Volume spike is flagged", func(t *testing.T) {
+		service, repo := newVolumeAnomalyTestService(60e9, storedVolumes(trailing...))
+
+		indicator, err := service.Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.Greater(t, indicator.Value, 3.0)
+		assert.Equal(t, VolumeSeverityExtreme, indicator.Metadata["severity"])
+		assert.Equal(t, true, indicator.Metadata["anomaly"])
+		assert.Equal(t, len(trailing), indicator.Metadata["samples"])
+		assert.InDelta(t, 20e9, indicator.Metadata["trailing_mean"], 1)
+		assert.InDelta(t, 3.0, indicator.Metadata["volume_ratio"], 1e-9)
+		assert.Equal(t, 0.85, indicator.Confidence)
+		assert.Equal(t, volumeAnomalyCalcVersion, indicator.CalcVersion)
+		repo.AssertCalled(t, "Create", mock.Anything, indicator)
+	})
+
+	t.Run("Ordinary volume is not flagged", func(t *testing.T) {
+		service, _ := newVolumeAnomalyTestService(20.5e9, storedVolumes(trailing...))
+
+		indicator, err := service.Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.Less(t, indicator.Value, 1.0)
+		assert.Equal(t, VolumeSeverityNormal, indicator.Metadata["severity"])
+		assert.Equal(t, false, indicator.Metadata["anomaly"])
+	})
+
+	t.Run("Cold start reports low confidence", func(t *testing.T) {
+		service, repo := newVolumeAnomalyTestService(60e9, storedVolumes(20e9, 21e9, 19e9))
+
+		indicator, err := service.Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0.0, indicator.Value)
+		assert.Equal(t, VolumeSeverityInsufficientHistory, indicator.Metadata["severity"])
+		assert.Equal(t, false, indicator.Metadata["anomaly"])
+		assert.Equal(t, 0.2, indicator.Confidence)
+		assert.Equal(t, 60e9, indicator.Metadata["volume_24h"])
+		repo.AssertCalled(t, "Create", mock.Anything, indicator)
+	})
+
+	t.Run("Only the last reading of each day counts", func(t *testing.T) {
+		history := storedVolumes(trailing[:6]...)
+		// An earlier reading on the same day as the last stored one is ignored
+		extra := history[5]
+		extra.Timestamp = extra.Timestamp.Add(-time.Hour)
+		extra.Metadata = map[string]interface{}{"volume_24h": 90e9}
+		// Readings from today are not part of the trailing window
+		today := entities.Indicator{Timestamp: time.Now(), Metadata: map[string]interface{}{"volume_24h": 90e9}}
+		history = append(history, extra, today)
+
+		service, _ := newVolumeAnomalyTestService(20e9, history)
+
+		indicator, err := service.Calculate(ctx, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 6, indicator.Metadata["samples"])
+		assert.Equal(t, VolumeSeverityInsufficientHistory, indicator.Metadata["severity"])
+	})
+}
@@ -2,11 +2,14 @@ package usecases
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"time"
 	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/internal/domain/repositories"
 	"crypto-indicator-dashboard/internal/domain/services"
 	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/pkg/errors"
 )
 
 // PortfolioUseCase handles portfolio-related business logic
@@ -29,8 +32,8 @@ func NewPortfolioUseCase(
 	}
 }
 
-// CreatePortfolio creates a new portfolio for a user
-func (uc *PortfolioUseCase) CreatePortfolio(ctx context.Context, req *dto.CreatePortfolioRequest) (*dto.PortfolioResponse, error) {
+// CreatePortfolio creates a new portfolio owned by userID
+func (uc *PortfolioUseCase) CreatePortfolio(ctx context.Context, req *dto.CreatePortfolioRequest, userID string) (*dto.PortfolioResponse, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
@@ -38,7 +41,7 @@ func (uc *PortfolioUseCase) CreatePortfolio(ctx context.Context, req *dto.Create
 	
 	// Create portfolio entity
 	portfolio := &entities.Portfolio{
-		UserID: req.UserID,
+		UserID: userID,
 		Name:   req.Name,
 	}
 	
@@ -50,11 +53,11 @@ func (uc *PortfolioUseCase) CreatePortfolio(ctx context.Context, req *dto.Create
 	return dto.NewPortfolioResponse(portfolio), nil
 }
 
-// GetPortfolio retrieves a portfolio by ID
-func (uc *PortfolioUseCase) GetPortfolio(ctx context.Context, portfolioID uint) (*dto.PortfolioResponse, error) {
-	portfolio, err := uc.portfolioRepo.GetByID(ctx, portfolioID)
+// GetPortfolio retrieves a portfolio of userID by ID
+func (uc *PortfolioUseCase) GetPortfolio(ctx context.Context, portfolioID uint, userID string) (*dto.PortfolioResponse, error) {
+	portfolio, err := uc.ownedPortfolio(ctx, portfolioID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+		return nil, err
 	}
 	
 	return dto.NewPortfolioResponse(portfolio), nil
@@ -70,17 +73,16 @@ func (uc *PortfolioUseCase) GetUserPortfolios(ctx context.Context, userID string
 	return dto.NewPortfolioListResponse(portfolios), nil
 }
 
-// AddHolding adds a new holding to a portfolio
-func (uc *PortfolioUseCase) AddHolding(ctx context.Context, req *dto.AddHoldingRequest) (*dto.HoldingResponse, error) {
+// AddHolding adds a new holding to a portfolio of userID
+func (uc *PortfolioUseCase) AddHolding(ctx context.Context, req *dto.AddHoldingRequest, userID string) (*dto.HoldingResponse, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 	
-	// Verify portfolio exists
-	_, err := uc.portfolioRepo.GetByID(ctx, req.PortfolioID)
-	if err != nil {
-		return nil, fmt.Errorf("portfolio not found: %w", err)
+	// Verify the portfolio exists and belongs to the user
+	if _, err := uc.ownedPortfolio(ctx, req.PortfolioID, userID); err != nil {
+		return nil, err
 	}
 	
 	// Create holding
@@ -98,12 +100,12 @@ func (uc *PortfolioUseCase) AddHolding(ctx context.Context, req *dto.AddHoldingR
 	return dto.NewHoldingResponse(holding), nil
 }
 
-// GetPortfolioSummary retrieves portfolio summary with analytics
-func (uc *PortfolioUseCase) GetPortfolioSummary(ctx context.Context, portfolioID uint) (*dto.PortfolioSummaryResponse, error) {
+// GetPortfolioSummary retrieves the summary of a portfolio of userID with analytics
+func (uc *PortfolioUseCase) GetPortfolioSummary(ctx context.Context, portfolioID uint, userID string) (*dto.PortfolioSummaryResponse, error) {
 	// Get portfolio
-	portfolio, err := uc.portfolioRepo.GetByID(ctx, portfolioID)
+	portfolio, err := uc.ownedPortfolio(ctx, portfolioID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+		return nil, err
 	}
 	
 	// Get portfolio summary
@@ -112,31 +114,35 @@ func (uc *PortfolioUseCase) GetPortfolioSummary(ctx context.Context, portfolioID
 		return nil, fmt.Errorf("failed to get portfolio summary: %w", err)
 	}
 	
-	// Calculate risk metrics
-	riskMetrics, err := uc.riskAnalysisSvc.AnalyzePortfolioRisk(ctx, portfolio)
-	if err != nil {
-		return nil, fmt.Errorf("failed to calculate risk metrics: %w", err)
+	// Calculate risk metrics when a risk analysis service is configured
+	if uc.riskAnalysisSvc != nil {
+		riskMetrics, err := uc.riskAnalysisSvc.AnalyzePortfolioRisk(ctx, portfolio)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate risk metrics: %w", err)
+		}
+		summary.RiskMetrics = *riskMetrics
 	}
 	
-	summary.RiskMetrics = *riskMetrics
-	
 	return dto.NewPortfolioSummaryResponse(summary), nil
 }
 
-// UpdateHolding updates an existing holding
-func (uc *PortfolioUseCase) UpdateHolding(ctx context.Context, req *dto.UpdateHoldingRequest) error {
+// UpdateHolding updates an existing holding of a portfolio of userID
+func (uc *PortfolioUseCase) UpdateHolding(ctx context.Context, portfolioID uint, req *dto.UpdateHoldingRequest, userID string) error {
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return fmt.Errorf("invalid request: %w", err)
 	}
 	
-	// Update holding
-	holding := &entities.PortfolioHolding{
-		ID:           req.HoldingID,
-		Amount:       req.Amount,
-		AveragePrice: req.AveragePrice,
+	holding, err := uc.ownedHolding(ctx, portfolioID, req.HoldingID, userID)
+	if err != nil {
+		return err
 	}
 	
+	// Update holding
+	holding.Amount = req.Amount
+	holding.AveragePrice = req.AveragePrice
+	revalueHolding(holding)
+	
 	if err := uc.portfolioRepo.UpdateHolding(ctx, holding); err != nil {
 		return fmt.Errorf("failed to update holding: %w", err)
 	}
@@ -144,11 +150,287 @@ func (uc *PortfolioUseCase) UpdateHolding(ctx context.Context, req *dto.UpdateHo
 	return nil
 }
 
-// RemoveHolding removes a holding from a portfolio
-func (uc *PortfolioUseCase) RemoveHolding(ctx context.Context, holdingID uint) error {
+// UpdateHoldings applies a batch of holding updates to a portfolio of userID atomically.
+// Every update is validated before any is applied.
+func (uc *PortfolioUseCase) UpdateHoldings(ctx context.Context, portfolioID uint, userID string, reqs []dto.UpdateHoldingRequest) error {
+	if len(reqs) == 0 {
+		return errors.Validation("Invalid holding updates", "at least one holding update is required")
+	}
+	if _, err := uc.ownedPortfolio(ctx, portfolioID, userID); err != nil {
+		return err
+	}
+	
+	holdings := make([]entities.PortfolioHolding, 0, len(reqs))
+	seen := make(map[uint]bool, len(reqs))
+	for i := range reqs {
+		if err := reqs[i].Validate(); err != nil {
+			return errors.Validation("Invalid holding updates", fmt.Sprintf("holdings[%d]: %v", i, err))
+		}
+		if seen[reqs[i].HoldingID] {
+			return errors.Validation("Invalid holding updates", fmt.Sprintf("holdings[%d]: holding %d is updated more than once", i, reqs[i].HoldingID))
+		}
+		seen[reqs[i].HoldingID] = true
+		
+		holdings = append(holdings, entities.PortfolioHolding{
+			ID:           reqs[i].HoldingID,
+			PortfolioID:  portfolioID,
+			Amount:       reqs[i].Amount,
+			AveragePrice: reqs[i].AveragePrice,
+		})
+	}
+	
+	if err := uc.portfolioRepo.UpdateHoldings(ctx, portfolioID, holdings); err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update holdings: %w", err)
+	}
+	
+	return nil
+}
+
+// RemoveHolding removes a holding from a portfolio of userID
+func (uc *PortfolioUseCase) RemoveHolding(ctx context.Context, portfolioID, holdingID uint, userID string) error {
+	if _, err := uc.ownedHolding(ctx, portfolioID, holdingID, userID); err != nil {
+		return err
+	}
+	
 	if err := uc.portfolioRepo.RemoveHolding(ctx, holdingID); err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return err
+		}
 		return fmt.Errorf("failed to remove holding: %w", err)
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// ClearHoldings removes every holding of a portfolio, archiving them first when archive is
+// set, and returns how many were removed. The portfolio must belong to userID.
+func (uc *PortfolioUseCase) ClearHoldings(ctx context.Context, portfolioID uint, userID string, archive bool) (int, error) {
+	if _, err := uc.ownedPortfolio(ctx, portfolioID, userID); err != nil {
+		return 0, err
+	}
+	
+	removed, err := uc.portfolioRepo.ClearHoldings(ctx, portfolioID, archive)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear holdings: %w", err)
+	}
+	
+	return removed, nil
+}
+
+// RecordTransaction applies a buy or sell to a holding's lots. Sells realize PnL against
+// the oldest lots first, and the holding's amount and average price are re-derived from
+// whatever remains. The portfolio must belong to userID.
+func (uc *PortfolioUseCase) RecordTransaction(ctx context.Context, req *dto.HoldingTransactionRequest, userID string) (*dto.HoldingTransactionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.Validation("Invalid transaction", err.Error())
+	}
+	if _, err := uc.ownedPortfolio(ctx, req.PortfolioID, userID); err != nil {
+		return nil, err
+	}
+	
+	executedAt := time.Now().UTC()
+	if req.ExecutedAt != nil {
+		executedAt = req.ExecutedAt.UTC()
+	}
+	
+	var (
+		holding *entities.PortfolioHolding
+		change  *repositories.HoldingChange
+	)
+	err := uc.portfolioRepo.RecordTransaction(ctx, req.HoldingID, func(locked *entities.PortfolioHolding, lots []entities.HoldingLot) (*repositories.HoldingChange, error) {
+		if locked.PortfolioID != req.PortfolioID {
+			return nil, errors.NotFound("Holding")
+		}
+		holding = locked
+		
+		// Holdings created before lots were tracked carry their position as amount and
+		// average price only, so open a single lot for it
+		if len(lots) == 0 && holding.Amount > 0 {
+			lots = append(lots, entities.HoldingLot{
+				HoldingID:  holding.ID,
+				Quantity:   holding.Amount,
+				Remaining:  holding.Amount,
+				Price:      holding.AveragePrice,
+				AcquiredAt: holding.CreatedAt,
+			})
+		}
+		
+		transaction := &entities.HoldingTransaction{
+			HoldingID:  holding.ID,
+			Side:       req.Side,
+			Quantity:   req.Quantity,
+			Price:      req.Price,
+			ExecutedAt: executedAt,
+		}
+		
+		switch req.Side {
+		case entities.TransactionSideBuy:
+			lots = append(lots, entities.HoldingLot{
+				HoldingID:  holding.ID,
+				Quantity:   req.Quantity,
+				Remaining:  req.Quantity,
+				Price:      req.Price,
+				AcquiredAt: executedAt,
+			})
+		case entities.TransactionSideSell:
+			realized, err := entities.ConsumeLotsFIFO(lots, req.Quantity, req.Price)
+			if stderrors.Is(err, entities.ErrInsufficientLots) {
+				return nil, errors.Validation("Sell quantity exceeds holding amount")
+			}
+			if err != nil {
+				return nil, err
+			}
+			transaction.RealizedPnL = realized
+			holding.RealizedPnL += realized
+		}
+		
+		holding.Amount, holding.AveragePrice = entities.OpenPosition(lots)
+		revalueHolding(holding)
+		
+		change = &repositories.HoldingChange{Transaction: transaction, Lots: lots}
+		return change, nil
+	})
+	if err != nil {
+		return nil, transactionError(err, "Failed to record transaction")
+	}
+	
+	entities.SortLotsFIFO(change.Lots)
+	return &dto.HoldingTransactionResponse{
+		Transaction: *change.Transaction,
+		Holding:     *dto.NewHoldingResponse(holding),
+		Lots:        change.Lots,
+	}, nil
+}
+
+// SellHolding sells part or all of a holding at its average cost: realized PnL is the
+// quantity times the sale price's premium over the average price, which the rest of the
+// position keeps. Lots shrink in proportion, which keeps their total and average price
+// equal to the holding's, so a holding can mix these sales with FIFO transactions: later
+// FIFO sells consume the shrunken lots, and each sale's PnL follows the method it used.
+// Selling the whole amount closes the holding; it is archived with its realized PnL and
+// removed in the same database transaction. The portfolio must belong to userID.
+func (uc *PortfolioUseCase) SellHolding(ctx context.Context, req *dto.SellHoldingRequest, userID string) (*dto.SellHoldingResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.Validation("Invalid sale", err.Error())
+	}
+	if _, err := uc.ownedPortfolio(ctx, req.PortfolioID, userID); err != nil {
+		return nil, err
+	}
+	
+	executedAt := time.Now().UTC()
+	if req.ExecutedAt != nil {
+		executedAt = req.ExecutedAt.UTC()
+	}
+	
+	var (
+		holding *entities.PortfolioHolding
+		change  *repositories.HoldingChange
+	)
+	err := uc.portfolioRepo.RecordTransaction(ctx, req.HoldingID, func(locked *entities.PortfolioHolding, lots []entities.HoldingLot) (*repositories.HoldingChange, error) {
+		if locked.PortfolioID != req.PortfolioID {
+			return nil, errors.NotFound("Holding")
+		}
+		holding = locked
+		if req.Quantity > holding.Amount+entities.QuantityEpsilon {
+			return nil, errors.Validation("Sell quantity exceeds holding amount",
+				fmt.Sprintf("quantity %g is more than the %g held", req.Quantity, holding.Amount))
+		}
+		
+		realized := (req.Price - holding.AveragePrice) * req.Quantity
+		transaction := &entities.HoldingTransaction{
+			HoldingID:   holding.ID,
+			Side:        entities.TransactionSideSell,
+			Quantity:    req.Quantity,
+			Price:       req.Price,
+			RealizedPnL: realized,
+			ExecutedAt:  executedAt,
+		}
+		
+		remaining := holding.Amount - req.Quantity
+		closed := remaining <= entities.QuantityEpsilon
+		if closed {
+			remaining = 0
+		}
+		keep := remaining / holding.Amount
+		for i := range lots {
+			lots[i].Remaining *= keep
+		}
+		
+		holding.Amount = remaining
+		holding.RealizedPnL += realized
+		revalueHolding(holding)
+		
+		change = &repositories.HoldingChange{Transaction: transaction, Lots: lots, Remove: closed}
+		return change, nil
+	})
+	if err != nil {
+		return nil, transactionError(err, "Failed to record sale")
+	}
+	
+	return &dto.SellHoldingResponse{
+		Transaction: *change.Transaction,
+		Holding:     *dto.NewHoldingResponse(holding),
+		Removed:     change.Remove,
+	}, nil
+}
+
+// ownedPortfolio loads a portfolio, refusing it unless it belongs to userID
+func (uc *PortfolioUseCase) ownedPortfolio(ctx context.Context, portfolioID uint, userID string) (*entities.Portfolio, error) {
+	portfolio, err := uc.portfolioRepo.GetByID(ctx, portfolioID)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+	if portfolio.UserID != userID {
+		return nil, errors.Forbidden("Portfolio belongs to another user")
+	}
+	
+	return portfolio, nil
+}
+
+// ownedHolding loads a holding of a portfolio, refusing it unless the portfolio belongs
+// to userID
+func (uc *PortfolioUseCase) ownedHolding(ctx context.Context, portfolioID, holdingID uint, userID string) (*entities.PortfolioHolding, error) {
+	if _, err := uc.ownedPortfolio(ctx, portfolioID, userID); err != nil {
+		return nil, err
+	}
+	
+	holding, err := uc.portfolioRepo.GetHolding(ctx, holdingID)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get holding: %w", err)
+	}
+	if holding.PortfolioID != portfolioID {
+		return nil, errors.NotFound("Holding")
+	}
+	
+	return holding, nil
+}
+
+// transactionError passes on the not-found and validation errors raised while applying a
+// transaction and reports anything else as an internal failure
+func transactionError(err error, message string) error {
+	if errors.IsType(err, errors.ErrorTypeNotFound) || errors.IsType(err, errors.ErrorTypeValidation) {
+		return err
+	}
+	return errors.Internal(message, err)
+}
+
+// revalueHolding recomputes a holding's value and unrealized PnL at its last known price
+func revalueHolding(holding *entities.PortfolioHolding) {
+	cost := holding.Amount * holding.AveragePrice
+	
+	holding.Value = holding.Amount * holding.CurrentPrice
+	holding.PnL = holding.Value - cost
+	holding.PnLPercent = 0
+	if cost > 0 {
+		holding.PnLPercent = holding.PnL / cost * 100
+	}
+}
@@ -0,0 +1,271 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lotRepository keeps the holding and lots written by RecordTransaction so the next
+// transaction sees them, the way the database repository would
+type lotRepository struct {
+	*testutil.MockPortfolioRepository
+	holding      entities.PortfolioHolding
+	lots         []entities.HoldingLot
+	nextID       uint
+	transactions int
+	removed      bool
+}
+
+func newLotRepository(holding entities.PortfolioHolding) *lotRepository {
+	return &lotRepository{MockPortfolioRepository: &testutil.MockPortfolioRepository{}, holding: holding, nextID: 1}
+}
+
+// GetByID serves alice's portfolio 1 and bob's portfolio 2
+func (r *lotRepository) GetByID(ctx context.Context, id uint) (*entities.Portfolio, error) {
+	owners := map[uint]string{1: "alice", 2: "bob"}
+	if owners[id] == "" {
+		return nil, errors.NotFound("Portfolio")
+	}
+	return &entities.Portfolio{ID: id, UserID: owners[id]}, nil
+}
+
+func (r *lotRepository) GetHolding(ctx context.Context, holdingID uint) (*entities.PortfolioHolding, error) {
+	if holdingID != r.holding.ID {
+		return nil, fmt.Errorf("holding not found")
+	}
+	holding := r.holding
+	return &holding, nil
+}
+
+func (r *lotRepository) GetLots(ctx context.Context, holdingID uint) ([]entities.HoldingLot, error) {
+	return append([]entities.HoldingLot(nil), r.lots...), nil
+}
+
+func (r *lotRepository) RecordTransaction(ctx context.Context, holdingID uint, apply repositories.HoldingTransactionFunc) error {
+	holding, err := r.GetHolding(ctx, holdingID)
+	if err != nil {
+		return errors.NotFound("Holding")
+	}
+	lots, _ := r.GetLots(ctx, holdingID)
+	change, err := apply(holding, lots)
+	if err != nil {
+		return err
+	}
+	if change.Remove {
+		r.removed = true
+		return nil
+	}
+	for i := range change.Lots {
+		if change.Lots[i].ID == 0 {
+			change.Lots[i].ID = r.nextID
+			r.nextID++
+		}
+	}
+	r.lots = append([]entities.HoldingLot(nil), change.Lots...)
+	r.holding = *holding
+	r.transactions++
+	return nil
+}
+
+func TestPortfolioUseCase_RecordTransaction_FIFO(t *testing.T) {
+	ctx := context.Background()
+	repo := newLotRepository(entities.PortfolioHolding{ID: 5, PortfolioID: 1, Symbol: "BTC", CurrentPrice: 250})
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	day := func(d int) *time.Time {
+		at := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &at
+	}
+	record := func(side string, quantity, price float64, at *time.Time) *dto.HoldingTransactionResponse {
+		result, err := uc.RecordTransaction(ctx, &dto.HoldingTransactionRequest{
+			PortfolioID: 1,
+			HoldingID:   5,
+			Side:        side,
+			Quantity:    quantity,
+			Price:       price,
+			ExecutedAt:  at,
+		}, "alice")
+		require.NoError(t, err)
+		return result
+	}
+
+	record(entities.TransactionSideBuy, 1, 100, day(1))
+	second := record(entities.TransactionSideBuy, 1, 200, day(2))
+	assert.InDelta(t, 2.0, second.Holding.Amount, 1e-9)
+	assert.InDelta(t, 150.0, second.Holding.AveragePrice, 1e-9)
+	assert.Zero(t, second.Holding.RealizedPnL)
+
+	// The sale empties the first lot (+200) and takes half of the second (+50)
+	sale := record(entities.TransactionSideSell, 1.5, 300, day(3))
+	assert.InDelta(t, 250.0, sale.Transaction.RealizedPnL, 1e-9)
+	assert.InDelta(t, 250.0, sale.Holding.RealizedPnL, 1e-9)
+	assert.InDelta(t, 0.5, sale.Holding.Amount, 1e-9)
+	assert.InDelta(t, 200.0, sale.Holding.AveragePrice, 1e-9)
+	assert.InDelta(t, 125.0, sale.Holding.Value, 1e-9)
+	assert.InDelta(t, 25.0, sale.Holding.PnL, 1e-9)
+
+	require.Len(t, sale.Lots, 2)
+	assert.Zero(t, sale.Lots[0].Remaining)
+	assert.InDelta(t, 0.5, sale.Lots[1].Remaining, 1e-9)
+	assert.Equal(t, 0.5, repo.holding.Amount)
+}
+
+func TestPortfolioUseCase_RecordTransaction_SeedsLegacyHolding(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo := newLotRepository(entities.PortfolioHolding{
+		ID: 5, PortfolioID: 1, Symbol: "ETH", Amount: 2, AveragePrice: 1000, CreatedAt: createdAt,
+	})
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	result, err := uc.RecordTransaction(ctx, &dto.HoldingTransactionRequest{
+		PortfolioID: 1, HoldingID: 5, Side: entities.TransactionSideSell, Quantity: 1, Price: 1500,
+	}, "alice")
+	require.NoError(t, err)
+
+	assert.InDelta(t, 500.0, result.Transaction.RealizedPnL, 1e-9)
+	assert.InDelta(t, 1.0, result.Holding.Amount, 1e-9)
+	require.Len(t, repo.lots, 1)
+	assert.Equal(t, createdAt, repo.lots[0].AcquiredAt)
+}
+
+func TestPortfolioUseCase_RecordTransaction_SellsRemainderDespiteDrift(t *testing.T) {
+	ctx := context.Background()
+	// 0.7 + 0.2 adds up to a hair below 0.9
+	repo := newLotRepository(entities.PortfolioHolding{ID: 5, PortfolioID: 1, Symbol: "BTC", Amount: 0.9, AveragePrice: 100})
+	repo.lots = []entities.HoldingLot{
+		{ID: 1, HoldingID: 5, Quantity: 1, Remaining: 0.7, Price: 100},
+		{ID: 2, HoldingID: 5, Quantity: 1, Remaining: 0.2, Price: 100},
+	}
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	result, err := uc.RecordTransaction(ctx, &dto.HoldingTransactionRequest{
+		PortfolioID: 1, HoldingID: 5, Side: entities.TransactionSideSell, Quantity: 0.9, Price: 200,
+	}, "alice")
+	require.NoError(t, err)
+
+	assert.InDelta(t, 90.0, result.Transaction.RealizedPnL, 1e-9)
+	assert.InDelta(t, 0, result.Holding.Amount, 1e-9)
+}
+
+func TestPortfolioUseCase_RecordTransaction_Errors(t *testing.T) {
+	ctx := context.Background()
+	repo := newLotRepository(entities.PortfolioHolding{ID: 5, PortfolioID: 1, Symbol: "BTC", Amount: 1, AveragePrice: 100})
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	tests := []struct {
+		name     string
+		userID   string
+		req      dto.HoldingTransactionRequest
+		expected errors.ErrorType
+	}{
+		{"unknown side", "alice", dto.HoldingTransactionRequest{PortfolioID: 1, HoldingID: 5, Side: "swap", Quantity: 1, Price: 1}, errors.ErrorTypeValidation},
+		{"oversell", "alice", dto.HoldingTransactionRequest{PortfolioID: 1, HoldingID: 5, Side: entities.TransactionSideSell, Quantity: 2, Price: 1}, errors.ErrorTypeValidation},
+		{"other portfolio", "bob", dto.HoldingTransactionRequest{PortfolioID: 2, HoldingID: 5, Side: entities.TransactionSideBuy, Quantity: 1, Price: 1}, errors.ErrorTypeNotFound},
+		{"another user's portfolio", "bob", dto.HoldingTransactionRequest{PortfolioID: 1, HoldingID: 5, Side: entities.TransactionSideSell, Quantity: 1, Price: 1}, errors.ErrorTypeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.RecordTransaction(ctx, &tt.req, tt.userID)
+			require.Error(t, err)
+			assert.True(t, errors.IsType(err, tt.expected))
+		})
+	}
+
+	assert.Zero(t, repo.transactions)
+}
+
+func TestPortfolioUseCase_SellHolding_Partial(t *testing.T) {
+	ctx := context.Background()
+	repo := newLotRepository(entities.PortfolioHolding{
+		ID: 5, PortfolioID: 1, Symbol: "BTC", Amount: 2, AveragePrice: 150, CurrentPrice: 250,
+	})
+	repo.lots = []entities.HoldingLot{
+		{ID: 1, HoldingID: 5, Quantity: 1, Remaining: 1, Price: 100},
+		{ID: 2, HoldingID: 5, Quantity: 1, Remaining: 1, Price: 200},
+	}
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	result, err := uc.SellHolding(ctx, &dto.SellHoldingRequest{PortfolioID: 1, HoldingID: 5, Quantity: 0.5, Price: 300}, "alice")
+	require.NoError(t, err)
+
+	// Average cost is 150, so each unit sold at 300 realizes 150
+	assert.InDelta(t, 75.0, result.Transaction.RealizedPnL, 1e-9)
+	assert.Equal(t, entities.TransactionSideSell, result.Transaction.Side)
+	assert.False(t, result.Removed)
+	assert.InDelta(t, 1.5, result.Holding.Amount, 1e-9)
+	assert.InDelta(t, 150.0, result.Holding.AveragePrice, 1e-9)
+	assert.InDelta(t, 75.0, result.Holding.RealizedPnL, 1e-9)
+	assert.InDelta(t, 375.0, result.Holding.Value, 1e-9)
+
+	// Lots shrink in proportion, keeping their average at the holding's
+	require.Len(t, repo.lots, 2)
+	assert.InDelta(t, 0.75, repo.lots[0].Remaining, 1e-9)
+	assert.InDelta(t, 0.75, repo.lots[1].Remaining, 1e-9)
+	assert.Equal(t, 1, repo.transactions)
+	assert.False(t, repo.removed)
+}
+
+func TestPortfolioUseCase_SellHolding_Full(t *testing.T) {
+	ctx := context.Background()
+	repo := newLotRepository(entities.PortfolioHolding{
+		ID: 5, PortfolioID: 1, Symbol: "ETH", Amount: 2, AveragePrice: 1000, RealizedPnL: 50,
+	})
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	result, err := uc.SellHolding(ctx, &dto.SellHoldingRequest{PortfolioID: 1, HoldingID: 5, Quantity: 2, Price: 800}, "alice")
+	require.NoError(t, err)
+
+	assert.True(t, result.Removed)
+	assert.InDelta(t, -400.0, result.Transaction.RealizedPnL, 1e-9)
+	assert.InDelta(t, -350.0, result.Holding.RealizedPnL, 1e-9)
+	assert.Zero(t, result.Holding.Amount)
+	assert.True(t, repo.removed, "the sale and the removal are one repository transaction")
+}
+
+func TestPortfolioUseCase_SellHolding_FullWithRoundingDust(t *testing.T) {
+	ctx := context.Background()
+	// 0.1 + 0.2 bought separately leaves an amount a hair above 0.3
+	repo := newLotRepository(entities.PortfolioHolding{ID: 5, PortfolioID: 1, Symbol: "BTC", Amount: 0.1 + 0.2, AveragePrice: 100})
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	result, err := uc.SellHolding(ctx, &dto.SellHoldingRequest{PortfolioID: 1, HoldingID: 5, Quantity: 0.3, Price: 200}, "alice")
+	require.NoError(t, err)
+
+	assert.True(t, result.Removed, "dust below the epsilon must not keep the holding open")
+	assert.Zero(t, result.Holding.Amount)
+	assert.True(t, repo.removed)
+}
+
+func TestPortfolioUseCase_SellHolding_Oversell(t *testing.T) {
+	ctx := context.Background()
+	repo := newLotRepository(entities.PortfolioHolding{ID: 5, PortfolioID: 1, Symbol: "BTC", Amount: 1, AveragePrice: 100})
+	uc := NewPortfolioUseCase(repo, nil, nil)
+
+	_, err := uc.SellHolding(ctx, &dto.SellHoldingRequest{PortfolioID: 1, HoldingID: 5, Quantity: 1.5, Price: 200}, "alice")
+	require.Error(t, err)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeValidation))
+
+	_, err = uc.SellHolding(ctx, &dto.SellHoldingRequest{PortfolioID: 2, HoldingID: 5, Quantity: 0.5, Price: 200}, "bob")
+	require.Error(t, err)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+
+	_, err = uc.SellHolding(ctx, &dto.SellHoldingRequest{PortfolioID: 1, HoldingID: 5, Quantity: 0.5, Price: 200}, "bob")
+	require.Error(t, err)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeForbidden))
+
+	assert.Zero(t, repo.transactions)
+	assert.Equal(t, 1.0, repo.holding.Amount)
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+)
+
+// Annotation types for chart event markers
+const (
+	AnnotationTypeHalving    = "halving"
+	AnnotationTypeNews       = "news"
+	AnnotationTypeRegulation = "regulation"
+	AnnotationTypeMacro      = "macro"
+	AnnotationTypeOther      = "other"
+)
+
+// IndicatorAnnotation marks an event, such as a halving or major news, on indicator charts
+type IndicatorAnnotation struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Timestamp time.Time `json:"timestamp" gorm:"not null;index"`
+	Label     string    `json:"label" gorm:"not null"`
+	Type      string    `json:"type" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for IndicatorAnnotation
+func (IndicatorAnnotation) TableName() string {
+	return "indicator_annotations"
+}
+
+// IsValidAnnotationType reports whether t is a known annotation type
+func IsValidAnnotationType(t string) bool {
+	switch t {
+	case AnnotationTypeHalving, AnnotationTypeNews, AnnotationTypeRegulation, AnnotationTypeMacro, AnnotationTypeOther:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// APIKeyScopeAdmin grants access to the /admin endpoints
+const APIKeyScopeAdmin = "admin"
+
+// APIKey represents a hashed API key issued to a user
+type APIKey struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     string     `json:"user_id" gorm:"not null;index"`
+	Name       string     `json:"name"`
+	KeyHash    string     `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes     string     `json:"scopes"`
+	Active     bool       `json:"active" gorm:"default:true"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for APIKey
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsValid reports whether the key is active and not expired at the given time
+func (k *APIKey) IsValid(now time.Time) bool {
+	if !k.Active {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// HasScope reports whether the comma-separated Scopes include scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range strings.Split(k.Scopes, ",") {
+		if strings.TrimSpace(s) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIKey returns the SHA-256 hex digest used to store and look up an API key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
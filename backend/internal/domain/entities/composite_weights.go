@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+)
+
+// CompositeComponents are the indicators the composite risk score can weight
+var CompositeComponents = []string{"mvrv", "fear_greed", "dominance", "rhodl", "coinbase_premium", "alt_season"}
+
+// DefaultCompositeWeights are the composite risk score weights used until an operator
+// stores their own
+var DefaultCompositeWeights = map[string]float64{
+	"mvrv":             0.3,
+	"fear_greed":       0.2,
+	"dominance":        0.15,
+	"rhodl":            0.15,
+	"coinbase_premium": 0.1,
+	"alt_season":       0.1,
+}
+
+// CompositeWeightConfig is one revision of the composite risk score weights. Revisions
+// are only ever added, so the latest row is the one in effect and older rows are history.
+type CompositeWeightConfig struct {
+	ID        uint               `json:"id" gorm:"primaryKey"`
+	Weights   map[string]float64 `json:"weights" gorm:"serializer:json;type:text;not null"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// TableName returns the table name for CompositeWeightConfig
+func (CompositeWeightConfig) TableName() string {
+	return "composite_weight_configs"
+}
+
+// CompositeScore is the composite risk score, 0 (lowest risk) to 100, combined from the
+// latest stored value of each weighted component
+type CompositeScore struct {
+	Score float64 `json:"score"`
+	// WeightsRevision is the ID of the weight revision used; zero when the defaults applied
+	WeightsRevision uint                      `json:"weights_revision"`
+	Components      []CompositeComponentScore `json:"components"`
+	// Missing lists weighted components without a stored value; their weight is spread
+	// over the components that have one
+	Missing    []string  `json:"missing,omitempty"`
+	Calculated time.Time `json:"calculated_at"`
+}
+
+// CompositeComponentScore is one component's contribution to the composite risk score
+type CompositeComponentScore struct {
+	Name      string    `json:"name"`
+	RiskLevel string    `json:"risk_level"`
+	Score     float64   `json:"score"`
+	Weight    float64   `json:"weight"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// IsCompositeComponent reports whether name is a component of the composite risk score
+func IsCompositeComponent(name string) bool {
+	for _, component := range CompositeComponents {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
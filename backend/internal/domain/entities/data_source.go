@@ -0,0 +1,82 @@
+package entities
+
+import "strings"
+
+// DataSource identifies where a recorded value came from. DataSource fields only hold the
+// canonical values below so they can be filtered and grouped on; how a value combined
+// several sources is described in a separate detail field.
+type DataSource string
+
+// Canonical data sources. Provider values match the provider names used by the price and
+// dominance source configs and the raw provider proxy.
+const (
+	DataSourceCoinMarketCap DataSource = "coinmarketcap"
+	DataSourceCoinGecko     DataSource = "coingecko"
+	DataSourceTradingView   DataSource = "tradingview"
+	DataSourceBinance       DataSource = "binance"
+	DataSourceCoinCap       DataSource = "coincap"
+	DataSourceAlternativeMe DataSource = "alternative_me"
+	DataSourceBlockchain    DataSource = "blockchain"
+	// DataSourceAggregated is a value combined from several sources, listed in its detail
+	DataSourceAggregated DataSource = "aggregated"
+	// DataSourceLastKnownGood is a previously stored value served because every source failed
+	DataSourceLastKnownGood DataSource = "last_known_good"
+	// DataSourceFallback is a configured static value served because every source failed
+	DataSourceFallback DataSource = "fallback"
+	// DataSourcePriceHistory is a value derived from stored price history
+	DataSourcePriceHistory DataSource = "price_history"
+	// DataSourceMarketData is a value derived from stored market data
+	DataSourceMarketData DataSource = "market_data"
+	// DataSourceBulkIngest is a precomputed value stored through bulk indicator ingestion
+	DataSourceBulkIngest DataSource = "bulk_ingest"
+	// DataSourceUnknown is a recorded label that matches no known source
+	DataSourceUnknown DataSource = "unknown"
+)
+
+// dataSourceLabels maps the free-form labels recorded before sources were canonical
+var dataSourceLabels = map[string]DataSource{
+	"coinmarketcap":   DataSourceCoinMarketCap,
+	"coingecko":       DataSourceCoinGecko,
+	"coingecko api":   DataSourceCoinGecko,
+	"tradingview":     DataSourceTradingView,
+	"binance":         DataSourceBinance,
+	"coincap":         DataSourceCoinCap,
+	"alternative.me":  DataSourceAlternativeMe,
+	"alternative_me":  DataSourceAlternativeMe,
+	"blockchain":      DataSourceBlockchain,
+	"blockchain.com":  DataSourceBlockchain,
+	"aggregated":      DataSourceAggregated,
+	"last known good": DataSourceLastKnownGood,
+	"last_known_good": DataSourceLastKnownGood,
+	"fallback":        DataSourceFallback,
+	"fallback data":   DataSourceFallback,
+	"price_history":   DataSourcePriceHistory,
+	"market_data":     DataSourceMarketData,
+	"bulk_ingest":     DataSourceBulkIngest,
+}
+
+// IsValid reports whether s is one of the canonical data sources
+func (s DataSource) IsValid() bool {
+	switch s {
+	case DataSourceCoinMarketCap, DataSourceCoinGecko, DataSourceTradingView, DataSourceBinance,
+		DataSourceCoinCap, DataSourceAlternativeMe, DataSourceBlockchain, DataSourceAggregated,
+		DataSourceLastKnownGood, DataSourceFallback, DataSourcePriceHistory, DataSourceMarketData,
+		DataSourceBulkIngest, DataSourceUnknown:
+		return true
+	}
+	return false
+}
+
+// NormalizeDataSource maps a recorded source label to its canonical source. Composite
+// labels such as "CoinMarketCap + TradingView (averaged)" become DataSourceAggregated,
+// with the label kept as detail; labels matching no source are DataSourceUnknown.
+func NormalizeDataSource(label string) (source DataSource, detail string) {
+	key := strings.ToLower(strings.TrimSpace(label))
+	if source, ok := dataSourceLabels[key]; ok {
+		return source, ""
+	}
+	if strings.Contains(key, "+") || strings.Contains(key, "averaged") {
+		return DataSourceAggregated, label
+	}
+	return DataSourceUnknown, label
+}
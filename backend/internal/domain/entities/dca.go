@@ -4,6 +4,22 @@ import (
 	"time"
 )
 
+// DCA purchase frequencies
+const (
+	DCAFrequencyDaily   = "daily"
+	DCAFrequencyWeekly  = "weekly"
+	DCAFrequencyMonthly = "monthly"
+)
+
+// IsDCAFrequency reports whether frequency is a supported purchase frequency
+func IsDCAFrequency(frequency string) bool {
+	switch frequency {
+	case DCAFrequencyDaily, DCAFrequencyWeekly, DCAFrequencyMonthly:
+		return true
+	}
+	return false
+}
+
 // DCAStrategy represents a dollar cost averaging strategy
 type DCAStrategy struct {
 	ID               uint       `json:"id"`
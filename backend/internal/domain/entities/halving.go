@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"time"
+)
+
+// BitcoinHalvings are the dates (UTC) of the Bitcoin block reward halvings so far, oldest
+// first. Each one starts a new market cycle for cycle comparisons.
+var BitcoinHalvings = []time.Time{
+	time.Date(2012, 11, 28, 0, 0, 0, 0, time.UTC),
+	time.Date(2016, 7, 9, 0, 0, 0, 0, time.UTC),
+	time.Date(2020, 5, 11, 0, 0, 0, 0, time.UTC),
+	time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC),
+}
@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Holding transaction sides
+const (
+	TransactionSideBuy  = "buy"
+	TransactionSideSell = "sell"
+)
+
+// QuantityEpsilon is the largest quantity difference treated as rounding error, so selling
+// what a holding shows as its amount closes it instead of leaving float dust behind
+const QuantityEpsilon = 1e-9
+
+// ErrInsufficientLots is returned when a sell exceeds the quantity left in a holding's lots
+var ErrInsufficientLots = errors.New("sell quantity exceeds remaining lots")
+
+// HoldingLot is the quantity acquired by a single buy. Sells consume the oldest lots first.
+type HoldingLot struct {
+	ID         uint      `json:"id"`
+	HoldingID  uint      `json:"holding_id"`
+	Quantity   float64   `json:"quantity"`
+	Remaining  float64   `json:"remaining"`
+	Price      float64   `json:"price"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// HoldingTransaction records a buy or sell against a holding
+type HoldingTransaction struct {
+	ID          uint      `json:"id"`
+	HoldingID   uint      `json:"holding_id"`
+	Side        string    `json:"side"`
+	Quantity    float64   `json:"quantity"`
+	Price       float64   `json:"price"`
+	RealizedPnL float64   `json:"realized_pnl"`
+	ExecutedAt  time.Time `json:"executed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SortLotsFIFO orders lots oldest first, breaking ties by creation order
+func SortLotsFIFO(lots []HoldingLot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		if !lots[i].AcquiredAt.Equal(lots[j].AcquiredAt) {
+			return lots[i].AcquiredAt.Before(lots[j].AcquiredAt)
+		}
+		return lots[i].ID < lots[j].ID
+	})
+}
+
+// ConsumeLotsFIFO sells quantity at price out of lots, oldest first, reducing each lot's
+// Remaining in place. It returns the realized PnL against the consumed lots' cost.
+// Lots are left untouched when quantity exceeds what they hold in total by more than
+// QuantityEpsilon, so selling the whole remainder survives float drift in the lots.
+func ConsumeLotsFIFO(lots []HoldingLot, quantity, price float64) (float64, error) {
+	SortLotsFIFO(lots)
+
+	available := 0.0
+	for _, lot := range lots {
+		available += lot.Remaining
+	}
+	if quantity > available+QuantityEpsilon {
+		return 0, ErrInsufficientLots
+	}
+
+	realized := 0.0
+	left := quantity
+	for i := range lots {
+		if left <= 0 {
+			break
+		}
+		if lots[i].Remaining <= 0 {
+			continue
+		}
+
+		used := lots[i].Remaining
+		if left < used {
+			used = left
+		}
+		lots[i].Remaining -= used
+		left -= used
+		realized += (price - lots[i].Price) * used
+	}
+
+	return realized, nil
+}
+
+// OpenPosition returns the quantity left across lots and its average cost
+func OpenPosition(lots []HoldingLot) (amount, averagePrice float64) {
+	cost := 0.0
+	for _, lot := range lots {
+		amount += lot.Remaining
+		cost += lot.Remaining * lot.Price
+	}
+	if amount > 0 {
+		averagePrice = cost / amount
+	}
+	return amount, averagePrice
+}
@@ -15,10 +15,12 @@ type Indicator struct {
 	RiskLevel    string                 `json:"risk_level"` // low, medium, high
 	Status       string                 `json:"status"`
 	Description  string                 `json:"description"`
-	Source       string                 `json:"source"`
+	Source       DataSource             `json:"source"`
 	Confidence   float64                `json:"confidence"` // 0.0 to 1.0
 	Metadata     map[string]interface{} `json:"metadata" gorm:"serializer:json"`
 	Timestamp    time.Time              `json:"timestamp"`
+	Version      uint                   `json:"version" gorm:"not null;default:1"` // Incremented on every update for optimistic locking
+	CalcVersion  uint                   `json:"calc_version" gorm:"not null;default:1;index"` // Version of the formula that produced Value
 	CreatedAt    time.Time              `json:"created_at"`
 	UpdatedAt    time.Time              `json:"updated_at"`
 }
@@ -71,17 +73,67 @@ type DominanceResult struct {
 
 // FearGreedResult represents Fear & Greed index analysis
 type FearGreedResult struct {
-	CurrentValue          int              `json:"current_value"`
-	Change24h             int              `json:"change_24h"`
-	Change7d              int              `json:"change_7d"`
-	Classification        string           `json:"classification"`
-	RiskLevel             string           `json:"risk_level"`
-	Status                string           `json:"status"`
-	Components            map[string]int   `json:"components"`
-	TradingRecommendation string           `json:"trading_recommendation"`
-	DataSource            string           `json:"data_source"`
-	NextUpdate            time.Time        `json:"next_update"`
-	LastUpdated           time.Time        `json:"last_updated"`
+	CurrentValue          int            `json:"current_value"`
+	Change24h             int            `json:"change_24h"`
+	Change7d              int            `json:"change_7d"`
+	Classification        string         `json:"classification"`
+	RiskLevel             string         `json:"risk_level"`
+	Status                string         `json:"status"`
+	Components            map[string]int `json:"components,omitempty"`
+	TradingRecommendation string         `json:"trading_recommendation"`
+	DataSource            DataSource     `json:"data_source"`
+	NextUpdate            time.Time      `json:"next_update"`
+	LastUpdated           time.Time      `json:"last_updated"`
+}
+
+// FearGreedReading is a single Fear & Greed index value from a data provider.
+// Components is nil when the provider only publishes the headline index.
+type FearGreedReading struct {
+	Value          int            `json:"value"`
+	Classification string         `json:"classification"`
+	Components     map[string]int `json:"components,omitempty"`
+	Timestamp      time.Time      `json:"timestamp"`
+	NextUpdate     time.Time      `json:"next_update"`
+}
+
+// FearGreedComponentHistory is the Fear & Greed component breakdown over time, rebuilt from
+// stored readings that carried components. Values and each component series are aligned with
+// Timestamps (Unix milliseconds); a nil entry means that reading lacked the component.
+type FearGreedComponentHistory struct {
+	Timestamps []int64           `json:"timestamps"`
+	Values     []int             `json:"values"`
+	Components map[string][]*int `json:"components"`
+}
+
+// HODLBands holds the realized value of the two HODL wave bands the RHODL ratio compares:
+// coins last moved within a week and coins last moved one to two years ago. Approximation
+// describes the proxy used when the bands are not measured on-chain.
+type HODLBands struct {
+	OneWeek       float64   `json:"one_week"`
+	OneToTwoYears float64   `json:"one_to_two_years"`
+	Approximation string    `json:"approximation,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// ETFFlows holds one day of aggregate spot Bitcoin ETF and trust flows in USD. NetFlow is
+// inflows minus outflows; Funds counts the products included in the totals.
+type ETFFlows struct {
+	Inflow    float64   `json:"inflow"`
+	Outflow   float64   `json:"outflow"`
+	NetFlow   float64   `json:"net_flow"`
+	Funds     int       `json:"funds"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ExchangeFlows holds one day of aggregate BTC flows into and out of exchange wallets, in
+// BTC. NetFlow is inflows minus outflows; Reserve is the BTC held on exchanges afterwards,
+// zero when the provider does not report it.
+type ExchangeFlows struct {
+	Inflow    float64   `json:"inflow"`
+	Outflow   float64   `json:"outflow"`
+	NetFlow   float64   `json:"net_flow"`
+	Reserve   float64   `json:"reserve"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // BubbleRiskResult represents bubble risk analysis
@@ -93,7 +145,7 @@ type BubbleRiskResult struct {
 	Status                string             `json:"status"`
 	Components            map[string]float64 `json:"components"`
 	TradingRecommendation string             `json:"trading_recommendation"`
-	DataSource            string             `json:"data_source"`
+	DataSource            DataSource         `json:"data_source"`
 	CriticalLevels        map[string]float64 `json:"critical_levels"`
 	LastUpdated           time.Time          `json:"last_updated"`
 }
@@ -116,4 +168,29 @@ type MarketCycle struct {
 // TableName returns the table name for MarketCycle
 func (MarketCycle) TableName() string {
 	return "market_cycles"
-}
\ No newline at end of file
+}
+// CorrelationPoint represents a pair of indicator values aligned to the same daily bucket
+type CorrelationPoint struct {
+	Date   time.Time `json:"date"`
+	ValueA float64   `json:"value_a"`
+	ValueB float64   `json:"value_b"`
+}
+
+// CorrelationResult represents the correlation between two indicator series
+type CorrelationResult struct {
+	IndicatorA   string             `json:"indicator_a"`
+	IndicatorB   string             `json:"indicator_b"`
+	Period       string             `json:"period"`
+	Coefficient  float64            `json:"coefficient"`
+	SampleSize   int                `json:"sample_size"`
+	Series       []CorrelationPoint `json:"series"`
+	CalculatedAt time.Time          `json:"calculated_at"`
+}
+
+// IndicatorChanges holds an indicator's change over fixed horizons.
+// A nil field means no historical value was found at that offset.
+type IndicatorChanges struct {
+	Change24h *float64 `json:"change_24h,omitempty"`
+	Change7d  *float64 `json:"change_7d,omitempty"`
+	Change30d *float64 `json:"change_30d,omitempty"`
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"time"
+)
+
+// IndicatorBand is one band of an indicator's scale, such as MVRV's "extreme_high"
+type IndicatorBand struct {
+	Label string `json:"label"`
+	// Min is the band's inclusive lower bound. The one band without a Min covers every
+	// value below the other bands.
+	Min       *float64 `json:"min,omitempty"`
+	RiskLevel string   `json:"risk_level"`
+	Status    string   `json:"status,omitempty"`
+}
+
+// IndicatorConfig holds an indicator's tunable description and bands. A stored config
+// replaces the indicator's default so bands can be tuned without redeploying.
+type IndicatorConfig struct {
+	Name        string          `json:"name" gorm:"primaryKey"`
+	Description string          `json:"description"`
+	Bands       []IndicatorBand `json:"bands" gorm:"serializer:json;type:text;not null"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// TableName returns the table name for IndicatorConfig
+func (IndicatorConfig) TableName() string {
+	return "indicator_configs"
+}
+
+// Classify returns the band value falls in: the band with the highest Min at or below
+// value, otherwise the unbounded band
+func (c IndicatorConfig) Classify(value float64) IndicatorBand {
+	var match, floor *IndicatorBand
+	for i := range c.Bands {
+		band := &c.Bands[i]
+		if band.Min == nil {
+			floor = band
+			continue
+		}
+		if value >= *band.Min && (match == nil || *band.Min > *match.Min) {
+			match = band
+		}
+	}
+
+	switch {
+	case match != nil:
+		return *match
+	case floor != nil:
+		return *floor
+	default:
+		return IndicatorBand{}
+	}
+}
+
+// Thresholds returns each bounded band's lower bound keyed by label
+func (c IndicatorConfig) Thresholds() map[string]float64 {
+	thresholds := make(map[string]float64, len(c.Bands))
+	for _, band := range c.Bands {
+		if band.Min != nil {
+			thresholds[band.Label] = *band.Min
+		}
+	}
+	return thresholds
+}
+
+// bandMin returns a pointer for a band's lower bound
+func bandMin(min float64) *float64 {
+	return &min
+}
+
+// defaultIndicatorConfigs are the built-in configs used until an operator stores their own
+var defaultIndicatorConfigs = map[string]IndicatorConfig{
+	"mvrv": {
+		Name:        "mvrv",
+		Description: "MVRV Z-Score: how far market cap sits above realized cap, in standard deviations",
+		Bands: []IndicatorBand{
+			{Label: "extreme_high", Min: bandMin(7.0), RiskLevel: "extreme_high", Status: "EXTREME: Historically top of cycle - Strong sell signal"},
+			{Label: "high", Min: bandMin(3.0), RiskLevel: "high", Status: "HIGH: Approaching cycle top - Consider taking profits"},
+			{Label: "medium", Min: bandMin(1.5), RiskLevel: "medium", Status: "MEDIUM: Testing resistance - Monitor closely"},
+			{Label: "above_average", Min: bandMin(0.5), RiskLevel: "low", Status: "LOW: Above average valuation - Neutral zone"},
+			{Label: "fair_value", Min: bandMin(-0.5), RiskLevel: "low", Status: "LOW: Fair value range - Accumulation zone"},
+			{Label: "below_average", Min: bandMin(-1.5), RiskLevel: "low", Status: "LOW: Below average - Good buying opportunity"},
+			{Label: "extreme_low", RiskLevel: "extreme_low", Status: "EXTREME: Historically bottom of cycle - Strong buy signal"},
+		},
+	},
+}
+
+// DefaultIndicatorConfig returns a copy of the built-in config for an indicator, and
+// false when the indicator has none
+func DefaultIndicatorConfig(name string) (IndicatorConfig, bool) {
+	config, ok := defaultIndicatorConfigs[name]
+	if !ok {
+		return IndicatorConfig{}, false
+	}
+
+	config.Bands = append([]IndicatorBand(nil), config.Bands...)
+	for i, band := range config.Bands {
+		if band.Min != nil {
+			config.Bands[i].Min = bandMin(*band.Min)
+		}
+	}
+	return config, true
+}
+
+// ConfigurableIndicators returns the names of indicators with a built-in config
+func ConfigurableIndicators() []string {
+	names := make([]string, 0, len(defaultIndicatorConfigs))
+	for name := range defaultIndicatorConfigs {
+		names = append(names, name)
+	}
+	return names
+}
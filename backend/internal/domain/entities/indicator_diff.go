@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"reflect"
+	"sort"
+)
+
+// IndicatorFieldChange is one field that differs between two indicator snapshots. Metadata
+// fields are named metadata.<key>; a key missing from one snapshot has a nil value there.
+type IndicatorFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// DiffIndicators lists the fields that changed from one snapshot to the other: value, risk
+// level, status, change, calculation version, and top-level metadata keys. Provenance is
+// left out since it has its own endpoint and differs on nearly every run.
+func DiffIndicators(from, to *Indicator) []IndicatorFieldChange {
+	changes := []IndicatorFieldChange{}
+	add := func(field string, a, b interface{}) {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, IndicatorFieldChange{Field: field, From: a, To: b})
+		}
+	}
+
+	add("value", from.Value, to.Value)
+	add("string_value", from.StringValue, to.StringValue)
+	add("change", from.Change, to.Change)
+	add("risk_level", from.RiskLevel, to.RiskLevel)
+	add("status", from.Status, to.Status)
+	add("calc_version", from.CalcVersion, to.CalcVersion)
+
+	keys := make(map[string]struct{}, len(from.Metadata)+len(to.Metadata))
+	for key := range from.Metadata {
+		keys[key] = struct{}{}
+	}
+	for key := range to.Metadata {
+		keys[key] = struct{}{}
+	}
+	delete(keys, ProvenanceMetadataKey)
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		add("metadata."+key, from.Metadata[key], to.Metadata[key])
+	}
+
+	return changes
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+const (
+	// IndicatorEventExtremeBandEntered is published when an indicator moves into an
+	// extreme_high or extreme_low risk level
+	IndicatorEventExtremeBandEntered = "extreme_band_entered"
+)
+
+// IndicatorEvent is a domain event about an indicator value, for notification systems
+type IndicatorEvent struct {
+	Type      string  `json:"type"`
+	Indicator string  `json:"indicator"`
+	Value     float64 `json:"value"`
+	RiskLevel string  `json:"risk_level"`
+	// PreviousRiskLevel is empty when no earlier value was seen
+	PreviousRiskLevel string    `json:"previous_risk_level,omitempty"`
+	Status            string    `json:"status"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// IsExtremeRiskLevel reports whether a risk level is one of the extreme bands
+func IsExtremeRiskLevel(riskLevel string) bool {
+	return riskLevel == "extreme_high" || riskLevel == "extreme_low"
+}
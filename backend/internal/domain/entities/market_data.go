@@ -4,20 +4,39 @@ import "time"
 
 // CryptoPrice represents cryptocurrency price data
 type CryptoPrice struct {
-	ID               uint      `json:"id" gorm:"primaryKey"`
-	Symbol           string    `json:"symbol" gorm:"index;not null"`
-	Name             string    `json:"name"`
-	Price            float64   `json:"price"`
-	Volume24h        float64   `json:"volume_24h"`
-	MarketCap        float64   `json:"market_cap"`
-	PercentChange1h  float64   `json:"percent_change_1h"`
-	PercentChange24h float64   `json:"percent_change_24h"`
-	PercentChange7d  float64   `json:"percent_change_7d"`
-	PercentChange30d float64   `json:"percent_change_30d"`
-	LastUpdated      time.Time `json:"last_updated"`
-	DataSource       string    `json:"data_source"`
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	Symbol           string     `json:"symbol" gorm:"index;not null"`
+	Name             string     `json:"name"`
+	Price            float64    `json:"price"`
+	Volume24h        float64    `json:"volume_24h"`
+	MarketCap        float64    `json:"market_cap"`
+	PercentChange1h  float64    `json:"percent_change_1h"`
+	PercentChange24h float64    `json:"percent_change_24h"`
+	PercentChange7d  float64    `json:"percent_change_7d"`
+	PercentChange30d float64    `json:"percent_change_30d"`
+	LastUpdated      time.Time  `json:"last_updated"`
+	DataSource       DataSource `json:"data_source"`
+	// DataSourceDetail names the sources an aggregated price was derived from
+	DataSourceDetail string    `json:"data_source_detail,omitempty"`
 	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Symbol metadata merged in for display; not stored with the price
+	Rank    int    `json:"rank,omitempty" gorm:"-"`
+	Slug    string `json:"slug,omitempty" gorm:"-"`
+	LogoURL string `json:"logo_url,omitempty" gorm:"-"`
+
+	// Metadata describes how an aggregated price was derived, such as its contributing sources
+	Metadata map[string]interface{} `json:"metadata,omitempty" gorm:"-"`
+}
+
+// SymbolMetadata holds slowly changing display metadata for a cryptocurrency symbol
+type SymbolMetadata struct {
+	Symbol  string `json:"symbol"`
+	Name    string `json:"name"`
+	Rank    int    `json:"rank"`
+	Slug    string `json:"slug"`
+	LogoURL string `json:"logo_url"`
 }
 
 // TableName returns the table name for CryptoPrice
@@ -27,16 +46,20 @@ func (CryptoPrice) TableName() string {
 
 // BitcoinDominance represents Bitcoin market dominance data
 type BitcoinDominance struct {
-	ID                uint      `json:"id" gorm:"primaryKey"`
-	CurrentDominance  float64   `json:"current_dominance"`
-	PreviousDominance float64   `json:"previous_dominance"`
-	Change24h         float64   `json:"change_24h"`
-	ChangePercent24h  float64   `json:"change_percent_24h"`
-	LastUpdated       time.Time `json:"last_updated"`
-	DataSource        string    `json:"data_source"`
-	Confidence        float64   `json:"confidence"` // Confidence level (0-1)
-	CreatedAt         time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt         time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	CurrentDominance  float64    `json:"current_dominance"`
+	PreviousDominance float64    `json:"previous_dominance"`
+	Change24h         float64    `json:"change_24h"`
+	ChangePercent24h  float64    `json:"change_percent_24h"`
+	LastUpdated       time.Time  `json:"last_updated"`
+	DataSource        DataSource `json:"data_source"`
+	// DataSourceDetail names the sources an aggregated reading was averaged from
+	DataSourceDetail string  `json:"data_source_detail,omitempty"`
+	Confidence       float64 `json:"confidence"` // Confidence level (0-1)
+	// Metadata explains the confidence: the factors it was derived from and why
+	Metadata  map[string]interface{} `json:"metadata,omitempty" gorm:"-"`
+	CreatedAt time.Time              `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time              `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // TableName returns the table name for BitcoinDominance
@@ -44,21 +67,32 @@ func (BitcoinDominance) TableName() string {
 	return "bitcoin_dominance"
 }
 
+// PriceTicker is a live 24h rolling ticker update for a trading pair
+type PriceTicker struct {
+	Symbol           string    `json:"symbol"`
+	Price            float64   `json:"price"`
+	ChangePercent24h float64   `json:"change_percent_24h"`
+	High24h          float64   `json:"high_24h"`
+	Low24h           float64   `json:"low_24h"`
+	Volume24h        float64   `json:"volume_24h"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
 // MarketMetrics represents overall market metrics
 type MarketMetrics struct {
-	ID                    uint      `json:"id" gorm:"primaryKey"`
-	TotalMarketCap        float64   `json:"total_market_cap"`
-	TotalVolume24h        float64   `json:"total_volume_24h"`
-	BitcoinDominance      float64   `json:"bitcoin_dominance"`
-	EthereumDominance     float64   `json:"ethereum_dominance"`
-	ActiveCryptocurrencies int      `json:"active_cryptocurrencies"`
-	ActiveExchanges       int       `json:"active_exchanges"`
-	MarketCapChange24h    float64   `json:"market_cap_change_24h"`
-	VolumeChange24h       float64   `json:"volume_change_24h"`
-	LastUpdated           time.Time `json:"last_updated"`
-	DataSource            string    `json:"data_source"`
-	CreatedAt             time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt             time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                     uint       `json:"id" gorm:"primaryKey"`
+	TotalMarketCap         float64    `json:"total_market_cap"`
+	TotalVolume24h         float64    `json:"total_volume_24h"`
+	BitcoinDominance       float64    `json:"bitcoin_dominance"`
+	EthereumDominance      float64    `json:"ethereum_dominance"`
+	ActiveCryptocurrencies int        `json:"active_cryptocurrencies"`
+	ActiveExchanges        int        `json:"active_exchanges"`
+	MarketCapChange24h     float64    `json:"market_cap_change_24h"`
+	VolumeChange24h        float64    `json:"volume_change_24h"`
+	LastUpdated            time.Time  `json:"last_updated"`
+	DataSource             DataSource `json:"data_source"`
+	CreatedAt              time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt              time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // TableName returns the table name for MarketMetrics
@@ -115,6 +149,57 @@ type MarketDataSummary struct {
 	LastUpdated          time.Time                   `json:"last_updated"`
 }
 
+// Market trend classifications of the average 24h change across top assets
+const (
+	MarketTrendBullish  = "bullish"
+	MarketTrendBearish  = "bearish"
+	MarketTrendSideways = "sideways"
+	MarketTrendUnknown  = "unknown"
+
+	// MarketTrendThreshold is the average 24h change, in percent, beyond which the market
+	// counts as bullish or bearish rather than sideways
+	MarketTrendThreshold = 3.0
+)
+
+// AverageChange24h returns the mean 24h percent change of prices, reporting false when
+// there are none
+func AverageChange24h(prices map[string]*CryptoPrice) (float64, bool) {
+	if len(prices) == 0 {
+		return 0, false
+	}
+
+	var total float64
+	for _, price := range prices {
+		total += price.PercentChange24h
+	}
+	return total / float64(len(prices)), true
+}
+
+// ClassifyMarketTrend classifies an average 24h percent change as bullish above
+// MarketTrendThreshold, bearish below its negative and sideways in between
+func ClassifyMarketTrend(avgChange24h float64) string {
+	switch {
+	case avgChange24h > MarketTrendThreshold:
+		return MarketTrendBullish
+	case avgChange24h < -MarketTrendThreshold:
+		return MarketTrendBearish
+	default:
+		return MarketTrendSideways
+	}
+}
+
+// MarketDataRefresh reports which parts of a market data refresh succeeded. Failed maps
+// each part that failed to its error message.
+type MarketDataRefresh struct {
+	Refreshed []string          `json:"refreshed"`
+	Failed    map[string]string `json:"failed,omitempty"`
+}
+
+// Partial reports whether some, but not all, parts of the refresh failed
+func (r *MarketDataRefresh) Partial() bool {
+	return len(r.Refreshed) > 0 && len(r.Failed) > 0
+}
+
 // GetTrendIndicator returns a simple trend indicator based on 24h changes
 func (cp *CryptoPrice) GetTrendIndicator() string {
 	if cp.PercentChange24h > 5 {
@@ -162,29 +247,29 @@ func (bd *BitcoinDominance) GetConfidenceLevel() string {
 
 // InflationResult represents inflation analysis results
 type InflationResult struct {
-	CurrentRate      float64   `json:"current_rate"`
-	PreviousRate     float64   `json:"previous_rate"`
-	Change           float64   `json:"change"`
-	ChangePercent    float64   `json:"change_percent"`
-	Trend            string    `json:"trend"` // "increasing", "decreasing", "stable"
-	ImpactOnCrypto   string    `json:"impact_on_crypto"` // "positive", "negative", "neutral"
-	LastUpdated      time.Time `json:"last_updated"`
-	DataSource       string    `json:"data_source"`
-	ConfidenceLevel  float64   `json:"confidence_level"`
+	CurrentRate     float64    `json:"current_rate"`
+	PreviousRate    float64    `json:"previous_rate"`
+	Change          float64    `json:"change"`
+	ChangePercent   float64    `json:"change_percent"`
+	Trend           string     `json:"trend"`            // "increasing", "decreasing", "stable"
+	ImpactOnCrypto  string     `json:"impact_on_crypto"` // "positive", "negative", "neutral"
+	LastUpdated     time.Time  `json:"last_updated"`
+	DataSource      DataSource `json:"data_source"`
+	ConfidenceLevel float64    `json:"confidence_level"`
 }
 
 // InterestRateResult represents interest rate analysis results  
 type InterestRateResult struct {
-	CurrentRate      float64   `json:"current_rate"`
-	PreviousRate     float64   `json:"previous_rate"`
-	Change           float64   `json:"change"`
-	ChangePercent    float64   `json:"change_percent"`
-	Trend            string    `json:"trend"` // "increasing", "decreasing", "stable"
-	ExpectedChange   string    `json:"expected_change"` // "hike", "cut", "hold"
-	ImpactOnCrypto   string    `json:"impact_on_crypto"` // "positive", "negative", "neutral"
-	LastUpdated      time.Time `json:"last_updated"`
-	DataSource       string    `json:"data_source"`
-	ConfidenceLevel  float64   `json:"confidence_level"`
+	CurrentRate     float64    `json:"current_rate"`
+	PreviousRate    float64    `json:"previous_rate"`
+	Change          float64    `json:"change"`
+	ChangePercent   float64    `json:"change_percent"`
+	Trend           string     `json:"trend"`            // "increasing", "decreasing", "stable"
+	ExpectedChange  string     `json:"expected_change"`  // "hike", "cut", "hold"
+	ImpactOnCrypto  string     `json:"impact_on_crypto"` // "positive", "negative", "neutral"
+	LastUpdated     time.Time  `json:"last_updated"`
+	DataSource      DataSource `json:"data_source"`
+	ConfidenceLevel float64    `json:"confidence_level"`
 }
 
 // MarketData represents unified market data for testing and services
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MetadataFieldType is the JSON type a metadata field must hold
+type MetadataFieldType string
+
+// Metadata field types
+const (
+	MetadataNumber MetadataFieldType = "number"
+	MetadataString MetadataFieldType = "string"
+	MetadataBool   MetadataFieldType = "bool"
+	MetadataObject MetadataFieldType = "object"
+	MetadataArray  MetadataFieldType = "array"
+)
+
+// MetadataSchema lists the fields an indicator's metadata must contain and their types.
+// Fields not in the schema are allowed.
+type MetadataSchema struct {
+	Required map[string]MetadataFieldType
+}
+
+// indicatorMetadataSchemas holds the metadata schema of each indicator, keyed by indicator name.
+// Indicators without a schema keep free-form metadata.
+var indicatorMetadataSchemas = map[string]MetadataSchema{
+	"mvrv": {
+		Required: map[string]MetadataFieldType{
+			"mvrv_ratio":   MetadataNumber,
+			"market_cap":   MetadataNumber,
+			"realized_cap": MetadataNumber,
+			"price":        MetadataNumber,
+			"z_score":      MetadataNumber,
+		},
+	},
+}
+
+// MetadataSchemaFor returns the metadata schema registered for an indicator name
+func MetadataSchemaFor(name string) (MetadataSchema, bool) {
+	schema, ok := indicatorMetadataSchemas[name]
+	return schema, ok
+}
+
+// Validate checks that metadata holds every required field with the right type and
+// reports all problems found in one error
+func (s MetadataSchema) Validate(metadata map[string]interface{}) error {
+	fields := make([]string, 0, len(s.Required))
+	for field := range s.Required {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var problems []string
+	for _, field := range fields {
+		want := s.Required[field]
+		value, ok := metadata[field]
+		if !ok || value == nil {
+			problems = append(problems, fmt.Sprintf("%s is required", field))
+			continue
+		}
+		if got := metadataFieldType(value); got != want {
+			problems = append(problems, fmt.Sprintf("%s must be a %s, got %s", field, want, got))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid metadata: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ValidateMetadata checks the indicator's metadata against the schema registered for its
+// name. Indicators without a schema always pass.
+func (i *Indicator) ValidateMetadata() error {
+	schema, ok := MetadataSchemaFor(i.Name)
+	if !ok {
+		return nil
+	}
+	return schema.Validate(i.Metadata)
+}
+
+// metadataFieldType reports the JSON type of a metadata value
+func metadataFieldType(value interface{}) MetadataFieldType {
+	switch value.(type) {
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return MetadataNumber
+	case string:
+		return MetadataString
+	case bool:
+		return MetadataBool
+	case map[string]interface{}:
+		return MetadataObject
+	case []interface{}:
+		return MetadataArray
+	default:
+		return MetadataFieldType(fmt.Sprintf("%T", value))
+	}
+}
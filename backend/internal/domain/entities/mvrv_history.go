@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// MVRVHistoryMetadataKey is the MVRV indicator metadata key its daily history is stored under
+const MVRVHistoryMetadataKey = "historical_data"
+
+// MVRVHistory returns the daily MVRV history stored in the indicator's metadata, oldest
+// first. It reads both values set in memory and the generic JSON form loaded back from
+// storage; points without a date are dropped.
+func (i *Indicator) MVRVHistory() ([]MVRVData, bool) {
+	value, ok := i.Metadata[MVRVHistoryMetadataKey]
+	if !ok || value == nil {
+		return nil, false
+	}
+
+	var history []MVRVData
+	if typed, ok := value.([]MVRVData); ok {
+		history = append(history, typed...)
+	} else {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, false
+		}
+		if err := json.Unmarshal(raw, &history); err != nil {
+			return nil, false
+		}
+	}
+
+	dated := history[:0]
+	for _, point := range history {
+		if !point.Date.IsZero() {
+			dated = append(dated, point)
+		}
+	}
+	sort.SliceStable(dated, func(a, b int) bool { return dated[a].Date.Before(dated[b].Date) })
+	return dated, true
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+)
+
+// NetworkMetrics is a snapshot of a blockchain network's statistics, stored in the
+// network_metrics hypertable
+type NetworkMetrics struct {
+	ID              uint       `json:"-" gorm:"primaryKey"`
+	Timestamp       time.Time  `json:"timestamp" gorm:"not null;index"`
+	Network         string     `json:"network" gorm:"size:20;not null"`
+	HashRate        float64    `json:"hash_rate"` // GH/s
+	Difficulty      float64    `json:"difficulty"`
+	BlockHeight     int64      `json:"block_height"`
+	TotalSupply     float64    `json:"total_supply"`
+	MempoolSize     int64      `json:"mempool_size"`     // Unconfirmed transactions
+	TransactionRate float64    `json:"transaction_rate"` // Transactions per minute
+	FeesTotal       float64    `json:"fees_total"`       // Fees paid over the last 24h
+	DataSource      DataSource `json:"data_source" gorm:"size:50;not null"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for NetworkMetrics
+func (NetworkMetrics) TableName() string {
+	return "network_metrics"
+}
@@ -28,6 +28,7 @@ type PortfolioHolding struct {
 	Value        float64   `json:"value"`
 	PnL          float64   `json:"pnl"`
 	PnLPercent   float64   `json:"pnl_percent"`
+	RealizedPnL  float64   `json:"realized_pnl"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
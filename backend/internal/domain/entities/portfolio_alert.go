@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// PortfolioDrawdownAlert fires when a portfolio's total value falls ThresholdPercent below
+// its trailing peak, the highest value seen since the alert was created. It fires once per
+// drawdown and re-arms when the value sets a new peak.
+type PortfolioDrawdownAlert struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	PortfolioID      uint       `json:"portfolio_id" gorm:"index;not null"`
+	UserID           string     `json:"user_id" gorm:"index;not null"`
+	ThresholdPercent float64    `json:"threshold_percent" gorm:"not null"`
+	PeakValue        float64    `json:"peak_value"`
+	PeakAt           *time.Time `json:"peak_at"`
+	Triggered        bool       `json:"triggered"`
+	IsActive         bool       `json:"is_active" gorm:"default:true"`
+	LastTriggered    *time.Time `json:"last_triggered"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for PortfolioDrawdownAlert
+func (PortfolioDrawdownAlert) TableName() string {
+	return "portfolio_drawdown_alerts"
+}
+
+// DrawdownPercent returns how far value sits below the peak, as a percentage of the peak
+func (a PortfolioDrawdownAlert) DrawdownPercent(value float64) float64 {
+	if a.PeakValue <= 0 || value >= a.PeakValue {
+		return 0
+	}
+	return (a.PeakValue - value) / a.PeakValue * 100
+}
+
+// PortfolioDrawdownEvent is dispatched when a drawdown alert fires
+type PortfolioDrawdownEvent struct {
+	AlertID          uint      `json:"alert_id"`
+	PortfolioID      uint      `json:"portfolio_id"`
+	UserID           string    `json:"user_id"`
+	ThresholdPercent float64   `json:"threshold_percent"`
+	PeakValue        float64   `json:"peak_value"`
+	Value            float64   `json:"value"`
+	DrawdownPercent  float64   `json:"drawdown_percent"`
+	Timestamp        time.Time `json:"timestamp"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+)
+
+// PriceObservation is one provider's USD price for an asset at a point in time, stored in
+// the price_data hypertable. ReliabilityScore is the confidence given to the provider, so
+// observations from several providers can be weighed against each other.
+type PriceObservation struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	Timestamp        time.Time  `json:"timestamp" gorm:"not null;index"`
+	AssetSymbol      string     `json:"asset_symbol" gorm:"size:10;not null;index"`
+	PriceUSD         float64    `json:"price_usd" gorm:"column:price_usd;not null"`
+	MarketCap        float64    `json:"market_cap,omitempty"`
+	Volume24h        float64    `json:"volume_24h,omitempty" gorm:"column:volume_24h"`
+	DataSource       DataSource `json:"data_source" gorm:"size:50;not null"`
+	ReliabilityScore float64    `json:"reliability_score"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// TableName returns the table name for PriceObservation
+func (PriceObservation) TableName() string {
+	return "price_data"
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"encoding/json"
+)
+
+// ProvenanceMetadataKey is the indicator metadata key provenance is recorded under
+const ProvenanceMetadataKey = "provenance"
+
+// IndicatorProvenance records what produced an indicator value: the data sources it was
+// read from, the inputs taken from them, the version of the calculation applied, and
+// whether a fallback replaced the normal calculation
+type IndicatorProvenance struct {
+	Sources            []string               `json:"sources"`
+	Inputs             map[string]interface{} `json:"inputs"`
+	CalculationVersion string                 `json:"calculation_version"`
+	Fallback           bool                   `json:"fallback"`
+	FallbackReason     string                 `json:"fallback_reason,omitempty"`
+}
+
+// SetProvenance records provenance in the indicator's metadata
+func (i *Indicator) SetProvenance(provenance IndicatorProvenance) {
+	if i.Metadata == nil {
+		i.Metadata = make(map[string]interface{})
+	}
+	i.Metadata[ProvenanceMetadataKey] = provenance
+}
+
+// Provenance returns the provenance recorded in the indicator's metadata. It reads both
+// values set in memory and the generic JSON form loaded back from storage.
+func (i *Indicator) Provenance() (*IndicatorProvenance, bool) {
+	switch value := i.Metadata[ProvenanceMetadataKey].(type) {
+	case IndicatorProvenance:
+		return &value, true
+	case *IndicatorProvenance:
+		return value, value != nil
+	case map[string]interface{}:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, false
+		}
+		var provenance IndicatorProvenance
+		if err := json.Unmarshal(raw, &provenance); err != nil {
+			return nil, false
+		}
+		return &provenance, true
+	}
+	return nil, false
+}
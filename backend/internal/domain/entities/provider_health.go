@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// ProviderHealthCheck is the recorded result of one data provider health check
+type ProviderHealthCheck struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	Provider string `json:"provider" gorm:"not null;index:idx_provider_health_provider_checked"`
+	Healthy  bool   `json:"healthy"`
+	Error    string `json:"error,omitempty"`
+	// LatencyMs is how long the check took, including failed ones
+	LatencyMs float64   `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at" gorm:"not null;index:idx_provider_health_provider_checked;index"`
+}
+
+// TableName returns the table name for ProviderHealthCheck
+func (ProviderHealthCheck) TableName() string {
+	return "provider_health_checks"
+}
+
+// ProviderHealthResult is the outcome of checking one data provider. A nil Err means the
+// provider is healthy.
+type ProviderHealthResult struct {
+	Latency time.Duration
+	Err     error
+}
+
+// LatencyMs returns the check's latency in milliseconds
+func (r ProviderHealthResult) LatencyMs() float64 {
+	return float64(r.Latency) / float64(time.Millisecond)
+}
+
+// ProviderHealthStatus summarizes a provider's latest health check and its recent uptime
+type ProviderHealthStatus struct {
+	Provider      string    `json:"provider"`
+	Healthy       bool      `json:"healthy"`
+	LastError     string    `json:"last_error,omitempty"`
+	LastCheckedAt time.Time `json:"last_checked_at"`
+	// UptimePercent is the share of checks within the window that passed, 0-100
+	UptimePercent float64 `json:"uptime_percent"`
+	Checks        int     `json:"checks"`
+	FailedChecks  int     `json:"failed_checks"`
+	// LastLatencyMs is the latest check's latency; AvgLatencyMs and MaxLatencyMs cover the
+	// checks within the window
+	LastLatencyMs float64 `json:"last_latency_ms"`
+	AvgLatencyMs  float64 `json:"avg_latency_ms"`
+	MaxLatencyMs  float64 `json:"max_latency_ms"`
+}
+
+// SummarizeProviderHealth reports each provider's latest result, and its uptime and
+// latency over the checks made since since. Providers are sorted by name; a provider
+// whose checks all predate since is reported with its latest result and no uptime.
+func SummarizeProviderHealth(checks []ProviderHealthCheck, since time.Time) []ProviderHealthStatus {
+	byProvider := make(map[string]*ProviderHealthStatus)
+	totalLatency := make(map[string]float64)
+	var names []string
+
+	for _, check := range checks {
+		status, ok := byProvider[check.Provider]
+		if !ok {
+			status = &ProviderHealthStatus{Provider: check.Provider}
+			byProvider[check.Provider] = status
+			names = append(names, check.Provider)
+		}
+
+		if !check.CheckedAt.Before(status.LastCheckedAt) {
+			status.LastCheckedAt = check.CheckedAt
+			status.Healthy = check.Healthy
+			status.LastError = check.Error
+			status.LastLatencyMs = check.LatencyMs
+		}
+
+		if check.CheckedAt.Before(since) {
+			continue
+		}
+		status.Checks++
+		if !check.Healthy {
+			status.FailedChecks++
+		}
+		totalLatency[check.Provider] += check.LatencyMs
+		if check.LatencyMs > status.MaxLatencyMs {
+			status.MaxLatencyMs = check.LatencyMs
+		}
+	}
+
+	sort.Strings(names)
+	statuses := make([]ProviderHealthStatus, 0, len(names))
+	for _, name := range names {
+		status := byProvider[name]
+		if status.Checks > 0 {
+			status.UptimePercent = float64(status.Checks-status.FailedChecks) / float64(status.Checks) * 100
+			status.AvgLatencyMs = totalLatency[name] / float64(status.Checks)
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// SmartDCACurvePoint maps an indicator reading to a purchase multiplier. Between points the
+// multiplier is interpolated linearly; beyond the first and last points it is held flat.
+type SmartDCACurvePoint struct {
+	Threshold  float64 `json:"threshold"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// SmartDCAConfig controls how a smart DCA strategy scales its base purchase amount. The
+// multipliers from the MVRV Z-score and Fear & Greed curves are averaged, then clamped to
+// [MinMultiplier, MaxMultiplier], which bounds what any purchase can spend. An empty curve
+// is ignored.
+type SmartDCAConfig struct {
+	ZScoreCurve    []SmartDCACurvePoint `json:"zscore_curve"`
+	FearGreedCurve []SmartDCACurvePoint `json:"fear_greed_curve"`
+	MinMultiplier  float64              `json:"min_multiplier"`
+	MaxMultiplier  float64              `json:"max_multiplier"`
+}
+
+// DefaultSmartDCAConfig buys up to twice the base amount near cycle bottoms (negative
+// Z-score, extreme fear) and down to a quarter of it near tops
+var DefaultSmartDCAConfig = SmartDCAConfig{
+	ZScoreCurve: []SmartDCACurvePoint{
+		{Threshold: -0.5, Multiplier: 2.0},
+		{Threshold: 0, Multiplier: 1.5},
+		{Threshold: 2, Multiplier: 1.0},
+		{Threshold: 5, Multiplier: 0.5},
+		{Threshold: 7, Multiplier: 0.25},
+	},
+	FearGreedCurve: []SmartDCACurvePoint{
+		{Threshold: 10, Multiplier: 2.0},
+		{Threshold: 25, Multiplier: 1.5},
+		{Threshold: 50, Multiplier: 1.0},
+		{Threshold: 75, Multiplier: 0.75},
+		{Threshold: 90, Multiplier: 0.25},
+	},
+	MinMultiplier: 0.25,
+	MaxMultiplier: 2.0,
+}
+
+// Validate checks that the bounds are ordered and each curve has increasing thresholds and
+// multipliers within the bounds
+func (c SmartDCAConfig) Validate() error {
+	if c.MinMultiplier < 0 {
+		return fmt.Errorf("min multiplier must not be negative")
+	}
+	if c.MaxMultiplier <= 0 || c.MaxMultiplier < c.MinMultiplier {
+		return fmt.Errorf("max multiplier must be positive and at least the min multiplier")
+	}
+	if len(c.ZScoreCurve) == 0 && len(c.FearGreedCurve) == 0 {
+		return fmt.Errorf("at least one multiplier curve is required")
+	}
+
+	curves := []struct {
+		name  string
+		curve []SmartDCACurvePoint
+	}{{"zscore", c.ZScoreCurve}, {"fear_greed", c.FearGreedCurve}}
+	for _, named := range curves {
+		name, curve := named.name, named.curve
+		for i, point := range curve {
+			if point.Multiplier < c.MinMultiplier || point.Multiplier > c.MaxMultiplier {
+				return fmt.Errorf("%s curve multiplier %g is outside [%g, %g]", name, point.Multiplier, c.MinMultiplier, c.MaxMultiplier)
+			}
+			if i > 0 && point.Threshold <= curve[i-1].Threshold {
+				return fmt.Errorf("%s curve thresholds must be strictly increasing", name)
+			}
+		}
+	}
+	return nil
+}
+
+// SmartDCAMultiplier is the purchase multiplier for a set of indicator readings. A reading
+// that is not available is nil and its curve does not contribute.
+type SmartDCAMultiplier struct {
+	MVRVZScore *float64  `json:"mvrv_zscore"`
+	FearGreed  *int      `json:"fear_greed"`
+	Multiplier float64   `json:"multiplier"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// DCABacktestPoint is one scheduled purchase in a backtest with the price and indicator
+// readings at that time
+type DCABacktestPoint struct {
+	Date       time.Time `json:"date"`
+	Price      float64   `json:"price"`
+	MVRVZScore float64   `json:"mvrv_zscore"`
+	FearGreed  int       `json:"fear_greed"`
+}
+
+// DCABacktestComparison holds the results of the same schedule run with a fixed amount and
+// with smart DCA multipliers
+type DCABacktestComparison struct {
+	Fixed          *DCASimulation `json:"fixed"`
+	Smart          *DCASimulation `json:"smart"`
+	SmartPurchases []DCAPurchase  `json:"smart_purchases"`
+}
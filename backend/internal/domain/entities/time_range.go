@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+)
+
+// DefaultHistoryPeriod is the relative period used when none or an unknown one is given
+const DefaultHistoryPeriod = "30d"
+
+// TimeRange is a window of history, inclusive of both ends
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// IsKnownPeriod reports whether period is one PeriodRange covers, rather than one it replaces
+// with DefaultHistoryPeriod
+func IsKnownPeriod(period string) bool {
+	switch period {
+	case "7d", "30d", "90d", "1y":
+		return true
+	default:
+		return false
+	}
+}
+
+// PeriodRange returns the window a relative period (7d, 30d, 90d or 1y) covers, ending at now.
+// Without a location it is a rolling window; with one it starts at midnight in that zone, so
+// the period covers whole calendar days there. Unknown periods cover DefaultHistoryPeriod.
+func PeriodRange(period string, now time.Time, loc *time.Location) TimeRange {
+	start := now
+	if loc != nil {
+		local := now.In(loc)
+		start = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	}
+
+	var from time.Time
+	switch period {
+	case "7d":
+		from = start.AddDate(0, 0, -7)
+	case "90d":
+		from = start.AddDate(0, 0, -90)
+	case "1y":
+		from = start.AddDate(-1, 0, 0)
+	default:
+		from = start.AddDate(0, 0, -30)
+	}
+
+	return TimeRange{From: from, To: now}
+}
@@ -8,15 +8,15 @@ import (
 
 // CoinCapClient defines the interface for CoinCap API interactions
 type CoinCapClient interface {
-	GetAssets(limit int) (*external.AssetsResponse, error)
-	GetAsset(assetID string) (*external.AssetResponse, error)
-	GetAssetHistory(assetID, interval string, start, end *time.Time) (*external.HistoryResponse, error)
-	GetMarkets(assetID string, limit int) (*external.MarketsResponse, error)
-	GetBitcoinPrice() (float64, error)
-	GetTop10Assets() (*external.AssetsResponse, error)
-	GetBitcoinHistoricalData(interval string, days int) (*external.HistoryResponse, error)
-	GetGlobalMarketData() (map[string]interface{}, error)
-	HealthCheck() error
+	GetAssets(ctx context.Context, limit int) (*external.AssetsResponse, error)
+	GetAsset(ctx context.Context, assetID string) (*external.AssetResponse, error)
+	GetAssetHistory(ctx context.Context, assetID, interval string, start, end *time.Time) (*external.HistoryResponse, error)
+	GetMarkets(ctx context.Context, assetID string, limit int) (*external.MarketsResponse, error)
+	GetBitcoinPrice(ctx context.Context) (float64, error)
+	GetTop10Assets(ctx context.Context) (*external.AssetsResponse, error)
+	GetBitcoinHistoricalData(ctx context.Context, interval string, days int) (*external.HistoryResponse, error)
+	GetGlobalMarketData(ctx context.Context) (map[string]interface{}, error)
+	HealthCheck(ctx context.Context) (time.Duration, error)
 }
 
 // CoinMarketCapClient defines the interface for CoinMarketCap API interactions
@@ -24,17 +24,17 @@ type CoinMarketCapClient interface {
 	GetLatestQuotes(symbols []string) (map[string]interface{}, error)
 	GetGlobalMetrics() (map[string]interface{}, error)
 	GetHistoricalData(symbol string, start, end time.Time) ([]map[string]interface{}, error)
-	HealthCheck() error
+	HealthCheck() (time.Duration, error)
 }
 
 // BlockchainClient defines the interface for blockchain data interactions
 type BlockchainClient interface {
-	GetNetworkStats() (map[string]interface{}, error)
-	GetBlockHeight() (int64, error)
-	GetHashRate() (float64, error)
-	GetDifficulty() (float64, error)
-	GetMempoolSize() (int64, error)
-	HealthCheck() error
+	GetNetworkSummary(ctx context.Context) (map[string]interface{}, error)
+	GetLatestBlockHeight(ctx context.Context) (int64, error)
+	GetHashRate(ctx context.Context) (float64, error)
+	GetDifficulty(ctx context.Context) (float64, error)
+	GetMempoolSize(ctx context.Context) (int64, error)
+	HealthCheck(ctx context.Context) (time.Duration, error)
 }
 
 // HTTPClient defines the interface for HTTP interactions
@@ -83,7 +83,7 @@ type NotificationService interface {
 
 // HealthChecker defines the interface for health check operations
 type HealthChecker interface {
-	HealthCheck() error
+	HealthCheck() (time.Duration, error)
 	Name() string
 }
 
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"time"
+)
+
+// AnnotationRepository defines the interface for chart annotation data operations
+type AnnotationRepository interface {
+	Create(ctx context.Context, annotation *entities.IndicatorAnnotation) error
+	GetByID(ctx context.Context, id uint) (*entities.IndicatorAnnotation, error)
+	// GetInRange returns annotations with from <= timestamp <= to, oldest first
+	GetInRange(ctx context.Context, from, to time.Time) ([]entities.IndicatorAnnotation, error)
+	Update(ctx context.Context, annotation *entities.IndicatorAnnotation) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+)
+
+// APIKeyRepository defines the interface for API key data operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *entities.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	TouchLastUsed(ctx context.Context, id uint) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+)
+
+// CompositeWeightRepository defines the interface for stored composite score weights
+type CompositeWeightRepository interface {
+	// GetLatest returns the most recent weights, or a not found error when none are stored
+	GetLatest(ctx context.Context) (*entities.CompositeWeightConfig, error)
+	// Create stores a new revision of the weights
+	Create(ctx context.Context, config *entities.CompositeWeightConfig) error
+}
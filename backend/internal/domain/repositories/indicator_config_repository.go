@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+)
+
+// IndicatorConfigRepository defines the interface for stored indicator configs
+type IndicatorConfigRepository interface {
+	// List returns every stored config, ordered by name
+	List(ctx context.Context) ([]entities.IndicatorConfig, error)
+	// Save stores config, replacing any stored config with the same name
+	Save(ctx context.Context, config *entities.IndicatorConfig) error
+}
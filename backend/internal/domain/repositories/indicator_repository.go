@@ -19,11 +19,15 @@ type IndicatorRepository interface {
 	// Historical data operations
 	GetHistoricalData(ctx context.Context, name string, from, to time.Time) ([]entities.Indicator, error)
 	GetLatest(ctx context.Context, name string) (*entities.Indicator, error)
+	GetNearest(ctx context.Context, name string, at time.Time) (*entities.Indicator, error)
 	GetLatestByType(ctx context.Context, indicatorType string) ([]entities.Indicator, error)
+	GetLatestForTypes(ctx context.Context, types []string) ([]entities.Indicator, error)
+	GetLatestHighConfidence(ctx context.Context, indicatorType string, minConfidence float64) ([]entities.Indicator, error)
+	GetLatestByTypeInRange(ctx context.Context, indicatorType string, from, to time.Time, limit int) ([]entities.Indicator, error)
 	
 	// Bulk operations
 	BulkCreate(ctx context.Context, indicators []entities.Indicator) error
-	CleanupOldData(ctx context.Context, olderThan time.Time) error
+	CleanupOldData(ctx context.Context, olderThan time.Time) (int64, error)
 }
 
 // MarketDataRepository defines the interface for market data operations
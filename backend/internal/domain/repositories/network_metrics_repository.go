@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+)
+
+// NetworkMetricsRepository defines the interface for stored network metrics snapshots
+type NetworkMetricsRepository interface {
+	// Save stores a network metrics snapshot
+	Save(ctx context.Context, metrics *entities.NetworkMetrics) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+)
+
+// PortfolioAlertRepository defines the interface for stored portfolio drawdown alerts
+type PortfolioAlertRepository interface {
+	CreateDrawdownAlert(ctx context.Context, alert *entities.PortfolioDrawdownAlert) error
+	// GetDrawdownAlerts returns a portfolio's alerts, oldest first; activeOnly skips
+	// deactivated ones
+	GetDrawdownAlerts(ctx context.Context, portfolioID uint, activeOnly bool) ([]entities.PortfolioDrawdownAlert, error)
+	// UpdateDrawdownAlert stores an alert's peak and trigger state
+	UpdateDrawdownAlert(ctx context.Context, alert *entities.PortfolioDrawdownAlert) error
+}
@@ -17,10 +17,41 @@ type PortfolioRepository interface {
 	// Portfolio Holdings operations
 	AddHolding(ctx context.Context, portfolioID uint, holding *entities.PortfolioHolding) error
 	UpdateHolding(ctx context.Context, holding *entities.PortfolioHolding) error
+	// UpdateHoldings sets the amount and average price of several holdings of a portfolio in
+	// one transaction; if any update fails, none are applied
+	UpdateHoldings(ctx context.Context, portfolioID uint, holdings []entities.PortfolioHolding) error
+	// RemoveHolding removes a holding together with its lots and transactions
 	RemoveHolding(ctx context.Context, holdingID uint) error
+	// ClearHoldings removes every holding of a portfolio, with its lots and transactions, and
+	// resets its total value in one transaction, copying the holdings to the archive first
+	// when archive is set. It returns the number of holdings removed.
+	ClearHoldings(ctx context.Context, portfolioID uint, archive bool) (int, error)
+	GetHolding(ctx context.Context, holdingID uint) (*entities.PortfolioHolding, error)
 	GetHoldings(ctx context.Context, portfolioID uint) ([]entities.PortfolioHolding, error)
+	GetActivePortfolioIDs(ctx context.Context) ([]uint, error)
+	
+	// Cost basis operations
+	GetLots(ctx context.Context, holdingID uint) ([]entities.HoldingLot, error)
+	// RecordTransaction locks a holding and its lots on the primary, passes them to apply
+	// and stores the change it returns together with the updated holding, all in one
+	// transaction. An error from apply rolls everything back and is returned as is.
+	RecordTransaction(ctx context.Context, holdingID uint, apply HoldingTransactionFunc) error
 	
 	// Portfolio analytics
 	CalculateTotalValue(ctx context.Context, portfolioID uint) (float64, error)
 	GetPortfolioSummary(ctx context.Context, portfolioID uint) (*entities.PortfolioSummary, error)
-}
\ No newline at end of file
+}
+
+// HoldingChange is what a HoldingTransactionFunc asks to store: the transaction and every
+// lot of the holding, new lots included. Stored IDs are written back into it.
+type HoldingChange struct {
+	Transaction *entities.HoldingTransaction
+	Lots        []entities.HoldingLot
+	// Remove closes the holding instead: it is archived with its final realized PnL and
+	// removed together with its lots and transactions, and Transaction is not stored
+	Remove bool
+}
+
+// HoldingTransactionFunc applies a transaction to a holding, updating it in place, given
+// its lots oldest first
+type HoldingTransactionFunc func(holding *entities.PortfolioHolding, lots []entities.HoldingLot) (*HoldingChange, error)
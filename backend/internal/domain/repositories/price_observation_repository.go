@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"time"
+)
+
+// PriceObservationRepository defines the interface for time-series price observations
+type PriceObservationRepository interface {
+	// StoreObservations stores a batch of price observations
+	StoreObservations(ctx context.Context, observations []entities.PriceObservation) error
+	// GetObservations returns an asset's observations from every source between from and
+	// to, oldest first
+	GetObservations(ctx context.Context, symbol string, from, to time.Time) ([]entities.PriceObservation, error)
+}
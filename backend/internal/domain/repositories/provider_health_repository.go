@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"time"
+)
+
+// ProviderHealthRepository defines the interface for recorded provider health checks
+type ProviderHealthRepository interface {
+	// Record stores a batch of health check results
+	Record(ctx context.Context, checks []entities.ProviderHealthCheck) error
+	// GetSince returns checks made at or after since, oldest first
+	GetSince(ctx context.Context, since time.Time) ([]entities.ProviderHealthCheck, error)
+	// DeleteBefore removes checks made before cutoff and returns how many were deleted
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -25,4 +25,15 @@ type DCAService interface {
 	// Analytics
 	CalculateStrategyPerformance(ctx context.Context, strategyID uint) (map[string]interface{}, error)
 	GetOptimalDCAFrequency(ctx context.Context, symbol string) (string, error)
-}
\ No newline at end of file
+}
+
+// SmartDCAService scales DCA purchases by market valuation: more when the MVRV Z-score and
+// Fear & Greed index say Bitcoin is cheap, less when they say it is expensive
+type SmartDCAService interface {
+	// Multiplier returns the purchase multiplier for the given readings; a nil reading is ignored
+	Multiplier(zScore *float64, fearGreed *int) float64
+	// CurrentMultiplier returns the multiplier for the latest stored MVRV Z-score and Fear & Greed readings
+	CurrentMultiplier(ctx context.Context) (*entities.SmartDCAMultiplier, error)
+	// Backtest runs the purchase schedule with a fixed amount and with smart multipliers
+	Backtest(ctx context.Context, amount float64, points []entities.DCABacktestPoint) (*entities.DCABacktestComparison, error)
+}
@@ -3,15 +3,82 @@ package services
 import (
 	"context"
 	"crypto-indicator-dashboard/internal/domain/entities"
+	"time"
 )
 
 // IndicatorService defines the general interface for indicator calculations
 type IndicatorService interface {
 	Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error)
-	GetHistoricalData(ctx context.Context, period string) ([]entities.Indicator, error)
+	GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error)
 	GetLatest(ctx context.Context) (*entities.Indicator, error)
 }
 
+// HistoricalIndicatorService is implemented by indicator services that can compute their
+// indicator as of a past date, for backfills and backtesting. CalculateAt persists the result
+// with the at timestamp rather than the time of calculation.
+type HistoricalIndicatorService interface {
+	IndicatorService
+	CalculateAt(ctx context.Context, at time.Time, params map[string]interface{}) (*entities.Indicator, error)
+}
+
+// RecomputeLock is a short-lived, per-key lock that keeps concurrent callers from running the
+// same expensive recomputation at once. A Redis-backed lock spans instances; an in-process one
+// only covers the current process.
+type RecomputeLock interface {
+	// TryAcquire takes the lock for key without blocking and holds it for at most ttl.
+	// It returns false when someone else already holds it.
+	TryAcquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Release gives up a lock taken by TryAcquire
+	Release(ctx context.Context, key string) error
+}
+
+// EventPublisher delivers indicator domain events to notification systems. Publish is
+// called synchronously from indicator calculations, so implementations should hand slow
+// deliveries off rather than block.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entities.IndicatorEvent) error
+}
+
+// CorrelationService defines the interface for cross-indicator correlation analysis
+type CorrelationService interface {
+	CalculateCorrelation(ctx context.Context, indicatorA, indicatorB, period string) (*entities.CorrelationResult, error)
+}
+
+// IndicatorChangeService computes multi-horizon changes from stored indicator history
+type IndicatorChangeService interface {
+	CalculateChanges(ctx context.Context, name string, current float64, at time.Time) (*entities.IndicatorChanges, error)
+}
+
+// CompositeWeightService manages the component weights of the composite risk score, so
+// they can be tuned at runtime rather than by redeploying
+type CompositeWeightService interface {
+	// GetWeights returns the weights in effect: the latest stored revision, or the defaults
+	// when none has been stored
+	GetWeights(ctx context.Context) (*entities.CompositeWeightConfig, error)
+	// UpdateWeights validates weights and stores them as the new revision
+	UpdateWeights(ctx context.Context, weights map[string]float64) (*entities.CompositeWeightConfig, error)
+	// GetCompositeScore combines the latest stored component values using the weights in effect
+	GetCompositeScore(ctx context.Context) (*entities.CompositeScore, error)
+}
+
+// IndicatorConfigProvider supplies the config in effect for an indicator: the stored one,
+// or the built-in default when none is stored
+type IndicatorConfigProvider interface {
+	IndicatorConfig(name string) (entities.IndicatorConfig, bool)
+}
+
+// IndicatorConfigService manages indicator descriptions and bands, so analysts can tune
+// them at runtime rather than by redeploying
+type IndicatorConfigService interface {
+	IndicatorConfigProvider
+	// Load reads the stored configs; call it at startup
+	Load(ctx context.Context) error
+	// List returns the config in effect for every configurable indicator
+	List(ctx context.Context) ([]entities.IndicatorConfig, error)
+	// Update validates config and stores it as name's config
+	Update(ctx context.Context, name string, config entities.IndicatorConfig) (*entities.IndicatorConfig, error)
+}
+
 // MVRVService defines the interface for MVRV analysis
 type MVRVService interface {
 	GetMVRVZScore(ctx context.Context) (*entities.MVRVResult, error)
@@ -30,9 +97,40 @@ type DominanceService interface {
 type FearGreedService interface {
 	GetFearGreedAnalysis(ctx context.Context) (*entities.FearGreedResult, error)
 	GetFearGreedChart(ctx context.Context) (map[string]interface{}, error)
+	GetComponentHistory(ctx context.Context, window entities.TimeRange) (*entities.FearGreedComponentHistory, error)
 	AnalyzeSentiment(ctx context.Context, value int) string
 }
 
+// FearGreedProvider supplies Fear & Greed index readings so the data source can be swapped.
+// GetReadings returns up to limit daily readings, newest first.
+type FearGreedProvider interface {
+	Name() string
+	GetReadings(ctx context.Context, limit int) ([]entities.FearGreedReading, error)
+}
+
+// HODLBandProvider supplies realized-cap HODL bands for the RHODL ratio so an on-chain
+// source can replace the default price history proxy
+type HODLBandProvider interface {
+	Name() string
+	GetHODLBands(ctx context.Context) (*entities.HODLBands, error)
+}
+
+// ETFFlowProvider supplies daily spot Bitcoin ETF and trust flows. Configured reports
+// whether the provider is backed by a real data source.
+type ETFFlowProvider interface {
+	Name() string
+	Configured() bool
+	GetETFFlows(ctx context.Context) (*entities.ETFFlows, error)
+}
+
+// ExchangeFlowProvider supplies daily BTC exchange flows. Configured reports whether the
+// provider is backed by a real data source.
+type ExchangeFlowProvider interface {
+	Name() string
+	Configured() bool
+	GetExchangeFlows(ctx context.Context) (*entities.ExchangeFlows, error)
+}
+
 // BubbleRiskService defines the interface for bubble risk analysis
 type BubbleRiskService interface {
 	GetBubbleRiskAnalysis(ctx context.Context) (*entities.BubbleRiskResult, error)
@@ -7,8 +7,9 @@ import (
 
 // MarketDataService defines the interface for market data operations
 type MarketDataService interface {
-	// GetCryptoPrices retrieves current cryptocurrency prices
-	GetCryptoPrices(ctx context.Context, symbols []string) (map[string]*entities.CryptoPrice, error)
+	// GetCryptoPrices retrieves current cryptocurrency prices. Requested symbols that no
+	// price was found for are returned as unresolved rather than failing the call.
+	GetCryptoPrices(ctx context.Context, symbols []string) (prices map[string]*entities.CryptoPrice, unresolved []string, err error)
 	
 	// GetBitcoinDominance retrieves current Bitcoin dominance data
 	GetBitcoinDominance(ctx context.Context) (*entities.BitcoinDominance, error)
@@ -19,11 +20,40 @@ type MarketDataService interface {
 	// GetTopCryptoPrices gets prices for top N cryptocurrencies by market cap
 	GetTopCryptoPrices(ctx context.Context, count int) (map[string]*entities.CryptoPrice, error)
 	
-	// RefreshAllMarketData refreshes all market data from external sources
-	RefreshAllMarketData(ctx context.Context) error
+	// RefreshMarketMetrics fetches market-wide totals and dominance and stores them
+	RefreshMarketMetrics(ctx context.Context) (*entities.MarketMetrics, error)
 	
-	// HealthCheck performs health checks on all external data sources
-	HealthCheck(ctx context.Context) map[string]error
+	// GetLatestMarketMetrics returns the latest stored market metrics, refreshing them when stale
+	GetLatestMarketMetrics(ctx context.Context) (*entities.MarketMetrics, error)
+	
+	// RefreshAllMarketData refreshes prices, dominance and market metrics, attempting every
+	// part even when another fails. It returns an error only when every part failed.
+	RefreshAllMarketData(ctx context.Context) (*entities.MarketDataRefresh, error)
+	
+	// HealthCheck checks every external data source, reporting each one's latency and error
+	HealthCheck(ctx context.Context) map[string]entities.ProviderHealthResult
+}
+
+// SymbolMetadataService provides display metadata (rank, slug, logo) for cryptocurrency symbols
+type SymbolMetadataService interface {
+	// GetMetadata returns metadata keyed by upper-case symbol; unknown symbols are omitted
+	GetMetadata(ctx context.Context, symbols []string) (map[string]*entities.SymbolMetadata, error)
+
+	// UnknownSymbols returns the given symbols that are not listed cryptocurrencies
+	UnknownSymbols(ctx context.Context, symbols []string) ([]string, error)
+}
+
+// NetworkMetricsService provides blockchain network statistics
+type NetworkMetricsService interface {
+	// GetBitcoinNetworkMetrics returns current Bitcoin network statistics
+	GetBitcoinNetworkMetrics(ctx context.Context) (*entities.NetworkMetrics, error)
+}
+
+// TickerStream delivers live ticker updates for a trading pair
+type TickerStream interface {
+	// Stream sends updates for symbol, such as BTCUSDT, to out until ctx is cancelled,
+	// reconnecting to the upstream as needed
+	Stream(ctx context.Context, symbol string, out chan<- entities.PriceTicker) error
 }
 
 // CacheService defines the interface for caching operations
@@ -40,6 +70,10 @@ type CacheService interface {
 	// Delete removes a value from cache
 	Delete(ctx context.Context, key string) error
 	
+	// DeletePattern removes every value whose key matches a glob pattern (*, ? and [...])
+	// and returns how many were removed
+	DeletePattern(ctx context.Context, pattern string) (int, error)
+	
 	// Exists checks if a key exists in cache
 	Exists(ctx context.Context, key string) bool
 	
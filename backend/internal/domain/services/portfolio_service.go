@@ -24,6 +24,28 @@ type PortfolioService interface {
 	CalculateRiskMetrics(ctx context.Context, portfolioID uint) (*entities.PortfolioRiskMetrics, error)
 	GetAssetAllocation(ctx context.Context, portfolioID uint) ([]entities.AssetAllocation, error)
 	UpdatePortfolioValues(ctx context.Context, portfolioID uint) error
+	RefreshValuations(ctx context.Context, portfolioID uint) (*entities.Portfolio, error)
+}
+
+// PortfolioValuationService re-prices stored portfolio holdings at current market prices
+type PortfolioValuationService interface {
+	RefreshValuations(ctx context.Context, portfolioID uint) (*entities.Portfolio, error)
+}
+
+// PortfolioAlertService defines the interface for portfolio drawdown alerts
+type PortfolioAlertService interface {
+	// CreateDrawdownAlert adds an alert to a portfolio the user owns, starting its peak at
+	// the portfolio's current value
+	CreateDrawdownAlert(ctx context.Context, userID string, portfolioID uint, thresholdPercent float64) (*entities.PortfolioDrawdownAlert, error)
+	GetDrawdownAlerts(ctx context.Context, userID string, portfolioID uint) ([]entities.PortfolioDrawdownAlert, error)
+	// EvaluateDrawdown moves each active alert's peak up to the portfolio's value, or fires
+	// the alert once the value has fallen past its threshold
+	EvaluateDrawdown(ctx context.Context, portfolio *entities.Portfolio) error
+}
+
+// PortfolioAlertNotifier delivers fired portfolio alerts to the user
+type PortfolioAlertNotifier interface {
+	NotifyDrawdown(ctx context.Context, event entities.PortfolioDrawdownEvent) error
 }
 
 // RiskAnalysisService defines the interface for portfolio risk analysis
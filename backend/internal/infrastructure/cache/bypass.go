@@ -0,0 +1,18 @@
+package cache
+
+import "context"
+
+// bypassKey marks a context whose GetOrSet calls skip cached values
+type bypassKey struct{}
+
+// WithBypass returns a context under which Get misses and GetOrSet ignores cached values
+// and always calls its fetcher. The fresh value is still cached for later callers.
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// Bypassed reports whether ctx was marked by WithBypass
+func Bypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassKey{}).(bool)
+	return bypass
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
 	"crypto-indicator-dashboard/pkg/logger"
 )
 
 // cacheServiceImpl implements the CacheService interface
 type cacheServiceImpl struct {
-	redisCache   services.CacheService
+	redisCache CacheService
+	logger     logger.Logger
+
+	// mu guards fallbackCache, which serves every request when Redis is down
+	mu            sync.RWMutex
 	fallbackCache map[string]fallbackCacheItem
-	logger       logger.Logger
 }
 
 // fallbackCacheItem represents an item in the fallback cache
@@ -22,8 +27,9 @@ type fallbackCacheItem struct {
 	ExpiresAt  time.Time
 }
 
-// NewCacheService creates a new cache service with Redis primary and in-memory fallback
-func NewCacheService(redisCache services.CacheService, logger logger.Logger) services.CacheService {
+// NewCacheService creates a new cache service with Redis primary and in-memory fallback.
+// A nil redisCache keeps every value in the in-memory fallback.
+func NewCacheService(redisCache CacheService, logger logger.Logger) services.CacheService {
 	return &cacheServiceImpl{
 		redisCache:    redisCache,
 		fallbackCache: make(map[string]fallbackCacheItem),
@@ -33,8 +39,8 @@ func NewCacheService(redisCache services.CacheService, logger logger.Logger) ser
 
 // GetOrSet gets a value from cache or sets it using the provided function
 func (c *cacheServiceImpl) GetOrSet(ctx context.Context, key string, dest interface{}, expiration interface{}, setFunc func() (interface{}, error)) error {
-	// Try to get from Redis first
-	if c.redisCache != nil {
+	// Try to get from Redis first, unless the caller asked for a fresh value
+	if c.redisCache != nil && !Bypassed(ctx) {
 		err := c.redisCache.Get(ctx, key, dest)
 		if err == nil {
 			c.logger.Debug("Cache hit from Redis", "key", key)
@@ -44,15 +50,12 @@ func (c *cacheServiceImpl) GetOrSet(ctx context.Context, key string, dest interf
 	}
 	
 	// Try fallback cache
-	if item, exists := c.fallbackCache[key]; exists {
-		if time.Now().Before(item.ExpiresAt) {
-			if err := json.Unmarshal(item.Data, dest); err == nil {
+	if !Bypassed(ctx) {
+		if data, exists := c.fallbackGet(key); exists {
+			if err := json.Unmarshal(data, dest); err == nil {
 				c.logger.Debug("Cache hit from fallback", "key", key)
 				return nil
 			}
-		} else {
-			// Remove expired item
-			delete(c.fallbackCache, key)
 		}
 	}
 	
@@ -80,6 +83,11 @@ func (c *cacheServiceImpl) GetOrSet(ctx context.Context, key string, dest interf
 
 // Get retrieves a value from cache
 func (c *cacheServiceImpl) Get(ctx context.Context, key string, dest interface{}) error {
+	// A bypassed context misses in both caches, so callers fetch fresh data
+	if Bypassed(ctx) {
+		return fmt.Errorf("key not found in cache: %s", key)
+	}
+
 	// Try Redis first
 	if c.redisCache != nil {
 		err := c.redisCache.Get(ctx, key, dest)
@@ -89,12 +97,8 @@ func (c *cacheServiceImpl) Get(ctx context.Context, key string, dest interface{}
 	}
 	
 	// Try fallback cache
-	if item, exists := c.fallbackCache[key]; exists {
-		if time.Now().Before(item.ExpiresAt) {
-			return json.Unmarshal(item.Data, dest)
-		} else {
-			delete(c.fallbackCache, key)
-		}
+	if data, exists := c.fallbackGet(key); exists {
+		return json.Unmarshal(data, dest)
 	}
 	
 	return fmt.Errorf("key not found in cache: %s", key)
@@ -131,10 +135,12 @@ func (c *cacheServiceImpl) Set(ctx context.Context, key string, value interface{
 		return fmt.Errorf("failed to marshal value for fallback cache: %w", err)
 	}
 	
+	c.mu.Lock()
 	c.fallbackCache[key] = fallbackCacheItem{
 		Data:      data,
 		ExpiresAt: time.Now().Add(exp),
 	}
+	c.mu.Unlock()
 	
 	c.logger.Debug("Set cache in fallback", "key", key, "expiration", exp)
 	return nil
@@ -142,49 +148,78 @@ func (c *cacheServiceImpl) Set(ctx context.Context, key string, value interface{
 
 // Exists checks if a key exists in cache
 func (c *cacheServiceImpl) Exists(ctx context.Context, key string) bool {
-	// Check Redis first (note: interface is different for Redis cache)
-	// For now, we'll skip Redis exists check and use Get for existence checking
-	
-	// Check fallback cache
-	if item, exists := c.fallbackCache[key]; exists {
-		if time.Now().Before(item.ExpiresAt) {
+	// Check Redis first
+	if c.redisCache != nil {
+		if exists, err := c.redisCache.Exists(ctx, key); err == nil && exists {
 			return true
-		} else {
-			// Remove expired item
-			delete(c.fallbackCache, key)
 		}
 	}
-	
-	return false
+
+	// Check fallback cache
+	_, exists := c.fallbackGet(key)
+	return exists
 }
 
 // Delete removes a value from cache
 func (c *cacheServiceImpl) Delete(ctx context.Context, key string) error {
 	// Delete from Redis
 	if c.redisCache != nil {
-		if err := c.redisCache.Delete(ctx, key); err != nil {
+		if err := c.redisCache.Delete(ctx, key); err != nil && !errors.IsType(err, errors.ErrorTypeNotFound) {
 			c.logger.Warn("Failed to delete from Redis cache", "key", key, "error", err)
 		}
 	}
 	
 	// Delete from fallback cache
+	c.mu.Lock()
 	delete(c.fallbackCache, key)
+	c.mu.Unlock()
 	
 	c.logger.Debug("Deleted from cache", "key", key)
 	return nil
 }
 
+// DeletePattern removes every value whose key matches pattern from both caches. The
+// fallback cache matches keys the way Redis does (see matchPattern).
+func (c *cacheServiceImpl) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	if err := validatePattern(pattern); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	if c.redisCache != nil {
+		n, err := c.redisCache.DeletePattern(ctx, pattern)
+		if err != nil {
+			c.logger.Warn("Failed to delete pattern from Redis cache", "pattern", pattern, "error", err)
+		}
+		deleted += n
+	}
+
+	c.mu.Lock()
+	for key := range c.fallbackCache {
+		if matchPattern(pattern, key) {
+			delete(c.fallbackCache, key)
+			deleted++
+		}
+	}
+	c.mu.Unlock()
+
+	c.logger.Debug("Deleted from cache by pattern", "pattern", pattern, "count", deleted)
+	return deleted, nil
+}
+
 // Clear clears all cache entries
 func (c *cacheServiceImpl) Clear(ctx context.Context) error {
 	// Clear Redis
 	if c.redisCache != nil {
-		if err := c.redisCache.Clear(ctx); err != nil {
+		if err := c.redisCache.FlushAll(ctx); err != nil {
 			c.logger.Warn("Failed to clear Redis cache", "error", err)
 		}
 	}
 	
 	// Clear fallback cache
+	c.mu.Lock()
 	c.fallbackCache = make(map[string]fallbackCacheItem)
+	c.mu.Unlock()
 	
 	c.logger.Info("Cleared all cache")
 	return nil
@@ -215,8 +250,32 @@ func (c *cacheServiceImpl) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// fallbackGet returns the unexpired fallback entry for key, dropping it once it has expired
+func (c *cacheServiceImpl) fallbackGet(key string) ([]byte, bool) {
+	c.mu.RLock()
+	item, exists := c.fallbackCache[key]
+	c.mu.RUnlock()
+	if !exists {
+		return nil, false
+	}
+	if time.Now().Before(item.ExpiresAt) {
+		return item.Data, true
+	}
+
+	c.mu.Lock()
+	// Another request may have stored a fresh value since the read above
+	if current, ok := c.fallbackCache[key]; ok && !time.Now().Before(current.ExpiresAt) {
+		delete(c.fallbackCache, key)
+	}
+	c.mu.Unlock()
+	return nil, false
+}
+
 // cleanupExpired removes expired items from fallback cache (should be called periodically)
 func (c *cacheServiceImpl) cleanupExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	now := time.Now()
 	for key, item := range c.fallbackCache {
 		if now.After(item.ExpiresAt) {
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheService_BypassMissesEveryCache(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("test")
+	redis := NewMockCache(log)
+	store := NewCacheService(redis, log)
+
+	require.NoError(t, redis.Set(ctx, "in_redis", "cached", time.Minute))
+	require.NoError(t, store.Set(ctx, "in_redis_too", "cached", time.Minute))
+
+	var value string
+	require.NoError(t, store.Get(ctx, "in_redis", &value))
+	assert.Equal(t, "cached", value)
+
+	for _, key := range []string{"in_redis", "in_redis_too"} {
+		assert.Error(t, store.Get(WithBypass(ctx), key, &value), key)
+	}
+
+	// GetOrSet fetches under a bypass and caches the fresh value for later callers
+	require.NoError(t, store.GetOrSet(WithBypass(ctx), "in_redis", &value, time.Minute, func() (interface{}, error) {
+		return "fresh", nil
+	}))
+	assert.Equal(t, "fresh", value)
+	require.NoError(t, store.Get(ctx, "in_redis", &value))
+	assert.Equal(t, "fresh", value)
+}
+
+func TestCacheService_FallbackIsSafeForConcurrentUse(t *testing.T) {
+	ctx := context.Background()
+	store := NewCacheService(nil, logger.New("test")).(*cacheServiceImpl)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("indicator:%d:%d", worker, i%10)
+				var value int
+				assert.NoError(t, store.GetOrSet(ctx, key, &value, time.Minute, func() (interface{}, error) {
+					return i, nil
+				}))
+				store.Exists(ctx, key)
+				_ = store.Get(ctx, key, &value)
+				_, err := store.DeletePattern(ctx, fmt.Sprintf("indicator:%d:*", worker))
+				assert.NoError(t, err)
+				assert.NoError(t, store.Delete(ctx, key))
+				if i%50 == 0 {
+					store.cleanupExpired()
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	require.NoError(t, store.Clear(ctx))
+	assert.False(t, store.Exists(ctx, "indicator:0:0"))
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"crypto-indicator-dashboard/pkg/errors"
+)
+
+// validatePattern rejects key patterns with an unterminated [...] class or a trailing \,
+// which Redis would silently match differently from what was meant
+func validatePattern(pattern string) error {
+	p := []rune(pattern)
+	for i := 0; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			if i == len(p)-1 {
+				return errors.Validation("Invalid cache key pattern", "pattern ends with an escape")
+			}
+			i++
+		case '[':
+			end := classEnd(p, i+1)
+			if end < 0 {
+				return errors.Validation("Invalid cache key pattern", "unterminated [ in pattern")
+			}
+			i = end
+		}
+	}
+	return nil
+}
+
+// matchPattern reports whether key matches pattern with Redis glob semantics: * matches any
+// run of characters, / included, ? matches one character, [...] matches one character
+// from a set or range (negated with ^), and \ escapes the next character
+func matchPattern(pattern, key string) bool {
+	return matchRunes([]rune(pattern), []rune(key))
+}
+
+func matchRunes(p, k []rune) bool {
+	for len(p) > 0 {
+		switch p[0] {
+		case '*':
+			for len(p) > 0 && p[0] == '*' {
+				p = p[1:]
+			}
+			if len(p) == 0 {
+				return true
+			}
+			for i := range k {
+				if matchRunes(p, k[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(k) == 0 {
+				return false
+			}
+		case '[':
+			end := classEnd(p, 1)
+			if len(k) == 0 || end < 0 || !matchClass(p[1:end], k[0]) {
+				return false
+			}
+			p = p[end:]
+		case '\\':
+			if len(p) > 1 {
+				p = p[1:]
+			}
+			fallthrough
+		default:
+			if len(k) == 0 || p[0] != k[0] {
+				return false
+			}
+		}
+		p, k = p[1:], k[1:]
+	}
+	return len(k) == 0
+}
+
+// classEnd returns the index of the ] closing the class whose body starts at start, or -1
+func classEnd(p []rune, start int) int {
+	for i := start; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			i++
+		case ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// matchClass reports whether c is in the class body between [ and ]
+func matchClass(class []rune, c rune) bool {
+	negate := len(class) > 0 && class[0] == '^'
+	if negate {
+		class = class[1:]
+	}
+
+	matched := false
+	for i := 0; i < len(class); i++ {
+		switch {
+		case class[i] == '\\' && i+1 < len(class):
+			i++
+			matched = matched || class[i] == c
+		case i+2 < len(class) && class[i+1] == '-':
+			lo, hi := class[i], class[i+2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			matched = matched || (c >= lo && c <= hi)
+			i += 2
+		default:
+			matched = matched || class[i] == c
+		}
+	}
+	return matched != negate
+}
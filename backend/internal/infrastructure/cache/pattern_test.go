@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"indicator_*", "indicator_mvrv", true},
+		{"indicator_*", "market_summary", false},
+		// Unlike path.Match, * crosses /, as it does in Redis
+		{"chart:*", "chart:mvrv/30d", true},
+		{"*", "", true},
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"key_[a-c]", "key_b", true},
+		{"key_[c-a]", "key_b", true},
+		{"key_[a-c]", "key_d", false},
+		{`key\*`, "key*", true},
+		{`key\*`, "key_mvrv", false},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchPattern(tt.pattern, tt.key), "%q against %q", tt.pattern, tt.key)
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	for _, pattern := range []string{"indicator_*", "key_[a-c]", `key\*`, `key_[\]]`} {
+		assert.NoError(t, validatePattern(pattern), pattern)
+	}
+	for _, pattern := range []string{"indicator_[", `key\`, `key_[\]`} {
+		assert.Error(t, validatePattern(pattern), pattern)
+	}
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/services"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// releaseLockScript deletes the lock only if it still holds our token, so a holder whose
+// TTL lapsed can't release a lock someone else has since taken
+var releaseLockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+// redisRecomputeLock implements RecomputeLock with SET NX so it holds across instances
+type redisRecomputeLock struct {
+	client *redis.Client
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewRedisRecomputeLock creates a recompute lock shared by every instance using client
+func NewRedisRecomputeLock(client *redis.Client) services.RecomputeLock {
+	return &redisRecomputeLock{
+		client: client,
+		tokens: make(map[string]string),
+	}
+}
+
+// TryAcquire sets key to a random token if it doesn't exist yet
+func (l *redisRecomputeLock) TryAcquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return false, err
+	}
+
+	acquired, err := l.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if acquired {
+		l.mu.Lock()
+		l.tokens[key] = token
+		l.mu.Unlock()
+	}
+	return acquired, nil
+}
+
+// Release deletes key if this instance still holds it
+func (l *redisRecomputeLock) Release(ctx context.Context, key string) error {
+	l.mu.Lock()
+	token, ok := l.tokens[key]
+	delete(l.tokens, key)
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := releaseLockScript.Run(ctx, l.client, []string{key}, token).Err(); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	return nil
+}
+
+func newLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// localRecomputeLock implements RecomputeLock in memory for a single instance
+type localRecomputeLock struct {
+	mu   sync.Mutex
+	held map[string]time.Time
+}
+
+// NewLocalRecomputeLock creates a recompute lock that only covers the current process
+func NewLocalRecomputeLock() services.RecomputeLock {
+	return &localRecomputeLock{
+		held: make(map[string]time.Time),
+	}
+}
+
+// TryAcquire takes key unless it is held and not yet expired
+func (l *localRecomputeLock) TryAcquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := l.held[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	l.held[key] = now.Add(ttl)
+	return true, nil
+}
+
+// Release frees key
+func (l *localRecomputeLock) Release(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.held, key)
+	return nil
+}
@@ -15,6 +15,7 @@ type CacheService interface {
 	Get(ctx context.Context, key string, dest interface{}) error
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Delete(ctx context.Context, key string) error
+	DeletePattern(ctx context.Context, pattern string) (int, error)
 	Exists(ctx context.Context, key string) (bool, error)
 	FlushAll(ctx context.Context) error
 	GetOrSet(ctx context.Context, key string, dest interface{}, fetcher func() (interface{}, error), expiration time.Duration) error
@@ -95,6 +96,30 @@ func (c *redisCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// DeletePattern removes every key matching pattern, scanning rather than blocking Redis with
+// KEYS, and returns how many were removed
+func (c *redisCache) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	c.logger.Debug("Deleting values from cache by pattern", "pattern", pattern)
+
+	deleted := 0
+	iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		n, err := c.client.Del(ctx, iter.Val()).Result()
+		if err != nil {
+			c.logger.Error("Failed to delete value from cache", "error", err, "key", iter.Val())
+			return deleted, errors.Wrap(err, errors.ErrorTypeExternal, "failed to delete value from cache")
+		}
+		deleted += int(n)
+	}
+	if err := iter.Err(); err != nil {
+		c.logger.Error("Failed to scan cache keys", "error", err, "pattern", pattern)
+		return deleted, errors.Wrap(err, errors.ErrorTypeExternal, "failed to scan cache keys")
+	}
+
+	c.logger.Debug("Successfully deleted values from cache by pattern", "pattern", pattern, "count", deleted)
+	return deleted, nil
+}
+
 // Exists checks if a key exists in cache
 func (c *redisCache) Exists(ctx context.Context, key string) (bool, error) {
 	c.logger.Debug("Checking if key exists in cache", "key", key)
@@ -127,8 +152,11 @@ func (c *redisCache) FlushAll(ctx context.Context) error {
 func (c *redisCache) GetOrSet(ctx context.Context, key string, dest interface{}, fetcher func() (interface{}, error), expiration time.Duration) error {
 	c.logger.Debug("GetOrSet operation", "key", key, "expiration", expiration)
 
-	// Try to get from cache first
-	err := c.Get(ctx, key, dest)
+	// Try to get from cache first, unless the caller asked for a fresh value
+	var err error = errors.NotFound("cache bypassed")
+	if !Bypassed(ctx) {
+		err = c.Get(ctx, key, dest)
+	}
 	if err == nil {
 		c.logger.Debug("Found value in cache", "key", key)
 		return nil
@@ -244,6 +272,26 @@ func (c *mockCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// DeletePattern removes every value whose key matches pattern from mock cache
+func (c *mockCache) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	c.logger.Debug("Deleting values from mock cache by pattern", "pattern", pattern)
+
+	if err := validatePattern(pattern); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for key := range c.data {
+		if matchPattern(pattern, key) {
+			delete(c.data, key)
+			deleted++
+		}
+	}
+
+	c.logger.Debug("Successfully deleted values from mock cache by pattern", "pattern", pattern, "count", deleted)
+	return deleted, nil
+}
+
 // Exists checks if a key exists in mock cache
 func (c *mockCache) Exists(ctx context.Context, key string) (bool, error) {
 	c.logger.Debug("Checking if key exists in mock cache", "key", key)
@@ -270,8 +318,11 @@ func (c *mockCache) FlushAll(ctx context.Context) error {
 func (c *mockCache) GetOrSet(ctx context.Context, key string, dest interface{}, fetcher func() (interface{}, error), expiration time.Duration) error {
 	c.logger.Debug("GetOrSet operation on mock cache", "key", key, "expiration", expiration)
 
-	// Try to get from cache first
-	err := c.Get(ctx, key, dest)
+	// Try to get from cache first, unless the caller asked for a fresh value
+	var err error = errors.NotFound("cache bypassed")
+	if !Bypassed(ctx) {
+		err = c.Get(ctx, key, dest)
+	}
 	if err == nil {
 		c.logger.Debug("Found value in mock cache", "key", key)
 		return nil
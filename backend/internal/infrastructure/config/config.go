@@ -1,18 +1,26 @@
 package config
 
 import (
+	"crypto-indicator-dashboard/internal/application/services"
+	"crypto-indicator-dashboard/internal/infrastructure/scheduler"
+	"crypto-indicator-dashboard/pkg/logger"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all configuration settings
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	External ExternalConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	External  ExternalConfig
+	Logging    LoggingConfig
+	Scheduler  SchedulerConfig
+	Indicators IndicatorConfig
+	Tracing    TracingConfig
 }
 
 // ServerConfig holds server configuration
@@ -24,6 +32,21 @@ type ServerConfig struct {
 	IdleTimeout     time.Duration
 	ShutdownTimeout time.Duration
 	Environment     string
+	// MaxBodyBytes limits POST, PUT and PATCH request bodies
+	MaxBodyBytes int64
+	// RateLimitExemptPaths are request paths the rate limiter never throttles
+	RateLimitExemptPaths []string
+	// Cache-Control max ages of successful GET responses; 0 leaves the routes uncached
+	IndicatorCacheMaxAge time.Duration
+	ChartCacheMaxAge     time.Duration
+	DocsCacheMaxAge      time.Duration
+	// SwaggerUIAssetsURL is the base URL the docs page loads swagger-ui-bundle.js and
+	// swagger-ui.css from
+	SwaggerUIAssetsURL string
+	// MarketStreamHeartbeat is how often an idle ticker stream sends a heartbeat event
+	MarketStreamHeartbeat time.Duration
+	// MarketStreamMaxPerClient caps concurrent ticker streams per client IP; 0 disables it
+	MarketStreamMaxPerClient int
 }
 
 // DatabaseConfig holds database configuration
@@ -36,6 +59,10 @@ type DatabaseConfig struct {
 	SSLMode  string
 	MaxConns int
 	MinConns int
+	// ReplicaHost and ReplicaPort locate a read replica sharing the primary's credentials;
+	// an empty host sends reads to the primary
+	ReplicaHost string
+	ReplicaPort string
 }
 
 // RedisConfig holds Redis configuration
@@ -46,25 +73,115 @@ type RedisConfig struct {
 	DB       int
 }
 
+// LoggingConfig holds logging configuration. Empty values use the environment defaults.
+type LoggingConfig struct {
+	Level  string
+	Format string
+}
+
+// SchedulerConfig holds cron schedules (with seconds) for background jobs
+type SchedulerConfig struct {
+	PortfolioValuationSchedule string
+	DataRetentionSchedule      string
+	IndicatorRetention         time.Duration // 0 disables the retention job
+	DrainTimeout               time.Duration
+	FailureThreshold           int
+	AutoDisableFailingJobs     bool
+	ProviderHealthSchedule     string // empty disables provider health recording
+	PriceObservationSchedule   string // empty disables price_data observation recording
+	PriceObservationSymbols    []string
+	MarketTrendSchedule        string // empty disables the daily market trend classification
+	// TradingViewCanarySchedule probes TradingView extraction; empty disables the canary
+	TradingViewCanarySchedule string
+	// TradingViewCanaryThreshold is how many consecutive failed extractions mark it degraded
+	TradingViewCanaryThreshold int
+}
+
+// IndicatorConfig controls how stale indicators are recomputed and how caches are warmed
+type IndicatorConfig struct {
+	// RecomputeLockTTL bounds how long one recomputation can hold an indicator's lock
+	RecomputeLockTTL time.Duration
+	// RecomputeWaitTimeout is how long a request with no stored value waits on another's recomputation
+	RecomputeWaitTimeout time.Duration
+	// WarmCachesOnStartup fills indicator and market summary caches before serving traffic
+	WarmCachesOnStartup bool
+	// CacheWarmupTimeout bounds how long startup waits on cache warm-up
+	CacheWarmupTimeout time.Duration
+	// MinDataPoints overrides how many data points statistical indicators need before their
+	// values are trusted; 0 keeps each indicator's own minimum
+	MinDataPoints int
+	// OutlierRejection is how statistical indicators handle outlying values: off (empty),
+	// trim or winsorize
+	OutlierRejection string
+	// OutlierIQRMultiplier is the outlier fence distance in interquartile ranges; 0 uses 1.5
+	OutlierIQRMultiplier float64
+}
+
+// TracingConfig controls OpenTelemetry span export
+type TracingConfig struct {
+	// OTLPEndpoint is the OTLP/HTTP collector URL spans are exported to; empty disables export
+	OTLPEndpoint string
+	ServiceName  string
+	// SampleRatio is the share of new traces recorded, 0-1
+	SampleRatio float64
+}
+
 // ExternalConfig holds external API configuration
 type ExternalConfig struct {
 	CoinGeckoAPIKey     string
 	CoinMarketCapAPIKey string
+	CoinCapAPIKey       string
 	AlternativeAPI      string
 	RateLimitDelay      time.Duration
+	// BinanceStreamURL is the Binance websocket base live tickers are read from
+	BinanceStreamURL string
+
+	// Bitcoin dominance source selection
+	DominanceSources            []string
+	DominanceAveragingThreshold float64
+	DominanceMinConfidence      float64
+	// DominanceSourceWeights is the reliability of each dominance source, from
+	// DOMINANCE_SOURCE_WEIGHTS as source:weight pairs; unlisted sources keep their defaults
+	DominanceSourceWeights map[string]float64
+	// DominanceMaxAge is the reading age at which dominance freshness reaches its floor
+	DominanceMaxAge time.Duration
+	// DominanceFallback is served when every source fails and no dominance has been stored; 0 disables it
+	DominanceFallback float64
+
+	// Crypto price sourcing: first_available falls back source to source, aggregated
+	// takes a weighted median across PriceSources
+	PriceSourceMode   string
+	PriceSources      []string
+	PriceMaxDeviation float64
+	// PriceFetchConcurrency bounds in-flight requests to providers quoting one symbol per request
+	PriceFetchConcurrency int
+	// MaxConcurrentRequests bounds in-flight requests across all upstream clients; 0 disables it
+	MaxConcurrentRequests int
+
+	// MarketSummaryCacheTTL is how long assembled market summaries are served from cache; 0 disables it
+	MarketSummaryCacheTTL time.Duration
 }
 
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	config := &Config{
 		Server: ServerConfig{
-			Port:            getEnv("PORT", "8080"),
-			Host:            getEnv("HOST", "localhost"),
-			ReadTimeout:     getDurationEnv("READ_TIMEOUT", 15*time.Second),
-			WriteTimeout:    getDurationEnv("WRITE_TIMEOUT", 15*time.Second),
-			IdleTimeout:     getDurationEnv("IDLE_TIMEOUT", 60*time.Second),
-			ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", 10*time.Second),
-			Environment:     getEnv("ENVIRONMENT", "development"),
+			Port:                 getEnv("PORT", "8080"),
+			Host:                 getEnv("HOST", "localhost"),
+			ReadTimeout:          getDurationEnv("READ_TIMEOUT", 15*time.Second),
+			WriteTimeout:         getDurationEnv("WRITE_TIMEOUT", 15*time.Second),
+			IdleTimeout:          getDurationEnv("IDLE_TIMEOUT", 60*time.Second),
+			ShutdownTimeout:      getDurationEnv("SHUTDOWN_TIMEOUT", 10*time.Second),
+			Environment:          getEnv("ENVIRONMENT", "development"),
+			MaxBodyBytes:         int64(getIntEnv("MAX_BODY_BYTES", 2<<20)),
+			RateLimitExemptPaths: getListEnv("RATE_LIMIT_EXEMPT_PATHS", []string{"/health", "/metrics", "/version"}),
+			IndicatorCacheMaxAge: getDurationEnv("CACHE_MAX_AGE_INDICATORS", 60*time.Second),
+			ChartCacheMaxAge:     getDurationEnv("CACHE_MAX_AGE_CHARTS", 300*time.Second),
+			DocsCacheMaxAge:      getDurationEnv("CACHE_MAX_AGE_DOCS", time.Hour),
+			SwaggerUIAssetsURL:   getEnv("SWAGGER_UI_ASSETS_URL", ""),
+
+			MarketStreamHeartbeat:    getDurationEnv("MARKET_STREAM_HEARTBEAT", 15*time.Second),
+			MarketStreamMaxPerClient: getIntEnv("MARKET_STREAM_MAX_PER_CLIENT", 3),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -75,6 +192,9 @@ func Load() (*Config, error) {
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 			MaxConns: getIntEnv("DB_MAX_CONNS", 25),
 			MinConns: getIntEnv("DB_MIN_CONNS", 5),
+
+			ReplicaHost: getEnv("DB_REPLICA_HOST", ""),
+			ReplicaPort: getEnv("DB_REPLICA_PORT", getEnv("DB_PORT", "5432")),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -85,9 +205,90 @@ func Load() (*Config, error) {
 		External: ExternalConfig{
 			CoinGeckoAPIKey:     getEnv("COINGECKO_API_KEY", ""),
 			CoinMarketCapAPIKey: getEnv("COINMARKETCAP_API_KEY", "f3ea5727-a012-4b0e-8e81-4d6b515c35e4"),
+			CoinCapAPIKey:       getEnv("COINCAP_API_KEY", ""),
 			AlternativeAPI:      getEnv("ALTERNATIVE_API_URL", "https://api.alternative.me"),
 			RateLimitDelay:      getDurationEnv("RATE_LIMIT_DELAY", 100*time.Millisecond),
+			BinanceStreamURL:    getEnv("BINANCE_STREAM_URL", "wss://stream.binance.com:9443/ws"),
+
+			DominanceSources:            getListEnv("DOMINANCE_SOURCES", []string{"coinmarketcap", "tradingview"}),
+			DominanceAveragingThreshold: getFloatEnv("DOMINANCE_AVERAGING_THRESHOLD", 2.0),
+			DominanceMinConfidence:      getFloatEnv("DOMINANCE_MIN_CONFIDENCE", 0),
+			DominanceSourceWeights:      getWeightsEnv("DOMINANCE_SOURCE_WEIGHTS"),
+			DominanceMaxAge:             getDurationEnv("DOMINANCE_MAX_AGE", time.Hour),
+			DominanceFallback:           getFloatEnv("DOMINANCE_FALLBACK", 0),
+
+			PriceSourceMode:       getEnv("PRICE_SOURCE_MODE", services.PriceSourceModeFirstAvailable),
+			PriceSources:          getListEnv("PRICE_SOURCES", []string{"coinmarketcap", "binance", "coincap"}),
+			PriceMaxDeviation:     getFloatEnv("PRICE_MAX_DEVIATION", 0.02),
+			PriceFetchConcurrency: getIntEnv("PRICE_FETCH_CONCURRENCY", 4),
+			MaxConcurrentRequests: getIntEnv("MAX_CONCURRENT_UPSTREAM_REQUESTS", 8),
+
+			MarketSummaryCacheTTL: getDurationEnv("MARKET_SUMMARY_CACHE_TTL", 60*time.Second),
+		},
+		Logging: LoggingConfig{
+			Level:  getEnv("LOG_LEVEL", ""),
+			Format: getEnv("LOG_FORMAT", ""),
 		},
+		Scheduler: SchedulerConfig{
+			PortfolioValuationSchedule: getEnv("PORTFOLIO_VALUATION_SCHEDULE", scheduler.DefaultPortfolioValuationSchedule),
+			DataRetentionSchedule:      getEnv("DATA_RETENTION_SCHEDULE", scheduler.DefaultDataRetentionSchedule),
+			IndicatorRetention:         getDurationEnv("INDICATOR_RETENTION", 365*24*time.Hour),
+			DrainTimeout:               getDurationEnv("SCHEDULER_DRAIN_TIMEOUT", 30*time.Second),
+			FailureThreshold:           getIntEnv("SCHEDULER_FAILURE_THRESHOLD", 5),
+			AutoDisableFailingJobs:     getBoolEnv("SCHEDULER_AUTO_DISABLE", false),
+			ProviderHealthSchedule:     getEnv("PROVIDER_HEALTH_SCHEDULE", scheduler.DefaultProviderHealthSchedule),
+			PriceObservationSchedule:   getEnv("PRICE_OBSERVATION_SCHEDULE", scheduler.DefaultPriceObservationSchedule),
+			PriceObservationSymbols:    getListEnv("PRICE_OBSERVATION_SYMBOLS", []string{"BTC", "ETH"}),
+			MarketTrendSchedule:        getEnv("MARKET_TREND_SCHEDULE", scheduler.DefaultMarketTrendSchedule),
+			TradingViewCanarySchedule:  getEnv("TRADINGVIEW_CANARY_SCHEDULE", scheduler.DefaultTradingViewCanarySchedule),
+			TradingViewCanaryThreshold: getIntEnv("TRADINGVIEW_CANARY_THRESHOLD", scheduler.DefaultTradingViewCanaryThreshold),
+		},
+		Indicators: IndicatorConfig{
+			RecomputeLockTTL:     getDurationEnv("INDICATOR_RECOMPUTE_LOCK_TTL", 2*time.Minute),
+			RecomputeWaitTimeout: getDurationEnv("INDICATOR_RECOMPUTE_WAIT_TIMEOUT", 10*time.Second),
+			WarmCachesOnStartup:  getBoolEnv("CACHE_WARMUP_ENABLED", false),
+			CacheWarmupTimeout:   getDurationEnv("CACHE_WARMUP_TIMEOUT", 30*time.Second),
+			MinDataPoints:        getIntEnv("INDICATOR_MIN_DATA_POINTS", 0),
+			OutlierRejection:     getEnv("INDICATOR_OUTLIER_REJECTION", ""),
+			OutlierIQRMultiplier: getFloatEnv("INDICATOR_OUTLIER_IQR_MULTIPLIER", 0),
+		},
+		Tracing: TracingConfig{
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "crypto-indicator-dashboard"),
+			SampleRatio:  getFloatEnv("TRACING_SAMPLE_RATIO", 1.0),
+		},
+	}
+
+	if config.Logging.Level != "" {
+		if _, err := logger.ParseLevel(config.Logging.Level); err != nil {
+			return nil, err
+		}
+	}
+	if config.Logging.Format != "" {
+		if err := logger.ValidateFormat(config.Logging.Format); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Tracing.SampleRatio < 0 || config.Tracing.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO %v: must be between 0 and 1", config.Tracing.SampleRatio)
+	}
+
+	switch config.External.PriceSourceMode {
+	case services.PriceSourceModeFirstAvailable, services.PriceSourceModeAggregated:
+	default:
+		return nil, fmt.Errorf("invalid PRICE_SOURCE_MODE %q: must be %s or %s", config.External.PriceSourceMode,
+			services.PriceSourceModeFirstAvailable, services.PriceSourceModeAggregated)
+	}
+
+	switch services.OutlierMode(config.Indicators.OutlierRejection) {
+	case services.OutlierRejectionOff, services.OutlierTrim, services.OutlierWinsorize:
+	default:
+		return nil, fmt.Errorf("invalid INDICATOR_OUTLIER_REJECTION %q: must be %s or %s",
+			config.Indicators.OutlierRejection, services.OutlierTrim, services.OutlierWinsorize)
+	}
+	if config.Indicators.OutlierIQRMultiplier < 0 {
+		return nil, fmt.Errorf("invalid INDICATOR_OUTLIER_IQR_MULTIPLIER %v: must not be negative", config.Indicators.OutlierIQRMultiplier)
 	}
 
 	return config, nil
@@ -99,11 +300,29 @@ func (c *DatabaseConfig) GetDSN() string {
 		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
 }
 
+// GetReplicaDSN returns the read replica connection string
+func (c *DatabaseConfig) GetReplicaDSN() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		c.ReplicaHost, c.ReplicaPort, c.User, c.Password, c.DBName, c.SSLMode)
+}
+
 // GetRedisAddr returns the Redis connection address
 func (c *RedisConfig) GetRedisAddr() string {
 	return fmt.Sprintf("%s:%s", c.Host, c.Port)
 }
 
+// LoggerOptions returns the logger options for the environment with any overrides applied
+func (c *Config) LoggerOptions() logger.Options {
+	opts := logger.DefaultOptions(c.Server.Environment)
+	if c.Logging.Level != "" {
+		opts.Level = c.Logging.Level
+	}
+	if c.Logging.Format != "" {
+		opts.Format = c.Logging.Format
+	}
+	return opts
+}
+
 // IsDevelopment returns true if running in development mode
 func (c *ServerConfig) IsDevelopment() bool {
 	return c.Environment == "development"
@@ -132,6 +351,15 @@ func getIntEnv(key string, fallback int) int {
 	return fallback
 }
 
+func getBoolEnv(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
 func getDurationEnv(key string, fallback time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if parsed, err := time.ParseDuration(value); err == nil {
@@ -139,4 +367,43 @@ func getDurationEnv(key string, fallback time.Duration) time.Duration {
 		}
 	}
 	return fallback
-}
\ No newline at end of file
+}
+
+func getFloatEnv(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+func getListEnv(key string, fallback []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		if len(items) > 0 {
+			return items
+		}
+	}
+	return fallback
+}
+
+// getWeightsEnv parses comma-separated name:weight pairs, skipping malformed entries
+func getWeightsEnv(key string) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, item := range getListEnv(key, nil) {
+		name, value, ok := strings.Cut(item, ":")
+		if !ok {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			weights[strings.ToLower(strings.TrimSpace(name))] = parsed
+		}
+	}
+	return weights
+}
@@ -4,18 +4,28 @@ import (
 	"context"
 	"crypto-indicator-dashboard/internal/application/services"
 	"crypto-indicator-dashboard/internal/application/usecases"
+	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/internal/domain/repositories"
 	domainServices "crypto-indicator-dashboard/internal/domain/services"
 	"crypto-indicator-dashboard/internal/infrastructure/cache"
 	"crypto-indicator-dashboard/internal/infrastructure/database"
 	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/internal/infrastructure/scheduler"
+	"crypto-indicator-dashboard/internal/infrastructure/tracing"
 	"crypto-indicator-dashboard/pkg/logger"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// redisPingTimeout bounds the startup Redis connectivity check
+const redisPingTimeout = 3 * time.Second
+
 // Dependencies holds all application dependencies
 type Dependencies struct {
 	// Configuration
@@ -25,23 +35,76 @@ type Dependencies struct {
 	DB     *gorm.DB
 	Redis  *redis.Client
 	Logger logger.Logger
-	Cache  domainServices.CacheService
+	// Cache is backed by Redis when it answered a ping at startup, and is in-memory otherwise
+	Cache domainServices.CacheService
+	// EventPublisher receives indicator events such as extreme band entries; the default
+	// only logs them
+	EventPublisher domainServices.EventPublisher
+	// PortfolioAlertNotifier delivers fired portfolio drawdown alerts; the default only
+	// logs them
+	PortfolioAlertNotifier domainServices.PortfolioAlertNotifier
+	// ReplicaDB serves repository reads when a read replica is configured; nil otherwise
+	ReplicaDB *gorm.DB
+	// shutdownTracing flushes and stops span export
+	shutdownTracing tracing.ShutdownFunc
 
 	// Repositories
-	PortfolioRepo  repositories.PortfolioRepository
-	IndicatorRepo  repositories.IndicatorRepository
-	MarketDataRepo repositories.MarketDataRepository
-	DCARepo        repositories.DCARepository
+	PortfolioRepo        repositories.PortfolioRepository
+	IndicatorRepo        repositories.IndicatorRepository
+	MarketDataRepo       repositories.MarketDataRepository
+	DCARepo              repositories.DCARepository
+	APIKeyRepo           repositories.APIKeyRepository
+	AnnotationRepo       repositories.AnnotationRepository
+	ProviderHealthRepo   repositories.ProviderHealthRepository
+	NetworkMetricsRepo   repositories.NetworkMetricsRepository
+	CompositeWeightRepo  repositories.CompositeWeightRepository
+	IndicatorConfigRepo  repositories.IndicatorConfigRepository
+	PriceObservationRepo repositories.PriceObservationRepository
+	PortfolioAlertRepo   repositories.PortfolioAlertRepository
 
 	// Domain Services
-	PortfolioService  domainServices.PortfolioService
-	IndicatorService  domainServices.IndicatorService
-	DCAService        domainServices.DCAService
-	MarketDataService domainServices.MarketDataService
+	PortfolioService          domainServices.PortfolioService
+	PortfolioValuationService domainServices.PortfolioValuationService
+	IndicatorService          domainServices.IndicatorService
+	DCAService                domainServices.DCAService
+	SmartDCAService           domainServices.SmartDCAService
+	MarketDataService         domainServices.MarketDataService
+	CorrelationService        domainServices.CorrelationService
+	ChangeService             domainServices.IndicatorChangeService
+	FearGreedService          domainServices.FearGreedService
+	DominanceService          domainServices.DominanceService
+	SymbolMetadataService     domainServices.SymbolMetadataService
+	NetworkMetricsService     domainServices.NetworkMetricsService
+	CompositeWeightService    domainServices.CompositeWeightService
+	IndicatorConfigService    domainServices.IndicatorConfigService
+	PortfolioAlertService     domainServices.PortfolioAlertService
+
+	// Additional indicator services
+	MVRVService            domainServices.IndicatorService
+	CoinbasePremiumService domainServices.IndicatorService
+	AltSeasonService       domainServices.IndicatorService
+	RealizedPriceService   domainServices.IndicatorService
+	RHODLService           domainServices.IndicatorService
+	ETFFlowService         domainServices.IndicatorService
+	ExchangeFlowService    domainServices.IndicatorService
+	VolumeAnomalyService   domainServices.IndicatorService
+	MarketTrendService     domainServices.IndicatorService
 
 	// External API Clients
 	CoinMarketCapClient *external.CoinMarketCapClient
 	TradingViewScraper  *external.TradingViewScraper
+	CoinCapClient       *external.CoinCapClient
+	AlternativeMeClient *external.AlternativeMeClient
+	BinanceClient       *external.BinanceClient
+	BlockchainClient    *external.BlockchainClient
+	// RawProviderProxy serves raw upstream payloads from whitelisted endpoints for debugging
+	RawProviderProxy *external.RawProxy
+
+	// TickerStream relays live Binance tickers for the market stream endpoint
+	TickerStream domainServices.TickerStream
+
+	// Background jobs
+	Scheduler *scheduler.CronScheduler
 
 	// Use Cases
 	PortfolioUseCase *usecases.PortfolioUseCase
@@ -55,7 +118,10 @@ func NewDependencies(config *Config) (*Dependencies, error) {
 	}
 
 	// Initialize logger
-	deps.Logger = logger.New(config.Server.Environment)
+	deps.Logger = logger.NewWithOptions(config.LoggerOptions())
+
+	// Initialize tracing before anything that may start spans
+	deps.initTracing()
 
 	// Initialize database
 	if err := deps.initDatabase(); err != nil {
@@ -80,33 +146,80 @@ func NewDependencies(config *Config) (*Dependencies, error) {
 
 	// Initialize domain services
 	deps.initDomainServices()
+	deps.initRecomputeGuards()
+	deps.initMinPointsGuards()
+	deps.initOutlierRejection()
+	deps.initEventPublishers()
+	deps.initIndicatorConfigs()
 
 	// Initialize use cases
 	deps.initUseCases()
 
+	// Register background jobs
+	if err := deps.initScheduler(); err != nil {
+		return nil, err
+	}
+
 	return deps, nil
 }
 
-// initDatabase initializes the database connection
+// initDatabase initializes the database connection, plus the read replica when configured.
+// A replica that can't be opened is skipped so reads fall back to the primary.
 func (d *Dependencies) initDatabase() error {
-	db, err := gorm.Open(postgres.Open(d.Config.Database.GetDSN()), &gorm.Config{
+	db, err := d.openDatabase(d.Config.Database.GetDSN())
+	if err != nil {
+		return err
+	}
+	d.DB = db
+
+	if d.Config.Database.ReplicaHost != "" {
+		replica, err := d.openDatabase(d.Config.Database.GetReplicaDSN())
+		if err != nil {
+			d.Logger.Error("Failed to initialize read replica, reading from primary", "error", err)
+			return nil
+		}
+		d.ReplicaDB = replica
+	}
+	return nil
+}
+
+// openDatabase opens a pooled connection to dsn
+func (d *Dependencies) openDatabase(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.NewGormLogger(d.Logger),
 	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		return nil, err
 	}
 
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	sqlDB.SetMaxOpenConns(d.Config.Database.MaxConns)
 	sqlDB.SetMaxIdleConns(d.Config.Database.MinConns)
 
-	d.DB = db
-	return nil
+	return db, nil
+}
+
+// initTracing installs trace propagation and, when configured, OTLP span export. Export
+// that can't be set up is skipped so the service runs untraced.
+func (d *Dependencies) initTracing() {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+		OTLPEndpoint: d.Config.Tracing.OTLPEndpoint,
+		ServiceName:  d.Config.Tracing.ServiceName,
+		SampleRatio:  d.Config.Tracing.SampleRatio,
+	}, d.Logger)
+	if err != nil {
+		d.Logger.Error("Failed to initialize tracing, continuing without span export", "error", err)
+		return
+	}
+	d.shutdownTracing = shutdown
 }
 
 // initRedis initializes the Redis connection
@@ -118,8 +231,10 @@ func (d *Dependencies) initRedis() error {
 	})
 
 	// Test connection
-	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(context.Background(), redisPingTimeout)
+	defer cancel()
 	if err := rdb.Ping(ctx).Err(); err != nil {
+		rdb.Close()
 		return err
 	}
 
@@ -129,6 +244,15 @@ func (d *Dependencies) initRedis() error {
 
 // initExternalClients initializes external API clients
 func (d *Dependencies) initExternalClients() {
+	// Every upstream client shares one limit on in-flight requests
+	external.SetMaxConcurrentRequests(d.Config.External.MaxConcurrentRequests)
+
+	d.RawProviderProxy = external.NewRawProxy(external.DefaultRawProviders(
+		d.Config.External.CoinMarketCapAPIKey,
+		d.Config.External.CoinCapAPIKey,
+		d.Config.External.AlternativeAPI,
+	), d.Logger)
+
 	// Initialize CoinMarketCap client
 	if d.Config.External.CoinMarketCapAPIKey != "" {
 		d.CoinMarketCapClient = external.NewCoinMarketCapClient(
@@ -139,52 +263,468 @@ func (d *Dependencies) initExternalClients() {
 
 	// Initialize TradingView scraper
 	d.TradingViewScraper = external.NewTradingViewScraper(d.Logger)
+
+	// Initialize CoinCap client (the API key is optional)
+	d.CoinCapClient = external.NewCoinCapClient(d.Config.External.CoinCapAPIKey, d.Logger)
+
+	// Initialize Binance client (public market data, no API key)
+	d.BinanceClient = external.NewBinanceClient(d.Logger)
+	d.TickerStream = external.NewBinanceTickerStream(d.Config.External.BinanceStreamURL, d.Logger)
+
+	// Initialize Blockchain.com client (network statistics, no API key)
+	d.BlockchainClient = external.NewBlockchainClient(d.Logger)
+
+	// Initialize Alternative.me client (Fear & Greed index)
+	if d.Config.External.AlternativeAPI != "" {
+		d.AlternativeMeClient = external.NewAlternativeMeClient(d.Config.External.AlternativeAPI, d.Logger)
+	}
 }
 
 // initCache initializes the cache service
 func (d *Dependencies) initCache() {
-	// Create a Redis cache service if available, otherwise use nil (will use fallback)
-	var redisCache domainServices.CacheService
+	// Without Redis, stay up with a per-instance in-memory cache rather than failing
+	var redisCache cache.CacheService
 	if d.Redis != nil {
-		// For now, we'll use nil for Redis and rely on fallback
-		redisCache = nil
+		redisCache = cache.NewRedisCache(d.Redis, d.Logger)
+	} else {
+		d.Logger.Warn("DEGRADED MODE: Redis is unavailable, falling back to an in-memory cache. "+
+			"Cached values are not shared between instances and are lost on restart.",
+			"redis_addr", d.Config.Redis.GetRedisAddr())
 	}
 
-	// Use our cache service implementation with fallback
 	d.Cache = cache.NewCacheService(redisCache, d.Logger)
 }
 
 // initRepositories initializes all repositories
 func (d *Dependencies) initRepositories() {
 	if d.DB != nil {
-		d.PortfolioRepo = database.NewPortfolioRepository(d.DB)
-		d.IndicatorRepo = database.NewIndicatorRepository(d.DB, d.Logger)
-		d.MarketDataRepo = database.NewMarketDataRepository(d.DB, d.Logger)
-		d.DCARepo = database.NewDCARepository(d.DB, d.Logger)
+		dbs := database.NewDBProvider(d.DB, d.ReplicaDB)
+		d.PortfolioRepo = database.NewPortfolioRepository(dbs)
+		d.IndicatorRepo = database.NewIndicatorRepository(dbs, d.Logger)
+		d.MarketDataRepo = database.NewMarketDataRepository(dbs, d.Logger)
+		d.DCARepo = database.NewDCARepository(dbs, d.Logger)
+		d.APIKeyRepo = database.NewAPIKeyRepository(dbs, d.Logger)
+		d.AnnotationRepo = database.NewAnnotationRepository(dbs, d.Logger)
+		d.ProviderHealthRepo = database.NewProviderHealthRepository(dbs, d.Logger)
+		d.NetworkMetricsRepo = database.NewNetworkMetricsRepository(dbs, d.Logger)
+		d.CompositeWeightRepo = database.NewCompositeWeightRepository(dbs, d.Logger)
+		d.IndicatorConfigRepo = database.NewIndicatorConfigRepository(dbs, d.Logger)
+		d.PriceObservationRepo = database.NewPriceObservationRepository(dbs, d.Logger)
+		d.PortfolioAlertRepo = database.NewPortfolioAlertRepository(dbs, d.Logger)
 	}
 }
 
+// priceSourceConfig builds the crypto price sourcing config, resolving the configured
+// source names to clients. Unknown names are skipped with a warning.
+func (d *Dependencies) priceSourceConfig() services.PriceSourceConfig {
+	cfg := services.DefaultPriceSourceConfig()
+	cfg.Mode = d.Config.External.PriceSourceMode
+	cfg.MaxDeviation = d.Config.External.PriceMaxDeviation
+	cfg.Concurrency = d.Config.External.PriceFetchConcurrency
+
+	for _, name := range d.Config.External.PriceSources {
+		switch strings.ToLower(name) {
+		case services.PriceSourceCoinMarketCap:
+			if d.CoinMarketCapClient != nil {
+				cfg.Sources = append(cfg.Sources, services.NewCoinMarketCapPriceSource(d.CoinMarketCapClient))
+			}
+		case services.PriceSourceBinance:
+			if d.BinanceClient != nil {
+				cfg.Sources = append(cfg.Sources, services.NewBinancePriceSource(d.BinanceClient, cfg.Concurrency))
+			}
+		case services.PriceSourceCoinCap:
+			if d.CoinCapClient != nil {
+				cfg.Sources = append(cfg.Sources, services.NewCoinCapPriceSource(d.CoinCapClient))
+			}
+		default:
+			d.Logger.Warn("Ignoring unknown price source", "source", name)
+		}
+	}
+
+	return cfg
+}
+
 // initDomainServices initializes domain services
 func (d *Dependencies) initDomainServices() {
+	// Initialize symbol metadata service
+	if d.CoinMarketCapClient != nil {
+		d.SymbolMetadataService = services.NewSymbolMetadataService(d.CoinMarketCapClient, d.Cache, d.Logger)
+	}
+
+	// When every dominance source fails, serve the last stored dominance before the configured value
+	if d.TradingViewScraper != nil {
+		var lastKnown external.LastKnownDominanceFunc
+		if d.MarketDataRepo != nil {
+			lastKnown = d.MarketDataRepo.GetLatestDominance
+		}
+		d.TradingViewScraper.SetFallback(lastKnown, d.Config.External.DominanceFallback)
+	}
+
 	// Initialize market data service
 	if d.MarketDataRepo != nil && d.CoinMarketCapClient != nil && d.TradingViewScraper != nil {
+		priceConfig := d.priceSourceConfig()
 		d.MarketDataService = services.NewMarketDataService(
 			d.MarketDataRepo,
 			d.CoinMarketCapClient,
 			d.TradingViewScraper,
 			d.Cache,
 			d.Logger,
+			services.MarketDataOptions{
+				PriceFallback: d.BinanceClient,
+				Metadata:      d.SymbolMetadataService,
+				Dominance: &services.DominanceSourceConfig{
+					Sources:            d.Config.External.DominanceSources,
+					AveragingThreshold: d.Config.External.DominanceAveragingThreshold,
+					MinConfidence:      d.Config.External.DominanceMinConfidence,
+					Weights:            d.Config.External.DominanceSourceWeights,
+					MaxAge:             d.Config.External.DominanceMaxAge,
+				},
+				Prices: &priceConfig,
+			},
+		)
+	}
+
+	// Initialize portfolio valuation refresh
+	if d.PortfolioRepo != nil && d.MarketDataService != nil {
+		d.PortfolioValuationService = services.NewPortfolioValuationService(d.PortfolioRepo, d.MarketDataService, d.Logger)
+	}
+
+	// Initialize portfolio drawdown alerts; evaluated by the valuation refresh job
+	if d.PortfolioAlertRepo != nil && d.PortfolioRepo != nil {
+		d.PortfolioAlertService = services.NewPortfolioAlertService(d.PortfolioAlertRepo, d.PortfolioRepo, d.PortfolioAlertNotifier, d.Logger)
+	}
+
+	// Initialize market trend service; classifies the top assets' average 24h change
+	if d.MarketDataService != nil {
+		d.MarketTrendService = services.NewMarketTrendService(d.MarketDataService, d.IndicatorRepo, d.Logger)
+	}
+
+	// Initialize MVRV Z-Score service (CoinGecko needs no API key); it models history from
+	// stored BTC prices when a database is available
+	d.MVRVService = services.NewMVRVService(d.IndicatorRepo, d.MarketDataRepo, d.Cache, d.Logger)
+
+	// Initialize Coinbase premium service
+	if d.CoinCapClient != nil {
+		d.CoinbasePremiumService = services.NewCoinbasePremiumService(d.CoinCapClient, d.IndicatorRepo, d.Cache, d.Logger)
+	}
+
+	// Initialize Fear & Greed service; swap the provider here to use a richer source
+	if d.AlternativeMeClient != nil {
+		d.FearGreedService = services.NewFearGreedService(d.AlternativeMeClient, d.IndicatorRepo, d.Cache, d.Logger)
+	}
+
+	// Initialize dominance service; it analyses the readings the market data service stores
+	if d.MarketDataRepo != nil {
+		d.DominanceService = services.NewDominanceService(d.MarketDataRepo, d.Logger)
+	}
+
+	// Initialize altcoin season index service
+	if d.CoinMarketCapClient != nil {
+		d.AltSeasonService = services.NewAltSeasonService(d.CoinMarketCapClient, d.IndicatorRepo, d.Cache, d.Logger)
+	}
+
+	// Initialize BTC volume anomaly service; it scores against volumes it has stored itself
+	if d.CoinMarketCapClient != nil {
+		d.VolumeAnomalyService = services.NewVolumeAnomalyService(d.CoinMarketCapClient, d.IndicatorRepo, d.Cache, d.Logger)
+	}
+
+	// Initialize realized price service (CoinGecko needs no API key)
+	d.RealizedPriceService = services.NewRealizedPriceService(d.IndicatorRepo, d.Cache, d.Logger)
+
+	// Initialize RHODL ratio service; bands are approximated from stored BTC prices until an
+	// on-chain HODL band provider is available
+	if d.MarketDataRepo != nil {
+		provider := services.NewPriceHistoryHODLBandProvider(d.MarketDataRepo)
+		d.RHODLService = services.NewRHODLService(provider, d.IndicatorRepo, d.Cache, d.Logger)
+	}
+
+	// Initialize ETF flow service; no flow source is integrated yet, so it serves a neutral
+	// reading marked as unconfigured until a provider is passed in here
+	d.ETFFlowService = services.NewETFFlowService(nil, d.IndicatorRepo, d.Cache, d.Logger)
+
+	// Initialize exchange flow service; like ETF flows it serves a neutral, unconfigured
+	// reading until an on-chain flow provider is passed in here
+	d.ExchangeFlowService = services.NewExchangeFlowService(nil, d.IndicatorRepo, d.Cache, d.Logger)
+
+	// Initialize smart DCA multipliers with the default curves
+	if smartDCA, err := services.NewSmartDCAService(entities.DefaultSmartDCAConfig, d.IndicatorRepo, d.Logger); err != nil {
+		d.Logger.Error("Failed to initialize smart DCA service", "error", err)
+	} else {
+		d.SmartDCAService = smartDCA
+	}
+
+	// Initialize Bitcoin network metrics service; snapshots are only stored with a database
+	if d.BlockchainClient != nil {
+		d.NetworkMetricsService = services.NewNetworkMetricsService(d.BlockchainClient, d.NetworkMetricsRepo, d.Cache, d.Logger)
+	}
+
+	// Initialize composite risk score weights and the score computed from them
+	if d.CompositeWeightRepo != nil && d.IndicatorRepo != nil {
+		d.CompositeWeightService = services.NewCompositeWeightService(d.CompositeWeightRepo, d.IndicatorRepo, d.Logger)
+	}
+
+	// Initialize indicator correlation and change services
+	if d.IndicatorRepo != nil {
+		d.CorrelationService = services.NewCorrelationService(d.IndicatorRepo, d.Logger)
+		d.ChangeService = services.NewIndicatorChangeService(d.IndicatorRepo, d.Logger)
+	}
+
+	// Initialize indicator configs; without storage every indicator keeps its default bands.
+	// Stored configs are loaded once migrations have run.
+	if d.IndicatorConfigRepo != nil {
+		d.IndicatorConfigService = services.NewIndicatorConfigService(d.IndicatorConfigRepo, d.Logger)
+	}
+}
+
+// initRecomputeGuards makes indicator services share one recompute lock, held in Redis when
+// available so a stale indicator is recomputed once across all instances
+func (d *Dependencies) initRecomputeGuards() {
+	cfg := services.RecomputeGuardConfig{
+		Lock:        cache.NewLocalRecomputeLock(),
+		LockTTL:     d.Config.Indicators.RecomputeLockTTL,
+		WaitTimeout: d.Config.Indicators.RecomputeWaitTimeout,
+	}
+	if d.Redis != nil {
+		cfg.Lock = cache.NewRedisRecomputeLock(d.Redis)
+	}
+
+	for _, service := range d.IndicatorServices() {
+		if guarded, ok := service.(services.RecomputeGuarded); ok {
+			guarded.SetRecomputeGuard(cfg)
+		}
+	}
+}
+
+// initMinPointsGuards applies the configured minimum data points to statistical indicators
+func (d *Dependencies) initMinPointsGuards() {
+	if d.Config.Indicators.MinDataPoints <= 0 {
+		return
+	}
+
+	for _, service := range d.IndicatorServices() {
+		if guarded, ok := service.(services.MinPointsGuarded); ok {
+			guarded.SetMinDataPoints(d.Config.Indicators.MinDataPoints)
+		}
+	}
+}
+
+// initOutlierRejection applies the configured outlier handling to statistical indicators
+func (d *Dependencies) initOutlierRejection() {
+	cfg := services.OutlierRejectionConfig{
+		Mode:          services.OutlierMode(d.Config.Indicators.OutlierRejection),
+		IQRMultiplier: d.Config.Indicators.OutlierIQRMultiplier,
+	}
+	if !cfg.Enabled() {
+		return
+	}
+
+	for _, service := range d.IndicatorServices() {
+		if rejecting, ok := service.(services.OutlierRejecting); ok {
+			rejecting.SetOutlierRejection(cfg)
+		}
+	}
+}
+
+// initEventPublishers points indicator services that publish extreme band events at the
+// shared publisher
+func (d *Dependencies) initEventPublishers() {
+	if d.EventPublisher == nil {
+		d.EventPublisher = services.NewLoggingEventPublisher(d.Logger)
+	}
+
+	for _, service := range d.IndicatorServices() {
+		if publishing, ok := service.(services.EventPublishing); ok {
+			publishing.SetEventPublisher(d.EventPublisher)
+		}
+	}
+}
+
+// initIndicatorConfigs points indicator services with configurable bands at the stored
+// indicator configs
+func (d *Dependencies) initIndicatorConfigs() {
+	if d.IndicatorConfigService == nil {
+		return
+	}
+
+	for _, service := range d.IndicatorServices() {
+		if configurable, ok := service.(services.IndicatorConfigurable); ok {
+			configurable.SetIndicatorConfigs(d.IndicatorConfigService)
+		}
+	}
+}
+
+// IndicatorServices returns the configured standalone indicator services by name. It is the
+// registry used for recompute guards, cache warm-up and on-demand recalculation.
+func (d *Dependencies) IndicatorServices() map[string]domainServices.IndicatorService {
+	configured := make(map[string]domainServices.IndicatorService)
+	for name, service := range map[string]domainServices.IndicatorService{
+		"mvrv":             d.MVRVService,
+		"coinbase_premium": d.CoinbasePremiumService,
+		"alt_season":       d.AltSeasonService,
+		"realized_price":   d.RealizedPriceService,
+		"rhodl":            d.RHODLService,
+		"etf_flow":         d.ETFFlowService,
+		"exchange_flow":    d.ExchangeFlowService,
+		"volume_anomaly":   d.VolumeAnomalyService,
+		"market_trend":     d.MarketTrendService,
+	} {
+		if service != nil {
+			configured[name] = service
+		}
+	}
+	return configured
+}
+
+// CacheWarmupTargets returns a warm-up target for the latest value of each configured
+// indicator service, the Bitcoin dominance reading and the Fear & Greed analysis. Bubble
+// risk is not fetched from anywhere, so it has nothing to warm.
+func (d *Dependencies) CacheWarmupTargets() []services.CacheWarmupTarget {
+	var targets []services.CacheWarmupTarget
+	for name, service := range d.IndicatorServices() {
+		service := service
+		targets = append(targets, services.CacheWarmupTarget{
+			Name: name,
+			Warm: func(ctx context.Context) error {
+				_, err := service.GetLatest(ctx)
+				return err
+			},
+		})
+	}
+	if d.MarketDataService != nil {
+		targets = append(targets, services.CacheWarmupTarget{
+			Name: "dominance",
+			Warm: func(ctx context.Context) error {
+				_, err := d.MarketDataService.GetBitcoinDominance(ctx)
+				return err
+			},
+		})
+	}
+	if d.FearGreedService != nil {
+		targets = append(targets, services.CacheWarmupTarget{
+			Name: "fear_greed",
+			Warm: func(ctx context.Context) error {
+				_, err := d.FearGreedService.GetFearGreedAnalysis(ctx)
+				return err
+			},
+		})
+	}
+
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Name < targets[j].Name })
+	return targets
+}
+
+// initScheduler registers background jobs; the caller starts and stops the scheduler
+func (d *Dependencies) initScheduler() error {
+	d.Scheduler = scheduler.NewCronScheduler(d.Logger)
+	d.Scheduler.SetDrainTimeout(d.Config.Scheduler.DrainTimeout)
+	d.Scheduler.SetFailurePolicy(scheduler.FailurePolicy{
+		Threshold:   d.Config.Scheduler.FailureThreshold,
+		AutoDisable: d.Config.Scheduler.AutoDisableFailingJobs,
+	})
+
+	if d.PortfolioRepo != nil && d.PortfolioValuationService != nil {
+		job := scheduler.NewPortfolioValuationJob(
+			d.Config.Scheduler.PortfolioValuationSchedule,
+			d.PortfolioRepo,
+			d.PortfolioValuationService,
+			d.PortfolioAlertService,
+			d.Logger,
 		)
+		if err := d.Scheduler.AddJob(job); err != nil {
+			return fmt.Errorf("failed to schedule portfolio valuation job: %w", err)
+		}
 	}
+
+	if d.IndicatorRepo != nil && d.Config.Scheduler.IndicatorRetention > 0 {
+		job := scheduler.NewDataRetentionJob(
+			d.Config.Scheduler.DataRetentionSchedule,
+			d.Config.Scheduler.IndicatorRetention,
+			d.IndicatorRepo,
+			d.Logger,
+		)
+		if err := d.Scheduler.AddJob(job); err != nil {
+			return fmt.Errorf("failed to schedule data retention job: %w", err)
+		}
+	}
+
+	if d.ProviderHealthRepo != nil && d.MarketDataService != nil && d.Config.Scheduler.ProviderHealthSchedule != "" {
+		job := scheduler.NewProviderHealthJob(
+			d.Config.Scheduler.ProviderHealthSchedule,
+			d.MarketDataService,
+			d.ProviderHealthRepo,
+			d.Logger,
+		)
+		if err := d.Scheduler.AddJob(job); err != nil {
+			return fmt.Errorf("failed to schedule provider health job: %w", err)
+		}
+	}
+
+	if d.MarketTrendService != nil && d.Config.Scheduler.MarketTrendSchedule != "" {
+		job := scheduler.NewMarketTrendJob(
+			d.Config.Scheduler.MarketTrendSchedule,
+			d.MarketTrendService,
+			d.Logger,
+		)
+		if err := d.Scheduler.AddJob(job); err != nil {
+			return fmt.Errorf("failed to schedule market trend job: %w", err)
+		}
+	}
+
+	if d.TradingViewScraper != nil && d.Config.Scheduler.TradingViewCanarySchedule != "" {
+		job := scheduler.NewTradingViewCanaryJob(
+			d.Config.Scheduler.TradingViewCanarySchedule,
+			d.Config.Scheduler.TradingViewCanaryThreshold,
+			d.TradingViewScraper,
+			d.Logger,
+		)
+		if err := d.Scheduler.AddJob(job); err != nil {
+			return fmt.Errorf("failed to schedule TradingView canary job: %w", err)
+		}
+	}
+
+	if d.PriceObservationRepo != nil && d.Config.Scheduler.PriceObservationSchedule != "" {
+		priceConfig := d.priceSourceConfig()
+		sources := make([]scheduler.ObservedPriceSource, 0, len(priceConfig.Sources))
+		for _, source := range priceConfig.Sources {
+			sources = append(sources, scheduler.ObservedPriceSource{
+				Quoter:      source,
+				Reliability: priceConfig.Weight(source.Name()),
+			})
+		}
+
+		if len(sources) > 0 {
+			job := scheduler.NewPriceObservationJob(
+				d.Config.Scheduler.PriceObservationSchedule,
+				d.Config.Scheduler.PriceObservationSymbols,
+				sources,
+				d.PriceObservationRepo,
+				d.Logger,
+			)
+			if err := d.Scheduler.AddJob(job); err != nil {
+				return fmt.Errorf("failed to schedule price observation job: %w", err)
+			}
+		}
+	}
+
+	return nil
 }
 
 // initUseCases initializes use cases
 func (d *Dependencies) initUseCases() {
-	// Note: These will be properly initialized once domain services are migrated
+	if d.PortfolioRepo != nil {
+		// There is no risk analysis service yet, so summaries carry no risk metrics
+		d.PortfolioUseCase = usecases.NewPortfolioUseCase(d.PortfolioRepo, d.PortfolioService, nil)
+	}
 }
 
 // Cleanup gracefully closes all connections
 func (d *Dependencies) Cleanup() error {
+	if d.Scheduler != nil && d.Scheduler.IsRunning() {
+		if err := d.Scheduler.Stop(); err != nil {
+			d.Logger.Error("Failed to stop job scheduler", "error", err)
+		}
+	}
+
 	if d.Redis != nil {
 		if err := d.Redis.Close(); err != nil {
 			d.Logger.Error("Failed to close Redis connection", "error", err)
@@ -200,6 +740,24 @@ func (d *Dependencies) Cleanup() error {
 		}
 	}
 
+	if d.ReplicaDB != nil {
+		sqlDB, err := d.ReplicaDB.DB()
+		if err == nil {
+			if err := sqlDB.Close(); err != nil {
+				d.Logger.Error("Failed to close read replica connection", "error", err)
+			}
+		}
+	}
+
+	// Flush spans last so those recorded while shutting down are exported too
+	if d.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), d.Config.Server.ShutdownTimeout)
+		defer cancel()
+		if err := d.shutdownTracing(ctx); err != nil {
+			d.Logger.Error("Failed to flush traces", "error", err)
+		}
+	}
+
 	return nil
 }
 
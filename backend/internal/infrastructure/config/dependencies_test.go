@@ -0,0 +1,253 @@
+package config
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/application/services"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	domainServices "crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// countingIndicatorService counts GetLatest calls
+type countingIndicatorService struct {
+	latestCalls int
+}
+
+func (s *countingIndicatorService) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	return &entities.Indicator{}, nil
+}
+
+func (s *countingIndicatorService) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	return nil, nil
+}
+
+func (s *countingIndicatorService) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	s.latestCalls++
+	return &entities.Indicator{}, nil
+}
+
+func TestCacheWarmupTargets(t *testing.T) {
+	premium := &countingIndicatorService{}
+	realized := &countingIndicatorService{}
+	etf := &countingIndicatorService{}
+	marketData := &testutil.MockMarketDataService{}
+	marketData.On("GetBitcoinDominance", mock.Anything).Return(&entities.BitcoinDominance{CurrentDominance: 55}, nil).Once()
+	d := &Dependencies{
+		CoinbasePremiumService: premium,
+		RealizedPriceService:   realized,
+		ETFFlowService:         etf,
+		MarketDataService:      marketData,
+	}
+
+	targets := d.CacheWarmupTargets()
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = target.Name
+	}
+	// Services that are not configured are skipped
+	assert.Equal(t, []string{"coinbase_premium", "dominance", "etf_flow", "realized_price"}, names)
+
+	failed := services.WarmCaches(context.Background(), targets, time.Second, logger.New("test"))
+	assert.Empty(t, failed)
+	for _, service := range []*countingIndicatorService{premium, realized, etf} {
+		assert.Equal(t, 1, service.latestCalls)
+	}
+	marketData.AssertExpectations(t)
+}
+
+func TestInitCache_FallsBackWhenRedisIsDown(t *testing.T) {
+	// Reserve a port, then close it so the Redis ping is refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+
+	d := &Dependencies{
+		Config: &Config{Redis: RedisConfig{Host: "127.0.0.1", Port: port}},
+		Logger: logger.New("test"),
+	}
+	assert.Error(t, d.initRedis())
+	assert.Nil(t, d.Redis)
+
+	d.initCache()
+	require.NotNil(t, d.Cache)
+
+	// The cache services use works without Redis
+	ctx := context.Background()
+	require.NoError(t, d.Cache.Set(ctx, "key", "value", time.Minute))
+	var value string
+	require.NoError(t, d.Cache.Get(ctx, "key", &value))
+	assert.Equal(t, "value", value)
+	assert.True(t, d.Cache.Exists(ctx, "key"))
+
+	deleted, err := d.Cache.DeletePattern(ctx, "k*")
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.False(t, d.Cache.Exists(ctx, "key"))
+}
+
+// newMVRVDependencies wires the domain services over a market data repository holding
+// days of steadily rising daily BTC prices up to at
+func newMVRVDependencies(t *testing.T, at time.Time, days int) *Dependencies {
+	prices := make([]entities.CryptoPrice, days)
+	for i := range prices {
+		price := 20000.0 + float64(i)*100
+		prices[i] = entities.CryptoPrice{Symbol: "BTC", Price: price, MarketCap: price * 19_000_000, LastUpdated: at.AddDate(0, 0, i-days+1)}
+	}
+	marketRepo := &testutil.MockMarketDataRepository{}
+	marketRepo.On("GetPriceHistory", mock.Anything, "BTC", mock.Anything, at).Return(prices, nil)
+
+	log := logger.New("test")
+	d := &Dependencies{
+		Config:         &Config{},
+		Logger:         log,
+		Cache:          cache.NewCacheService(nil, log),
+		MarketDataRepo: marketRepo,
+	}
+	d.initDomainServices()
+	return d
+}
+
+// calculateMVRVAt runs the wired MVRV service's historical calculation
+func calculateMVRVAt(t *testing.T, d *Dependencies, at time.Time) *entities.Indicator {
+	service, ok := d.IndicatorServices()["mvrv"]
+	require.True(t, ok, "MVRV should be a registered indicator service")
+	historical, ok := service.(domainServices.HistoricalIndicatorService)
+	require.True(t, ok, "MVRV should support historical calculation")
+
+	indicator, err := historical.CalculateAt(context.Background(), at, nil)
+	require.NoError(t, err)
+	return indicator
+}
+
+// storeMVRVConfig stores config as MVRV's indicator config and points the indicator
+// services at it
+func storeMVRVConfig(t *testing.T, d *Dependencies, config entities.IndicatorConfig) {
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.IndicatorConfigsTableDDL)
+	repo := database.NewIndicatorConfigRepository(database.NewDBProvider(testDB.DB, nil), d.Logger)
+	d.IndicatorConfigService = services.NewIndicatorConfigService(repo, d.Logger)
+
+	_, err := d.IndicatorConfigService.Update(context.Background(), "mvrv", config)
+	require.NoError(t, err)
+	d.initIndicatorConfigs()
+}
+
+func TestInitIndicatorConfigs_ReachesMVRV(t *testing.T) {
+	at := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	d := newMVRVDependencies(t, at, 365)
+
+	// Every Z-Score is classified into the one band of the stored config
+	storeMVRVConfig(t, d, entities.IndicatorConfig{
+		Description: "Single band",
+		Bands:       []entities.IndicatorBand{{Label: "all", RiskLevel: "medium", Status: "Stored band"}},
+	})
+
+	indicator := calculateMVRVAt(t, d, at)
+	assert.Equal(t, "Single band", indicator.Description)
+	assert.Equal(t, "Stored band", indicator.Status)
+}
+
+func TestInitMinPointsGuards_ReachesMVRV(t *testing.T) {
+	at := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	d := newMVRVDependencies(t, at, 365)
+
+	indicator := calculateMVRVAt(t, d, at)
+	assert.NotContains(t, indicator.Metadata, services.InsufficientDataMetadataKey)
+
+	d.Config.Indicators.MinDataPoints = 400
+	d.initMinPointsGuards()
+
+	indicator = calculateMVRVAt(t, d, at)
+	assert.Equal(t, true, indicator.Metadata[services.InsufficientDataMetadataKey])
+	assert.EqualValues(t, 400, indicator.Metadata["min_data_points"])
+}
+
+// recordingPublisher keeps every published event
+type recordingPublisher struct {
+	events []entities.IndicatorEvent
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event entities.IndicatorEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestInitEventPublishers_ReachesMVRV(t *testing.T) {
+	ctx := context.Background()
+	d := newMVRVDependencies(t, time.Now(), 30)
+
+	// Serve the CoinGecko data from the cache, and classify every Z-Score as extreme
+	var btcData services.CoinGeckoBitcoinData
+	btcData.MarketData.CurrentPrice.USD = 30000
+	btcData.MarketData.MarketCap.USD = 30000 * 19_000_000
+	btcData.MarketData.CirculatingSupply = 19_000_000
+	require.NoError(t, d.Cache.Set(ctx, "bitcoin_market_data", btcData, 5*time.Minute))
+	storeMVRVConfig(t, d, entities.IndicatorConfig{
+		Bands: []entities.IndicatorBand{{Label: "all", RiskLevel: "extreme_high", Status: "Extreme"}},
+	})
+
+	publisher := &recordingPublisher{}
+	d.EventPublisher = publisher
+	d.initEventPublishers()
+
+	_, err := d.IndicatorServices()["mvrv"].Calculate(ctx, nil)
+	require.NoError(t, err)
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, entities.IndicatorEventExtremeBandEntered, publisher.events[0].Type)
+	assert.Equal(t, "mvrv", publisher.events[0].Indicator)
+}
+
+func TestInitOutlierRejection_ReachesMVRV(t *testing.T) {
+	at := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	d := newMVRVDependencies(t, at, 365)
+
+	indicator := calculateMVRVAt(t, d, at)
+	assert.NotContains(t, indicator.Metadata, "outlier_rejection")
+
+	d.Config.Indicators.OutlierRejection = string(services.OutlierWinsorize)
+	d.initOutlierRejection()
+
+	indicator = calculateMVRVAt(t, d, at)
+	assert.Equal(t, string(services.OutlierWinsorize), indicator.Metadata["outlier_rejection"])
+}
+
+func TestInitUseCases_WiresPortfolioUseCase(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.PortfolioTablesDDL...)
+
+	d := &Dependencies{Config: &Config{}, Logger: logger.New("test"), DB: testDB.DB}
+	d.initRepositories()
+	d.initUseCases()
+	require.NotNil(t, d.PortfolioUseCase, "the portfolio routes need a use case whenever the database is up")
+
+	ctx := context.Background()
+	portfolio, err := d.PortfolioUseCase.CreatePortfolio(ctx, &dto.CreatePortfolioRequest{Name: "Main"}, "alice")
+	require.NoError(t, err)
+
+	// Summaries are served without risk metrics until a risk analysis service exists
+	_, err = d.PortfolioUseCase.GetPortfolioSummary(ctx, portfolio.ID, "alice")
+	require.NoError(t, err)
+
+	// Without a database there is nothing to wire
+	d = &Dependencies{Config: &Config{}, Logger: logger.New("test")}
+	d.initRepositories()
+	d.initUseCases()
+	assert.Nil(t, d.PortfolioUseCase)
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"gorm.io/gorm"
+	"time"
+)
+
+// annotationRepository implements the AnnotationRepository interface
+type annotationRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewAnnotationRepository creates a new instance of annotation repository
+func NewAnnotationRepository(db DBProvider, logger logger.Logger) repositories.AnnotationRepository {
+	return &annotationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create saves a new annotation to the database
+func (r *annotationRepository) Create(ctx context.Context, annotation *entities.IndicatorAnnotation) error {
+	r.logger.Info("Creating annotation", "label", annotation.Label, "type", annotation.Type)
+
+	if err := r.db.Writer().WithContext(ctx).Create(annotation).Error; err != nil {
+		r.logger.Error("Failed to create annotation", "error", err, "label", annotation.Label)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to create annotation")
+	}
+
+	return nil
+}
+
+// GetByID retrieves an annotation by its ID
+func (r *annotationRepository) GetByID(ctx context.Context, id uint) (*entities.IndicatorAnnotation, error) {
+	var annotation entities.IndicatorAnnotation
+	if err := r.db.Reader().WithContext(ctx).First(&annotation, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("annotation")
+		}
+		r.logger.Error("Failed to retrieve annotation", "error", err, "id", id)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve annotation")
+	}
+
+	return &annotation, nil
+}
+
+// GetInRange retrieves annotations between from and to, oldest first
+func (r *annotationRepository) GetInRange(ctx context.Context, from, to time.Time) ([]entities.IndicatorAnnotation, error) {
+	var annotations []entities.IndicatorAnnotation
+	if err := r.db.Reader().WithContext(ctx).
+		Where("timestamp >= ? AND timestamp <= ?", from, to).
+		Order("timestamp ASC, id ASC").
+		Find(&annotations).Error; err != nil {
+		r.logger.Error("Failed to retrieve annotations", "error", err, "from", from, "to", to)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve annotations")
+	}
+
+	return annotations, nil
+}
+
+// Update modifies an existing annotation
+func (r *annotationRepository) Update(ctx context.Context, annotation *entities.IndicatorAnnotation) error {
+	r.logger.Info("Updating annotation", "id", annotation.ID)
+
+	result := r.db.Writer().WithContext(ctx).
+		Model(&entities.IndicatorAnnotation{}).
+		Where("id = ?", annotation.ID).
+		Updates(map[string]interface{}{
+			"timestamp":  annotation.Timestamp,
+			"label":      annotation.Label,
+			"type":       annotation.Type,
+			"updated_at": time.Now(),
+		})
+	if err := result.Error; err != nil {
+		r.logger.Error("Failed to update annotation", "error", err, "id", annotation.ID)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update annotation")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NotFound("annotation")
+	}
+
+	return nil
+}
+
+// Delete removes an annotation from the database
+func (r *annotationRepository) Delete(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting annotation", "id", id)
+
+	result := r.db.Writer().WithContext(ctx).Delete(&entities.IndicatorAnnotation{}, id)
+	if err := result.Error; err != nil {
+		r.logger.Error("Failed to delete annotation", "error", err, "id", id)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to delete annotation")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NotFound("annotation")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAnnotationRepository(t *testing.T) *annotationRepository {
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.AnnotationsTableDDL)
+
+	return NewAnnotationRepository(NewDBProvider(testDB.DB, nil), testDB.Logger).(*annotationRepository)
+}
+
+func TestAnnotationRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestAnnotationRepository(t)
+
+	halving := &entities.IndicatorAnnotation{
+		Timestamp: time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC),
+		Label:     "Fourth halving",
+		Type:      entities.AnnotationTypeHalving,
+	}
+	require.NoError(t, repo.Create(ctx, halving))
+	require.NotZero(t, halving.ID)
+
+	stored, err := repo.GetByID(ctx, halving.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Fourth halving", stored.Label)
+	assert.Equal(t, entities.AnnotationTypeHalving, stored.Type)
+	assert.True(t, halving.Timestamp.Equal(stored.Timestamp))
+
+	halving.Label = "Bitcoin halving"
+	require.NoError(t, repo.Update(ctx, halving))
+	stored, err = repo.GetByID(ctx, halving.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Bitcoin halving", stored.Label)
+
+	require.NoError(t, repo.Delete(ctx, halving.ID))
+	_, err = repo.GetByID(ctx, halving.ID)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound))
+
+	assert.True(t, errors.IsType(repo.Update(ctx, halving), errors.ErrorTypeNotFound))
+	assert.True(t, errors.IsType(repo.Delete(ctx, halving.ID), errors.ErrorTypeNotFound))
+}
+
+func TestAnnotationRepository_GetInRange(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestAnnotationRepository(t)
+
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC) }
+	for _, a := range []entities.IndicatorAnnotation{
+		{Timestamp: day(20), Label: "late", Type: entities.AnnotationTypeNews},
+		{Timestamp: day(1), Label: "before", Type: entities.AnnotationTypeNews},
+		{Timestamp: day(10), Label: "early", Type: entities.AnnotationTypeMacro},
+		{Timestamp: day(30), Label: "after", Type: entities.AnnotationTypeOther},
+	} {
+		a := a
+		require.NoError(t, repo.Create(ctx, &a))
+	}
+
+	annotations, err := repo.GetInRange(ctx, day(10), day(20))
+	require.NoError(t, err)
+
+	require.Len(t, annotations, 2)
+	assert.Equal(t, "early", annotations[0].Label)
+	assert.Equal(t, "late", annotations[1].Label)
+}
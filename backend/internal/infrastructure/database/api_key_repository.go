@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"gorm.io/gorm"
+	"time"
+)
+
+// apiKeyRepository implements the APIKeyRepository interface
+type apiKeyRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewAPIKeyRepository creates a new instance of API key repository
+func NewAPIKeyRepository(db DBProvider, logger logger.Logger) repositories.APIKeyRepository {
+	return &apiKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create saves a new API key to the database
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey *entities.APIKey) error {
+	r.logger.Info("Creating API key", "user_id", apiKey.UserID, "name", apiKey.Name)
+
+	if err := r.db.Writer().WithContext(ctx).Create(apiKey).Error; err != nil {
+		r.logger.Error("Failed to create API key", "error", err, "user_id", apiKey.UserID)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to create API key")
+	}
+
+	return nil
+}
+
+// GetByHash retrieves an API key by the hash of its secret. It reads from the primary so
+// a key works as soon as it is created and stops working as soon as it is revoked, however
+// far a replica lags.
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	var apiKey entities.APIKey
+	if err := r.db.Writer().WithContext(ctx).Where("key_hash = ?", keyHash).First(&apiKey).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("api key")
+		}
+		r.logger.Error("Failed to retrieve API key", "error", err)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve API key")
+	}
+
+	return &apiKey, nil
+}
+
+// TouchLastUsed records the time an API key was last used
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint) error {
+	if err := r.db.Writer().WithContext(ctx).
+		Model(&entities.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", time.Now()).Error; err != nil {
+		r.logger.Warn("Failed to update API key last used time", "error", err, "id", id)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update API key")
+	}
+
+	return nil
+}
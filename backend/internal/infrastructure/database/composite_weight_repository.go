@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// compositeWeightRepository implements the CompositeWeightRepository interface
+type compositeWeightRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewCompositeWeightRepository creates a new instance of composite weight repository
+func NewCompositeWeightRepository(db DBProvider, logger logger.Logger) repositories.CompositeWeightRepository {
+	return &compositeWeightRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetLatest retrieves the most recently stored weights. It reads from the primary so a
+// weight change takes effect immediately.
+func (r *compositeWeightRepository) GetLatest(ctx context.Context) (*entities.CompositeWeightConfig, error) {
+	var config entities.CompositeWeightConfig
+	if err := r.db.Writer().WithContext(ctx).Order("id DESC").First(&config).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("composite weights")
+		}
+		r.logger.Error("Failed to retrieve composite weights", "error", err)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve composite weights")
+	}
+
+	return &config, nil
+}
+
+// Create stores a new revision of the composite weights
+func (r *compositeWeightRepository) Create(ctx context.Context, config *entities.CompositeWeightConfig) error {
+	if err := r.db.Writer().WithContext(ctx).Create(config).Error; err != nil {
+		r.logger.Error("Failed to store composite weights", "error", err)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to store composite weights")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// DBProvider hands repositories the connection for each kind of query. Reads may be served
+// by a replica that lags the primary, so anything that must see its own writes, including
+// every query inside a transaction, goes through Writer.
+type DBProvider interface {
+	// Reader returns the connection for read-only queries
+	Reader() *gorm.DB
+	// Writer returns the primary connection for writes and transactions
+	Writer() *gorm.DB
+}
+
+// dbProvider routes reads to a replica and writes to the primary
+type dbProvider struct {
+	reader *gorm.DB
+	writer *gorm.DB
+}
+
+// NewDBProvider creates a provider that writes to primary and reads from replica. A nil
+// replica sends reads to the primary as well.
+func NewDBProvider(primary, replica *gorm.DB) DBProvider {
+	if replica == nil {
+		replica = primary
+	}
+	return &dbProvider{reader: replica, writer: primary}
+}
+
+// Reader returns the read replica, or the primary when none is configured
+func (p *dbProvider) Reader() *gorm.DB {
+	return p.reader
+}
+
+// Writer returns the primary connection
+func (p *dbProvider) Writer() *gorm.DB {
+	return p.writer
+}
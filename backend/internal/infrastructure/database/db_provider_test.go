@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newIndicatorsTestDB(t *testing.T) *testutil.TestDB {
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.IndicatorsTableDDL)
+	return testDB
+}
+
+func countIndicators(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	require.NoError(t, db.Model(&entities.Indicator{}).Count(&count).Error)
+	return count
+}
+
+func TestNewDBProvider_WithoutReplica(t *testing.T) {
+	primary := newIndicatorsTestDB(t)
+
+	dbs := NewDBProvider(primary.DB, nil)
+
+	assert.Same(t, primary.DB, dbs.Reader())
+	assert.Same(t, primary.DB, dbs.Writer())
+}
+
+func TestDBProvider_RoutesReadsAndWrites(t *testing.T) {
+	ctx := context.Background()
+	primary := newIndicatorsTestDB(t)
+	replica := newIndicatorsTestDB(t)
+	repo := NewIndicatorRepository(NewDBProvider(primary.DB, replica.DB), primary.Logger)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	replicated := &entities.Indicator{Name: "mvrv", Type: "onchain", Value: 1.5, Timestamp: now.Add(-time.Hour)}
+	require.NoError(t, replica.DB.Create(replicated).Error)
+
+	t.Run("writes go to the primary", func(t *testing.T) {
+		written := &entities.Indicator{Name: "mvrv", Type: "onchain", Value: 2.5, Timestamp: now}
+		require.NoError(t, repo.Create(ctx, written))
+		require.NoError(t, repo.BulkCreate(ctx, []entities.Indicator{
+			{Name: "rhodl", Type: "onchain", Value: 3, Timestamp: now},
+		}))
+
+		assert.Equal(t, int64(2), countIndicators(t, primary.DB))
+		assert.Equal(t, int64(1), countIndicators(t, replica.DB))
+	})
+
+	t.Run("reads come from the replica", func(t *testing.T) {
+		latest, err := repo.GetLatest(ctx, "mvrv")
+		require.NoError(t, err)
+		assert.Equal(t, 1.5, latest.Value, "the primary's newer value is not visible to reads")
+
+		history, err := repo.GetHistoricalData(ctx, "mvrv", now.Add(-2*time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, replicated.ID, history[0].ID)
+
+		_, err = repo.GetLatest(ctx, "rhodl")
+		assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound), "rows only on the primary are not found: %v", err)
+	})
+
+	t.Run("updates and deletes go to the primary", func(t *testing.T) {
+		var stored entities.Indicator
+		require.NoError(t, primary.DB.Where("name = ?", "rhodl").First(&stored).Error)
+
+		stored.Value = 4
+		require.NoError(t, repo.Update(ctx, &stored))
+
+		var updated entities.Indicator
+		require.NoError(t, primary.DB.First(&updated, stored.ID).Error)
+		assert.Equal(t, 4.0, updated.Value)
+
+		require.NoError(t, repo.Delete(ctx, stored.ID))
+		assert.Equal(t, int64(1), countIndicators(t, primary.DB))
+		assert.Equal(t, int64(1), countIndicators(t, replica.DB), "the replica is untouched")
+	})
+}
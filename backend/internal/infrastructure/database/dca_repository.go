@@ -13,12 +13,12 @@ import (
 
 // dcaRepository implements the DCARepository interface
 type dcaRepository struct {
-	db     *gorm.DB
+	db     DBProvider
 	logger logger.Logger
 }
 
 // NewDCARepository creates a new instance of DCA repository
-func NewDCARepository(db *gorm.DB, logger logger.Logger) repositories.DCARepository {
+func NewDCARepository(db DBProvider, logger logger.Logger) repositories.DCARepository {
 	return &dcaRepository{
 		db:     db,
 		logger: logger,
@@ -32,7 +32,7 @@ func (r *dcaRepository) CreateStrategy(ctx context.Context, strategy *entities.D
 		"name", strategy.Name,
 		"symbol", strategy.Symbol)
 
-	if err := r.db.WithContext(ctx).Create(strategy).Error; err != nil {
+	if err := r.db.Writer().WithContext(ctx).Create(strategy).Error; err != nil {
 		r.logger.Error("Failed to create DCA strategy", 
 			"error", err, 
 			"user_id", strategy.UserID,
@@ -52,7 +52,7 @@ func (r *dcaRepository) GetStrategyByID(ctx context.Context, id uint) (*entities
 	r.logger.Debug("Retrieving DCA strategy by ID", "id", id)
 
 	var strategy entities.DCAStrategy
-	if err := r.db.WithContext(ctx).First(&strategy, id).Error; err != nil {
+	if err := r.db.Reader().WithContext(ctx).First(&strategy, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			r.logger.Debug("DCA strategy not found", "id", id)
 			return nil, errors.NotFound("dca_strategy")
@@ -69,7 +69,7 @@ func (r *dcaRepository) GetStrategiesByUserID(ctx context.Context, userID string
 	r.logger.Debug("Retrieving DCA strategies for user", "user_id", userID)
 
 	var strategies []entities.DCAStrategy
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&strategies).Error; err != nil {
@@ -90,7 +90,7 @@ func (r *dcaRepository) UpdateStrategy(ctx context.Context, strategy *entities.D
 
 	strategy.UpdatedAt = time.Now()
 	
-	if err := r.db.WithContext(ctx).Save(strategy).Error; err != nil {
+	if err := r.db.Writer().WithContext(ctx).Save(strategy).Error; err != nil {
 		r.logger.Error("Failed to update DCA strategy", 
 			"error", err, 
 			"id", strategy.ID)
@@ -105,7 +105,7 @@ func (r *dcaRepository) UpdateStrategy(ctx context.Context, strategy *entities.D
 func (r *dcaRepository) DeleteStrategy(ctx context.Context, id uint) error {
 	r.logger.Info("Deleting DCA strategy", "id", id)
 
-	result := r.db.WithContext(ctx).Delete(&entities.DCAStrategy{}, id)
+	result := r.db.Writer().WithContext(ctx).Delete(&entities.DCAStrategy{}, id)
 	if err := result.Error; err != nil {
 		r.logger.Error("Failed to delete DCA strategy", "error", err, "id", id)
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to delete DCA strategy")
@@ -127,7 +127,7 @@ func (r *dcaRepository) CreatePurchase(ctx context.Context, purchase *entities.D
 		"amount", purchase.Amount,
 		"price", purchase.Price)
 
-	if err := r.db.WithContext(ctx).Create(purchase).Error; err != nil {
+	if err := r.db.Writer().WithContext(ctx).Create(purchase).Error; err != nil {
 		r.logger.Error("Failed to create DCA purchase", 
 			"error", err, 
 			"strategy_id", purchase.StrategyID)
@@ -143,7 +143,7 @@ func (r *dcaRepository) GetPurchasesByStrategy(ctx context.Context, strategyID u
 	r.logger.Debug("Retrieving purchases for strategy", "strategy_id", strategyID)
 
 	var purchases []entities.DCAPurchase
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("strategy_id = ?", strategyID).
 		Order("created_at DESC").
 		Find(&purchases).Error; err != nil {
@@ -162,7 +162,7 @@ func (r *dcaRepository) SaveSimulation(ctx context.Context, simulation *entities
 		"symbol", simulation.Symbol,
 		"amount", simulation.Amount)
 
-	if err := r.db.WithContext(ctx).Create(simulation).Error; err != nil {
+	if err := r.db.Writer().WithContext(ctx).Create(simulation).Error; err != nil {
 		r.logger.Error("Failed to save DCA simulation", 
 			"error", err, 
 			"user_id", simulation.UserID)
@@ -177,7 +177,7 @@ func (r *dcaRepository) GetSimulationByID(ctx context.Context, id uint) (*entiti
 	r.logger.Debug("Retrieving DCA simulation by ID", "id", id)
 
 	var simulation entities.DCASimulation
-	if err := r.db.WithContext(ctx).First(&simulation, id).Error; err != nil {
+	if err := r.db.Reader().WithContext(ctx).First(&simulation, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			r.logger.Debug("DCA simulation not found", "id", id)
 			return nil, errors.NotFound("dca_simulation")
@@ -194,7 +194,7 @@ func (r *dcaRepository) GetSimulationsByUser(ctx context.Context, userID string)
 	r.logger.Debug("Retrieving DCA simulations for user", "user_id", userID)
 
 	var simulations []entities.DCASimulation
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&simulations).Error; err != nil {
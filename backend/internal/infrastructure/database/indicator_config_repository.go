@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// indicatorConfigRepository implements the IndicatorConfigRepository interface
+type indicatorConfigRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewIndicatorConfigRepository creates a new instance of indicator config repository
+func NewIndicatorConfigRepository(db DBProvider, logger logger.Logger) repositories.IndicatorConfigRepository {
+	return &indicatorConfigRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// List retrieves every stored indicator config. It reads from the primary so an edit is
+// seen immediately.
+func (r *indicatorConfigRepository) List(ctx context.Context) ([]entities.IndicatorConfig, error) {
+	var configs []entities.IndicatorConfig
+	if err := r.db.Writer().WithContext(ctx).Order("name ASC").Find(&configs).Error; err != nil {
+		r.logger.Error("Failed to retrieve indicator configs", "error", err)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve indicator configs")
+	}
+
+	return configs, nil
+}
+
+// Save inserts or replaces the config stored under config.Name
+func (r *indicatorConfigRepository) Save(ctx context.Context, config *entities.IndicatorConfig) error {
+	if err := r.db.Writer().WithContext(ctx).Save(config).Error; err != nil {
+		r.logger.Error("Failed to store indicator config", "error", err, "name", config.Name)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to store indicator config")
+	}
+
+	return nil
+}
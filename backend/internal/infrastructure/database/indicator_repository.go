@@ -13,15 +13,17 @@ import (
 
 // indicatorRepository implements the IndicatorRepository interface
 type indicatorRepository struct {
-	db *gorm.DB
-	logger logger.Logger
+	db          DBProvider
+	logger      logger.Logger
+	retryPolicy RetryPolicy
 }
 
 // NewIndicatorRepository creates a new instance of indicator repository
-func NewIndicatorRepository(db *gorm.DB, logger logger.Logger) repositories.IndicatorRepository {
+func NewIndicatorRepository(db DBProvider, logger logger.Logger) repositories.IndicatorRepository {
 	return &indicatorRepository{
-		db:     db,
-		logger: logger,
+		db:          db,
+		logger:      logger,
+		retryPolicy: DefaultRetryPolicy(),
 	}
 }
 
@@ -31,7 +33,10 @@ func (r *indicatorRepository) Create(ctx context.Context, indicator *entities.In
 		"name", indicator.Name, 
 		"type", indicator.Type)
 
-	if err := r.db.WithContext(ctx).Create(indicator).Error; err != nil {
+	err := withRetry(ctx, r.retryPolicy, r.logger, "create indicator", func() error {
+		return r.db.Writer().WithContext(ctx).Create(indicator).Error
+	})
+	if err != nil {
 		r.logger.Error("Failed to create indicator", 
 			"error", err, 
 			"name", indicator.Name)
@@ -49,7 +54,7 @@ func (r *indicatorRepository) GetByID(ctx context.Context, id uint) (*entities.I
 	r.logger.Debug("Retrieving indicator by ID", "id", id)
 
 	var indicator entities.Indicator
-	if err := r.db.WithContext(ctx).First(&indicator, id).Error; err != nil {
+	if err := r.db.Reader().WithContext(ctx).First(&indicator, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			r.logger.Debug("Indicator not found", "id", id)
 			return nil, errors.NotFound("indicator")
@@ -66,7 +71,7 @@ func (r *indicatorRepository) GetByName(ctx context.Context, name string) (*enti
 	r.logger.Debug("Retrieving indicator by name", "name", name)
 
 	var indicator entities.Indicator
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&indicator).Error; err != nil {
+	if err := r.db.Reader().WithContext(ctx).Where("name = ?", name).First(&indicator).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			r.logger.Debug("Indicator not found", "name", name)
 			return nil, errors.NotFound("indicator")
@@ -83,7 +88,7 @@ func (r *indicatorRepository) GetByType(ctx context.Context, indicatorType strin
 	r.logger.Debug("Retrieving indicators by type", "type", indicatorType)
 
 	var indicators []entities.Indicator
-	if err := r.db.WithContext(ctx).Where("type = ?", indicatorType).Order("created_at DESC").Find(&indicators).Error; err != nil {
+	if err := r.db.Reader().WithContext(ctx).Where("type = ?", indicatorType).Order("created_at DESC").Find(&indicators).Error; err != nil {
 		r.logger.Error("Failed to retrieve indicators", "error", err, "type", indicatorType)
 		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve indicators")
 	}
@@ -92,30 +97,68 @@ func (r *indicatorRepository) GetByType(ctx context.Context, indicatorType strin
 	return indicators, nil
 }
 
-// Update modifies an existing indicator
+// Update modifies an existing indicator using optimistic locking. The update only
+// applies if the stored version still matches indicator.Version; otherwise the row
+// changed since it was read and a conflict error is returned.
 func (r *indicatorRepository) Update(ctx context.Context, indicator *entities.Indicator) error {
 	r.logger.Info("Updating indicator", 
 		"id", indicator.ID, 
-		"name", indicator.Name)
-
-	indicator.UpdatedAt = time.Now()
-	
-	if err := r.db.WithContext(ctx).Save(indicator).Error; err != nil {
+		"name", indicator.Name,
+		"version", indicator.Version)
+
+	expectedVersion := indicator.Version
+	updated := *indicator
+	updated.Version = expectedVersion + 1
+	updated.UpdatedAt = time.Now()
+
+	var rowsAffected int64
+	err := withRetry(ctx, r.retryPolicy, r.logger, "update indicator", func() error {
+		result := r.db.Writer().WithContext(ctx).
+			Model(&entities.Indicator{}).
+			Where("id = ? AND version = ?", indicator.ID, expectedVersion).
+			Select("*").
+			Omit("id", "created_at").
+			Updates(&updated)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
 		r.logger.Error("Failed to update indicator", 
 			"error", err, 
 			"id", indicator.ID)
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update indicator")
 	}
 
-	r.logger.Info("Successfully updated indicator", "id", indicator.ID)
+	if rowsAffected == 0 {
+		return r.updateMissError(ctx, indicator.ID, expectedVersion)
+	}
+
+	indicator.Version = updated.Version
+	indicator.UpdatedAt = updated.UpdatedAt
+
+	r.logger.Info("Successfully updated indicator", "id", indicator.ID, "version", indicator.Version)
 	return nil
 }
 
+// updateMissError explains why an optimistic update matched no rows
+func (r *indicatorRepository) updateMissError(ctx context.Context, id uint, expectedVersion uint) error {
+	var count int64
+	if err := r.db.Writer().WithContext(ctx).Model(&entities.Indicator{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update indicator")
+	}
+	if count == 0 {
+		return errors.NotFound("indicator")
+	}
+
+	r.logger.Warn("Indicator update conflict", "id", id, "expected_version", expectedVersion)
+	return errors.Conflict("indicator was modified since it was read")
+}
+
 // Delete removes an indicator from the database
 func (r *indicatorRepository) Delete(ctx context.Context, id uint) error {
 	r.logger.Info("Deleting indicator", "id", id)
 
-	result := r.db.WithContext(ctx).Delete(&entities.Indicator{}, id)
+	result := r.db.Writer().WithContext(ctx).Delete(&entities.Indicator{}, id)
 	if err := result.Error; err != nil {
 		r.logger.Error("Failed to delete indicator", "error", err, "id", id)
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to delete indicator")
@@ -138,7 +181,7 @@ func (r *indicatorRepository) GetHistoricalData(ctx context.Context, name string
 		"to", to)
 
 	var indicators []entities.Indicator
-	query := r.db.WithContext(ctx).
+	query := r.db.Reader().WithContext(ctx).
 		Where("name = ? AND created_at BETWEEN ? AND ?", name, from, to).
 		Order("created_at ASC")
 
@@ -160,7 +203,7 @@ func (r *indicatorRepository) GetLatest(ctx context.Context, name string) (*enti
 	r.logger.Debug("Retrieving latest indicator", "name", name)
 
 	var indicator entities.Indicator
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("name = ?", name).
 		Order("created_at DESC").
 		First(&indicator).Error; err != nil {
@@ -175,6 +218,54 @@ func (r *indicatorRepository) GetLatest(ctx context.Context, name string) (*enti
 	return &indicator, nil
 }
 
+// GetNearest retrieves the indicator whose timestamp is closest to at, preferring the earlier
+// record on a tie
+func (r *indicatorRepository) GetNearest(ctx context.Context, name string, at time.Time) (*entities.Indicator, error) {
+	r.logger.Debug("Retrieving nearest indicator", "name", name, "at", at)
+
+	var before, after entities.Indicator
+	foundBefore, err := r.firstIndicator(ctx, &before, "name = ? AND timestamp <= ?", "timestamp DESC", name, at)
+	if err != nil {
+		return nil, err
+	}
+	foundAfter, err := r.firstIndicator(ctx, &after, "name = ? AND timestamp > ?", "timestamp ASC", name, at)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case foundBefore && foundAfter:
+		if after.Timestamp.Sub(at) < at.Sub(before.Timestamp) {
+			return &after, nil
+		}
+		return &before, nil
+	case foundBefore:
+		return &before, nil
+	case foundAfter:
+		return &after, nil
+	}
+
+	r.logger.Debug("No indicator found", "name", name)
+	return nil, errors.NotFound("indicator")
+}
+
+// firstIndicator loads the first indicator matching the condition in the given order and
+// reports whether there was one
+func (r *indicatorRepository) firstIndicator(ctx context.Context, dest *entities.Indicator, condition, order string, args ...interface{}) (bool, error) {
+	err := r.db.Reader().WithContext(ctx).
+		Where(condition, args...).
+		Order(order).
+		First(dest).Error
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to retrieve nearest indicator", "error", err)
+		return false, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve nearest indicator")
+	}
+	return true, nil
+}
+
 // GetLatestByType retrieves the most recent indicators for each name of a specific type
 func (r *indicatorRepository) GetLatestByType(ctx context.Context, indicatorType string) ([]entities.Indicator, error) {
 	r.logger.Debug("Retrieving latest indicators by type", "type", indicatorType)
@@ -182,13 +273,13 @@ func (r *indicatorRepository) GetLatestByType(ctx context.Context, indicatorType
 	var indicators []entities.Indicator
 	
 	// Use a subquery to get the latest record for each name of the specified type
-	subquery := r.db.WithContext(ctx).
+	subquery := r.db.Reader().WithContext(ctx).
 		Model(&entities.Indicator{}).
 		Select("name, MAX(created_at) as max_created_at").
 		Where("type = ?", indicatorType).
 		Group("name")
 
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Joins("JOIN (?) as latest ON indicators.name = latest.name AND indicators.created_at = latest.max_created_at", subquery).
 		Where("indicators.type = ?", indicatorType).
 		Find(&indicators).Error; err != nil {
@@ -200,6 +291,121 @@ func (r *indicatorRepository) GetLatestByType(ctx context.Context, indicatorType
 	return indicators, nil
 }
 
+// GetLatestForTypes retrieves the most recent indicator for each name of any of the given
+// types in one query, ordered by type and name
+func (r *indicatorRepository) GetLatestForTypes(ctx context.Context, types []string) ([]entities.Indicator, error) {
+	r.logger.Debug("Retrieving latest indicators for types", "types", types)
+
+	if len(types) == 0 {
+		return []entities.Indicator{}, nil
+	}
+
+	var indicators []entities.Indicator
+	subquery := r.db.Reader().WithContext(ctx).
+		Model(&entities.Indicator{}).
+		Select("name, MAX(created_at) as max_created_at").
+		Where("type IN ?", types).
+		Group("name")
+
+	if err := r.db.Reader().WithContext(ctx).
+		Joins("JOIN (?) as latest ON indicators.name = latest.name AND indicators.created_at = latest.max_created_at", subquery).
+		Where("indicators.type IN ?", types).
+		Order("indicators.type, indicators.name, indicators.id DESC").
+		Find(&indicators).Error; err != nil {
+		r.logger.Error("Failed to retrieve latest indicators", "error", err, "types", types)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve latest indicators")
+	}
+
+	// Records sharing a name's latest created_at are ordered newest ID first; keep that one
+	latest := make([]entities.Indicator, 0, len(indicators))
+	seen := make(map[string]bool, len(indicators))
+	for _, indicator := range indicators {
+		if seen[indicator.Name] {
+			continue
+		}
+		seen[indicator.Name] = true
+		latest = append(latest, indicator)
+	}
+
+	r.logger.Debug("Retrieved latest indicators", "count", len(latest), "types", types)
+	return latest, nil
+}
+
+// GetLatestHighConfidence retrieves the most recent indicator for each name of a type among
+// records with at least minConfidence, so a low-confidence fallback value gives way to the
+// last confident one. Results are ordered by name.
+func (r *indicatorRepository) GetLatestHighConfidence(ctx context.Context, indicatorType string, minConfidence float64) ([]entities.Indicator, error) {
+	r.logger.Debug("Retrieving latest high-confidence indicators", "type", indicatorType, "min_confidence", minConfidence)
+
+	var indicators []entities.Indicator
+	subquery := r.db.Reader().WithContext(ctx).
+		Model(&entities.Indicator{}).
+		Select("name, MAX(created_at) as max_created_at").
+		Where("type = ? AND confidence >= ?", indicatorType, minConfidence).
+		Group("name")
+
+	if err := r.db.Reader().WithContext(ctx).
+		Joins("JOIN (?) as latest ON indicators.name = latest.name AND indicators.created_at = latest.max_created_at", subquery).
+		Where("indicators.type = ? AND indicators.confidence >= ?", indicatorType, minConfidence).
+		Order("indicators.name, indicators.id DESC").
+		Find(&indicators).Error; err != nil {
+		r.logger.Error("Failed to retrieve latest high-confidence indicators", "error", err, "type", indicatorType)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve latest indicators")
+	}
+
+	// Records sharing a name's latest created_at are ordered newest ID first; keep that one
+	latest := make([]entities.Indicator, 0, len(indicators))
+	seen := make(map[string]bool, len(indicators))
+	for _, indicator := range indicators {
+		if seen[indicator.Name] {
+			continue
+		}
+		seen[indicator.Name] = true
+		latest = append(latest, indicator)
+	}
+
+	r.logger.Debug("Retrieved latest high-confidence indicators", "count", len(latest), "type", indicatorType)
+	return latest, nil
+}
+
+// GetLatestByTypeInRange retrieves the most recent indicator for each name of a type among
+// records whose timestamp falls within a range, newest first. Deduplication happens in SQL
+// so the limit counts indicators rather than stored records; a non-positive limit returns
+// every indicator.
+func (r *indicatorRepository) GetLatestByTypeInRange(ctx context.Context, indicatorType string, from, to time.Time, limit int) ([]entities.Indicator, error) {
+	r.logger.Debug("Retrieving latest indicators by type in range",
+		"type", indicatorType,
+		"from", from,
+		"to", to,
+		"limit", limit)
+
+	// A record is the latest for its name unless a later one, or one stored after it at the
+	// same timestamp, exists in the range
+	newer := r.db.Reader().WithContext(ctx).
+		Table("indicators AS newer").
+		Select("1").
+		Where("newer.type = indicators.type AND newer.name = indicators.name").
+		Where("newer.timestamp BETWEEN ? AND ?", from, to).
+		Where("newer.timestamp > indicators.timestamp OR (newer.timestamp = indicators.timestamp AND newer.id > indicators.id)")
+
+	var indicators []entities.Indicator
+	query := r.db.Reader().WithContext(ctx).
+		Where("indicators.type = ? AND indicators.timestamp BETWEEN ? AND ?", indicatorType, from, to).
+		Where("NOT EXISTS (?)", newer).
+		Order("indicators.timestamp DESC, indicators.id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&indicators).Error; err != nil {
+		r.logger.Error("Failed to retrieve latest indicators in range", "error", err, "type", indicatorType)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve indicators")
+	}
+
+	r.logger.Debug("Retrieved latest indicators in range", "count", len(indicators), "type", indicatorType)
+	return indicators, nil
+}
+
 // BulkCreate saves multiple indicators in a single transaction
 func (r *indicatorRepository) BulkCreate(ctx context.Context, indicators []entities.Indicator) error {
 	r.logger.Info("Bulk creating indicators", "count", len(indicators))
@@ -208,7 +414,12 @@ func (r *indicatorRepository) BulkCreate(ctx context.Context, indicators []entit
 		return nil
 	}
 
-	if err := r.db.WithContext(ctx).CreateInBatches(indicators, 100).Error; err != nil {
+	err := withRetry(ctx, r.retryPolicy, r.logger, "bulk create indicators", func() error {
+		return r.db.Writer().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.CreateInBatches(indicators, 100).Error
+		})
+	})
+	if err != nil {
 		r.logger.Error("Failed to bulk create indicators", "error", err, "count", len(indicators))
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to bulk create indicators")
 	}
@@ -217,21 +428,21 @@ func (r *indicatorRepository) BulkCreate(ctx context.Context, indicators []entit
 	return nil
 }
 
-// CleanupOldData removes indicators older than the specified time
-func (r *indicatorRepository) CleanupOldData(ctx context.Context, olderThan time.Time) error {
+// CleanupOldData removes indicators older than the specified time and returns the number deleted
+func (r *indicatorRepository) CleanupOldData(ctx context.Context, olderThan time.Time) (int64, error) {
 	r.logger.Info("Cleaning up old indicator data", "older_than", olderThan)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.Writer().WithContext(ctx).
 		Where("created_at < ?", olderThan).
 		Delete(&entities.Indicator{})
 
 	if err := result.Error; err != nil {
 		r.logger.Error("Failed to cleanup old data", "error", err, "older_than", olderThan)
-		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to cleanup old data")
+		return 0, errors.Wrap(err, errors.ErrorTypeInternal, "failed to cleanup old data")
 	}
 
 	r.logger.Info("Successfully cleaned up old data", 
 		"deleted_count", result.RowsAffected, 
 		"older_than", olderThan)
-	return nil
+	return result.RowsAffected, nil
 }
\ No newline at end of file
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -26,29 +28,11 @@ func (suite *IndicatorRepositoryTestSuite) SetupSuite() {
 	suite.ctx = context.Background()
 
 	// Manually create table to avoid GORM auto-migration conflicts
-	err := suite.testDB.DB.Exec(`
-		CREATE TABLE IF NOT EXISTS indicators (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL,
-			type TEXT NOT NULL,
-			value REAL,
-			string_value TEXT,
-			change TEXT,
-			risk_level TEXT,
-			status TEXT,
-			description TEXT,
-			source TEXT,
-			confidence REAL,
-			metadata TEXT,
-			timestamp DATETIME,
-			created_at DATETIME,
-			updated_at DATETIME
-		)
-	`).Error
+	err := suite.testDB.DB.Exec(testutil.IndicatorsTableDDL).Error
 	require.NoError(suite.T(), err, "Failed to create indicators table")
 
 	// Initialize repository
-	suite.repo = NewIndicatorRepository(suite.testDB.DB, suite.testDB.Logger).(*indicatorRepository)
+	suite.repo = NewIndicatorRepository(NewDBProvider(suite.testDB.DB, nil), suite.testDB.Logger).(*indicatorRepository)
 }
 
 func (suite *IndicatorRepositoryTestSuite) TearDownSuite() {
@@ -255,6 +239,174 @@ func (suite *IndicatorRepositoryTestSuite) TestGetHistoricalData_EmptyResult() {
 	assert.Empty(suite.T(), results, "Should return empty slice for non-existent indicator")
 }
 
+func (suite *IndicatorRepositoryTestSuite) TestGetLatestByTypeInRange_FiltersByTypeAndRange() {
+	now := time.Now()
+	from := now.Add(-7 * 24 * time.Hour)
+	to := now
+
+	testData := []*entities.Indicator{
+		{Name: "mvrv", Type: "onchain", Value: 1.5, Timestamp: now.Add(-8 * 24 * time.Hour)},  // Outside range
+		{Name: "mvrv", Type: "onchain", Value: 2.0, Timestamp: now.Add(-6 * 24 * time.Hour)},  // In range, superseded
+		{Name: "nupl", Type: "onchain", Value: 0.4, Timestamp: now.Add(-3 * 24 * time.Hour)},  // In range
+		{Name: "mvrv", Type: "onchain", Value: 2.5, Timestamp: now.Add(-1 * 24 * time.Hour)},  // In range, latest
+		{Name: "dominance", Type: "market", Value: 55.0, Timestamp: now.Add(-2 * time.Hour)}, // Different type
+		{Name: "mvrv", Type: "onchain", Value: 3.0, Timestamp: now.Add(1 * time.Hour)},       // Future (outside range)
+	}
+
+	for _, indicator := range testData {
+		require.NoError(suite.T(), suite.repo.Create(suite.ctx, indicator))
+	}
+
+	results, err := suite.repo.GetLatestByTypeInRange(suite.ctx, "onchain", from, to, 0)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), results, 2, "Should return the latest onchain value per name within the range")
+
+	// Newest first
+	assert.Equal(suite.T(), "mvrv", results[0].Name)
+	assert.Equal(suite.T(), 2.5, results[0].Value)
+	assert.Equal(suite.T(), "nupl", results[1].Name)
+	assert.Equal(suite.T(), 0.4, results[1].Value)
+}
+
+func (suite *IndicatorRepositoryTestSuite) TestGetLatestByTypeInRange_LimitCountsIndicators() {
+	now := time.Now()
+	// Many records for one name must not push other names past the limit
+	for i := 0; i < 5; i++ {
+		indicator := &entities.Indicator{
+			Name:      "mvrv",
+			Type:      "onchain",
+			Value:     float64(i),
+			Timestamp: now.Add(-time.Duration(i+1) * time.Minute),
+		}
+		require.NoError(suite.T(), suite.repo.Create(suite.ctx, indicator))
+	}
+	for _, name := range []string{"nupl", "puell"} {
+		indicator := &entities.Indicator{Name: name, Type: "onchain", Value: 1, Timestamp: now.Add(-time.Hour)}
+		require.NoError(suite.T(), suite.repo.Create(suite.ctx, indicator))
+	}
+
+	results, err := suite.repo.GetLatestByTypeInRange(suite.ctx, "onchain", now.Add(-24*time.Hour), now, 2)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), results, 2, "Should honour the limit")
+	assert.Equal(suite.T(), "mvrv", results[0].Name)
+	assert.Equal(suite.T(), 0.0, results[0].Value, "Should keep the newest mvrv record")
+	assert.NotEqual(suite.T(), "mvrv", results[1].Name)
+}
+
+func (suite *IndicatorRepositoryTestSuite) TestGetLatestForTypes() {
+	now := time.Now()
+	// Stored oldest first, so the last record of each name is the latest
+	testData := []*entities.Indicator{
+		{Name: "mvrv", Type: "onchain", Value: 1.5, Timestamp: now.Add(-3 * time.Hour)},
+		{Name: "nupl", Type: "onchain", Value: 0.4, Timestamp: now.Add(-3 * time.Hour)},
+		{Name: "fear_greed", Type: "sentiment", Value: 40, Timestamp: now.Add(-2 * time.Hour)},
+		{Name: "mvrv", Type: "onchain", Value: 2.5, Timestamp: now.Add(-1 * time.Hour)},
+		{Name: "dominance", Type: "market", Value: 55.0, Timestamp: now.Add(-1 * time.Hour)},
+		{Name: "fear_greed", Type: "sentiment", Value: 72, Timestamp: now},
+	}
+	for _, indicator := range testData {
+		require.NoError(suite.T(), suite.repo.Create(suite.ctx, indicator))
+	}
+
+	results, err := suite.repo.GetLatestForTypes(suite.ctx, []string{"onchain", "sentiment"})
+	require.NoError(suite.T(), err)
+
+	latest := make(map[string]float64, len(results))
+	for _, result := range results {
+		_, duplicate := latest[result.Name]
+		assert.False(suite.T(), duplicate, "%s returned more than once", result.Name)
+		latest[result.Name] = result.Value
+	}
+	assert.Equal(suite.T(), map[string]float64{"mvrv": 2.5, "nupl": 0.4, "fear_greed": 72}, latest)
+
+	// Ordered by type, then name
+	require.Len(suite.T(), results, 3)
+	assert.Equal(suite.T(), []string{"mvrv", "nupl", "fear_greed"},
+		[]string{results[0].Name, results[1].Name, results[2].Name})
+}
+
+func (suite *IndicatorRepositoryTestSuite) TestGetLatestHighConfidence_ExcludesLowConfidence() {
+	now := time.Now()
+	// Stored oldest first; the latest mvrv is a low-confidence fallback value
+	testData := []*entities.Indicator{
+		{Name: "mvrv", Type: "onchain", Value: 2.0, Confidence: 0.9, Timestamp: now.Add(-2 * time.Hour)},
+		{Name: "nupl", Type: "onchain", Value: 0.4, Confidence: 0.3, Timestamp: now.Add(-2 * time.Hour)},
+		{Name: "mvrv", Type: "onchain", Value: 2.4, Confidence: 0.4, Timestamp: now.Add(-1 * time.Hour)},
+		{Name: "rhodl", Type: "onchain", Value: 900, Confidence: 0.8, Timestamp: now},
+		{Name: "dominance", Type: "market", Value: 55.0, Confidence: 0.95, Timestamp: now},
+	}
+	for _, indicator := range testData {
+		require.NoError(suite.T(), suite.repo.Create(suite.ctx, indicator))
+	}
+
+	results, err := suite.repo.GetLatestHighConfidence(suite.ctx, "onchain", 0.7)
+	require.NoError(suite.T(), err)
+
+	// The fallback mvrv gives way to the last confident one, and nupl has none
+	require.Len(suite.T(), results, 2)
+	assert.Equal(suite.T(), "mvrv", results[0].Name)
+	assert.Equal(suite.T(), 2.0, results[0].Value)
+	assert.Equal(suite.T(), "rhodl", results[1].Name)
+
+	// A lower bound admits the latest values again
+	results, err = suite.repo.GetLatestHighConfidence(suite.ctx, "onchain", 0.3)
+	require.NoError(suite.T(), err)
+	values := make(map[string]float64, len(results))
+	for _, result := range results {
+		values[result.Name] = result.Value
+	}
+	assert.Equal(suite.T(), map[string]float64{"mvrv": 2.4, "nupl": 0.4, "rhodl": 900}, values)
+}
+
+func (suite *IndicatorRepositoryTestSuite) TestGetLatestForTypes_NoMatches() {
+	require.NoError(suite.T(), suite.repo.Create(suite.ctx, &entities.Indicator{
+		Name: "dominance", Type: "market", Value: 55.0, Timestamp: time.Now(),
+	}))
+
+	results, err := suite.repo.GetLatestForTypes(suite.ctx, []string{"onchain"})
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), results)
+
+	results, err = suite.repo.GetLatestForTypes(suite.ctx, nil)
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), results)
+}
+
+func (suite *IndicatorRepositoryTestSuite) TestGetNearest() {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, offset := range []time.Duration{-48 * time.Hour, -2 * time.Hour, 6 * time.Hour} {
+		require.NoError(suite.T(), suite.repo.Create(suite.ctx, &entities.Indicator{
+			Name: "mvrv", Type: "onchain", Value: float64(i + 1), Timestamp: base.Add(offset),
+		}))
+	}
+	require.NoError(suite.T(), suite.repo.Create(suite.ctx, &entities.Indicator{
+		Name: "nupl", Type: "onchain", Value: 9, Timestamp: base,
+	}))
+
+	tests := []struct {
+		at    time.Time
+		value float64
+	}{
+		{base, 2},                      // 2h before beats 6h after
+		{base.Add(3 * time.Hour), 3},   // 3h after beats 5h before
+		{base.Add(2 * time.Hour), 2},   // Tie goes to the earlier record
+		{base.Add(-72 * time.Hour), 1}, // Before all records
+		{base.Add(240 * time.Hour), 3}, // After all records
+	}
+	for _, tt := range tests {
+		result, err := suite.repo.GetNearest(suite.ctx, "mvrv", tt.at)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), tt.value, result.Value, "at %v", tt.at)
+	}
+}
+
+func (suite *IndicatorRepositoryTestSuite) TestGetNearest_NotFound() {
+	_, err := suite.repo.GetNearest(suite.ctx, "nonexistent", time.Now())
+	assert.True(suite.T(), errors.IsType(err, errors.ErrorTypeNotFound))
+}
+
 func (suite *IndicatorRepositoryTestSuite) TestUpdate_Success() {
 	// Create original indicator
 	original := &entities.Indicator{
@@ -303,6 +455,46 @@ func (suite *IndicatorRepositoryTestSuite) TestUpdate_NotFound() {
 	assert.Error(suite.T(), err, "Should return error when updating non-existent indicator")
 }
 
+func (suite *IndicatorRepositoryTestSuite) TestUpdate_StaleVersionConflict() {
+	indicator := &entities.Indicator{
+		Name:      "mvrv",
+		Type:      "market",
+		Value:     2.0,
+		Timestamp: time.Now(),
+	}
+	require.NoError(suite.T(), suite.repo.Create(suite.ctx, indicator))
+	assert.Equal(suite.T(), uint(1), indicator.Version, "New indicators start at version 1")
+
+	// Two writers read the same row
+	first, err := suite.repo.GetByID(suite.ctx, indicator.ID)
+	require.NoError(suite.T(), err)
+	second, err := suite.repo.GetByID(suite.ctx, indicator.ID)
+	require.NoError(suite.T(), err)
+
+	first.Value = 2.5
+	require.NoError(suite.T(), suite.repo.Update(suite.ctx, first))
+	assert.Equal(suite.T(), uint(2), first.Version)
+
+	// The second writer's copy is now stale
+	second.Value = 3.0
+	err = suite.repo.Update(suite.ctx, second)
+	require.Error(suite.T(), err)
+	assert.True(suite.T(), errors.IsType(err, errors.ErrorTypeConflict))
+	assert.Equal(suite.T(), http.StatusConflict, errors.GetStatusCode(err))
+
+	stored, err := suite.repo.GetByID(suite.ctx, indicator.ID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2.5, stored.Value, "Stale update must not overwrite the first writer")
+	assert.Equal(suite.T(), uint(2), stored.Version)
+
+	// Re-reading picks up the new version and the retry succeeds
+	second, err = suite.repo.GetByID(suite.ctx, indicator.ID)
+	require.NoError(suite.T(), err)
+	second.Value = 3.0
+	require.NoError(suite.T(), suite.repo.Update(suite.ctx, second))
+	assert.Equal(suite.T(), uint(3), second.Version)
+}
+
 func (suite *IndicatorRepositoryTestSuite) TestDelete_Success() {
 	// Create indicator to delete
 	indicator := &entities.Indicator{
@@ -441,13 +633,15 @@ func TestIndicatorRepository_EdgeCases(t *testing.T) {
 			confidence REAL,
 			metadata TEXT,
 			timestamp DATETIME,
+			version INTEGER NOT NULL DEFAULT 1,
+			calc_version INTEGER NOT NULL DEFAULT 1,
 			created_at DATETIME,
 			updated_at DATETIME
 		)
 	`).Error
 	require.NoError(t, err)
 
-	repo := NewIndicatorRepository(testDB.DB, testDB.Logger).(*indicatorRepository)
+	repo := NewIndicatorRepository(NewDBProvider(testDB.DB, nil), testDB.Logger).(*indicatorRepository)
 
 	ctx := context.Background()
 
@@ -12,12 +12,12 @@ import (
 
 // marketDataRepository implements the MarketDataRepository interface
 type marketDataRepository struct {
-	db     *gorm.DB
+	db     DBProvider
 	logger logger.Logger
 }
 
 // NewMarketDataRepository creates a new instance of market data repository
-func NewMarketDataRepository(db *gorm.DB, logger logger.Logger) repositories.MarketDataRepository {
+func NewMarketDataRepository(db DBProvider, logger logger.Logger) repositories.MarketDataRepository {
 	return &marketDataRepository{
 		db:     db,
 		logger: logger,
@@ -28,7 +28,7 @@ func NewMarketDataRepository(db *gorm.DB, logger logger.Logger) repositories.Mar
 func (r *marketDataRepository) StorePriceData(ctx context.Context, priceData *entities.CryptoPrice) error {
 	r.logger.Debug("Saving price data", "symbol", priceData.Symbol, "price", priceData.Price)
 
-	if err := r.db.WithContext(ctx).Create(priceData).Error; err != nil {
+	if err := r.db.Writer().WithContext(ctx).Create(priceData).Error; err != nil {
 		r.logger.Error("Failed to save price data", "error", err, "symbol", priceData.Symbol)
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to save price data")
 	}
@@ -41,7 +41,7 @@ func (r *marketDataRepository) GetPriceHistory(ctx context.Context, symbol strin
 	r.logger.Debug("Retrieving price history", "symbol", symbol, "from", from, "to", to)
 
 	var priceData []entities.CryptoPrice
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("symbol = ? AND created_at BETWEEN ? AND ?", symbol, from, to).
 		Order("created_at ASC").
 		Find(&priceData).Error; err != nil {
@@ -57,7 +57,7 @@ func (r *marketDataRepository) GetLatestPrice(ctx context.Context, symbol string
 	r.logger.Debug("Retrieving latest price", "symbol", symbol)
 
 	var priceData entities.CryptoPrice
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("symbol = ?", symbol).
 		Order("created_at DESC").
 		First(&priceData).Error; err != nil {
@@ -75,7 +75,7 @@ func (r *marketDataRepository) GetLatestPrice(ctx context.Context, symbol string
 func (r *marketDataRepository) StoreDominanceData(ctx context.Context, dominanceData *entities.BitcoinDominance) error {
 	r.logger.Debug("Saving dominance data", "dominance", dominanceData.CurrentDominance, "source", dominanceData.DataSource)
 
-	if err := r.db.WithContext(ctx).Create(dominanceData).Error; err != nil {
+	if err := r.db.Writer().WithContext(ctx).Create(dominanceData).Error; err != nil {
 		r.logger.Error("Failed to save dominance data", "error", err, "dominance", dominanceData.CurrentDominance)
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to save dominance data")
 	}
@@ -88,7 +88,7 @@ func (r *marketDataRepository) GetDominanceHistory(ctx context.Context, from, to
 	r.logger.Debug("Retrieving dominance history", "from", from, "to", to)
 
 	var dominanceData []entities.BitcoinDominance
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("created_at BETWEEN ? AND ?", from, to).
 		Order("created_at ASC").
 		Find(&dominanceData).Error; err != nil {
@@ -104,7 +104,7 @@ func (r *marketDataRepository) GetLatestDominance(ctx context.Context) (*entitie
 	r.logger.Debug("Retrieving latest dominance data")
 
 	var dominanceData entities.BitcoinDominance
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Order("created_at DESC").
 		First(&dominanceData).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -121,7 +121,7 @@ func (r *marketDataRepository) GetLatestDominance(ctx context.Context) (*entitie
 func (r *marketDataRepository) SaveMarketMetrics(ctx context.Context, metrics *entities.MarketMetrics) error {
 	r.logger.Debug("Saving market metrics")
 
-	if err := r.db.WithContext(ctx).Create(metrics).Error; err != nil {
+	if err := r.db.Writer().WithContext(ctx).Create(metrics).Error; err != nil {
 		r.logger.Error("Failed to save market metrics", "error", err)
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to save market metrics")
 	}
@@ -134,7 +134,7 @@ func (r *marketDataRepository) GetMarketMetricsHistory(ctx context.Context, from
 	r.logger.Debug("Retrieving market metrics history", "from", from, "to", to)
 
 	var metrics []entities.MarketMetrics
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Where("created_at BETWEEN ? AND ?", from, to).
 		Order("created_at ASC").
 		Find(&metrics).Error; err != nil {
@@ -150,7 +150,7 @@ func (r *marketDataRepository) GetLatestMarketMetrics(ctx context.Context) (*ent
 	r.logger.Debug("Retrieving latest market metrics")
 
 	var metrics entities.MarketMetrics
-	if err := r.db.WithContext(ctx).
+	if err := r.db.Reader().WithContext(ctx).
 		Order("created_at DESC").
 		First(&metrics).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
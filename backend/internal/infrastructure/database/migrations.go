@@ -0,0 +1,868 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// schemaMigrationsTable records which migration versions have been applied
+const schemaMigrationsTable = "schema_migrations"
+
+// Migration is one versioned schema change. Up and Down run inside a transaction.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// schemaMigration is a row of the schema_migrations table
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for schemaMigration
+func (schemaMigration) TableName() string {
+	return schemaMigrationsTable
+}
+
+// Migrator applies and rolls back versioned migrations, recording progress in the
+// schema_migrations table so each migration runs once
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	logger     logger.Logger
+}
+
+// NewMigrator creates a migrator for the application's schema migrations
+func NewMigrator(db *gorm.DB, logger logger.Logger) *Migrator {
+	migrations := Migrations()
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return &Migrator{db: db, migrations: migrations, logger: logger}
+}
+
+// Migrate applies every pending migration in version order and returns how many ran.
+// Running it again once the schema is current does nothing.
+func (m *Migrator) Migrate(ctx context.Context) (int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+		})
+		if err != nil {
+			return count, fmt.Errorf("migration %d %s failed: %w", migration.Version, migration.Name, err)
+		}
+		m.logger.Info("Applied migration", "version", migration.Version, "name", migration.Name)
+		count++
+	}
+	return count, nil
+}
+
+// Rollback reverts the most recently applied migrations, at most steps of them, and
+// returns how many were reverted
+func (m *Migrator) Rollback(ctx context.Context, steps int) (int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if !applied[migration.Version] {
+			continue
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return count, fmt.Errorf("rollback of migration %d %s failed: %w", migration.Version, migration.Name, err)
+		}
+		m.logger.Info("Rolled back migration", "version", migration.Version, "name", migration.Name)
+		count++
+	}
+	return count, nil
+}
+
+// appliedVersions creates the schema_migrations table if needed and returns the versions
+// recorded in it
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int64]bool, error) {
+	db := m.db.WithContext(ctx)
+	ddl := newSchemaDialect(db).expand(`CREATE TABLE IF NOT EXISTS ` + schemaMigrationsTable + ` (
+		version {{bigint}} PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at {{timestamp}} NOT NULL
+	)`)
+	if err := db.Exec(ddl).Error; err != nil {
+		return nil, fmt.Errorf("failed to create %s table: %w", schemaMigrationsTable, err)
+	}
+
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	applied := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = true
+	}
+	return applied, nil
+}
+
+// schemaDialect expands the column type placeholders used in migration DDL for the
+// connected database. GORM's CreateTable is not used because the SQLite driver in use
+// declares autoincrement keys twice ("more than one primary key").
+type schemaDialect struct {
+	replacer *strings.Replacer
+}
+
+func newSchemaDialect(db *gorm.DB) schemaDialect {
+	if db.Dialector.Name() == "postgres" {
+		return schemaDialect{strings.NewReplacer(
+			"{{pk}}", "BIGSERIAL PRIMARY KEY",
+			"{{bigint}}", "BIGINT",
+			"{{float}}", "DOUBLE PRECISION",
+			"{{timestamp}}", "TIMESTAMPTZ",
+		)}
+	}
+	return schemaDialect{strings.NewReplacer(
+		"{{pk}}", "INTEGER PRIMARY KEY AUTOINCREMENT",
+		"{{bigint}}", "INTEGER",
+		"{{float}}", "REAL",
+		"{{timestamp}}", "DATETIME",
+	)}
+}
+
+func (d schemaDialect) expand(ddl string) string {
+	return d.replacer.Replace(ddl)
+}
+
+// column is a table column and its DDL type, which may use the schemaDialect placeholders
+type column struct {
+	name string
+	ddl  string
+}
+
+// tableSchema describes a table created by a migration
+type tableSchema struct {
+	name    string
+	columns []column
+	indexes []string
+}
+
+// create creates the table and its indexes if they are missing. A table that already
+// exists, such as one created by an earlier GORM AutoMigrate, gains any missing columns
+// so migrations can adopt existing databases.
+func (t tableSchema) create(tx *gorm.DB) error {
+	dialect := newSchemaDialect(tx)
+
+	definitions := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		definitions[i] = col.name + " " + col.ddl
+	}
+	ddl := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n)", t.name, strings.Join(definitions, ",\n\t"))
+	if err := tx.Exec(dialect.expand(ddl)).Error; err != nil {
+		return fmt.Errorf("failed to create table %s: %w", t.name, err)
+	}
+
+	for _, col := range t.columns {
+		if col.ddl == "{{pk}}" || tx.Migrator().HasColumn(t.name, col.name) {
+			continue
+		}
+		ddl := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", t.name, col.name, col.ddl)
+		if err := tx.Exec(dialect.expand(ddl)).Error; err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", t.name, col.name, err)
+		}
+	}
+
+	for _, index := range t.indexes {
+		if err := tx.Exec(index).Error; err != nil {
+			return fmt.Errorf("failed to create index on %s: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+// drop drops the table and its indexes
+func (t tableSchema) drop(tx *gorm.DB) error {
+	if err := tx.Exec("DROP TABLE IF EXISTS " + t.name).Error; err != nil {
+		return fmt.Errorf("failed to drop table %s: %w", t.name, err)
+	}
+	return nil
+}
+
+// createTables returns a migration step creating tables in order
+func createTables(tables ...tableSchema) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, table := range tables {
+			if err := table.create(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// dropTables returns a migration step dropping tables in reverse order
+func dropTables(tables ...tableSchema) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for i := len(tables) - 1; i >= 0; i-- {
+			if err := tables[i].drop(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Migrations returns the application's schema migrations in version order. Applied
+// migrations must not be edited; change the schema by appending a new one.
+func Migrations() []Migration {
+	indicatorTables := []tableSchema{indicatorsTable, onChainDataTable, macroDataTable}
+	marketDataTables := []tableSchema{cryptoPricesTable, bitcoinDominanceTable, marketMetricsTable, tradingPairsTable, marketDataTable}
+	applicationTables := []tableSchema{
+		portfoliosTable, portfolioHoldingsTable, archivedHoldingsTable, holdingLotsTable, holdingTransactionsTable,
+		portfolioDrawdownAlertsTable, marketCyclesTable, dcaStrategiesTable, dcaPurchasesTable, dcaSimulationsTable,
+		priceAlertsTable, apiKeysTable, indicatorAnnotationsTable, providerHealthChecksTable, networkMetricsTable,
+		compositeWeightConfigsTable, indicatorConfigsTable, priceDataTable,
+	}
+
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "create_indicator_tables",
+			Up:      createTables(indicatorTables...),
+			Down:    dropTables(indicatorTables...),
+		},
+		{
+			Version: 2,
+			Name:    "create_market_data_tables",
+			Up:      createTables(marketDataTables...),
+			Down:    dropTables(marketDataTables...),
+		},
+		{
+			Version: 3,
+			Name:    "normalize_data_sources",
+			Up:      normalizeDataSources,
+			Down:    dropDataSourceDetails,
+		},
+		{
+			Version: 4,
+			Name:    "create_application_tables",
+			Up:      createTables(applicationTables...),
+			Down:    dropTables(applicationTables...),
+		},
+	}
+}
+
+// dataSourceColumns hold an entities.DataSource, keyed by table
+var dataSourceColumns = []struct{ table, column string }{
+	{"crypto_prices", "data_source"},
+	{"bitcoin_dominance", "data_source"},
+	{"market_metrics", "data_source"},
+	{"network_metrics", "data_source"},
+	{"price_data", "data_source"},
+	{"indicators", "source"},
+}
+
+// dataSourceDetailTables also describe aggregated values in a data_source_detail column
+var dataSourceDetailTables = []string{"crypto_prices", "bitcoin_dominance"}
+
+// normalizeDataSources adds the data_source_detail columns and rewrites recorded source
+// labels such as "CoinGecko API" to their canonical entities.DataSource. Composite and
+// unrecognized labels become "aggregated" or "unknown" only where a data_source_detail
+// column keeps the original label; elsewhere they are left as recorded rather than lose
+// it. Tables that don't exist yet are skipped; they are created with canonical values only.
+func normalizeDataSources(tx *gorm.DB) error {
+	for _, table := range dataSourceDetailTables {
+		if !tx.Migrator().HasTable(table) || tx.Migrator().HasColumn(table, "data_source_detail") {
+			continue
+		}
+		if err := tx.Exec("ALTER TABLE " + table + " ADD COLUMN data_source_detail TEXT").Error; err != nil {
+			return fmt.Errorf("failed to add column %s.data_source_detail: %w", table, err)
+		}
+	}
+
+	for _, target := range dataSourceColumns {
+		table, column := target.table, target.column
+		if !tx.Migrator().HasTable(table) {
+			continue
+		}
+
+		var labels []string
+		if err := tx.Table(table).Distinct(column).Where(column+" IS NOT NULL").Pluck(column, &labels).Error; err != nil {
+			return fmt.Errorf("failed to read %s data sources: %w", table, err)
+		}
+
+		hasDetail := tx.Migrator().HasColumn(table, "data_source_detail")
+		for _, label := range labels {
+			source, detail := entities.NormalizeDataSource(label)
+			if string(source) == label || (detail != "" && !hasDetail) {
+				continue
+			}
+			updates := map[string]interface{}{column: string(source)}
+			if detail != "" {
+				updates["data_source_detail"] = detail
+			}
+			if err := tx.Table(table).Where(column+" = ?", label).Updates(updates).Error; err != nil {
+				return fmt.Errorf("failed to normalize %s data source %q: %w", table, label, err)
+			}
+		}
+	}
+	return nil
+}
+
+// dropDataSourceDetails drops the data_source_detail columns. Normalized labels are kept.
+func dropDataSourceDetails(tx *gorm.DB) error {
+	for _, table := range dataSourceDetailTables {
+		if !tx.Migrator().HasColumn(table, "data_source_detail") {
+			continue
+		}
+		if err := tx.Exec("ALTER TABLE " + table + " DROP COLUMN data_source_detail").Error; err != nil {
+			return fmt.Errorf("failed to drop column %s.data_source_detail: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// indicatorsTable matches entities.Indicator, the shape IndicatorRepository reads and writes
+var indicatorsTable = tableSchema{
+	name: "indicators",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"name", "TEXT NOT NULL"},
+		{"type", "TEXT NOT NULL"},
+		{"value", "{{float}}"},
+		{"string_value", "TEXT"},
+		{"change", "TEXT"},
+		{"risk_level", "TEXT"},
+		{"status", "TEXT"},
+		{"description", "TEXT"},
+		{"source", "TEXT"},
+		{"confidence", "{{float}}"},
+		{"metadata", "TEXT"},
+		{"timestamp", "{{timestamp}}"},
+		{"version", "{{bigint}} NOT NULL DEFAULT 1"},
+		{"calc_version", "{{bigint}} NOT NULL DEFAULT 1"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_indicators_name ON indicators (name)",
+		"CREATE INDEX IF NOT EXISTS idx_indicators_timestamp ON indicators (timestamp)",
+		"CREATE INDEX IF NOT EXISTS idx_indicators_calc_version ON indicators (calc_version)",
+	},
+}
+
+var onChainDataTable = tableSchema{
+	name: "on_chain_data",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"symbol", "TEXT NOT NULL"},
+		{"market_value", "{{float}}"},
+		{"realized_value", "{{float}}"},
+		{"mvrv_ratio", "{{float}}"},
+		{"mvrvz_score", "{{float}}"},
+		{"active_addresses", "{{bigint}}"},
+		{"transaction_count", "{{bigint}}"},
+		{"network_hash_rate", "{{float}}"},
+		{"timestamp", "{{timestamp}} NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_on_chain_data_symbol ON on_chain_data (symbol)",
+		"CREATE INDEX IF NOT EXISTS idx_on_chain_data_timestamp ON on_chain_data (timestamp)",
+	},
+}
+
+var macroDataTable = tableSchema{
+	name: "macro_data",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"indicator", "TEXT NOT NULL"},
+		{"value", "{{float}} NOT NULL"},
+		{"change", "{{float}}"},
+		{"country", "TEXT DEFAULT 'US'"},
+		{"source", "TEXT"},
+		{"release_date", "{{timestamp}}"},
+		{"timestamp", "{{timestamp}} NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_macro_data_indicator ON macro_data (indicator)",
+		"CREATE INDEX IF NOT EXISTS idx_macro_data_timestamp ON macro_data (timestamp)",
+	},
+}
+
+var cryptoPricesTable = tableSchema{
+	name: "crypto_prices",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"symbol", "TEXT NOT NULL"},
+		{"name", "TEXT"},
+		{"price", "{{float}}"},
+		{"volume24h", "{{float}}"},
+		{"market_cap", "{{float}}"},
+		{"percent_change1h", "{{float}}"},
+		{"percent_change24h", "{{float}}"},
+		{"percent_change7d", "{{float}}"},
+		{"percent_change30d", "{{float}}"},
+		{"last_updated", "{{timestamp}}"},
+		{"data_source", "TEXT"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_crypto_prices_symbol ON crypto_prices (symbol)",
+	},
+}
+
+var bitcoinDominanceTable = tableSchema{
+	name: "bitcoin_dominance",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"current_dominance", "{{float}}"},
+		{"previous_dominance", "{{float}}"},
+		{"change24h", "{{float}}"},
+		{"change_percent24h", "{{float}}"},
+		{"last_updated", "{{timestamp}}"},
+		{"data_source", "TEXT"},
+		{"confidence", "{{float}}"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+}
+
+var marketMetricsTable = tableSchema{
+	name: "market_metrics",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"total_market_cap", "{{float}}"},
+		{"total_volume24h", "{{float}}"},
+		{"bitcoin_dominance", "{{float}}"},
+		{"ethereum_dominance", "{{float}}"},
+		{"active_cryptocurrencies", "{{bigint}}"},
+		{"active_exchanges", "{{bigint}}"},
+		{"market_cap_change24h", "{{float}}"},
+		{"volume_change24h", "{{float}}"},
+		{"last_updated", "{{timestamp}}"},
+		{"data_source", "TEXT"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+}
+
+var tradingPairsTable = tableSchema{
+	name: "trading_pairs",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"base_asset", "TEXT"},
+		{"quote_asset", "TEXT"},
+		{"symbol", "TEXT"},
+		{"exchange", "TEXT"},
+		{"price", "{{float}}"},
+		{"volume24h", "{{float}}"},
+		{"is_active", "BOOLEAN DEFAULT true"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_trading_pairs_symbol ON trading_pairs (symbol)",
+	},
+}
+
+var marketDataTable = tableSchema{
+	name: "market_data",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"symbol", "TEXT NOT NULL"},
+		{"name", "TEXT"},
+		{"price", "{{float}}"},
+		{"market_cap", "{{float}}"},
+		{"volume24h", "{{float}}"},
+		{"change24h", "{{float}}"},
+		{"change7d", "{{float}}"},
+		{"change30d", "{{float}}"},
+		{"dominance", "{{float}}"},
+		{"circ_supply", "{{float}}"},
+		{"max_supply", "{{float}}"},
+		{"source", "TEXT"},
+		{"confidence", "{{float}}"},
+		{"last_updated", "{{timestamp}}"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_market_data_symbol ON market_data (symbol)",
+	},
+}
+
+// The tables below were created by GORM AutoMigrate before migration 4; their index names
+// match the ones it generated so existing databases adopt them unchanged.
+
+var portfoliosTable = tableSchema{
+	name: "portfolios",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"user_id", "TEXT NOT NULL"},
+		{"name", "TEXT NOT NULL"},
+		{"total_value", "{{float}}"},
+		{"risk_level", "TEXT"},
+		{"last_updated", "{{timestamp}}"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_portfolios_user_id ON portfolios (user_id)",
+	},
+}
+
+var portfolioHoldingsTable = tableSchema{
+	name: "portfolio_holdings",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"portfolio_id", "{{bigint}} NOT NULL"},
+		{"symbol", "TEXT NOT NULL"},
+		{"amount", "{{float}} NOT NULL"},
+		{"average_price", "{{float}}"},
+		{"current_price", "{{float}}"},
+		{"value", "{{float}}"},
+		{"pn_l", "{{float}}"},
+		{"pn_l_percent", "{{float}}"},
+		{"realized_pn_l", "{{float}} DEFAULT 0"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_portfolio_holdings_portfolio_id ON portfolio_holdings (portfolio_id)",
+	},
+}
+
+var archivedHoldingsTable = tableSchema{
+	name: "archived_holdings",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"holding_id", "{{bigint}} NOT NULL"},
+		{"portfolio_id", "{{bigint}} NOT NULL"},
+		{"symbol", "TEXT NOT NULL"},
+		{"amount", "{{float}} NOT NULL"},
+		{"average_price", "{{float}}"},
+		{"current_price", "{{float}}"},
+		{"value", "{{float}}"},
+		{"realized_pn_l", "{{float}}"},
+		{"held_since", "{{timestamp}}"},
+		{"archived_at", "{{timestamp}} NOT NULL"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_archived_holdings_portfolio_id ON archived_holdings (portfolio_id)",
+	},
+}
+
+var holdingLotsTable = tableSchema{
+	name: "holding_lots",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"holding_id", "{{bigint}} NOT NULL"},
+		{"quantity", "{{float}} NOT NULL"},
+		{"remaining", "{{float}} NOT NULL"},
+		{"price", "{{float}} NOT NULL"},
+		{"acquired_at", "{{timestamp}} NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_holding_lots_holding_id ON holding_lots (holding_id)",
+		"CREATE INDEX IF NOT EXISTS idx_holding_lots_acquired_at ON holding_lots (acquired_at)",
+	},
+}
+
+var holdingTransactionsTable = tableSchema{
+	name: "holding_transactions",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"holding_id", "{{bigint}} NOT NULL"},
+		{"side", "TEXT NOT NULL"},
+		{"quantity", "{{float}} NOT NULL"},
+		{"price", "{{float}} NOT NULL"},
+		{"realized_pn_l", "{{float}}"},
+		{"executed_at", "{{timestamp}} NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_holding_transactions_holding_id ON holding_transactions (holding_id)",
+		"CREATE INDEX IF NOT EXISTS idx_holding_transactions_executed_at ON holding_transactions (executed_at)",
+	},
+}
+
+var portfolioDrawdownAlertsTable = tableSchema{
+	name: "portfolio_drawdown_alerts",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"portfolio_id", "{{bigint}} NOT NULL"},
+		{"user_id", "TEXT NOT NULL"},
+		{"threshold_percent", "{{float}} NOT NULL"},
+		{"peak_value", "{{float}}"},
+		{"peak_at", "{{timestamp}}"},
+		{"triggered", "BOOLEAN"},
+		{"is_active", "BOOLEAN DEFAULT true"},
+		{"last_triggered", "{{timestamp}}"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_portfolio_drawdown_alerts_portfolio_id ON portfolio_drawdown_alerts (portfolio_id)",
+		"CREATE INDEX IF NOT EXISTS idx_portfolio_drawdown_alerts_user_id ON portfolio_drawdown_alerts (user_id)",
+	},
+}
+
+var marketCyclesTable = tableSchema{
+	name: "market_cycles",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"stage", "TEXT NOT NULL"},
+		{"confidence", "{{float}}"},
+		{"dominance_level", "{{float}}"},
+		{"fear_greed_index", "{{bigint}}"},
+		{"mvrvz_score", "{{float}}"},
+		{"bubble_risk", "TEXT"},
+		{"estimated_duration", "{{bigint}}"},
+		{"timestamp", "{{timestamp}} NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_market_cycles_timestamp ON market_cycles (timestamp)",
+	},
+}
+
+var dcaStrategiesTable = tableSchema{
+	name: "dca_strategies",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"user_id", "TEXT NOT NULL"},
+		{"name", "TEXT NOT NULL"},
+		{"symbol", "TEXT NOT NULL"},
+		{"amount", "{{float}} NOT NULL"},
+		{"frequency", "TEXT NOT NULL"},
+		{"start_date", "{{timestamp}} NOT NULL"},
+		{"end_date", "{{timestamp}}"},
+		{"is_active", "BOOLEAN DEFAULT true"},
+		{"total_invested", "{{float}} DEFAULT 0"},
+		{"total_quantity", "{{float}} DEFAULT 0"},
+		{"average_price", "{{float}} DEFAULT 0"},
+		{"current_value", "{{float}} DEFAULT 0"},
+		{"total_return", "{{float}} DEFAULT 0"},
+		{"total_return_pct", "{{float}} DEFAULT 0"},
+		{"purchase_count", "{{bigint}} DEFAULT 0"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_dca_strategies_user_id ON dca_strategies (user_id)",
+	},
+}
+
+var dcaPurchasesTable = tableSchema{
+	name: "dca_purchases",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"strategy_id", "{{bigint}} NOT NULL"},
+		{"date", "{{timestamp}} NOT NULL"},
+		{"amount", "{{float}} NOT NULL"},
+		{"price", "{{float}} NOT NULL"},
+		{"quantity", "{{float}} NOT NULL"},
+		{"market_cap", "{{float}}"},
+		{"mvrvz_score", "{{float}}"},
+		{"fear_greed", "{{bigint}}"},
+		{"is_simulated", "BOOLEAN DEFAULT false"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_dca_purchases_strategy_id ON dca_purchases (strategy_id)",
+		"CREATE INDEX IF NOT EXISTS idx_dca_purchases_date ON dca_purchases (date)",
+	},
+}
+
+var dcaSimulationsTable = tableSchema{
+	name: "dca_simulations",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"user_id", "TEXT NOT NULL"},
+		{"symbol", "TEXT NOT NULL"},
+		{"amount", "{{float}} NOT NULL"},
+		{"frequency", "TEXT NOT NULL"},
+		{"start_date", "{{timestamp}} NOT NULL"},
+		{"end_date", "{{timestamp}} NOT NULL"},
+		{"total_invested", "{{float}}"},
+		{"total_quantity", "{{float}}"},
+		{"final_value", "{{float}}"},
+		{"total_return", "{{float}}"},
+		{"total_return_pct", "{{float}}"},
+		{"annualized_return", "{{float}}"},
+		{"max_drawdown", "{{float}}"},
+		{"max_drawdown_pct", "{{float}}"},
+		{"sharpe_ratio", "{{float}}"},
+		{"purchase_count", "{{bigint}}"},
+		{"best_purchase_date", "{{timestamp}}"},
+		{"worst_purchase_date", "{{timestamp}}"},
+		{"avg_mvrv_at_purchase", "{{float}}"},
+		{"avg_fear_greed_at_purchase", "{{bigint}}"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_dca_simulations_user_id ON dca_simulations (user_id)",
+	},
+}
+
+var priceAlertsTable = tableSchema{
+	name: "price_alerts",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"user_id", "TEXT NOT NULL"},
+		{"symbol", "TEXT NOT NULL"},
+		{"alert_type", "TEXT"},
+		{"target_price", "{{float}}"},
+		{"target_percent", "{{float}}"},
+		{"is_active", "BOOLEAN DEFAULT true"},
+		{"last_triggered", "{{timestamp}}"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_price_alerts_user_id ON price_alerts (user_id)",
+	},
+}
+
+var apiKeysTable = tableSchema{
+	name: "api_keys",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"user_id", "TEXT NOT NULL"},
+		{"name", "TEXT"},
+		{"key_hash", "TEXT NOT NULL"},
+		{"scopes", "TEXT"},
+		{"active", "BOOLEAN DEFAULT true"},
+		{"expires_at", "{{timestamp}}"},
+		{"last_used_at", "{{timestamp}}"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash)",
+	},
+}
+
+var indicatorAnnotationsTable = tableSchema{
+	name: "indicator_annotations",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"timestamp", "{{timestamp}} NOT NULL"},
+		{"label", "TEXT NOT NULL"},
+		{"type", "TEXT NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+		{"updated_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_indicator_annotations_timestamp ON indicator_annotations (timestamp)",
+		"CREATE INDEX IF NOT EXISTS idx_indicator_annotations_type ON indicator_annotations (type)",
+	},
+}
+
+var providerHealthChecksTable = tableSchema{
+	name: "provider_health_checks",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"provider", "TEXT NOT NULL"},
+		{"healthy", "BOOLEAN"},
+		{"error", "TEXT"},
+		{"latency_ms", "{{float}}"},
+		{"checked_at", "{{timestamp}} NOT NULL"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_provider_health_provider_checked ON provider_health_checks (provider, checked_at)",
+		"CREATE INDEX IF NOT EXISTS idx_provider_health_checks_checked_at ON provider_health_checks (checked_at)",
+	},
+}
+
+var networkMetricsTable = tableSchema{
+	name: "network_metrics",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"timestamp", "{{timestamp}} NOT NULL"},
+		{"network", "VARCHAR(20) NOT NULL"},
+		{"hash_rate", "{{float}}"},
+		{"difficulty", "{{float}}"},
+		{"block_height", "{{bigint}}"},
+		{"total_supply", "{{float}}"},
+		{"mempool_size", "{{bigint}}"},
+		{"transaction_rate", "{{float}}"},
+		{"fees_total", "{{float}}"},
+		{"data_source", "VARCHAR(50) NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_network_metrics_timestamp ON network_metrics (timestamp)",
+	},
+}
+
+var compositeWeightConfigsTable = tableSchema{
+	name: "composite_weight_configs",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"weights", "TEXT NOT NULL"},
+		{"created_at", "{{timestamp}}"},
+	},
+}
+
+var indicatorConfigsTable = tableSchema{
+	name: "indicator_configs",
+	columns: []column{
+		{"name", "TEXT PRIMARY KEY"},
+		{"description", "TEXT"},
+		{"bands", "TEXT NOT NULL"},
+		{"updated_at", "{{timestamp}}"},
+	},
+}
+
+// priceDataTable matches entities.PriceObservation
+var priceDataTable = tableSchema{
+	name: "price_data",
+	columns: []column{
+		{"id", "{{pk}}"},
+		{"timestamp", "{{timestamp}} NOT NULL"},
+		{"asset_symbol", "VARCHAR(10) NOT NULL"},
+		{"price_usd", "{{float}} NOT NULL"},
+		{"market_cap", "{{float}}"},
+		{"volume_24h", "{{float}}"},
+		{"data_source", "VARCHAR(50) NOT NULL"},
+		{"reliability_score", "{{float}}"},
+		{"created_at", "{{timestamp}}"},
+	},
+	indexes: []string{
+		"CREATE INDEX IF NOT EXISTS idx_price_data_timestamp ON price_data (timestamp)",
+		"CREATE INDEX IF NOT EXISTS idx_price_data_asset_symbol ON price_data (asset_symbol)",
+	},
+}
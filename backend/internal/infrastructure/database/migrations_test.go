@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// sqliteSchema returns every table and index definition, keyed by name
+func sqliteSchema(t *testing.T, db *gorm.DB) map[string]string {
+	t.Helper()
+	var rows []struct {
+		Name string
+		SQL  string
+	}
+	require.NoError(t, db.Raw("SELECT name, sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%'").Scan(&rows).Error)
+	schema := make(map[string]string, len(rows))
+	for _, row := range rows {
+		schema[row.Name] = row.SQL
+	}
+	return schema
+}
+
+// applicationModels are stored in the tables created by migration 4
+var applicationModels = []interface{}{
+	&models.Portfolio{}, &models.PortfolioHolding{}, &models.ArchivedHolding{}, &models.HoldingLot{}, &models.HoldingTransaction{},
+	&entities.PortfolioDrawdownAlert{}, &models.MarketCycle{}, &models.DCAStrategy{}, &models.DCAPurchase{}, &models.DCASimulation{},
+	&entities.PriceAlert{}, &entities.APIKey{}, &entities.IndicatorAnnotation{}, &entities.ProviderHealthCheck{}, &entities.NetworkMetrics{},
+	&entities.CompositeWeightConfig{}, &entities.IndicatorConfig{}, &entities.PriceObservation{},
+}
+
+func TestMigrator_MigrateIsIdempotent(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+	ctx := context.Background()
+	migrator := NewMigrator(testDB.DB, testDB.Logger)
+
+	applied, err := migrator.Migrate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(Migrations()), applied)
+	schema := sqliteSchema(t, testDB.DB)
+	for _, table := range []string{"indicators", "on_chain_data", "macro_data", "crypto_prices", "bitcoin_dominance", "market_metrics", "trading_pairs", "market_data", "portfolios", "api_keys", "price_data"} {
+		assert.Contains(t, schema, table)
+	}
+
+	applied, err = migrator.Migrate(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, applied)
+	assert.Equal(t, schema, sqliteSchema(t, testDB.DB))
+
+	// Every column the models map to exists
+	for _, model := range append([]interface{}{&entities.Indicator{}, &models.OnChainData{}, &models.MacroData{}, &entities.CryptoPrice{}, &entities.BitcoinDominance{}, &entities.MarketMetrics{}, &entities.TradingPair{}, &entities.MarketData{}}, applicationModels...) {
+		stmt := &gorm.Statement{DB: testDB.DB}
+		require.NoError(t, stmt.Parse(model))
+		for _, name := range stmt.Schema.DBNames {
+			assert.True(t, testDB.DB.Migrator().HasColumn(stmt.Schema.Table, name), "%s.%s missing", stmt.Schema.Table, name)
+		}
+	}
+
+	// The migrated tables fit the entities stored in them
+	indicator := &entities.Indicator{Name: "mvrv", Type: "on-chain", Value: 2.5, Confidence: 0.9, Metadata: map[string]interface{}{"source": "test"}, Timestamp: time.Now()}
+	require.NoError(t, testDB.DB.Create(indicator).Error)
+	require.NoError(t, testDB.DB.Create(&entities.CryptoPrice{Symbol: "BTC", Price: 67000, LastUpdated: time.Now()}).Error)
+
+	var stored entities.Indicator
+	require.NoError(t, testDB.DB.First(&stored, indicator.ID).Error)
+	assert.Equal(t, 2.5, stored.Value)
+	assert.Equal(t, uint(1), stored.CalcVersion)
+	assert.Equal(t, "test", stored.Metadata["source"])
+}
+
+func TestMigrator_RollbackAndReapply(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+	ctx := context.Background()
+	migrator := NewMigrator(testDB.DB, testDB.Logger)
+
+	_, err := migrator.Migrate(ctx)
+	require.NoError(t, err)
+	schema := sqliteSchema(t, testDB.DB)
+
+	reverted, err := migrator.Rollback(ctx, len(Migrations())-1)
+	require.NoError(t, err)
+	assert.Equal(t, len(Migrations())-1, reverted)
+	assert.False(t, testDB.DB.Migrator().HasTable("market_data"))
+	assert.True(t, testDB.DB.Migrator().HasTable("indicators"))
+
+	applied, err := migrator.Migrate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(Migrations())-1, applied)
+	assert.Equal(t, schema, sqliteSchema(t, testDB.DB))
+
+	reverted, err = migrator.Rollback(ctx, len(Migrations())+1)
+	require.NoError(t, err)
+	assert.Equal(t, len(Migrations()), reverted)
+	assert.False(t, testDB.DB.Migrator().HasTable("indicators"))
+}
+
+func TestMigrator_AdoptsExistingTables(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	// A table left by an earlier auto-migration, missing columns added since
+	require.NoError(t, testDB.DB.Exec(`CREATE TABLE indicators (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		type TEXT NOT NULL,
+		value REAL,
+		timestamp DATETIME
+	)`).Error)
+	require.NoError(t, testDB.DB.Exec("INSERT INTO indicators (name, type, value) VALUES ('mvrv', 'on-chain', 1.5)").Error)
+
+	_, err := NewMigrator(testDB.DB, testDB.Logger).Migrate(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, testDB.DB.Migrator().HasColumn("indicators", "calc_version"))
+	var stored entities.Indicator
+	require.NoError(t, testDB.DB.Where("name = ?", "mvrv").First(&stored).Error)
+	assert.Equal(t, 1.5, stored.Value)
+	assert.Equal(t, uint(1), stored.CalcVersion)
+}
+
+func TestMigrator_AdoptsAutoMigratedApplicationTables(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	// An api_keys table and index left by the startup auto-migration, before last_used_at
+	require.NoError(t, testDB.DB.Exec(`CREATE TABLE api_keys (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id TEXT NOT NULL,
+		name TEXT,
+		key_hash TEXT NOT NULL,
+		scopes TEXT,
+		active NUMERIC DEFAULT true,
+		expires_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`).Error)
+	require.NoError(t, testDB.DB.Exec("CREATE UNIQUE INDEX idx_api_keys_key_hash ON api_keys (key_hash)").Error)
+	require.NoError(t, testDB.DB.Exec("INSERT INTO api_keys (user_id, key_hash, scopes) VALUES ('alice', 'hash', 'read')").Error)
+
+	_, err := NewMigrator(testDB.DB, testDB.Logger).Migrate(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, testDB.DB.Migrator().HasColumn("api_keys", "last_used_at"))
+	var stored entities.APIKey
+	require.NoError(t, testDB.DB.Where("key_hash = ?", "hash").First(&stored).Error)
+	assert.Equal(t, "alice", stored.UserID)
+	assert.Error(t, testDB.DB.Create(&entities.APIKey{UserID: "bob", KeyHash: "hash"}).Error, "key hashes stay unique")
+}
+
+func TestMigrator_NormalizesDataSources(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+	ctx := context.Background()
+
+	// Rows recorded with free-form labels before sources were canonical
+	legacy := &Migrator{db: testDB.DB, migrations: Migrations()[:2], logger: testDB.Logger}
+	_, err := legacy.Migrate(ctx)
+	require.NoError(t, err)
+	for _, label := range []string{"CoinGecko API", "Fallback Data", "CoinMarketCap + TradingView (averaged)", "coinmarketcap"} {
+		require.NoError(t, testDB.DB.Exec("INSERT INTO bitcoin_dominance (current_dominance, data_source) VALUES (?, ?)", 55.0, label).Error)
+	}
+	for _, label := range []string{"CoinMarketCap", "CoinMarketCap + CoinGecko"} {
+		require.NoError(t, testDB.DB.Exec("INSERT INTO market_metrics (total_market_cap, data_source) VALUES (?, ?)", 2.5e12, label).Error)
+	}
+	for _, label := range []string{"CoinGecko", "price_history", "some_provider"} {
+		require.NoError(t, testDB.DB.Exec("INSERT INTO indicators (name, type, source) VALUES (?, ?, ?)", "mvrv", "onchain", label).Error)
+	}
+
+	_, err = NewMigrator(testDB.DB, testDB.Logger).Migrate(ctx)
+	require.NoError(t, err)
+
+	var dominance []entities.BitcoinDominance
+	require.NoError(t, testDB.DB.Order("id").Find(&dominance).Error)
+	require.Len(t, dominance, 4)
+	assert.Equal(t, entities.DataSourceCoinGecko, dominance[0].DataSource)
+	assert.Equal(t, entities.DataSourceFallback, dominance[1].DataSource)
+	assert.Equal(t, entities.DataSourceAggregated, dominance[2].DataSource)
+	assert.Equal(t, "CoinMarketCap + TradingView (averaged)", dominance[2].DataSourceDetail)
+	assert.Equal(t, entities.DataSourceCoinMarketCap, dominance[3].DataSource)
+	assert.Empty(t, dominance[3].DataSourceDetail)
+
+	// Without a detail column, a composite label is kept rather than lose what it combined
+	var metrics []entities.MarketMetrics
+	require.NoError(t, testDB.DB.Order("id").Find(&metrics).Error)
+	require.Len(t, metrics, 2)
+	assert.Equal(t, entities.DataSourceCoinMarketCap, metrics[0].DataSource)
+	assert.Equal(t, entities.DataSource("CoinMarketCap + CoinGecko"), metrics[1].DataSource)
+
+	var sources []entities.DataSource
+	require.NoError(t, testDB.DB.Model(&entities.Indicator{}).Order("id").Pluck("source", &sources).Error)
+	assert.Equal(t, []entities.DataSource{entities.DataSourceCoinGecko, entities.DataSourcePriceHistory, "some_provider"}, sources)
+}
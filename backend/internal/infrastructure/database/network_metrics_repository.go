@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// networkMetricsRepository implements the NetworkMetricsRepository interface
+type networkMetricsRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewNetworkMetricsRepository creates a new instance of network metrics repository
+func NewNetworkMetricsRepository(db DBProvider, logger logger.Logger) repositories.NetworkMetricsRepository {
+	return &networkMetricsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save stores a network metrics snapshot
+func (r *networkMetricsRepository) Save(ctx context.Context, metrics *entities.NetworkMetrics) error {
+	if err := r.db.Writer().WithContext(ctx).Create(metrics).Error; err != nil {
+		r.logger.Error("Failed to save network metrics", "error", err, "network", metrics.Network)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to save network metrics")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// portfolioAlertRepository implements the PortfolioAlertRepository interface
+type portfolioAlertRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewPortfolioAlertRepository creates a new instance of portfolio alert repository
+func NewPortfolioAlertRepository(db DBProvider, logger logger.Logger) repositories.PortfolioAlertRepository {
+	return &portfolioAlertRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateDrawdownAlert stores a new drawdown alert
+func (r *portfolioAlertRepository) CreateDrawdownAlert(ctx context.Context, alert *entities.PortfolioDrawdownAlert) error {
+	if err := r.db.Writer().WithContext(ctx).Create(alert).Error; err != nil {
+		r.logger.Error("Failed to create drawdown alert", "portfolio_id", alert.PortfolioID, "error", err)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to create drawdown alert")
+	}
+
+	return nil
+}
+
+// GetDrawdownAlerts retrieves a portfolio's drawdown alerts. It reads from the primary so
+// the valuation job always compares against the latest stored peak.
+func (r *portfolioAlertRepository) GetDrawdownAlerts(ctx context.Context, portfolioID uint, activeOnly bool) ([]entities.PortfolioDrawdownAlert, error) {
+	query := r.db.Writer().WithContext(ctx).Where("portfolio_id = ?", portfolioID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var alerts []entities.PortfolioDrawdownAlert
+	if err := query.Order("id ASC").Find(&alerts).Error; err != nil {
+		r.logger.Error("Failed to retrieve drawdown alerts", "portfolio_id", portfolioID, "error", err)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve drawdown alerts")
+	}
+
+	return alerts, nil
+}
+
+// UpdateDrawdownAlert stores an alert's peak and trigger state
+func (r *portfolioAlertRepository) UpdateDrawdownAlert(ctx context.Context, alert *entities.PortfolioDrawdownAlert) error {
+	err := r.db.Writer().WithContext(ctx).
+		Model(alert).
+		Select("peak_value", "peak_at", "triggered", "last_triggered", "updated_at").
+		Updates(alert).Error
+	if err != nil {
+		r.logger.Error("Failed to update drawdown alert", "alert_id", alert.ID, "error", err)
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update drawdown alert")
+	}
+
+	return nil
+}
@@ -3,19 +3,22 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/internal/domain/repositories"
 	"crypto-indicator-dashboard/models"
+	"crypto-indicator-dashboard/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // portfolioRepository implements the PortfolioRepository interface
 type portfolioRepository struct {
-	db *gorm.DB
+	db DBProvider
 }
 
 // NewPortfolioRepository creates a new portfolio repository
-func NewPortfolioRepository(db *gorm.DB) repositories.PortfolioRepository {
+func NewPortfolioRepository(db DBProvider) repositories.PortfolioRepository {
 	return &portfolioRepository{
 		db: db,
 	}
@@ -30,8 +33,8 @@ func (r *portfolioRepository) Create(ctx context.Context, portfolio *entities.Po
 		RiskLevel:  portfolio.RiskLevel,
 	}
 	
-	if err := r.db.WithContext(ctx).Create(dbPortfolio).Error; err != nil {
-		return fmt.Errorf("failed to create portfolio: %w", err)
+	if err := r.db.Writer().WithContext(ctx).Create(dbPortfolio).Error; err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to create portfolio")
 	}
 	
 	// Update entity with generated ID
@@ -46,11 +49,11 @@ func (r *portfolioRepository) Create(ctx context.Context, portfolio *entities.Po
 func (r *portfolioRepository) GetByID(ctx context.Context, id uint) (*entities.Portfolio, error) {
 	var dbPortfolio models.Portfolio
 	
-	if err := r.db.WithContext(ctx).Preload("Holdings").First(&dbPortfolio, id).Error; err != nil {
+	if err := r.db.Reader().WithContext(ctx).Preload("Holdings").First(&dbPortfolio, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("portfolio not found")
+			return nil, errors.NotFound("Portfolio")
 		}
-		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get portfolio")
 	}
 	
 	return r.mapToEntity(&dbPortfolio), nil
@@ -60,8 +63,8 @@ func (r *portfolioRepository) GetByID(ctx context.Context, id uint) (*entities.P
 func (r *portfolioRepository) GetByUserID(ctx context.Context, userID string) ([]entities.Portfolio, error) {
 	var dbPortfolios []models.Portfolio
 	
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Preload("Holdings").Find(&dbPortfolios).Error; err != nil {
-		return nil, fmt.Errorf("failed to get user portfolios: %w", err)
+	if err := r.db.Reader().WithContext(ctx).Where("user_id = ?", userID).Preload("Holdings").Find(&dbPortfolios).Error; err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get user portfolios")
 	}
 	
 	portfolios := make([]entities.Portfolio, len(dbPortfolios))
@@ -76,8 +79,8 @@ func (r *portfolioRepository) GetByUserID(ctx context.Context, userID string) ([
 func (r *portfolioRepository) Update(ctx context.Context, portfolio *entities.Portfolio) error {
 	dbPortfolio := r.mapToModel(portfolio)
 	
-	if err := r.db.WithContext(ctx).Save(dbPortfolio).Error; err != nil {
-		return fmt.Errorf("failed to update portfolio: %w", err)
+	if err := r.db.Writer().WithContext(ctx).Save(dbPortfolio).Error; err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update portfolio")
 	}
 	
 	return nil
@@ -85,8 +88,12 @@ func (r *portfolioRepository) Update(ctx context.Context, portfolio *entities.Po
 
 // Delete deletes a portfolio
 func (r *portfolioRepository) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&models.Portfolio{}, id).Error; err != nil {
-		return fmt.Errorf("failed to delete portfolio: %w", err)
+	result := r.db.Writer().WithContext(ctx).Delete(&models.Portfolio{}, id)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, errors.ErrorTypeInternal, "failed to delete portfolio")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound("Portfolio")
 	}
 	
 	return nil
@@ -103,10 +110,11 @@ func (r *portfolioRepository) AddHolding(ctx context.Context, portfolioID uint,
 		Value:        holding.Value,
 		PnL:          holding.PnL,
 		PnLPercent:   holding.PnLPercent,
+		RealizedPnL:  holding.RealizedPnL,
 	}
 	
-	if err := r.db.WithContext(ctx).Create(dbHolding).Error; err != nil {
-		return fmt.Errorf("failed to add holding: %w", err)
+	if err := r.db.Writer().WithContext(ctx).Create(dbHolding).Error; err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to add holding")
 	}
 	
 	// Update entity with generated ID
@@ -117,7 +125,8 @@ func (r *portfolioRepository) AddHolding(ctx context.Context, portfolioID uint,
 	return nil
 }
 
-// UpdateHolding updates a holding
+// UpdateHolding updates a holding and replaces its lots with one lot for the new amount at
+// the new average price, so later transactions start from the updated position
 func (r *portfolioRepository) UpdateHolding(ctx context.Context, holding *entities.PortfolioHolding) error {
 	dbHolding := &models.PortfolioHolding{
 		ID:           holding.ID,
@@ -129,30 +138,120 @@ func (r *portfolioRepository) UpdateHolding(ctx context.Context, holding *entiti
 		Value:        holding.Value,
 		PnL:          holding.PnL,
 		PnLPercent:   holding.PnLPercent,
+		RealizedPnL:  holding.RealizedPnL,
+		CreatedAt:    holding.CreatedAt,
 	}
 	
-	if err := r.db.WithContext(ctx).Save(dbHolding).Error; err != nil {
-		return fmt.Errorf("failed to update holding: %w", err)
+	return r.db.Writer().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(dbHolding).Error; err != nil {
+			return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update holding")
+		}
+		return resetLots(tx, holding.ID, holding.Amount, holding.AveragePrice)
+	})
+}
+
+// UpdateHoldings updates the amount and average price of several holdings in one transaction,
+// replacing each one's lots as UpdateHolding does. A holding that does not belong to the
+// portfolio rolls back the whole batch.
+func (r *portfolioRepository) UpdateHoldings(ctx context.Context, portfolioID uint, holdings []entities.PortfolioHolding) error {
+	return r.db.Writer().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, holding := range holdings {
+			result := tx.Model(&models.PortfolioHolding{}).
+				Where("id = ? AND portfolio_id = ?", holding.ID, portfolioID).
+				Updates(map[string]interface{}{
+					"amount":        holding.Amount,
+					"average_price": holding.AveragePrice,
+				})
+			if result.Error != nil {
+				return errors.Wrap(result.Error, errors.ErrorTypeInternal, fmt.Sprintf("failed to update holding %d", holding.ID))
+			}
+			if result.RowsAffected == 0 {
+				return errors.NotFound(fmt.Sprintf("Holding %d", holding.ID))
+			}
+			if err := resetLots(tx, holding.ID, holding.Amount, holding.AveragePrice); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveHolding removes a holding together with its lots and transactions
+func (r *portfolioRepository) RemoveHolding(ctx context.Context, holdingID uint) error {
+	return r.db.Writer().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		removed, err := deleteHoldings(tx, []uint{holdingID})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return errors.NotFound("Holding")
+		}
+		return nil
+	})
+}
+
+// ClearHoldings removes all holdings of a portfolio with their lots and transactions,
+// optionally archiving the holdings, and zeroes the portfolio's total value
+func (r *portfolioRepository) ClearHoldings(ctx context.Context, portfolioID uint, archive bool) (int, error) {
+	var removed int
+	err := r.db.Writer().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var holdings []models.PortfolioHolding
+		if err := tx.Where("portfolio_id = ?", portfolioID).Order("id").Find(&holdings).Error; err != nil {
+			return errors.Wrap(err, errors.ErrorTypeInternal, "failed to load holdings")
+		}
+		
+		if archive {
+			if err := archiveHoldings(tx, holdings); err != nil {
+				return err
+			}
+		}
+		
+		holdingIDs := make([]uint, len(holdings))
+		for i, holding := range holdings {
+			holdingIDs[i] = holding.ID
+		}
+		if _, err := deleteHoldings(tx, holdingIDs); err != nil {
+			return err
+		}
+		
+		if err := tx.Model(&models.Portfolio{}).Where("id = ?", portfolioID).Updates(map[string]interface{}{
+			"total_value":  0,
+			"last_updated": time.Now(),
+		}).Error; err != nil {
+			return errors.Wrap(err, errors.ErrorTypeInternal, "failed to reset portfolio value")
+		}
+		
+		removed = len(holdings)
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
 	
-	return nil
+	return removed, nil
 }
 
-// RemoveHolding removes a holding
-func (r *portfolioRepository) RemoveHolding(ctx context.Context, holdingID uint) error {
-	if err := r.db.WithContext(ctx).Delete(&models.PortfolioHolding{}, holdingID).Error; err != nil {
-		return fmt.Errorf("failed to remove holding: %w", err)
+// GetHolding retrieves a single holding by ID
+func (r *portfolioRepository) GetHolding(ctx context.Context, holdingID uint) (*entities.PortfolioHolding, error) {
+	var dbHolding models.PortfolioHolding
+	
+	if err := r.db.Reader().WithContext(ctx).First(&dbHolding, holdingID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("Holding")
+		}
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get holding")
 	}
 	
-	return nil
+	holding := holdingToEntity(dbHolding)
+	return &holding, nil
 }
 
 // GetHoldings retrieves all holdings for a portfolio
 func (r *portfolioRepository) GetHoldings(ctx context.Context, portfolioID uint) ([]entities.PortfolioHolding, error) {
 	var dbHoldings []models.PortfolioHolding
 	
-	if err := r.db.WithContext(ctx).Where("portfolio_id = ?", portfolioID).Find(&dbHoldings).Error; err != nil {
-		return nil, fmt.Errorf("failed to get holdings: %w", err)
+	if err := r.db.Reader().WithContext(ctx).Where("portfolio_id = ?", portfolioID).Find(&dbHoldings).Error; err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get holdings")
 	}
 	
 	holdings := make([]entities.PortfolioHolding, len(dbHoldings))
@@ -167,6 +266,7 @@ func (r *portfolioRepository) GetHoldings(ctx context.Context, portfolioID uint)
 			Value:        dbHolding.Value,
 			PnL:          dbHolding.PnL,
 			PnLPercent:   dbHolding.PnLPercent,
+			RealizedPnL:  dbHolding.RealizedPnL,
 			CreatedAt:    dbHolding.CreatedAt,
 			UpdatedAt:    dbHolding.UpdatedAt,
 		}
@@ -175,15 +275,134 @@ func (r *portfolioRepository) GetHoldings(ctx context.Context, portfolioID uint)
 	return holdings, nil
 }
 
+// GetLots retrieves a holding's lots, oldest first
+func (r *portfolioRepository) GetLots(ctx context.Context, holdingID uint) ([]entities.HoldingLot, error) {
+	var dbLots []models.HoldingLot
+	
+	if err := r.db.Reader().WithContext(ctx).Where("holding_id = ?", holdingID).
+		Order("acquired_at, id").
+		Find(&dbLots).Error; err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get lots")
+	}
+	
+	return lotsToEntities(dbLots), nil
+}
+
+// RecordTransaction reads the holding and its lots inside the write transaction with row
+// locks, so concurrent transactions on one holding apply one after the other instead of
+// overwriting each other's result. Lots without an ID are created; the rest are updated.
+func (r *portfolioRepository) RecordTransaction(ctx context.Context, holdingID uint, apply repositories.HoldingTransactionFunc) error {
+	return r.db.Writer().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		forUpdate := clause.Locking{Strength: "UPDATE"}
+		
+		var dbHolding models.PortfolioHolding
+		if err := tx.Clauses(forUpdate).First(&dbHolding, holdingID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NotFound("Holding")
+			}
+			return errors.Wrap(err, errors.ErrorTypeInternal, "failed to get holding")
+		}
+		
+		var dbLots []models.HoldingLot
+		if err := tx.Clauses(forUpdate).Where("holding_id = ?", holdingID).Order("acquired_at, id").Find(&dbLots).Error; err != nil {
+			return errors.Wrap(err, errors.ErrorTypeInternal, "failed to get lots")
+		}
+		
+		holding := holdingToEntity(dbHolding)
+		change, err := apply(&holding, lotsToEntities(dbLots))
+		if err != nil {
+			return err
+		}
+		
+		dbHolding = models.PortfolioHolding{
+			ID:           holding.ID,
+			PortfolioID:  holding.PortfolioID,
+			Symbol:       holding.Symbol,
+			Amount:       holding.Amount,
+			AveragePrice: holding.AveragePrice,
+			CurrentPrice: holding.CurrentPrice,
+			Value:        holding.Value,
+			PnL:          holding.PnL,
+			PnLPercent:   holding.PnLPercent,
+			RealizedPnL:  holding.RealizedPnL,
+			CreatedAt:    holding.CreatedAt,
+		}
+		if change.Remove {
+			if err := archiveHoldings(tx, []models.PortfolioHolding{dbHolding}); err != nil {
+				return err
+			}
+			_, err := deleteHoldings(tx, []uint{holding.ID})
+			return err
+		}
+		
+		lots := change.Lots
+		for i := range lots {
+			dbLot := &models.HoldingLot{
+				ID:         lots[i].ID,
+				HoldingID:  holding.ID,
+				Quantity:   lots[i].Quantity,
+				Remaining:  lots[i].Remaining,
+				Price:      lots[i].Price,
+				AcquiredAt: lots[i].AcquiredAt,
+				CreatedAt:  lots[i].CreatedAt,
+			}
+			if err := tx.Save(dbLot).Error; err != nil {
+				return errors.Wrap(err, errors.ErrorTypeInternal, "failed to save lot")
+			}
+			lots[i].ID = dbLot.ID
+			lots[i].HoldingID = dbLot.HoldingID
+			lots[i].CreatedAt = dbLot.CreatedAt
+			lots[i].UpdatedAt = dbLot.UpdatedAt
+		}
+		
+		transaction := change.Transaction
+		dbTransaction := &models.HoldingTransaction{
+			HoldingID:   holding.ID,
+			Side:        transaction.Side,
+			Quantity:    transaction.Quantity,
+			Price:       transaction.Price,
+			RealizedPnL: transaction.RealizedPnL,
+			ExecutedAt:  transaction.ExecutedAt,
+		}
+		if err := tx.Create(dbTransaction).Error; err != nil {
+			return errors.Wrap(err, errors.ErrorTypeInternal, "failed to record transaction")
+		}
+		transaction.ID = dbTransaction.ID
+		transaction.HoldingID = dbTransaction.HoldingID
+		transaction.CreatedAt = dbTransaction.CreatedAt
+		
+		if err := tx.Save(&dbHolding).Error; err != nil {
+			return errors.Wrap(err, errors.ErrorTypeInternal, "failed to update holding")
+		}
+		holding.UpdatedAt = dbHolding.UpdatedAt
+		
+		return nil
+	})
+}
+
+// GetActivePortfolioIDs returns the IDs of portfolios that have at least one holding
+func (r *portfolioRepository) GetActivePortfolioIDs(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	
+	if err := r.db.Reader().WithContext(ctx).Model(&models.PortfolioHolding{}).
+		Distinct("portfolio_id").
+		Order("portfolio_id").
+		Pluck("portfolio_id", &ids).Error; err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to get active portfolios")
+	}
+	
+	return ids, nil
+}
+
 // CalculateTotalValue calculates the total value of a portfolio
 func (r *portfolioRepository) CalculateTotalValue(ctx context.Context, portfolioID uint) (float64, error) {
 	var totalValue float64
 	
-	if err := r.db.WithContext(ctx).Model(&models.PortfolioHolding{}).
+	if err := r.db.Reader().WithContext(ctx).Model(&models.PortfolioHolding{}).
 		Where("portfolio_id = ?", portfolioID).
 		Select("COALESCE(SUM(value), 0)").
 		Scan(&totalValue).Error; err != nil {
-		return 0, fmt.Errorf("failed to calculate total value: %w", err)
+		return 0, errors.Wrap(err, errors.ErrorTypeInternal, "failed to calculate total value")
 	}
 	
 	return totalValue, nil
@@ -195,7 +414,7 @@ func (r *portfolioRepository) GetPortfolioSummary(ctx context.Context, portfolio
 	// In a real implementation, you would calculate various metrics
 	holdings, err := r.GetHoldings(ctx, portfolioID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get holdings for summary: %w", err)
+		return nil, err
 	}
 	
 	var totalValue, totalPnL float64
@@ -247,6 +466,7 @@ func (r *portfolioRepository) mapToEntity(dbPortfolio *models.Portfolio) *entiti
 			Value:        dbHolding.Value,
 			PnL:          dbHolding.PnL,
 			PnLPercent:   dbHolding.PnLPercent,
+			RealizedPnL:  dbHolding.RealizedPnL,
 			CreatedAt:    dbHolding.CreatedAt,
 			UpdatedAt:    dbHolding.UpdatedAt,
 		}
@@ -279,6 +499,7 @@ func (r *portfolioRepository) mapToModel(portfolio *entities.Portfolio) *models.
 			Value:        holding.Value,
 			PnL:          holding.PnL,
 			PnLPercent:   holding.PnLPercent,
+			RealizedPnL:  holding.RealizedPnL,
 			CreatedAt:    holding.CreatedAt,
 			UpdatedAt:    holding.UpdatedAt,
 		}
@@ -295,4 +516,105 @@ func (r *portfolioRepository) mapToModel(portfolio *entities.Portfolio) *models.
 		CreatedAt:   portfolio.CreatedAt,
 		UpdatedAt:   portfolio.UpdatedAt,
 	}
-}
\ No newline at end of file
+}
+
+// archiveHoldings copies holdings, with the realized PnL they closed with, to the archive
+func archiveHoldings(tx *gorm.DB, holdings []models.PortfolioHolding) error {
+	if len(holdings) == 0 {
+		return nil
+	}
+	
+	now := time.Now()
+	archived := make([]models.ArchivedHolding, len(holdings))
+	for i, holding := range holdings {
+		archived[i] = models.ArchivedHolding{
+			HoldingID:    holding.ID,
+			PortfolioID:  holding.PortfolioID,
+			Symbol:       holding.Symbol,
+			Amount:       holding.Amount,
+			AveragePrice: holding.AveragePrice,
+			CurrentPrice: holding.CurrentPrice,
+			Value:        holding.Value,
+			RealizedPnL:  holding.RealizedPnL,
+			HeldSince:    holding.CreatedAt,
+			ArchivedAt:   now,
+		}
+	}
+	if err := tx.Create(&archived).Error; err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to archive holdings")
+	}
+	return nil
+}
+
+// deleteHoldings removes holdings with their lots and transactions, so neither is left
+// pointing at a holding that no longer exists, and returns how many holdings it removed
+func deleteHoldings(tx *gorm.DB, holdingIDs []uint) (int64, error) {
+	if err := tx.Where("holding_id IN ?", holdingIDs).Delete(&models.HoldingLot{}).Error; err != nil {
+		return 0, errors.Wrap(err, errors.ErrorTypeInternal, "failed to remove holding lots")
+	}
+	if err := tx.Where("holding_id IN ?", holdingIDs).Delete(&models.HoldingTransaction{}).Error; err != nil {
+		return 0, errors.Wrap(err, errors.ErrorTypeInternal, "failed to remove holding transactions")
+	}
+	
+	result := tx.Where("id IN ?", holdingIDs).Delete(&models.PortfolioHolding{})
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, errors.ErrorTypeInternal, "failed to remove holding")
+	}
+	return result.RowsAffected, nil
+}
+
+// resetLots replaces a holding's lots with a single lot of amount at price, acquired now.
+// Without it the next transaction would rebuild the position from the old lots.
+func resetLots(tx *gorm.DB, holdingID uint, amount, price float64) error {
+	if err := tx.Where("holding_id = ?", holdingID).Delete(&models.HoldingLot{}).Error; err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to remove holding lots")
+	}
+	
+	lot := &models.HoldingLot{
+		HoldingID:  holdingID,
+		Quantity:   amount,
+		Remaining:  amount,
+		Price:      price,
+		AcquiredAt: time.Now().UTC(),
+	}
+	if err := tx.Create(lot).Error; err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to save lot")
+	}
+	return nil
+}
+
+// holdingToEntity maps a stored holding to its domain entity
+func holdingToEntity(dbHolding models.PortfolioHolding) entities.PortfolioHolding {
+	return entities.PortfolioHolding{
+		ID:           dbHolding.ID,
+		PortfolioID:  dbHolding.PortfolioID,
+		Symbol:       dbHolding.Symbol,
+		Amount:       dbHolding.Amount,
+		AveragePrice: dbHolding.AveragePrice,
+		CurrentPrice: dbHolding.CurrentPrice,
+		Value:        dbHolding.Value,
+		PnL:          dbHolding.PnL,
+		PnLPercent:   dbHolding.PnLPercent,
+		RealizedPnL:  dbHolding.RealizedPnL,
+		CreatedAt:    dbHolding.CreatedAt,
+		UpdatedAt:    dbHolding.UpdatedAt,
+	}
+}
+
+// lotsToEntities maps stored lots to domain entities, keeping their order
+func lotsToEntities(dbLots []models.HoldingLot) []entities.HoldingLot {
+	lots := make([]entities.HoldingLot, len(dbLots))
+	for i, dbLot := range dbLots {
+		lots[i] = entities.HoldingLot{
+			ID:         dbLot.ID,
+			HoldingID:  dbLot.HoldingID,
+			Quantity:   dbLot.Quantity,
+			Remaining:  dbLot.Remaining,
+			Price:      dbLot.Price,
+			AcquiredAt: dbLot.AcquiredAt,
+			CreatedAt:  dbLot.CreatedAt,
+			UpdatedAt:  dbLot.UpdatedAt,
+		}
+	}
+	return lots
+}
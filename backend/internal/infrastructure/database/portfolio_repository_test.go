@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/models"
+	"crypto-indicator-dashboard/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPortfolioRepository(t *testing.T) *portfolioRepository {
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.PortfolioTablesDDL...)
+
+	return NewPortfolioRepository(NewDBProvider(testDB.DB, nil)).(*portfolioRepository)
+}
+
+func TestPortfolioRepository_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPortfolioRepository(t)
+
+	portfolio := &entities.Portfolio{UserID: "alice", Name: "Main"}
+	require.NoError(t, repo.Create(ctx, portfolio))
+	holding := &entities.PortfolioHolding{Symbol: "BTC", Amount: 1, AveragePrice: 30000}
+	require.NoError(t, repo.AddHolding(ctx, portfolio.ID, holding))
+
+	_, err := repo.GetByID(ctx, 999)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound), "got %v", err)
+
+	_, err = repo.GetHolding(ctx, 999)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound), "got %v", err)
+
+	err = repo.RemoveHolding(ctx, 999)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound), "got %v", err)
+
+	err = repo.Delete(ctx, 999)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound), "got %v", err)
+
+	// Existing records are unaffected and can still be removed
+	stored, err := repo.GetByID(ctx, portfolio.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Holdings, 1)
+	require.NoError(t, repo.RemoveHolding(ctx, holding.ID))
+	require.NoError(t, repo.Delete(ctx, portfolio.ID))
+
+	_, err = repo.GetByID(ctx, portfolio.ID)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound), "got %v", err)
+}
+
+func TestPortfolioRepository_DatabaseErrorsAreInternal(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPortfolioRepository(t)
+	require.NoError(t, repo.db.Writer().Exec("DROP TABLE portfolio_holdings").Error)
+
+	_, err := repo.GetHoldings(ctx, 1)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeInternal), "got %v", err)
+
+	_, err = repo.GetPortfolioSummary(ctx, 1)
+	assert.True(t, errors.IsType(err, errors.ErrorTypeInternal), "got %v", err)
+}
+
+func TestPortfolioRepository_RecordTransactionReadsPrimary(t *testing.T) {
+	ctx := context.Background()
+	primary := newTestPortfolioRepository(t)
+	replica := newTestPortfolioRepository(t)
+	repo := NewPortfolioRepository(NewDBProvider(primary.db.Writer(), replica.db.Writer()))
+
+	// The replica still has the holding as it was before a buy reached the primary
+	for _, r := range []*portfolioRepository{primary, replica} {
+		require.NoError(t, r.AddHolding(ctx, 1, &entities.PortfolioHolding{Symbol: "BTC", Amount: 1, AveragePrice: 30000}))
+	}
+	require.NoError(t, primary.db.Writer().Model(&models.PortfolioHolding{}).Where("id = ?", 1).
+		Update("amount", 3).Error)
+
+	err := repo.RecordTransaction(ctx, 1, func(holding *entities.PortfolioHolding, lots []entities.HoldingLot) (*repositories.HoldingChange, error) {
+		assert.Equal(t, 3.0, holding.Amount, "the transaction must see the primary's holding")
+		holding.Amount -= 2
+		return &repositories.HoldingChange{
+			Transaction: &entities.HoldingTransaction{Side: entities.TransactionSideSell, Quantity: 2, Price: 40000, ExecutedAt: time.Now()},
+		}, nil
+	})
+	require.NoError(t, err)
+
+	stored, err := primary.GetHolding(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, stored.Amount)
+
+	t.Run("apply errors roll back", func(t *testing.T) {
+		err := repo.RecordTransaction(ctx, 1, func(holding *entities.PortfolioHolding, lots []entities.HoldingLot) (*repositories.HoldingChange, error) {
+			holding.Amount = 0
+			return nil, errors.Validation("rejected")
+		})
+		assert.True(t, errors.IsType(err, errors.ErrorTypeValidation), "got %v", err)
+
+		stored, err := primary.GetHolding(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, stored.Amount)
+	})
+
+	t.Run("missing holding", func(t *testing.T) {
+		err := repo.RecordTransaction(ctx, 999, func(*entities.PortfolioHolding, []entities.HoldingLot) (*repositories.HoldingChange, error) {
+			t.Fatal("apply must not run without a holding")
+			return nil, nil
+		})
+		assert.True(t, errors.IsType(err, errors.ErrorTypeNotFound), "got %v", err)
+	})
+}
+
+func TestPortfolioRepository_RemoveHoldingRemovesLotsAndTransactions(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPortfolioRepository(t)
+	db := repo.db.Writer()
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, repo.AddHolding(ctx, 1, &entities.PortfolioHolding{Symbol: "BTC", Amount: 1, AveragePrice: 30000}))
+		holdingID := uint(i + 1)
+		require.NoError(t, db.Create(&models.HoldingLot{HoldingID: holdingID, Quantity: 1, Remaining: 1, Price: 30000, AcquiredAt: time.Now()}).Error)
+		require.NoError(t, db.Create(&models.HoldingTransaction{HoldingID: holdingID, Side: entities.TransactionSideBuy, Quantity: 1, Price: 30000, ExecutedAt: time.Now()}).Error)
+	}
+
+	require.NoError(t, repo.RemoveHolding(ctx, 1))
+
+	var lots, transactions []uint
+	require.NoError(t, db.Model(&models.HoldingLot{}).Pluck("holding_id", &lots).Error)
+	require.NoError(t, db.Model(&models.HoldingTransaction{}).Pluck("holding_id", &transactions).Error)
+	assert.Equal(t, []uint{2}, lots, "only the other holding's lots remain")
+	assert.Equal(t, []uint{2}, transactions, "only the other holding's transactions remain")
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"strings"
+	"time"
+)
+
+// priceObservationRepository implements the PriceObservationRepository interface
+type priceObservationRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewPriceObservationRepository creates a new instance of price observation repository
+func NewPriceObservationRepository(db DBProvider, logger logger.Logger) repositories.PriceObservationRepository {
+	return &priceObservationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// StoreObservations saves a batch of price observations
+func (r *priceObservationRepository) StoreObservations(ctx context.Context, observations []entities.PriceObservation) error {
+	if len(observations) == 0 {
+		return nil
+	}
+
+	if err := r.db.Writer().WithContext(ctx).Create(&observations).Error; err != nil {
+		r.logger.Error("Failed to store price observations", "error", err, "count", len(observations))
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to store price observations")
+	}
+
+	return nil
+}
+
+// GetObservations retrieves an asset's price observations between from and to, oldest first
+func (r *priceObservationRepository) GetObservations(ctx context.Context, symbol string, from, to time.Time) ([]entities.PriceObservation, error) {
+	var observations []entities.PriceObservation
+	if err := r.db.Reader().WithContext(ctx).
+		Where("asset_symbol = ? AND timestamp >= ? AND timestamp <= ?", strings.ToUpper(symbol), from, to).
+		Order("timestamp ASC, id ASC").
+		Find(&observations).Error; err != nil {
+		r.logger.Error("Failed to retrieve price observations", "error", err, "symbol", symbol, "from", from, "to", to)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve price observations")
+	}
+
+	return observations, nil
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"time"
+)
+
+// providerHealthRepository implements the ProviderHealthRepository interface
+type providerHealthRepository struct {
+	db     DBProvider
+	logger logger.Logger
+}
+
+// NewProviderHealthRepository creates a new instance of provider health repository
+func NewProviderHealthRepository(db DBProvider, logger logger.Logger) repositories.ProviderHealthRepository {
+	return &providerHealthRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Record saves a batch of provider health check results
+func (r *providerHealthRepository) Record(ctx context.Context, checks []entities.ProviderHealthCheck) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	if err := r.db.Writer().WithContext(ctx).Create(&checks).Error; err != nil {
+		r.logger.Error("Failed to record provider health checks", "error", err, "count", len(checks))
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to record provider health checks")
+	}
+
+	return nil
+}
+
+// GetSince retrieves provider health checks made at or after since, oldest first
+func (r *providerHealthRepository) GetSince(ctx context.Context, since time.Time) ([]entities.ProviderHealthCheck, error) {
+	var checks []entities.ProviderHealthCheck
+	if err := r.db.Reader().WithContext(ctx).
+		Where("checked_at >= ?", since).
+		Order("checked_at ASC, id ASC").
+		Find(&checks).Error; err != nil {
+		r.logger.Error("Failed to retrieve provider health checks", "error", err, "since", since)
+		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to retrieve provider health checks")
+	}
+
+	return checks, nil
+}
+
+// DeleteBefore removes provider health checks made before cutoff
+func (r *providerHealthRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.Writer().WithContext(ctx).
+		Where("checked_at < ?", cutoff).
+		Delete(&entities.ProviderHealthCheck{})
+	if err := result.Error; err != nil {
+		r.logger.Error("Failed to delete old provider health checks", "error", err, "cutoff", cutoff)
+		return 0, errors.Wrap(err, errors.ErrorTypeInternal, "failed to delete old provider health checks")
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// RetryPolicy controls how write operations are retried on transient database errors
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by repositories
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+}
+
+// transientErrorMarkers are error fragments for lock contention and serialization
+// failures that usually succeed when retried
+var transientErrorMarkers = []string{
+	// SQLite
+	"database is locked",
+	"database table is locked",
+	"sqlite_busy",
+	// PostgreSQL serialization_failure and deadlock_detected
+	"sqlstate 40001",
+	"sqlstate 40p01",
+	"could not serialize access",
+	"deadlock detected",
+}
+
+// IsTransientError reports whether err is a transient database error worth retrying
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, marker := range transientErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry runs fn, retrying with exponential backoff while it fails with a transient error
+func withRetry(ctx context.Context, policy RetryPolicy, log logger.Logger, operation string, fn func() error) error {
+	attempts := policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := policy.InitialBackoff
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil || !IsTransientError(err) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+
+		log.WithContext(ctx).Warn("Transient database error, retrying",
+			"operation", operation,
+			"attempt", attempt,
+			"backoff", backoff,
+			"error", err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+
+	return err
+}
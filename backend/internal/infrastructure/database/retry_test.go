@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{nil, false},
+		{fmt.Errorf("database is locked"), true},
+		{fmt.Errorf("database table is locked: indicators"), true},
+		{fmt.Errorf("ERROR: could not serialize access due to concurrent update (SQLSTATE 40001)"), true},
+		{fmt.Errorf("ERROR: deadlock detected (SQLSTATE 40P01)"), true},
+		{fmt.Errorf("UNIQUE constraint failed: indicators.id"), false},
+		{gorm.ErrRecordNotFound, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.transient, IsTransientError(tt.err), "%v", tt.err)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	log := logger.New("test")
+
+	t.Run("Succeeds after transient errors", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), testRetryPolicy(), log, "test", func() error {
+			calls++
+			if calls <= 2 {
+				return fmt.Errorf("database is locked")
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), testRetryPolicy(), log, "test", func() error {
+			calls++
+			return fmt.Errorf("database is locked")
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Does not retry permanent errors", func(t *testing.T) {
+		calls := 0
+		err := withRetry(context.Background(), testRetryPolicy(), log, "test", func() error {
+			calls++
+			return fmt.Errorf("UNIQUE constraint failed")
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Stops when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := withRetry(ctx, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second}, log, "test", func() error {
+			calls++
+			return fmt.Errorf("database is locked")
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestIndicatorRepository_CreateRetriesTransientErrors(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+	testDB.CreateTables(t, testutil.IndicatorsTableDDL)
+
+	// Fail the first two inserts with a transient lock error
+	failures := 0
+	err := testDB.DB.Callback().Create().Before("gorm:create").Register("test:inject_lock", func(db *gorm.DB) {
+		if failures < 2 {
+			failures++
+			db.AddError(fmt.Errorf("database is locked"))
+		}
+	})
+	require.NoError(t, err)
+
+	repo := NewIndicatorRepository(NewDBProvider(testDB.DB, nil), testDB.Logger).(*indicatorRepository)
+	repo.retryPolicy = testRetryPolicy()
+
+	indicator := &entities.Indicator{
+		Name:      "mvrv",
+		Type:      "onchain",
+		Value:     1.5,
+		Timestamp: time.Now(),
+	}
+	require.NoError(t, repo.Create(context.Background(), indicator))
+
+	assert.Equal(t, 2, failures)
+	assert.NotZero(t, indicator.ID)
+
+	var count int64
+	require.NoError(t, testDB.DB.Model(&entities.Indicator{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
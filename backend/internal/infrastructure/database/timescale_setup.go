@@ -121,6 +121,7 @@ func (tm *TimescaleManager) SetupHypertables() error {
 					transaction_count BIGINT,
 					fees_total DECIMAL(20,8),
 					mempool_size INTEGER,
+					transaction_rate DECIMAL(20,8),
 					data_source VARCHAR(50) NOT NULL,
 					created_at TIMESTAMPTZ DEFAULT NOW()
 				);
@@ -0,0 +1,127 @@
+package external
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AlternativeMeClient fetches the Crypto Fear & Greed Index from Alternative.me.
+// The API only publishes the headline index, so readings carry no components.
+type AlternativeMeClient struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     logger.Logger
+}
+
+// NewAlternativeMeClient creates a new Alternative.me API client
+func NewAlternativeMeClient(baseURL string, logger logger.Logger) *AlternativeMeClient {
+	return &AlternativeMeClient{
+		baseURL:    baseURL,
+		httpClient: NewHTTPClient(15 * time.Second),
+		logger:     logger,
+	}
+}
+
+// FearGreedData represents a single index value from the /fng/ endpoint
+type FearGreedData struct {
+	Value               string `json:"value"`
+	ValueClassification string `json:"value_classification"`
+	Timestamp           string `json:"timestamp"`
+	TimeUntilUpdate     string `json:"time_until_update,omitempty"`
+}
+
+// FearGreedResponse represents the response from the /fng/ endpoint
+type FearGreedResponse struct {
+	Name     string          `json:"name"`
+	Data     []FearGreedData `json:"data"`
+	Metadata struct {
+		Error *string `json:"error"`
+	} `json:"metadata"`
+}
+
+// Name returns the provider name reported as the data source
+func (c *AlternativeMeClient) Name() string {
+	return string(entities.DataSourceAlternativeMe)
+}
+
+// GetReadings retrieves the latest limit daily index values, newest first
+func (c *AlternativeMeClient) GetReadings(ctx context.Context, limit int) ([]entities.FearGreedReading, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("format", "json")
+	reqURL := c.baseURL + "/fng/?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "CryptoIndicatorDashboard/1.0")
+
+	c.logger.WithContext(ctx).Debug("Making Alternative.me API request", "url", reqURL)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if err := checkJSONResponse(resp, body); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, bodySnippet(body))
+	}
+
+	var response FearGreedResponse
+	if err := decodeJSON(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fear & greed response: %w", err)
+	}
+	if response.Metadata.Error != nil && *response.Metadata.Error != "" {
+		return nil, fmt.Errorf("Alternative.me API error: %s", *response.Metadata.Error)
+	}
+
+	readings := make([]entities.FearGreedReading, 0, len(response.Data))
+	for i, data := range response.Data {
+		reading, err := data.toReading()
+		if err != nil {
+			return nil, fmt.Errorf("invalid fear & greed value at index %d: %w", i, err)
+		}
+		readings = append(readings, reading)
+	}
+
+	return readings, nil
+}
+
+// toReading converts the API's string fields to a typed reading
+func (d FearGreedData) toReading() (entities.FearGreedReading, error) {
+	value, err := strconv.Atoi(d.Value)
+	if err != nil {
+		return entities.FearGreedReading{}, fmt.Errorf("value %q: %w", d.Value, err)
+	}
+	unix, err := strconv.ParseInt(d.Timestamp, 10, 64)
+	if err != nil {
+		return entities.FearGreedReading{}, fmt.Errorf("timestamp %q: %w", d.Timestamp, err)
+	}
+
+	reading := entities.FearGreedReading{
+		Value:          value,
+		Classification: d.ValueClassification,
+		Timestamp:      time.Unix(unix, 0).UTC(),
+	}
+	if seconds, err := strconv.ParseInt(d.TimeUntilUpdate, 10, 64); err == nil {
+		reading.NextUpdate = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	return reading, nil
+}
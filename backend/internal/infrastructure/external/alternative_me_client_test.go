@@ -0,0 +1,72 @@
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlternativeMeClient_GetReadings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/fng/", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		w.Write([]byte(`{
+			"name": "Fear and Greed Index",
+			"data": [
+				{"value": "72", "value_classification": "Greed", "timestamp": "1717200000", "time_until_update": "3600"},
+				{"value": "65", "value_classification": "Greed", "timestamp": "1717113600"}
+			],
+			"metadata": {"error": null}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewAlternativeMeClient(server.URL, logger.New("test"))
+
+	readings, err := client.GetReadings(context.Background(), 2)
+
+	require.NoError(t, err)
+	require.Len(t, readings, 2)
+	assert.Equal(t, 72, readings[0].Value)
+	assert.Equal(t, "Greed", readings[0].Classification)
+	assert.Equal(t, time.Unix(1717200000, 0).UTC(), readings[0].Timestamp)
+	assert.False(t, readings[0].NextUpdate.IsZero())
+	assert.Nil(t, readings[0].Components, "Alternative.me publishes no component breakdown")
+	assert.Equal(t, 65, readings[1].Value)
+
+	source, _ := entities.NormalizeDataSource(client.Name())
+	assert.Equal(t, entities.DataSourceAlternativeMe, source, "the provider name is recorded as a canonical data source")
+}
+
+func TestAlternativeMeClient_GetReadings_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"HTTP error", http.StatusInternalServerError, `oops`},
+		{"API error", http.StatusOK, `{"data": [], "metadata": {"error": "limit too large"}}`},
+		{"Malformed value", http.StatusOK, `{"data": [{"value": "high", "timestamp": "1717200000"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewAlternativeMeClient(server.URL, logger.New("test")).GetReadings(context.Background(), 1)
+			assert.Error(t, err)
+		})
+	}
+}
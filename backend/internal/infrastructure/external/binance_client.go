@@ -0,0 +1,295 @@
+package external
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto-indicator-dashboard/pkg/logger"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// binanceDepthLimit is the number of order book levels requested per side
+const binanceDepthLimit = 100
+
+// BinanceClient handles Binance public REST API interactions. No API key is needed
+// for market data endpoints.
+type BinanceClient struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     logger.Logger
+}
+
+// BinanceBaseURL is the Binance REST API the client calls by default
+const BinanceBaseURL = "https://api.binance.com"
+
+// NewBinanceClient creates a new Binance API client
+func NewBinanceClient(logger logger.Logger) *BinanceClient {
+	return NewBinanceClientWithBaseURL(BinanceBaseURL, logger)
+}
+
+// NewBinanceClientWithBaseURL creates a new Binance API client with configurable base URL (for testing)
+func NewBinanceClientWithBaseURL(baseURL string, logger logger.Logger) *BinanceClient {
+	return &BinanceClient{
+		baseURL:    baseURL,
+		httpClient: NewHTTPClient(15 * time.Second),
+		logger:     logger,
+	}
+}
+
+// BinanceTickerPrice represents the response from the ticker price endpoint
+type BinanceTickerPrice struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+// Kline represents a single candlestick. Binance encodes each kline as a JSON array.
+type Kline struct {
+	OpenTime    time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64
+	CloseTime   time.Time
+	QuoteVolume float64
+	Trades      int64
+}
+
+// DepthLevel is a single price level in the order book
+type DepthLevel struct {
+	Price    float64
+	Quantity float64
+}
+
+// OrderBook represents the response from the depth endpoint, best prices first
+type OrderBook struct {
+	LastUpdateID int64
+	Bids         []DepthLevel
+	Asks         []DepthLevel
+}
+
+// binanceError is the error body returned by the Binance API
+type binanceError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// GetPrice retrieves the latest price for a trading pair such as BTCUSDT
+func (c *BinanceClient) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	params := url.Values{}
+	params.Set("symbol", strings.ToUpper(symbol))
+
+	data, err := c.makeRequest(ctx, "/api/v3/ticker/price", params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch price for %s: %w", symbol, err)
+	}
+
+	var ticker BinanceTickerPrice
+	if err := decodeJSON(data, &ticker); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal ticker price response: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(ticker.Price, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse price %q for %s: %w", ticker.Price, symbol, err)
+	}
+
+	return price, nil
+}
+
+// GetKlines retrieves up to limit candlesticks for a trading pair, oldest first.
+// interval uses Binance notation (1m, 1h, 4h, 1d, 1w, ...).
+func (c *BinanceClient) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error) {
+	params := url.Values{}
+	params.Set("symbol", strings.ToUpper(symbol))
+	params.Set("interval", interval)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	data, err := c.makeRequest(ctx, "/api/v3/klines", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch klines for %s: %w", symbol, err)
+	}
+
+	var klines []Kline
+	if err := decodeJSON(data, &klines); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal klines response: %w", err)
+	}
+
+	c.logger.WithContext(ctx).Debug("Successfully fetched klines",
+		"symbol", symbol,
+		"interval", interval,
+		"count", len(klines))
+	return klines, nil
+}
+
+// GetDepth retrieves the top order book levels for a trading pair
+func (c *BinanceClient) GetDepth(ctx context.Context, symbol string) (*OrderBook, error) {
+	params := url.Values{}
+	params.Set("symbol", strings.ToUpper(symbol))
+	params.Set("limit", strconv.Itoa(binanceDepthLimit))
+
+	data, err := c.makeRequest(ctx, "/api/v3/depth", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch depth for %s: %w", symbol, err)
+	}
+
+	var response struct {
+		LastUpdateID int64       `json:"lastUpdateId"`
+		Bids         [][2]string `json:"bids"`
+		Asks         [][2]string `json:"asks"`
+	}
+	if err := decodeJSON(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal depth response: %w", err)
+	}
+
+	bids, err := parseDepthLevels(response.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bid level: %w", err)
+	}
+	asks, err := parseDepthLevels(response.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ask level: %w", err)
+	}
+
+	return &OrderBook{
+		LastUpdateID: response.LastUpdateID,
+		Bids:         bids,
+		Asks:         asks,
+	}, nil
+}
+
+// HealthCheck pings the Binance API and returns how long it took
+func (c *BinanceClient) HealthCheck(ctx context.Context) (time.Duration, error) {
+	latency, err := timeHealthCheck(func() error {
+		_, err := c.makeRequest(ctx, "/api/v3/ping", nil)
+		return err
+	})
+	if err != nil {
+		return latency, fmt.Errorf("Binance health check failed: %w", err)
+	}
+	return latency, nil
+}
+
+// makeRequest makes an HTTP request to the Binance API
+func (c *BinanceClient) makeRequest(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	reqURL := c.baseURL + endpoint
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("User-Agent", "CryptoIndicatorDashboard/1.0")
+
+	c.logger.WithContext(ctx).Debug("Making Binance API request", "url", reqURL)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Handle gzip compression
+	var reader io.Reader = resp.Body
+	if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if err := checkJSONResponse(resp, body); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr binanceError
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Msg != "" {
+			return nil, fmt.Errorf("API request failed with status %d: %s (code %d)", resp.StatusCode, apiErr.Msg, apiErr.Code)
+		}
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, bodySnippet(body))
+	}
+
+	return body, nil
+}
+
+// UnmarshalJSON decodes a kline from Binance's positional array format
+func (k *Kline) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) < 9 {
+		return fmt.Errorf("kline has %d fields, expected at least 9", len(fields))
+	}
+
+	var openTime, closeTime int64
+	if err := json.Unmarshal(fields[0], &openTime); err != nil {
+		return fmt.Errorf("open time: %w", err)
+	}
+	if err := json.Unmarshal(fields[6], &closeTime); err != nil {
+		return fmt.Errorf("close time: %w", err)
+	}
+	if err := json.Unmarshal(fields[8], &k.Trades); err != nil {
+		return fmt.Errorf("trades: %w", err)
+	}
+	k.OpenTime = time.UnixMilli(openTime).UTC()
+	k.CloseTime = time.UnixMilli(closeTime).UTC()
+
+	decimals := []struct {
+		index int
+		dest  *float64
+	}{
+		{1, &k.Open}, {2, &k.High}, {3, &k.Low}, {4, &k.Close}, {5, &k.Volume}, {7, &k.QuoteVolume},
+	}
+	for _, d := range decimals {
+		var raw string
+		if err := json.Unmarshal(fields[d.index], &raw); err != nil {
+			return fmt.Errorf("field %d: %w", d.index, err)
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("field %d: %w", d.index, err)
+		}
+		*d.dest = value
+	}
+
+	return nil
+}
+
+// parseDepthLevels converts [price, quantity] string pairs into depth levels
+func parseDepthLevels(raw [][2]string) ([]DepthLevel, error) {
+	levels := make([]DepthLevel, 0, len(raw))
+	for _, pair := range raw {
+		price, err := strconv.ParseFloat(pair[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("price %q: %w", pair[0], err)
+		}
+		quantity, err := strconv.ParseFloat(pair[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("quantity %q: %w", pair[1], err)
+		}
+		levels = append(levels, DepthLevel{Price: price, Quantity: quantity})
+	}
+	return levels, nil
+}
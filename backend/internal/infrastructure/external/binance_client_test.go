@@ -0,0 +1,133 @@
+package external
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleKlines = `[
+	[1717200000000, "67450.01", "67800.00", "67300.50", "67712.34", "1234.567", 1717203599999, "83245678.90", 45678, "600.1", "40500000.0", "0"],
+	[1717203600000, "67712.34", "67900.00", "67650.00", "67801.10", "987.654", 1717207199999, "66912345.67", 34567, "500.2", "33900000.0", "0"]
+]`
+
+const sampleDepth = `{
+	"lastUpdateId": 1027024,
+	"bids": [["67711.99", "1.25000000"], ["67711.50", "0.40000000"]],
+	"asks": [["67712.01", "0.80000000"], ["67712.50", "2.10000000"]]
+}`
+
+// newBinanceTestServer serves canned Binance payloads, gzip-encoding them when asked
+func newBinanceTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/api/v3/ping":
+			body = `{}`
+		case "/api/v3/ticker/price":
+			if r.URL.Query().Get("symbol") != "BTCUSDT" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+				return
+			}
+			body = `{"symbol":"BTCUSDT","price":"67712.34000000"}`
+		case "/api/v3/klines":
+			assert.Equal(t, "1h", r.URL.Query().Get("interval"))
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			body = sampleKlines
+		case "/api/v3/depth":
+			body = sampleDepth
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Header.Get("Accept-Encoding") == "gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			defer gz.Close()
+			gz.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBinanceClient_GetPrice(t *testing.T) {
+	client := NewBinanceClientWithBaseURL(newBinanceTestServer(t).URL, logger.New("test"))
+
+	price, err := client.GetPrice(context.Background(), "btcusdt")
+	require.NoError(t, err)
+	assert.Equal(t, 67712.34, price)
+
+	_, err = client.GetPrice(context.Background(), "NOPEUSDT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid symbol.")
+	assert.Contains(t, err.Error(), "-1121")
+}
+
+func TestBinanceClient_GetKlines(t *testing.T) {
+	client := NewBinanceClientWithBaseURL(newBinanceTestServer(t).URL, logger.New("test"))
+
+	klines, err := client.GetKlines(context.Background(), "BTCUSDT", "1h", 2)
+
+	require.NoError(t, err)
+	require.Len(t, klines, 2)
+	assert.Equal(t, time.UnixMilli(1717200000000).UTC(), klines[0].OpenTime)
+	assert.Equal(t, time.UnixMilli(1717203599999).UTC(), klines[0].CloseTime)
+	assert.Equal(t, 67450.01, klines[0].Open)
+	assert.Equal(t, 67800.00, klines[0].High)
+	assert.Equal(t, 67300.50, klines[0].Low)
+	assert.Equal(t, 67712.34, klines[0].Close)
+	assert.Equal(t, 1234.567, klines[0].Volume)
+	assert.Equal(t, 83245678.90, klines[0].QuoteVolume)
+	assert.Equal(t, int64(45678), klines[0].Trades)
+	assert.Equal(t, 67801.10, klines[1].Close)
+}
+
+func TestBinanceClient_GetDepth(t *testing.T) {
+	client := NewBinanceClientWithBaseURL(newBinanceTestServer(t).URL, logger.New("test"))
+
+	book, err := client.GetDepth(context.Background(), "BTCUSDT")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1027024), book.LastUpdateID)
+	require.Len(t, book.Bids, 2)
+	require.Len(t, book.Asks, 2)
+	assert.Equal(t, DepthLevel{Price: 67711.99, Quantity: 1.25}, book.Bids[0])
+	assert.Equal(t, DepthLevel{Price: 67712.01, Quantity: 0.8}, book.Asks[0])
+}
+
+func TestBinanceClient_MalformedKline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[[1717200000000, "not-a-number", "1", "1", "1", "1", 1717203599999, "1", 1]]`))
+	}))
+	defer server.Close()
+
+	client := NewBinanceClientWithBaseURL(server.URL, logger.New("test"))
+
+	_, err := client.GetKlines(context.Background(), "BTCUSDT", "1h", 1)
+	assert.Error(t, err)
+}
+
+func TestBinanceClient_HealthCheck(t *testing.T) {
+	client := NewBinanceClientWithBaseURL(newBinanceTestServer(t).URL, logger.New("test"))
+	latency, err := client.HealthCheck(context.Background())
+	assert.NoError(t, err)
+	assert.Positive(t, latency)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.HealthCheck(ctx)
+	assert.Error(t, err, "a cancelled context aborts the request")
+}
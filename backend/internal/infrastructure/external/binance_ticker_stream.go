@@ -0,0 +1,155 @@
+package external
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Binance ticker stream defaults. Binance pushes a ticker every second, so a connection that
+// stays silent for binanceStreamReadTimeout is treated as dropped.
+const (
+	binanceStreamDialTimeout = 10 * time.Second
+	binanceStreamReadTimeout = 30 * time.Second
+	binanceStreamMinBackoff  = time.Second
+	binanceStreamMaxBackoff  = 30 * time.Second
+	binanceStreamOrigin      = "http://localhost/"
+)
+
+// BinanceTickerStream relays Binance's 24h rolling ticker websocket stream
+type BinanceTickerStream struct {
+	streamURL  string
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	logger     logger.Logger
+}
+
+// NewBinanceTickerStream creates a ticker stream reading from streamURL, the Binance
+// websocket base such as wss://stream.binance.com:9443/ws
+func NewBinanceTickerStream(streamURL string, logger logger.Logger) *BinanceTickerStream {
+	return &BinanceTickerStream{
+		streamURL:  strings.TrimSuffix(streamURL, "/"),
+		minBackoff: binanceStreamMinBackoff,
+		maxBackoff: binanceStreamMaxBackoff,
+		logger:     logger,
+	}
+}
+
+// binanceTickerEvent is a 24hrTicker stream event; Binance sends numbers as strings.
+// encoding/json falls back to case-insensitive key matching, so keys differing from a used
+// field only by case ("e", "p", "C", "L") need fields of their own to be decoded safely.
+type binanceTickerEvent struct {
+	EventType     string `json:"e"`
+	EventTime     int64  `json:"E"`
+	Symbol        string `json:"s"`
+	PriceChange   string `json:"p"`
+	ChangePercent string `json:"P"`
+	LastPrice     string `json:"c"`
+	CloseTime     int64  `json:"C"`
+	High          string `json:"h"`
+	Low           string `json:"l"`
+	LastTradeID   int64  `json:"L"`
+	Volume        string `json:"v"`
+}
+
+// toTicker parses the event's string fields
+func (e binanceTickerEvent) toTicker() (entities.PriceTicker, error) {
+	ticker := entities.PriceTicker{Symbol: e.Symbol, Timestamp: time.UnixMilli(e.EventTime)}
+	fields := []struct {
+		name  string
+		raw   string
+		value *float64
+	}{
+		{"c", e.LastPrice, &ticker.Price},
+		{"P", e.ChangePercent, &ticker.ChangePercent24h},
+		{"h", e.High, &ticker.High24h},
+		{"l", e.Low, &ticker.Low24h},
+		{"v", e.Volume, &ticker.Volume24h},
+	}
+	for _, field := range fields {
+		value, err := strconv.ParseFloat(field.raw, 64)
+		if err != nil {
+			return entities.PriceTicker{}, fmt.Errorf("invalid ticker field %s %q: %w", field.name, field.raw, err)
+		}
+		*field.value = value
+	}
+	return ticker, nil
+}
+
+// Stream sends ticker updates for symbol to out until ctx is cancelled. A dropped or silent
+// connection is re-dialled with exponential backoff, which resets once a connection
+// delivers a ticker.
+func (s *BinanceTickerStream) Stream(ctx context.Context, symbol string, out chan<- entities.PriceTicker) error {
+	log := s.logger.WithContext(ctx)
+	backoff := s.minBackoff
+	for {
+		delivered, err := s.streamOnce(ctx, symbol, out)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if delivered {
+			backoff = s.minBackoff
+		}
+
+		log.Warn("Binance ticker stream disconnected, reconnecting", "symbol", symbol, "error", err, "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// streamOnce reads one upstream connection until it fails or ctx ends, reporting whether
+// any ticker was delivered
+func (s *BinanceTickerStream) streamOnce(ctx context.Context, symbol string, out chan<- entities.PriceTicker) (bool, error) {
+	config, err := websocket.NewConfig(s.streamURL+"/"+strings.ToLower(symbol)+"@ticker", binanceStreamOrigin)
+	if err != nil {
+		return false, err
+	}
+	config.Dialer = &net.Dialer{Timeout: binanceStreamDialTimeout}
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// Closing the connection unblocks the pending read when the client goes away
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	delivered := false
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(binanceStreamReadTimeout)); err != nil {
+			return delivered, err
+		}
+
+		var event binanceTickerEvent
+		if err := websocket.JSON.Receive(conn, &event); err != nil {
+			return delivered, err
+		}
+		ticker, err := event.toTicker()
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("Skipping malformed Binance ticker", "symbol", symbol, "error", err)
+			continue
+		}
+
+		select {
+		case out <- ticker:
+			delivered = true
+		case <-ctx.Done():
+			return delivered, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package external
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+const sampleTickerEvent = `{
+	"e": "24hrTicker", "E": 1717200000000, "s": "BTCUSDT",
+	"p": "836.12", "P": "1.25", "w": "67350.10", "x": "66876.22",
+	"c": "67712.34", "Q": "0.015", "b": "67712.33", "B": "1.2", "a": "67712.34", "A": "0.8",
+	"o": "66876.22", "h": "67900.00", "l": "66800.50", "v": "12345.678", "q": "831462510.55",
+	"O": 1717113600000, "C": 1717199999999, "F": 3540000000, "L": 3541234567, "n": 1234568
+}`
+
+func TestBinanceTickerStream_ReconnectsAfterDrop(t *testing.T) {
+	var connections atomic.Int32
+	var lastPath atomic.Value
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		connections.Add(1)
+		lastPath.Store(ws.Request().URL.Path)
+		// A malformed ticker is skipped, then the connection drops after one good ticker
+		websocket.Message.Send(ws, `{"E":1717200000000,"s":"BTCUSDT","c":"not-a-number"}`)
+		websocket.Message.Send(ws, sampleTickerEvent)
+	}))
+	defer server.Close()
+
+	stream := NewBinanceTickerStream("ws"+strings.TrimPrefix(server.URL, "http")+"/ws/", logger.New("test"))
+	stream.minBackoff = 10 * time.Millisecond
+	stream.maxBackoff = 20 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out := make(chan entities.PriceTicker)
+	done := make(chan error, 1)
+	go func() { done <- stream.Stream(ctx, "BTCUSDT", out) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case ticker := <-out:
+			assert.Equal(t, "BTCUSDT", ticker.Symbol)
+			assert.Equal(t, 67712.34, ticker.Price)
+			assert.Equal(t, 1.25, ticker.ChangePercent24h)
+			assert.Equal(t, 67900.0, ticker.High24h)
+			assert.Equal(t, 66800.5, ticker.Low24h)
+			assert.Equal(t, 12345.678, ticker.Volume24h)
+			assert.Equal(t, int64(1717200000000), ticker.Timestamp.UnixMilli())
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for ticker")
+		}
+	}
+	assert.GreaterOrEqual(t, connections.Load(), int32(2))
+	assert.Equal(t, "/ws/btcusdt@ticker", lastPath.Load())
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
@@ -2,7 +2,7 @@ package external
 
 import (
 	"compress/gzip"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,13 +18,14 @@ type BlockchainClient struct {
 	logger     logger.Logger
 }
 
+// BlockchainBaseURL is the Blockchain.com API the client calls
+const BlockchainBaseURL = "https://blockchain.info"
+
 // NewBlockchainClient creates a new Blockchain.com API client
 func NewBlockchainClient(logger logger.Logger) *BlockchainClient {
 	return &BlockchainClient{
-		baseURL: "https://blockchain.info",
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL: BlockchainBaseURL,
+		httpClient: NewHTTPClient(30 * time.Second),
 		logger: logger,
 	}
 }
@@ -86,20 +87,20 @@ type PoolsData struct {
 }
 
 // GetBitcoinStats retrieves comprehensive Bitcoin network statistics
-func (bc *BlockchainClient) GetBitcoinStats() (*BitcoinStats, error) {
+func (bc *BlockchainClient) GetBitcoinStats(ctx context.Context) (*BitcoinStats, error) {
 	endpoint := "/stats?format=json"
 	
-	data, err := bc.makeRequest(endpoint)
+	data, err := bc.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch Bitcoin stats: %w", err)
 	}
 
 	var stats BitcoinStats
-	if err := json.Unmarshal(data, &stats); err != nil {
+	if err := decodeJSON(data, &stats); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal Bitcoin stats: %w", err)
 	}
 
-	bc.logger.Info("Successfully fetched Bitcoin stats", 
+	bc.logger.WithContext(ctx).Info("Successfully fetched Bitcoin stats", 
 		"price_usd", stats.MarketPriceUSD,
 		"hash_rate", stats.HashRate,
 		"difficulty", stats.Difficulty)
@@ -108,8 +109,8 @@ func (bc *BlockchainClient) GetBitcoinStats() (*BitcoinStats, error) {
 }
 
 // GetBitcoinPrice retrieves current Bitcoin price from Blockchain.com
-func (bc *BlockchainClient) GetBitcoinPrice() (float64, error) {
-	stats, err := bc.GetBitcoinStats()
+func (bc *BlockchainClient) GetBitcoinPrice(ctx context.Context) (float64, error) {
+	stats, err := bc.GetBitcoinStats(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get Bitcoin price: %w", err)
 	}
@@ -117,8 +118,8 @@ func (bc *BlockchainClient) GetBitcoinPrice() (float64, error) {
 }
 
 // GetHashRate retrieves current network hash rate
-func (bc *BlockchainClient) GetHashRate() (float64, error) {
-	stats, err := bc.GetBitcoinStats()
+func (bc *BlockchainClient) GetHashRate(ctx context.Context) (float64, error) {
+	stats, err := bc.GetBitcoinStats(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get hash rate: %w", err)
 	}
@@ -126,8 +127,8 @@ func (bc *BlockchainClient) GetHashRate() (float64, error) {
 }
 
 // GetDifficulty retrieves current mining difficulty
-func (bc *BlockchainClient) GetDifficulty() (float64, error) {
-	stats, err := bc.GetBitcoinStats()
+func (bc *BlockchainClient) GetDifficulty(ctx context.Context) (float64, error) {
+	stats, err := bc.GetBitcoinStats(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get difficulty: %w", err)
 	}
@@ -135,41 +136,41 @@ func (bc *BlockchainClient) GetDifficulty() (float64, error) {
 }
 
 // GetSingleStat retrieves a specific statistic
-func (bc *BlockchainClient) GetSingleStat(statName string) (*SingleStatValue, error) {
+func (bc *BlockchainClient) GetSingleStat(ctx context.Context, statName string) (*SingleStatValue, error) {
 	endpoint := fmt.Sprintf("/single/%s?format=json", statName)
 	
-	data, err := bc.makeRequest(endpoint)
+	data, err := bc.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch single stat %s: %w", statName, err)
 	}
 
 	var stat SingleStatValue
-	if err := json.Unmarshal(data, &stat); err != nil {
+	if err := decodeJSON(data, &stat); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal single stat: %w", err)
 	}
 
-	bc.logger.Info("Successfully fetched single stat", "stat", statName, "values_count", len(stat.Values))
+	bc.logger.WithContext(ctx).Info("Successfully fetched single stat", "stat", statName, "values_count", len(stat.Values))
 	return &stat, nil
 }
 
 // GetChartData retrieves historical chart data for specific metrics
-func (bc *BlockchainClient) GetChartData(chartType string, timespan *string) (*ChartData, error) {
+func (bc *BlockchainClient) GetChartData(ctx context.Context, chartType string, timespan *string) (*ChartData, error) {
 	endpoint := fmt.Sprintf("/charts/%s?format=json", chartType)
 	if timespan != nil {
 		endpoint += fmt.Sprintf("&timespan=%s", *timespan)
 	}
 	
-	data, err := bc.makeRequest(endpoint)
+	data, err := bc.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch chart data for %s: %w", chartType, err)
 	}
 
 	var chartData ChartData
-	if err := json.Unmarshal(data, &chartData); err != nil {
+	if err := decodeJSON(data, &chartData); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal chart data: %w", err)
 	}
 
-	bc.logger.Info("Successfully fetched chart data", 
+	bc.logger.WithContext(ctx).Info("Successfully fetched chart data", 
 		"chart_type", chartType, 
 		"values_count", len(chartData.Values))
 
@@ -177,36 +178,36 @@ func (bc *BlockchainClient) GetChartData(chartType string, timespan *string) (*C
 }
 
 // GetHashRateHistory retrieves historical hash rate data
-func (bc *BlockchainClient) GetHashRateHistory(timespan string) (*ChartData, error) {
-	return bc.GetChartData("hash-rate", &timespan)
+func (bc *BlockchainClient) GetHashRateHistory(ctx context.Context, timespan string) (*ChartData, error) {
+	return bc.GetChartData(ctx, "hash-rate", &timespan)
 }
 
 // GetDifficultyHistory retrieves historical difficulty data
-func (bc *BlockchainClient) GetDifficultyHistory(timespan string) (*ChartData, error) {
-	return bc.GetChartData("difficulty", &timespan)
+func (bc *BlockchainClient) GetDifficultyHistory(ctx context.Context, timespan string) (*ChartData, error) {
+	return bc.GetChartData(ctx, "difficulty", &timespan)
 }
 
 // GetTransactionCountHistory retrieves historical transaction count
-func (bc *BlockchainClient) GetTransactionCountHistory(timespan string) (*ChartData, error) {
-	return bc.GetChartData("n-transactions", &timespan)
+func (bc *BlockchainClient) GetTransactionCountHistory(ctx context.Context, timespan string) (*ChartData, error) {
+	return bc.GetChartData(ctx, "n-transactions", &timespan)
 }
 
 // GetBlockSizeHistory retrieves historical average block size
-func (bc *BlockchainClient) GetBlockSizeHistory(timespan string) (*ChartData, error) {
-	return bc.GetChartData("avg-block-size", &timespan)
+func (bc *BlockchainClient) GetBlockSizeHistory(ctx context.Context, timespan string) (*ChartData, error) {
+	return bc.GetChartData(ctx, "avg-block-size", &timespan)
 }
 
 // GetMempoolSize retrieves current mempool transaction count
-func (bc *BlockchainClient) GetMempoolSize() (int64, error) {
+func (bc *BlockchainClient) GetMempoolSize(ctx context.Context) (int64, error) {
 	endpoint := "/q/unconfirmedcount"
 	
-	data, err := bc.makeRequest(endpoint)
+	data, err := bc.makeRequest(ctx, endpoint)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch mempool size: %w", err)
 	}
 
 	var count int64
-	if err := json.Unmarshal(data, &count); err != nil {
+	if err := decodeJSON(data, &count); err != nil {
 		return 0, fmt.Errorf("failed to unmarshal mempool size: %w", err)
 	}
 
@@ -214,16 +215,16 @@ func (bc *BlockchainClient) GetMempoolSize() (int64, error) {
 }
 
 // GetLatestBlockHeight retrieves the latest block height
-func (bc *BlockchainClient) GetLatestBlockHeight() (int64, error) {
+func (bc *BlockchainClient) GetLatestBlockHeight(ctx context.Context) (int64, error) {
 	endpoint := "/q/getblockcount"
 	
-	data, err := bc.makeRequest(endpoint)
+	data, err := bc.makeRequest(ctx, endpoint)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch block height: %w", err)
 	}
 
 	var height int64
-	if err := json.Unmarshal(data, &height); err != nil {
+	if err := decodeJSON(data, &height); err != nil {
 		return 0, fmt.Errorf("failed to unmarshal block height: %w", err)
 	}
 
@@ -231,16 +232,16 @@ func (bc *BlockchainClient) GetLatestBlockHeight() (int64, error) {
 }
 
 // GetTotalBitcoinsInCirculation retrieves total bitcoins in circulation
-func (bc *BlockchainClient) GetTotalBitcoinsInCirculation() (float64, error) {
+func (bc *BlockchainClient) GetTotalBitcoinsInCirculation(ctx context.Context) (float64, error) {
 	endpoint := "/q/totalbc"
 	
-	data, err := bc.makeRequest(endpoint)
+	data, err := bc.makeRequest(ctx, endpoint)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch total bitcoins: %w", err)
 	}
 
 	var total float64
-	if err := json.Unmarshal(data, &total); err != nil {
+	if err := decodeJSON(data, &total); err != nil {
 		return 0, fmt.Errorf("failed to unmarshal total bitcoins: %w", err)
 	}
 
@@ -249,33 +250,33 @@ func (bc *BlockchainClient) GetTotalBitcoinsInCirculation() (float64, error) {
 }
 
 // GetMiningPoolDistribution retrieves mining pool distribution
-func (bc *BlockchainClient) GetMiningPoolDistribution() (*PoolsData, error) {
+func (bc *BlockchainClient) GetMiningPoolDistribution(ctx context.Context) (*PoolsData, error) {
 	endpoint := "/pools?format=json"
 	
-	data, err := bc.makeRequest(endpoint)
+	data, err := bc.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch mining pools: %w", err)
 	}
 
 	var pools PoolsData
-	if err := json.Unmarshal(data, &pools); err != nil {
+	if err := decodeJSON(data, &pools); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal mining pools: %w", err)
 	}
 
-	bc.logger.Info("Successfully fetched mining pool distribution", "pools_count", len(pools.Pools))
+	bc.logger.WithContext(ctx).Info("Successfully fetched mining pool distribution", "pools_count", len(pools.Pools))
 	return &pools, nil
 }
 
 // GetNetworkSummary provides a comprehensive network summary
-func (bc *BlockchainClient) GetNetworkSummary() (map[string]interface{}, error) {
-	stats, err := bc.GetBitcoinStats()
+func (bc *BlockchainClient) GetNetworkSummary(ctx context.Context) (map[string]interface{}, error) {
+	stats, err := bc.GetBitcoinStats(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get network summary: %w", err)
 	}
 
-	blockHeight, _ := bc.GetLatestBlockHeight()
-	mempoolSize, _ := bc.GetMempoolSize()
-	totalBTC, _ := bc.GetTotalBitcoinsInCirculation()
+	blockHeight, _ := bc.GetLatestBlockHeight(ctx)
+	mempoolSize, _ := bc.GetMempoolSize(ctx)
+	totalBTC, _ := bc.GetTotalBitcoinsInCirculation(ctx)
 
 	summary := map[string]interface{}{
 		"price_usd":             stats.MarketPriceUSD,
@@ -296,10 +297,10 @@ func (bc *BlockchainClient) GetNetworkSummary() (map[string]interface{}, error)
 }
 
 // makeRequest makes an HTTP request to the Blockchain.com API
-func (bc *BlockchainClient) makeRequest(endpoint string) ([]byte, error) {
+func (bc *BlockchainClient) makeRequest(ctx context.Context, endpoint string) ([]byte, error) {
 	reqURL := bc.baseURL + endpoint
 
-	req, err := http.NewRequest("GET", reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -309,7 +310,7 @@ func (bc *BlockchainClient) makeRequest(endpoint string) ([]byte, error) {
 	req.Header.Set("Accept-Encoding", "gzip, deflate")
 	req.Header.Set("User-Agent", "CryptoIndicatorDashboard/1.0")
 
-	bc.logger.Debug("Making Blockchain.com API request", 
+	bc.logger.WithContext(ctx).Debug("Making Blockchain.com API request", 
 		"url", reqURL,
 		"endpoint", endpoint)
 
@@ -335,22 +336,28 @@ func (bc *BlockchainClient) makeRequest(endpoint string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if err := checkJSONResponse(resp, body); err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		bc.logger.Error("Blockchain.com API request failed", 
+		bc.logger.WithContext(ctx).Error("Blockchain.com API request failed", 
 			"status_code", resp.StatusCode,
 			"response", string(body))
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, bodySnippet(body))
 	}
 
 	return body, nil
 }
 
-// HealthCheck performs a health check on the Blockchain.com service
-func (bc *BlockchainClient) HealthCheck() error {
-	// Try to fetch Bitcoin price as a simple health check
-	_, err := bc.GetBitcoinPrice()
+// HealthCheck fetches the Bitcoin price from Blockchain.com and returns how long it took
+func (bc *BlockchainClient) HealthCheck(ctx context.Context) (time.Duration, error) {
+	latency, err := timeHealthCheck(func() error {
+		_, err := bc.GetBitcoinPrice(ctx)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("Blockchain.com health check failed: %w", err)
+		return latency, fmt.Errorf("Blockchain.com health check failed: %w", err)
 	}
-	return nil
+	return latency, nil
 }
\ No newline at end of file
@@ -2,9 +2,10 @@ package external
 
 import (
 	"compress/gzip"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"strings"
 	"time"
@@ -19,14 +20,15 @@ type CoinCapClient struct {
 	logger     logger.Logger
 }
 
+// CoinCapBaseURL is the CoinCap REST API the client calls
+const CoinCapBaseURL = "https://rest.coincap.io/v3"
+
 // NewCoinCapClient creates a new CoinCap API client
 func NewCoinCapClient(apiKey string, logger logger.Logger) *CoinCapClient {
 	return &CoinCapClient{
 		apiKey:  apiKey,
-		baseURL: "https://rest.coincap.io/v3",
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL: CoinCapBaseURL,
+		httpClient: NewHTTPClient(30 * time.Second),
 		logger: logger,
 	}
 }
@@ -94,46 +96,46 @@ type MarketsResponse struct {
 }
 
 // GetAssets retrieves list of all assets
-func (c *CoinCapClient) GetAssets(limit int) (*AssetsResponse, error) {
+func (c *CoinCapClient) GetAssets(ctx context.Context, limit int) (*AssetsResponse, error) {
 	endpoint := "/assets"
 	if limit > 0 {
 		endpoint += fmt.Sprintf("?limit=%d", limit)
 	}
 	
-	data, err := c.makeRequest(endpoint)
+	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch assets: %w", err)
 	}
 
 	var response AssetsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := decodeJSON(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal assets response: %w", err)
 	}
 
-	c.logger.Info("Successfully fetched assets", "count", len(response.Data))
+	c.logger.WithContext(ctx).Info("Successfully fetched assets", "count", len(response.Data))
 	return &response, nil
 }
 
 // GetAsset retrieves a specific asset by ID
-func (c *CoinCapClient) GetAsset(assetID string) (*AssetResponse, error) {
+func (c *CoinCapClient) GetAsset(ctx context.Context, assetID string) (*AssetResponse, error) {
 	endpoint := fmt.Sprintf("/assets/%s", assetID)
 	
-	data, err := c.makeRequest(endpoint)
+	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch asset %s: %w", assetID, err)
 	}
 
 	var response AssetResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := decodeJSON(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal asset response: %w", err)
 	}
 
-	c.logger.Info("Successfully fetched asset", "asset_id", assetID, "price", response.Data.PriceUSD)
+	c.logger.WithContext(ctx).Info("Successfully fetched asset", "asset_id", assetID, "price", response.Data.PriceUSD)
 	return &response, nil
 }
 
 // GetAssetHistory retrieves historical price data for an asset
-func (c *CoinCapClient) GetAssetHistory(assetID, interval string, start, end *time.Time) (*HistoryResponse, error) {
+func (c *CoinCapClient) GetAssetHistory(ctx context.Context, assetID, interval string, start, end *time.Time) (*HistoryResponse, error) {
 	endpoint := fmt.Sprintf("/assets/%s/history", assetID)
 	
 	// Add query parameters
@@ -152,17 +154,17 @@ func (c *CoinCapClient) GetAssetHistory(assetID, interval string, start, end *ti
 		endpoint += "?" + strings.Join(params, "&")
 	}
 	
-	data, err := c.makeRequest(endpoint)
+	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch asset history for %s: %w", assetID, err)
 	}
 
 	var response HistoryResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := decodeJSON(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal history response: %w", err)
 	}
 
-	c.logger.Info("Successfully fetched asset history", 
+	c.logger.WithContext(ctx).Info("Successfully fetched asset history", 
 		"asset_id", assetID, 
 		"interval", interval,
 		"data_points", len(response.Data))
@@ -171,7 +173,7 @@ func (c *CoinCapClient) GetAssetHistory(assetID, interval string, start, end *ti
 }
 
 // GetMarkets retrieves market data for an asset
-func (c *CoinCapClient) GetMarkets(assetID string, limit int) (*MarketsResponse, error) {
+func (c *CoinCapClient) GetMarkets(ctx context.Context, assetID string, limit int) (*MarketsResponse, error) {
 	endpoint := "/markets"
 	params := []string{}
 	
@@ -186,53 +188,54 @@ func (c *CoinCapClient) GetMarkets(assetID string, limit int) (*MarketsResponse,
 		endpoint += "?" + strings.Join(params, "&")
 	}
 	
-	data, err := c.makeRequest(endpoint)
+	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch markets: %w", err)
 	}
 
 	var response MarketsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := decodeJSON(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal markets response: %w", err)
 	}
 
-	c.logger.Info("Successfully fetched markets", "count", len(response.Data))
+	c.logger.WithContext(ctx).Info("Successfully fetched markets", "count", len(response.Data))
 	return &response, nil
 }
 
 // GetBitcoinPrice retrieves current Bitcoin price
-func (c *CoinCapClient) GetBitcoinPrice() (float64, error) {
-	response, err := c.GetAsset("bitcoin")
+func (c *CoinCapClient) GetBitcoinPrice(ctx context.Context) (float64, error) {
+	response, err := c.GetAsset(ctx, "bitcoin")
 	if err != nil {
 		return 0, fmt.Errorf("failed to get Bitcoin price: %w", err)
 	}
 
-	var price float64
-	if _, err := fmt.Sscanf(response.Data.PriceUSD, "%f", &price); err != nil {
-		return 0, fmt.Errorf("failed to parse Bitcoin price: %w", err)
+	price, ok := parseDecimal(response.Data.PriceUSD)
+	if !ok {
+		return 0, fmt.Errorf("failed to parse Bitcoin price %q", response.Data.PriceUSD)
 	}
 
-	return price, nil
+	value, _ := price.Float64()
+	return value, nil
 }
 
 // GetTop10Assets retrieves top 10 assets by market cap
-func (c *CoinCapClient) GetTop10Assets() (*AssetsResponse, error) {
-	return c.GetAssets(10)
+func (c *CoinCapClient) GetTop10Assets(ctx context.Context) (*AssetsResponse, error) {
+	return c.GetAssets(ctx, 10)
 }
 
 // GetBitcoinHistoricalData retrieves Bitcoin historical data for a specific period
-func (c *CoinCapClient) GetBitcoinHistoricalData(interval string, days int) (*HistoryResponse, error) {
+func (c *CoinCapClient) GetBitcoinHistoricalData(ctx context.Context, interval string, days int) (*HistoryResponse, error) {
 	end := time.Now()
 	start := end.AddDate(0, 0, -days)
 	
-	return c.GetAssetHistory("bitcoin", interval, &start, &end)
+	return c.GetAssetHistory(ctx, "bitcoin", interval, &start, &end)
 }
 
 // makeRequest makes an HTTP request to the CoinCap API
-func (c *CoinCapClient) makeRequest(endpoint string) ([]byte, error) {
+func (c *CoinCapClient) makeRequest(ctx context.Context, endpoint string) ([]byte, error) {
 	reqURL := c.baseURL + endpoint
 
-	req, err := http.NewRequest("GET", reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -247,7 +250,7 @@ func (c *CoinCapClient) makeRequest(endpoint string) ([]byte, error) {
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	}
 
-	c.logger.Debug("Making CoinCap API request", 
+	c.logger.WithContext(ctx).Debug("Making CoinCap API request", 
 		"url", reqURL,
 		"endpoint", endpoint)
 
@@ -273,54 +276,44 @@ func (c *CoinCapClient) makeRequest(endpoint string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if err := checkJSONResponse(resp, body); err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		c.logger.Error("CoinCap API request failed", 
+		c.logger.WithContext(ctx).Error("CoinCap API request failed", 
 			"status_code", resp.StatusCode,
 			"response", string(body))
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, bodySnippet(body))
 	}
 
 	return body, nil
 }
 
-// HealthCheck performs a health check on the CoinCap service
-func (c *CoinCapClient) HealthCheck() error {
-	// Try to fetch Bitcoin price as a simple health check
-	_, err := c.GetBitcoinPrice()
+// HealthCheck fetches the Bitcoin price from CoinCap and returns how long it took
+func (c *CoinCapClient) HealthCheck(ctx context.Context) (time.Duration, error) {
+	latency, err := timeHealthCheck(func() error {
+		_, err := c.GetBitcoinPrice(ctx)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("CoinCap health check failed: %w", err)
+		return latency, fmt.Errorf("CoinCap health check failed: %w", err)
 	}
-	return nil
+	return latency, nil
 }
 
 // GetGlobalMarketData provides global market statistics
-func (c *CoinCapClient) GetGlobalMarketData() (map[string]interface{}, error) {
+func (c *CoinCapClient) GetGlobalMarketData(ctx context.Context) (map[string]interface{}, error) {
 	// Get top 10 assets to calculate global stats
-	response, err := c.GetTop10Assets()
+	response, err := c.GetTop10Assets(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get global market data: %w", err)
 	}
 
-	var totalMarketCap, totalVolume float64
-	var btcDominance float64
-
-	for _, asset := range response.Data {
-		if marketCap := parseFloat(asset.MarketCapUSD); marketCap > 0 {
-			totalMarketCap += marketCap
-			if asset.Symbol == "BTC" {
-				btcDominance = marketCap
-			}
-		}
-		if volume := parseFloat(asset.VolumeUSD24Hr); volume > 0 {
-			totalVolume += volume
-		}
-	}
-
-	// Calculate BTC dominance percentage
-	btcDominancePercent := 0.0
-	if totalMarketCap > 0 {
-		btcDominancePercent = (btcDominance / totalMarketCap) * 100
-	}
+	totals := sumGlobalMarketData(response.Data)
+	totalMarketCap, _ := totals.MarketCap.Float64()
+	totalVolume, _ := totals.Volume24h.Float64()
+	btcDominancePercent, _ := totals.BTCDominance().Float64()
 
 	return map[string]interface{}{
 		"total_market_cap":    totalMarketCap,
@@ -331,9 +324,50 @@ func (c *CoinCapClient) GetGlobalMarketData() (map[string]interface{}, error) {
 	}, nil
 }
 
-// parseFloat safely parses a string to float64
-func parseFloat(s string) float64 {
-	var f float64
-	fmt.Sscanf(s, "%f", &f)
-	return f
-}
\ No newline at end of file
+// globalMarketTotals are market-wide sums kept as exact decimals, so adding market caps in
+// the trillions doesn't lose the smaller ones to float64 rounding
+type globalMarketTotals struct {
+	MarketCap    *big.Rat
+	Volume24h    *big.Rat
+	BTCMarketCap *big.Rat
+}
+
+// sumGlobalMarketData totals the positive market caps and volumes of assets, skipping
+// values that don't parse
+func sumGlobalMarketData(assets []Asset) globalMarketTotals {
+	totals := globalMarketTotals{
+		MarketCap:    new(big.Rat),
+		Volume24h:    new(big.Rat),
+		BTCMarketCap: new(big.Rat),
+	}
+
+	for _, asset := range assets {
+		if marketCap, ok := parseDecimal(asset.MarketCapUSD); ok && marketCap.Sign() > 0 {
+			totals.MarketCap.Add(totals.MarketCap, marketCap)
+			if asset.Symbol == "BTC" {
+				totals.BTCMarketCap.Set(marketCap)
+			}
+		}
+		if volume, ok := parseDecimal(asset.VolumeUSD24Hr); ok && volume.Sign() > 0 {
+			totals.Volume24h.Add(totals.Volume24h, volume)
+		}
+	}
+	return totals
+}
+
+// BTCDominance is BTC's share of the total market cap as a percentage, 0 when there is none
+func (t globalMarketTotals) BTCDominance() *big.Rat {
+	if t.MarketCap.Sign() == 0 {
+		return new(big.Rat)
+	}
+	share := new(big.Rat).Quo(t.BTCMarketCap, t.MarketCap)
+	return share.Mul(share, big.NewRat(100, 1))
+}
+
+// parseDecimal parses a decimal string such as CoinCap's "67712.3400000000" exactly
+func parseDecimal(s string) (*big.Rat, bool) {
+	if s == "" {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
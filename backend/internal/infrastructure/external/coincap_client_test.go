@@ -0,0 +1,123 @@
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// floatSum adds values the way GetGlobalMarketData used to, parsing each to float64
+func floatSum(values []string) float64 {
+	var total float64
+	for _, v := range values {
+		f, _ := strconv.ParseFloat(v, 64)
+		total += f
+	}
+	return total
+}
+
+func TestSumGlobalMarketData_Precision(t *testing.T) {
+	t.Run("Small caps are not lost next to a large one", func(t *testing.T) {
+		// 2^53: beyond it float64 can no longer represent every integer
+		caps := []string{"9007199254740992", "1", "1", "1", "1"}
+		assets := make([]Asset, len(caps))
+		for i, c := range caps {
+			assets[i] = Asset{Symbol: "A" + strconv.Itoa(i), MarketCapUSD: c}
+		}
+
+		totals := sumGlobalMarketData(assets)
+
+		assert.Equal(t, "9007199254740996", totals.MarketCap.RatString())
+		exact, _ := totals.MarketCap.Float64()
+		assert.Equal(t, 9007199254740996.0, exact)
+		assert.Equal(t, 9007199254740992.0, floatSum(caps), "float addition drops every 1")
+	})
+
+	t.Run("Fractional volumes add up exactly", func(t *testing.T) {
+		volumes := make([]string, 10)
+		assets := make([]Asset, len(volumes))
+		for i := range volumes {
+			volumes[i] = "0.1"
+			assets[i] = Asset{VolumeUSD24Hr: volumes[i]}
+		}
+
+		totals := sumGlobalMarketData(assets)
+
+		assert.Equal(t, "1", totals.Volume24h.RatString())
+		assert.NotEqual(t, 1.0, floatSum(volumes), "0.1 has no exact float64 form")
+	})
+
+	t.Run("Dominance of large caps", func(t *testing.T) {
+		assets := []Asset{
+			{Symbol: "BTC", MarketCapUSD: "1333333333333.333333333333"},
+			{Symbol: "ETH", MarketCapUSD: "1333333333333.333333333333"},
+			{Symbol: "USDT", MarketCapUSD: "1333333333333.333333333334"},
+			{Symbol: "BAD", MarketCapUSD: "not a number", VolumeUSD24Hr: ""},
+		}
+
+		totals := sumGlobalMarketData(assets)
+
+		assert.Equal(t, "4000000000000", totals.MarketCap.RatString())
+		assert.Equal(t, "33.333333333333", totals.BTCDominance().FloatString(12))
+	})
+
+	t.Run("No market cap means no dominance", func(t *testing.T) {
+		totals := sumGlobalMarketData(nil)
+		assert.Zero(t, totals.BTCDominance().Sign())
+	})
+}
+
+func TestCoinCapClient_DecimalPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/assets/bitcoin":
+			w.Write([]byte(`{"data":{"id":"bitcoin","symbol":"BTC","priceUsd":"67712.3400000000"}}`))
+		case "/assets":
+			w.Write([]byte(`{"data":[
+				{"symbol":"BTC","marketCapUsd":"1340000000000.12","volumeUsd24Hr":"0.1"},
+				{"symbol":"ETH","marketCapUsd":"420000000000.33","volumeUsd24Hr":"0.2"},
+				{"symbol":"USDT","marketCapUsd":"110000000000.55","volumeUsd24Hr":"bogus"}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &CoinCapClient{baseURL: server.URL, httpClient: NewHTTPClient(5 * time.Second), logger: logger.New("test")}
+
+	price, err := client.GetBitcoinPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 67712.34, price)
+
+	global, err := client.GetGlobalMarketData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1870000000001.0, global["total_market_cap"])
+	assert.Equal(t, 0.3, global["total_volume_24h"], "0.1 + 0.2 without float rounding")
+	assert.InDelta(t, 71.657754, global["btc_dominance"].(float64), 1e-6)
+	assert.Equal(t, 3, global["active_cryptocurrencies"])
+}
+
+func TestCoinCapClient_UsesRequestContext(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := &CoinCapClient{baseURL: server.URL, httpClient: NewHTTPClient(5 * time.Second), logger: logger.New("test")}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetBitcoinPrice(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, requests)
+}
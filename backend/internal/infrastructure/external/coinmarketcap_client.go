@@ -2,16 +2,28 @@ package external
 
 import (
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
+	"crypto-indicator-dashboard/pkg/errors"
 	"crypto-indicator-dashboard/pkg/logger"
 )
 
+// CoinMarketCapBaseURL is the CoinMarketCap Pro API the client calls by default
+const CoinMarketCapBaseURL = "https://pro-api.coinmarketcap.com/v1"
+
+// CoinMarketCap status error codes for exceeded minute, daily, monthly and IP rate limits
+const (
+	cmcErrorCodeRateLimitMinute = 1008
+	cmcErrorCodeRateLimitIP     = 1011
+)
+
 // CoinMarketCapClient handles CoinMarketCap API interactions
 type CoinMarketCapClient struct {
 	apiKey     string
@@ -22,12 +34,15 @@ type CoinMarketCapClient struct {
 
 // NewCoinMarketCapClient creates a new CoinMarketCap API client
 func NewCoinMarketCapClient(apiKey string, logger logger.Logger) *CoinMarketCapClient {
+	return NewCoinMarketCapClientWithBaseURL(apiKey, CoinMarketCapBaseURL, logger)
+}
+
+// NewCoinMarketCapClientWithBaseURL creates a new CoinMarketCap API client with a custom base URL (for testing)
+func NewCoinMarketCapClientWithBaseURL(apiKey, baseURL string, logger logger.Logger) *CoinMarketCapClient {
 	return &CoinMarketCapClient{
 		apiKey:  apiKey,
-		baseURL: "https://pro-api.coinmarketcap.com/v1",
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL: baseURL,
+		httpClient: NewHTTPClient(30 * time.Second),
 		logger: logger,
 	}
 }
@@ -49,6 +64,7 @@ type Quote struct {
 	PercentChange24h float64   `json:"percent_change_24h"`
 	PercentChange7d  float64   `json:"percent_change_7d"`
 	PercentChange30d float64   `json:"percent_change_30d"`
+	PercentChange90d *float64  `json:"percent_change_90d"` // nil when the coin has no 90d history
 	MarketCap        float64   `json:"market_cap"`
 	MarketCapDominance float64 `json:"market_cap_dominance"`
 	FullyDilutedMarketCap float64 `json:"fully_diluted_market_cap"`
@@ -61,6 +77,7 @@ type CryptoPriceData struct {
 	Name              string                 `json:"name"`
 	Symbol            string                 `json:"symbol"`
 	Slug              string                 `json:"slug"`
+	CMCRank           int                    `json:"cmc_rank"`
 	NumMarketPairs    int                    `json:"num_market_pairs"`
 	DateAdded         time.Time              `json:"date_added"`
 	Tags              []string               `json:"tags"`
@@ -84,6 +101,19 @@ type LatestQuotesResponse struct {
 	Data map[string]CryptoPriceData `json:"data"`
 }
 
+// ListingsLatestResponse represents the response from the latest listings endpoint
+type ListingsLatestResponse struct {
+	Status struct {
+		Timestamp    time.Time `json:"timestamp"`
+		ErrorCode    int       `json:"error_code"`
+		ErrorMessage *string   `json:"error_message"`
+		Elapsed      int       `json:"elapsed"`
+		CreditCount  int       `json:"credit_count"`
+		Notice       *string   `json:"notice"`
+	} `json:"status"`
+	Data []CryptoPriceData `json:"data"`
+}
+
 // GlobalMetricsData represents global cryptocurrency market data
 type GlobalMetricsData struct {
 	ActiveCryptocurrencies int `json:"active_cryptocurrencies"`
@@ -109,10 +139,21 @@ type GlobalMetricsData struct {
 	DerivativesVolume24h  float64 `json:"derivatives_volume_24h"`
 	DerivativesVolume24hReported float64 `json:"derivatives_volume_24h_reported"`
 	DerivativesVolume24hPercentageChange float64 `json:"derivatives_volume_24h_percentage_change"`
-	Quote                 map[string]Quote `json:"quote"`
+	Quote                 map[string]GlobalMetricsQuote `json:"quote"`
 	LastUpdated           time.Time `json:"last_updated"`
 }
 
+// GlobalMetricsQuote represents market-wide totals in one currency
+type GlobalMetricsQuote struct {
+	TotalMarketCap                           float64   `json:"total_market_cap"`
+	TotalVolume24h                           float64   `json:"total_volume_24h"`
+	TotalMarketCapYesterdayPercentageChange  float64   `json:"total_market_cap_yesterday_percentage_change"`
+	TotalVolume24hYesterdayPercentageChange  float64   `json:"total_volume_24h_yesterday_percentage_change"`
+	AltcoinMarketCap                         float64   `json:"altcoin_market_cap"`
+	AltcoinVolume24h                         float64   `json:"altcoin_volume_24h"`
+	LastUpdated                              time.Time `json:"last_updated"`
+}
+
 // GlobalMetricsResponse represents the response from global metrics endpoint
 type GlobalMetricsResponse struct {
 	Status struct {
@@ -127,7 +168,7 @@ type GlobalMetricsResponse struct {
 }
 
 // GetLatestQuotes retrieves latest price quotes for specified cryptocurrencies
-func (c *CoinMarketCapClient) GetLatestQuotes(symbols []string, convert string) (*LatestQuotesResponse, error) {
+func (c *CoinMarketCapClient) GetLatestQuotes(ctx context.Context, symbols []string, convert string) (*LatestQuotesResponse, error) {
 	if convert == "" {
 		convert = "USD"
 	}
@@ -145,13 +186,13 @@ func (c *CoinMarketCapClient) GetLatestQuotes(symbols []string, convert string)
 	params.Set("convert", convert)
 
 	endpoint := "/cryptocurrency/quotes/latest"
-	data, err := c.makeRequest(endpoint, params)
+	data, err := c.makeRequest(ctx, endpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch latest quotes: %w", err)
 	}
 
 	var response LatestQuotesResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := decodeJSON(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal latest quotes response: %w", err)
 	}
 
@@ -163,7 +204,7 @@ func (c *CoinMarketCapClient) GetLatestQuotes(symbols []string, convert string)
 		return nil, fmt.Errorf("CoinMarketCap API error: %s (code: %d)", errorMsg, response.Status.ErrorCode)
 	}
 
-	c.logger.Info("Successfully fetched latest quotes", 
+	c.logger.WithContext(ctx).Info("Successfully fetched latest quotes", 
 		"symbols", symbols, 
 		"convert", convert,
 		"credit_count", response.Status.CreditCount)
@@ -171,8 +212,155 @@ func (c *CoinMarketCapClient) GetLatestQuotes(symbols []string, convert string)
 	return &response, nil
 }
 
+// GetListingsLatest retrieves the top cryptocurrencies by market cap, ordered by rank
+func (c *CoinMarketCapClient) GetListingsLatest(ctx context.Context, limit int, convert string) (*ListingsLatestResponse, error) {
+	if convert == "" {
+		convert = "USD"
+	}
+
+	params := url.Values{}
+	params.Set("start", "1")
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("sort", "market_cap")
+	params.Set("convert", convert)
+
+	endpoint := "/cryptocurrency/listings/latest"
+	data, err := c.makeRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest listings: %w", err)
+	}
+
+	var response ListingsLatestResponse
+	if err := decodeJSON(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal latest listings response: %w", err)
+	}
+
+	if response.Status.ErrorCode != 0 {
+		errorMsg := "unknown error"
+		if response.Status.ErrorMessage != nil {
+			errorMsg = *response.Status.ErrorMessage
+		}
+		return nil, fmt.Errorf("CoinMarketCap API error: %s (code: %d)", errorMsg, response.Status.ErrorCode)
+	}
+
+	c.logger.WithContext(ctx).Info("Successfully fetched latest listings",
+		"limit", limit,
+		"count", len(response.Data),
+		"credit_count", response.Status.CreditCount)
+
+	return &response, nil
+}
+
+// CryptocurrencyMapEntry represents a cryptocurrency from the ID map endpoint
+type CryptocurrencyMapEntry struct {
+	ID       int    `json:"id"`
+	Rank     int    `json:"rank"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Slug     string `json:"slug"`
+	IsActive int    `json:"is_active"`
+}
+
+// CryptocurrencyMapResponse represents the response from the ID map endpoint
+type CryptocurrencyMapResponse struct {
+	Status struct {
+		Timestamp    time.Time `json:"timestamp"`
+		ErrorCode    int       `json:"error_code"`
+		ErrorMessage *string   `json:"error_message"`
+		Elapsed      int       `json:"elapsed"`
+		CreditCount  int       `json:"credit_count"`
+		Notice       *string   `json:"notice"`
+	} `json:"status"`
+	Data []CryptocurrencyMapEntry `json:"data"`
+}
+
+// CryptocurrencyInfo represents static metadata for a cryptocurrency
+type CryptocurrencyInfo struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Slug     string `json:"slug"`
+	Category string `json:"category"`
+	Logo     string `json:"logo"`
+}
+
+// CryptocurrencyInfoResponse represents the response from the info endpoint, keyed by ID
+type CryptocurrencyInfoResponse struct {
+	Status struct {
+		Timestamp    time.Time `json:"timestamp"`
+		ErrorCode    int       `json:"error_code"`
+		ErrorMessage *string   `json:"error_message"`
+		Elapsed      int       `json:"elapsed"`
+		CreditCount  int       `json:"credit_count"`
+		Notice       *string   `json:"notice"`
+	} `json:"status"`
+	Data map[string]CryptocurrencyInfo `json:"data"`
+}
+
+// GetCryptocurrencyMap retrieves CoinMarketCap IDs, ranks and slugs for the given symbols,
+// or for every listed cryptocurrency when symbols is empty
+func (c *CoinMarketCapClient) GetCryptocurrencyMap(ctx context.Context, symbols []string) (*CryptocurrencyMapResponse, error) {
+	params := url.Values{}
+	if len(symbols) > 0 {
+		params.Set("symbol", strings.Join(symbols, ","))
+	}
+
+	endpoint := "/cryptocurrency/map"
+	data, err := c.makeRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cryptocurrency map: %w", err)
+	}
+
+	var response CryptocurrencyMapResponse
+	if err := decodeJSON(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cryptocurrency map response: %w", err)
+	}
+
+	if response.Status.ErrorCode != 0 {
+		errorMsg := "unknown error"
+		if response.Status.ErrorMessage != nil {
+			errorMsg = *response.Status.ErrorMessage
+		}
+		return nil, fmt.Errorf("CoinMarketCap API error: %s (code: %d)", errorMsg, response.Status.ErrorCode)
+	}
+
+	return &response, nil
+}
+
+// GetCryptocurrencyInfo retrieves static metadata such as logos for the given CoinMarketCap IDs
+func (c *CoinMarketCapClient) GetCryptocurrencyInfo(ctx context.Context, ids []int) (*CryptocurrencyInfoResponse, error) {
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = strconv.Itoa(id)
+	}
+
+	params := url.Values{}
+	params.Set("id", strings.Join(idStrings, ","))
+
+	endpoint := "/cryptocurrency/info"
+	data, err := c.makeRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cryptocurrency info: %w", err)
+	}
+
+	var response CryptocurrencyInfoResponse
+	if err := decodeJSON(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cryptocurrency info response: %w", err)
+	}
+
+	if response.Status.ErrorCode != 0 {
+		errorMsg := "unknown error"
+		if response.Status.ErrorMessage != nil {
+			errorMsg = *response.Status.ErrorMessage
+		}
+		return nil, fmt.Errorf("CoinMarketCap API error: %s (code: %d)", errorMsg, response.Status.ErrorCode)
+	}
+
+	return &response, nil
+}
+
 // GetGlobalMetrics retrieves global cryptocurrency market metrics
-func (c *CoinMarketCapClient) GetGlobalMetrics(convert string) (*GlobalMetricsResponse, error) {
+func (c *CoinMarketCapClient) GetGlobalMetrics(ctx context.Context, convert string) (*GlobalMetricsResponse, error) {
 	if convert == "" {
 		convert = "USD"
 	}
@@ -181,13 +369,13 @@ func (c *CoinMarketCapClient) GetGlobalMetrics(convert string) (*GlobalMetricsRe
 	params.Set("convert", convert)
 
 	endpoint := "/global-metrics/quotes/latest"
-	data, err := c.makeRequest(endpoint, params)
+	data, err := c.makeRequest(ctx, endpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch global metrics: %w", err)
 	}
 
 	var response GlobalMetricsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := decodeJSON(data, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal global metrics response: %w", err)
 	}
 
@@ -199,7 +387,7 @@ func (c *CoinMarketCapClient) GetGlobalMetrics(convert string) (*GlobalMetricsRe
 		return nil, fmt.Errorf("CoinMarketCap API error: %s (code: %d)", errorMsg, response.Status.ErrorCode)
 	}
 
-	c.logger.Info("Successfully fetched global metrics", 
+	c.logger.WithContext(ctx).Info("Successfully fetched global metrics", 
 		"convert", convert,
 		"btc_dominance", response.Data.BtcDominance,
 		"credit_count", response.Status.CreditCount)
@@ -208,8 +396,8 @@ func (c *CoinMarketCapClient) GetGlobalMetrics(convert string) (*GlobalMetricsRe
 }
 
 // GetPriceBySymbol is a convenience method to get price for a single symbol
-func (c *CoinMarketCapClient) GetPriceBySymbol(symbol, convert string) (float64, error) {
-	response, err := c.GetLatestQuotes([]string{symbol}, convert)
+func (c *CoinMarketCapClient) GetPriceBySymbol(ctx context.Context, symbol, convert string) (float64, error) {
+	response, err := c.GetLatestQuotes(ctx, []string{symbol}, convert)
 	if err != nil {
 		return 0, err
 	}
@@ -225,8 +413,8 @@ func (c *CoinMarketCapClient) GetPriceBySymbol(symbol, convert string) (float64,
 }
 
 // GetBitcoinDominance retrieves Bitcoin dominance from global metrics
-func (c *CoinMarketCapClient) GetBitcoinDominance() (float64, error) {
-	response, err := c.GetGlobalMetrics("USD")
+func (c *CoinMarketCapClient) GetBitcoinDominance(ctx context.Context) (float64, error) {
+	response, err := c.GetGlobalMetrics(ctx, "USD")
 	if err != nil {
 		return 0, fmt.Errorf("failed to get Bitcoin dominance: %w", err)
 	}
@@ -235,13 +423,13 @@ func (c *CoinMarketCapClient) GetBitcoinDominance() (float64, error) {
 }
 
 // makeRequest makes an HTTP request to the CoinMarketCap API
-func (c *CoinMarketCapClient) makeRequest(endpoint string, params url.Values) ([]byte, error) {
+func (c *CoinMarketCapClient) makeRequest(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
 	reqURL := c.baseURL + endpoint
 	if len(params) > 0 {
 		reqURL += "?" + params.Encode()
 	}
 
-	req, err := http.NewRequest("GET", reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -251,7 +439,8 @@ func (c *CoinMarketCapClient) makeRequest(endpoint string, params url.Values) ([
 	req.Header.Set("Accept-Encoding", "deflate, gzip")
 	req.Header.Set("X-CMC_PRO_API_KEY", c.apiKey)
 
-	c.logger.Debug("Making CoinMarketCap API request", 
+	log := c.logger.WithContext(ctx)
+	log.Debug("Making CoinMarketCap API request", 
 		"url", reqURL,
 		"endpoint", endpoint)
 
@@ -277,22 +466,55 @@ func (c *CoinMarketCapClient) makeRequest(endpoint string, params url.Values) ([
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if isCoinMarketCapRateLimited(resp.StatusCode, body) {
+		resetTime := rateLimitResetTime(resp.Header, time.Now())
+		log.Warn("CoinMarketCap rate limit exceeded",
+			"status_code", resp.StatusCode,
+			"reset_time", resetTime)
+		return nil, errors.NewRateLimitError("CoinMarketCap", resetTime)
+	}
+
+	if err := checkJSONResponse(resp, body); err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		c.logger.Error("CoinMarketCap API request failed", 
+		log.Error("CoinMarketCap API request failed", 
 			"status_code", resp.StatusCode,
 			"response", string(body))
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, bodySnippet(body))
 	}
 
 	return body, nil
 }
 
-// Health check for the CoinMarketCap service
-func (c *CoinMarketCapClient) HealthCheck() error {
-	// Try to fetch Bitcoin price as a simple health check
-	_, err := c.GetPriceBySymbol("BTC", "USD")
+// isCoinMarketCapRateLimited reports whether a response is a rate limit rejection, either by
+// HTTP status or by one of the rate limit status error codes in the body
+func isCoinMarketCapRateLimited(statusCode int, body []byte) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	var envelope struct {
+		Status struct {
+			ErrorCode int `json:"error_code"`
+		} `json:"status"`
+	}
+	if json.Unmarshal(body, &envelope) != nil {
+		return false
+	}
+	code := envelope.Status.ErrorCode
+	return code >= cmcErrorCodeRateLimitMinute && code <= cmcErrorCodeRateLimitIP
+}
+
+// HealthCheck fetches the Bitcoin price from CoinMarketCap and returns how long it took
+func (c *CoinMarketCapClient) HealthCheck(ctx context.Context) (time.Duration, error) {
+	latency, err := timeHealthCheck(func() error {
+		_, err := c.GetPriceBySymbol(ctx, "BTC", "USD")
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("CoinMarketCap health check failed: %w", err)
+		return latency, fmt.Errorf("CoinMarketCap health check failed: %w", err)
 	}
-	return nil
+	return latency, nil
 }
\ No newline at end of file
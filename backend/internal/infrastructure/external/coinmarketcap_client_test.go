@@ -0,0 +1,125 @@
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinMarketCapClient_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "42")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":{"error_code":1008,"error_message":"You've exceeded your API Key's HTTP request rate limit."}}`))
+	}))
+	defer server.Close()
+
+	client := NewCoinMarketCapClientWithBaseURL("test-key", server.URL, logger.New("test"))
+	before := time.Now()
+
+	_, err := client.GetLatestQuotes(context.Background(), []string{"BTC"}, "USD")
+
+	require.Error(t, err)
+	resetTime, ok := errors.RateLimitResetTime(err)
+	require.True(t, ok, "wrapped error should carry the rate limit")
+	assert.WithinDuration(t, before.Add(42*time.Second), resetTime, 2*time.Second)
+}
+
+func TestCoinMarketCapClient_RateLimitErrorCode(t *testing.T) {
+	// Daily credit exhaustion is reported through the status error code
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"status":{"error_code":1009,"error_message":"You've exceeded your API Key's daily rate limit."}}`))
+	}))
+	defer server.Close()
+
+	client := NewCoinMarketCapClientWithBaseURL("test-key", server.URL, logger.New("test"))
+
+	_, err := client.GetGlobalMetrics(context.Background(), "USD")
+
+	resetTime, ok := errors.RateLimitResetTime(err)
+	require.True(t, ok)
+	assert.True(t, resetTime.After(time.Now()), "reset defaults to a short backoff without headers")
+}
+
+func TestCoinMarketCapClient_OtherErrorsAreNotRateLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":{"error_code":1001,"error_message":"This API Key is invalid."}}`))
+	}))
+	defer server.Close()
+
+	client := NewCoinMarketCapClientWithBaseURL("bad-key", server.URL, logger.New("test"))
+
+	_, err := client.GetLatestQuotes(context.Background(), []string{"BTC"}, "USD")
+
+	require.Error(t, err)
+	_, ok := errors.RateLimitResetTime(err)
+	assert.False(t, ok)
+}
+
+func TestCoinMarketCapClient_NonJSONResponses(t *testing.T) {
+	respond := func(contentType, body string) error {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		client := NewCoinMarketCapClientWithBaseURL("test-key", server.URL, logger.New("test"))
+		_, err := client.GetLatestQuotes(context.Background(), []string{"BTC"}, "USD")
+		return err
+	}
+
+	t.Run("HTML page includes status and snippet", func(t *testing.T) {
+		page := "<!DOCTYPE html>\n<html>\n  <head><title>Just a moment...</title></head>\n  <body>" +
+			strings.Repeat("Checking your browser. ", 50) + "</body>\n</html>"
+
+		err := respond("text/html; charset=UTF-8", page)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "text/html")
+		assert.Contains(t, err.Error(), "status 200")
+		assert.Contains(t, err.Error(), "<!DOCTYPE html> <html> <head><title>Just a moment...</title></head>")
+		assert.NotContains(t, err.Error(), page, "long bodies are truncated")
+	})
+
+	t.Run("Undecodable body includes snippet", func(t *testing.T) {
+		err := respond("application/json", "upstream connect error or disconnect/reset before headers")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to unmarshal")
+		assert.Contains(t, err.Error(), "upstream connect error")
+	})
+}
+
+func TestRateLimitResetTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		header   http.Header
+		expected time.Time
+	}{
+		{"Delay seconds", http.Header{"Retry-After": {"30"}}, now.Add(30 * time.Second)},
+		{"HTTP date", http.Header{"Retry-After": {"Wed, 01 May 2024 12:05:00 GMT"}}, now.Add(5 * time.Minute)},
+		{"Reset timestamp", http.Header{"X-Ratelimit-Reset": {"1714565100"}}, time.Unix(1714565100, 0)},
+		{"No headers", http.Header{}, now.Add(defaultRateLimitBackoff)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.expected.Equal(rateLimitResetTime(tt.header, now)))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package external
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Semaphore is a weighted semaphore. Waiters are served in arrival order, so a large
+// acquisition is not starved by a stream of small ones.
+type Semaphore struct {
+	size    int64
+	mu      sync.Mutex
+	cur     int64
+	waiters list.List
+}
+
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// NewSemaphore creates a semaphore with the given total weight
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire blocks until n units are available or ctx is done. On failure nothing is held.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	if n > s.size {
+		return fmt.Errorf("semaphore: acquiring %d exceeds size %d", n, s.size)
+	}
+
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := semaphoreWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// Acquired just as ctx was cancelled; give the units back
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// Waiters behind a removed front waiter may now fit
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes n units without blocking and reports whether it succeeded
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release returns n units to the semaphore
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("semaphore: released more than held")
+	}
+	s.notifyWaiters()
+}
+
+// notifyWaiters wakes waiters in order while the front one fits. Callers hold s.mu.
+func (s *Semaphore) notifyWaiters() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			return
+		}
+		w := next.Value.(semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(next)
+		close(w.ready)
+	}
+}
+
+// upstreamLimit bounds in-flight requests across every upstream client; nil means unlimited
+var upstreamLimit atomic.Pointer[Semaphore]
+
+// SetMaxConcurrentRequests limits how many upstream requests all clients combined may have
+// in flight. It applies to clients created before the call too; 0 or less removes the limit.
+func SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		upstreamLimit.Store(nil)
+		return
+	}
+	upstreamLimit.Store(NewSemaphore(int64(n)))
+}
+
+// NewHTTPClient creates an HTTP client for upstream APIs that honors the global concurrency
+// limit. Each request gets a client span, including any wait for a free slot, and carries
+// the trace context of the request's context to the upstream.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: otelhttp.NewTransport(&limitedTransport{base: http.DefaultTransport}),
+	}
+}
+
+// limitedTransport holds a unit of the upstream limit from sending a request until its
+// response body is closed, so slow body reads count as in flight
+type limitedTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip waits for a free slot, giving up when the request's context is done
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sem := upstreamLimit.Load()
+	if sem == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if err := sem.Acquire(req.Context(), 1); err != nil {
+		return nil, fmt.Errorf("waiting for upstream request slot: %w", err)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		sem.Release(1)
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { sem.Release(1) }}
+	return resp, nil
+}
+
+// releasingBody releases its slot the first time it is closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the body and frees the request's slot
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,128 @@
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Run("weighted acquisitions share the size", func(t *testing.T) {
+		sem := NewSemaphore(3)
+		require.NoError(t, sem.Acquire(context.Background(), 2))
+		assert.False(t, sem.TryAcquire(2))
+		assert.True(t, sem.TryAcquire(1))
+
+		sem.Release(2)
+		assert.True(t, sem.TryAcquire(2))
+	})
+
+	t.Run("acquire gives up when the context is done", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		require.True(t, sem.TryAcquire(1))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, sem.Acquire(ctx, 1), context.DeadlineExceeded)
+
+		// The abandoned wait holds nothing
+		sem.Release(1)
+		assert.True(t, sem.TryAcquire(1))
+	})
+
+	t.Run("waiter is woken by release", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		require.True(t, sem.TryAcquire(1))
+
+		acquired := make(chan error)
+		go func() { acquired <- sem.Acquire(context.Background(), 1) }()
+		sem.Release(1)
+
+		select {
+		case err := <-acquired:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("waiter was not woken")
+		}
+	})
+
+	t.Run("weight above size fails", func(t *testing.T) {
+		assert.Error(t, NewSemaphore(2).Acquire(context.Background(), 3))
+	})
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	const limit = 3
+	SetMaxConcurrentRequests(limit)
+	t.Cleanup(func() { SetMaxConcurrentRequests(0) })
+
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"symbol":"BTCUSDT","price":"67712.34"}`))
+	}))
+	defer server.Close()
+
+	// Two clients share the global limit
+	binance := NewBinanceClientWithBaseURL(server.URL, logger.New("test"))
+	other := NewBinanceClientWithBaseURL(server.URL, logger.New("test"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		client := binance
+		if i%2 == 1 {
+			client = other
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetPrice(context.Background(), "BTC")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(limit))
+	assert.Equal(t, int32(limit), atomic.LoadInt32(&peak), "burst should saturate the limit")
+}
+
+func TestMaxConcurrentRequests_ContextCancelled(t *testing.T) {
+	SetMaxConcurrentRequests(1)
+	t.Cleanup(func() { SetMaxConcurrentRequests(0) })
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewBinanceClientWithBaseURL(server.URL, logger.New("test"))
+	go client.HealthCheck(context.Background())
+	// Let the first request take the only slot
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.HealthCheck(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "waiting for upstream request slot")
+}
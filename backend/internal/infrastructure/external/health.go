@@ -0,0 +1,11 @@
+package external
+
+import "time"
+
+// timeHealthCheck runs check and returns how long it took. The latency is returned for
+// failed checks too, so a provider that is timing out shows up as slow as well as down.
+func timeHealthCheck(check func() error) (time.Duration, error) {
+	start := time.Now()
+	err := check()
+	return time.Since(start), err
+}
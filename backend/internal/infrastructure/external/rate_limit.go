@@ -0,0 +1,53 @@
+package external
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRateLimitBackoff is used when a rate limited response carries no usable reset header
+const defaultRateLimitBackoff = time.Minute
+
+// rateLimitResetTime derives when a rate limit lifts from the Retry-After header, which may
+// hold delay seconds or an HTTP date, falling back to X-RateLimit-Reset as Unix seconds
+func rateLimitResetTime(header http.Header, now time.Time) time.Time {
+	if reset, ok := headerResetTime(header, now); ok {
+		return reset
+	}
+	return now.Add(defaultRateLimitBackoff)
+}
+
+// RetryAfter returns how long a rate limited response asks callers to wait, read like
+// rateLimitResetTime, and false when its headers name no delay
+func RetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	reset, ok := headerResetTime(header, now)
+	if !ok {
+		return 0, false
+	}
+	if wait := reset.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// headerResetTime reads the reset time from Retry-After or X-RateLimit-Reset
+func headerResetTime(header http.Header, now time.Time) (time.Time, bool) {
+	if retryAfter := strings.TrimSpace(header.Get("Retry-After")); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return now.Add(time.Duration(seconds) * time.Second), true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return date, true
+		}
+	}
+
+	if reset := strings.TrimSpace(header.Get("X-RateLimit-Reset")); reset != "" {
+		if unix, err := strconv.ParseInt(reset, 10, 64); err == nil && unix > now.Unix() {
+			return time.Unix(unix, 0), true
+		}
+	}
+
+	return time.Time{}, false
+}
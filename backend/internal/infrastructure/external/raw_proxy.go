@@ -0,0 +1,207 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+// maxRawResponseBytes caps how much of an upstream body the raw proxy returns
+const maxRawResponseBytes = 1 << 20
+
+// RawProvider is an upstream the raw proxy may call. Only the whitelisted Endpoints can be
+// requested, each with fixed query parameters, so callers never choose the host, the path
+// or the query.
+type RawProvider struct {
+	BaseURL string
+	// Header is sent with every request, e.g. an API key; it is never echoed back
+	Header http.Header
+	// Endpoints maps each allowed path, relative to BaseURL, to its query parameters
+	Endpoints map[string]url.Values
+}
+
+// RawResponse is an upstream response as received
+type RawResponse struct {
+	Provider    string `json:"provider"`
+	Endpoint    string `json:"endpoint"`
+	URL         string `json:"url"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+	// Truncated reports that the body was cut at maxRawResponseBytes
+	Truncated bool      `json:"truncated"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// RawProxy fetches raw upstream payloads from whitelisted provider endpoints, for debugging
+// indicators against exactly what the provider returned
+type RawProxy struct {
+	providers  map[string]RawProvider
+	httpClient *http.Client
+	logger     logger.Logger
+}
+
+// NewRawProxy creates a raw proxy over the given providers, keyed by provider name.
+// Redirects are not followed so a whitelisted endpoint can't bounce the request elsewhere.
+func NewRawProxy(providers map[string]RawProvider, logger logger.Logger) *RawProxy {
+	client := NewHTTPClient(15 * time.Second)
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &RawProxy{
+		providers:  providers,
+		httpClient: client,
+		logger:     logger,
+	}
+}
+
+// DefaultRawProviders returns the upstream endpoints the indicators read from, with the
+// API keys the clients would send
+func DefaultRawProviders(coinMarketCapAPIKey, coinCapAPIKey, alternativeMeURL string) map[string]RawProvider {
+	coinMarketCapHeader := http.Header{}
+	if coinMarketCapAPIKey != "" {
+		coinMarketCapHeader.Set("X-CMC_PRO_API_KEY", coinMarketCapAPIKey)
+	}
+	coinCapHeader := http.Header{}
+	if coinCapAPIKey != "" {
+		coinCapHeader.Set("Authorization", "Bearer "+coinCapAPIKey)
+	}
+
+	return map[string]RawProvider{
+		"coinmarketcap": {
+			BaseURL: CoinMarketCapBaseURL,
+			Header:  coinMarketCapHeader,
+			Endpoints: map[string]url.Values{
+				"/cryptocurrency/quotes/latest":   {"symbol": {"BTC,ETH"}, "convert": {"USD"}},
+				"/cryptocurrency/listings/latest": {"limit": {"100"}, "convert": {"USD"}},
+				"/global-metrics/quotes/latest":   {"convert": {"USD"}},
+			},
+		},
+		"coingecko": {
+			BaseURL: CoinGeckoBaseURL + "/api/v3",
+			Endpoints: map[string]url.Values{
+				"/coins/bitcoin": {
+					"localization":   {"false"},
+					"tickers":        {"false"},
+					"market_data":    {"true"},
+					"community_data": {"false"},
+					"developer_data": {"false"},
+					"sparkline":      {"false"},
+				},
+				"/global": nil,
+			},
+		},
+		"binance": {
+			BaseURL: BinanceBaseURL,
+			Endpoints: map[string]url.Values{
+				"/api/v3/ticker/price": {"symbol": {"BTCUSDT"}},
+				"/api/v3/ticker/24hr":  {"symbol": {"BTCUSDT"}},
+			},
+		},
+		"coincap": {
+			BaseURL: CoinCapBaseURL,
+			Header:  coinCapHeader,
+			Endpoints: map[string]url.Values{
+				"/assets/bitcoin": nil,
+			},
+		},
+		"alternative_me": {
+			BaseURL: alternativeMeURL,
+			Endpoints: map[string]url.Values{
+				"/fng/": {"limit": {"1"}},
+			},
+		},
+		"blockchain": {
+			BaseURL: BlockchainBaseURL,
+			Endpoints: map[string]url.Values{
+				"/stats": {"format": {"json"}},
+			},
+		},
+	}
+}
+
+// Endpoints returns the whitelisted endpoints of a provider, sorted
+func (p *RawProxy) Endpoints(provider string) ([]string, bool) {
+	upstream, ok := p.providers[provider]
+	if !ok {
+		return nil, false
+	}
+
+	endpoints := make([]string, 0, len(upstream.Endpoints))
+	for endpoint := range upstream.Endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints, true
+}
+
+// Fetch calls a whitelisted endpoint of provider and returns the response as received,
+// whatever its status. Unknown providers are NotFound and endpoints outside the whitelist
+// are rejected as Validation errors without any request being made.
+func (p *RawProxy) Fetch(ctx context.Context, provider, endpoint string) (*RawResponse, error) {
+	upstream, ok := p.providers[provider]
+	if !ok {
+		return nil, errors.NotFound("provider " + provider)
+	}
+	params, ok := upstream.Endpoints[endpoint]
+	if !ok {
+		allowed, _ := p.Endpoints(provider)
+		return nil, errors.Validation("Endpoint not allowed for "+provider,
+			"allowed endpoints: "+strings.Join(allowed, ", "))
+	}
+
+	reqURL := upstream.BaseURL + endpoint
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, errors.Internal("failed to create raw provider request", err)
+	}
+	for key, values := range upstream.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "CryptoIndicatorDashboard/1.0")
+
+	p.logger.WithContext(ctx).Info("Fetching raw provider response", "provider", provider, "endpoint", endpoint)
+
+	start := time.Now()
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.External(provider, fmt.Sprintf("request to %s failed", endpoint), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRawResponseBytes+1))
+	if err != nil {
+		return nil, errors.External(provider, fmt.Sprintf("reading %s response failed", endpoint), err)
+	}
+	truncated := len(body) > maxRawResponseBytes
+	if truncated {
+		body = body[:maxRawResponseBytes]
+	}
+
+	return &RawResponse{
+		Provider:    provider,
+		Endpoint:    endpoint,
+		URL:         reqURL,
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(body),
+		Truncated:   truncated,
+		FetchedAt:   start,
+	}, nil
+}
@@ -0,0 +1,18 @@
+package external
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultRawProviders_APIKeyHeaders(t *testing.T) {
+	providers := DefaultRawProviders("cmc-key", "coincap-key", "https://api.alternative.me")
+	assert.Equal(t, "cmc-key", providers["coinmarketcap"].Header.Get("X-CMC_PRO_API_KEY"))
+	assert.Equal(t, "Bearer coincap-key", providers["coincap"].Header.Get("Authorization"))
+	assert.Equal(t, CoinMarketCapBaseURL, providers["coinmarketcap"].BaseURL)
+
+	providers = DefaultRawProviders("", "", "https://api.alternative.me")
+	assert.Empty(t, providers["coinmarketcap"].Header)
+	assert.Empty(t, providers["coincap"].Header)
+}
@@ -0,0 +1,52 @@
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxBodySnippet caps how much of an unexpected response body is quoted in errors
+const maxBodySnippet = 200
+
+// bodySnippet returns the start of a response body for error messages, with runs of
+// whitespace collapsed so HTML pages stay on one line
+func bodySnippet(body []byte) string {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) > maxBodySnippet {
+		// Back up to the start of a rune so a multi-byte character is not split
+		cut := maxBodySnippet
+		for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+			cut--
+		}
+		return snippet[:cut] + "..."
+	}
+	return snippet
+}
+
+// checkJSONResponse rejects HTML responses. Upstreams serve captchas, maintenance and CDN
+// error pages as HTML, sometimes with a 200 status, which would otherwise only surface as
+// an opaque unmarshal error. Plain text bodies pass, since some endpoints return bare numbers.
+func checkJSONResponse(resp *http.Response, body []byte) error {
+	contentType := resp.Header.Get("Content-Type")
+	isHTML := strings.Contains(strings.ToLower(contentType), "html") ||
+		bytes.HasPrefix(bytes.TrimSpace(body), []byte("<"))
+	if !isHTML {
+		return nil
+	}
+	if contentType == "" {
+		contentType = "HTML"
+	}
+	return fmt.Errorf("expected JSON but got %s (status %d): %s", contentType, resp.StatusCode, bodySnippet(body))
+}
+
+// decodeJSON unmarshals body into v, quoting the start of the body when it is not valid JSON
+func decodeJSON(body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%w (body: %q)", err, bodySnippet(body))
+	}
+	return nil
+}
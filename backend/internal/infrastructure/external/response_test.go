@@ -0,0 +1,22 @@
+package external
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodySnippet(t *testing.T) {
+	assert.Equal(t, "<html> <body>Busy</body> </html>", bodySnippet([]byte("<html>\n  <body>Busy</body>\n</html>")))
+
+	long := strings.Repeat("a", maxBodySnippet+10)
+	assert.Equal(t, long[:maxBodySnippet]+"...", bodySnippet([]byte(long)))
+
+	// A two-byte character straddling the limit is dropped rather than split
+	straddling := strings.Repeat("a", maxBodySnippet-1) + "é" + "tail"
+	snippet := bodySnippet([]byte(straddling))
+	assert.True(t, utf8.ValidString(snippet), snippet)
+	assert.Equal(t, strings.Repeat("a", maxBodySnippet-1)+"...", snippet)
+}
@@ -1,51 +1,140 @@
 package external
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/pkg/logger"
 )
 
+// Extraction strategy names recorded in ScrapeDiagnostics
+const (
+	StrategyDominanceLabel = "dominance_label"
+	StrategyValueClass     = "value_class"
+	StrategyChange24h      = "change_24h"
+	StrategyChangeLabel    = "change_label"
+)
+
+// maxScrapeBodyBytes caps how much of a TradingView page or CoinGecko response is read
+const maxScrapeBodyBytes = 5 << 20
+
 // TradingViewScraper handles scraping data from TradingView
 type TradingViewScraper struct {
-	httpClient *http.Client
-	logger     logger.Logger
+	httpClient     *http.Client
+	logger         logger.Logger
+	tradingViewURL string
+	coinGeckoURL   string
+	maxBodyBytes   int64
+
+	// Served when every source fails; see SetFallback
+	lastKnownDominance LastKnownDominanceFunc
+	fallbackDominance  float64
+
+	mu              sync.RWMutex
+	lastDiagnostics *ScrapeDiagnostics
+}
+
+// LastKnownDominanceFunc returns the most recent Bitcoin dominance that was fetched successfully
+type LastKnownDominanceFunc func(ctx context.Context) (*entities.BitcoinDominance, error)
+
+// ExtractionAttempt records the outcome of a single HTML extraction strategy
+type ExtractionAttempt struct {
+	Strategy string  `json:"strategy"`
+	Matched  bool    `json:"matched"`
+	Value    float64 `json:"value,omitempty"`
+}
+
+// ScrapeDiagnostics describes how the most recent Bitcoin dominance lookup was resolved
+type ScrapeDiagnostics struct {
+	Timestamp      time.Time           `json:"timestamp"`
+	Source         entities.DataSource `json:"source"`
+	Degraded       bool                `json:"degraded"`
+	CoinGeckoError string              `json:"coingecko_error,omitempty"`
+	ScrapeError    string              `json:"scrape_error,omitempty"`
+	Attempts       []ExtractionAttempt `json:"attempts,omitempty"`
 }
 
+// CoinGeckoBaseURL is the public CoinGecko API host; its endpoints live under /api/v3
+const CoinGeckoBaseURL = "https://api.coingecko.com"
+
 // NewTradingViewScraper creates a new TradingView scraper
 func NewTradingViewScraper(logger logger.Logger) *TradingViewScraper {
+	return NewTradingViewScraperWithURLs(
+		"https://www.tradingview.com/symbols/BTC.D/",
+		CoinGeckoBaseURL + "/api/v3/global",
+		logger,
+	)
+}
+
+// NewTradingViewScraperWithURLs creates a new TradingView scraper with custom source URLs (for testing)
+func NewTradingViewScraperWithURLs(tradingViewURL, coinGeckoURL string, logger logger.Logger) *TradingViewScraper {
 	return &TradingViewScraper{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: logger,
+		httpClient: NewHTTPClient(30 * time.Second),
+		logger:         logger,
+		tradingViewURL: tradingViewURL,
+		coinGeckoURL:   coinGeckoURL,
+		maxBodyBytes:   maxScrapeBodyBytes,
 	}
 }
 
+// SetFallback configures what GetBitcoinDominanceWithFallback serves when every source fails:
+// the dominance returned by lastKnown when it has one, otherwise value. A value of 0 disables
+// the fixed fallback, so the lookup fails once nothing has been stored.
+func (s *TradingViewScraper) SetFallback(lastKnown LastKnownDominanceFunc, value float64) {
+	s.lastKnownDominance = lastKnown
+	s.fallbackDominance = value
+}
+
 // BitcoinDominanceData represents Bitcoin dominance data from TradingView
 type BitcoinDominanceData struct {
-	CurrentDominance    float64   `json:"current_dominance"`
-	PreviousDominance   float64   `json:"previous_dominance"`
-	Change24h           float64   `json:"change_24h"`
-	ChangePercent24h    float64   `json:"change_percent_24h"`
-	LastUpdated         time.Time `json:"last_updated"`
-	DataSource          string    `json:"data_source"`
+	CurrentDominance  float64             `json:"current_dominance"`
+	PreviousDominance float64             `json:"previous_dominance"`
+	Change24h         float64             `json:"change_24h"`
+	ChangePercent24h  float64             `json:"change_percent_24h"`
+	LastUpdated       time.Time           `json:"last_updated"`
+	DataSource        entities.DataSource `json:"data_source"`
+}
+
+// IsFallback reports whether the data was served from a fallback rather than a live source
+func (d *BitcoinDominanceData) IsFallback() bool {
+	return d.DataSource == entities.DataSourceLastKnownGood || d.DataSource == entities.DataSourceFallback
 }
 
 // ScrapeBitcoinDominance scrapes Bitcoin dominance data from TradingView
-func (s *TradingViewScraper) ScrapeBitcoinDominance() (*BitcoinDominanceData, error) {
-	url := "https://www.tradingview.com/symbols/BTC.D/"
+func (s *TradingViewScraper) ScrapeBitcoinDominance(ctx context.Context) (*BitcoinDominanceData, error) {
+	data, attempts, err := s.scrapeTradingView(ctx)
+
+	diagnostics := &ScrapeDiagnostics{
+		Timestamp: time.Now(),
+		Attempts:  attempts,
+	}
+	if err != nil {
+		diagnostics.ScrapeError = err.Error()
+	} else {
+		diagnostics.Source = data.DataSource
+	}
+	s.recordDiagnostics(diagnostics)
+
+	return data, err
+}
+
+// scrapeTradingView fetches the TradingView page and extracts dominance data,
+// returning the extraction attempts made along the way
+func (s *TradingViewScraper) scrapeTradingView(ctx context.Context) (*BitcoinDominanceData, []ExtractionAttempt, error) {
+	url := s.tradingViewURL
 	
-	s.logger.Debug("Scraping Bitcoin dominance from TradingView", "url", url)
+	s.logger.WithContext(ctx).Debug("Scraping Bitcoin dominance from TradingView", "url", url)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add headers to mimic a real browser
@@ -59,37 +148,42 @@ func (s *TradingViewScraper) ScrapeBitcoinDominance() (*BitcoinDominanceData, er
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch TradingView page: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch TradingView page: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TradingView request failed with status: %d", resp.StatusCode)
+		return nil, nil, fmt.Errorf("TradingView request failed with status: %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := s.readBody(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, err
 	}
 
-	dominanceData, err := s.extractDominanceFromHTML(string(body))
+	dominanceData, attempts, err := s.extractDominanceFromHTML(string(body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract dominance data: %w", err)
+		s.logger.WithContext(ctx).Warn("TradingView extraction failed, page markup may have changed",
+			"error", err,
+			"attempts", attempts)
+		return nil, attempts, fmt.Errorf("failed to extract dominance data: %w", err)
 	}
 
-	dominanceData.DataSource = "TradingView"
+	dominanceData.DataSource = entities.DataSourceTradingView
 	dominanceData.LastUpdated = time.Now()
 
-	s.logger.Info("Successfully scraped Bitcoin dominance", 
+	s.logger.WithContext(ctx).Info("Successfully scraped Bitcoin dominance", 
 		"dominance", dominanceData.CurrentDominance,
 		"change_24h", dominanceData.Change24h)
 
-	return dominanceData, nil
+	return dominanceData, attempts, nil
 }
 
-// extractDominanceFromHTML extracts Bitcoin dominance data from HTML content
-func (s *TradingViewScraper) extractDominanceFromHTML(html string) (*BitcoinDominanceData, error) {
+// extractDominanceFromHTML extracts Bitcoin dominance data from HTML content,
+// recording the outcome of each extraction strategy it tries
+func (s *TradingViewScraper) extractDominanceFromHTML(html string) (*BitcoinDominanceData, []ExtractionAttempt, error) {
 	data := &BitcoinDominanceData{}
+	var attempts []ExtractionAttempt
 
 	// Extract current dominance value
 	// Look for patterns like "BTC.D" or "Bitcoin Dominance" followed by percentage
@@ -100,6 +194,11 @@ func (s *TradingViewScraper) extractDominanceFromHTML(html string) (*BitcoinDomi
 			data.CurrentDominance = dominance
 		}
 	}
+	attempts = append(attempts, ExtractionAttempt{
+		Strategy: StrategyDominanceLabel,
+		Matched:  data.CurrentDominance != 0,
+		Value:    data.CurrentDominance,
+	})
 
 	// If the above pattern doesn't work, try alternative patterns
 	if data.CurrentDominance == 0 {
@@ -114,6 +213,11 @@ func (s *TradingViewScraper) extractDominanceFromHTML(html string) (*BitcoinDomi
 				}
 			}
 		}
+		attempts = append(attempts, ExtractionAttempt{
+			Strategy: StrategyValueClass,
+			Matched:  data.CurrentDominance != 0,
+			Value:    data.CurrentDominance,
+		})
 	}
 
 	// Extract change information
@@ -127,6 +231,11 @@ func (s *TradingViewScraper) extractDominanceFromHTML(html string) (*BitcoinDomi
 			data.Change24h = (change / 100) * data.CurrentDominance
 		}
 	}
+	attempts = append(attempts, ExtractionAttempt{
+		Strategy: StrategyChange24h,
+		Matched:  data.ChangePercent24h != 0,
+		Value:    data.ChangePercent24h,
+	})
 
 	// Alternative change extraction
 	if data.ChangePercent24h == 0 {
@@ -138,6 +247,11 @@ func (s *TradingViewScraper) extractDominanceFromHTML(html string) (*BitcoinDomi
 				data.Change24h = (change / 100) * data.CurrentDominance
 			}
 		}
+		attempts = append(attempts, ExtractionAttempt{
+			Strategy: StrategyChangeLabel,
+			Matched:  data.ChangePercent24h != 0,
+			Value:    data.ChangePercent24h,
+		})
 	}
 
 	// Calculate previous dominance
@@ -147,52 +261,114 @@ func (s *TradingViewScraper) extractDominanceFromHTML(html string) (*BitcoinDomi
 
 	// Validate extracted data
 	if data.CurrentDominance == 0 {
-		return nil, fmt.Errorf("could not extract Bitcoin dominance value from TradingView page")
+		return nil, attempts, fmt.Errorf("could not extract Bitcoin dominance value from TradingView page")
 	}
 
 	if data.CurrentDominance < 20 || data.CurrentDominance > 90 {
-		return nil, fmt.Errorf("extracted dominance value seems invalid: %.2f%%", data.CurrentDominance)
+		return nil, attempts, fmt.Errorf("extracted dominance value seems invalid: %.2f%%", data.CurrentDominance)
 	}
 
-	return data, nil
+	return data, attempts, nil
 }
 
-// GetBitcoinDominanceWithFallback gets Bitcoin dominance with fallback data if scraping fails
-func (s *TradingViewScraper) GetBitcoinDominanceWithFallback() (*BitcoinDominanceData, error) {
+// GetBitcoinDominanceWithFallback gets Bitcoin dominance with fallback data if scraping fails.
+// A cancelled ctx is returned as an error rather than masked by the fallback data.
+func (s *TradingViewScraper) GetBitcoinDominanceWithFallback(ctx context.Context) (*BitcoinDominanceData, error) {
+	diagnostics := &ScrapeDiagnostics{Timestamp: time.Now()}
+	defer s.recordDiagnostics(diagnostics)
+
 	// Try CoinGecko API first (more reliable)
-	data, err := s.getBitcoinDominanceFromCoinGecko()
+	data, err := s.getBitcoinDominanceFromCoinGecko(ctx)
 	if err == nil {
+		diagnostics.Source = data.DataSource
 		return data, nil
 	}
+	diagnostics.CoinGeckoError = err.Error()
 	
-	s.logger.Warn("CoinGecko API failed, trying TradingView scraping", "error", err)
+	s.logger.WithContext(ctx).Warn("CoinGecko API failed, trying TradingView scraping", "error", err)
 	
 	// Try TradingView scraping
-	data, err = s.ScrapeBitcoinDominance()
+	data, attempts, err := s.scrapeTradingView(ctx)
+	diagnostics.Attempts = attempts
 	if err != nil {
-		s.logger.Warn("Failed to scrape Bitcoin dominance, using fallback data", "error", err)
-		
-		// Return fallback data (updated to match current real market conditions)
-		return &BitcoinDominanceData{
-			CurrentDominance:  60.77, // Current real Bitcoin dominance from TradingView
-			PreviousDominance: 61.03, // Previous value to get -0.42% change
-			Change24h:        -0.26,
-			ChangePercent24h: -0.42,
-			LastUpdated:      time.Now(),
-			DataSource:       "Fallback Data",
-		}, nil
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			diagnostics.ScrapeError = err.Error()
+			return nil, fmt.Errorf("bitcoin dominance lookup cancelled: %w", ctxErr)
+		}
+
+		diagnostics.ScrapeError = err.Error()
+		fallback, fallbackErr := s.fallbackDominanceData(ctx)
+		if fallbackErr != nil {
+			return nil, fmt.Errorf("failed to get Bitcoin dominance from any source: %w", err)
+		}
+
+		s.logger.WithContext(ctx).Warn("Failed to scrape Bitcoin dominance, using fallback data", "error", err, "source", fallback.DataSource)
+		diagnostics.Source = fallback.DataSource
+		diagnostics.Degraded = true
+		return fallback, nil
 	}
 	
+	diagnostics.Source = data.DataSource
 	return data, nil
 }
 
+// fallbackDominanceData returns the last known good dominance, or the configured fixed value
+// when nothing has been stored
+func (s *TradingViewScraper) fallbackDominanceData(ctx context.Context) (*BitcoinDominanceData, error) {
+	if s.lastKnownDominance != nil {
+		stored, err := s.lastKnownDominance(ctx)
+		if err == nil && stored != nil && stored.CurrentDominance > 0 {
+			return &BitcoinDominanceData{
+				CurrentDominance:  stored.CurrentDominance,
+				PreviousDominance: stored.PreviousDominance,
+				Change24h:         stored.Change24h,
+				ChangePercent24h:  stored.ChangePercent24h,
+				LastUpdated:       stored.LastUpdated,
+				DataSource:        entities.DataSourceLastKnownGood,
+			}, nil
+		}
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("No last known Bitcoin dominance available", "error", err)
+		}
+	}
+
+	if s.fallbackDominance <= 0 {
+		return nil, fmt.Errorf("no fallback Bitcoin dominance configured")
+	}
+	return &BitcoinDominanceData{
+		CurrentDominance: s.fallbackDominance,
+		LastUpdated:      time.Now(),
+		DataSource:       entities.DataSourceFallback,
+	}, nil
+}
+
+// LastScrapeDiagnostics returns diagnostics for the most recent dominance lookup, or nil if none has run
+func (s *TradingViewScraper) LastScrapeDiagnostics() *ScrapeDiagnostics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.lastDiagnostics == nil {
+		return nil
+	}
+	diagnostics := *s.lastDiagnostics
+	diagnostics.Attempts = append([]ExtractionAttempt(nil), s.lastDiagnostics.Attempts...)
+	return &diagnostics
+}
+
+// recordDiagnostics stores the diagnostics of the latest dominance lookup
+func (s *TradingViewScraper) recordDiagnostics(diagnostics *ScrapeDiagnostics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastDiagnostics = diagnostics
+}
+
 // getBitcoinDominanceFromCoinGecko gets Bitcoin dominance from CoinGecko API
-func (s *TradingViewScraper) getBitcoinDominanceFromCoinGecko() (*BitcoinDominanceData, error) {
-	url := "https://api.coingecko.com/api/v3/global"
+func (s *TradingViewScraper) getBitcoinDominanceFromCoinGecko(ctx context.Context) (*BitcoinDominanceData, error) {
+	url := s.coinGeckoURL
 	
-	s.logger.Debug("Fetching Bitcoin dominance from CoinGecko", "url", url)
+	s.logger.WithContext(ctx).Debug("Fetching Bitcoin dominance from CoinGecko", "url", url)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -211,9 +387,9 @@ func (s *TradingViewScraper) getBitcoinDominanceFromCoinGecko() (*BitcoinDominan
 		return nil, fmt.Errorf("CoinGecko API request failed with status: %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := s.readBody(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	// Parse JSON response to extract Bitcoin dominance
@@ -222,15 +398,28 @@ func (s *TradingViewScraper) getBitcoinDominanceFromCoinGecko() (*BitcoinDominan
 		return nil, fmt.Errorf("failed to parse CoinGecko response: %w", err)
 	}
 
-	dominanceData.DataSource = "CoinGecko API"
+	dominanceData.DataSource = entities.DataSourceCoinGecko
 	dominanceData.LastUpdated = time.Now()
 
-	s.logger.Info("Successfully fetched Bitcoin dominance from CoinGecko", 
+	s.logger.WithContext(ctx).Info("Successfully fetched Bitcoin dominance from CoinGecko", 
 		"dominance", dominanceData.CurrentDominance)
 
 	return dominanceData, nil
 }
 
+// readBody reads a response body, failing once it grows past maxBodyBytes instead of
+// holding an arbitrarily large page in memory
+func (s *TradingViewScraper) readBody(body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, s.maxBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(data)) > s.maxBodyBytes {
+		return nil, fmt.Errorf("response body exceeds %d bytes", s.maxBodyBytes)
+	}
+	return data, nil
+}
+
 // parseCoinGeckoResponse parses CoinGecko API response to extract Bitcoin dominance
 func (s *TradingViewScraper) parseCoinGeckoResponse(jsonResponse string) (*BitcoinDominanceData, error) {
 	// Look for Bitcoin percentage in market_cap_percentage field
@@ -261,21 +450,24 @@ func (s *TradingViewScraper) parseCoinGeckoResponse(jsonResponse string) (*Bitco
 	}, nil
 }
 
-// HealthCheck performs a health check on the TradingView scraper
-func (s *TradingViewScraper) HealthCheck() error {
-	_, err := s.ScrapeBitcoinDominance()
+// HealthCheck scrapes Bitcoin dominance from TradingView and returns how long it took
+func (s *TradingViewScraper) HealthCheck(ctx context.Context) (time.Duration, error) {
+	latency, err := timeHealthCheck(func() error {
+		_, err := s.ScrapeBitcoinDominance(ctx)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("TradingView scraper health check failed: %w", err)
+		return latency, fmt.Errorf("TradingView scraper health check failed: %w", err)
 	}
-	return nil
+	return latency, nil
 }
 
 // Alternative scraping method using TradingView's mobile API (if available)
-func (s *TradingViewScraper) ScrapeBitcoinDominanceAlternative() (*BitcoinDominanceData, error) {
+func (s *TradingViewScraper) ScrapeBitcoinDominanceAlternative(ctx context.Context) (*BitcoinDominanceData, error) {
 	// This is a backup method that could use TradingView's mobile endpoints or API
 	// For now, we'll use the main scraping method
-	s.logger.Debug("Using alternative scraping method for Bitcoin dominance")
-	return s.ScrapeBitcoinDominance()
+	s.logger.WithContext(ctx).Debug("Using alternative scraping method for Bitcoin dominance")
+	return s.ScrapeBitcoinDominance(ctx)
 }
 
 // GetHistoricalDominance could be implemented to get historical data
@@ -0,0 +1,287 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findAttempt(attempts []ExtractionAttempt, strategy string) *ExtractionAttempt {
+	for i := range attempts {
+		if attempts[i].Strategy == strategy {
+			return &attempts[i]
+		}
+	}
+	return nil
+}
+
+func TestExtractDominanceFromHTML_Strategies(t *testing.T) {
+	scraper := NewTradingViewScraper(logger.New("test"))
+
+	tests := []struct {
+		name              string
+		html              string
+		expectError       bool
+		expectedDominance float64
+		matched           []string
+		unmatched         []string
+	}{
+		{
+			name:              "Dominance label with 24h change",
+			html:              `<h1>Bitcoin Dominance</h1><span>58.42%</span><div>-0.35% past 24h</div>`,
+			expectedDominance: 58.42,
+			matched:           []string{StrategyDominanceLabel, StrategyChange24h},
+		},
+		{
+			name:              "Value class markup",
+			html:              `<div class="js-symbol-last quote-value">61.10%</div>`,
+			expectedDominance: 61.10,
+			matched:           []string{StrategyValueClass},
+			unmatched:         []string{StrategyDominanceLabel, StrategyChange24h, StrategyChangeLabel},
+		},
+		{
+			name:        "Out of range value",
+			html:        `<h1>BTC.D</h1><span>5.5%</span>`,
+			expectError: true,
+			matched:     []string{StrategyDominanceLabel},
+		},
+		{
+			name:        "Markup matching no strategy",
+			html:        `<html><body><div id="app"></div><script src="bundle.js"></script></body></html>`,
+			expectError: true,
+			unmatched:   []string{StrategyDominanceLabel, StrategyValueClass, StrategyChange24h, StrategyChangeLabel},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, attempts, err := scraper.extractDominanceFromHTML(tt.html)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, data)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedDominance, data.CurrentDominance)
+			}
+
+			for _, strategy := range tt.matched {
+				attempt := findAttempt(attempts, strategy)
+				require.NotNil(t, attempt, "strategy %s should be recorded", strategy)
+				assert.True(t, attempt.Matched, "strategy %s should match", strategy)
+			}
+			for _, strategy := range tt.unmatched {
+				attempt := findAttempt(attempts, strategy)
+				require.NotNil(t, attempt, "strategy %s should be recorded", strategy)
+				assert.False(t, attempt.Matched, "strategy %s should not match", strategy)
+			}
+		})
+	}
+}
+
+func TestScrapeBitcoinDominance_Diagnostics(t *testing.T) {
+	html := `<div class="page">redesigned markup without values</div>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, html)
+	}))
+	defer server.Close()
+
+	scraper := NewTradingViewScraperWithURLs(server.URL, server.URL, logger.New("test"))
+	assert.Nil(t, scraper.LastScrapeDiagnostics())
+
+	_, err := scraper.ScrapeBitcoinDominance(context.Background())
+	require.Error(t, err)
+
+	diagnostics := scraper.LastScrapeDiagnostics()
+	require.NotNil(t, diagnostics)
+	assert.Empty(t, diagnostics.Source)
+	assert.Contains(t, diagnostics.ScrapeError, "could not extract")
+	require.NotEmpty(t, diagnostics.Attempts)
+	for _, attempt := range diagnostics.Attempts {
+		assert.False(t, attempt.Matched, "strategy %s should not match", attempt.Strategy)
+	}
+
+	// A successful scrape replaces the failure diagnostics
+	html = `<title>BTC.D</title><span>59.30%</span>`
+	data, err := scraper.ScrapeBitcoinDominance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 59.30, data.CurrentDominance)
+	assert.Equal(t, entities.DataSourceTradingView, data.DataSource)
+
+	diagnostics = scraper.LastScrapeDiagnostics()
+	assert.Equal(t, entities.DataSourceTradingView, diagnostics.Source)
+	assert.Empty(t, diagnostics.ScrapeError)
+	assert.True(t, findAttempt(diagnostics.Attempts, StrategyDominanceLabel).Matched)
+}
+
+func TestGetBitcoinDominanceWithFallback_Diagnostics(t *testing.T) {
+	tradingView := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<div>nothing useful here</div>`)
+	}))
+	defer tradingView.Close()
+
+	t.Run("CoinGecko value is tagged as its source", func(t *testing.T) {
+		coinGecko := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":{"market_cap_percentage":{"btc":57.5,"eth":12.1}}}`)
+		}))
+		defer coinGecko.Close()
+
+		scraper := NewTradingViewScraperWithURLs(tradingView.URL, coinGecko.URL, logger.New("test"))
+		data, err := scraper.GetBitcoinDominanceWithFallback(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 57.5, data.CurrentDominance)
+		assert.Equal(t, entities.DataSourceCoinGecko, data.DataSource)
+
+		diagnostics := scraper.LastScrapeDiagnostics()
+		require.NotNil(t, diagnostics)
+		assert.Equal(t, entities.DataSourceCoinGecko, diagnostics.Source)
+		assert.False(t, diagnostics.Degraded)
+		assert.Empty(t, diagnostics.Attempts)
+	})
+
+	t.Run("All sources failing degrades to fallback data", func(t *testing.T) {
+		coinGecko := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer coinGecko.Close()
+
+		scraper := NewTradingViewScraperWithURLs(tradingView.URL, coinGecko.URL, logger.New("test"))
+		scraper.SetFallback(nil, 58.2)
+		data, err := scraper.GetBitcoinDominanceWithFallback(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, entities.DataSourceFallback, data.DataSource)
+		assert.Equal(t, 58.2, data.CurrentDominance)
+		assert.True(t, data.IsFallback())
+
+		diagnostics := scraper.LastScrapeDiagnostics()
+		require.NotNil(t, diagnostics)
+		assert.Equal(t, entities.DataSourceFallback, diagnostics.Source)
+		assert.True(t, diagnostics.Degraded)
+		assert.Contains(t, diagnostics.CoinGeckoError, "500")
+		assert.Contains(t, diagnostics.ScrapeError, "could not extract")
+		require.NotEmpty(t, diagnostics.Attempts)
+		for _, attempt := range diagnostics.Attempts {
+			assert.False(t, attempt.Matched, "strategy %s should not match", attempt.Strategy)
+		}
+	})
+}
+
+func TestScrapeBitcoinDominance_BodyLimit(t *testing.T) {
+	// The dominance value sits past the limit, so only a full read would find it
+	page := strings.Repeat("<div>padding</div>", 1000) + `<title>BTC.D</title><span>59.30%</span>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	scraper := NewTradingViewScraperWithURLs(server.URL, server.URL, logger.New("test"))
+	scraper.maxBodyBytes = 1024
+
+	_, err := scraper.ScrapeBitcoinDominance(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds 1024 bytes")
+
+	scraper.maxBodyBytes = int64(len(page))
+	data, err := scraper.ScrapeBitcoinDominance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 59.30, data.CurrentDominance)
+}
+
+func TestScrapeBitcoinDominance_ContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	scraper := NewTradingViewScraperWithURLs(server.URL, server.URL, logger.New("test"))
+
+	t.Run("Scrape stops at the deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := scraper.ScrapeBitcoinDominance(ctx)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("Fallback is not served for a cancelled lookup", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		data, err := scraper.GetBitcoinDominanceWithFallback(ctx)
+
+		require.Error(t, err)
+		assert.Nil(t, data)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestGetBitcoinDominanceWithFallback_LastKnownGood(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	storedAt := time.Now().Add(-3 * time.Hour)
+	lastKnown := func(ctx context.Context) (*entities.BitcoinDominance, error) {
+		return &entities.BitcoinDominance{CurrentDominance: 54.3, PreviousDominance: 54.9, Change24h: -0.6, LastUpdated: storedAt}, nil
+	}
+	nothingStored := func(ctx context.Context) (*entities.BitcoinDominance, error) {
+		return nil, fmt.Errorf("no dominance data found")
+	}
+
+	t.Run("Last stored dominance is preferred over the configured value", func(t *testing.T) {
+		scraper := NewTradingViewScraperWithURLs(failing.URL, failing.URL, logger.New("test"))
+		scraper.SetFallback(lastKnown, 60.77)
+
+		data, err := scraper.GetBitcoinDominanceWithFallback(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 54.3, data.CurrentDominance)
+		assert.Equal(t, -0.6, data.Change24h)
+		assert.Equal(t, storedAt, data.LastUpdated)
+		assert.Equal(t, entities.DataSourceLastKnownGood, data.DataSource)
+		assert.True(t, scraper.LastScrapeDiagnostics().Degraded)
+	})
+
+	t.Run("Configured value is used when nothing is stored", func(t *testing.T) {
+		scraper := NewTradingViewScraperWithURLs(failing.URL, failing.URL, logger.New("test"))
+		scraper.SetFallback(nothingStored, 60.77)
+
+		data, err := scraper.GetBitcoinDominanceWithFallback(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 60.77, data.CurrentDominance)
+		assert.Equal(t, entities.DataSourceFallback, data.DataSource)
+	})
+
+	t.Run("Error without any fallback", func(t *testing.T) {
+		scraper := NewTradingViewScraperWithURLs(failing.URL, failing.URL, logger.New("test"))
+		scraper.SetFallback(nothingStored, 0)
+
+		data, err := scraper.GetBitcoinDominanceWithFallback(context.Background())
+
+		assert.Error(t, err)
+		assert.Nil(t, data)
+	})
+}
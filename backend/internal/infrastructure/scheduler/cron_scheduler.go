@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"crypto-indicator-dashboard/pkg/logger"
@@ -11,32 +12,70 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
+// scheduleParser parses six-field cron expressions with a leading seconds field.
+// It is shared by schedule validation and the cron runner so both agree.
+var scheduleParser = cron.NewParser(
+	cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// DefaultDrainTimeout is how long Stop waits for running jobs before cancelling them
+const DefaultDrainTimeout = 30 * time.Second
+
+// DefaultFailureThreshold is the number of consecutive failures that marks a job unhealthy
+const DefaultFailureThreshold = 5
+
 // CronScheduler implements JobScheduler using the robfig/cron library
 type CronScheduler struct {
-	cron        *cron.Cron
-	jobs        map[string]Job
-	cronEntries map[string]cron.EntryID
-	executions  map[string][]*JobExecution
-	stats       map[string]*JobStats
-	logger      logger.Logger
-	mu          sync.RWMutex
-	isRunning   bool
-	ctx         context.Context
-	cancel      context.CancelFunc
+	cron         *cron.Cron
+	jobs         map[string]Job
+	cronEntries  map[string]cron.EntryID
+	executions   map[string][]*JobExecution
+	stats        map[string]*JobStats
+	logger       logger.Logger
+	mu           sync.RWMutex
+	isRunning    bool
+	ctx          context.Context
+	cancel       context.CancelFunc
+	drainTimeout time.Duration
+	activeJobs   int64
+	failure      FailurePolicy
 }
 
 // NewCronScheduler creates a new cron-based job scheduler
 func NewCronScheduler(log logger.Logger) *CronScheduler {
 	return &CronScheduler{
-		cron:        cron.New(cron.WithSeconds()),
-		jobs:        make(map[string]Job),
-		cronEntries: make(map[string]cron.EntryID),
-		executions:  make(map[string][]*JobExecution),
-		stats:       make(map[string]*JobStats),
-		logger:      log,
+		cron:         cron.New(cron.WithParser(scheduleParser)),
+		jobs:         make(map[string]Job),
+		cronEntries:  make(map[string]cron.EntryID),
+		executions:   make(map[string][]*JobExecution),
+		stats:        make(map[string]*JobStats),
+		logger:       log,
+		drainTimeout: DefaultDrainTimeout,
+		failure:      FailurePolicy{Threshold: DefaultFailureThreshold},
 	}
 }
 
+// SetDrainTimeout sets how long Stop waits for running jobs to finish before
+// cancelling their context. A non-positive timeout cancels running jobs immediately.
+func (cs *CronScheduler) SetDrainTimeout(timeout time.Duration) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.drainTimeout = timeout
+}
+
+// SetFailurePolicy sets how repeatedly failing jobs are flagged and whether they
+// are automatically unscheduled
+func (cs *CronScheduler) SetFailurePolicy(policy FailurePolicy) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.failure = policy
+}
+
+// ActiveJobs returns the number of job executions currently in progress
+func (cs *CronScheduler) ActiveJobs() int {
+	return int(atomic.LoadInt64(&cs.activeJobs))
+}
+
 // Start begins the job scheduler
 func (cs *CronScheduler) Start(ctx context.Context) error {
 	cs.mu.Lock()
@@ -54,23 +93,47 @@ func (cs *CronScheduler) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop gracefully shuts down the job scheduler
+// Stop gracefully shuts down the job scheduler. No new runs are started; running
+// jobs get up to the drain timeout to finish before their context is cancelled.
 func (cs *CronScheduler) Stop() error {
 	cs.mu.Lock()
-	defer cs.mu.Unlock()
-
 	if !cs.isRunning {
+		cs.mu.Unlock()
 		return fmt.Errorf("scheduler is not running")
 	}
+	cs.isRunning = false
+	cancel := cs.cancel
+	drainTimeout := cs.drainTimeout
+	// Release the lock while draining: finishing jobs need it to record their stats
+	cs.mu.Unlock()
+
+	// stopCtx is done once every running job has returned
+	stopCtx := cs.cron.Stop()
+
+	if active := cs.ActiveJobs(); active > 0 {
+		cs.logger.Info("Waiting for running jobs to finish",
+			"active_jobs", active,
+			"drain_timeout", drainTimeout)
+	}
+
+	if drainTimeout > 0 {
+		timer := time.NewTimer(drainTimeout)
+		defer timer.Stop()
 
-	if cs.cancel != nil {
-		cs.cancel()
+		select {
+		case <-stopCtx.Done():
+		case <-timer.C:
+			cs.logger.Warn("Drain timeout exceeded, cancelling running jobs",
+				"active_jobs", cs.ActiveJobs(),
+				"drain_timeout", drainTimeout)
+		}
 	}
 
-	stopCtx := cs.cron.Stop()
+	if cancel != nil {
+		cancel()
+	}
 	<-stopCtx.Done()
 
-	cs.isRunning = false
 	cs.logger.Info("Job scheduler stopped")
 	return nil
 }
@@ -88,7 +151,7 @@ func (cs *CronScheduler) AddJob(job Job) error {
 	}
 
 	// Validate cron schedule
-	_, err := cron.ParseStandard(job.Schedule())
+	_, err := scheduleParser.Parse(job.Schedule())
 	if err != nil {
 		return fmt.Errorf("invalid cron schedule '%s': %w", job.Schedule(), err)
 	}
@@ -109,6 +172,7 @@ func (cs *CronScheduler) AddJob(job Job) error {
 	cs.stats[jobID] = &JobStats{
 		JobID:   jobID,
 		JobName: job.Name(),
+		Healthy: true,
 	}
 
 	cs.logger.Info("Job added to scheduler",
@@ -124,13 +188,14 @@ func (cs *CronScheduler) RemoveJob(jobID string) error {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 
-	entryID, exists := cs.cronEntries[jobID]
-	if !exists {
+	if _, exists := cs.jobs[jobID]; !exists {
 		return fmt.Errorf("job with ID '%s' not found", jobID)
 	}
 
-	// Remove from cron
-	cs.cron.Remove(entryID)
+	// Remove from cron; disabled jobs are already unscheduled
+	if entryID, scheduled := cs.cronEntries[jobID]; scheduled {
+		cs.cron.Remove(entryID)
+	}
 
 	// Clean up
 	delete(cs.jobs, jobID)
@@ -142,6 +207,35 @@ func (cs *CronScheduler) RemoveJob(jobID string) error {
 	return nil
 }
 
+// EnableJob reschedules a job that was disabled after repeated failures and
+// resets its health state
+func (cs *CronScheduler) EnableJob(jobID string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	job, exists := cs.jobs[jobID]
+	if !exists {
+		return fmt.Errorf("job with ID '%s' not found", jobID)
+	}
+
+	stats := cs.stats[jobID]
+	if stats.Disabled {
+		entryID, err := cs.cron.AddFunc(job.Schedule(), cs.wrapJob(job))
+		if err != nil {
+			return fmt.Errorf("failed to reschedule job: %w", err)
+		}
+		cs.cronEntries[jobID] = entryID
+	}
+
+	stats.Disabled = false
+	stats.Healthy = true
+	stats.ConsecutiveFailures = 0
+	stats.UnhealthySince = time.Time{}
+
+	cs.logger.Info("Job enabled", "job_id", jobID)
+	return nil
+}
+
 // GetJob retrieves a job by ID
 func (cs *CronScheduler) GetJob(jobID string) (Job, bool) {
 	cs.mu.RLock()
@@ -229,6 +323,9 @@ func (cs *CronScheduler) wrapJob(job Job) func() {
 		default:
 		}
 
+		atomic.AddInt64(&cs.activeJobs, 1)
+		defer atomic.AddInt64(&cs.activeJobs, -1)
+
 		jobID := job.ID()
 		startTime := time.Now()
 
@@ -274,15 +371,25 @@ func (cs *CronScheduler) wrapJob(job Job) func() {
 		}
 
 		// Update statistics and execution history
-		cs.updateJobStats(jobID, execution)
+		if unhealthy, notify := cs.updateJobStats(jobID, execution); unhealthy != nil && notify != nil {
+			notify(*unhealthy)
+		}
 	}
 }
 
-// updateJobStats updates job statistics and execution history
-func (cs *CronScheduler) updateJobStats(jobID string, execution *JobExecution) {
+// updateJobStats updates job statistics and execution history. When the execution
+// makes the job unhealthy it returns a stats snapshot and the policy's OnUnhealthy
+// callback, so the caller can notify without holding the lock.
+func (cs *CronScheduler) updateJobStats(jobID string, execution *JobExecution) (*JobStats, func(JobStats)) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 
+	stats, exists := cs.stats[jobID]
+	if !exists {
+		// The job was removed while it was running
+		return nil, nil
+	}
+
 	// Add to execution history (keep last 100 executions)
 	executions := cs.executions[jobID]
 	executions = append(executions, execution)
@@ -292,16 +399,24 @@ func (cs *CronScheduler) updateJobStats(jobID string, execution *JobExecution) {
 	cs.executions[jobID] = executions
 
 	// Update statistics
-	stats := cs.stats[jobID]
 	stats.TotalExecutions++
 	stats.LastExecution = execution.EndTime
 
+	becameUnhealthy := false
 	if execution.Status == "success" {
 		stats.SuccessfulRuns++
 		stats.LastSuccess = execution.EndTime
+		if !stats.Healthy {
+			cs.logger.Info("Job recovered", "job_id", jobID, "job_name", stats.JobName)
+		}
+		stats.ConsecutiveFailures = 0
+		stats.Healthy = true
+		stats.UnhealthySince = time.Time{}
 	} else {
 		stats.FailedRuns++
 		stats.LastError = execution.Error
+		stats.ConsecutiveFailures++
+		becameUnhealthy = cs.checkJobHealth(jobID, stats)
 	}
 
 	// Calculate average duration
@@ -319,5 +434,43 @@ func (cs *CronScheduler) updateJobStats(jobID string, execution *JobExecution) {
 	if entryID, exists := cs.cronEntries[jobID]; exists {
 		entry := cs.cron.Entry(entryID)
 		stats.NextScheduled = entry.Next
+	} else {
+		stats.NextScheduled = time.Time{}
 	}
+
+	if !becameUnhealthy {
+		return nil, nil
+	}
+	statsCopy := *stats
+	return &statsCopy, cs.failure.OnUnhealthy
+}
+
+// checkJobHealth flags a job unhealthy once it reaches the failure threshold and
+// disables it if configured. It reports whether the job just became unhealthy.
+// The caller must hold the lock.
+func (cs *CronScheduler) checkJobHealth(jobID string, stats *JobStats) bool {
+	threshold := cs.failure.Threshold
+	if threshold <= 0 || !stats.Healthy || stats.ConsecutiveFailures < threshold {
+		return false
+	}
+
+	stats.Healthy = false
+	stats.UnhealthySince = time.Now()
+
+	if cs.failure.AutoDisable {
+		if entryID, scheduled := cs.cronEntries[jobID]; scheduled {
+			cs.cron.Remove(entryID)
+			delete(cs.cronEntries, jobID)
+		}
+		stats.Disabled = true
+	}
+
+	cs.logger.Error("Job marked unhealthy after repeated failures",
+		"job_id", jobID,
+		"job_name", stats.JobName,
+		"consecutive_failures", stats.ConsecutiveFailures,
+		"last_error", stats.LastError,
+		"disabled", stats.Disabled)
+
+	return true
 }
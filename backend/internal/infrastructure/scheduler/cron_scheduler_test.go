@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowJob runs for a fixed duration unless its context is cancelled first
+type slowJob struct {
+	*BaseJob
+	duration  time.Duration
+	started   chan struct{}
+	completed chan bool
+}
+
+func newSlowJob(duration time.Duration) *slowJob {
+	return &slowJob{
+		BaseJob:   NewBaseJob("slow_job", "Slow job", "* * * * * *"),
+		duration:  duration,
+		started:   make(chan struct{}, 1),
+		completed: make(chan bool, 1),
+	}
+}
+
+func (j *slowJob) Execute(ctx context.Context) error {
+	select {
+	case j.started <- struct{}{}:
+	default:
+		// Only the first run is observed
+		return nil
+	}
+
+	select {
+	case <-time.After(j.duration):
+		j.completed <- true
+		return nil
+	case <-ctx.Done():
+		j.completed <- false
+		return ctx.Err()
+	}
+}
+
+func startSlowJob(t *testing.T, drainTimeout, jobDuration time.Duration) (*CronScheduler, *slowJob) {
+	cs := NewCronScheduler(logger.New("test"))
+	cs.SetDrainTimeout(drainTimeout)
+
+	job := newSlowJob(jobDuration)
+	require.NoError(t, cs.AddJob(job))
+	require.NoError(t, cs.Start(context.Background()))
+
+	select {
+	case <-job.started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("job did not start")
+	}
+	assert.Equal(t, 1, cs.ActiveJobs())
+
+	return cs, job
+}
+
+func TestCronScheduler_StopDrainsRunningJobs(t *testing.T) {
+	cs, job := startSlowJob(t, 5*time.Second, 300*time.Millisecond)
+
+	require.NoError(t, cs.Stop())
+
+	select {
+	case completed := <-job.completed:
+		assert.True(t, completed, "job should finish before its context is cancelled")
+	default:
+		t.Fatal("Stop returned before the running job finished")
+	}
+	assert.Equal(t, 0, cs.ActiveJobs())
+	assert.False(t, cs.IsRunning())
+
+	stats, ok := cs.GetJobStats(job.ID())
+	require.True(t, ok)
+	assert.Equal(t, 1, stats.SuccessfulRuns)
+}
+
+func TestCronScheduler_StopCancelsJobsAfterDrainTimeout(t *testing.T) {
+	drainTimeout := 100 * time.Millisecond
+	cs, job := startSlowJob(t, drainTimeout, 10*time.Second)
+
+	stopStart := time.Now()
+	require.NoError(t, cs.Stop())
+	elapsed := time.Since(stopStart)
+
+	select {
+	case completed := <-job.completed:
+		assert.False(t, completed, "job should be cancelled once the drain timeout expires")
+	default:
+		t.Fatal("Stop returned before the running job exited")
+	}
+	assert.GreaterOrEqual(t, elapsed, drainTimeout, "job should not be cancelled before the drain timeout")
+	assert.Less(t, elapsed, 5*time.Second)
+	assert.Equal(t, 0, cs.ActiveJobs())
+}
+
+func TestCronScheduler_StopWhenNotRunning(t *testing.T) {
+	cs := NewCronScheduler(logger.New("test"))
+	assert.Error(t, cs.Stop())
+}
+
+func TestCronScheduler_AddJobValidatesSecondsSchedule(t *testing.T) {
+	cs := NewCronScheduler(logger.New("test"))
+
+	assert.NoError(t, cs.AddJob(&slowJob{BaseJob: NewBaseJob("valid", "valid", DefaultPortfolioValuationSchedule)}))
+	assert.Error(t, cs.AddJob(&slowJob{BaseJob: NewBaseJob("five_fields", "five_fields", "*/5 * * * *")}))
+	assert.Error(t, cs.AddJob(&slowJob{BaseJob: NewBaseJob("invalid", "invalid", "not a schedule")}))
+}
+
+// flakyJob fails while fail is true
+type flakyJob struct {
+	*BaseJob
+	fail bool
+}
+
+func (j *flakyJob) Execute(ctx context.Context) error {
+	if j.fail {
+		return errors.New("upstream unavailable")
+	}
+	return nil
+}
+
+// startFlakyJob registers a job on a schedule that never fires during the test,
+// so runs are driven directly through the wrapped job
+func startFlakyJob(t *testing.T, policy FailurePolicy) (*CronScheduler, *flakyJob, func()) {
+	cs := NewCronScheduler(logger.New("test"))
+	cs.SetFailurePolicy(policy)
+
+	job := &flakyJob{BaseJob: NewBaseJob("flaky_job", "Flaky job", "0 0 0 1 1 *"), fail: true}
+	require.NoError(t, cs.AddJob(job))
+	require.NoError(t, cs.Start(context.Background()))
+	t.Cleanup(func() { cs.Stop() })
+
+	return cs, job, cs.wrapJob(job)
+}
+
+func TestCronScheduler_MarksJobUnhealthyAfterThreshold(t *testing.T) {
+	var notified []JobStats
+	cs, job, run := startFlakyJob(t, FailurePolicy{
+		Threshold:   3,
+		OnUnhealthy: func(stats JobStats) { notified = append(notified, stats) },
+	})
+
+	for i := 0; i < 2; i++ {
+		run()
+	}
+	stats, ok := cs.GetJobStats(job.ID())
+	require.True(t, ok)
+	assert.True(t, stats.Healthy, "job stays healthy below the threshold")
+	assert.Equal(t, 2, stats.ConsecutiveFailures)
+
+	run()
+	run()
+	stats, _ = cs.GetJobStats(job.ID())
+	assert.False(t, stats.Healthy)
+	assert.False(t, stats.Disabled, "job is not disabled unless configured")
+	assert.False(t, stats.UnhealthySince.IsZero())
+	assert.Equal(t, 4, stats.ConsecutiveFailures)
+	assert.Equal(t, "upstream unavailable", stats.LastError)
+	require.Len(t, notified, 1, "notification fires once when the job becomes unhealthy")
+	assert.Equal(t, 3, notified[0].ConsecutiveFailures)
+
+	// A successful run recovers the job
+	job.fail = false
+	run()
+	stats, _ = cs.GetJobStats(job.ID())
+	assert.True(t, stats.Healthy)
+	assert.Equal(t, 0, stats.ConsecutiveFailures)
+	assert.Equal(t, 4, stats.FailedRuns)
+}
+
+func TestCronScheduler_AutoDisablesUnhealthyJob(t *testing.T) {
+	cs, job, run := startFlakyJob(t, FailurePolicy{Threshold: 2, AutoDisable: true})
+
+	run()
+	run()
+
+	stats, ok := cs.GetJobStats(job.ID())
+	require.True(t, ok)
+	assert.False(t, stats.Healthy)
+	assert.True(t, stats.Disabled)
+	assert.True(t, stats.NextScheduled.IsZero(), "disabled job has no next run")
+	assert.Len(t, cs.cron.Entries(), 0, "disabled job is removed from the cron schedule")
+
+	require.NoError(t, cs.EnableJob(job.ID()))
+	stats, _ = cs.GetJobStats(job.ID())
+	assert.True(t, stats.Healthy)
+	assert.False(t, stats.Disabled)
+	assert.Equal(t, 0, stats.ConsecutiveFailures)
+	assert.Len(t, cs.cron.Entries(), 1)
+
+	// Disabled jobs can still be removed
+	run()
+	run()
+	require.NoError(t, cs.RemoveJob(job.ID()))
+	_, ok = cs.GetJobStats(job.ID())
+	assert.False(t, ok)
+}
+
+func TestCronScheduler_ZeroThresholdDisablesHealthTracking(t *testing.T) {
+	cs, job, run := startFlakyJob(t, FailurePolicy{Threshold: 0, AutoDisable: true})
+
+	for i := 0; i < 10; i++ {
+		run()
+	}
+
+	stats, _ := cs.GetJobStats(job.ID())
+	assert.True(t, stats.Healthy)
+	assert.False(t, stats.Disabled)
+	assert.Equal(t, 10, stats.ConsecutiveFailures)
+}
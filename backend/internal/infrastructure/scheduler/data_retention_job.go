@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	// DataRetentionJobID is the scheduler ID of the indicator data retention job
+	DataRetentionJobID = "indicator_data_retention"
+	// DefaultDataRetentionSchedule runs the cleanup daily at 03:00
+	DefaultDataRetentionSchedule = "0 0 3 * * *"
+	// DefaultIndicatorRetention keeps one year of indicator history
+	DefaultIndicatorRetention = 365 * 24 * time.Hour
+)
+
+// DataRetentionJob deletes indicators older than the retention window from the
+// indicators table. TimescaleDB deployments also prune via retention policies;
+// this covers databases without them, such as SQLite in development.
+type DataRetentionJob struct {
+	*BaseJob
+	indicatorRepo repositories.IndicatorRepository
+	retention     time.Duration
+	now           func() time.Time
+	logger        logger.Logger
+}
+
+// NewDataRetentionJob creates a job that removes indicators older than retention on the given schedule
+func NewDataRetentionJob(
+	schedule string,
+	retention time.Duration,
+	indicatorRepo repositories.IndicatorRepository,
+	log logger.Logger,
+) *DataRetentionJob {
+	if schedule == "" {
+		schedule = DefaultDataRetentionSchedule
+	}
+	if retention <= 0 {
+		retention = DefaultIndicatorRetention
+	}
+
+	return &DataRetentionJob{
+		BaseJob:       NewBaseJob(DataRetentionJobID, "Indicator data retention", schedule),
+		indicatorRepo: indicatorRepo,
+		retention:     retention,
+		now:           time.Now,
+		logger:        log.With("job", DataRetentionJobID),
+	}
+}
+
+// Execute deletes indicators created before the retention cutoff
+func (j *DataRetentionJob) Execute(ctx context.Context) error {
+	cutoff := j.now().Add(-j.retention)
+
+	deleted, err := j.indicatorRepo.CleanupOldData(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to delete indicators older than %s: %w", cutoff.Format(time.RFC3339), err)
+	}
+
+	j.logger.Info("Indicator data retention completed", "deleted", deleted, "cutoff", cutoff, "retention", j.retention)
+	return nil
+}
+
+// OnError logs failed cleanup runs
+func (j *DataRetentionJob) OnError(err error, duration time.Duration) {
+	j.logger.Error("Indicator data retention failed", "error", err, "duration", duration)
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataRetentionJob_RemovesOnlyOldRows(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	testDB.CreateTables(t, testutil.IndicatorsTableDDL)
+
+	now := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+	rows := []struct {
+		name string
+		age  time.Duration
+	}{
+		{"expired_a", 400 * 24 * time.Hour},
+		{"expired_b", 31 * 24 * time.Hour},
+		{"recent_a", 29 * 24 * time.Hour},
+		{"recent_b", time.Hour},
+	}
+	for _, row := range rows {
+		created := now.Add(-row.age)
+		require.NoError(t, testDB.DB.Create(&entities.Indicator{
+			Name: row.name, Type: "market", Value: 1, Timestamp: created, CreatedAt: created, UpdatedAt: created,
+		}).Error)
+	}
+
+	repo := database.NewIndicatorRepository(database.NewDBProvider(testDB.DB, nil), testDB.Logger)
+	job := NewDataRetentionJob("", 30*24*time.Hour, repo, logger.New("test"))
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Execute(context.Background()))
+
+	var remaining []string
+	require.NoError(t, testDB.DB.Model(&entities.Indicator{}).Order("name").Pluck("name", &remaining).Error)
+	assert.Equal(t, []string{"recent_a", "recent_b"}, remaining)
+}
+
+func TestDataRetentionJob_Defaults(t *testing.T) {
+	job := NewDataRetentionJob("", 0, &testutil.MockIndicatorRepository{}, logger.New("test"))
+
+	assert.Equal(t, DataRetentionJobID, job.ID())
+	assert.Equal(t, DefaultDataRetentionSchedule, job.Schedule())
+	assert.Equal(t, DefaultIndicatorRetention, job.retention)
+}
+
+func TestDataRetentionJob_RepositoryError(t *testing.T) {
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("CleanupOldData", mock.Anything, mock.Anything).Return(int64(0), errors.New("database is locked"))
+
+	job := NewDataRetentionJob("", time.Hour, repo, logger.New("test"))
+
+	err := job.Execute(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database is locked")
+}
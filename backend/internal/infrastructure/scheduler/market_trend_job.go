@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	// MarketTrendJobID is the scheduler ID of the market trend classification job
+	MarketTrendJobID = "market_trend"
+	// DefaultMarketTrendSchedule classifies the market trend daily at 00:05
+	DefaultMarketTrendSchedule = "0 5 0 * * *"
+)
+
+// IndicatorCalculator calculates and stores an indicator. Indicator services satisfy it.
+type IndicatorCalculator interface {
+	Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error)
+}
+
+// MarketTrendJob stores a daily market trend classification so trend history can be charted
+type MarketTrendJob struct {
+	*BaseJob
+	calculator IndicatorCalculator
+	logger     logger.Logger
+}
+
+// NewMarketTrendJob creates a job that classifies the market trend on the given schedule
+func NewMarketTrendJob(schedule string, calculator IndicatorCalculator, log logger.Logger) *MarketTrendJob {
+	if schedule == "" {
+		schedule = DefaultMarketTrendSchedule
+	}
+
+	return &MarketTrendJob{
+		BaseJob:    NewBaseJob(MarketTrendJobID, "Market trend classification", schedule),
+		calculator: calculator,
+		logger:     log.With("job", MarketTrendJobID),
+	}
+}
+
+// Execute classifies and stores the current market trend
+func (j *MarketTrendJob) Execute(ctx context.Context) error {
+	indicator, err := j.calculator.Calculate(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to classify market trend: %w", err)
+	}
+
+	j.logger.Info("Market trend classified", "trend", indicator.StringValue, "avg_change_24h", indicator.Value)
+	return nil
+}
+
+// OnError logs failed classification runs
+func (j *MarketTrendJob) OnError(err error, duration time.Duration) {
+	j.logger.Error("Market trend classification failed", "error", err, "duration", duration)
+}
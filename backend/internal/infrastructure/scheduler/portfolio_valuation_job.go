@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	// PortfolioValuationJobID is the scheduler ID of the portfolio valuation refresh job
+	PortfolioValuationJobID = "portfolio_valuation_refresh"
+	// DefaultPortfolioValuationSchedule refreshes valuations every five minutes
+	DefaultPortfolioValuationSchedule = "0 */5 * * * *"
+)
+
+// DrawdownEvaluator checks a freshly valued portfolio against its drawdown alerts
+type DrawdownEvaluator interface {
+	EvaluateDrawdown(ctx context.Context, portfolio *entities.Portfolio) error
+}
+
+// PortfolioValuationJob periodically re-prices the holdings of every active portfolio and
+// evaluates their drawdown alerts
+type PortfolioValuationJob struct {
+	*BaseJob
+	portfolioRepo repositories.PortfolioRepository
+	valuations    services.PortfolioValuationService
+	alerts        DrawdownEvaluator
+	logger        logger.Logger
+}
+
+// NewPortfolioValuationJob creates a job that refreshes portfolio valuations on the given
+// schedule; alerts may be nil to skip drawdown alert evaluation
+func NewPortfolioValuationJob(
+	schedule string,
+	portfolioRepo repositories.PortfolioRepository,
+	valuations services.PortfolioValuationService,
+	alerts DrawdownEvaluator,
+	log logger.Logger,
+) *PortfolioValuationJob {
+	if schedule == "" {
+		schedule = DefaultPortfolioValuationSchedule
+	}
+
+	return &PortfolioValuationJob{
+		BaseJob:       NewBaseJob(PortfolioValuationJobID, "Portfolio valuation refresh", schedule),
+		portfolioRepo: portfolioRepo,
+		valuations:    valuations,
+		alerts:        alerts,
+		logger:        log.With("job", PortfolioValuationJobID),
+	}
+}
+
+// Execute refreshes every active portfolio, continuing past individual failures. Alert
+// evaluation failures are logged but do not fail the run, since the valuation was stored.
+func (j *PortfolioValuationJob) Execute(ctx context.Context) error {
+	portfolioIDs, err := j.portfolioRepo.GetActivePortfolioIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list active portfolios: %w", err)
+	}
+
+	var failed int
+	for _, portfolioID := range portfolioIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		portfolio, err := j.valuations.RefreshValuations(ctx, portfolioID)
+		if err != nil {
+			failed++
+			j.logger.Warn("Failed to refresh portfolio valuation", "portfolio_id", portfolioID, "error", err)
+			continue
+		}
+
+		if j.alerts != nil {
+			if err := j.alerts.EvaluateDrawdown(ctx, portfolio); err != nil {
+				j.logger.Warn("Failed to evaluate drawdown alerts", "portfolio_id", portfolioID, "error", err)
+			}
+		}
+	}
+
+	j.logger.Info("Portfolio valuations refreshed", "portfolios", len(portfolioIDs), "failed", failed)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to refresh %d of %d portfolios", failed, len(portfolioIDs))
+	}
+	return nil
+}
+
+// OnError logs failed refresh runs
+func (j *PortfolioValuationJob) OnError(err error, duration time.Duration) {
+	j.logger.Error("Portfolio valuation refresh failed", "error", err, "duration", duration)
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubValuations values each portfolio at a fixed total, failing for those in failing
+type stubValuations struct {
+	values  map[uint]float64
+	failing map[uint]bool
+}
+
+func (s *stubValuations) RefreshValuations(ctx context.Context, portfolioID uint) (*entities.Portfolio, error) {
+	if s.failing[portfolioID] {
+		return nil, errors.New("price unavailable")
+	}
+	return &entities.Portfolio{ID: portfolioID, TotalValue: s.values[portfolioID]}, nil
+}
+
+// recordingEvaluator records the portfolios whose drawdown alerts were evaluated, failing
+// while err is set
+type recordingEvaluator struct {
+	evaluated []entities.Portfolio
+	err       error
+}
+
+func (e *recordingEvaluator) EvaluateDrawdown(ctx context.Context, portfolio *entities.Portfolio) error {
+	e.evaluated = append(e.evaluated, *portfolio)
+	return e.err
+}
+
+func TestPortfolioValuationJob_EvaluatesDrawdownAfterRefresh(t *testing.T) {
+	portfolios := &testutil.MockPortfolioRepository{}
+	portfolios.On("GetActivePortfolioIDs", mock.Anything).Return([]uint{1, 2, 3}, nil)
+
+	valuations := &stubValuations{
+		values:  map[uint]float64{1: 1000, 3: 250},
+		failing: map[uint]bool{2: true},
+	}
+	alerts := &recordingEvaluator{}
+	job := NewPortfolioValuationJob("", portfolios, valuations, alerts, logger.New("test"))
+
+	err := job.Execute(context.Background())
+	require.Error(t, err, "a failed refresh fails the run")
+
+	// Only freshly valued portfolios are evaluated, with their new totals
+	require.Len(t, alerts.evaluated, 2)
+	assert.Equal(t, uint(1), alerts.evaluated[0].ID)
+	assert.Equal(t, 1000.0, alerts.evaluated[0].TotalValue)
+	assert.Equal(t, uint(3), alerts.evaluated[1].ID)
+	assert.Equal(t, 250.0, alerts.evaluated[1].TotalValue)
+}
+
+func TestPortfolioValuationJob_AlertFailuresDoNotFailTheRun(t *testing.T) {
+	portfolios := &testutil.MockPortfolioRepository{}
+	portfolios.On("GetActivePortfolioIDs", mock.Anything).Return([]uint{1, 2}, nil)
+
+	alerts := &recordingEvaluator{err: errors.New("notifier down")}
+	job := NewPortfolioValuationJob("", portfolios, &stubValuations{}, alerts, logger.New("test"))
+
+	require.NoError(t, job.Execute(context.Background()))
+	assert.Len(t, alerts.evaluated, 2, "evaluation continues past a failing portfolio")
+
+	// Without an evaluator the refresh still runs
+	job = NewPortfolioValuationJob("", portfolios, &stubValuations{}, nil, logger.New("test"))
+	require.NoError(t, job.Execute(context.Background()))
+}
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	// PriceObservationJobID is the scheduler ID of the price observation job
+	PriceObservationJobID = "price_observations"
+	// DefaultPriceObservationSchedule records prices every five minutes
+	DefaultPriceObservationSchedule = "0 */5 * * * *"
+)
+
+// PriceQuoter is a provider of USD spot prices keyed by upper-case symbol. The price
+// sources used for aggregation satisfy it.
+type PriceQuoter interface {
+	Name() string
+	GetPrices(ctx context.Context, symbols []string) (map[string]float64, error)
+}
+
+// ObservedPriceSource is a provider whose prices are recorded, with the reliability score
+// stored alongside each of its observations
+type ObservedPriceSource struct {
+	Quoter      PriceQuoter
+	Reliability float64
+}
+
+// PriceObservationJob records every source's price for each tracked symbol in the
+// price_data hypertable, building a per-source price history for charting
+type PriceObservationJob struct {
+	*BaseJob
+	symbols []string
+	sources []ObservedPriceSource
+	repo    repositories.PriceObservationRepository
+	now     func() time.Time
+	logger  logger.Logger
+}
+
+// NewPriceObservationJob creates a job that records prices of symbols on the given schedule
+func NewPriceObservationJob(
+	schedule string,
+	symbols []string,
+	sources []ObservedPriceSource,
+	repo repositories.PriceObservationRepository,
+	log logger.Logger,
+) *PriceObservationJob {
+	if schedule == "" {
+		schedule = DefaultPriceObservationSchedule
+	}
+
+	normalized := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(symbol))
+	}
+
+	return &PriceObservationJob{
+		BaseJob: NewBaseJob(PriceObservationJobID, "Price observation recording", schedule),
+		symbols: normalized,
+		sources: sources,
+		repo:    repo,
+		now:     time.Now,
+		logger:  log.With("job", PriceObservationJobID),
+	}
+}
+
+// Execute queries each source and stores one observation per source and symbol. A failing
+// source is skipped; the run only fails when no source returned prices.
+func (j *PriceObservationJob) Execute(ctx context.Context) error {
+	observedAt := j.now().UTC()
+
+	var observations []entities.PriceObservation
+	failed := 0
+	for _, source := range j.sources {
+		name := source.Quoter.Name()
+		dataSource, _ := entities.NormalizeDataSource(name)
+		prices, err := source.Quoter.GetPrices(ctx, j.symbols)
+		if err != nil {
+			j.logger.Warn("Failed to fetch prices for observation", "source", name, "error", err)
+			failed++
+			continue
+		}
+
+		for symbol, price := range prices {
+			if price <= 0 {
+				continue
+			}
+			observations = append(observations, entities.PriceObservation{
+				Timestamp:        observedAt,
+				AssetSymbol:      strings.ToUpper(symbol),
+				PriceUSD:         price,
+				DataSource:       dataSource,
+				ReliabilityScore: source.Reliability,
+			})
+		}
+	}
+
+	if len(j.sources) > 0 && failed == len(j.sources) {
+		return fmt.Errorf("no price source returned prices for %v", j.symbols)
+	}
+
+	// Map iteration order is random; store in a predictable order
+	sort.Slice(observations, func(a, b int) bool {
+		if observations[a].AssetSymbol != observations[b].AssetSymbol {
+			return observations[a].AssetSymbol < observations[b].AssetSymbol
+		}
+		return observations[a].DataSource < observations[b].DataSource
+	})
+
+	if err := j.repo.StoreObservations(ctx, observations); err != nil {
+		return fmt.Errorf("failed to store price observations: %w", err)
+	}
+
+	j.logger.Info("Price observations recorded", "observations", len(observations), "failed_sources", failed)
+	return nil
+}
+
+// OnError logs failed recording runs
+func (j *PriceObservationJob) OnError(err error, duration time.Duration) {
+	j.logger.Error("Price observation recording failed", "error", err, "duration", duration)
+}
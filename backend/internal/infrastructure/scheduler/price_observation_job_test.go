@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubQuoter returns fixed prices, or err when set
+type stubQuoter struct {
+	name   string
+	prices map[string]float64
+	err    error
+}
+
+func (q stubQuoter) Name() string { return q.name }
+
+func (q stubQuoter) GetPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	return q.prices, q.err
+}
+
+func newPriceObservationTestJob(t *testing.T, sources []ObservedPriceSource) (*PriceObservationJob, *testutil.TestDB) {
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+
+	testDB.CreateTables(t, testutil.PriceDataTableDDL)
+
+	repo := database.NewPriceObservationRepository(database.NewDBProvider(testDB.DB, nil), testDB.Logger)
+	job := NewPriceObservationJob("", []string{"btc", "eth"}, sources, repo, logger.New("test"))
+	return job, testDB
+}
+
+func TestPriceObservationJob_RecordsSourceAndReliability(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	job, testDB := newPriceObservationTestJob(t, []ObservedPriceSource{
+		{Quoter: stubQuoter{name: "coinmarketcap", prices: map[string]float64{"BTC": 67712.34, "ETH": 3512.5}}, Reliability: 1.0},
+		{Quoter: stubQuoter{name: "binance", prices: map[string]float64{"BTC": 67705.1, "ETH": 0}}, Reliability: 0.9},
+		{Quoter: stubQuoter{name: "coincap", err: errors.New("upstream down")}, Reliability: 0.8},
+	})
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Execute(context.Background()))
+
+	var rows []entities.PriceObservation
+	require.NoError(t, testDB.DB.Order("id").Find(&rows).Error)
+	require.Len(t, rows, 3, "one row per source and priced symbol; zero prices and failed sources are skipped")
+
+	expected := []struct {
+		symbol      string
+		source      entities.DataSource
+		price       float64
+		reliability float64
+	}{
+		{"BTC", "binance", 67705.1, 0.9},
+		{"BTC", "coinmarketcap", 67712.34, 1.0},
+		{"ETH", "coinmarketcap", 3512.5, 1.0},
+	}
+	for i, want := range expected {
+		assert.Equal(t, want.symbol, rows[i].AssetSymbol)
+		assert.Equal(t, want.source, rows[i].DataSource)
+		assert.Equal(t, want.price, rows[i].PriceUSD)
+		assert.Equal(t, want.reliability, rows[i].ReliabilityScore)
+		assert.True(t, now.Equal(rows[i].Timestamp))
+	}
+
+	// The repository serves the history for charting
+	observations, err := job.repo.GetObservations(context.Background(), "btc", now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	assert.Len(t, observations, 2)
+}
+
+func TestPriceObservationJob_FailsWhenEverySourceFails(t *testing.T) {
+	job, testDB := newPriceObservationTestJob(t, []ObservedPriceSource{
+		{Quoter: stubQuoter{name: "coinmarketcap", err: errors.New("rate limited")}, Reliability: 1.0},
+	})
+
+	require.Error(t, job.Execute(context.Background()))
+
+	var count int64
+	require.NoError(t, testDB.DB.Model(&entities.PriceObservation{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
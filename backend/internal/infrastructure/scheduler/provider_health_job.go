@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	// ProviderHealthJobID is the scheduler ID of the provider health recording job
+	ProviderHealthJobID = "provider_health"
+	// DefaultProviderHealthSchedule checks providers every five minutes
+	DefaultProviderHealthSchedule = "0 */5 * * * *"
+	// ProviderHealthRetention is how long recorded health checks are kept
+	ProviderHealthRetention = 7 * 24 * time.Hour
+)
+
+// ProviderHealthChecker checks every external data provider, keyed by provider name.
+// MarketDataService satisfies it.
+type ProviderHealthChecker interface {
+	HealthCheck(ctx context.Context) map[string]entities.ProviderHealthResult
+}
+
+// ProviderHealthJob records each provider's health check result and latency so flaky or
+// degrading sources show up in their history
+type ProviderHealthJob struct {
+	*BaseJob
+	checker ProviderHealthChecker
+	repo    repositories.ProviderHealthRepository
+	now     func() time.Time
+	logger  logger.Logger
+}
+
+// NewProviderHealthJob creates a job that records provider health on the given schedule
+func NewProviderHealthJob(
+	schedule string,
+	checker ProviderHealthChecker,
+	repo repositories.ProviderHealthRepository,
+	log logger.Logger,
+) *ProviderHealthJob {
+	if schedule == "" {
+		schedule = DefaultProviderHealthSchedule
+	}
+
+	return &ProviderHealthJob{
+		BaseJob: NewBaseJob(ProviderHealthJobID, "Provider health recording", schedule),
+		checker: checker,
+		repo:    repo,
+		now:     time.Now,
+		logger:  log.With("job", ProviderHealthJobID),
+	}
+}
+
+// Execute runs the provider health checks, records the results and prunes old ones
+func (j *ProviderHealthJob) Execute(ctx context.Context) error {
+	checkedAt := j.now()
+	results := j.checker.HealthCheck(ctx)
+
+	checks := make([]entities.ProviderHealthCheck, 0, len(results))
+	unhealthy := 0
+	for provider, result := range results {
+		check := entities.ProviderHealthCheck{
+			Provider:  provider,
+			Healthy:   result.Err == nil,
+			LatencyMs: result.LatencyMs(),
+			CheckedAt: checkedAt,
+		}
+		if result.Err != nil {
+			check.Error = result.Err.Error()
+			unhealthy++
+		}
+		checks = append(checks, check)
+	}
+
+	if err := j.repo.Record(ctx, checks); err != nil {
+		return fmt.Errorf("failed to record provider health: %w", err)
+	}
+
+	cutoff := checkedAt.Add(-ProviderHealthRetention)
+	if _, err := j.repo.DeleteBefore(ctx, cutoff); err != nil {
+		j.logger.Warn("Failed to prune provider health history", "error", err, "cutoff", cutoff)
+	}
+
+	j.logger.Info("Provider health recorded", "providers", len(checks), "unhealthy", unhealthy)
+	return nil
+}
+
+// OnError logs failed recording runs
+func (j *ProviderHealthJob) OnError(err error, duration time.Duration) {
+	j.logger.Error("Provider health recording failed", "error", err, "duration", duration)
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticHealthChecker reports fixed provider health results
+type staticHealthChecker map[string]entities.ProviderHealthResult
+
+func (c staticHealthChecker) HealthCheck(ctx context.Context) map[string]entities.ProviderHealthResult {
+	return c
+}
+
+func TestProviderHealthJob_RecordsResultsAndPrunes(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	testDB.CreateTables(t, testutil.ProviderHealthTableDDL)
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := database.NewProviderHealthRepository(database.NewDBProvider(testDB.DB, nil), testDB.Logger)
+	require.NoError(t, repo.Record(context.Background(), []entities.ProviderHealthCheck{
+		{Provider: "coinmarketcap", Healthy: true, CheckedAt: now.Add(-8 * 24 * time.Hour)},
+	}))
+
+	job := NewProviderHealthJob("", staticHealthChecker{
+		"coinmarketcap": {Latency: 180 * time.Millisecond},
+		"tradingview":   {Latency: 5 * time.Second, Err: errors.New("status 503")},
+	}, repo, logger.New("test"))
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Execute(context.Background()))
+
+	checks, err := repo.GetSince(context.Background(), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, checks, 2, "the check past the retention window should be pruned")
+
+	byProvider := map[string]entities.ProviderHealthCheck{}
+	for _, check := range checks {
+		byProvider[check.Provider] = check
+		assert.True(t, now.Equal(check.CheckedAt))
+	}
+	assert.True(t, byProvider["coinmarketcap"].Healthy)
+	assert.False(t, byProvider["tradingview"].Healthy)
+	assert.Equal(t, "status 503", byProvider["tradingview"].Error)
+	assert.Equal(t, 180.0, byProvider["coinmarketcap"].LatencyMs)
+	assert.Equal(t, 5000.0, byProvider["tradingview"].LatencyMs)
+}
@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/logger"
+)
+
+const (
+	// TradingViewCanaryJobID is the scheduler ID of the TradingView markup-drift canary
+	TradingViewCanaryJobID = "tradingview_canary"
+	// DefaultTradingViewCanarySchedule probes TradingView every fifteen minutes
+	DefaultTradingViewCanarySchedule = "0 */15 * * * *"
+	// DefaultTradingViewCanaryThreshold is how many consecutive failed extractions mark
+	// the scraper degraded
+	DefaultTradingViewCanaryThreshold = 3
+)
+
+// tradingViewCanaryMetrics publishes the canary's state under "tradingview_canary" in
+// the expvar metrics: consecutive_failures, degraded (0 or 1) and the unix time of the
+// last successful extraction
+var tradingViewCanaryMetrics = expvar.NewMap("tradingview_canary")
+
+// DominanceScraper extracts Bitcoin dominance from TradingView alone, without falling
+// back to other sources. TradingViewScraper satisfies it.
+type DominanceScraper interface {
+	ScrapeBitcoinDominance(ctx context.Context) (*external.BitcoinDominanceData, error)
+}
+
+// TradingViewCanaryJob scrapes TradingView on a schedule so a markup change that breaks
+// extraction is noticed before users are served fallback dominance data. After threshold
+// consecutive failures it logs an error and flips the degraded metric; the next
+// successful extraction flips it back.
+type TradingViewCanaryJob struct {
+	*BaseJob
+	scraper   DominanceScraper
+	threshold int
+	now       func() time.Time
+	logger    logger.Logger
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	degraded            bool
+}
+
+// NewTradingViewCanaryJob creates a canary that probes the scraper on the given schedule
+func NewTradingViewCanaryJob(
+	schedule string,
+	threshold int,
+	scraper DominanceScraper,
+	log logger.Logger,
+) *TradingViewCanaryJob {
+	if schedule == "" {
+		schedule = DefaultTradingViewCanarySchedule
+	}
+	if threshold <= 0 {
+		threshold = DefaultTradingViewCanaryThreshold
+	}
+
+	job := &TradingViewCanaryJob{
+		BaseJob:   NewBaseJob(TradingViewCanaryJobID, "TradingView markup-drift canary", schedule),
+		scraper:   scraper,
+		threshold: threshold,
+		now:       time.Now,
+		logger:    log.With("job", TradingViewCanaryJobID),
+	}
+	job.publish()
+	return job
+}
+
+// Execute runs one TradingView extraction and updates the canary state. A failed
+// extraction is the canary doing its job, not the job failing, so it returns nil and
+// the scheduler's failure policy never disables the canary.
+func (j *TradingViewCanaryJob) Execute(ctx context.Context) error {
+	data, err := j.scraper.ScrapeBitcoinDominance(ctx)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err != nil {
+		j.consecutiveFailures++
+		if j.consecutiveFailures >= j.threshold {
+			if !j.degraded {
+				j.logger.Error("TradingView dominance extraction is failing; markup may have changed",
+					"consecutive_failures", j.consecutiveFailures, "threshold", j.threshold, "error", err)
+			}
+			j.degraded = true
+		} else {
+			j.logger.Warn("TradingView dominance extraction failed",
+				"consecutive_failures", j.consecutiveFailures, "threshold", j.threshold, "error", err)
+		}
+		j.publish()
+		return nil
+	}
+
+	if j.degraded {
+		j.logger.Info("TradingView dominance extraction recovered",
+			"failed_runs", j.consecutiveFailures, "dominance", data.CurrentDominance)
+	}
+	j.consecutiveFailures = 0
+	j.degraded = false
+	tradingViewCanaryMetrics.Set("last_success_unix", intVar(j.now().Unix()))
+	j.publish()
+	return nil
+}
+
+// Degraded reports whether extraction has failed at least threshold times in a row
+func (j *TradingViewCanaryJob) Degraded() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.degraded
+}
+
+// ConsecutiveFailures returns how many extractions in a row have failed
+func (j *TradingViewCanaryJob) ConsecutiveFailures() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.consecutiveFailures
+}
+
+// publish copies the canary state to its metrics; callers hold j.mu
+func (j *TradingViewCanaryJob) publish() {
+	degraded := int64(0)
+	if j.degraded {
+		degraded = 1
+	}
+	tradingViewCanaryMetrics.Set("consecutive_failures", intVar(int64(j.consecutiveFailures)))
+	tradingViewCanaryMetrics.Set("degraded", intVar(degraded))
+}
+
+// TradingViewCanaryMetricsJSON returns the canary's published metrics as a JSON object
+func TradingViewCanaryMetricsJSON() string {
+	return tradingViewCanaryMetrics.String()
+}
+
+func intVar(value int64) *expvar.Int {
+	v := new(expvar.Int)
+	v.Set(value)
+	return v
+}
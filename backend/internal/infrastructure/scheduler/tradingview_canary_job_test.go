@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedScraper fails or succeeds according to the failing flag
+type scriptedScraper struct {
+	failing bool
+	calls   int
+}
+
+func (s *scriptedScraper) ScrapeBitcoinDominance(ctx context.Context) (*external.BitcoinDominanceData, error) {
+	s.calls++
+	if s.failing {
+		return nil, errors.New("could not extract Bitcoin dominance from TradingView")
+	}
+	return &external.BitcoinDominanceData{CurrentDominance: 54.2, DataSource: entities.DataSourceTradingView}, nil
+}
+
+func canaryMetric(t *testing.T, key string) string {
+	t.Helper()
+	value := tradingViewCanaryMetrics.Get(key)
+	require.NotNil(t, value, "metric %s should be published", key)
+	return value.(*expvar.Int).String()
+}
+
+func TestTradingViewCanaryJob_FlipsAfterConsecutiveFailures(t *testing.T) {
+	scraper := &scriptedScraper{failing: true}
+	job := NewTradingViewCanaryJob("", 3, scraper, logger.New("test"))
+	ctx := context.Background()
+
+	for run := 1; run <= 2; run++ {
+		require.NoError(t, job.Execute(ctx), "extraction failures must not fail the job")
+		assert.Equal(t, run, job.ConsecutiveFailures())
+		assert.False(t, job.Degraded(), "below the threshold the canary stays healthy")
+		assert.Equal(t, "0", canaryMetric(t, "degraded"))
+	}
+
+	require.NoError(t, job.Execute(ctx))
+	assert.True(t, job.Degraded(), "the third consecutive failure should flip the canary")
+	assert.Equal(t, "1", canaryMetric(t, "degraded"))
+	assert.Equal(t, "3", canaryMetric(t, "consecutive_failures"))
+
+	require.NoError(t, job.Execute(ctx))
+	assert.True(t, job.Degraded())
+	assert.Equal(t, 4, job.ConsecutiveFailures())
+	assert.Equal(t, 4, scraper.calls)
+}
+
+func TestTradingViewCanaryJob_SuccessResetsState(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	scraper := &scriptedScraper{failing: true}
+	job := NewTradingViewCanaryJob("", 2, scraper, logger.New("test"))
+	job.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, job.Execute(ctx))
+	require.NoError(t, job.Execute(ctx))
+	require.True(t, job.Degraded())
+
+	scraper.failing = false
+	require.NoError(t, job.Execute(ctx))
+	assert.False(t, job.Degraded(), "a successful extraction should clear the canary")
+	assert.Zero(t, job.ConsecutiveFailures())
+	assert.Equal(t, "0", canaryMetric(t, "degraded"))
+	assert.Equal(t, "0", canaryMetric(t, "consecutive_failures"))
+	assert.Equal(t, "1717243200", canaryMetric(t, "last_success_unix"))
+
+	// A single failure after recovery starts counting from scratch
+	scraper.failing = true
+	require.NoError(t, job.Execute(ctx))
+	assert.Equal(t, 1, job.ConsecutiveFailures())
+	assert.False(t, job.Degraded())
+}
+
+func TestNewTradingViewCanaryJob_Defaults(t *testing.T) {
+	job := NewTradingViewCanaryJob("", 0, &scriptedScraper{}, logger.New("test"))
+
+	assert.Equal(t, TradingViewCanaryJobID, job.ID())
+	assert.Equal(t, DefaultTradingViewCanarySchedule, job.Schedule())
+	assert.Equal(t, DefaultTradingViewCanaryThreshold, job.threshold)
+}
+
+func TestTradingViewCanaryMetricsJSON_OnlyCanaryKeys(t *testing.T) {
+	NewTradingViewCanaryJob("", 0, &scriptedScraper{}, logger.New("test"))
+
+	var metrics map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(TradingViewCanaryMetricsJSON()), &metrics))
+	assert.Contains(t, metrics, "degraded")
+	assert.Contains(t, metrics, "consecutive_failures")
+	assert.NotContains(t, metrics, "cmdline")
+	assert.NotContains(t, metrics, "memstats")
+}
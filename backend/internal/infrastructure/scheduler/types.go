@@ -73,6 +73,25 @@ type JobStats struct {
 	LastError        string        `json:"last_error,omitempty"`
 	AverageDuration  time.Duration `json:"average_duration"`
 	NextScheduled    time.Time     `json:"next_scheduled"`
+
+	// Health tracking for repeatedly failing jobs
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Healthy             bool      `json:"healthy"`
+	UnhealthySince      time.Time `json:"unhealthy_since,omitempty"`
+	Disabled            bool      `json:"disabled"`
+}
+
+// FailurePolicy controls how the scheduler reacts to a job failing repeatedly
+type FailurePolicy struct {
+	// Threshold is the number of consecutive failures after which a job is
+	// marked unhealthy. Zero disables health tracking.
+	Threshold int
+
+	// AutoDisable unschedules an unhealthy job until EnableJob is called
+	AutoDisable bool
+
+	// OnUnhealthy, if set, is called once each time a job becomes unhealthy
+	OnUnhealthy func(stats JobStats)
 }
 
 // BaseJob provides a basic implementation of the Job interface
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type tracedRow struct {
+	ID   uint
+	Name string
+}
+
+func TestGormPlugin_NestsQuerySpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(NewGormPlugin()))
+	require.NoError(t, db.Exec("CREATE TABLE traced_rows (id INTEGER PRIMARY KEY, name TEXT)").Error)
+	exporter.Reset()
+
+	ctx, parent := Tracer().Start(context.Background(), "repository")
+	require.NoError(t, db.WithContext(ctx).Create(&tracedRow{Name: "btc"}).Error)
+	var missing tracedRow
+	err = db.WithContext(ctx).First(&missing, 42).Error
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	create, query := spans[0], spans[1]
+
+	assert.Equal(t, "db.create", create.Name)
+	assert.Equal(t, "db.query", query.Name)
+	for _, span := range []tracetest.SpanStub{create, query} {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Contains(t, span.Attributes, attribute.String("db.system", "sqlite"))
+		assert.Contains(t, span.Attributes, attribute.String("db.collection.name", "traced_rows"))
+		assert.Equal(t, "Unset", span.Status.Code.String(), "a missing record is not a failed query")
+	}
+	assert.Contains(t, create.Attributes, attribute.Int64("db.rows_affected", 1))
+}
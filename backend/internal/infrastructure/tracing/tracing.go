@@ -0,0 +1,76 @@
+// Package tracing sets up OpenTelemetry tracing. Spans are exported over OTLP when an
+// endpoint is configured; otherwise the global no-op tracer provider stays in place and
+// spans cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName names the tracer every span in the application is started from
+const InstrumentationName = "crypto-indicator-dashboard"
+
+// Options configures span export
+type Options struct {
+	// OTLPEndpoint is the OTLP/HTTP collector URL, e.g. http://localhost:4318; empty
+	// disables export
+	OTLPEndpoint string
+	ServiceName  string
+	// SampleRatio is the share of new traces recorded, 0-1. Requests arriving with a
+	// sampled parent trace are always recorded.
+	SampleRatio float64
+}
+
+// ShutdownFunc flushes buffered spans and stops the exporter
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs the W3C trace context propagator and, when an OTLP endpoint is
+// configured, a tracer provider exporting to it. The returned function flushes and
+// stops the exporter and is safe to call when export is disabled.
+func Setup(ctx context.Context, opts Options, log logger.Logger) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if opts.OTLPEndpoint == "" {
+		log.Info("Tracing export disabled, no OTLP endpoint configured")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.OTLPEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(opts.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	log.Info("Tracing export enabled", "endpoint", opts.OTLPEndpoint, "sample_ratio", opts.SampleRatio)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the application tracer from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
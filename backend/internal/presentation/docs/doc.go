@@ -0,0 +1,7 @@
+// Package docs builds the OpenAPI document for the HTTP API. The spec is
+// assembled from the route table in openapi.go and schemas reflected from the
+// DTO and entity structs, so it is served live at /swagger/doc.json and can
+// also be written to openapi.json with go generate.
+package docs
+
+//go:generate go run ../../../cmd/openapi -out openapi.json
@@ -0,0 +1,432 @@
+package docs
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/buildinfo"
+)
+
+// APIVersion is the version reported in the OpenAPI document
+const APIVersion = "2.0.0"
+
+// parameter describes a path or query parameter
+type parameter struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	Schema      string
+}
+
+func pathParam(name, description string) parameter {
+	return parameter{Name: name, In: "path", Description: description, Required: true, Schema: "string"}
+}
+
+func queryParam(name, description string) parameter {
+	return parameter{Name: name, In: "query", Description: description, Schema: "string"}
+}
+
+// pageParams documents ?limit= and ?offset= for a paginated list
+func pageParams(defaultLimit, maxLimit int) []parameter {
+	return []parameter{
+		{Name: "limit", In: "query", Description: fmt.Sprintf("Page size (1-%d, default %d)", maxLimit, defaultLimit), Schema: "integer"},
+		{Name: "offset", In: "query", Description: "Items to skip (default 0)", Schema: "integer"},
+	}
+}
+
+// operation describes one documented route. Request and Response are sample
+// values whose types are turned into schemas; a nil Response documents an
+// untyped data payload. Paginated responses carry a pagination object next to data.
+type operation struct {
+	Method      string
+	Path        string
+	Tag         string
+	Summary     string
+	Params      []parameter
+	Request     interface{}
+	Response    interface{}
+	Status      int
+	RequiresKey bool
+	Paginated   bool
+}
+
+// operations lists every documented route, using OpenAPI {param} path syntax
+var operations = []operation{
+	{Method: http.MethodGet, Path: "/health", Tag: "system", Summary: "Service health check"},
+	{Method: http.MethodGet, Path: "/version", Tag: "system", Summary: "Build version, commit and time", Response: buildinfo.Info{}},
+	{Method: http.MethodGet, Path: "/metrics", Tag: "system", Summary: "TradingView canary metrics as JSON"},
+
+	// Indicators
+	{Method: http.MethodGet, Path: "/api/v1/indicators/mvrv", Tag: "indicators", Summary: "MVRV Z-Score indicator", Response: dto.MVRVResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/dominance", Tag: "indicators", Summary: "Bitcoin dominance indicator", Response: dto.DominanceResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/fear-greed", Tag: "indicators", Summary: "Fear & Greed index", Response: dto.FearGreedResponse{}},
+	{
+		Method: http.MethodGet, Path: "/api/v1/indicators/fear-greed/components", Tag: "indicators",
+		Summary: "History of each Fear & Greed component, rebuilt from stored readings (empty for index-only providers)",
+		Params: []parameter{
+			queryParam("period", "7d, 30d (default), 90d or 1y"),
+			queryParam("from", "Range start (RFC3339, with 'to')"),
+			queryParam("to", "Range end (RFC3339, with 'from')"),
+		},
+		Response: entities.FearGreedComponentHistory{},
+	},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/bubble-risk", Tag: "indicators", Summary: "Bubble risk assessment", Response: dto.BubbleRiskResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/coinbase-premium", Tag: "indicators", Summary: "Coinbase BTC/USD premium over the global average"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/alt-season", Tag: "indicators", Summary: "Altcoin Season Index: share of the top 50 altcoins outperforming BTC over 90 days"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/realized-price", Tag: "indicators", Summary: "Bitcoin realized price and realized cap"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/rhodl", Tag: "indicators", Summary: "Realized HODL ratio with cycle top/bottom bands (approximated from price history)"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/etf-flow", Tag: "indicators", Summary: "Daily net spot Bitcoin ETF and trust flows (neutral placeholder until a flow provider is configured)"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/exchange-flow", Tag: "indicators", Summary: "Daily net BTC exchange flow, classified as accumulation or distribution (neutral placeholder until a flow provider is configured)"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/volume-anomaly", Tag: "indicators", Summary: "Z-score of BTC 24h volume against its trailing 30 day average"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/market-trend", Tag: "indicators", Summary: "Bullish, bearish or sideways classification of the top 10 assets' average 24h change"},
+	{Method: http.MethodGet, Path: "/api/v1/indicators/composite", Tag: "indicators", Summary: "Composite risk score (0-100) from the latest component values under the weights in effect", Response: entities.CompositeScore{}},
+	{
+		Method: http.MethodGet, Path: "/api/v1/indicators/market-trend/history", Tag: "indicators",
+		Summary: "Stored daily market trend classifications with the average 24h change behind each",
+		Params: []parameter{
+			queryParam("period", "7d, 30d (default), 90d or 1y"),
+			queryParam("tz", "IANA time zone the period starts at midnight in"),
+			queryParam("from", "Range start (RFC3339); overrides period when given with 'to'"),
+			queryParam("to", "Range end (RFC3339)"),
+			queryParam("format", "json (default) or csv to download timestamp, value, risk_level, confidence rows"),
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/indicators/correlation", Tag: "indicators",
+		Summary: "Pearson correlation between two indicators",
+		Params: []parameter{
+			{Name: "a", In: "query", Description: "First indicator name", Required: true, Schema: "string"},
+			{Name: "b", In: "query", Description: "Second indicator name", Required: true, Schema: "string"},
+			queryParam("period", "7d, 30d, 90d (default) or 1y"),
+		},
+		Response: entities.CorrelationResult{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/indicators/type/{type}", Tag: "indicators",
+		Summary: "Latest value of each indicator of a type within a time range",
+		Params: []parameter{
+			pathParam("type", "Indicator type"),
+			queryParam("from", "Range start (RFC3339 or YYYY-MM-DD, default 7 days before 'to')"),
+			queryParam("to", "Range end (RFC3339 or YYYY-MM-DD, default now)"),
+			{Name: "min_confidence", In: "query", Description: "Only values with at least this confidence (0-1); each indicator's latest such value is returned", Schema: "number"},
+			{Name: "limit", In: "query", Description: "Maximum indicators returned before paging (1-5000, default 500)", Schema: "integer"},
+			{Name: "page_size", In: "query", Description: "Page size (1-500, default 100)", Schema: "integer"},
+			{Name: "offset", In: "query", Description: "Items to skip (default 0)", Schema: "integer"},
+		},
+		Response:  []entities.Indicator{},
+		Paginated: true,
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/indicators/{name}/latest/provenance", Tag: "indicators",
+		Summary:  "Sources, inputs and calculation version behind an indicator's latest stored value",
+		Params:   []parameter{pathParam("name", "Stored indicator name; hyphens match underscores")},
+		Response: entities.IndicatorProvenance{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/indicators/{name}/diff", Tag: "indicators",
+		Summary: "Field-by-field changes between the stored values nearest to two timestamps",
+		Params: []parameter{
+			pathParam("name", "Stored indicator name; hyphens match underscores"),
+			{Name: "from", In: "query", Description: "First timestamp (RFC3339 or YYYY-MM-DD)", Required: true, Schema: "string"},
+			queryParam("to", "Second timestamp (RFC3339 or YYYY-MM-DD, default now)"),
+		},
+		Response: []entities.IndicatorFieldChange{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/indicators/bulk", Tag: "indicators",
+		Summary: "Latest stored value of every indicator of several types in one request",
+		Params: []parameter{
+			{Name: "types", In: "query", Description: "Comma-separated indicator types (at most 20)", Required: true, Schema: "string"},
+		},
+		Response: []entities.Indicator{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/indicators/bulk", Tag: "admin",
+		Summary: "Bulk ingest precomputed indicator values", Request: []dto.IndicatorPayload{}, Status: http.StatusCreated,
+		RequiresKey: true,
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/charts/{indicator}", Tag: "indicators",
+		Summary: "Chart data for an indicator",
+		Params: []parameter{
+			pathParam("indicator", "mvrv, dominance, fear-greed, bubble-risk or realized-price"),
+			queryParam("normalize", "Optional normalization: minmax or zscore"),
+			queryParam("period", "realized-price, or mvrv with format=csv: 7d, 30d (default), 90d or 1y"),
+			queryParam("tz", "realized-price, or mvrv with format=csv: IANA time zone the period starts at midnight in"),
+			queryParam("from", "realized-price, or mvrv with format=csv: RFC3339 range start; with to, overrides period"),
+			queryParam("to", "realized-price, or mvrv with format=csv: RFC3339 range end; must be after from"),
+			{Name: "calc_version", In: "query", Description: "realized-price, or mvrv with format=csv: keep values from this calculation version", Schema: "integer"},
+			queryParam("format", "json (default) or, for realized-price and mvrv only, csv to download stored history as timestamp, value, risk_level, confidence"),
+			queryParam("ma", "mvrv only: comma-separated moving average windows in days (default 7,30)"),
+			{Name: "points", In: "query", Description: "Maximum points per series (2-5000, default 500)", Schema: "integer"},
+			queryParam("downsample", "Downsampling method for longer series: last (default), avg or ohlc"),
+			{Name: "annotations", In: "query", Description: "Include annotations within the chart's time range", Schema: "boolean"},
+			queryParam("overlay", "realized-price only: cycles adds the price series split and aligned by Bitcoin halving"),
+			queryParam("smooth", "Optional smoothed series: ema or sma"),
+			{Name: "span", In: "query", Description: "Smoothing span in points (1-365, default 14)", Schema: "integer"},
+		},
+	},
+
+	// Operations
+	{Method: http.MethodGet, Path: "/api/v1/admin/providers/health", Tag: "admin", Summary: "Latest health check and 24h uptime per data provider", Response: dto.ProviderHealthResponse{}, RequiresKey: true},
+	{
+		Method: http.MethodGet, Path: "/api/v1/admin/providers/{name}/raw", Tag: "admin",
+		Summary: "Raw status and body of a whitelisted upstream provider endpoint, for debugging",
+		Params: []parameter{
+			pathParam("name", "coinmarketcap, coingecko, binance, coincap, alternative_me or blockchain"),
+			{Name: "endpoint", In: "query", Description: "Whitelisted endpoint path, e.g. /global; others are rejected with the allowed list", Required: true, Schema: "string"},
+		},
+		Response: external.RawResponse{}, RequiresKey: true,
+	},
+	{Method: http.MethodGet, Path: "/api/v1/admin/composite-weights", Tag: "admin", Summary: "Composite risk score weights in effect (defaults until set)", Response: entities.CompositeWeightConfig{}, RequiresKey: true},
+	{Method: http.MethodPut, Path: "/api/v1/admin/composite-weights", Tag: "admin", Summary: "Store new composite risk score weights; weights must be non-negative", Request: dto.CompositeWeightsRequest{}, Response: entities.CompositeWeightConfig{}, RequiresKey: true},
+	{Method: http.MethodGet, Path: "/api/v1/admin/indicator-configs", Tag: "admin", Summary: "Description and bands in effect for every configurable indicator", Response: []entities.IndicatorConfig{}, RequiresKey: true},
+	{Method: http.MethodGet, Path: "/api/v1/admin/indicator-configs/{name}", Tag: "admin", Summary: "Description and bands in effect for one indicator (defaults until set)", Params: []parameter{pathParam("name", "Configurable indicator, e.g. mvrv")}, Response: entities.IndicatorConfig{}, RequiresKey: true},
+	{Method: http.MethodPut, Path: "/api/v1/admin/indicator-configs/{name}", Tag: "admin", Summary: "Store an indicator's description and bands; exactly one band must omit min", Params: []parameter{pathParam("name", "Configurable indicator, e.g. mvrv")}, Request: dto.IndicatorConfigRequest{}, Response: entities.IndicatorConfig{}, RequiresKey: true},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/cache", Tag: "admin", Summary: "Invalidate every cache key matching a glob pattern", Params: []parameter{{Name: "pattern", In: "query", Description: "Redis glob using *, ?, [...] and \\ escapes; * also matches /, e.g. indicator_*", Required: true, Schema: "string"}}, RequiresKey: true},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/cache/{key}", Tag: "admin", Summary: "Invalidate a single cache key", Params: []parameter{pathParam("key", "Cache key")}, RequiresKey: true},
+	{
+		Method: http.MethodPost, Path: "/api/v1/indicators/{name}/recalculate", Tag: "admin",
+		Summary:  "Recalculate an indicator now, bypassing cached upstream data, and store the result",
+		Params:   []parameter{pathParam("name", "mvrv, coinbase_premium, alt_season, realized_price, rhodl, etf_flow, exchange_flow, volume_anomaly or market_trend")},
+		Response: entities.Indicator{}, RequiresKey: true,
+	},
+
+	// Chart annotations
+	{
+		Method: http.MethodGet, Path: "/api/v1/annotations", Tag: "annotations",
+		Summary: "List chart annotations, oldest first",
+		Params: append([]parameter{
+			queryParam("from", "Range start (RFC3339 or YYYY-MM-DD)"),
+			queryParam("to", "Range end (RFC3339 or YYYY-MM-DD)"),
+		}, pageParams(100, 1000)...),
+		Response:  []entities.IndicatorAnnotation{},
+		Paginated: true,
+	},
+	{Method: http.MethodPost, Path: "/api/v1/annotations", Tag: "annotations", Summary: "Create a chart annotation", Request: dto.AnnotationRequest{}, Response: entities.IndicatorAnnotation{}, Status: http.StatusCreated, RequiresKey: true},
+	{Method: http.MethodGet, Path: "/api/v1/annotations/{id}", Tag: "annotations", Summary: "Get a chart annotation", Params: []parameter{pathParam("id", "Annotation ID")}, Response: entities.IndicatorAnnotation{}},
+	{Method: http.MethodPut, Path: "/api/v1/annotations/{id}", Tag: "annotations", Summary: "Replace a chart annotation", Params: []parameter{pathParam("id", "Annotation ID")}, Request: dto.AnnotationRequest{}, Response: entities.IndicatorAnnotation{}, RequiresKey: true},
+	{Method: http.MethodDelete, Path: "/api/v1/annotations/{id}", Tag: "annotations", Summary: "Delete a chart annotation", Params: []parameter{pathParam("id", "Annotation ID")}, RequiresKey: true},
+
+	// Market data
+	{Method: http.MethodGet, Path: "/api/v1/market/prices", Tag: "market", Summary: "Current prices", Params: []parameter{queryParam("symbols", "Comma-separated symbols; duplicates are fetched once and an empty list is rejected (default top 10 when omitted)")}, Response: map[string]entities.CryptoPrice{}},
+	{Method: http.MethodGet, Path: "/api/v1/market/price/{symbol}", Tag: "market", Summary: "Current price for a symbol", Params: []parameter{pathParam("symbol", "Asset symbol")}, Response: entities.CryptoPrice{}},
+	{Method: http.MethodGet, Path: "/api/v1/market/dominance", Tag: "market", Summary: "Bitcoin dominance", Response: entities.BitcoinDominance{}},
+	{Method: http.MethodGet, Path: "/api/v1/market/metrics", Tag: "market", Summary: "Latest market-wide metrics (total market cap, volume, dominance)", Response: entities.MarketMetrics{}},
+	{Method: http.MethodGet, Path: "/api/v1/market/summary", Tag: "market", Summary: "Market summary with top assets", Params: []parameter{{Name: "count", In: "query", Description: "Number of assets", Schema: "integer"}}},
+	{Method: http.MethodPost, Path: "/api/v1/market/refresh", Tag: "market", Summary: "Refresh prices, dominance and market metrics, reporting which parts refreshed", Response: entities.MarketDataRefresh{}},
+	{Method: http.MethodGet, Path: "/api/v1/market/health", Tag: "market", Summary: "Market data source health"},
+	{Method: http.MethodGet, Path: "/api/v1/market/stream/{symbol}", Tag: "market", Summary: "Live ticker as server-sent events (ticker and heartbeat events)", Params: []parameter{pathParam("symbol", "Trading pair such as BTCUSDT")}},
+	{Method: http.MethodGet, Path: "/api/v1/market/cycle", Tag: "market", Summary: "Market cycle (placeholder)"},
+	{Method: http.MethodGet, Path: "/api/v1/network/bitcoin", Tag: "network", Summary: "Bitcoin hash rate, difficulty, block height, mempool size, transaction rate and fees", Response: entities.NetworkMetrics{}},
+	{Method: http.MethodGet, Path: "/api/v1/macro/inflation", Tag: "macro", Summary: "Inflation indicator (placeholder)"},
+	{Method: http.MethodGet, Path: "/api/v1/macro/interest-rates", Tag: "macro", Summary: "Interest rate indicator (placeholder)"},
+
+	// Portfolios
+	{Method: http.MethodPost, Path: "/api/v1/portfolios", Tag: "portfolios", Summary: "Create a portfolio owned by the authenticated user", Request: dto.CreatePortfolioRequest{}, Response: dto.PortfolioResponse{}, Status: http.StatusCreated, RequiresKey: true},
+	{Method: http.MethodGet, Path: "/api/v1/portfolios", Tag: "portfolios", Summary: "Portfolios of the authenticated user", Params: pageParams(50, 200), Response: []dto.PortfolioResponse{}, RequiresKey: true, Paginated: true},
+	{Method: http.MethodGet, Path: "/api/v1/portfolios/{id}", Tag: "portfolios", Summary: "Get a portfolio", Params: []parameter{pathParam("id", "Portfolio ID")}, Response: dto.PortfolioResponse{}, RequiresKey: true},
+	{Method: http.MethodGet, Path: "/api/v1/portfolios/{id}/summary", Tag: "portfolios", Summary: "Portfolio summary", Params: []parameter{pathParam("id", "Portfolio ID")}, Response: dto.PortfolioSummaryResponse{}, RequiresKey: true},
+	{Method: http.MethodPost, Path: "/api/v1/portfolios/{id}/holdings", Tag: "portfolios", Summary: "Add a holding", Params: []parameter{pathParam("id", "Portfolio ID")}, Request: dto.AddHoldingRequest{}, Response: dto.HoldingResponse{}, Status: http.StatusCreated, RequiresKey: true},
+	{Method: http.MethodPut, Path: "/api/v1/portfolios/{id}/holdings", Tag: "portfolios", Summary: "Update several holdings in one transaction; all or none are applied", Params: []parameter{pathParam("id", "Portfolio ID")}, Request: []dto.UpdateHoldingRequest{}, RequiresKey: true},
+	{Method: http.MethodDelete, Path: "/api/v1/portfolios/{id}/holdings", Tag: "portfolios", Summary: "Remove all holdings and reset the total value", Params: []parameter{pathParam("id", "Portfolio ID"), {Name: "archive", In: "query", Description: "Copy the holdings to the archive before removing them", Schema: "boolean"}}, RequiresKey: true},
+	{Method: http.MethodPut, Path: "/api/v1/portfolios/{id}/holdings/{holdingId}", Tag: "portfolios", Summary: "Update a holding", Params: []parameter{pathParam("id", "Portfolio ID"), pathParam("holdingId", "Holding ID")}, Request: dto.UpdateHoldingRequest{}, RequiresKey: true},
+	{Method: http.MethodDelete, Path: "/api/v1/portfolios/{id}/holdings/{holdingId}", Tag: "portfolios", Summary: "Remove a holding", Params: []parameter{pathParam("id", "Portfolio ID"), pathParam("holdingId", "Holding ID")}, RequiresKey: true},
+	{Method: http.MethodPost, Path: "/api/v1/portfolios/{id}/holdings/{holdingId}/transactions", Tag: "portfolios", Summary: "Record a buy or sell against a holding", Params: []parameter{pathParam("id", "Portfolio ID"), pathParam("holdingId", "Holding ID")}, Request: dto.HoldingTransactionRequest{}, Response: dto.HoldingTransactionResponse{}, Status: http.StatusCreated, RequiresKey: true},
+	{Method: http.MethodPost, Path: "/api/v1/portfolios/{id}/holdings/{holdingId}/sell", Tag: "portfolios", Summary: "Sell part or all of a holding at average cost, removing it once emptied", Params: []parameter{pathParam("id", "Portfolio ID"), pathParam("holdingId", "Holding ID")}, Request: dto.SellHoldingRequest{}, Response: dto.SellHoldingResponse{}, Status: http.StatusCreated, RequiresKey: true},
+	{Method: http.MethodGet, Path: "/api/v1/portfolios/{id}/drawdown-alerts", Tag: "portfolios", Summary: "Drawdown alerts on a portfolio, with their trailing peaks", Params: []parameter{pathParam("id", "Portfolio ID")}, Response: []entities.PortfolioDrawdownAlert{}, RequiresKey: true},
+	{Method: http.MethodPost, Path: "/api/v1/portfolios/{id}/drawdown-alerts", Tag: "portfolios", Summary: "Alert when the portfolio falls a percentage below its trailing peak", Params: []parameter{pathParam("id", "Portfolio ID")}, Request: dto.CreateDrawdownAlertRequest{}, Response: entities.PortfolioDrawdownAlert{}, Status: http.StatusCreated, RequiresKey: true},
+	{Method: http.MethodGet, Path: "/api/v1/portfolio/risk", Tag: "portfolios", Summary: "Portfolio risk (placeholder)", RequiresKey: true},
+
+	// DCA strategies
+	{Method: http.MethodPost, Path: "/api/v1/dca/strategies", Tag: "dca", Summary: "Create a DCA strategy; invalid fields are listed in the error details", Request: dto.CreateDCAStrategyRequest{}, Response: entities.DCAStrategy{}, Status: http.StatusCreated, RequiresKey: true},
+	{Method: http.MethodPost, Path: "/api/v1/dca/backtest", Tag: "dca", Summary: "Run a purchase schedule with a fixed amount and with smart DCA multipliers", Request: dto.DCABacktestRequest{}, Response: entities.DCABacktestComparison{}, RequiresKey: true},
+}
+
+// Spec builds the OpenAPI 3 document for the API
+func Spec() map[string]interface{} {
+	registry := newSchemaRegistry()
+	paths := make(map[string]interface{})
+
+	for _, op := range operations {
+		item, ok := paths[op.Path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[op.Path] = item
+		}
+		item[methodKey(op.Method)] = buildOperation(op, registry)
+	}
+
+	registry.components["ErrorResponse"] = errorResponseSchema()
+	registry.components["Pagination"] = paginationSchema()
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Crypto Indicator Dashboard API",
+			"description": "Market indicators, market data and portfolio tracking",
+			"version":     APIVersion,
+		},
+		"tags":  specTags(),
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": registry.components,
+			"securitySchemes": map[string]interface{}{
+				"ApiKeyAuth": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": "X-API-Key",
+				},
+			},
+		},
+	}
+}
+
+func buildOperation(op operation, registry *schemaRegistry) map[string]interface{} {
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	data := map[string]interface{}{}
+	if op.Response != nil {
+		data = registry.schemaFor(op.Response)
+	}
+
+	properties := map[string]interface{}{
+		"success": map[string]interface{}{"type": "boolean"},
+		"data":    data,
+		"meta":    map[string]interface{}{"type": "object", "additionalProperties": true},
+	}
+	if op.Paginated {
+		properties["pagination"] = map[string]interface{}{"$ref": "#/components/schemas/Pagination"}
+	}
+
+	result := map[string]interface{}{
+		"summary": op.Summary,
+		"tags":    []string{op.Tag},
+		"responses": map[string]interface{}{
+			statusKey(status): jsonResponse(http.StatusText(status), map[string]interface{}{
+				"type":       "object",
+				"properties": properties,
+			}),
+			"default": jsonResponse("Error", map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"}),
+		},
+	}
+
+	if len(op.Params) > 0 {
+		params := make([]map[string]interface{}, 0, len(op.Params))
+		for _, p := range op.Params {
+			params = append(params, map[string]interface{}{
+				"name":        p.Name,
+				"in":          p.In,
+				"description": p.Description,
+				"required":    p.Required,
+				"schema":      map[string]interface{}{"type": p.Schema},
+			})
+		}
+		result["parameters"] = params
+	}
+
+	if op.Request != nil {
+		result["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": registry.schemaFor(op.Request)},
+			},
+		}
+	}
+
+	if op.RequiresKey {
+		result["security"] = []map[string]interface{}{{"ApiKeyAuth": []string{}}}
+	}
+
+	return result
+}
+
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// errorResponseSchema describes the {"success": false, "error": {...}} error envelope
+func errorResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"success": map[string]interface{}{"type": "boolean"},
+			"error": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"type":    map[string]interface{}{"type": "string"},
+					"message": map[string]interface{}{"type": "string"},
+					"details": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+}
+
+// paginationSchema describes the pagination object of list responses
+func paginationSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"total":  map[string]interface{}{"type": "integer"},
+			"limit":  map[string]interface{}{"type": "integer"},
+			"offset": map[string]interface{}{"type": "integer"},
+			"next":   map[string]interface{}{"type": "integer", "nullable": true, "description": "Offset of the next page; null on the last page"},
+		},
+	}
+}
+
+func specTags() []map[string]interface{} {
+	seen := make(map[string]bool)
+	var names []string
+	for _, op := range operations {
+		if !seen[op.Tag] {
+			seen[op.Tag] = true
+			names = append(names, op.Tag)
+		}
+	}
+	sort.Strings(names)
+
+	tags := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		tags[i] = map[string]interface{}{"name": name}
+	}
+	return tags
+}
+
+func methodKey(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "post"
+	case http.MethodPut:
+		return "put"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "get"
+	}
+}
+
+func statusKey(status int) string {
+	switch status {
+	case http.StatusCreated:
+		return "201"
+	default:
+		return "200"
+	}
+}
@@ -0,0 +1,22 @@
+package docs
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedSpecUpToDate fails when openapi.json drifts from Spec();
+// run go generate ./internal/presentation/docs to refresh it.
+func TestGeneratedSpecUpToDate(t *testing.T) {
+	checkedIn, err := os.ReadFile("openapi.json")
+	require.NoError(t, err)
+
+	generated, err := json.MarshalIndent(Spec(), "", "  ")
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(generated), string(checkedIn))
+}
@@ -0,0 +1,120 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry generates OpenAPI schemas from Go types using their json and
+// binding tags, registering named structs as reusable components
+type schemaRegistry struct {
+	components map[string]interface{}
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{components: make(map[string]interface{})}
+}
+
+// schemaFor returns an inline schema or a component reference for a value's type
+func (r *schemaRegistry) schemaFor(v interface{}) map[string]interface{} {
+	return r.schema(reflect.TypeOf(v))
+}
+
+func (r *schemaRegistry) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": r.schema(t.Elem())}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return map[string]interface{}{"type": "object", "additionalProperties": true}
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		if _, ok := r.components[t.Name()]; !ok {
+			// Reserve the name first so self-referencing types terminate
+			r.components[t.Name()] = nil
+			r.components[t.Name()] = r.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema builds an object schema from exported fields, flattening embedded structs
+func (r *schemaRegistry) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	r.collectFields(t, properties, &required)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (r *schemaRegistry) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.collectFields(embedded, properties, required)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		properties[name] = r.schema(field.Type)
+		for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+			if rule == "required" {
+				*required = append(*required, name)
+			}
+		}
+	}
+}
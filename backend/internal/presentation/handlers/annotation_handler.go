@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bounds used when an annotation listing omits ?from= or ?to=
+var (
+	annotationRangeStart = time.Unix(0, 0).UTC()
+	annotationRangeEnd   = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// Page sizes for annotation listings
+const (
+	defaultAnnotationPageLimit = 100
+	maxAnnotationPageLimit     = 1000
+)
+
+// AnnotationHandler handles CRUD requests for chart annotations
+type AnnotationHandler struct {
+	annotationRepo repositories.AnnotationRepository
+	logger         logger.Logger
+}
+
+// NewAnnotationHandler creates a new annotation handler
+func NewAnnotationHandler(annotationRepo repositories.AnnotationRepository, logger logger.Logger) *AnnotationHandler {
+	return &AnnotationHandler{
+		annotationRepo: annotationRepo,
+		logger:         logger.With("handler", "annotation"),
+	}
+}
+
+// RegisterRoutes registers the annotation routes. Reads are public; writeMiddleware
+// (such as API key authentication) guards create, update and delete.
+func (h *AnnotationHandler) RegisterRoutes(router *gin.RouterGroup, writeMiddleware ...gin.HandlerFunc) {
+	guarded := func(handler gin.HandlerFunc) []gin.HandlerFunc {
+		return append(append([]gin.HandlerFunc{}, writeMiddleware...), handler)
+	}
+
+	annotations := router.Group("/annotations")
+	{
+		annotations.GET("", h.ListAnnotations)
+		annotations.GET("/:id", h.GetAnnotation)
+		annotations.POST("", guarded(h.CreateAnnotation)...)
+		annotations.PUT("/:id", guarded(h.UpdateAnnotation)...)
+		annotations.DELETE("/:id", guarded(h.DeleteAnnotation)...)
+	}
+}
+
+// CreateAnnotation creates a new chart annotation
+func (h *AnnotationHandler) CreateAnnotation(c *gin.Context) {
+	var req dto.AnnotationRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(c, h.logger, errors.Validation("Invalid annotation", err.Error()))
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	annotation := req.ToEntity()
+	if err := h.annotationRepo.Create(c.Request.Context(), annotation); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondCreated(c, annotation, nil)
+}
+
+// ListAnnotations returns annotations within ?from=&to=, oldest first.
+// Times are RFC3339 or YYYY-MM-DD; either bound may be omitted.
+func (h *AnnotationHandler) ListAnnotations(c *gin.Context) {
+	from, to := annotationRangeStart, annotationRangeEnd
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseTimeParam(raw)
+		if err != nil {
+			respondError(c, h.logger, errors.Validation("Invalid 'from' parameter", err.Error()))
+			return
+		}
+		from = parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseTimeParam(raw)
+		if err != nil {
+			respondError(c, h.logger, errors.Validation("Invalid 'to' parameter", err.Error()))
+			return
+		}
+		to = parsed
+	}
+	if from.After(to) {
+		respondError(c, h.logger, errors.Validation("Invalid time range", "'from' must not be after 'to'"))
+		return
+	}
+	limit, offset, err := parsePagination(c, defaultAnnotationPageLimit, maxAnnotationPageLimit)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	annotations, err := h.annotationRepo.GetInRange(c.Request.Context(), from, to)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	pagination := newPagination(len(annotations), limit, offset)
+	start, end := pagination.bounds()
+	RespondPage(c, annotations[start:end], pagination, nil)
+}
+
+// GetAnnotation returns a single annotation
+func (h *AnnotationHandler) GetAnnotation(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	annotation, err := h.annotationRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, annotation, nil)
+}
+
+// UpdateAnnotation replaces an annotation's timestamp, label and type
+func (h *AnnotationHandler) UpdateAnnotation(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	var req dto.AnnotationRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(c, h.logger, errors.Validation("Invalid annotation", err.Error()))
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	annotation := req.ToEntity()
+	annotation.ID = id
+	if err := h.annotationRepo.Update(c.Request.Context(), annotation); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	updated, err := h.annotationRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, updated, nil)
+}
+
+// DeleteAnnotation removes an annotation
+func (h *AnnotationHandler) DeleteAnnotation(c *gin.Context) {
+	id, err := h.parseID(c)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	if err := h.annotationRepo.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, nil, gin.H{"message": "Annotation deleted successfully"})
+}
+
+// available responds 503 when annotation storage is not configured
+func (h *AnnotationHandler) available(c *gin.Context) bool {
+	if h.annotationRepo != nil {
+		return true
+	}
+	respondUnavailable(c, "Annotation storage")
+	return false
+}
+
+func (h *AnnotationHandler) parseID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, errors.Validation("Invalid parameter format: id")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAnnotationRouter serves the annotation and chart routes over a SQLite annotation store
+func newAnnotationRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.AnnotationsTableDDL)
+
+	deps := &config.Dependencies{
+		Logger:         testDB.Logger,
+		Cache:          testutil.NewMockCacheService(),
+		AnnotationRepo: database.NewAnnotationRepository(database.NewDBProvider(testDB.DB, nil), testDB.Logger),
+	}
+
+	router := gin.New()
+	apiV1 := router.Group("/api/v1")
+	NewIndicatorHandler(deps).RegisterRoutes(apiV1)
+	NewAnnotationHandler(deps.AnnotationRepo, deps.Logger).RegisterRoutes(apiV1)
+	return router
+}
+
+func createAnnotation(t *testing.T, router *gin.Engine, body string) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/annotations", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return w.Code, response
+}
+
+func TestAnnotationHandler_ChartIncludesAnnotations(t *testing.T) {
+	router := newAnnotationRouter(t)
+
+	// The dominance chart covers the last 30 days
+	inRange := time.Now().AddDate(0, 0, -10).UTC().Format(time.RFC3339)
+	outOfRange := time.Now().AddDate(0, 0, -90).UTC().Format(time.RFC3339)
+
+	status, created := createAnnotation(t, router,
+		fmt.Sprintf(`{"timestamp": %q, "label": "ETF approval", "type": "news"}`, inRange))
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "ETF approval", created["data"].(map[string]interface{})["label"])
+
+	status, _ = createAnnotation(t, router,
+		fmt.Sprintf(`{"timestamp": %q, "label": "Old news", "type": "news"}`, outOfRange))
+	require.Equal(t, http.StatusCreated, status)
+
+	t.Run("Included with annotations=true", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/dominance?annotations=true", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		chart := response.Data
+		annotations, ok := chart["annotations"].([]interface{})
+		require.True(t, ok, "chart should carry an annotations list")
+		require.Len(t, annotations, 1)
+
+		annotation := annotations[0].(map[string]interface{})
+		assert.Equal(t, "ETF approval", annotation["label"])
+		assert.Equal(t, entities.AnnotationTypeNews, annotation["type"])
+	})
+
+	t.Run("Omitted by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/dominance", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		chart := response.Data
+		assert.NotContains(t, chart, "annotations")
+	})
+
+	t.Run("Invalid flag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/dominance?annotations=maybe", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAnnotationHandler_Create_Validation(t *testing.T) {
+	router := newAnnotationRouter(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Missing label", `{"timestamp": "2024-04-20T00:00:00Z", "type": "halving"}`},
+		{"Missing timestamp", `{"label": "Halving", "type": "halving"}`},
+		{"Unknown type", `{"timestamp": "2024-04-20T00:00:00Z", "label": "Halving", "type": "rumour"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := createAnnotation(t, router, tt.body)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.False(t, response["success"].(bool))
+		})
+	}
+}
+
+func TestAnnotationHandler_ListPagination(t *testing.T) {
+	router := newAnnotationRouter(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		status, _ := createAnnotation(t, router, fmt.Sprintf(`{"timestamp": %q, "label": "Event %d", "type": "news"}`,
+			start.AddDate(0, 0, i).Format(time.RFC3339), i))
+		require.Equal(t, http.StatusCreated, status)
+	}
+
+	list := func(query string) (int, []string, Pagination) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/annotations"+query, nil))
+
+		var response struct {
+			Data       []entities.IndicatorAnnotation `json:"data"`
+			Pagination Pagination                     `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+		labels := make([]string, len(response.Data))
+		for i, annotation := range response.Data {
+			labels[i] = annotation.Label
+		}
+		return w.Code, labels, response.Pagination
+	}
+
+	t.Run("Middle page", func(t *testing.T) {
+		status, labels, pagination := list("?limit=2&offset=2")
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{"Event 2", "Event 3"}, labels)
+		assert.Equal(t, 5, pagination.Total)
+		assert.Equal(t, 2, pagination.Limit)
+		assert.Equal(t, 2, pagination.Offset)
+		require.NotNil(t, pagination.Next)
+		assert.Equal(t, 4, *pagination.Next)
+	})
+
+	t.Run("Last page", func(t *testing.T) {
+		status, labels, pagination := list("?limit=2&offset=4")
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{"Event 4"}, labels)
+		assert.Equal(t, 5, pagination.Total)
+		assert.Nil(t, pagination.Next)
+	})
+
+	t.Run("Past the end", func(t *testing.T) {
+		status, labels, pagination := list("?offset=10")
+		require.Equal(t, http.StatusOK, status)
+		assert.Empty(t, labels)
+		assert.Equal(t, defaultAnnotationPageLimit, pagination.Limit)
+		assert.Nil(t, pagination.Next)
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"?limit=0", "?limit=5000", "?offset=-1", "?limit=abc"} {
+			status, _, _ := list(query)
+			assert.Equal(t, http.StatusBadRequest, status, query)
+		}
+	})
+}
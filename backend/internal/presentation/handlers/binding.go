@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"crypto-indicator-dashboard/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Report validation failures by JSON field name rather than Go field name
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// bindJSON decodes and validates the request body into obj. Failures come back as app errors:
+// 413 for a body over the MaxBodyBytes limit, otherwise 400 with the failing fields in the details.
+func bindJSON(c *gin.Context, obj interface{}) error {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return nil
+	}
+
+	var tooLarge *http.MaxBytesError
+	if stderrors.As(err, &tooLarge) {
+		return errors.PayloadTooLarge(fmt.Sprintf("Request body exceeds %d bytes", tooLarge.Limit))
+	}
+
+	if fields := invalidFields(err); len(fields) > 0 {
+		return errors.Validation("Invalid request body", strings.Join(fields, "; "))
+	}
+
+	return errors.Validation("Invalid request format", err.Error())
+}
+
+// invalidFields describes each failed validation tag in err, including those of every element
+// of a JSON array body. It returns nil when err is not a validation failure.
+func invalidFields(err error) []string {
+	var elements binding.SliceValidationError
+	if stderrors.As(err, &elements) {
+		var fields []string
+		for _, elementErr := range elements {
+			fields = append(fields, invalidFields(elementErr)...)
+		}
+		return fields
+	}
+
+	var invalid validator.ValidationErrors
+	if !stderrors.As(err, &invalid) {
+		return nil
+	}
+	fields := make([]string, 0, len(invalid))
+	for _, fieldErr := range invalid {
+		fields = append(fields, describeFieldError(fieldErr))
+	}
+	return fields
+}
+
+// describeFieldError renders one failed validation tag as "field: problem"
+func describeFieldError(fieldErr validator.FieldError) string {
+	field := fieldErr.Namespace()
+	// Drop the top-level type name, keeping nested field names
+	if i := strings.Index(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+
+	var problem string
+	switch fieldErr.Tag() {
+	case "required":
+		problem = "is required"
+	case "gt":
+		problem = "must be greater than " + fieldErr.Param()
+	case "gte":
+		problem = "must be at least " + fieldErr.Param()
+	case "lt":
+		problem = "must be less than " + fieldErr.Param()
+	case "lte":
+		problem = "must be at most " + fieldErr.Param()
+	case "min", "max":
+		bound := "at least "
+		if fieldErr.Tag() == "max" {
+			bound = "at most "
+		}
+		switch fieldErr.Kind() {
+		case reflect.String:
+			problem = "must have " + bound + fieldErr.Param() + " characters"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			problem = "must have " + bound + fieldErr.Param() + " items"
+		default:
+			problem = "must be " + bound + fieldErr.Param()
+		}
+	case "oneof":
+		problem = "must be one of: " + fieldErr.Param()
+	default:
+		problem = fmt.Sprintf("failed %q validation", fieldErr.Tag())
+	}
+	return field + ": " + problem
+}
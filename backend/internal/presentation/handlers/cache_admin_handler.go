@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheAdminHandler lets operators invalidate cached responses without flushing the cache
+type CacheAdminHandler struct {
+	cache  services.CacheService
+	logger logger.Logger
+}
+
+// NewCacheAdminHandler creates a new cache admin handler
+func NewCacheAdminHandler(cache services.CacheService, logger logger.Logger) *CacheAdminHandler {
+	return &CacheAdminHandler{
+		cache:  cache,
+		logger: logger.With("handler", "cache_admin"),
+	}
+}
+
+// RegisterRoutes registers the cache admin routes behind the given middleware
+func (h *CacheAdminHandler) RegisterRoutes(router *gin.RouterGroup, middleware ...gin.HandlerFunc) {
+	admin := router.Group("/admin", middleware...)
+	{
+		admin.DELETE("/cache", h.DeletePattern)
+		admin.DELETE("/cache/:key", h.DeleteKey)
+	}
+}
+
+// DeleteKey removes a single cache key
+func (h *CacheAdminHandler) DeleteKey(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	key := c.Param("key")
+	if err := h.cache.Delete(c.Request.Context(), key); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Cache key invalidated", "key", key)
+	RespondOK(c, gin.H{"key": key}, nil)
+}
+
+// DeletePattern removes every cache key matching the ?pattern= glob, such as indicator_*
+func (h *CacheAdminHandler) DeletePattern(c *gin.Context) {
+	pattern := c.Query("pattern")
+	if pattern == "" {
+		respondError(c, h.logger, errors.Validation("Missing pattern", "pattern is required, e.g. ?pattern=indicator_*"))
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	deleted, err := h.cache.DeletePattern(c.Request.Context(), pattern)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Cache keys invalidated", "pattern", pattern, "count", deleted)
+	RespondOK(c, gin.H{"pattern": pattern, "deleted": deleted}, nil)
+}
+
+// available responds 503 when no cache is configured
+func (h *CacheAdminHandler) available(c *gin.Context) bool {
+	if h.cache != nil {
+		return true
+	}
+	respondUnavailable(c, "Cache")
+	return false
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCacheAdminRouter serves the cache admin routes over an in-memory cache seeded with keys
+func newCacheAdminRouter(t *testing.T, keys ...string) (*gin.Engine, services.CacheService) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+	store := cache.NewCacheService(nil, log)
+	for _, key := range keys {
+		require.NoError(t, store.Set(context.Background(), key, "cached", time.Minute))
+	}
+
+	router := gin.New()
+	NewCacheAdminHandler(store, log).RegisterRoutes(router.Group("/api/v1"))
+	return router, store
+}
+
+func TestCacheAdminHandler_DeleteKey(t *testing.T) {
+	ctx := context.Background()
+	router, store := newCacheAdminRouter(t, "indicator_mvrv", "indicator_rhodl", "market_summary_10")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/cache/indicator_mvrv", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.False(t, store.Exists(ctx, "indicator_mvrv"))
+	assert.True(t, store.Exists(ctx, "indicator_rhodl"))
+	assert.True(t, store.Exists(ctx, "market_summary_10"))
+}
+
+func TestCacheAdminHandler_DeletePattern(t *testing.T) {
+	ctx := context.Background()
+	router, store := newCacheAdminRouter(t, "indicator_mvrv", "indicator_rhodl", "market_summary_10")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/cache?pattern=indicator_*", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Data struct {
+			Deleted int `json:"deleted"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Data.Deleted)
+
+	assert.False(t, store.Exists(ctx, "indicator_mvrv"))
+	assert.False(t, store.Exists(ctx, "indicator_rhodl"))
+	assert.True(t, store.Exists(ctx, "market_summary_10"))
+
+	for _, query := range []string{"", "?pattern=indicator_[", "?pattern="} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/cache"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	assert.True(t, store.Exists(ctx, "market_summary_10"))
+}
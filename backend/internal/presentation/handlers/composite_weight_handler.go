@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompositeWeightHandler serves the composite risk score and lets operators read and adjust
+// its weights
+type CompositeWeightHandler struct {
+	service services.CompositeWeightService
+	logger  logger.Logger
+}
+
+// NewCompositeWeightHandler creates a new composite weight handler
+func NewCompositeWeightHandler(service services.CompositeWeightService, logger logger.Logger) *CompositeWeightHandler {
+	return &CompositeWeightHandler{
+		service: service,
+		logger:  logger.With("handler", "composite_weights"),
+	}
+}
+
+// RegisterRoutes registers the public composite score route and the composite weight
+// routes behind the given middleware
+func (h *CompositeWeightHandler) RegisterRoutes(router *gin.RouterGroup, middleware ...gin.HandlerFunc) {
+	router.GET("/indicators/composite", h.GetCompositeScore)
+
+	admin := router.Group("/admin", middleware...)
+	{
+		admin.GET("/composite-weights", h.GetWeights)
+		admin.PUT("/composite-weights", h.UpdateWeights)
+	}
+}
+
+// GetCompositeScore returns the composite risk score under the weights in effect
+func (h *CompositeWeightHandler) GetCompositeScore(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	score, err := h.service.GetCompositeScore(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, score, nil)
+}
+
+// GetWeights returns the composite weights in effect
+func (h *CompositeWeightHandler) GetWeights(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	config, err := h.service.GetWeights(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, config, nil)
+}
+
+// UpdateWeights replaces the composite weights with a new revision
+func (h *CompositeWeightHandler) UpdateWeights(c *gin.Context) {
+	var req dto.CompositeWeightsRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	config, err := h.service.UpdateWeights(c.Request.Context(), req.Weights)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, config, nil)
+}
+
+// available responds 503 when composite weight storage is not configured
+func (h *CompositeWeightHandler) available(c *gin.Context) bool {
+	if h.service != nil {
+		return true
+	}
+	respondUnavailable(c, "Composite weight storage")
+	return false
+}
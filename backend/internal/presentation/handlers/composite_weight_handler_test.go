@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/application/services"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompositeWeightRouter serves the composite routes over SQLite weight and indicator stores
+func newCompositeWeightRouter(t *testing.T) (*gin.Engine, repositories.IndicatorRepository) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.CompositeWeightsTableDDL, testutil.IndicatorsTableDDL)
+
+	provider := database.NewDBProvider(testDB.DB, nil)
+	repo := database.NewCompositeWeightRepository(provider, testDB.Logger)
+	indicatorRepo := database.NewIndicatorRepository(provider, testDB.Logger)
+	router := gin.New()
+	NewCompositeWeightHandler(services.NewCompositeWeightService(repo, indicatorRepo, testDB.Logger), testDB.Logger).
+		RegisterRoutes(router.Group("/api/v1"))
+	return router, indicatorRepo
+}
+
+func requestCompositeWeights(t *testing.T, router *gin.Engine, method, body string) (int, map[string]float64) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/api/v1/admin/composite-weights", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response struct {
+		Data entities.CompositeWeightConfig `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+	return w.Code, response.Data.Weights
+}
+
+func TestCompositeWeightHandler_Update(t *testing.T) {
+	router, _ := newCompositeWeightRouter(t)
+
+	// Defaults apply until weights are stored
+	code, weights := requestCompositeWeights(t, router, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, entities.DefaultCompositeWeights, weights)
+
+	code, weights = requestCompositeWeights(t, router, http.MethodPut, `{"weights":{"mvrv":0.6,"fear_greed":0.4,"dominance":0}}`)
+	require.Equal(t, http.StatusOK, code)
+	expected := map[string]float64{"mvrv": 0.6, "fear_greed": 0.4, "dominance": 0}
+	assert.Equal(t, expected, weights)
+
+	// The latest revision is what's read back
+	code, weights = requestCompositeWeights(t, router, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expected, weights)
+
+	code, _ = requestCompositeWeights(t, router, http.MethodPut, `{"weights":{"mvrv":1}}`)
+	require.Equal(t, http.StatusOK, code)
+	_, weights = requestCompositeWeights(t, router, http.MethodGet, "")
+	assert.Equal(t, map[string]float64{"mvrv": 1}, weights)
+}
+
+func TestCompositeWeightHandler_RejectsInvalidWeights(t *testing.T) {
+	router, _ := newCompositeWeightRouter(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Negative weight", `{"weights":{"mvrv":0.8,"fear_greed":-0.2}}`},
+		{"Unknown component", `{"weights":{"mvrv":0.5,"nvt":0.5}}`},
+		{"All weights zero", `{"weights":{"mvrv":0,"fear_greed":0}}`},
+		{"No weights", `{"weights":{}}`},
+		{"Missing weights", `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := requestCompositeWeights(t, router, http.MethodPut, tt.body)
+			assert.Equal(t, http.StatusBadRequest, code)
+		})
+	}
+
+	// Rejected updates leave the weights in effect unchanged
+	_, weights := requestCompositeWeights(t, router, http.MethodGet, "")
+	assert.Equal(t, entities.DefaultCompositeWeights, weights)
+}
+
+func TestCompositeWeightHandler_CompositeScore(t *testing.T) {
+	router, indicatorRepo := newCompositeWeightRouter(t)
+
+	getScore := func() (int, entities.CompositeScore) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/composite", nil))
+
+		var response struct {
+			Data entities.CompositeScore `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+		return w.Code, response.Data
+	}
+
+	// Without any stored component there is nothing to combine
+	code, _ := getScore()
+	assert.Equal(t, http.StatusNotFound, code)
+
+	now := time.Now()
+	for _, indicator := range []entities.Indicator{
+		{Name: "mvrv", Type: "onchain", RiskLevel: "high", Timestamp: now},
+		{Name: "fear_greed", Type: "sentiment", RiskLevel: "low", Timestamp: now},
+		{Name: "altcoin_season_index", Type: "market", RiskLevel: "extreme_high", Timestamp: now},
+	} {
+		indicator := indicator
+		require.NoError(t, indicatorRepo.Create(context.Background(), &indicator))
+	}
+
+	code, _ = requestCompositeWeights(t, router, http.MethodPut, `{"weights":{"mvrv":0.5,"fear_greed":0.25,"alt_season":0.25}}`)
+	require.Equal(t, http.StatusOK, code)
+
+	code, score := getScore()
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 0.5*75+0.25*25+0.25*100, score.Score, 1e-9)
+	assert.NotZero(t, score.WeightsRevision)
+	assert.Len(t, score.Components, 3)
+	assert.Empty(t, score.Missing)
+
+	// New weights apply to the next score; components without a value are renormalised away
+	code, _ = requestCompositeWeights(t, router, http.MethodPut, `{"weights":{"mvrv":0.5,"rhodl":0.5}}`)
+	require.Equal(t, http.StatusOK, code)
+
+	code, score = getScore()
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 75, score.Score, 1e-9)
+	assert.Equal(t, []string{"rhodl"}, score.Missing)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exportFormatJSON = "json"
+	exportFormatCSV  = "csv"
+
+	// csvFlushRows is how many rows are buffered before they are flushed to the client
+	csvFlushRows = 500
+)
+
+// indicatorCSVHeader is the header row of indicator history exports
+var indicatorCSVHeader = []string{"timestamp", "value", "risk_level", "confidence"}
+
+// parseExportFormat reads ?format=, which is json (the default) or csv
+func parseExportFormat(c *gin.Context) (string, error) {
+	switch format := c.DefaultQuery("format", exportFormatJSON); format {
+	case exportFormatJSON, exportFormatCSV:
+		return format, nil
+	default:
+		return "", errors.Validation("Invalid 'format' parameter", "format must be json or csv")
+	}
+}
+
+// respondIndicatorCSV streams history as a CSV attachment named after the indicator and
+// window, one row per stored value in the order given
+func respondIndicatorCSV(c *gin.Context, name string, window entities.TimeRange, history []entities.Indicator) {
+	filename := fmt.Sprintf("%s_%s_%s.csv", name, window.From.UTC().Format("20060102"), window.To.UTC().Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write(indicatorCSVHeader)
+	for i, point := range history {
+		_ = writer.Write([]string{
+			point.Timestamp.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(point.Value, 'f', -1, 64),
+			point.RiskLevel,
+			strconv.FormatFloat(point.Confidence, 'f', -1, 64),
+		})
+		if (i+1)%csvFlushRows == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+		}
+	}
+	writer.Flush()
+}
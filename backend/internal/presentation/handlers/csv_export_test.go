@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorHandler_HistoryCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	history := []entities.Indicator{
+		{Timestamp: day, Value: 31250.5, RiskLevel: "medium", Confidence: 0.85, CalcVersion: 1, Metadata: map[string]interface{}{"price": 60000.0}},
+		{Timestamp: day.AddDate(0, 0, 1), Value: -4.2, RiskLevel: "low", Confidence: 0.8, CalcVersion: 2, Metadata: map[string]interface{}{"price": 61000.0}},
+	}
+
+	deps := &config.Dependencies{
+		Logger:               logger.New("test"),
+		Cache:                testutil.NewMockCacheService(),
+		RealizedPriceService: fixedHistoryService{history: history},
+		MVRVService:          fixedHistoryService{history: history},
+		MarketTrendService:   fixedHistoryService{history: history},
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("Streams a CSV attachment", func(t *testing.T) {
+		for path, name := range map[string]string{
+			"/api/v1/charts/realized-price?format=csv&from=2024-03-01T00:00:00Z&to=2024-03-03T00:00:00Z":           "realized_price",
+			"/api/v1/charts/mvrv?format=csv&from=2024-03-01T00:00:00Z&to=2024-03-03T00:00:00Z":                     "mvrv",
+			"/api/v1/indicators/market-trend/history?format=csv&from=2024-03-01T00:00:00Z&to=2024-03-03T00:00:00Z": "market_trend",
+		} {
+			w := get(path)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="`+name+`_20240301_20240303.csv"`, w.Header().Get("Content-Disposition"))
+
+			rows, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, rows, 3, path)
+			assert.Equal(t, []string{"timestamp", "value", "risk_level", "confidence"}, rows[0])
+			assert.Equal(t, []string{"2024-03-01T00:00:00Z", "31250.5", "medium", "0.85"}, rows[1])
+			assert.Equal(t, []string{"2024-03-02T00:00:00Z", "-4.2", "low", "0.8"}, rows[2])
+		}
+	})
+
+	t.Run("Honours calc_version", func(t *testing.T) {
+		w := get("/api/v1/charts/realized-price?format=csv&calc_version=2")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		rows, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "-4.2", rows[1][1])
+	})
+
+	t.Run("JSON stays the default", func(t *testing.T) {
+		w := get("/api/v1/indicators/market-trend/history")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	})
+
+	t.Run("Charts without stored history reject CSV", func(t *testing.T) {
+		for _, path := range []string{"/api/v1/charts/dominance?format=csv", "/api/v1/charts/fear-greed?format=csv", "/api/v1/charts/bubble-risk?format=csv"} {
+			assert.Equal(t, http.StatusBadRequest, get(path).Code, path)
+		}
+	})
+
+	t.Run("MVRV chart errors use the error envelope", func(t *testing.T) {
+		// fixedHistoryService has no latest calculation, only a rate limited upstream
+		w := get("/api/v1/charts/mvrv")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"success":false`)
+	})
+
+	t.Run("Unknown formats are rejected", func(t *testing.T) {
+		for _, path := range []string{"/api/v1/charts/realized-price?format=xml", "/api/v1/indicators/market-trend/history?format=xlsx"} {
+			assert.Equal(t, http.StatusBadRequest, get(path).Code, path)
+		}
+	})
+}
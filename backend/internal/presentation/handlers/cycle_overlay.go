@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/errors"
+)
+
+// cycleOverlaySeries names, per chart, the price series ?overlay=cycles splits into
+// halving cycles. Only price-based charts can be overlaid.
+var cycleOverlaySeries = map[string]string{
+	"realized-price": "price_data",
+}
+
+// cycleSegment is the part of a series that falls in one halving cycle, positioned by
+// whole days since that cycle's halving so cycles can be drawn over each other
+type cycleSegment struct {
+	Halving          time.Time `json:"halving"`
+	DaysSinceHalving []int     `json:"days_since_halving"`
+	Values           []float64 `json:"values"`
+	// RelativeValues divides each value by the segment's first, putting cycles that
+	// traded at very different prices on one scale
+	RelativeValues []float64 `json:"relative_values,omitempty"`
+}
+
+// parseCycleOverlay validates ?overlay= for a chart and returns the series to overlay,
+// or "" when no overlay was asked for
+func parseCycleOverlay(overlay, indicator string) (string, error) {
+	if overlay == "" {
+		return "", nil
+	}
+	if overlay != "cycles" {
+		return "", errors.Validation("Invalid 'overlay' parameter", "overlay must be cycles")
+	}
+
+	seriesKey, ok := cycleOverlaySeries[indicator]
+	if !ok {
+		charts := make([]string, 0, len(cycleOverlaySeries))
+		for chart := range cycleOverlaySeries {
+			charts = append(charts, chart)
+		}
+		sort.Strings(charts)
+		return "", errors.Validation("Cycle overlay not supported for this chart",
+			"overlay=cycles is available for: "+strings.Join(charts, ", "))
+	}
+	return seriesKey, nil
+}
+
+// addCycleOverlay adds a "cycles" list splitting chartData[seriesKey] by Bitcoin halving
+func addCycleOverlay(chartData map[string]interface{}, seriesKey string) {
+	timestamps, _ := chartData["timestamps"].([]int64)
+	series, _ := floatSeries(chartData[seriesKey])
+	chartData["cycles"] = alignByHalving(timestamps, series, entities.BitcoinHalvings)
+}
+
+// alignByHalving splits a series into one segment per halving cycle, oldest first. A
+// cycle runs from its halving until the next one; points before the first halving
+// belong to no cycle and are dropped, as are cycles without any points.
+func alignByHalving(timestamps []int64, values []float64, halvings []time.Time) []cycleSegment {
+	segments := make([]cycleSegment, len(halvings))
+	for i, halving := range halvings {
+		segments[i].Halving = halving
+	}
+
+	for i, ts := range timestamps {
+		if i >= len(values) {
+			break
+		}
+		at := time.UnixMilli(ts)
+
+		// The cycle is the last halving at or before the point
+		cycle := sort.Search(len(halvings), func(j int) bool { return halvings[j].After(at) }) - 1
+		if cycle < 0 {
+			continue
+		}
+
+		segment := &segments[cycle]
+		segment.DaysSinceHalving = append(segment.DaysSinceHalving, int(at.Sub(segment.Halving)/(24*time.Hour)))
+		segment.Values = append(segment.Values, values[i])
+	}
+
+	aligned := make([]cycleSegment, 0, len(segments))
+	for _, segment := range segments {
+		if len(segment.Values) == 0 {
+			continue
+		}
+		if first := segment.Values[0]; first > 0 {
+			segment.RelativeValues = make([]float64, len(segment.Values))
+			for i, v := range segment.Values {
+				segment.RelativeValues[i] = v / first
+			}
+		}
+		aligned = append(aligned, segment)
+	}
+	return aligned
+}
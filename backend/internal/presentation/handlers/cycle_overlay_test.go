@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlignByHalving(t *testing.T) {
+	halvings := []time.Time{
+		time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	day := func(halving time.Time, days int) int64 {
+		return halving.AddDate(0, 0, days).Add(6 * time.Hour).UnixMilli()
+	}
+
+	// Two synthetic cycles with the same shape at different price levels, plus a
+	// point before the first halving
+	timestamps := []int64{
+		halvings[0].AddDate(0, 0, -3).UnixMilli(),
+		day(halvings[0], 0), day(halvings[0], 10), day(halvings[0], 20),
+		day(halvings[1], 0), day(halvings[1], 10), day(halvings[1], 20),
+	}
+	values := []float64{50, 100, 150, 200, 1000, 1500, 2000}
+
+	cycles := alignByHalving(timestamps, values, halvings)
+
+	require.Len(t, cycles, 2, "the cycle without data is dropped")
+	for i, cycle := range cycles {
+		assert.True(t, cycle.Halving.Equal(halvings[i]))
+		assert.Equal(t, []int{0, 10, 20}, cycle.DaysSinceHalving)
+		assert.Equal(t, []float64{1, 1.5, 2}, cycle.RelativeValues)
+	}
+	assert.Equal(t, []float64{100, 150, 200}, cycles[0].Values)
+	assert.Equal(t, []float64{1000, 1500, 2000}, cycles[1].Values)
+}
+
+func TestParseCycleOverlay(t *testing.T) {
+	seriesKey, err := parseCycleOverlay("", "dominance")
+	require.NoError(t, err)
+	assert.Empty(t, seriesKey)
+
+	seriesKey, err = parseCycleOverlay("cycles", "realized-price")
+	require.NoError(t, err)
+	assert.Equal(t, "price_data", seriesKey)
+
+	_, err = parseCycleOverlay("epochs", "realized-price")
+	assert.Error(t, err)
+
+	_, err = parseCycleOverlay("cycles", "dominance")
+	assert.Error(t, err)
+}
+
+// fixedHistoryService returns the same history for any window
+type fixedHistoryService struct {
+	rateLimitedIndicatorService
+	history []entities.Indicator
+}
+
+func (s fixedHistoryService) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	return s.history, nil
+}
+
+func TestIndicatorHandler_ChartCycleOverlay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	halving := entities.BitcoinHalvings[len(entities.BitcoinHalvings)-1]
+	var history []entities.Indicator
+	for _, days := range []int{-2, 0, 30} {
+		history = append(history, entities.Indicator{
+			Timestamp: halving.AddDate(0, 0, days),
+			Value:     40000,
+			Metadata:  map[string]interface{}{"price": 60000 + float64(days)*100},
+		})
+	}
+
+	deps := &config.Dependencies{
+		Logger:               testDB.Logger,
+		Cache:                testutil.NewMockCacheService(),
+		RealizedPriceService: fixedHistoryService{history: history},
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	t.Run("Price series split by halving", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/realized-price?period=1y&overlay=cycles", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response struct {
+			Data struct {
+				Cycles []cycleSegment `json:"cycles"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+		cycles := response.Data.Cycles
+		require.Len(t, cycles, 2)
+		assert.True(t, cycles[0].Halving.Equal(entities.BitcoinHalvings[len(entities.BitcoinHalvings)-2]))
+		assert.Equal(t, []float64{59800}, cycles[0].Values)
+		assert.True(t, cycles[1].Halving.Equal(halving))
+		assert.Equal(t, []int{0, 30}, cycles[1].DaysSinceHalving)
+		assert.Equal(t, []float64{60000, 63000}, cycles[1].Values)
+	})
+
+	t.Run("Charts without a price series are rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/dominance?overlay=cycles", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
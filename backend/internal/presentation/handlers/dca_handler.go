@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DCAHandler manages users' dollar-cost averaging strategies and backtests them
+type DCAHandler struct {
+	repo     repositories.DCARepository
+	smartDCA services.SmartDCAService
+	logger   logger.Logger
+}
+
+// NewDCAHandler creates a new DCA handler
+func NewDCAHandler(repo repositories.DCARepository, smartDCA services.SmartDCAService, logger logger.Logger) *DCAHandler {
+	return &DCAHandler{
+		repo:     repo,
+		smartDCA: smartDCA,
+		logger:   logger.With("handler", "dca"),
+	}
+}
+
+// RegisterRoutes registers the DCA routes behind the given middleware, which must set the
+// authenticated user
+func (h *DCAHandler) RegisterRoutes(router *gin.RouterGroup, middleware ...gin.HandlerFunc) {
+	dca := router.Group("/dca", middleware...)
+	{
+		dca.POST("/strategies", h.CreateStrategy)
+		dca.POST("/backtest", h.Backtest)
+	}
+}
+
+// CreateStrategy validates and stores a new strategy for the authenticated user
+func (h *DCAHandler) CreateStrategy(c *gin.Context) {
+	var req dto.CreateDCAStrategyRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(c, h.logger, errors.Validation("Invalid DCA strategy", err.Error()))
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		respondError(c, h.logger, errors.Unauthorized("An API key is required to create DCA strategies"))
+		return
+	}
+
+	if h.repo == nil {
+		respondUnavailable(c, "DCA strategy storage")
+		return
+	}
+
+	strategy := req.ToEntity(userID)
+	if err := h.repo.CreateStrategy(c.Request.Context(), strategy); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondCreated(c, strategy, nil)
+}
+
+// Backtest runs the requested purchase schedule with a fixed amount and with smart DCA
+// multipliers, so the two can be compared
+func (h *DCAHandler) Backtest(c *gin.Context) {
+	var req dto.DCABacktestRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	if h.smartDCA == nil {
+		respondUnavailable(c, "Smart DCA service")
+		return
+	}
+
+	comparison, err := h.smartDCA.Backtest(c.Request.Context(), req.Amount, req.Points)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, comparison, nil)
+}
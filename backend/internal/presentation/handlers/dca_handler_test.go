@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/application/services"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	domainservices "crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDCARepository records created strategies; other methods are not used by the handler
+type fakeDCARepository struct {
+	repositories.DCARepository
+	created []*entities.DCAStrategy
+}
+
+func (r *fakeDCARepository) CreateStrategy(_ context.Context, strategy *entities.DCAStrategy) error {
+	strategy.ID = uint(len(r.created) + 1)
+	r.created = append(r.created, strategy)
+	return nil
+}
+
+// newDCARouter serves the DCA routes as an authenticated user
+func newDCARouter(repo repositories.DCARepository, smartDCA domainservices.SmartDCAService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	authenticate := func(c *gin.Context) { c.Set(middleware.UserIDKey, "user-1") }
+	NewDCAHandler(repo, smartDCA, logger.New("test")).RegisterRoutes(router.Group("/api/v1"), authenticate)
+	return router
+}
+
+func postDCAStrategy(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	return postDCA(router, "/api/v1/dca/strategies", body)
+}
+
+func postDCA(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDCAHandler_CreateStrategy(t *testing.T) {
+	repo := &fakeDCARepository{}
+	router := newDCARouter(repo, nil)
+
+	w := postDCAStrategy(router, `{"name":" Weekly stack ","symbol":"btc","amount":100,"frequency":"weekly",
+		"start_date":"2024-01-01T00:00:00Z","end_date":"2025-01-01T00:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response struct {
+		Data entities.DCAStrategy `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(1), response.Data.ID)
+	assert.Equal(t, "Weekly stack", response.Data.Name)
+	assert.Equal(t, "BTC", response.Data.Symbol)
+	assert.Equal(t, "user-1", response.Data.UserID)
+	assert.True(t, response.Data.IsActive)
+
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, entities.DCAFrequencyWeekly, repo.created[0].Frequency)
+}
+
+func TestDCAHandler_CreateStrategyValidation(t *testing.T) {
+	const valid = `"name":"Stack","symbol":"BTC","amount":100,"frequency":"weekly","start_date":"2024-01-01T00:00:00Z"`
+
+	tests := []struct {
+		name    string
+		body    string
+		details string
+	}{
+		{"Unknown frequency", `{"name":"Stack","symbol":"BTC","amount":100,"frequency":"hourly","start_date":"2024-01-01T00:00:00Z"}`, "frequency"},
+		{"Zero amount", `{"name":"Stack","symbol":"BTC","amount":0,"frequency":"weekly","start_date":"2024-01-01T00:00:00Z"}`, "amount"},
+		{"Negative amount", `{"name":"Stack","symbol":"BTC","amount":-5,"frequency":"weekly","start_date":"2024-01-01T00:00:00Z"}`, "amount"},
+		{"Invalid symbol", `{"name":"Stack","symbol":"BT-C!","amount":100,"frequency":"weekly","start_date":"2024-01-01T00:00:00Z"}`, "symbol: must be 2 to 10 letters or digits"},
+		{"End before start", `{` + valid + `,"end_date":"2023-06-01T00:00:00Z"}`, "end_date: must be after start_date"},
+		{"End equals start", `{` + valid + `,"end_date":"2024-01-01T00:00:00Z"}`, "end_date: must be after start_date"},
+		{"Missing start date", `{"name":"Stack","symbol":"BTC","amount":100,"frequency":"weekly"}`, "start_date"},
+		{"Missing name", `{"symbol":"BTC","amount":100,"frequency":"weekly","start_date":"2024-01-01T00:00:00Z"}`, "name"},
+		{"Malformed JSON", `{"name":`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeDCARepository{}
+			w := postDCAStrategy(newDCARouter(repo, nil), tt.body)
+			require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+			var response struct {
+				Error struct {
+					Type    string `json:"type"`
+					Details string `json:"details"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "VALIDATION_ERROR", response.Error.Type)
+			assert.Contains(t, response.Error.Details, tt.details)
+			assert.Empty(t, repo.created)
+		})
+	}
+}
+
+func TestDCAHandler_CreateStrategyUnavailable(t *testing.T) {
+	w := postDCAStrategy(newDCARouter(nil, nil),
+		`{"name":"Stack","symbol":"BTC","amount":100,"frequency":"daily","start_date":"2024-01-01T00:00:00Z"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestDCAHandler_Backtest(t *testing.T) {
+	smartDCA, err := services.NewSmartDCAService(entities.DefaultSmartDCAConfig, nil, logger.New("test"))
+	require.NoError(t, err)
+	router := newDCARouter(nil, smartDCA)
+
+	// A cheap market (negative Z-score, extreme fear) followed by an expensive one
+	w := postDCA(router, "/api/v1/dca/backtest", `{"amount":100,"points":[
+		{"date":"2024-02-01T00:00:00Z","price":60000,"mvrv_zscore":7,"fear_greed":90},
+		{"date":"2024-01-01T00:00:00Z","price":20000,"mvrv_zscore":-1,"fear_greed":5}]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Data entities.DCABacktestComparison `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.InDelta(t, 200, response.Data.Fixed.TotalInvested, 1e-9)
+	assert.InDelta(t, 225, response.Data.Smart.TotalInvested, 1e-9, "2x the base amount at the bottom, 0.25x at the top")
+	assert.Greater(t, response.Data.Smart.TotalQuantity, response.Data.Fixed.TotalQuantity)
+	require.Len(t, response.Data.SmartPurchases, 2)
+	assert.InDelta(t, 200, response.Data.SmartPurchases[0].Amount, 1e-9, "purchases run in date order")
+
+	for name, body := range map[string]string{
+		"Zero amount":    `{"amount":0,"points":[{"date":"2024-01-01T00:00:00Z","price":20000}]}`,
+		"No points":      `{"amount":100,"points":[]}`,
+		"Zero price":     `{"amount":100,"points":[{"date":"2024-01-01T00:00:00Z","price":0}]}`,
+		"Malformed JSON": `{"amount":`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, postDCA(router, "/api/v1/dca/backtest", body).Code, name)
+	}
+}
+
+func TestDCAHandler_BacktestUnavailable(t *testing.T) {
+	w := postDCA(newDCARouter(nil, nil), "/api/v1/dca/backtest",
+		`{"amount":100,"points":[{"date":"2024-01-01T00:00:00Z","price":20000}]}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
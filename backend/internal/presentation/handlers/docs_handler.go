@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"crypto-indicator-dashboard/internal/presentation/docs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultSwaggerUIAssetsURL serves a pinned Swagger UI release from a CDN; point
+// SWAGGER_UI_ASSETS_URL at a self-hosted copy to keep the docs page off third-party hosts
+const DefaultSwaggerUIAssetsURL = "https://unpkg.com/swagger-ui-dist@5.17.14"
+
+// swaggerUIPage renders Swagger UI against the served spec; %[1]s is the assets base URL
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Crypto Indicator Dashboard API</title>
+  <link rel="stylesheet" href="%[1]s/swagger-ui.css" crossorigin="anonymous" referrerpolicy="no-referrer">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="%[1]s/swagger-ui-bundle.js" crossorigin="anonymous" referrerpolicy="no-referrer"></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "/swagger/doc.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>`
+
+// DocsHandler serves the OpenAPI document and a Swagger UI page
+type DocsHandler struct {
+	spec []byte
+	page []byte
+}
+
+// NewDocsHandler creates a new docs handler, rendering the spec and the Swagger UI page,
+// which loads its assets from assetsURL, once up front
+func NewDocsHandler(assetsURL string) (*DocsHandler, error) {
+	spec, err := json.Marshal(docs.Spec())
+	if err != nil {
+		return nil, err
+	}
+	if assetsURL == "" {
+		assetsURL = DefaultSwaggerUIAssetsURL
+	}
+	page := fmt.Sprintf(swaggerUIPage, html.EscapeString(strings.TrimSuffix(assetsURL, "/")))
+	return &DocsHandler{spec: spec, page: []byte(page)}, nil
+}
+
+// RegisterRoutes registers the /swagger/* routes
+func (h *DocsHandler) RegisterRoutes(router gin.IRouter) {
+	swagger := router.Group("/swagger")
+	{
+		swagger.GET("/doc.json", h.GetSpec)
+		swagger.GET("/index.html", h.GetUI)
+		swagger.GET("", h.redirectToUI)
+		swagger.GET("/", h.redirectToUI)
+	}
+}
+
+// GetSpec handles GET /swagger/doc.json
+func (h *DocsHandler) GetSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// GetUI handles GET /swagger/index.html
+func (h *DocsHandler) GetUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", h.page)
+}
+
+func (h *DocsHandler) redirectToUI(c *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDocsRouter(t *testing.T, assetsURL string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler, err := NewDocsHandler(assetsURL)
+	require.NoError(t, err)
+	handler.RegisterRoutes(router)
+	return router
+}
+
+func TestDocsHandler_GetSpec(t *testing.T) {
+	router := newDocsRouter(t, "")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/swagger/doc.json", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec["openapi"])
+
+	paths, ok := spec["paths"].(map[string]interface{})
+	require.True(t, ok, "spec should contain paths")
+	for _, path := range []string{
+		"/api/v1/indicators/mvrv",
+		"/api/v1/indicators/type/{type}",
+		"/api/v1/charts/{indicator}",
+		"/api/v1/market/prices",
+		"/api/v1/market/price/{symbol}",
+		"/api/v1/portfolios",
+		"/api/v1/portfolios/{id}/holdings/{holdingId}",
+	} {
+		assert.Contains(t, paths, path)
+	}
+
+	holding := paths["/api/v1/portfolios/{id}/holdings/{holdingId}"].(map[string]interface{})
+	assert.Contains(t, holding, "put")
+	assert.Contains(t, holding, "delete")
+
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	for _, name := range []string{"MVRVResponse", "CreatePortfolioRequest", "PortfolioResponse", "CryptoPrice", "ErrorResponse"} {
+		assert.Contains(t, schemas, name)
+	}
+
+	create := schemas["CreatePortfolioRequest"].(map[string]interface{})
+	assert.Contains(t, create["required"], "name")
+}
+
+func TestDocsHandler_GetUI(t *testing.T) {
+	router := newDocsRouter(t, "")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/swagger/index.html", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/swagger/doc.json")
+	assert.Contains(t, w.Body.String(), DefaultSwaggerUIAssetsURL+"/swagger-ui-bundle.js")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/swagger/", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/swagger/index.html", w.Header().Get("Location"))
+}
+
+func TestDocsHandler_SelfHostedAssets(t *testing.T) {
+	router := newDocsRouter(t, "/static/swagger-ui/")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/swagger/index.html", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `src="/static/swagger-ui/swagger-ui-bundle.js"`)
+	assert.Contains(t, w.Body.String(), `href="/static/swagger-ui/swagger-ui.css"`)
+	assert.NotContains(t, w.Body.String(), "unpkg.com")
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// indicatorETag derives a strong ETag from the timestamp and value of each given indicator
+func indicatorETag(indicators ...entities.Indicator) string {
+	hash := sha256.New()
+	for _, indicator := range indicators {
+		hash.Write([]byte(indicator.Name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(indicator.Timestamp.UTC().Format(time.RFC3339Nano)))
+		hash.Write([]byte{0})
+		hash.Write([]byte(strconv.FormatFloat(indicator.Value, 'g', -1, 64)))
+		hash.Write([]byte{0})
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// changesETag derives the ETag of an indicator payload from the indicator and the change_*
+// fields addChanges set on it, which move as the comparison readings age even when the
+// value itself does not
+func changesETag(indicator entities.Indicator, data gin.H) string {
+	tagged := []entities.Indicator{indicator}
+	for _, key := range []string{"change_24h", "change_7d", "change_30d"} {
+		if change, ok := data[key].(float64); ok {
+			tagged = append(tagged, entities.Indicator{Name: key, Value: change})
+		}
+	}
+	return indicatorETag(tagged...)
+}
+
+// notModified sets the ETag header and, when If-None-Match already names it,
+// answers 304 Not Modified. Callers should skip writing a body when it returns true.
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+
+	if !etagMatches(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// etagMatches applies the weak comparison If-None-Match requires (RFC 9110 13.1.2)
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
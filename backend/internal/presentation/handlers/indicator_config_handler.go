@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IndicatorConfigHandler lets analysts read and tune indicator descriptions and bands
+type IndicatorConfigHandler struct {
+	service services.IndicatorConfigService
+	logger  logger.Logger
+}
+
+// NewIndicatorConfigHandler creates a new indicator config handler
+func NewIndicatorConfigHandler(service services.IndicatorConfigService, logger logger.Logger) *IndicatorConfigHandler {
+	return &IndicatorConfigHandler{
+		service: service,
+		logger:  logger.With("handler", "indicator_configs"),
+	}
+}
+
+// RegisterRoutes registers the indicator config routes behind the given middleware
+func (h *IndicatorConfigHandler) RegisterRoutes(router *gin.RouterGroup, middleware ...gin.HandlerFunc) {
+	admin := router.Group("/admin", middleware...)
+	{
+		admin.GET("/indicator-configs", h.ListConfigs)
+		admin.GET("/indicator-configs/:name", h.GetConfig)
+		admin.PUT("/indicator-configs/:name", h.UpdateConfig)
+	}
+}
+
+// ListConfigs returns the config in effect for every configurable indicator
+func (h *IndicatorConfigHandler) ListConfigs(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	configs, err := h.service.List(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, configs, gin.H{"count": len(configs)})
+}
+
+// GetConfig returns the config in effect for one indicator
+func (h *IndicatorConfigHandler) GetConfig(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	name := c.Param("name")
+	config, ok := h.service.IndicatorConfig(name)
+	if !ok {
+		respondError(c, h.logger, errors.NotFound("indicator config "+name))
+		return
+	}
+
+	RespondOK(c, config, nil)
+}
+
+// UpdateConfig replaces an indicator's description and bands
+func (h *IndicatorConfigHandler) UpdateConfig(c *gin.Context) {
+	var req dto.IndicatorConfigRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if !h.available(c) {
+		return
+	}
+
+	config, err := h.service.Update(c.Request.Context(), c.Param("name"), entities.IndicatorConfig{
+		Description: req.Description,
+		Bands:       req.Bands,
+	})
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, config, nil)
+}
+
+// available responds 503 when indicator config storage is not configured
+func (h *IndicatorConfigHandler) available(c *gin.Context) bool {
+	if h.service != nil {
+		return true
+	}
+	respondUnavailable(c, "Indicator config storage")
+	return false
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/application/services"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorConfigHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.IndicatorConfigsTableDDL)
+
+	repo := database.NewIndicatorConfigRepository(database.NewDBProvider(testDB.DB, nil), testDB.Logger)
+	router := gin.New()
+	NewIndicatorConfigHandler(services.NewIndicatorConfigService(repo, testDB.Logger), testDB.Logger).
+		RegisterRoutes(router.Group("/api/v1"))
+
+	request := func(method, path, body string) (int, entities.IndicatorConfig) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data entities.IndicatorConfig `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+		return w.Code, response.Data
+	}
+
+	// Defaults apply until a config is stored
+	code, config := request(http.MethodGet, "/api/v1/admin/indicator-configs/mvrv", "")
+	require.Equal(t, http.StatusOK, code)
+	defaults, _ := entities.DefaultIndicatorConfig("mvrv")
+	assert.Equal(t, defaults.Thresholds(), config.Thresholds())
+
+	body := `{"description":"Tighter bands","bands":[
+		{"label":"hot","min":4,"risk_level":"extreme_high"},
+		{"label":"cold","risk_level":"low"}]}`
+	code, config = request(http.MethodPut, "/api/v1/admin/indicator-configs/mvrv", body)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Tighter bands", config.Description)
+
+	code, config = request(http.MethodGet, "/api/v1/admin/indicator-configs/mvrv", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]float64{"hot": 4}, config.Thresholds())
+
+	// A fresh service picks the stored config up at startup
+	reloaded := services.NewIndicatorConfigService(repo, testDB.Logger)
+	require.NoError(t, reloaded.Load(context.Background()))
+	stored, _ := reloaded.IndicatorConfig("mvrv")
+	assert.Equal(t, "hot", stored.Classify(4.5).Label)
+	assert.Equal(t, "Tighter bands", stored.Description)
+
+	t.Run("Rejects invalid bands", func(t *testing.T) {
+		for _, body := range []string{
+			`{"bands":[]}`,
+			`{"bands":[{"label":"a","min":1,"risk_level":"low"}]}`,
+			`{"bands":[{"label":"a","risk_level":"low"},{"label":"a","min":2,"risk_level":"high"}]}`,
+			`{"bands":[{"label":"a","risk_level":"severe"}]}`,
+		} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/indicator-configs/mvrv", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("Unknown indicators are not found", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPut} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(method, "/api/v1/admin/indicator-configs/nope", bytes.NewBufferString(`{"bands":[{"label":"a","risk_level":"low"}]}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusNotFound, w.Code, method)
+		}
+	})
+}
@@ -2,41 +2,113 @@ package handlers
 
 import (
 	"context"
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/application/services"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
 	domainservices "crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
 	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/pkg/errors"
 	"crypto-indicator-dashboard/pkg/logger"
+	"crypto-indicator-dashboard/pkg/stats"
+	"fmt"
 	"math"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Stored indicator names used to look up change history
+const (
+	mvrvIndicatorName      = "mvrv"
+	dominanceIndicatorName = "dominance"
+	fearGreedIndicatorName = "fear_greed"
+)
+
 // IndicatorHandler handles HTTP requests for market indicators
 type IndicatorHandler struct {
-	mvrvService    domainservices.IndicatorService
-	cache          domainservices.CacheService
-	logger         logger.Logger
-	dependencies   *config.Dependencies
+	mvrvService            domainservices.IndicatorService
+	coinbasePremiumService domainservices.IndicatorService
+	altSeasonService       domainservices.IndicatorService
+	realizedPriceService   domainservices.IndicatorService
+	rhodlService           domainservices.IndicatorService
+	etfFlowService         domainservices.IndicatorService
+	exchangeFlowService    domainservices.IndicatorService
+	volumeAnomalyService   domainservices.IndicatorService
+	marketTrendService     domainservices.IndicatorService
+	correlationService     domainservices.CorrelationService
+	changeService          domainservices.IndicatorChangeService
+	fearGreedService       domainservices.FearGreedService
+	dominanceService       domainservices.DominanceService
+	indicatorServices      map[string]domainservices.IndicatorService
+	indicatorRepo          repositories.IndicatorRepository
+	annotationRepo         repositories.AnnotationRepository
+	cache                  domainservices.CacheService
+	logger                 logger.Logger
+	dependencies           *config.Dependencies
 }
 
 // NewIndicatorHandler creates a new indicator handler
 func NewIndicatorHandler(deps *config.Dependencies) *IndicatorHandler {
 	return &IndicatorHandler{
-		cache:        deps.Cache,
-		logger:       deps.Logger,
-		dependencies: deps,
+		mvrvService:            deps.MVRVService,
+		coinbasePremiumService: deps.CoinbasePremiumService,
+		altSeasonService:       deps.AltSeasonService,
+		realizedPriceService:   deps.RealizedPriceService,
+		rhodlService:           deps.RHODLService,
+		etfFlowService:         deps.ETFFlowService,
+		exchangeFlowService:    deps.ExchangeFlowService,
+		volumeAnomalyService:   deps.VolumeAnomalyService,
+		marketTrendService:     deps.MarketTrendService,
+		correlationService:     deps.CorrelationService,
+		changeService:          deps.ChangeService,
+		fearGreedService:       deps.FearGreedService,
+		dominanceService:       deps.DominanceService,
+		indicatorServices:      deps.IndicatorServices(),
+		indicatorRepo:          deps.IndicatorRepo,
+		annotationRepo:         deps.AnnotationRepo,
+		cache:                  deps.Cache,
+		logger:                 deps.Logger,
+		dependencies:           deps,
 	}
 }
 
-// RegisterRoutes registers all indicator routes
-func (h *IndicatorHandler) RegisterRoutes(router *gin.RouterGroup) {
+// RegisterRoutes registers all indicator routes. Reads are public; writeMiddleware, such as
+// API key authentication, guards the routes that store indicators or hit upstream providers.
+func (h *IndicatorHandler) RegisterRoutes(router *gin.RouterGroup, writeMiddleware ...gin.HandlerFunc) {
+	guarded := func(handler gin.HandlerFunc) []gin.HandlerFunc {
+		return append(append([]gin.HandlerFunc{}, writeMiddleware...), handler)
+	}
+
 	indicators := router.Group("/indicators")
 	{
 		indicators.GET("/mvrv", h.GetMVRVIndicator)
 		indicators.GET("/dominance", h.GetDominanceIndicator)
 		indicators.GET("/fear-greed", h.GetFearGreedIndicator)
+		indicators.GET("/fear-greed/components", h.GetFearGreedComponents)
 		indicators.GET("/bubble-risk", h.GetBubbleRiskIndicator)
+		indicators.GET("/coinbase-premium", h.GetCoinbasePremiumIndicator)
+		indicators.GET("/alt-season", h.GetAltSeasonIndicator)
+		indicators.GET("/realized-price", h.GetRealizedPriceIndicator)
+		indicators.GET("/rhodl", h.GetRHODLIndicator)
+		indicators.GET("/etf-flow", h.GetETFFlowIndicator)
+		indicators.GET("/exchange-flow", h.GetExchangeFlowIndicator)
+		indicators.GET("/volume-anomaly", h.GetVolumeAnomalyIndicator)
+		indicators.GET("/market-trend", h.GetMarketTrendIndicator)
+		indicators.GET("/market-trend/history", h.GetMarketTrendHistory)
+		indicators.GET("/correlation", h.GetIndicatorCorrelation)
+		indicators.GET("/bulk", h.GetLatestIndicators)
+		indicators.POST("/bulk", guarded(h.BulkIngestIndicators)...)
+		indicators.GET("/type/:type", h.GetIndicatorsByType)
+		indicators.GET("/:name/latest/provenance", h.GetLatestProvenance)
+		indicators.GET("/:name/diff", h.GetIndicatorDiff)
+		// Forcing a fresh calculation hits upstream providers
+		indicators.POST("/:name/recalculate", guarded(h.RecalculateIndicator)...)
 	}
 
 	// Chart data endpoints
@@ -44,113 +116,1070 @@ func (h *IndicatorHandler) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		charts.GET("/:indicator", h.GetChartData)
 	}
-}
-
-// GetMVRVIndicator handles MVRV Z-Score indicator requests
-func (h *IndicatorHandler) GetMVRVIndicator(c *gin.Context) {
-	h.logger.Info("Processing MVRV indicator request")
+}
+
+// GetMVRVIndicator handles MVRV Z-Score indicator requests
+func (h *IndicatorHandler) GetMVRVIndicator(c *gin.Context) {
+	h.logger.Info("Processing MVRV indicator request")
+
+	if h.mvrvService == nil {
+		// Return mock data when the MVRV service is not configured
+		current := 2.43
+		data := gin.H{
+			"value":           fmt.Sprintf("%.2f", current),
+			"change":          "+0.12", 
+			"risk_level":      "medium",
+			"status":          "Service temporarily unavailable - under maintenance",
+			"last_updated":    time.Now(),
+		}
+		h.addChanges(c.Request.Context(), data, mvrvIndicatorName, current)
+
+		RespondOK(c, data, nil)
+		return
+	}
+
+	indicator, err := h.mvrvService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	data := gin.H{
+		"value":        fmt.Sprintf("%.2f", indicator.Value),
+		"z_score":      indicator.Value,
+		"ratio":        indicator.Metadata["mvrv_ratio"],
+		"thresholds":   indicator.Metadata["zscore_thresholds"],
+		"risk_level":   h.convertRiskLevel(indicator.RiskLevel),
+		"status":       indicator.Status,
+		"metadata":     indicator.Metadata,
+		"last_updated": indicator.Timestamp,
+	}
+	h.addChanges(c.Request.Context(), data, mvrvIndicatorName, indicator.Value)
+
+	if notModified(c, changesETag(*indicator, data)) {
+		return
+	}
+
+	RespondOK(c, data, nil)
+}
+
+// GetDominanceIndicator handles Bitcoin dominance indicator requests
+func (h *IndicatorHandler) GetDominanceIndicator(c *gin.Context) {
+	h.logger.Info("Processing dominance indicator request")
+
+	if h.dominanceService == nil {
+		h.respondMockDominance(c)
+		return
+	}
+
+	result, err := h.dominanceService.GetDominanceAnalysis(c.Request.Context())
+	if errors.IsType(err, errors.ErrorTypeNotFound) {
+		// No dominance history stored yet
+		h.respondMockDominance(c)
+		return
+	}
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	data := gin.H{
+		"value":        fmt.Sprintf("%.1f%%", result.CurrentDominance),
+		"change":       fmt.Sprintf("%+.1f%%", result.Change24h),
+		"risk_level":   result.RiskLevel,
+		"status":       result.Status,
+		"last_updated": result.LastUpdated,
+		"details": gin.H{
+			"trend":           result.Trend,
+			"trend_strength":  result.TrendStrength,
+			"change_7d":       result.Change7d,
+			"change_30d":      result.Change30d,
+			"alt_season":      result.AltSeasonSignal,
+			"critical_levels": result.CriticalLevels,
+		},
+	}
+	h.addChanges(c.Request.Context(), data, dominanceIndicatorName, result.CurrentDominance)
+
+	etag := changesETag(entities.Indicator{
+		Name:      dominanceIndicatorName,
+		Value:     result.CurrentDominance,
+		Timestamp: result.LastUpdated,
+	}, data)
+	if notModified(c, etag) {
+		return
+	}
+
+	RespondOK(c, data, nil)
+}
+
+// respondMockDominance serves mock dominance data - use /api/v1/market/dominance for real data
+func (h *IndicatorHandler) respondMockDominance(c *gin.Context) {
+	current := 56.8
+	data := gin.H{
+		"value":           fmt.Sprintf("%.1f%%", current),
+		"change":          "-1.2%",
+		"risk_level":      "low",
+		"status":          "Use /api/v1/market/dominance for real data",
+		"last_updated":    time.Now(),
+	}
+	h.addChanges(c.Request.Context(), data, dominanceIndicatorName, current)
+
+	RespondOK(c, data, nil)
+}
+
+// GetFearGreedIndicator handles Fear & Greed index requests
+func (h *IndicatorHandler) GetFearGreedIndicator(c *gin.Context) {
+	h.logger.Info("Processing Fear & Greed indicator request")
+
+	if h.fearGreedService == nil {
+		// Return mock data when no provider is configured
+		current := 72.0
+		data := gin.H{
+			"value":           fmt.Sprintf("%.0f", current),
+			"change":          "+5",
+			"risk_level":      "high",
+			"status":          "Greed territory - Consider taking profits",
+			"last_updated":    time.Now(),
+		}
+		h.addChanges(c.Request.Context(), data, fearGreedIndicatorName, current)
+
+		RespondOK(c, data, nil)
+		return
+	}
+
+	result, err := h.fearGreedService.GetFearGreedAnalysis(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	data := gin.H{
+		"value":                  fmt.Sprintf("%d", result.CurrentValue),
+		"change":                 fmt.Sprintf("%+d", result.Change24h),
+		"classification":         result.Classification,
+		"risk_level":             result.RiskLevel,
+		"status":                 result.Status,
+		"trading_recommendation": result.TradingRecommendation,
+		"data_source":            result.DataSource,
+		"last_updated":           result.LastUpdated,
+	}
+	// Only providers with a component breakdown report components
+	if len(result.Components) > 0 {
+		data["components"] = result.Components
+	}
+	h.addChanges(c.Request.Context(), data, fearGreedIndicatorName, float64(result.CurrentValue))
+
+	etag := changesETag(entities.Indicator{
+		Name:      fearGreedIndicatorName,
+		Value:     float64(result.CurrentValue),
+		Timestamp: result.LastUpdated,
+	}, data)
+	if notModified(c, etag) {
+		return
+	}
+
+	RespondOK(c, data, nil)
+}
+
+// GetFearGreedComponents returns the history of each Fear & Greed component over the
+// requested period, rebuilt from stored readings. It is empty while the provider only
+// publishes the headline index.
+func (h *IndicatorHandler) GetFearGreedComponents(c *gin.Context) {
+	h.logger.Info("Processing Fear & Greed components request")
+
+	if h.fearGreedService == nil {
+		respondUnavailable(c, "Fear & Greed service")
+		return
+	}
+
+	window, period, err := parseHistoryRange(c, time.Now())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	history, err := h.fearGreedService.GetComponentHistory(c.Request.Context(), window)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"period":     period,
+		"from":       window.From,
+		"to":         window.To,
+		"available":  len(history.Components) > 0,
+		"timestamps": history.Timestamps,
+		"values":     history.Values,
+		"components": history.Components,
+	}, nil)
+}
+
+// GetBubbleRiskIndicator handles bubble risk assessment requests
+func (h *IndicatorHandler) GetBubbleRiskIndicator(c *gin.Context) {
+	h.logger.Info("Processing bubble risk indicator request")
+
+	// Return mock data
+	RespondOK(c, gin.H{
+		"value":           "Medium",
+		"change":          "Stable",
+		"risk_level":      "medium",
+		"status":          "Monitor closely for rapid changes",
+		"last_updated":    time.Now(),
+	}, nil)
+}
+
+// GetCoinbasePremiumIndicator handles Coinbase Premium Index requests
+func (h *IndicatorHandler) GetCoinbasePremiumIndicator(c *gin.Context) {
+	h.logger.Info("Processing Coinbase premium indicator request")
+
+	if h.coinbasePremiumService == nil {
+		respondUnavailable(c, "Coinbase premium service")
+		return
+	}
+
+	indicator, err := h.coinbasePremiumService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"value":          fmt.Sprintf("%.3f%%", indicator.Value),
+		"premium":        indicator.Value,
+		"classification": indicator.Metadata["classification"],
+		"risk_level":     h.convertRiskLevel(indicator.RiskLevel),
+		"status":         indicator.Status,
+		"metadata":       indicator.Metadata,
+		"last_updated":   indicator.Timestamp,
+	}, nil)
+}
+
+// GetAltSeasonIndicator handles Altcoin Season Index requests
+func (h *IndicatorHandler) GetAltSeasonIndicator(c *gin.Context) {
+	h.logger.Info("Processing altcoin season indicator request")
+
+	if h.altSeasonService == nil {
+		respondUnavailable(c, "Altcoin season service")
+		return
+	}
+
+	indicator, err := h.altSeasonService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"value":          fmt.Sprintf("%.0f", indicator.Value),
+		"index":          indicator.Value,
+		"classification": indicator.Metadata["classification"],
+		"alt_season":     indicator.Value > services.AltSeasonThreshold,
+		"risk_level":     h.convertRiskLevel(indicator.RiskLevel),
+		"status":         indicator.Status,
+		"metadata":       indicator.Metadata,
+		"last_updated":   indicator.Timestamp,
+	}, nil)
+}
+
+// GetRealizedPriceIndicator handles realized price / realized cap requests
+func (h *IndicatorHandler) GetRealizedPriceIndicator(c *gin.Context) {
+	h.logger.Info("Processing realized price indicator request")
+
+	if h.realizedPriceService == nil {
+		respondUnavailable(c, "Realized price service")
+		return
+	}
+
+	indicator, err := h.realizedPriceService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"value":          fmt.Sprintf("$%.0f", indicator.Value),
+		"realized_price": indicator.Value,
+		"realized_cap":   indicator.Metadata["realized_cap"],
+		"price":          indicator.Metadata["price"],
+		"classification": indicator.Metadata["classification"],
+		"change":         indicator.Change,
+		"risk_level":     h.convertRiskLevel(indicator.RiskLevel),
+		"status":         indicator.Status,
+		"metadata":       indicator.Metadata,
+		"last_updated":   indicator.Timestamp,
+	}, nil)
+}
+
+// GetRHODLIndicator handles Realized HODL ratio requests
+func (h *IndicatorHandler) GetRHODLIndicator(c *gin.Context) {
+	h.logger.Info("Processing RHODL indicator request")
+
+	if h.rhodlService == nil {
+		respondUnavailable(c, "RHODL service")
+		return
+	}
+
+	indicator, err := h.rhodlService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"value":         fmt.Sprintf("%.2f", indicator.Value),
+		"ratio":         indicator.Value,
+		"band":          indicator.Metadata["band"],
+		"approximation": indicator.Metadata["approximation"],
+		"risk_level":    h.convertRiskLevel(indicator.RiskLevel),
+		"status":        indicator.Status,
+		"metadata":      indicator.Metadata,
+		"last_updated":  indicator.Timestamp,
+	}, nil)
+}
+
+// GetETFFlowIndicator handles spot Bitcoin ETF flow requests
+func (h *IndicatorHandler) GetETFFlowIndicator(c *gin.Context) {
+	h.logger.Info("Processing ETF flow indicator request")
+
+	if h.etfFlowService == nil {
+		respondUnavailable(c, "ETF flow service")
+		return
+	}
+
+	indicator, err := h.etfFlowService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"value":        indicator.Change,
+		"net_flow":     indicator.Value,
+		"band":         indicator.Metadata["band"],
+		"configured":   indicator.Metadata["configured"],
+		"risk_level":   h.convertRiskLevel(indicator.RiskLevel),
+		"status":       indicator.Status,
+		"metadata":     indicator.Metadata,
+		"last_updated": indicator.Timestamp,
+	}, nil)
+}
+
+// GetExchangeFlowIndicator handles BTC exchange net flow requests
+func (h *IndicatorHandler) GetExchangeFlowIndicator(c *gin.Context) {
+	h.logger.Info("Processing exchange flow indicator request")
+
+	if h.exchangeFlowService == nil {
+		respondUnavailable(c, "Exchange flow service")
+		return
+	}
+
+	indicator, err := h.exchangeFlowService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"value":        indicator.Change,
+		"net_flow":     indicator.Value,
+		"band":         indicator.Metadata["band"],
+		"configured":   indicator.Metadata["configured"],
+		"risk_level":   h.convertRiskLevel(indicator.RiskLevel),
+		"status":       indicator.Status,
+		"metadata":     indicator.Metadata,
+		"last_updated": indicator.Timestamp,
+	}, nil)
+}
+
+// GetVolumeAnomalyIndicator handles BTC volume anomaly requests
+func (h *IndicatorHandler) GetVolumeAnomalyIndicator(c *gin.Context) {
+	h.logger.Info("Processing volume anomaly indicator request")
+
+	if h.volumeAnomalyService == nil {
+		respondUnavailable(c, "Volume anomaly service")
+		return
+	}
+
+	indicator, err := h.volumeAnomalyService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"value":        fmt.Sprintf("%.2f", indicator.Value),
+		"z_score":      indicator.Value,
+		"severity":     indicator.Metadata["severity"],
+		"anomaly":      indicator.Metadata["anomaly"],
+		"confidence":   indicator.Confidence,
+		"risk_level":   h.convertRiskLevel(indicator.RiskLevel),
+		"status":       indicator.Status,
+		"metadata":     indicator.Metadata,
+		"last_updated": indicator.Timestamp,
+	}, nil)
+}
+
+// GetMarketTrendIndicator handles market trend classification requests
+func (h *IndicatorHandler) GetMarketTrendIndicator(c *gin.Context) {
+	h.logger.Info("Processing market trend indicator request")
+
+	if !h.marketTrendAvailable(c) {
+		return
+	}
+
+	indicator, err := h.marketTrendService.GetLatest(c.Request.Context())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if notModified(c, indicatorETag(*indicator)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"trend":          indicator.StringValue,
+		"avg_change_24h": indicator.Value,
+		"confidence":     indicator.Confidence,
+		"risk_level":     h.convertRiskLevel(indicator.RiskLevel),
+		"status":         indicator.Status,
+		"metadata":       indicator.Metadata,
+		"last_updated":   indicator.Timestamp,
+	}, nil)
+}
+
+// GetMarketTrendHistory returns the stored daily market trend classifications for
+// ?period= or ?from=&to=, oldest first. ?format=csv streams them as a CSV download.
+func (h *IndicatorHandler) GetMarketTrendHistory(c *gin.Context) {
+	if !h.marketTrendAvailable(c) {
+		return
+	}
+
+	window, period, err := parseHistoryRange(c, time.Now())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	format, err := parseExportFormat(c)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	history, err := h.marketTrendService.GetHistoricalData(c.Request.Context(), window)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if format == exportFormatCSV {
+		respondIndicatorCSV(c, "market_trend", window, history)
+		return
+	}
+
+	points := make([]gin.H, 0, len(history))
+	for _, point := range history {
+		points = append(points, gin.H{
+			"timestamp":      point.Timestamp,
+			"trend":          point.StringValue,
+			"avg_change_24h": point.Value,
+		})
+	}
+
+	RespondOK(c, gin.H{
+		"history": points,
+		"period":  period,
+		"from":    window.From,
+		"to":      window.To,
+	}, nil)
+}
+
+// marketTrendAvailable responds 503 when no market trend service is configured
+func (h *IndicatorHandler) marketTrendAvailable(c *gin.Context) bool {
+	if h.marketTrendService != nil {
+		return true
+	}
+	respondUnavailable(c, "Market trend service")
+	return false
+}
+
+// GetIndicatorCorrelation handles correlation analysis requests between two indicators
+func (h *IndicatorHandler) GetIndicatorCorrelation(c *gin.Context) {
+	indicatorA := c.Query("a")
+	indicatorB := c.Query("b")
+	period := c.DefaultQuery("period", "90d")
+	h.logger.Info("Processing indicator correlation request", "a", indicatorA, "b", indicatorB, "period", period)
+
+	if indicatorA == "" || indicatorB == "" {
+		respondError(c, h.logger, errors.Validation("Missing indicator", "query parameters 'a' and 'b' are required"))
+		return
+	}
+
+	if h.correlationService == nil {
+		respondUnavailable(c, "Correlation service")
+		return
+	}
+
+	result, err := h.correlationService.CalculateCorrelation(c.Request.Context(), indicatorA, indicatorB, period)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, result, nil)
+}
+
+// BulkIngestIndicators handles bulk ingestion of precomputed indicator values
+func (h *IndicatorHandler) BulkIngestIndicators(c *gin.Context) {
+	var payloads []dto.IndicatorPayload
+	if err := bindJSON(c, &payloads); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	h.logger.Info("Processing bulk indicator ingestion", "count", len(payloads))
+
+	if len(payloads) == 0 {
+		respondError(c, h.logger, errors.Validation("No indicators provided"))
+		return
+	}
+	if len(payloads) > dto.MaxBulkIndicators {
+		respondError(c, h.logger, errors.Validation("Too many indicators",
+			fmt.Sprintf("a batch may contain at most %d indicators", dto.MaxBulkIndicators)))
+		return
+	}
+
+	// Reject the whole batch if any entry is invalid
+	indicators := make([]entities.Indicator, 0, len(payloads))
+	for i := range payloads {
+		if err := payloads[i].Validate(); err != nil {
+			respondError(c, h.logger, errors.Validation(fmt.Sprintf("Invalid indicator at index %d", i),
+				fmt.Sprintf("indicators[%d]: %s", i, err.Error())))
+			return
+		}
+		indicators = append(indicators, payloads[i].ToEntity())
+	}
+
+	if h.indicatorRepo == nil {
+		respondUnavailable(c, "Indicator storage")
+		return
+	}
+
+	if err := h.indicatorRepo.BulkCreate(c.Request.Context(), indicators); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondCreated(c, gin.H{
+		"created": len(indicators),
+	}, nil)
+}
+
+// maxBulkTypes is the most indicator types GetLatestIndicators accepts in one request
+const maxBulkTypes = 20
+
+// GetLatestIndicators returns the latest stored value of every indicator of the types in
+// ?types=a,b in a single query, for pages that show many indicators at once
+func (h *IndicatorHandler) GetLatestIndicators(c *gin.Context) {
+	types, err := parseIndicatorTypes(c.Query("types"))
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	h.logger.Info("Processing latest indicators request", "types", types)
+
+	if h.indicatorRepo == nil {
+		respondUnavailable(c, "Indicator storage")
+		return
+	}
+
+	latest, err := h.indicatorRepo.GetLatestForTypes(c.Request.Context(), types)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	if notModified(c, indicatorETag(latest...)) {
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"types":      types,
+		"count":      len(latest),
+		"indicators": latest,
+	}, nil)
+}
+
+// parseIndicatorTypes parses a required comma-separated list of indicator types, dropping
+// blanks and duplicates
+func parseIndicatorTypes(raw string) ([]string, error) {
+	types := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		indicatorType := strings.TrimSpace(part)
+		if indicatorType == "" || seen[indicatorType] {
+			continue
+		}
+		seen[indicatorType] = true
+		types = append(types, indicatorType)
+	}
+
+	if len(types) == 0 {
+		return nil, errors.Validation("Missing 'types' parameter", "at least one indicator type is required")
+	}
+	if len(types) > maxBulkTypes {
+		return nil, errors.Validation("Invalid 'types' parameter", fmt.Sprintf("at most %d types are supported", maxBulkTypes))
+	}
+	return types, nil
+}
+
+// Defaults and bounds for GetIndicatorsByType
+const (
+	defaultTypeRangeWindow = 7 * 24 * time.Hour
+	// ?limit= caps the indicators returned before paging
+	defaultTypeRangeLimit = 500
+	maxTypeRangeLimit     = 5000
+	defaultTypePageLimit  = 100
+	maxTypePageLimit      = 500
+)
+
+// GetIndicatorsByType returns the latest value of each indicator of a type within ?from=&to=,
+// paginated with ?page_size=&offset=; ?limit= caps the indicators returned. Times are RFC3339 or YYYY-MM-DD; the range defaults to the
+// last 7 days. With ?min_confidence= (0-1) each indicator's latest value at or above that
+// confidence is returned instead, so low-confidence fallback values are skipped; indicators
+// whose latest confident value is outside the range are left out.
+func (h *IndicatorHandler) GetIndicatorsByType(c *gin.Context) {
+	indicatorType := c.Param("type")
+	h.logger.Info("Processing indicators by type request", "type", indicatorType,
+		"from", c.Query("from"), "to", c.Query("to"))
+
+	to := time.Now()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseTimeParam(raw)
+		if err != nil {
+			respondError(c, h.logger, errors.Validation("Invalid 'to' parameter", err.Error()))
+			return
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultTypeRangeWindow)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseTimeParam(raw)
+		if err != nil {
+			respondError(c, h.logger, errors.Validation("Invalid 'from' parameter", err.Error()))
+			return
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		respondError(c, h.logger, errors.Validation("Invalid time range", "'from' must not be after 'to'"))
+		return
+	}
+
+	limit := defaultTypeRangeLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxTypeRangeLimit {
+			respondError(c, h.logger, errors.Validation("Invalid 'limit' parameter",
+				fmt.Sprintf("limit must be between 1 and %d", maxTypeRangeLimit)))
+			return
+		}
+		limit = parsed
+	}
+
+	pageSize, offset, err := parsePaginationParam(c, "page_size", defaultTypePageLimit, maxTypePageLimit)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	minConfidence, filterConfidence, err := parseMinConfidence(c.Query("min_confidence"))
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	if h.indicatorRepo == nil {
+		respondUnavailable(c, "Indicator storage")
+		return
+	}
+
+	var latest []entities.Indicator
+	if filterConfidence {
+		confident, err := h.indicatorRepo.GetLatestHighConfidence(c.Request.Context(), indicatorType, minConfidence)
+		if err != nil {
+			respondError(c, h.logger, err)
+			return
+		}
+		latest = make([]entities.Indicator, 0, len(confident))
+		for _, indicator := range confident {
+			if !indicator.Timestamp.Before(from) && !indicator.Timestamp.After(to) {
+				latest = append(latest, indicator)
+			}
+		}
+		// Newest first, like the unfiltered listing
+		sort.SliceStable(latest, func(i, j int) bool { return latest[i].Timestamp.After(latest[j].Timestamp) })
+	} else {
+		latest, err = h.indicatorRepo.GetLatestByTypeInRange(c.Request.Context(), indicatorType, from, to, limit)
+		if err != nil {
+			respondError(c, h.logger, err)
+			return
+		}
+	}
+
+	if notModified(c, indicatorETag(latest...)) {
+		return
+	}
+
+	meta := gin.H{
+		"type": indicatorType,
+		"from": from,
+		"to":   to,
+	}
+	if filterConfidence {
+		meta["min_confidence"] = minConfidence
+	}
+
+	pagination := newPagination(len(latest), pageSize, offset)
+	start, end := pagination.bounds()
+	RespondPage(c, latest[start:end], pagination, meta)
+}
+
+// parseMinConfidence parses an optional ?min_confidence= between 0 and 1; ok is false when
+// it is not set
+func parseMinConfidence(raw string) (minConfidence float64, ok bool, err error) {
+	if raw == "" {
+		return 0, false, nil
+	}
+	minConfidence, err = strconv.ParseFloat(raw, 64)
+	if err != nil || minConfidence < 0 || minConfidence > 1 {
+		return 0, false, errors.Validation("Invalid 'min_confidence' parameter", "min_confidence must be a number between 0 and 1")
+	}
+	return minConfidence, true, nil
+}
+
+// GetLatestProvenance returns the sources, inputs and calculation version recorded for the
+// latest stored value of an indicator. Route names like fear-greed match stored fear_greed.
+func (h *IndicatorHandler) GetLatestProvenance(c *gin.Context) {
+	name := strings.ReplaceAll(c.Param("name"), "-", "_")
+	h.logger.Info("Processing indicator provenance request", "name", name)
+
+	if h.indicatorRepo == nil {
+		respondUnavailable(c, "Indicator storage")
+		return
+	}
+
+	indicator, err := h.indicatorRepo.GetLatest(c.Request.Context(), name)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	provenance, ok := indicator.Provenance()
+	if !ok {
+		respondError(c, h.logger, errors.NotFound("provenance"))
+		return
+	}
+
+	RespondOK(c, gin.H{
+		"name":       indicator.Name,
+		"value":      indicator.Value,
+		"timestamp":  indicator.Timestamp,
+		"provenance": provenance,
+	}, nil)
+}
+
+// RecalculateIndicator runs the named indicator service's Calculate with cached upstream data
+// bypassed, so the fresh value is persisted and returned. Names use the registry's
+// underscore form; hyphens are accepted.
+func (h *IndicatorHandler) RecalculateIndicator(c *gin.Context) {
+	name := strings.ReplaceAll(c.Param("name"), "-", "_")
+	log := h.logger.WithContext(c.Request.Context())
+	log.Info("Processing indicator recalculation request", "name", name)
+
+	service, ok := h.indicatorServices[name]
+	if !ok {
+		respondError(c, h.logger, errors.NotFound("indicator service "+name))
+		return
+	}
+
+	indicator, err := service.Calculate(cache.WithBypass(c.Request.Context()), nil)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	log.Info("Recalculated indicator", "name", name, "value", indicator.Value)
+	RespondOK(c, indicator, nil)
+}
+
+// GetIndicatorDiff compares the stored values of an indicator nearest to two timestamps and
+// lists the fields that changed. to defaults to now.
+func (h *IndicatorHandler) GetIndicatorDiff(c *gin.Context) {
+	name := strings.ReplaceAll(c.Param("name"), "-", "_")
+	h.logger.Info("Processing indicator diff request", "name", name)
+
+	rawFrom := c.Query("from")
+	if rawFrom == "" {
+		respondError(c, h.logger, errors.Validation("Missing 'from' parameter", "from is required"))
+		return
+	}
+	from, err := parseTimeParam(rawFrom)
+	if err != nil {
+		respondError(c, h.logger, errors.Validation("Invalid 'from' parameter", err.Error()))
+		return
+	}
+
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseTimeParam(raw)
+		if err != nil {
+			respondError(c, h.logger, errors.Validation("Invalid 'to' parameter", err.Error()))
+			return
+		}
+		to = parsed
+	}
+
+	if h.indicatorRepo == nil {
+		respondUnavailable(c, "Indicator storage")
+		return
+	}
+
+	ctx := c.Request.Context()
+	before, err := h.indicatorRepo.GetNearest(ctx, name, from)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	after, err := h.indicatorRepo.GetNearest(ctx, name, to)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
 
-	// Temporarily return mock data due to cache interface conflicts
-	// TODO: Fix cache interface compatibility between old and new services
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": gin.H{
-			"value":           "2.43",
-			"change":          "+0.12", 
-			"risk_level":      "medium",
-			"status":          "Service temporarily unavailable - under maintenance",
-			"last_updated":    time.Now(),
-		},
-	})
+	changes := entities.DiffIndicators(before, after)
+	RespondOK(c, gin.H{
+		"name":        name,
+		"from":        gin.H{"requested": from, "snapshot": before},
+		"to":          gin.H{"requested": to, "snapshot": after},
+		"same_record": before.ID == after.ID,
+		"changed":     len(changes),
+		"diff":        changes,
+	}, nil)
 }
 
-// GetDominanceIndicator handles Bitcoin dominance indicator requests
-func (h *IndicatorHandler) GetDominanceIndicator(c *gin.Context) {
-	h.logger.Info("Processing dominance indicator request")
-
-	// Return mock data - use /api/v1/market/dominance for real data
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": gin.H{
-			"value":           "56.8%",
-			"change":          "-1.2%",
-			"risk_level":      "low",
-			"status":          "Use /api/v1/market/dominance for real data",
-			"last_updated":    time.Now(),
-		},
-	})
+// parseTimeParam parses an RFC3339 timestamp or a YYYY-MM-DD date
+func parseTimeParam(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339 timestamp or YYYY-MM-DD date, got %q", raw)
+	}
+	return t, nil
 }
 
-// GetFearGreedIndicator handles Fear & Greed index requests
-func (h *IndicatorHandler) GetFearGreedIndicator(c *gin.Context) {
-	h.logger.Info("Processing Fear & Greed indicator request")
+// Moving average overlays on the MVRV chart, in data points (days)
+var defaultMAWindows = []int{7, 30}
 
-	// Return mock data
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": gin.H{
-			"value":           "72",
-			"change":          "+5",
-			"risk_level":      "high",
-			"status":          "Greed territory - Consider taking profits",
-			"last_updated":    time.Now(),
-		},
-	})
-}
+const (
+	maxMAWindows = 5
+	maxMAWindow  = 365
+)
 
-// GetBubbleRiskIndicator handles bubble risk assessment requests
-func (h *IndicatorHandler) GetBubbleRiskIndicator(c *gin.Context) {
-	h.logger.Info("Processing bubble risk indicator request")
+// Smoothing span used when ?smooth= is given without ?span=
+const defaultSmoothingSpan = 14
 
-	// Return mock data
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": gin.H{
-			"value":           "Medium",
-			"change":          "Stable",
-			"risk_level":      "medium",
-			"status":          "Monitor closely for rapid changes",
-			"last_updated":    time.Now(),
-		},
-	})
-}
+// Chart point caps; longer series are downsampled to at most ?points= values
+const (
+	defaultChartPoints = 500
+	maxChartPoints     = 5000
+)
 
-// GetChartData handles chart data requests for indicators
+// GetChartData handles chart data requests for indicators.
+// An optional ?normalize=minmax|zscore adds a normalized_values series, and the MVRV
+// chart accepts ?ma=7,30 to choose its moving average overlays. Series longer than
+// ?points= (default 500) are downsampled with ?downsample=last|avg|ohlc (default last).
+// ?annotations=true adds the annotations that fall within the chart's time range, and
+// ?overlay=cycles on price-based charts adds the price series split by halving cycle.
+// ?smooth=ema|sma with ?span= (default 14) adds a smoothed copy of the primary series.
+// The realized-price and MVRV charts also take ?format=csv to download their stored history
+// as CSV; other charts reject it.
 func (h *IndicatorHandler) GetChartData(c *gin.Context) {
 	ctx := c.Request.Context()
 	indicator := c.Param("indicator")
-	h.logger.Info("Processing chart data request", "indicator", indicator)
+	normalize := c.Query("normalize")
+	h.logger.Info("Processing chart data request", "indicator", indicator, "normalize", normalize)
+
+	points, method, err := parseDownsampleParams(c)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	withAnnotations := false
+	if raw := c.Query("annotations"); raw != "" {
+		withAnnotations, err = strconv.ParseBool(raw)
+		if err != nil {
+			respondError(c, h.logger, errors.Validation("Invalid 'annotations' parameter", "annotations must be true or false"))
+			return
+		}
+	}
+
+	overlaySeries, err := parseCycleOverlay(c.Query("overlay"), indicator)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	smoothing, span, err := parseSmoothingParams(c)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	if format == exportFormatCSV && indicator != "mvrv" && indicator != "realized-price" {
+		respondError(c, h.logger, errors.Validation("Invalid 'format' parameter", "csv is only available for the mvrv and realized-price charts"))
+		return
+	}
+
+	var chartData map[string]interface{}
 
 	switch indicator {
 	case "mvrv":
-		chartData, err := h.getMVRVChartData(ctx)
+		if format == exportFormatCSV {
+			if h.mvrvService == nil {
+				respondUnavailable(c, "MVRV service")
+				return
+			}
+			h.respondHistoryCSV(c, h.mvrvService, "mvrv")
+			return
+		}
+
+		windows, err := parseMAWindows(c.Query("ma"))
 		if err != nil {
-			h.logger.Error("Failed to get MVRV chart data", "error", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to fetch MVRV chart data",
-			})
+			respondError(c, h.logger, err)
 			return
 		}
-		c.JSON(http.StatusOK, chartData)
+
+		chartData, err = h.getMVRVChartData(ctx)
+		if err != nil {
+			respondError(c, h.logger, err)
+			return
+		}
+		addMovingAverages(chartData, "zscore_data", windows)
 
 	case "dominance":
-		chartData := h.generateDominanceChartData()
-		c.JSON(http.StatusOK, chartData)
+		chartData = h.getDominanceChartData(ctx)
 
 	case "fear-greed":
-		chartData := h.generateFearGreedChartData()
-		c.JSON(http.StatusOK, chartData)
+		chartData = h.getFearGreedChartData(ctx)
 
 	case "bubble-risk":
-		chartData := h.generateBubbleRiskChartData()
-		c.JSON(http.StatusOK, chartData)
+		chartData = h.generateBubbleRiskChartData()
+
+	case "realized-price":
+		if h.realizedPriceService == nil {
+			respondUnavailable(c, "Realized price service")
+			return
+		}
+		if format == exportFormatCSV {
+			h.respondHistoryCSV(c, h.realizedPriceService, "realized_price")
+			return
+		}
+		window, period, rangeErr := parseHistoryRange(c, time.Now())
+		if rangeErr != nil {
+			respondError(c, h.logger, rangeErr)
+			return
+		}
+		calcVersion, versionErr := parseCalcVersion(c.Query("calc_version"))
+		if versionErr != nil {
+			respondError(c, h.logger, versionErr)
+			return
+		}
+		chartData, err = h.getRealizedPriceChartData(ctx, window, period, calcVersion)
+		if err != nil {
+			respondError(c, h.logger, err)
+			return
+		}
 
 	default:
-		c.JSON(http.StatusOK, gin.H{
+		data := gin.H{
 			"indicator": indicator,
-			"message":   "Chart data coming soon",
 			"mock_data": h.generateMockChartData(),
-		})
+		}
+		meta := gin.H{"message": "Chart data coming soon"}
+		if legacyResponse(c) {
+			// Legacy chart responses were bare objects with the message inline
+			data["message"] = meta["message"]
+			c.JSON(http.StatusOK, data)
+			return
+		}
+		RespondOK(c, data, meta)
+		return
+	}
+
+	if normalize != "" {
+		if err := h.addNormalizedValues(chartData, normalize); err != nil {
+			respondError(c, h.logger, err)
+			return
+		}
+	}
+
+	// Smooth before downsampling so the averages use every raw point
+	if smoothing != "" {
+		if err := addSmoothedValues(chartData, smoothing, span); err != nil {
+			respondError(c, h.logger, err)
+			return
+		}
+	}
+
+	if err := downsampleChart(chartData, points, method); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	if overlaySeries != "" {
+		addCycleOverlay(chartData, overlaySeries)
+	}
+
+	if withAnnotations {
+		if err := h.addAnnotations(ctx, chartData); err != nil {
+			respondError(c, h.logger, err)
+			return
+		}
+	}
+
+	if legacyResponse(c) {
+		c.JSON(http.StatusOK, chartData)
+	} else {
+		RespondOK(c, chartData, nil)
 	}
 
 	h.logger.Info("Successfully processed chart data request", "indicator", indicator)
@@ -176,11 +1205,308 @@ func (h *IndicatorHandler) convertRiskLevel(riskLevel string) string {
 	}
 }
 
-// getMVRVChartData retrieves MVRV chart data
+// addChanges adds change_24h/7d/30d to an indicator payload, omitting horizons without history
+func (h *IndicatorHandler) addChanges(ctx context.Context, data gin.H, name string, current float64) {
+	if h.changeService == nil {
+		return
+	}
+
+	changes, err := h.changeService.CalculateChanges(ctx, name, current, time.Now())
+	if err != nil {
+		h.logger.WithContext(ctx).Warn("Failed to calculate indicator changes", "name", name, "error", err)
+		return
+	}
+
+	if changes.Change24h != nil {
+		data["change_24h"] = *changes.Change24h
+	}
+	if changes.Change7d != nil {
+		data["change_7d"] = *changes.Change7d
+	}
+	if changes.Change30d != nil {
+		data["change_30d"] = *changes.Change30d
+	}
+}
+
+// addNormalizedValues adds a normalized copy of the chart's primary series
+func (h *IndicatorHandler) addNormalizedValues(chartData map[string]interface{}, method string) error {
+	series, ok := chartSeries(chartData)
+	if !ok {
+		return errors.Validation("Chart does not support normalization")
+	}
+
+	normalized, err := stats.Normalize(series, method)
+	if err != nil {
+		return errors.Validation("Invalid normalization method", "supported methods: minmax, zscore")
+	}
+
+	chartData["normalized_values"] = normalized
+	chartData["normalization"] = method
+	return nil
+}
+
+// parseSmoothingParams reads the ?smooth= method and ?span= length; an empty method
+// means no smoothing
+func parseSmoothingParams(c *gin.Context) (string, int, error) {
+	smoothing := strings.ToLower(c.Query("smooth"))
+	switch smoothing {
+	case "":
+		return "", 0, nil
+	case "ema", "sma":
+	default:
+		return "", 0, errors.Validation("Invalid 'smooth' parameter", "supported methods: ema, sma")
+	}
+
+	span := defaultSmoothingSpan
+	if raw := c.Query("span"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxMAWindow {
+			return "", 0, errors.Validation("Invalid 'span' parameter",
+				fmt.Sprintf("span must be an integer between 1 and %d", maxMAWindow))
+		}
+		span = parsed
+	}
+	return smoothing, span, nil
+}
+
+// addSmoothedValues adds a smoothed copy of the chart's primary series
+func addSmoothedValues(chartData map[string]interface{}, method string, span int) error {
+	series, ok := chartSeries(chartData)
+	if !ok {
+		return errors.Validation("Chart does not support smoothing")
+	}
+
+	if method == "ema" {
+		chartData["smoothed"] = stats.ExponentialMovingAverage(series, span)
+	} else {
+		chartData["smoothed"] = stats.SimpleMovingAverage(series, span)
+	}
+	chartData["smoothing"] = gin.H{"method": method, "span": span}
+	return nil
+}
+
+// addAnnotations adds the annotations between the chart's first and last timestamps.
+// Charts without timestamps, or without annotation storage, get an empty list.
+func (h *IndicatorHandler) addAnnotations(ctx context.Context, chartData map[string]interface{}) error {
+	annotations := []entities.IndicatorAnnotation{}
+	timestamps, _ := chartData["timestamps"].([]int64)
+	if h.annotationRepo != nil && len(timestamps) > 0 {
+		from, to := timestamps[0], timestamps[0]
+		for _, ts := range timestamps {
+			if ts < from {
+				from = ts
+			}
+			if ts > to {
+				to = ts
+			}
+		}
+
+		found, err := h.annotationRepo.GetInRange(ctx, time.UnixMilli(from), time.UnixMilli(to))
+		if err != nil {
+			return err
+		}
+		annotations = append(annotations, found...)
+	}
+
+	chartData["annotations"] = annotations
+	return nil
+}
+
+// parseMAWindows parses a comma-separated list of moving average windows, defaulting to 7 and 30
+func parseMAWindows(raw string) ([]int, error) {
+	if raw == "" {
+		return defaultMAWindows, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxMAWindows {
+		return nil, errors.Validation("Invalid 'ma' parameter", fmt.Sprintf("at most %d moving averages are supported", maxMAWindows))
+	}
+
+	windows := make([]int, 0, len(parts))
+	for _, part := range parts {
+		window, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || window < 1 || window > maxMAWindow {
+			return nil, errors.Validation("Invalid 'ma' parameter",
+				fmt.Sprintf("windows must be integers between 1 and %d, got %q", maxMAWindow, part))
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// addMovingAverages adds a moving_averages map keyed "ma_<window>" computed over chartData[seriesKey]
+func addMovingAverages(chartData map[string]interface{}, seriesKey string, windows []int) {
+	series, ok := chartData[seriesKey].([]float64)
+	if !ok {
+		return
+	}
+
+	averages := make(map[string][]float64, len(windows))
+	for _, window := range windows {
+		averages[fmt.Sprintf("ma_%d", window)] = stats.SimpleMovingAverage(series, window)
+	}
+	chartData["moving_averages"] = averages
+}
+
+// chartSeries extracts the primary value series from chart data as floats
+func chartSeries(chartData map[string]interface{}) ([]float64, bool) {
+	for _, key := range []string{"values", "zscore_data"} {
+		if series, ok := floatSeries(chartData[key]); ok {
+			return series, true
+		}
+	}
+	return nil, false
+}
+
+// floatSeries converts a numeric chart series to floats
+func floatSeries(value interface{}) ([]float64, bool) {
+	switch values := value.(type) {
+	case []float64:
+		return values, true
+	case []int:
+		series := make([]float64, len(values))
+		for i, v := range values {
+			series[i] = float64(v)
+		}
+		return series, true
+	}
+	return nil, false
+}
+
+// parseDownsampleParams reads the ?points= cap and ?downsample= method
+func parseDownsampleParams(c *gin.Context) (int, string, error) {
+	points := defaultChartPoints
+	if raw := c.Query("points"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 2 || parsed > maxChartPoints {
+			return 0, "", errors.Validation("Invalid 'points' parameter",
+				fmt.Sprintf("points must be between 2 and %d", maxChartPoints))
+		}
+		points = parsed
+	}
+
+	method := c.DefaultQuery("downsample", stats.DownsampleLast)
+	switch method {
+	case stats.DownsampleLast, stats.DownsampleAvg, stats.DownsampleOHLC:
+	default:
+		return 0, "", errors.Validation("Invalid 'downsample' parameter", "supported methods: last, avg, ohlc")
+	}
+	return points, method, nil
+}
+
+// parseHistoryRange reads the window of history a request asks for. Explicit RFC3339 ?from=
+// and ?to= take precedence over the relative ?period=, which ends at now and starts at
+// midnight in the optional ?tz= zone. The returned period is "custom" for explicit ranges.
+func parseHistoryRange(c *gin.Context, now time.Time) (entities.TimeRange, string, error) {
+	rawFrom, rawTo := c.Query("from"), c.Query("to")
+	if rawFrom != "" || rawTo != "" {
+		if rawFrom == "" || rawTo == "" {
+			return entities.TimeRange{}, "", errors.Validation("Invalid time range", "from and to must be given together")
+		}
+		from, err := time.Parse(time.RFC3339, rawFrom)
+		if err != nil {
+			return entities.TimeRange{}, "", errors.Validation("Invalid 'from' parameter", "from must be an RFC3339 timestamp")
+		}
+		to, err := time.Parse(time.RFC3339, rawTo)
+		if err != nil {
+			return entities.TimeRange{}, "", errors.Validation("Invalid 'to' parameter", "to must be an RFC3339 timestamp")
+		}
+		if !from.Before(to) {
+			return entities.TimeRange{}, "", errors.Validation("Invalid time range", "from must be before to")
+		}
+		return entities.TimeRange{From: from, To: to}, "custom", nil
+	}
+
+	var loc *time.Location
+	if tz := c.Query("tz"); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return entities.TimeRange{}, "", errors.Validation("Invalid 'tz' parameter", "tz must be an IANA time zone such as Europe/Berlin")
+		}
+	}
+
+	period := c.DefaultQuery("period", entities.DefaultHistoryPeriod)
+	return entities.PeriodRange(period, now, loc), period, nil
+}
+
+// downsampleChart caps every series aligned with chartData["timestamps"], including
+// moving average overlays, at target points. The ohlc method adds a "<series>_ohlc"
+// entry per series alongside its close values.
+func downsampleChart(chartData map[string]interface{}, target int, method string) error {
+	timestamps, ok := chartData["timestamps"].([]int64)
+	if !ok || len(timestamps) <= target {
+		return nil
+	}
+
+	downsample := func(series []float64) ([]float64, []stats.OHLC, []int64, error) {
+		points := make([]stats.Point, len(series))
+		for i, v := range series {
+			points[i] = stats.Point{Timestamp: timestamps[i], Value: v}
+		}
+		reduced, err := stats.Downsample(points, target, method)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		values := make([]float64, len(reduced))
+		stamps := make([]int64, len(reduced))
+		var ohlc []stats.OHLC
+		for i, p := range reduced {
+			values[i] = p.Value
+			stamps[i] = p.Timestamp
+			if p.OHLC != nil {
+				ohlc = append(ohlc, *p.OHLC)
+			}
+		}
+		return values, ohlc, stamps, nil
+	}
+
+	var reducedTimestamps []int64
+	for key, value := range chartData {
+		if averages, ok := value.(map[string][]float64); ok {
+			for name, series := range averages {
+				if len(series) != len(timestamps) {
+					continue
+				}
+				values, _, _, err := downsample(series)
+				if err != nil {
+					return errors.Internal("failed to downsample chart data", err)
+				}
+				averages[name] = values
+			}
+			continue
+		}
+
+		series, ok := floatSeries(value)
+		if !ok || key == "timestamps" || len(series) != len(timestamps) {
+			continue
+		}
+		values, ohlc, stamps, err := downsample(series)
+		if err != nil {
+			return errors.Internal("failed to downsample chart data", err)
+		}
+		chartData[key] = values
+		if ohlc != nil {
+			chartData[key+"_ohlc"] = ohlc
+		}
+		reducedTimestamps = stamps
+	}
+
+	if reducedTimestamps != nil {
+		chartData["timestamps"] = reducedTimestamps
+		chartData["downsampled"] = gin.H{
+			"method":          method,
+			"points":          len(reducedTimestamps),
+			"original_points": len(timestamps),
+		}
+	}
+	return nil
+}
+
+// getMVRVChartData returns the z-score and price series from the MVRV history stored with
+// the latest calculation. GetChartData downsamples them to the requested point cap.
 func (h *IndicatorHandler) getMVRVChartData(ctx context.Context) (map[string]interface{}, error) {
-	// Skip MVRV service initialization due to architecture migration
-	// TODO: Complete migration of indicator services to new architecture
-	
 	// Return mock data since service is not available
 	if h.mvrvService == nil {
 		return h.generateMockMVRVChartData(), nil
@@ -192,28 +1518,33 @@ func (h *IndicatorHandler) getMVRVChartData(ctx context.Context) (map[string]int
 		return nil, err
 	}
 
-	// Convert to chart format
-	var timestamps []int64
-	var zScores []float64
-	var prices []float64
+	history, ok := indicator.MVRVHistory()
+	if !ok {
+		h.logger.WithContext(ctx).Warn("Latest MVRV calculation has no stored history")
+	}
 
-	// For now, generate mock data based on the indicator
-	// In production, this would extract and process historical_data from metadata
-	for i := 0; i < 30; i++ {
-		timestamp := time.Now().AddDate(0, 0, -30+i).Unix() * 1000
-		timestamps = append(timestamps, timestamp)
-		zScores = append(zScores, -2.0+float64(i)*0.15) // Mock z-score progression
-		prices = append(prices, 30000+float64(i)*1000)  // Mock price progression
+	timestamps := make([]int64, len(history))
+	zScores := make([]float64, len(history))
+	prices := make([]float64, len(history))
+	for i, point := range history {
+		timestamps[i] = point.Date.UnixMilli()
+		zScores[i] = point.MVRVZScore
+		prices[i] = point.Price
 	}
 
-	return map[string]interface{}{
+	chartData := map[string]interface{}{
 		"timestamps":     timestamps,
 		"zscore_data":    zScores,
 		"price_data":     prices,
 		"current_zscore": indicator.Value,
 		"thresholds":     indicator.Metadata["zscore_thresholds"],
 		"last_updated":   indicator.Timestamp,
-	}, nil
+	}
+	// Mean, standard deviation and ±1/±2 standard deviation ratios for drawing bands
+	if bands, ok := indicator.Metadata["ratio_bands"]; ok {
+		chartData["ratio_bands"] = bands
+	}
+	return chartData, nil
 }
 
 // generateDominanceData creates mock dominance data
@@ -239,32 +1570,6 @@ func (h *IndicatorHandler) generateDominanceData() map[string]interface{} {
 	}
 }
 
-// generateFearGreedData creates mock fear & greed data
-func (h *IndicatorHandler) generateFearGreedData() map[string]interface{} {
-	return gin.H{
-		"value":      "72",
-		"change":     "+5",
-		"risk_level": "medium",
-		"status":     "GREED: Market sentiment is greedy - Be cautious",
-		"timestamp":  time.Now().Format(time.RFC3339),
-		"details": gin.H{
-			"classification":         "Greed",
-			"change_7d":             8,
-			"trading_recommendation": "Consider taking some profits",
-			"data_source":           "Alternative.me API",
-			"next_update":           time.Now().Add(24 * time.Hour).Format(time.RFC3339),
-			"components": gin.H{
-				"volatility": 75,
-				"momentum":   80,
-				"social":     65,
-				"surveys":    70,
-				"dominance":  68,
-				"trends":     74,
-			},
-		},
-	}
-}
-
 // generateBubbleRiskData creates mock bubble risk data
 func (h *IndicatorHandler) generateBubbleRiskData() map[string]interface{} {
 	return gin.H{
@@ -277,7 +1582,7 @@ func (h *IndicatorHandler) generateBubbleRiskData() map[string]interface{} {
 			"risk_score":             45,
 			"confidence_level":       82,
 			"trading_recommendation": "Maintain current positions with tight stops",
-			"data_source":           "Multi-factor analysis",
+			"data_source":           entities.DataSourceAggregated,
 			"components": gin.H{
 				"mvrv_score":    40,
 				"nvt_score":     50,
@@ -319,6 +1624,116 @@ func (h *IndicatorHandler) generateDominanceChartData() map[string]interface{} {
 	}
 }
 
+// getDominanceChartData returns stored dominance history, falling back to mock data
+func (h *IndicatorHandler) getDominanceChartData(ctx context.Context) map[string]interface{} {
+	if h.dominanceService != nil {
+		chartData, err := h.dominanceService.GetDominanceChart(ctx)
+		if err == nil {
+			return chartData
+		}
+		h.logger.WithContext(ctx).Warn("Failed to get dominance chart data, using mock data", "error", err)
+	}
+	return h.generateDominanceChartData()
+}
+
+// getFearGreedChartData returns provider history, falling back to mock data
+func (h *IndicatorHandler) getFearGreedChartData(ctx context.Context) map[string]interface{} {
+	if h.fearGreedService != nil {
+		chartData, err := h.fearGreedService.GetFearGreedChart(ctx)
+		if err == nil {
+			return chartData
+		}
+		h.logger.WithContext(ctx).Warn("Failed to get Fear & Greed chart data, using mock data", "error", err)
+	}
+	return h.generateFearGreedChartData()
+}
+
+// getRealizedPriceChartData returns stored realized price history alongside the BTC price.
+// A non-zero calcVersion keeps only values produced by that calculation version.
+func (h *IndicatorHandler) getRealizedPriceChartData(ctx context.Context, window entities.TimeRange, period string, calcVersion uint) (map[string]interface{}, error) {
+	history, err := storedHistory(ctx, h.realizedPriceService, window, calcVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]int64, 0, len(history))
+	realized := make([]float64, 0, len(history))
+	prices := make([]float64, 0, len(history))
+	for _, point := range history {
+		price, ok := point.Metadata["price"].(float64)
+		if !ok {
+			continue
+		}
+		timestamps = append(timestamps, point.Timestamp.UnixMilli())
+		realized = append(realized, point.Value)
+		prices = append(prices, price)
+	}
+
+	chartData := map[string]interface{}{
+		"timestamps": timestamps,
+		"values":     realized,
+		"price_data": prices,
+		"period":     period,
+		"from":       window.From,
+		"to":         window.To,
+	}
+	if calcVersion != 0 {
+		chartData["calc_version"] = calcVersion
+	}
+	return chartData, nil
+}
+
+// respondHistoryCSV streams service's stored history for the ?period= or ?from=&to= range
+// and ?calc_version= as a CSV download named after name
+func (h *IndicatorHandler) respondHistoryCSV(c *gin.Context, service domainservices.IndicatorService, name string) {
+	window, _, err := parseHistoryRange(c, time.Now())
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	calcVersion, err := parseCalcVersion(c.Query("calc_version"))
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	history, err := storedHistory(c.Request.Context(), service, window, calcVersion)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	respondIndicatorCSV(c, name, window, history)
+}
+
+// storedHistory returns the values service stored in window, only those of calcVersion
+// unless it is 0
+func storedHistory(ctx context.Context, service domainservices.IndicatorService, window entities.TimeRange, calcVersion uint) ([]entities.Indicator, error) {
+	history, err := service.GetHistoricalData(ctx, window)
+	if err != nil || calcVersion == 0 {
+		return history, err
+	}
+
+	filtered := make([]entities.Indicator, 0, len(history))
+	for _, point := range history {
+		if point.CalcVersion == calcVersion {
+			filtered = append(filtered, point)
+		}
+	}
+	return filtered, nil
+}
+
+// parseCalcVersion reads ?calc_version=, returning 0 when history of every version is wanted
+func parseCalcVersion(raw string) (uint, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	version, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || version == 0 {
+		return 0, errors.Validation("Invalid 'calc_version' parameter", "calc_version must be a positive integer")
+	}
+	return uint(version), nil
+}
+
 func (h *IndicatorHandler) generateFearGreedChartData() map[string]interface{} {
 	timestamps := make([]int64, 30)
 	values := make([]int, 30)
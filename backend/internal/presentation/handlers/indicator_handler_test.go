@@ -1,16 +1,28 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
 	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
 	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -116,6 +128,12 @@ func (suite *IndicatorHandlerTestSuite) TestGetFearGreedIndicator_Success() {
 	assert.Contains(suite.T(), data, "risk_level")
 }
 
+func (suite *IndicatorHandlerTestSuite) TestGetFearGreedComponents_ServiceUnavailable() {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/fear-greed/components", nil))
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+}
+
 func (suite *IndicatorHandlerTestSuite) TestGetBubbleRiskIndicator_Success() {
 	req, err := http.NewRequest("GET", "/api/v1/indicators/bubble-risk", nil)
 	require.NoError(suite.T(), err)
@@ -147,12 +165,13 @@ func (suite *IndicatorHandlerTestSuite) TestGetChartData_MVRV() {
 	var response map[string]interface{}
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(suite.T(), err)
+	chart := response["data"].(map[string]interface{})
 
-	assert.Contains(suite.T(), response, "timestamps")
-	assert.Contains(suite.T(), response, "zscore_data")
-	assert.Contains(suite.T(), response, "price_data")
-	assert.Contains(suite.T(), response, "current_zscore")
-	assert.Contains(suite.T(), response, "thresholds")
+	assert.Contains(suite.T(), chart, "timestamps")
+	assert.Contains(suite.T(), chart, "zscore_data")
+	assert.Contains(suite.T(), chart, "price_data")
+	assert.Contains(suite.T(), chart, "current_zscore")
+	assert.Contains(suite.T(), chart, "thresholds")
 }
 
 func (suite *IndicatorHandlerTestSuite) TestGetChartData_Dominance() {
@@ -167,11 +186,132 @@ func (suite *IndicatorHandlerTestSuite) TestGetChartData_Dominance() {
 	var response map[string]interface{}
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(suite.T(), err)
+	chart := response["data"].(map[string]interface{})
+
+	assert.Contains(suite.T(), chart, "timestamps")
+	assert.Contains(suite.T(), chart, "values")
+	assert.Contains(suite.T(), chart, "current")
+	assert.Contains(suite.T(), chart, "levels")
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetChartData_Normalized() {
+	tests := []struct {
+		endpoint  string
+		seriesKey string
+		method    string
+	}{
+		{"/api/v1/charts/dominance?normalize=minmax", "values", "minmax"},
+		{"/api/v1/charts/fear-greed?normalize=zscore", "values", "zscore"},
+		{"/api/v1/charts/mvrv?normalize=minmax", "zscore_data", "minmax"},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", tt.endpoint, nil)
+		require.NoError(suite.T(), err)
+
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusOK, w.Code, tt.endpoint)
+
+		var response map[string]interface{}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(suite.T(), err)
+		chart := response["data"].(map[string]interface{})
+
+		raw := chart[tt.seriesKey].([]interface{})
+		normalized := chart["normalized_values"].([]interface{})
+		assert.Len(suite.T(), normalized, len(raw), tt.endpoint)
+		assert.Equal(suite.T(), tt.method, chart["normalization"])
+
+		if tt.method == "minmax" {
+			for _, v := range normalized {
+				assert.GreaterOrEqual(suite.T(), v.(float64), 0.0)
+				assert.LessOrEqual(suite.T(), v.(float64), 1.0)
+			}
+		}
+	}
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetChartData_MVRVMovingAverages() {
+	req, err := http.NewRequest("GET", "/api/v1/charts/mvrv?ma=3,60", nil)
+	require.NoError(suite.T(), err)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	chart := response["data"].(map[string]interface{})
+
+	zScores := chart["zscore_data"].([]interface{})
+	averages := chart["moving_averages"].(map[string]interface{})
+	require.Len(suite.T(), averages, 2)
+
+	// The mock series is z[i] = -2 + 0.15*i over 30 days
+	ma3 := averages["ma_3"].([]interface{})
+	require.Len(suite.T(), ma3, len(zScores))
+	assert.InDelta(suite.T(), -2.0, ma3[0].(float64), 1e-9)
+	assert.InDelta(suite.T(), -1.925, ma3[1].(float64), 1e-9)
+	assert.InDelta(suite.T(), -1.85, ma3[2].(float64), 1e-9)
+	assert.InDelta(suite.T(), 2.2, ma3[29].(float64), 1e-9)
+
+	// A window longer than the series averages everything available
+	ma60 := averages["ma_60"].([]interface{})
+	require.Len(suite.T(), ma60, len(zScores))
+	assert.InDelta(suite.T(), 0.175, ma60[29].(float64), 1e-9)
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetChartData_MVRVDefaultMovingAverages() {
+	req, err := http.NewRequest("GET", "/api/v1/charts/mvrv", nil)
+	require.NoError(suite.T(), err)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	chart := response["data"].(map[string]interface{})
+
+	averages := chart["moving_averages"].(map[string]interface{})
+	assert.Contains(suite.T(), averages, "ma_7")
+	assert.Contains(suite.T(), averages, "ma_30")
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetChartData_InvalidMovingAverage() {
+	for _, ma := range []string{"0", "abc", "7,,30", "1000", "1,2,3,4,5,6"} {
+		req, err := http.NewRequest("GET", "/api/v1/charts/mvrv?ma="+ma, nil)
+		require.NoError(suite.T(), err)
+
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code, "ma=%s", ma)
+	}
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetChartData_InvalidDownsampling() {
+	for _, query := range []string{"points=1", "points=abc", "points=100000", "downsample=median"} {
+		req, err := http.NewRequest("GET", "/api/v1/charts/dominance?"+query, nil)
+		require.NoError(suite.T(), err)
+
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetChartData_InvalidNormalization() {
+	req, err := http.NewRequest("GET", "/api/v1/charts/dominance?normalize=log", nil)
+	require.NoError(suite.T(), err)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
 
-	assert.Contains(suite.T(), response, "timestamps")
-	assert.Contains(suite.T(), response, "values")
-	assert.Contains(suite.T(), response, "current")
-	assert.Contains(suite.T(), response, "levels")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
 
 func (suite *IndicatorHandlerTestSuite) TestGetChartData_UnknownIndicator() {
@@ -186,10 +326,39 @@ func (suite *IndicatorHandlerTestSuite) TestGetChartData_UnknownIndicator() {
 	var response map[string]interface{}
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(suite.T(), err)
+	chart := response["data"].(map[string]interface{})
+
+	assert.Equal(suite.T(), "unknown", chart["indicator"])
+	assert.Contains(suite.T(), response["meta"], "message")
+	assert.Contains(suite.T(), chart, "mock_data")
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetIndicatorCorrelation_MissingIndicator() {
+	req, err := http.NewRequest("GET", "/api/v1/indicators/correlation?a=mvrv", nil)
+	require.NoError(suite.T(), err)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(suite.T(), err)
+
+	assert.False(suite.T(), response["success"].(bool))
+	errorBody := response["error"].(map[string]interface{})
+	assert.Equal(suite.T(), "VALIDATION_ERROR", errorBody["type"])
+}
+
+func (suite *IndicatorHandlerTestSuite) TestGetIndicatorCorrelation_ServiceUnavailable() {
+	req, err := http.NewRequest("GET", "/api/v1/indicators/correlation?a=mvrv&b=fear_greed&period=90d", nil)
+	require.NoError(suite.T(), err)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
 
-	assert.Equal(suite.T(), "unknown", response["indicator"])
-	assert.Contains(suite.T(), response, "message")
-	assert.Contains(suite.T(), response, "mock_data")
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
 }
 
 // Test suite runner
@@ -263,6 +432,127 @@ func TestIndicatorHandler_ResponseFormats(t *testing.T) {
 	}
 }
 
+func TestIndicatorHandler_BulkIngest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCount  int
+		expectedError  string
+	}{
+		{
+			name: "Valid batch",
+			body: `[
+				{"name": "mvrv", "type": "onchain", "value": 2.1, "timestamp": "2024-01-01T00:00:00Z", "metadata": {"source": "pipeline"}},
+				{"name": "fear_greed", "type": "sentiment", "value": 0, "timestamp": "2024-01-01T00:00:00Z"}
+			]`,
+			expectedStatus: http.StatusCreated,
+			expectedCount:  2,
+		},
+		{
+			name: "Missing name rejects the batch",
+			body: `[
+				{"name": "mvrv", "type": "onchain", "value": 2.1, "timestamp": "2024-01-01T00:00:00Z"},
+				{"type": "sentiment", "value": 55, "timestamp": "2024-01-01T00:00:00Z"}
+			]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "indicators[1]: name is required",
+		},
+		{
+			name:           "Empty batch",
+			body:           `[]`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &testutil.MockIndicatorRepository{}
+			if tt.expectedCount > 0 {
+				repo.On("BulkCreate", mock.Anything, mock.MatchedBy(func(indicators []entities.Indicator) bool {
+					return len(indicators) == tt.expectedCount && indicators[0].Name == "mvrv"
+				})).Return(nil)
+			}
+
+			deps := &config.Dependencies{
+				Logger:        testDB.Logger,
+				Cache:         testutil.NewMockCacheService(),
+				IndicatorRepo: repo,
+			}
+			router := gin.New()
+			NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+			req, err := http.NewRequest("POST", "/api/v1/indicators/bulk", bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			if tt.expectedCount > 0 {
+				assert.True(t, response["success"].(bool))
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, float64(tt.expectedCount), data["created"])
+			} else {
+				assert.False(t, response["success"].(bool))
+				repo.AssertNotCalled(t, "BulkCreate", mock.Anything, mock.Anything)
+			}
+			if tt.expectedError != "" {
+				errorBody := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedError, errorBody["details"])
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+// unusedAPIKeyRepository fails the test if authentication ever looks a key up
+type unusedAPIKeyRepository struct {
+	repositories.APIKeyRepository
+}
+
+func TestIndicatorHandler_WriteRoutesRequireAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	repo := &testutil.MockIndicatorRepository{}
+	deps := &config.Dependencies{Logger: log, Cache: testutil.NewMockCacheService(), IndicatorRepo: repo}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"),
+		middleware.APIKeyAuth(unusedAPIKeyRepository{}, log))
+
+	for _, path := range []string{"/api/v1/indicators/bulk", "/api/v1/indicators/mvrv/recalculate"} {
+		t.Run(path, func(t *testing.T) {
+			body := `[{"name": "mvrv", "type": "onchain", "value": 2.1, "timestamp": "2024-01-01T00:00:00Z"}]`
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+	repo.AssertNotCalled(t, "BulkCreate", mock.Anything, mock.Anything)
+
+	t.Run("reads stay public", func(t *testing.T) {
+		repo.On("GetLatestForTypes", mock.Anything, mock.Anything).Return([]entities.Indicator{}, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/bulk?types=onchain", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
 // Benchmark tests for handler performance
 func BenchmarkIndicatorHandler(b *testing.B) {
 	gin.SetMode(gin.TestMode)
@@ -301,4 +591,1151 @@ func BenchmarkIndicatorHandler(b *testing.B) {
 			router.ServeHTTP(w, req)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestIndicatorHandler_ConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	stored := []entities.Indicator{
+		{Name: "mvrv", Type: "onchain", Value: 2.1, Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Name: "nupl", Type: "onchain", Value: 0.5, Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("GetLatestByTypeInRange", mock.Anything, "onchain", mock.Anything, mock.Anything, mock.Anything).
+		Return(stored, nil)
+
+	deps := &config.Dependencies{
+		Logger:        testDB.Logger,
+		Cache:         testutil.NewMockCacheService(),
+		IndicatorRepo: repo,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	fetch := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", "/api/v1/indicators/type/onchain", nil)
+		require.NoError(t, err)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := fetch("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	second := fetch(etag)
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Equal(t, etag, second.Header().Get("ETag"))
+	assert.Empty(t, second.Body.Bytes())
+
+	// A stale tag gets the full body back
+	third := fetch(`"stale"`)
+	assert.Equal(t, http.StatusOK, third.Code)
+	assert.Equal(t, etag, third.Header().Get("ETag"))
+
+	// New data produces a new tag
+	stored[0].Value = 2.2
+	fourth := fetch(etag)
+	assert.Equal(t, http.StatusOK, fourth.Code)
+	assert.NotEqual(t, etag, fourth.Header().Get("ETag"))
+}
+
+func TestIndicatorHandler_TypeLimitAndPageSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	stored := []entities.Indicator{
+		{Name: "mvrv", Type: "onchain", Value: 2.1, Timestamp: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{Name: "nupl", Type: "onchain", Value: 0.5, Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Name: "puell", Type: "onchain", Value: 1.1, Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("GetLatestByTypeInRange", mock.Anything, "onchain", mock.Anything, mock.Anything, mock.Anything).
+		Return(stored, nil)
+
+	deps := &config.Dependencies{
+		Logger:        testDB.Logger,
+		Cache:         testutil.NewMockCacheService(),
+		IndicatorRepo: repo,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	fetch := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/type/onchain"+query, nil))
+		return w
+	}
+
+	// limit is passed through to the query, so existing clients sending limit=1000 keep working
+	w := fetch("?limit=1000")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	repo.AssertCalled(t, "GetLatestByTypeInRange", mock.Anything, "onchain", mock.Anything, mock.Anything, 1000)
+
+	// page_size and offset page through the latest values
+	w = fetch("?page_size=2&offset=1")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Data       []entities.Indicator `json:"data"`
+		Pagination Pagination           `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, "nupl", response.Data[0].Name)
+	assert.Equal(t, 3, response.Pagination.Total)
+	assert.Equal(t, 2, response.Pagination.Limit)
+
+	for _, query := range []string{"?limit=0", "?limit=5001", "?page_size=0", "?page_size=501", "?offset=-1"} {
+		assert.Equal(t, http.StatusBadRequest, fetch(query).Code, query)
+	}
+}
+
+func TestIndicatorHandler_MinConfidenceFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+	testDB.CreateTables(t, testutil.IndicatorsTableDDL)
+
+	repo := database.NewIndicatorRepository(database.NewDBProvider(testDB.DB, nil), testDB.Logger)
+	now := time.Now()
+	for _, indicator := range []*entities.Indicator{
+		{Name: "mvrv", Type: "onchain", Value: 2.0, Confidence: 0.9, Timestamp: now.Add(-2 * time.Hour)},
+		{Name: "mvrv", Type: "onchain", Value: 2.4, Confidence: 0.4, Timestamp: now.Add(-time.Hour)},
+		{Name: "nupl", Type: "onchain", Value: 0.4, Confidence: 0.2, Timestamp: now.Add(-time.Hour)},
+	} {
+		require.NoError(t, repo.Create(context.Background(), indicator))
+	}
+
+	deps := &config.Dependencies{
+		Logger:        testDB.Logger,
+		Cache:         testutil.NewMockCacheService(),
+		IndicatorRepo: repo,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	fetch := func(query string) (int, map[string]float64) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/indicators/type/onchain"+query, nil)
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data []entities.Indicator `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+		values := make(map[string]float64, len(response.Data))
+		for _, indicator := range response.Data {
+			values[indicator.Name] = indicator.Value
+		}
+		return w.Code, values
+	}
+
+	// Without the filter the latest values are served whatever their confidence
+	code, values := fetch("")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]float64{"mvrv": 2.4, "nupl": 0.4}, values)
+
+	// With it, low-confidence values are excluded in favour of the last confident one
+	code, values = fetch("?min_confidence=0.7")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]float64{"mvrv": 2.0}, values)
+
+	// Confident values outside the range are left out
+	code, values = fetch("?min_confidence=0.7&from=" + now.Add(-90*time.Minute).UTC().Format(time.RFC3339))
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, values)
+
+	for _, query := range []string{"?min_confidence=high", "?min_confidence=1.5", "?min_confidence=-0.1"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/type/onchain"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestDownsampleChart(t *testing.T) {
+	const n = 1000
+	timestamps := make([]int64, n)
+	values := make([]float64, n)
+	counts := make([]int, n)
+	for i := 0; i < n; i++ {
+		timestamps[i] = int64(i) * 3600 * 1000
+		values[i] = float64(i)
+		counts[i] = i
+	}
+	chartData := map[string]interface{}{
+		"timestamps":      timestamps,
+		"values":          values,
+		"volume":          counts,
+		"moving_averages": map[string][]float64{"ma_7": values},
+		"thresholds":      []float64{1, 2, 3},
+	}
+
+	require.NoError(t, downsampleChart(chartData, 100, "ohlc"))
+
+	reduced := chartData["timestamps"].([]int64)
+	require.Len(t, reduced, 100)
+	assert.Equal(t, timestamps[n-1], reduced[99], "latest point is retained")
+	assert.Len(t, chartData["values"], 100)
+	assert.Equal(t, 999.0, chartData["values"].([]float64)[99])
+	assert.Len(t, chartData["volume"], 100)
+	assert.Len(t, chartData["values_ohlc"], 100)
+	assert.Len(t, chartData["moving_averages"].(map[string][]float64)["ma_7"], 100)
+	assert.Equal(t, []float64{1, 2, 3}, chartData["thresholds"], "unaligned series are left alone")
+	assert.Equal(t, n, chartData["downsampled"].(gin.H)["original_points"])
+
+	short := map[string]interface{}{"timestamps": timestamps[:30], "values": values[:30]}
+	require.NoError(t, downsampleChart(short, 100, "last"))
+	assert.Len(t, short["values"], 30)
+	assert.NotContains(t, short, "downsampled")
+}
+
+// rateLimitedIndicatorService fails every call with a wrapped upstream rate limit error
+type rateLimitedIndicatorService struct {
+	resetTime time.Time
+}
+
+func (s rateLimitedIndicatorService) err() error {
+	return errors.External("CoinMarketCap", "failed to fetch listings", errors.NewRateLimitError("CoinMarketCap", s.resetTime))
+}
+
+func (s rateLimitedIndicatorService) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	return nil, s.err()
+}
+
+func (s rateLimitedIndicatorService) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	return nil, s.err()
+}
+
+func (s rateLimitedIndicatorService) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	return nil, s.err()
+}
+
+func TestIndicatorHandler_UpstreamRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	deps := &config.Dependencies{
+		Logger:           testDB.Logger,
+		Cache:            testutil.NewMockCacheService(),
+		AltSeasonService: rateLimitedIndicatorService{resetTime: time.Now().Add(30 * time.Second)},
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	req, err := http.NewRequest("GET", "/api/v1/indicators/alt-season", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, 30, retryAfter, 2)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.False(t, response["success"].(bool))
+	assert.Equal(t, "RATE_LIMIT_ERROR", response["error"].(map[string]interface{})["type"])
+}
+
+// recordingHistoryService returns no history and records the window it was asked for
+type recordingHistoryService struct {
+	rateLimitedIndicatorService
+	window *entities.TimeRange
+}
+
+func (s recordingHistoryService) GetHistoricalData(ctx context.Context, window entities.TimeRange) ([]entities.Indicator, error) {
+	*s.window = window
+	return []entities.Indicator{}, nil
+}
+
+func TestIndicatorHandler_ChartHistoryRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	var window entities.TimeRange
+	deps := &config.Dependencies{
+		Logger:               testDB.Logger,
+		Cache:                testutil.NewMockCacheService(),
+		RealizedPriceService: recordingHistoryService{window: &window},
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	get := func(query string) (int, map[string]interface{}) {
+		window = entities.TimeRange{}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/realized-price?"+query, nil))
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		if chart, ok := response["data"].(map[string]interface{}); ok {
+			return w.Code, chart
+		}
+		return w.Code, response
+	}
+
+	t.Run("Explicit range takes precedence over period", func(t *testing.T) {
+		code, response := get("period=1y&from=2024-01-01T00:00:00%2B02:00&to=2024-02-01T00:00:00Z")
+
+		require.Equal(t, http.StatusOK, code, response)
+		assert.True(t, window.From.Equal(time.Date(2023, 12, 31, 22, 0, 0, 0, time.UTC)))
+		assert.True(t, window.To.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, "custom", response["period"])
+	})
+
+	t.Run("Invalid ranges are rejected", func(t *testing.T) {
+		for _, query := range []string{
+			"from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			"from=2024-01-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			"from=2024-01-01T00:00:00Z",
+			"from=yesterday&to=2024-01-01T00:00:00Z",
+			"period=7d&tz=Mars/Olympus_Mons",
+		} {
+			code, response := get(query)
+
+			assert.Equal(t, http.StatusBadRequest, code, query)
+			assert.Equal(t, "VALIDATION_ERROR", response["error"].(map[string]interface{})["type"], query)
+			assert.True(t, window.From.IsZero(), "service should not be called for %s", query)
+		}
+	})
+
+	t.Run("Relative period without explicit range", func(t *testing.T) {
+		code, response := get("period=7d")
+
+		require.Equal(t, http.StatusOK, code, response)
+		assert.WithinDuration(t, time.Now(), window.To, time.Minute)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), window.From, time.Minute)
+		assert.Equal(t, "7d", response["period"])
+	})
+
+	t.Run("Relative period starts at midnight in tz", func(t *testing.T) {
+		code, _ := get("period=7d&tz=America/New_York")
+
+		require.Equal(t, http.StatusOK, code)
+		ny, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+		start := window.From.In(ny)
+		assert.Equal(t, 0, start.Hour()*60+start.Minute())
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), window.From, 25*time.Hour)
+	})
+
+	t.Run("Default period", func(t *testing.T) {
+		code, _ := get("")
+
+		require.Equal(t, http.StatusOK, code)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), window.From, time.Minute)
+	})
+}
+
+func TestIndicatorHandler_ChartCalcVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	start := time.Now().AddDate(0, 0, -3)
+	history := []entities.Indicator{
+		{Timestamp: start, Value: 30000, CalcVersion: 1, Metadata: map[string]interface{}{"price": 60000.0}},
+		{Timestamp: start.AddDate(0, 0, 1), Value: 31000, CalcVersion: 1, Metadata: map[string]interface{}{"price": 61000.0}},
+		{Timestamp: start.AddDate(0, 0, 2), Value: 42000, CalcVersion: 2, Metadata: map[string]interface{}{"price": 62000.0}},
+	}
+
+	deps := &config.Dependencies{
+		Logger:               testDB.Logger,
+		Cache:                testutil.NewMockCacheService(),
+		RealizedPriceService: fixedHistoryService{history: history},
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/realized-price?"+query, nil))
+		return w
+	}
+
+	type chart struct {
+		Data struct {
+			Values      []float64 `json:"values"`
+			PriceData   []float64 `json:"price_data"`
+			CalcVersion uint      `json:"calc_version"`
+		} `json:"data"`
+	}
+
+	t.Run("Filters history to one version", func(t *testing.T) {
+		w := get("calc_version=1")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response chart
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []float64{30000, 31000}, response.Data.Values)
+		assert.Equal(t, []float64{60000, 61000}, response.Data.PriceData)
+		assert.Equal(t, uint(1), response.Data.CalcVersion)
+	})
+
+	t.Run("Every version without the parameter", func(t *testing.T) {
+		w := get("")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response chart
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data.Values, 3)
+	})
+
+	t.Run("Invalid versions are rejected", func(t *testing.T) {
+		for _, query := range []string{"calc_version=0", "calc_version=-1", "calc_version=v2"} {
+			assert.Equal(t, http.StatusBadRequest, get(query).Code, query)
+		}
+	})
+}
+
+func TestIndicatorHandler_ChartSmoothing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	start := time.Now().AddDate(0, 0, -5)
+	var history []entities.Indicator
+	for i, value := range []float64{10, 12, 8, 14, 10} {
+		history = append(history, entities.Indicator{
+			Timestamp: start.AddDate(0, 0, i),
+			Value:     value,
+			Metadata:  map[string]interface{}{"price": 60000.0},
+		})
+	}
+
+	deps := &config.Dependencies{
+		Logger:               testDB.Logger,
+		Cache:                testutil.NewMockCacheService(),
+		RealizedPriceService: fixedHistoryService{history: history},
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/charts/realized-price?"+query, nil))
+		return w
+	}
+
+	type chart struct {
+		Data struct {
+			Values    []float64              `json:"values"`
+			Smoothed  []float64              `json:"smoothed"`
+			Smoothing map[string]interface{} `json:"smoothing"`
+		} `json:"data"`
+	}
+
+	t.Run("EMA alongside raw values", func(t *testing.T) {
+		w := get("smooth=ema&span=3")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response chart
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []float64{10, 12, 8, 14, 10}, response.Data.Values)
+		assert.Equal(t, []float64{10, 11, 9.5, 11.75, 10.875}, response.Data.Smoothed)
+		assert.Equal(t, "ema", response.Data.Smoothing["method"])
+		assert.Equal(t, float64(3), response.Data.Smoothing["span"])
+	})
+
+	t.Run("SMA with the default span longer than the series", func(t *testing.T) {
+		w := get("smooth=sma")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response chart
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []float64{10, 11, 10, 11, 10.8}, response.Data.Smoothed)
+		assert.Equal(t, float64(14), response.Data.Smoothing["span"])
+	})
+
+	t.Run("No smoothing by default", func(t *testing.T) {
+		w := get("")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "smoothed")
+	})
+
+	t.Run("Invalid parameters are rejected", func(t *testing.T) {
+		for _, query := range []string{"smooth=wma", "smooth=ema&span=0", "smooth=sma&span=abc"} {
+			assert.Equal(t, http.StatusBadRequest, get(query).Code, query)
+		}
+	})
+}
+
+// staticIndicatorService always returns the same latest indicator
+type staticIndicatorService struct {
+	rateLimitedIndicatorService
+	latest *entities.Indicator
+}
+
+func (s staticIndicatorService) GetLatest(ctx context.Context) (*entities.Indicator, error) {
+	return s.latest, nil
+}
+
+func TestIndicatorHandler_MVRVFromService(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	thresholds := map[string]interface{}{"high": 3.0, "extreme_high": 7.0}
+	handler := &IndicatorHandler{
+		mvrvService: staticIndicatorService{latest: &entities.Indicator{
+			Name:      mvrvIndicatorName,
+			Value:     3.21,
+			RiskLevel: "high",
+			Status:    "HIGH: Approaching cycle top - Consider taking profits",
+			Timestamp: time.Now(),
+			Metadata:  map[string]interface{}{"mvrv_ratio": 2.8, "zscore_thresholds": thresholds},
+		}},
+		logger: testutil.NewTestDB(t).Logger,
+	}
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/v1"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/mvrv", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "3.21", response.Data["value"])
+	assert.Equal(t, 2.8, response.Data["ratio"])
+	assert.Equal(t, "high", response.Data["risk_level"])
+	assert.Equal(t, thresholds, response.Data["thresholds"])
+}
+
+func TestIndicatorHandler_MVRVChartRatioBands(t *testing.T) {
+	bands := map[string]interface{}{"mean": 1.5, "std_dev": 0.4, "minus_2sd": 0.7, "minus_1sd": 1.1, "plus_1sd": 1.9, "plus_2sd": 2.3}
+	handler := &IndicatorHandler{
+		mvrvService: staticIndicatorService{latest: &entities.Indicator{
+			Name:     mvrvIndicatorName,
+			Value:    1.2,
+			Metadata: map[string]interface{}{"ratio_bands": bands},
+		}},
+		logger: testutil.NewTestDB(t).Logger,
+	}
+
+	chart, err := handler.getMVRVChartData(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, bands, chart["ratio_bands"])
+	assert.Equal(t, 1.2, chart["current_zscore"])
+}
+
+func TestIndicatorHandler_MVRVChartStoredHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := make([]entities.MVRVData, 10)
+	for i := range history {
+		history[i] = entities.MVRVData{
+			Date:       start.AddDate(0, 0, i),
+			Price:      40000 + float64(i)*100,
+			MVRVZScore: 0.5 + float64(i)*0.1,
+		}
+	}
+	// Stored newest first and decoded into generic JSON values, as it comes back from storage
+	reversed := make([]entities.MVRVData, len(history))
+	for i, point := range history {
+		reversed[len(history)-1-i] = point
+	}
+	raw, err := json.Marshal(reversed)
+	require.NoError(t, err)
+	var stored []interface{}
+	require.NoError(t, json.Unmarshal(raw, &stored))
+
+	handler := &IndicatorHandler{
+		mvrvService: staticIndicatorService{latest: &entities.Indicator{
+			Name:     mvrvIndicatorName,
+			Value:    1.4,
+			Metadata: map[string]interface{}{entities.MVRVHistoryMetadataKey: stored},
+		}},
+		logger: testutil.NewTestDB(t).Logger,
+	}
+
+	t.Run("Chart reflects the stored history", func(t *testing.T) {
+		chart, err := handler.getMVRVChartData(context.Background())
+
+		require.NoError(t, err)
+		timestamps := chart["timestamps"].([]int64)
+		require.Len(t, timestamps, len(history))
+		assert.Equal(t, start.UnixMilli(), timestamps[0], "history is returned oldest first")
+		assert.Equal(t, start.AddDate(0, 0, 9).UnixMilli(), timestamps[9])
+		assert.InDelta(t, 0.5, chart["zscore_data"].([]float64)[0], 1e-9)
+		assert.InDelta(t, 1.4, chart["zscore_data"].([]float64)[9], 1e-9)
+		assert.Equal(t, 40900.0, chart["price_data"].([]float64)[9])
+	})
+
+	t.Run("Long history is downsampled to the point cap", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/charts/:indicator", handler.GetChartData)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/charts/mvrv?points=5&ma=3", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		chart := response["data"].(map[string]interface{})
+
+		assert.Len(t, chart["timestamps"], 5)
+		assert.Len(t, chart["zscore_data"], 5)
+		assert.Len(t, chart["price_data"], 5)
+		downsampled := chart["downsampled"].(map[string]interface{})
+		assert.Equal(t, 10.0, downsampled["original_points"])
+		zScores := chart["zscore_data"].([]interface{})
+		assert.InDelta(t, 1.4, zScores[len(zScores)-1].(float64), 1e-9, "the latest point is kept")
+	})
+
+	t.Run("Missing history yields empty series rather than made-up ones", func(t *testing.T) {
+		empty := &IndicatorHandler{
+			mvrvService: staticIndicatorService{latest: &entities.Indicator{Name: mvrvIndicatorName, Value: 1.4}},
+			logger:      testutil.NewTestDB(t).Logger,
+		}
+
+		chart, err := empty.getMVRVChartData(context.Background())
+
+		require.NoError(t, err)
+		assert.Empty(t, chart["timestamps"])
+		assert.Empty(t, chart["zscore_data"])
+	})
+}
+
+func TestIndicatorHandler_LatestProvenance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	// Metadata as it comes back from storage, decoded into generic JSON values
+	withProvenance := &entities.Indicator{
+		Name:      "mvrv",
+		Value:     2.4,
+		Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Metadata: map[string]interface{}{
+			"provenance": map[string]interface{}{
+				"sources":             []interface{}{"https://api.coingecko.com/api/v3/coins/bitcoin"},
+				"inputs":              map[string]interface{}{"price": 43000.0, "market_cap": 850000000000.0},
+				"calculation_version": "1",
+				"fallback":            false,
+			},
+		},
+	}
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("GetLatest", mock.Anything, "mvrv").Return(withProvenance, nil)
+	repo.On("GetLatest", mock.Anything, "fear_greed").Return(&entities.Indicator{Name: "fear_greed", Value: 55}, nil)
+
+	newRouter := func(repo *testutil.MockIndicatorRepository) *gin.Engine {
+		deps := &config.Dependencies{
+			Logger: testDB.Logger,
+			Cache:  testutil.NewMockCacheService(),
+		}
+		if repo != nil {
+			deps.IndicatorRepo = repo
+		}
+		router := gin.New()
+		NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+		return router
+	}
+	router := newRouter(repo)
+
+	t.Run("Stored provenance is returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/mvrv/latest/provenance", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response struct {
+			Data struct {
+				Name       string                       `json:"name"`
+				Provenance entities.IndicatorProvenance `json:"provenance"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "mvrv", response.Data.Name)
+		assert.Equal(t, []string{"https://api.coingecko.com/api/v3/coins/bitcoin"}, response.Data.Provenance.Sources)
+		assert.Equal(t, 43000.0, response.Data.Provenance.Inputs["price"])
+		assert.Equal(t, "1", response.Data.Provenance.CalculationVersion)
+		assert.False(t, response.Data.Provenance.Fallback)
+	})
+
+	t.Run("Indicator without provenance", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/fear-greed/latest/provenance", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Storage not configured", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/mvrv/latest/provenance", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestIndicatorHandler_IndicatorDiff(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	earlier := &entities.Indicator{
+		ID: 1, Name: "rhodl", Value: 1.1, RiskLevel: "low", Status: "accumulation zone", CalcVersion: 1,
+		Timestamp: from,
+		Metadata: map[string]interface{}{
+			"band":          "accumulation",
+			"approximation": true,
+			"provenance":    map[string]interface{}{"calculation_version": "1"},
+		},
+	}
+	later := &entities.Indicator{
+		ID: 2, Name: "rhodl", Value: 3.2, RiskLevel: "high", Status: "accumulation zone", CalcVersion: 1,
+		Timestamp: to,
+		Metadata: map[string]interface{}{
+			"band":          "overheated",
+			"approximation": true,
+			"one_week_band": 60000.0,
+			"provenance":    map[string]interface{}{"calculation_version": "2"},
+		},
+	}
+
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("GetNearest", mock.Anything, "rhodl", from).Return(earlier, nil)
+	repo.On("GetNearest", mock.Anything, "rhodl", to).Return(later, nil)
+	repo.On("GetNearest", mock.Anything, "fear_greed", mock.Anything).Return(nil, errors.NotFound("indicator"))
+
+	deps := &config.Dependencies{
+		Logger:        testDB.Logger,
+		Cache:         testutil.NewMockCacheService(),
+		IndicatorRepo: repo,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	type diffResponse struct {
+		Data struct {
+			Name       string                          `json:"name"`
+			SameRecord bool                            `json:"same_record"`
+			Changed    int                             `json:"changed"`
+			Diff       []entities.IndicatorFieldChange `json:"diff"`
+			From       struct {
+				Snapshot entities.Indicator `json:"snapshot"`
+			} `json:"from"`
+		} `json:"data"`
+	}
+
+	t.Run("Changed fields are listed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+			"/api/v1/indicators/rhodl/diff?from=2024-01-01&to=2024-02-01", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response diffResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "rhodl", response.Data.Name)
+		assert.False(t, response.Data.SameRecord)
+		assert.Equal(t, 1.1, response.Data.From.Snapshot.Value)
+
+		changed := make(map[string]entities.IndicatorFieldChange)
+		for _, change := range response.Data.Diff {
+			changed[change.Field] = change
+		}
+		assert.Equal(t, response.Data.Changed, len(response.Data.Diff))
+		assert.ElementsMatch(t,
+			[]string{"value", "risk_level", "metadata.band", "metadata.one_week_band"},
+			keysOf(changed))
+		assert.Equal(t, 1.1, changed["value"].From)
+		assert.Equal(t, 3.2, changed["value"].To)
+		assert.Equal(t, "low", changed["risk_level"].From)
+		assert.Equal(t, "high", changed["risk_level"].To)
+		assert.Equal(t, "overheated", changed["metadata.band"].To)
+		assert.Nil(t, changed["metadata.one_week_band"].From)
+	})
+
+	t.Run("Same snapshot has no changes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+			"/api/v1/indicators/rhodl/diff?from=2024-02-01&to=2024-02-01", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response diffResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Data.SameRecord)
+		assert.Equal(t, 0, response.Data.Changed)
+		assert.Empty(t, response.Data.Diff)
+	})
+
+	t.Run("Missing from", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/rhodl/diff", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid to", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/rhodl/diff?from=2024-01-01&to=yesterday", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("No stored values", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/fear-greed/diff?from=2024-01-01", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// keysOf returns the keys of a field change map
+func keysOf(m map[string]entities.IndicatorFieldChange) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// stubFearGreedService serves a fixed reading and component history and records the
+// requested window
+type stubFearGreedService struct {
+	result  *entities.FearGreedResult
+	history *entities.FearGreedComponentHistory
+	window  entities.TimeRange
+}
+
+func (s *stubFearGreedService) GetFearGreedAnalysis(ctx context.Context) (*entities.FearGreedResult, error) {
+	if s.result == nil {
+		return nil, errors.NotFound("Fear & Greed reading")
+	}
+	return s.result, nil
+}
+
+func (s *stubFearGreedService) GetFearGreedChart(ctx context.Context) (map[string]interface{}, error) {
+	return nil, errors.NotFound("Fear & Greed history")
+}
+
+func (s *stubFearGreedService) GetComponentHistory(ctx context.Context, window entities.TimeRange) (*entities.FearGreedComponentHistory, error) {
+	s.window = window
+	return s.history, nil
+}
+
+func (s *stubFearGreedService) AnalyzeSentiment(ctx context.Context, value int) string {
+	return ""
+}
+
+func TestIndicatorHandler_FearGreedComponents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	volatility, momentum := 35, 45
+	service := &stubFearGreedService{history: &entities.FearGreedComponentHistory{
+		Timestamps: []int64{1709251200000},
+		Values:     []int{40},
+		Components: map[string][]*int{"volatility": {&volatility}, "momentum": {&momentum}},
+	}}
+	deps := &config.Dependencies{
+		Logger:           testDB.Logger,
+		Cache:            testutil.NewMockCacheService(),
+		FearGreedService: service,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/fear-greed/components?period=7d", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Data struct {
+			Period     string             `json:"period"`
+			Available  bool               `json:"available"`
+			Timestamps []int64            `json:"timestamps"`
+			Components map[string][]*int `json:"components"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "7d", response.Data.Period)
+	assert.True(t, response.Data.Available)
+	assert.Equal(t, []int64{1709251200000}, response.Data.Timestamps)
+	assert.Equal(t, 35, *response.Data.Components["volatility"][0])
+	assert.InDelta(t, 7*24, service.window.To.Sub(service.window.From).Hours(), 1)
+}
+
+// stubChangeService reports a fixed 24h change
+type stubChangeService struct {
+	change24h float64
+}
+
+func (s *stubChangeService) CalculateChanges(ctx context.Context, name string, current float64, at time.Time) (*entities.IndicatorChanges, error) {
+	change := s.change24h
+	return &entities.IndicatorChanges{Change24h: &change}, nil
+}
+
+func TestIndicatorHandler_FearGreedETagCoversChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	changes := &stubChangeService{change24h: 5}
+	deps := &config.Dependencies{
+		Logger: testDB.Logger,
+		Cache:  testutil.NewMockCacheService(),
+		FearGreedService: &stubFearGreedService{result: &entities.FearGreedResult{
+			CurrentValue: 72,
+			LastUpdated:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		ChangeService: changes,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	fetch := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/indicators/fear-greed", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := fetch("")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, http.StatusNotModified, fetch(etag).Code)
+
+	// Same reading, but the 24h comparison moved on
+	changes.change24h = 3
+	second := fetch(etag)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.NotEqual(t, etag, second.Header().Get("ETag"))
+}
+
+func TestIndicatorHandler_DominanceETagCoversChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	changes := &stubChangeService{change24h: 0.4}
+	deps := &config.Dependencies{
+		Logger: testDB.Logger,
+		Cache:  testutil.NewMockCacheService(),
+		DominanceService: staticDominanceService{result: &entities.DominanceResult{
+			CurrentDominance: 61.25,
+			LastUpdated:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		ChangeService: changes,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	fetch := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/indicators/dominance", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := fetch("")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, http.StatusNotModified, fetch(etag).Code)
+
+	// Same reading, but the 24h comparison moved on
+	changes.change24h = -0.2
+	second := fetch(etag)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.NotEqual(t, etag, second.Header().Get("ETag"))
+}
+
+func TestIndicatorHandler_LatestIndicators(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	stored := []entities.Indicator{
+		{Name: "mvrv", Type: "onchain", Value: 2.1, Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Name: "fear_greed", Type: "sentiment", Value: 72, Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	repo := &testutil.MockIndicatorRepository{}
+	repo.On("GetLatestForTypes", mock.Anything, []string{"onchain", "sentiment"}).Return(stored, nil).Once()
+
+	deps := &config.Dependencies{
+		Logger:        testDB.Logger,
+		Cache:         testutil.NewMockCacheService(),
+		IndicatorRepo: repo,
+	}
+	router := gin.New()
+	NewIndicatorHandler(deps).RegisterRoutes(router.Group("/api/v1"))
+
+	fetch := func(query string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/bulk"+query, nil))
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	t.Run("one query for every type", func(t *testing.T) {
+		code, response := fetch("?types=onchain,%20sentiment,onchain")
+		require.Equal(t, http.StatusOK, code, response)
+
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"onchain", "sentiment"}, data["types"])
+		assert.Equal(t, float64(2), data["count"])
+		assert.Len(t, data["indicators"], 2)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("types are required", func(t *testing.T) {
+		code, _ := fetch("?types=,")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("too many types", func(t *testing.T) {
+		query := "?types=type0"
+		for i := 1; i <= maxBulkTypes; i++ {
+			query += ",type" + strconv.Itoa(i)
+		}
+		code, _ := fetch(query)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+// calculatingIndicatorService returns a new value from every Calculate and records whether
+// the call bypassed the cache
+type calculatingIndicatorService struct {
+	rateLimitedIndicatorService
+	calls    *int
+	bypassed *bool
+}
+
+func (s calculatingIndicatorService) Calculate(ctx context.Context, params map[string]interface{}) (*entities.Indicator, error) {
+	*s.calls++
+	*s.bypassed = cache.Bypassed(ctx)
+	return &entities.Indicator{Name: "rhodl", Type: "onchain", Value: float64(*s.calls), Timestamp: time.Now()}, nil
+}
+
+func TestIndicatorHandler_RecalculateIndicator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var calls int
+	var bypassed bool
+	deps := &config.Dependencies{
+		Logger:       logger.New("test"),
+		Cache:        testutil.NewMockCacheService(),
+		RHODLService: calculatingIndicatorService{calls: &calls, bypassed: &bypassed},
+	}
+	router := gin.New()
+	router.POST("/api/v1/indicators/:name/recalculate", NewIndicatorHandler(deps).RecalculateIndicator)
+
+	recalculate := func(name string) (int, entities.Indicator) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/indicators/"+name+"/recalculate", nil)
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data entities.Indicator `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+		return w.Code, response.Data
+	}
+
+	code, indicator := recalculate("rhodl")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, calls)
+	assert.True(t, bypassed, "recalculation bypasses cached upstream data")
+	assert.Equal(t, 1.0, indicator.Value)
+
+	// Every request calculates again rather than serving the previous value
+	code, indicator = recalculate("rhodl")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2.0, indicator.Value)
+
+	code, _ = recalculate("etf-flow")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, 2, calls)
+}
+
+// staticDominanceService returns a fixed dominance analysis or error
+type staticDominanceService struct {
+	result *entities.DominanceResult
+	err    error
+}
+
+func (s staticDominanceService) GetDominanceAnalysis(ctx context.Context) (*entities.DominanceResult, error) {
+	return s.result, s.err
+}
+
+func (s staticDominanceService) GetDominanceChart(ctx context.Context) (map[string]interface{}, error) {
+	return nil, s.err
+}
+
+func (s staticDominanceService) DetectAltSeason(ctx context.Context, dominance float64) bool {
+	return false
+}
+
+func TestIndicatorHandler_DominanceFromService(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	get := func(service staticDominanceService) (int, map[string]interface{}) {
+		handler := &IndicatorHandler{dominanceService: service, logger: logger.New("test")}
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("/api/v1"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/indicators/dominance", nil))
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response.Data
+	}
+
+	t.Run("Stored history is analysed", func(t *testing.T) {
+		code, data := get(staticDominanceService{result: &entities.DominanceResult{
+			CurrentDominance: 61.25,
+			Change24h:        0.5,
+			RiskLevel:        "medium",
+			Trend:            "increasing",
+			LastUpdated:      time.Now(),
+		}})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "61.2%", data["value"])
+		assert.Equal(t, "+0.5%", data["change"])
+		assert.Equal(t, "increasing", data["details"].(map[string]interface{})["trend"])
+	})
+
+	t.Run("No stored history falls back to mock data", func(t *testing.T) {
+		code, data := get(staticDominanceService{err: errors.NotFound("dominance_data")})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "56.8%", data["value"])
+		assert.Equal(t, "Use /api/v1/market/dominance for real data", data["status"])
+	})
+
+	t.Run("Other errors are returned", func(t *testing.T) {
+		code, _ := get(staticDominanceService{err: errors.Internal("database unavailable", nil)})
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+}
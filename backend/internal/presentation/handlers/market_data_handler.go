@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"crypto-indicator-dashboard/internal/domain/entities"
 	"crypto-indicator-dashboard/internal/domain/services"
 	"crypto-indicator-dashboard/internal/infrastructure/external"
@@ -11,39 +14,72 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	defaultSummaryCount = 10
+	maxSummaryCount     = 50
+	// marketSummaryCacheKeyPrefix is followed by the requested count
+	marketSummaryCacheKeyPrefix = "market_summary_"
+)
+
 // MarketDataHandler handles market data HTTP requests
 type MarketDataHandler struct {
 	marketDataService   services.MarketDataService
 	coinMarketCapClient *external.CoinMarketCapClient
 	tradingViewScraper  *external.TradingViewScraper
+	symbolMetadata      services.SymbolMetadataService
+	cache               services.CacheService
+	summaryTTL          time.Duration
 	logger              logger.Logger
 }
 
-// NewMarketDataHandler creates a new market data handler
+// marketSummary is the assembled GET /market/summary payload
+type marketSummary struct {
+	TotalMarketCap      float64                         `json:"total_market_cap"`
+	TotalVolume24h      float64                         `json:"total_volume_24h"`
+	BitcoinDominance    *entities.BitcoinDominance      `json:"bitcoin_dominance"`
+	TopCryptocurrencies map[string]*entities.CryptoPrice `json:"top_cryptocurrencies"`
+	MarketTrend         string                          `json:"market_trend"`
+	CryptoCount         int                             `json:"crypto_count"`
+}
+
+// NewMarketDataHandler creates a new market data handler. Market summaries are cached
+// for summaryTTL when cache is set and summaryTTL is positive. Requested symbols are
+// checked against the known listing when symbolMetadata is set.
 func NewMarketDataHandler(
 	marketDataService services.MarketDataService,
 	coinMarketCapClient *external.CoinMarketCapClient,
 	tradingViewScraper *external.TradingViewScraper,
+	symbolMetadata services.SymbolMetadataService,
+	cache services.CacheService,
+	summaryTTL time.Duration,
 	logger logger.Logger,
 ) *MarketDataHandler {
 	return &MarketDataHandler{
 		marketDataService:   marketDataService,
 		coinMarketCapClient: coinMarketCapClient,
 		tradingViewScraper:  tradingViewScraper,
+		symbolMetadata:      symbolMetadata,
+		cache:               cache,
+		summaryTTL:          summaryTTL,
 		logger:              logger,
 	}
 }
 
 // GetCryptoPrices handles GET /api/v1/market/prices
 func (h *MarketDataHandler) GetCryptoPrices(c *gin.Context) {
-	symbolsParam := c.Query("symbols")
 	var symbols []string
-	
-	if symbolsParam != "" {
-		symbols = strings.Split(symbolsParam, ",")
-		// Clean up whitespace
-		for i, symbol := range symbols {
-			symbols[i] = strings.TrimSpace(strings.ToUpper(symbol))
+
+	if symbolsParam, ok := c.GetQuery("symbols"); ok {
+		symbols = parseSymbolList(symbolsParam)
+		if len(symbols) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid symbols",
+				"message": "symbols must list at least one ticker symbol, e.g. ?symbols=BTC,ETH; omit it for the default list",
+			})
+			return
+		}
+		if h.rejectUnknownSymbols(c, symbols) {
+			return
 		}
 	} else {
 		// Default symbols
@@ -52,9 +88,12 @@ func (h *MarketDataHandler) GetCryptoPrices(c *gin.Context) {
 
 	h.logger.Info("Fetching crypto prices", "symbols", symbols)
 
-	prices, err := h.marketDataService.GetCryptoPrices(c.Request.Context(), symbols)
+	prices, unresolved, err := h.marketDataService.GetCryptoPrices(c.Request.Context(), symbols)
 	if err != nil {
 		h.logger.Error("Failed to get crypto prices", "error", err, "symbols", symbols)
+		if respondRateLimited(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch crypto prices",
 			"message": err.Error(),
@@ -62,13 +101,96 @@ func (h *MarketDataHandler) GetCryptoPrices(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    prices,
-		"count":   len(prices),
+	if unresolved == nil {
+		unresolved = []string{}
+	}
+	if len(unresolved) > 0 {
+		h.logger.Warn("Some symbols could not be resolved", "unresolved", unresolved)
+	}
+
+	RespondOK(c, prices, gin.H{
+		"count":      len(prices),
+		"resolved":   resolvedSymbols(symbols, unresolved),
+		"unresolved": unresolved,
 	})
 }
 
+// parseSymbolList splits a comma-separated ?symbols= value into upper-case symbols,
+// dropping blank entries and duplicates while keeping the first occurrence's order
+func parseSymbolList(raw string) []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, symbol := range strings.Split(raw, ",") {
+		symbol = strings.TrimSpace(strings.ToUpper(symbol))
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// rejectUnknownSymbols writes a 404 and returns true when any symbol is not a listed
+// cryptocurrency. When the listing cannot be loaded the symbols are let through, so an
+// unavailable listing never blocks price requests.
+func (h *MarketDataHandler) rejectUnknownSymbols(c *gin.Context, symbols []string) bool {
+	if h.symbolMetadata == nil {
+		return false
+	}
+
+	unknown, err := h.symbolMetadata.UnknownSymbols(c.Request.Context(), symbols)
+	if err != nil {
+		h.logger.Warn("Failed to validate symbols, skipping validation", "error", err)
+		return false
+	}
+	if len(unknown) == 0 {
+		return false
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{
+		"error":           "Unknown symbol",
+		"message":         fmt.Sprintf("%s is not a listed cryptocurrency symbol; use ticker symbols such as BTC or ETH", strings.Join(unknown, ", ")),
+		"unknown_symbols": unknown,
+	})
+	return true
+}
+
+// resolvedSymbols returns the requested symbols that are not in unresolved, in request order
+func resolvedSymbols(symbols, unresolved []string) []string {
+	skip := make(map[string]bool, len(unresolved))
+	for _, symbol := range unresolved {
+		skip[symbol] = true
+	}
+
+	resolved := []string{}
+	for _, symbol := range symbols {
+		if !skip[symbol] {
+			skip[symbol] = true
+			resolved = append(resolved, symbol)
+		}
+	}
+	return resolved
+}
+
+// GetMarketMetrics handles GET /api/v1/market/metrics
+func (h *MarketDataHandler) GetMarketMetrics(c *gin.Context) {
+	metrics, err := h.marketDataService.GetLatestMarketMetrics(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get market metrics", "error", err)
+		if respondRateLimited(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch market metrics",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	RespondOK(c, metrics, nil)
+}
+
 // GetBitcoinDominance handles GET /api/v1/market/dominance
 func (h *MarketDataHandler) GetBitcoinDominance(c *gin.Context) {
 	h.logger.Info("Fetching Bitcoin dominance")
@@ -76,6 +198,9 @@ func (h *MarketDataHandler) GetBitcoinDominance(c *gin.Context) {
 	dominance, err := h.marketDataService.GetBitcoinDominance(c.Request.Context())
 	if err != nil {
 		h.logger.Error("Failed to get Bitcoin dominance", "error", err)
+		if respondRateLimited(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch Bitcoin dominance",
 			"message": err.Error(),
@@ -83,26 +208,35 @@ func (h *MarketDataHandler) GetBitcoinDominance(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    dominance,
-	})
+	RespondOK(c, dominance, nil)
 }
 
 // GetMarketSummary handles GET /api/v1/market/summary
 func (h *MarketDataHandler) GetMarketSummary(c *gin.Context) {
 	h.logger.Info("Fetching market summary")
+	ctx := c.Request.Context()
 
 	// Get top cryptocurrencies
-	countParam := c.DefaultQuery("count", "10")
+	countParam := c.DefaultQuery("count", strconv.Itoa(defaultSummaryCount))
 	count, err := strconv.Atoi(countParam)
-	if err != nil || count <= 0 || count > 50 {
-		count = 10
+	if err != nil || count <= 0 || count > maxSummaryCount {
+		count = defaultSummaryCount
 	}
 
-	prices, err := h.marketDataService.GetTopCryptoPrices(c.Request.Context(), count)
+	if h.summaryCacheEnabled() {
+		var cached marketSummary
+		if err := h.cache.Get(ctx, marketSummaryCacheKey(count), &cached); err == nil {
+			RespondOK(c, cached, nil)
+			return
+		}
+	}
+
+	summary, err := h.buildMarketSummary(ctx, count)
 	if err != nil {
 		h.logger.Error("Failed to get crypto prices for summary", "error", err)
+		if respondRateLimited(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch market summary",
 			"message": err.Error(),
@@ -110,8 +244,26 @@ func (h *MarketDataHandler) GetMarketSummary(c *gin.Context) {
 		return
 	}
 
+	RespondOK(c, summary, nil)
+}
+
+// WarmSummaryCache builds the default market summary, filling the summary cache and the
+// price caches behind it, so the first request after startup does not wait on upstream APIs
+func (h *MarketDataHandler) WarmSummaryCache(ctx context.Context) error {
+	_, err := h.buildMarketSummary(ctx, defaultSummaryCount)
+	return err
+}
+
+// buildMarketSummary assembles the summary of the top count assets and caches it when the
+// summary cache is enabled
+func (h *MarketDataHandler) buildMarketSummary(ctx context.Context, count int) (*marketSummary, error) {
+	prices, err := h.marketDataService.GetTopCryptoPrices(ctx, count)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get Bitcoin dominance
-	dominance, err := h.marketDataService.GetBitcoinDominance(c.Request.Context())
+	dominance, err := h.marketDataService.GetBitcoinDominance(ctx)
 	if err != nil {
 		h.logger.Warn("Failed to get Bitcoin dominance for summary", "error", err)
 		// Continue without dominance data
@@ -124,30 +276,46 @@ func (h *MarketDataHandler) GetMarketSummary(c *gin.Context) {
 		totalVolume24h += price.Volume24h
 	}
 
-	summary := map[string]interface{}{
-		"total_market_cap":      totalMarketCap,
-		"total_volume_24h":      totalVolume24h,
-		"bitcoin_dominance":     dominance,
-		"top_cryptocurrencies":  prices,
-		"market_trend":          determineTrendFromPrices(prices),
-		"crypto_count":          len(prices),
+	summary := &marketSummary{
+		TotalMarketCap:      totalMarketCap,
+		TotalVolume24h:      totalVolume24h,
+		BitcoinDominance:    dominance,
+		TopCryptocurrencies: prices,
+		MarketTrend:         determineTrendFromPrices(prices),
+		CryptoCount:         len(prices),
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    summary,
-	})
+	if h.summaryCacheEnabled() {
+		if err := h.cache.Set(ctx, marketSummaryCacheKey(count), summary, h.summaryTTL); err != nil {
+			h.logger.Warn("Failed to cache market summary", "error", err, "count", count)
+		}
+	}
+
+	return summary, nil
 }
 
 // GetSinglePrice handles GET /api/v1/market/price/:symbol
 func (h *MarketDataHandler) GetSinglePrice(c *gin.Context) {
-	symbol := strings.ToUpper(c.Param("symbol"))
-	
+	symbol := strings.TrimSpace(strings.ToUpper(c.Param("symbol")))
+	if symbol == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid symbol",
+			"message": "symbol must be a ticker symbol such as BTC",
+		})
+		return
+	}
+
 	h.logger.Info("Fetching single price", "symbol", symbol)
+	if h.rejectUnknownSymbols(c, []string{symbol}) {
+		return
+	}
 
-	prices, err := h.marketDataService.GetCryptoPrices(c.Request.Context(), []string{symbol})
+	prices, _, err := h.marketDataService.GetCryptoPrices(c.Request.Context(), []string{symbol})
 	if err != nil {
 		h.logger.Error("Failed to get single price", "error", err, "symbol", symbol)
+		if respondRateLimited(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch price",
 			"message": err.Error(),
@@ -164,30 +332,37 @@ func (h *MarketDataHandler) GetSinglePrice(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    price,
-	})
+	RespondOK(c, price, nil)
 }
 
-// RefreshMarketData handles POST /api/v1/market/refresh
+// RefreshMarketData handles POST /api/v1/market/refresh. The response lists the parts
+// that refreshed and why the others failed; it is an error only when all of them failed.
 func (h *MarketDataHandler) RefreshMarketData(c *gin.Context) {
 	h.logger.Info("Refreshing market data")
 
-	err := h.marketDataService.RefreshAllMarketData(c.Request.Context())
+	result, err := h.marketDataService.RefreshAllMarketData(c.Request.Context())
+	h.invalidateMarketSummaries(c.Request.Context())
 	if err != nil {
 		h.logger.Error("Failed to refresh market data", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
+		if respondRateLimited(c, err) {
+			return
+		}
+		body := gin.H{
 			"error":   "Failed to refresh market data",
 			"message": err.Error(),
-		})
+		}
+		if result != nil {
+			body["failed"] = result.Failed
+		}
+		c.JSON(http.StatusInternalServerError, body)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Market data refreshed successfully",
-	})
+	message := "Market data refreshed successfully"
+	if result.Partial() {
+		message = "Market data partially refreshed"
+	}
+	RespondOK(c, result, gin.H{"message": message, "partial": result.Partial()})
 }
 
 // GetHealthCheck handles GET /api/v1/market/health
@@ -197,8 +372,8 @@ func (h *MarketDataHandler) GetHealthCheck(c *gin.Context) {
 	healthResults := h.marketDataService.HealthCheck(c.Request.Context())
 	
 	allHealthy := true
-	for _, err := range healthResults {
-		if err != nil {
+	for _, result := range healthResults {
+		if result.Err != nil {
 			allHealthy = false
 			break
 		}
@@ -214,19 +389,28 @@ func (h *MarketDataHandler) GetHealthCheck(c *gin.Context) {
 		"sources": make(map[string]interface{}),
 	}
 
-	for source, err := range healthResults {
-		if err != nil {
+	for source, result := range healthResults {
+		if result.Err != nil {
 			response["sources"].(map[string]interface{})[source] = map[string]interface{}{
-				"healthy": false,
-				"error":   err.Error(),
+				"healthy":    false,
+				"error":      result.Err.Error(),
+				"latency_ms": result.LatencyMs(),
 			}
 		} else {
 			response["sources"].(map[string]interface{})[source] = map[string]interface{}{
-				"healthy": true,
+				"healthy":    true,
+				"latency_ms": result.LatencyMs(),
 			}
 		}
 	}
 
+	// Include scraper diagnostics so markup drift is visible to operators
+	if h.tradingViewScraper != nil {
+		if diagnostics := h.tradingViewScraper.LastScrapeDiagnostics(); diagnostics != nil {
+			response["scraper_diagnostics"] = diagnostics
+		}
+	}
+
 	c.JSON(status, response)
 }
 
@@ -237,37 +421,40 @@ func (h *MarketDataHandler) RegisterRoutes(router *gin.RouterGroup) {
 		market.GET("/prices", h.GetCryptoPrices)
 		market.GET("/price/:symbol", h.GetSinglePrice)
 		market.GET("/dominance", h.GetBitcoinDominance)
+		market.GET("/metrics", h.GetMarketMetrics)
 		market.GET("/summary", h.GetMarketSummary)
 		market.POST("/refresh", h.RefreshMarketData)
 		market.GET("/health", h.GetHealthCheck)
 	}
 }
 
-// Helper function to determine market trend based on price changes
-func determineTrendFromPrices(prices map[string]*entities.CryptoPrice) string {
-	if len(prices) == 0 {
-		return "unknown"
-	}
-
-	var totalChange24h float64
-	count := 0
+// summaryCacheEnabled reports whether assembled market summaries are cached
+func (h *MarketDataHandler) summaryCacheEnabled() bool {
+	return h.cache != nil && h.summaryTTL > 0
+}
 
-	for _, price := range prices {
-		totalChange24h += price.PercentChange24h
-		count++
+// invalidateMarketSummaries drops the cached summary for every allowed count
+func (h *MarketDataHandler) invalidateMarketSummaries(ctx context.Context) {
+	if !h.summaryCacheEnabled() {
+		return
 	}
-
-	if count == 0 {
-		return "unknown"
+	for count := 1; count <= maxSummaryCount; count++ {
+		if err := h.cache.Delete(ctx, marketSummaryCacheKey(count)); err != nil {
+			h.logger.Warn("Failed to invalidate market summary cache", "error", err, "count", count)
+		}
 	}
+}
 
-	avgChange := totalChange24h / float64(count)
-	
-	if avgChange > 3 {
-		return "bullish"
-	} else if avgChange < -3 {
-		return "bearish"
-	} else {
-		return "sideways"
+// marketSummaryCacheKey returns the cache key of the summary for count assets
+func marketSummaryCacheKey(count int) string {
+	return fmt.Sprintf("%s%d", marketSummaryCacheKeyPrefix, count)
+}
+
+// determineTrendFromPrices classifies the market trend from the average 24h change of prices
+func determineTrendFromPrices(prices map[string]*entities.CryptoPrice) string {
+	avgChange, ok := entities.AverageChange24h(prices)
+	if !ok {
+		return entities.MarketTrendUnknown
 	}
-}
\ No newline at end of file
+	return entities.ClassifyMarketTrend(avgChange)
+}
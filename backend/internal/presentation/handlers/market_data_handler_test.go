@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketDataHandler_SummaryCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	service := &testutil.MockMarketDataService{}
+	service.On("GetTopCryptoPrices", mock.Anything, 5).Return(map[string]*entities.CryptoPrice{
+		"BTC": {Symbol: "BTC", Price: 67000, MarketCap: 1.3e12, Volume24h: 3e10, PercentChange24h: 2.5},
+		"ETH": {Symbol: "ETH", Price: 3500, MarketCap: 4.2e11, Volume24h: 1.5e10, PercentChange24h: 1.5},
+	}, nil)
+	service.On("GetBitcoinDominance", mock.Anything).Return(&entities.BitcoinDominance{CurrentDominance: 54.2}, nil)
+	service.On("RefreshAllMarketData", mock.Anything).Return(&entities.MarketDataRefresh{Refreshed: []string{"prices", "dominance", "market_metrics"}}, nil)
+
+	router := gin.New()
+	handler := NewMarketDataHandler(service, nil, nil, nil, cache.NewCacheService(nil, log), time.Minute, log)
+	handler.RegisterRoutes(router.Group("/api/v1"))
+
+	request := func(method, path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := request("GET", "/api/v1/market/summary?count=5")
+	require.Equal(t, http.StatusOK, first.Code)
+	second := request("GET", "/api/v1/market/summary?count=5")
+	require.Equal(t, http.StatusOK, second.Code)
+
+	assert.JSONEq(t, first.Body.String(), second.Body.String(), "cached summary should match the computed one")
+	service.AssertNumberOfCalls(t, "GetTopCryptoPrices", 1)
+	service.AssertNumberOfCalls(t, "GetBitcoinDominance", 1)
+
+	var response struct {
+		Data marketSummary `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Data.CryptoCount)
+	assert.Equal(t, 54.2, response.Data.BitcoinDominance.CurrentDominance)
+
+	// Refreshing market data invalidates the cached summary
+	require.Equal(t, http.StatusOK, request("POST", "/api/v1/market/refresh").Code)
+	require.Equal(t, http.StatusOK, request("GET", "/api/v1/market/summary?count=5").Code)
+	service.AssertNumberOfCalls(t, "GetTopCryptoPrices", 2)
+}
+
+func TestMarketDataHandler_SummaryCacheDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	service := &testutil.MockMarketDataService{}
+	service.On("GetTopCryptoPrices", mock.Anything, 10).Return(map[string]*entities.CryptoPrice{}, nil)
+	service.On("GetBitcoinDominance", mock.Anything).Return(nil, assert.AnError)
+
+	router := gin.New()
+	NewMarketDataHandler(service, nil, nil, nil, cache.NewCacheService(nil, log), 0, log).RegisterRoutes(router.Group("/api/v1"))
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("GET", "/api/v1/market/summary", nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	service.AssertNumberOfCalls(t, "GetTopCryptoPrices", 2)
+}
+
+func TestMarketDataHandler_GetCryptoPricesUnresolved(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	service := &testutil.MockMarketDataService{}
+	service.On("GetCryptoPrices", mock.Anything, []string{"BTC", "NOTACOIN", "ETH"}).Return(map[string]*entities.CryptoPrice{
+		"BTC": {Symbol: "BTC", Price: 67000},
+		"ETH": {Symbol: "ETH", Price: 3500},
+	}, []string{"NOTACOIN"}, nil)
+
+	router := gin.New()
+	NewMarketDataHandler(service, nil, nil, nil, nil, 0, log).RegisterRoutes(router.Group("/api/v1"))
+
+	req, err := http.NewRequest("GET", "/api/v1/market/prices?symbols=btc,%20notacoin,eth", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Meta struct {
+			Count      int      `json:"count"`
+			Resolved   []string `json:"resolved"`
+			Unresolved []string `json:"unresolved"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Meta.Count)
+	assert.Equal(t, []string{"BTC", "ETH"}, response.Meta.Resolved)
+	assert.Equal(t, []string{"NOTACOIN"}, response.Meta.Unresolved)
+}
+
+func TestMarketDataHandler_GetCryptoPricesSymbolList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	service := &testutil.MockMarketDataService{}
+	service.On("GetCryptoPrices", mock.Anything, []string{"BTC", "ETH"}).Return(map[string]*entities.CryptoPrice{
+		"BTC": {Symbol: "BTC", Price: 67000},
+		"ETH": {Symbol: "ETH", Price: 3500},
+	}, []string{}, nil)
+
+	router := gin.New()
+	NewMarketDataHandler(service, nil, nil, nil, nil, 0, log).RegisterRoutes(router.Group("/api/v1"))
+
+	t.Run("Empty and whitespace-only lists are rejected", func(t *testing.T) {
+		for _, query := range []string{"?symbols=", "?symbols=%20%20", "?symbols=,%20,", "?symbols=%09"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/market/prices"+query, nil))
+			require.Equal(t, http.StatusBadRequest, w.Code, query)
+
+			var response struct {
+				Error string `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "Invalid symbols", response.Error)
+		}
+		service.AssertNotCalled(t, "GetCryptoPrices", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Duplicate symbols are fetched once", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/market/prices?symbols=btc,ETH,%20BTC,,eth", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response struct {
+			Meta struct {
+				Resolved []string `json:"resolved"`
+			} `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []string{"BTC", "ETH"}, response.Meta.Resolved)
+		service.AssertCalled(t, "GetCryptoPrices", mock.Anything, []string{"BTC", "ETH"})
+	})
+
+	t.Run("Whitespace-only single symbol is rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/market/price/%20", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// stubSymbolMetadata knows only the symbols in known
+type stubSymbolMetadata struct {
+	known map[string]bool
+}
+
+func (s *stubSymbolMetadata) GetMetadata(ctx context.Context, symbols []string) (map[string]*entities.SymbolMetadata, error) {
+	return map[string]*entities.SymbolMetadata{}, nil
+}
+
+func (s *stubSymbolMetadata) UnknownSymbols(ctx context.Context, symbols []string) ([]string, error) {
+	var unknown []string
+	for _, symbol := range symbols {
+		if !s.known[symbol] {
+			unknown = append(unknown, symbol)
+		}
+	}
+	return unknown, nil
+}
+
+func TestMarketDataHandler_SymbolValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	service := &testutil.MockMarketDataService{}
+	service.On("GetCryptoPrices", mock.Anything, []string{"BTC"}).Return(map[string]*entities.CryptoPrice{
+		"BTC": {Symbol: "BTC", Price: 67000},
+	}, []string{}, nil)
+
+	router := gin.New()
+	metadata := &stubSymbolMetadata{known: map[string]bool{"BTC": true, "ETH": true}}
+	NewMarketDataHandler(service, nil, nil, metadata, nil, 0, log).RegisterRoutes(router.Group("/api/v1"))
+
+	t.Run("Known symbol is fetched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/market/price/btc", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		service.AssertNumberOfCalls(t, "GetCryptoPrices", 1)
+	})
+
+	t.Run("Unknown symbol is rejected without an upstream call", func(t *testing.T) {
+		for _, path := range []string{"/api/v1/market/price/notacoin", "/api/v1/market/prices?symbols=BTC,NOTACOIN"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, http.StatusNotFound, w.Code, path)
+
+			var response struct {
+				Message        string   `json:"message"`
+				UnknownSymbols []string `json:"unknown_symbols"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, []string{"NOTACOIN"}, response.UnknownSymbols)
+			assert.Contains(t, response.Message, "NOTACOIN")
+		}
+		service.AssertNumberOfCalls(t, "GetCryptoPrices", 1)
+	})
+}
+
+func TestMarketDataHandler_RefreshReportsParts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	refresh := func(result *entities.MarketDataRefresh, err error) *httptest.ResponseRecorder {
+		service := &testutil.MockMarketDataService{}
+		service.On("RefreshAllMarketData", mock.Anything).Return(result, err)
+
+		router := gin.New()
+		NewMarketDataHandler(service, nil, nil, nil, cache.NewCacheService(nil, log), time.Minute, log).
+			RegisterRoutes(router.Group("/api/v1"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/market/refresh", nil))
+		return w
+	}
+
+	t.Run("Partial refresh succeeds", func(t *testing.T) {
+		w := refresh(&entities.MarketDataRefresh{
+			Refreshed: []string{"prices"},
+			Failed:    map[string]string{"dominance": "upstream down"},
+		}, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data entities.MarketDataRefresh `json:"data"`
+			Meta map[string]interface{}     `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []string{"prices"}, response.Data.Refreshed)
+		assert.Equal(t, "upstream down", response.Data.Failed["dominance"])
+		assert.Equal(t, true, response.Meta["partial"])
+	})
+
+	t.Run("Total failure lists every part", func(t *testing.T) {
+		failed := map[string]string{"prices": "down", "dominance": "down", "market_metrics": "down"}
+		w := refresh(&entities.MarketDataRefresh{Refreshed: []string{}, Failed: failed}, assert.AnError)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+
+		var response struct {
+			Failed map[string]string `json:"failed"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, failed, response.Failed)
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tickerSymbolPattern matches trading pairs such as BTCUSDT
+var tickerSymbolPattern = regexp.MustCompile(`^[A-Z0-9]{5,20}$`)
+
+const (
+	// tickerBufferSize is how many upstream tickers may queue while a client catches up
+	tickerBufferSize = 16
+	// defaultStreamHeartbeat is used when no positive heartbeat interval is configured
+	defaultStreamHeartbeat = 15 * time.Second
+)
+
+// MarketStreamHandler streams live tickers to clients as server-sent events
+type MarketStreamHandler struct {
+	source       services.TickerStream
+	heartbeat    time.Duration
+	maxPerClient int
+	logger       logger.Logger
+
+	mu     sync.Mutex
+	active map[string]int
+}
+
+// NewMarketStreamHandler creates a stream handler that sends a heartbeat event every
+// heartbeat and allows each client at most maxPerClient concurrent streams
+func NewMarketStreamHandler(source services.TickerStream, heartbeat time.Duration, maxPerClient int, logger logger.Logger) *MarketStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultStreamHeartbeat
+	}
+	return &MarketStreamHandler{
+		source:       source,
+		heartbeat:    heartbeat,
+		maxPerClient: maxPerClient,
+		logger:       logger.With("handler", "market_stream"),
+		active:       make(map[string]int),
+	}
+}
+
+// RegisterRoutes registers the market stream routes
+func (h *MarketStreamHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/market/stream/:symbol", h.StreamTicker)
+}
+
+// StreamTicker relays live tickers for a trading pair as `ticker` events, with a
+// `heartbeat` event whenever the stream is otherwise idle for the heartbeat interval. The
+// stream ends when the client disconnects.
+func (h *MarketStreamHandler) StreamTicker(c *gin.Context) {
+	symbol := strings.ToUpper(c.Param("symbol"))
+	if !tickerSymbolPattern.MatchString(symbol) {
+		respondError(c, h.logger, errors.Validation("Invalid symbol", "symbol must be a trading pair such as BTCUSDT"))
+		return
+	}
+
+	if h.source == nil {
+		respondUnavailable(c, "Ticker streaming")
+		return
+	}
+
+	client := c.ClientIP()
+	if !h.acquire(client) {
+		respondError(c, h.logger, errors.RateLimit(fmt.Sprintf("At most %d concurrent streams per client", h.maxPerClient)))
+		return
+	}
+	defer h.release(client)
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	log := h.logger.WithContext(ctx)
+	log.Info("Ticker stream opened", "symbol", symbol, "client", client)
+
+	tickers := make(chan entities.PriceTicker, tickerBufferSize)
+	go func() {
+		if err := h.source.Stream(ctx, symbol, tickers); err != nil && ctx.Err() == nil {
+			log.Error("Ticker stream source failed", "symbol", symbol, "error", err)
+		}
+	}()
+
+	// Streams outlive the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Debug("Could not clear write deadline for ticker stream", "error", err)
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case ticker := <-tickers:
+			c.SSEvent("ticker", ticker)
+			heartbeat.Reset(h.heartbeat)
+		case now := <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"timestamp": now.UTC()})
+		}
+		return true
+	})
+
+	log.Info("Ticker stream closed", "symbol", symbol, "client", client)
+}
+
+// acquire reserves a stream slot for client, reporting false when it has none left
+func (h *MarketStreamHandler) acquire(client string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.maxPerClient > 0 && h.active[client] >= h.maxPerClient {
+		return false
+	}
+	h.active[client]++
+	return true
+}
+
+// release frees a slot taken by acquire
+func (h *MarketStreamHandler) release(client string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.active[client]--; h.active[client] <= 0 {
+		delete(h.active, client)
+	}
+}
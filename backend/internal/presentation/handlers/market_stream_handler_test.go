@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTickerStream sends its tickers for the requested symbol, then holds the stream open
+// until the client goes away
+type fakeTickerStream struct {
+	prices []float64
+}
+
+func (f fakeTickerStream) Stream(ctx context.Context, symbol string, out chan<- entities.PriceTicker) error {
+	for _, price := range f.prices {
+		select {
+		case out <- entities.PriceTicker{Symbol: symbol, Price: price, Timestamp: time.Now()}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// sseEvent is one parsed server-sent event
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvents reads events from an SSE body until n have arrived
+func readEvents(t *testing.T, scanner *bufio.Scanner, n int) []sseEvent {
+	t.Helper()
+	var events []sseEvent
+	var current sseEvent
+	for len(events) < n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			current.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			current.data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case line == "" && current.name != "":
+			events = append(events, current)
+			current = sseEvent{}
+		}
+	}
+	require.Len(t, events, n, "stream ended early: %v", scanner.Err())
+	return events
+}
+
+func newMarketStreamServer(source fakeTickerStream, heartbeat time.Duration, maxPerClient int) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewMarketStreamHandler(source, heartbeat, maxPerClient, logger.New("test")).RegisterRoutes(router.Group("/api/v1"))
+	return httptest.NewServer(router)
+}
+
+func openStream(t *testing.T, ctx context.Context, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestMarketStreamHandler_ForwardsTickers(t *testing.T) {
+	server := newMarketStreamServer(fakeTickerStream{prices: []float64{67000.5, 67010.25}}, 50*time.Millisecond, 3)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp := openStream(t, ctx, server.URL+"/api/v1/market/stream/btcusdt")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	events := readEvents(t, bufio.NewScanner(resp.Body), 3)
+
+	for i, price := range []float64{67000.5, 67010.25} {
+		assert.Equal(t, "ticker", events[i].name)
+		var ticker entities.PriceTicker
+		require.NoError(t, json.Unmarshal([]byte(events[i].data), &ticker))
+		assert.Equal(t, "BTCUSDT", ticker.Symbol)
+		assert.Equal(t, price, ticker.Price)
+	}
+
+	// Once the upstream goes quiet the client still hears from the server
+	assert.Equal(t, "heartbeat", events[2].name)
+}
+
+func TestMarketStreamHandler_SubscriptionCap(t *testing.T) {
+	server := newMarketStreamServer(fakeTickerStream{prices: []float64{67000}}, time.Minute, 1)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := openStream(t, ctx, server.URL+"/api/v1/market/stream/BTCUSDT")
+	require.Equal(t, http.StatusOK, first.StatusCode)
+	readEvents(t, bufio.NewScanner(first.Body), 1)
+
+	second := openStream(t, ctx, server.URL+"/api/v1/market/stream/ETHUSDT")
+	assert.Equal(t, http.StatusTooManyRequests, second.StatusCode)
+
+	// Closing the first stream frees its slot
+	first.Body.Close()
+	assert.Eventually(t, func() bool {
+		third := openStream(t, ctx, server.URL+"/api/v1/market/stream/ETHUSDT")
+		defer third.Body.Close()
+		return third.StatusCode == http.StatusOK
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestMarketStreamHandler_InvalidSymbol(t *testing.T) {
+	server := newMarketStreamServer(fakeTickerStream{}, time.Minute, 1)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/market/stream/btc-usdt")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NetworkHandler serves blockchain network statistics
+type NetworkHandler struct {
+	service services.NetworkMetricsService
+	logger  logger.Logger
+}
+
+// NewNetworkHandler creates a new network handler
+func NewNetworkHandler(service services.NetworkMetricsService, logger logger.Logger) *NetworkHandler {
+	return &NetworkHandler{
+		service: service,
+		logger:  logger.With("handler", "network"),
+	}
+}
+
+// RegisterRoutes registers the network routes
+func (h *NetworkHandler) RegisterRoutes(router *gin.RouterGroup) {
+	network := router.Group("/network")
+	{
+		network.GET("/bitcoin", h.GetBitcoinNetwork)
+	}
+}
+
+// GetBitcoinNetwork returns Bitcoin hash rate, difficulty, block height, mempool size,
+// transaction rate and fees
+func (h *NetworkHandler) GetBitcoinNetwork(c *gin.Context) {
+	if h.service == nil {
+		respondUnavailable(c, "Network metrics")
+		return
+	}
+
+	metrics, err := h.service.GetBitcoinNetworkMetrics(c.Request.Context())
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to get Bitcoin network metrics", "error", err)
+		c.JSON(errors.GetStatusCode(err), gin.H{
+			"success": false,
+			"error": gin.H{
+				"type":    errors.ErrorTypeExternal,
+				"message": "Failed to fetch Bitcoin network metrics",
+			},
+		})
+		return
+	}
+
+	RespondOK(c, metrics, nil)
+}
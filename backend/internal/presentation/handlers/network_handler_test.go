@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/application/services"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/cache"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubNetworkSummaryClient returns a fixed Blockchain.com network summary
+type stubNetworkSummaryClient struct {
+	summary map[string]interface{}
+	calls   int
+}
+
+func (c *stubNetworkSummaryClient) GetNetworkSummary(ctx context.Context) (map[string]interface{}, error) {
+	c.calls++
+	return c.summary, nil
+}
+
+// recordingNetworkMetricsRepo keeps the snapshots it is asked to save
+type recordingNetworkMetricsRepo struct {
+	saved []entities.NetworkMetrics
+}
+
+func (r *recordingNetworkMetricsRepo) Save(ctx context.Context, metrics *entities.NetworkMetrics) error {
+	r.saved = append(r.saved, *metrics)
+	return nil
+}
+
+func TestNetworkHandler_GetBitcoinNetwork(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	client := &stubNetworkSummaryClient{summary: map[string]interface{}{
+		"price_usd":        67000.0,
+		"hash_rate":        6.1e11,
+		"difficulty":       8.8e13,
+		"block_height":     int64(842000),
+		"mempool_size":     int64(45210),
+		"total_btc":        19700000.0,
+		"transaction_rate": 310.5,
+		"total_fees_btc":   21.75,
+		"last_updated":     int64(1714564800),
+	}}
+	repo := &recordingNetworkMetricsRepo{}
+	service := services.NewNetworkMetricsService(client, repo, cache.NewCacheService(nil, log), log)
+
+	router := gin.New()
+	NewNetworkHandler(service, log).RegisterRoutes(router.Group("/api/v1"))
+
+	request := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/network/bitcoin", nil))
+		return w
+	}
+
+	w := request()
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Success bool                   `json:"success"`
+		Data    map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "bitcoin", response.Data["network"])
+	assert.Equal(t, 6.1e11, response.Data["hash_rate"])
+	assert.Equal(t, 8.8e13, response.Data["difficulty"])
+	assert.Equal(t, 842000.0, response.Data["block_height"])
+	assert.Equal(t, 45210.0, response.Data["mempool_size"])
+	assert.Equal(t, 310.5, response.Data["transaction_rate"])
+	assert.Equal(t, 21.75, response.Data["fees_total"])
+	assert.Equal(t, "2024-05-01T12:00:00Z", response.Data["timestamp"])
+
+	require.Len(t, repo.saved, 1, "the fetched snapshot is stored")
+	assert.Equal(t, "bitcoin", repo.saved[0].Network)
+	assert.Equal(t, int64(842000), repo.saved[0].BlockHeight)
+	assert.Equal(t, entities.DataSourceBlockchain, repo.saved[0].DataSource)
+
+	// A second request within the cache period neither refetches nor stores again
+	require.Equal(t, http.StatusOK, request().Code)
+	assert.Equal(t, 1, client.calls)
+	assert.Len(t, repo.saved, 1)
+}
+
+func TestNetworkHandler_Unavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	NewNetworkHandler(nil, logger.New("test")).RegisterRoutes(router.Group("/api/v1"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/network/bitcoin", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
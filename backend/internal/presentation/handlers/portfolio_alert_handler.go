@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"strconv"
+
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortfolioAlertHandler manages drawdown alerts on the authenticated user's portfolios
+type PortfolioAlertHandler struct {
+	service services.PortfolioAlertService
+	logger  logger.Logger
+}
+
+// NewPortfolioAlertHandler creates a new portfolio alert handler
+func NewPortfolioAlertHandler(service services.PortfolioAlertService, logger logger.Logger) *PortfolioAlertHandler {
+	return &PortfolioAlertHandler{
+		service: service,
+		logger:  logger.With("handler", "portfolio_alert"),
+	}
+}
+
+// RegisterRoutes registers the drawdown alert routes behind the given middleware, which
+// must set the authenticated user
+func (h *PortfolioAlertHandler) RegisterRoutes(router *gin.RouterGroup, middleware ...gin.HandlerFunc) {
+	alerts := router.Group("/portfolios/:id/drawdown-alerts", middleware...)
+	{
+		alerts.GET("", h.ListDrawdownAlerts)
+		alerts.POST("", h.CreateDrawdownAlert)
+	}
+}
+
+// ListDrawdownAlerts returns every drawdown alert on the portfolio
+func (h *PortfolioAlertHandler) ListDrawdownAlerts(c *gin.Context) {
+	userID, portfolioID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	alerts, err := h.service.GetDrawdownAlerts(c.Request.Context(), userID, portfolioID)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, alerts, gin.H{"count": len(alerts)})
+}
+
+// CreateDrawdownAlert adds a drawdown alert to the portfolio
+func (h *PortfolioAlertHandler) CreateDrawdownAlert(c *gin.Context) {
+	var req dto.CreateDrawdownAlertRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	userID, portfolioID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	alert, err := h.service.CreateDrawdownAlert(c.Request.Context(), userID, portfolioID, req.ThresholdPercent)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondCreated(c, alert, nil)
+}
+
+// target resolves the authenticated user and portfolio ID, responding with an error when
+// either is missing or the service is not configured
+func (h *PortfolioAlertHandler) target(c *gin.Context) (string, uint, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		respondError(c, h.logger, errors.Unauthorized("An API key is required to manage portfolio alerts"))
+		return "", 0, false
+	}
+
+	portfolioID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, h.logger, errors.Validation("Invalid parameter format: id"))
+		return "", 0, false
+	}
+
+	if h.service == nil {
+		respondUnavailable(c, "Portfolio alert storage")
+		return "", 0, false
+	}
+
+	return userID, uint(portfolioID), true
+}
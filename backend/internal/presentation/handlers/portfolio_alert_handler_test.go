@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/services"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePortfolioAlertService keeps alerts for portfolio 1, owned by alice
+type fakePortfolioAlertService struct {
+	alerts []entities.PortfolioDrawdownAlert
+}
+
+func (s *fakePortfolioAlertService) owned(userID string, portfolioID uint) error {
+	if portfolioID != 1 {
+		return errors.NotFound("Portfolio")
+	}
+	if userID != "alice" {
+		return errors.Forbidden("Portfolio belongs to another user")
+	}
+	return nil
+}
+
+func (s *fakePortfolioAlertService) CreateDrawdownAlert(ctx context.Context, userID string, portfolioID uint, thresholdPercent float64) (*entities.PortfolioDrawdownAlert, error) {
+	if err := s.owned(userID, portfolioID); err != nil {
+		return nil, err
+	}
+	alert := entities.PortfolioDrawdownAlert{
+		ID:               uint(len(s.alerts) + 1),
+		PortfolioID:      portfolioID,
+		ThresholdPercent: thresholdPercent,
+		IsActive:         true,
+	}
+	s.alerts = append(s.alerts, alert)
+	return &alert, nil
+}
+
+func (s *fakePortfolioAlertService) GetDrawdownAlerts(ctx context.Context, userID string, portfolioID uint) ([]entities.PortfolioDrawdownAlert, error) {
+	if err := s.owned(userID, portfolioID); err != nil {
+		return nil, err
+	}
+	return s.alerts, nil
+}
+
+func (s *fakePortfolioAlertService) EvaluateDrawdown(ctx context.Context, portfolio *entities.Portfolio) error {
+	return nil
+}
+
+// newPortfolioAlertRouter serves the drawdown alert routes, as userID when it is set
+func newPortfolioAlertRouter(service services.PortfolioAlertService, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	authenticate := func(c *gin.Context) {
+		if userID != "" {
+			c.Set(middleware.UserIDKey, userID)
+		}
+	}
+	NewPortfolioAlertHandler(service, logger.New("test")).RegisterRoutes(router.Group("/api/v1"), authenticate)
+	return router
+}
+
+func serveAlertRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPortfolioAlertHandler_CreateAndList(t *testing.T) {
+	router := newPortfolioAlertRouter(&fakePortfolioAlertService{}, "alice")
+
+	w := serveAlertRequest(router, http.MethodPost, "/api/v1/portfolios/1/drawdown-alerts", `{"threshold_percent": 15}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created struct {
+		Data entities.PortfolioDrawdownAlert `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, uint(1), created.Data.PortfolioID)
+	assert.Equal(t, 15.0, created.Data.ThresholdPercent)
+
+	w = serveAlertRequest(router, http.MethodGet, "/api/v1/portfolios/1/drawdown-alerts", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var listed struct {
+		Data []entities.PortfolioDrawdownAlert `json:"data"`
+		Meta map[string]interface{}            `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.Len(t, listed.Data, 1)
+	assert.Equal(t, float64(1), listed.Meta["count"])
+}
+
+func TestPortfolioAlertHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		service services.PortfolioAlertService
+		userID  string
+		method  string
+		path    string
+		body    string
+		want    int
+	}{
+		{"No API key user", &fakePortfolioAlertService{}, "", http.MethodGet, "/api/v1/portfolios/1/drawdown-alerts", "", http.StatusUnauthorized},
+		{"Invalid portfolio ID", &fakePortfolioAlertService{}, "alice", http.MethodGet, "/api/v1/portfolios/abc/drawdown-alerts", "", http.StatusBadRequest},
+		{"Another user's portfolio", &fakePortfolioAlertService{}, "bob", http.MethodGet, "/api/v1/portfolios/1/drawdown-alerts", "", http.StatusForbidden},
+		{"Unknown portfolio", &fakePortfolioAlertService{}, "alice", http.MethodPost, "/api/v1/portfolios/9/drawdown-alerts", `{"threshold_percent": 15}`, http.StatusNotFound},
+		{"Threshold out of range", &fakePortfolioAlertService{}, "alice", http.MethodPost, "/api/v1/portfolios/1/drawdown-alerts", `{"threshold_percent": 100}`, http.StatusBadRequest},
+		{"Missing threshold", &fakePortfolioAlertService{}, "alice", http.MethodPost, "/api/v1/portfolios/1/drawdown-alerts", `{}`, http.StatusBadRequest},
+		{"Service not configured", nil, "alice", http.MethodGet, "/api/v1/portfolios/1/drawdown-alerts", "", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveAlertRequest(newPortfolioAlertRouter(tt.service, tt.userID), tt.method, tt.path, tt.body)
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+}
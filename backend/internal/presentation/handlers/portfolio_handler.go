@@ -1,15 +1,21 @@
 package handlers
 
 import (
-	"net/http"
 	"strconv"
 	"crypto-indicator-dashboard/internal/application/dto"
 	"crypto-indicator-dashboard/internal/application/usecases"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
 	"crypto-indicator-dashboard/pkg/errors"
 	"crypto-indicator-dashboard/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
+// Page sizes for portfolio listings
+const (
+	defaultPortfolioPageLimit = 50
+	maxPortfolioPageLimit     = 200
+)
+
 // PortfolioHandler handles portfolio-related HTTP requests
 type PortfolioHandler struct {
 	portfolioUseCase *usecases.PortfolioUseCase
@@ -24,107 +30,122 @@ func NewPortfolioHandler(portfolioUseCase *usecases.PortfolioUseCase, logger log
 	}
 }
 
-// CreatePortfolio creates a new portfolio
+// CreatePortfolio creates a new portfolio owned by the API key's user
 func (h *PortfolioHandler) CreatePortfolio(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
 	var req dto.CreatePortfolioRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.handleError(c, errors.Validation("Invalid request format", err.Error()))
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
 		return
 	}
 	
-	portfolio, err := h.portfolioUseCase.CreatePortfolio(c.Request.Context(), &req)
+	portfolio, err := h.portfolioUseCase.CreatePortfolio(c.Request.Context(), &req, userID)
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
-	h.logger.Info("Portfolio created successfully", "portfolio_id", portfolio.ID, "user_id", req.UserID)
+	h.logger.Info("Portfolio created successfully", "portfolio_id", portfolio.ID, "user_id", userID)
 	
-	c.JSON(http.StatusCreated, gin.H{
-		"success": true,
-		"message": "Portfolio created successfully",
-		"data":    portfolio,
-	})
+	RespondCreated(c, portfolio, gin.H{"message": "Portfolio created successfully"})
 }
 
 // GetPortfolio retrieves a portfolio by ID
 func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
 	portfolioID, err := h.parseUintParam(c, "id")
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
-	portfolio, err := h.portfolioUseCase.GetPortfolio(c.Request.Context(), portfolioID)
+	portfolio, err := h.portfolioUseCase.GetPortfolio(c.Request.Context(), portfolioID, userID)
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    portfolio,
-	})
+	RespondOK(c, portfolio, nil)
 }
 
-// GetUserPortfolios retrieves all portfolios for a user
+// GetUserPortfolios retrieves a page of the user's portfolios (?limit=&offset=)
 func (h *PortfolioHandler) GetUserPortfolios(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		userID = "default_user" // In production, get from JWT token
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
 	}
-	
+
+	limit, offset, err := parsePagination(c, defaultPortfolioPageLimit, maxPortfolioPageLimit)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
 	portfolios, err := h.portfolioUseCase.GetUserPortfolios(c.Request.Context(), userID)
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
-	
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    portfolios,
-	})
+
+	pagination := newPagination(len(portfolios.Portfolios), limit, offset)
+	start, end := pagination.bounds()
+	RespondPage(c, portfolios.Portfolios[start:end], pagination, nil)
 }
 
 // GetPortfolioSummary retrieves portfolio summary with analytics
 func (h *PortfolioHandler) GetPortfolioSummary(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
 	portfolioID, err := h.parseUintParam(c, "id")
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
-	summary, err := h.portfolioUseCase.GetPortfolioSummary(c.Request.Context(), portfolioID)
+	summary, err := h.portfolioUseCase.GetPortfolioSummary(c.Request.Context(), portfolioID, userID)
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    summary,
-	})
+	RespondOK(c, summary, nil)
 }
 
 // AddHolding adds a new holding to a portfolio
 func (h *PortfolioHandler) AddHolding(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
 	portfolioID, err := h.parseUintParam(c, "id")
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
 	var req dto.AddHoldingRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.handleError(c, errors.Validation("Invalid request format", err.Error()))
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
 		return
 	}
 	
 	req.PortfolioID = portfolioID
 	
-	holding, err := h.portfolioUseCase.AddHolding(c.Request.Context(), &req)
+	holding, err := h.portfolioUseCase.AddHolding(c.Request.Context(), &req, userID)
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
@@ -134,65 +155,242 @@ func (h *PortfolioHandler) AddHolding(c *gin.Context) {
 		"amount", req.Amount,
 	)
 	
-	c.JSON(http.StatusCreated, gin.H{
-		"success": true,
-		"message": "Holding added successfully",
-		"data":    holding,
-	})
+	RespondCreated(c, holding, gin.H{"message": "Holding added successfully"})
 }
 
 // UpdateHolding updates an existing holding
 func (h *PortfolioHandler) UpdateHolding(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
+	portfolioID, err := h.parseUintParam(c, "id")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
 	holdingID, err := h.parseUintParam(c, "holdingId")
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
 	var req dto.UpdateHoldingRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.handleError(c, errors.Validation("Invalid request format", err.Error()))
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
 		return
 	}
 	
 	req.HoldingID = holdingID
 	
-	if err := h.portfolioUseCase.UpdateHolding(c.Request.Context(), &req); err != nil {
-		h.handleError(c, err)
+	if err := h.portfolioUseCase.UpdateHolding(c.Request.Context(), portfolioID, &req, userID); err != nil {
+		respondError(c, h.logger, err)
 		return
 	}
 	
 	h.logger.Info("Holding updated successfully", "holding_id", holdingID)
 	
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Holding updated successfully",
-	})
+	RespondOK(c, nil, gin.H{"message": "Holding updated successfully"})
+}
+
+// UpdateHoldings applies a batch of holding updates in one transaction
+func (h *PortfolioHandler) UpdateHoldings(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
+	portfolioID, err := h.parseUintParam(c, "id")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	var reqs []dto.UpdateHoldingRequest
+	if err := bindJSON(c, &reqs); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	if err := h.portfolioUseCase.UpdateHoldings(c.Request.Context(), portfolioID, userID, reqs); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	h.logger.Info("Holdings updated successfully", "portfolio_id", portfolioID, "count", len(reqs))
+	
+	RespondOK(c, gin.H{"updated": len(reqs)}, gin.H{"message": "Holdings updated successfully"})
 }
 
 // RemoveHolding removes a holding from a portfolio
 func (h *PortfolioHandler) RemoveHolding(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
+	portfolioID, err := h.parseUintParam(c, "id")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
 	holdingID, err := h.parseUintParam(c, "holdingId")
 	if err != nil {
-		h.handleError(c, err)
+		respondError(c, h.logger, err)
 		return
 	}
 	
-	if err := h.portfolioUseCase.RemoveHolding(c.Request.Context(), holdingID); err != nil {
-		h.handleError(c, err)
+	if err := h.portfolioUseCase.RemoveHolding(c.Request.Context(), portfolioID, holdingID, userID); err != nil {
+		respondError(c, h.logger, err)
 		return
 	}
 	
 	h.logger.Info("Holding removed successfully", "holding_id", holdingID)
 	
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Holding removed successfully",
-	})
+	RespondOK(c, nil, gin.H{"message": "Holding removed successfully"})
+}
+
+// ClearHoldings removes all holdings of a portfolio; ?archive=true keeps a copy of them
+func (h *PortfolioHandler) ClearHoldings(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
+	portfolioID, err := h.parseUintParam(c, "id")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	archive := false
+	if raw := c.Query("archive"); raw != "" {
+		archive, err = strconv.ParseBool(raw)
+		if err != nil {
+			respondError(c, h.logger, errors.Validation("Invalid 'archive' parameter", "archive must be true or false"))
+			return
+		}
+	}
+	
+	removed, err := h.portfolioUseCase.ClearHoldings(c.Request.Context(), portfolioID, userID, archive)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	h.logger.Info("Holdings cleared successfully", "portfolio_id", portfolioID, "removed", removed, "archived", archive)
+	
+	RespondOK(c, gin.H{"removed": removed, "archived": archive}, gin.H{"message": "Holdings cleared successfully"})
+}
+
+// RecordTransaction records a buy or sell against a holding
+func (h *PortfolioHandler) RecordTransaction(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
+	portfolioID, err := h.parseUintParam(c, "id")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	holdingID, err := h.parseUintParam(c, "holdingId")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	var req dto.HoldingTransactionRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	req.PortfolioID = portfolioID
+	req.HoldingID = holdingID
+	
+	result, err := h.portfolioUseCase.RecordTransaction(c.Request.Context(), &req, userID)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	h.logger.Info("Transaction recorded successfully",
+		"holding_id", holdingID,
+		"side", req.Side,
+		"quantity", req.Quantity,
+	)
+	
+	RespondCreated(c, result, gin.H{"message": "Transaction recorded successfully"})
+}
+
+// SellHolding sells part or all of a holding at average cost, removing it once emptied
+func (h *PortfolioHandler) SellHolding(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	
+	portfolioID, err := h.parseUintParam(c, "id")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	holdingID, err := h.parseUintParam(c, "holdingId")
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	var req dto.SellHoldingRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	req.PortfolioID = portfolioID
+	req.HoldingID = holdingID
+	
+	result, err := h.portfolioUseCase.SellHolding(c.Request.Context(), &req, userID)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+	
+	h.logger.Info("Holding sold successfully",
+		"holding_id", holdingID,
+		"quantity", req.Quantity,
+		"realized_pnl", result.Transaction.RealizedPnL,
+		"removed", result.Removed,
+	)
+	
+	RespondCreated(c, result, gin.H{"message": "Sale recorded successfully"})
 }
 
 // Helper methods
 
+// requireUser returns the API key's user, answering 401 when there is none; every
+// portfolio route acts on that user's portfolios only. Without portfolio storage it
+// answers 503 instead.
+func (h *PortfolioHandler) requireUser(c *gin.Context) (string, bool) {
+	if h.portfolioUseCase == nil {
+		respondUnavailable(c, "Portfolio storage")
+		return "", false
+	}
+	
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		respondError(c, h.logger, errors.Unauthorized("An API key is required to access portfolios"))
+	}
+	return userID, ok
+}
+
 func (h *PortfolioHandler) parseUintParam(c *gin.Context, param string) (uint, error) {
 	paramStr := c.Param(param)
 	if paramStr == "" {
@@ -205,35 +403,4 @@ func (h *PortfolioHandler) parseUintParam(c *gin.Context, param string) (uint, e
 	}
 	
 	return uint(id), nil
-}
-
-func (h *PortfolioHandler) handleError(c *gin.Context, err error) {
-	h.logger.Error("Request failed", "error", err, "path", c.Request.URL.Path)
-	
-	statusCode := errors.GetStatusCode(err)
-	
-	// Convert error to response format
-	var errorResponse gin.H
-	if appErr, ok := err.(*errors.AppError); ok {
-		errorResponse = gin.H{
-			"success": false,
-			"error": gin.H{
-				"type":    appErr.Type,
-				"message": appErr.Message,
-			},
-		}
-		if appErr.Details != "" {
-			errorResponse["error"].(gin.H)["details"] = appErr.Details
-		}
-	} else {
-		errorResponse = gin.H{
-			"success": false,
-			"error": gin.H{
-				"type":    "INTERNAL_ERROR",
-				"message": "An internal error occurred",
-			},
-		}
-	}
-	
-	c.JSON(statusCode, errorResponse)
 }
\ No newline at end of file
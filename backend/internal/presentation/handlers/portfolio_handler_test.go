@@ -0,0 +1,498 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/application/usecases"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/presentation/middleware"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newPortfolioRouter serves the holding routes over a SQLite portfolio store seeded with
+// BTC (1) and ETH (2) in alice's portfolio 1 and SOL (3) in bob's portfolio 2
+func newPortfolioRouter(t *testing.T, middlewares ...gin.HandlerFunc) (*gin.Engine, *gorm.DB) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	t.Cleanup(func() { testDB.Cleanup() })
+	testDB.CreateTables(t, testutil.PortfolioTablesDDL...)
+	require.NoError(t, testDB.DB.Create(&[]models.Portfolio{
+		{ID: 1, UserID: "alice", Name: "Main", TotalValue: 50000},
+		{ID: 2, UserID: "bob", Name: "Alts", TotalValue: 5000},
+	}).Error)
+	require.NoError(t, testDB.DB.Create(&[]models.PortfolioHolding{
+		{ID: 1, PortfolioID: 1, Symbol: "BTC", Amount: 1, AveragePrice: 30000},
+		{ID: 2, PortfolioID: 1, Symbol: "ETH", Amount: 10, AveragePrice: 2000},
+		{ID: 3, PortfolioID: 2, Symbol: "SOL", Amount: 100, AveragePrice: 50},
+	}).Error)
+
+	useCase := usecases.NewPortfolioUseCase(database.NewPortfolioRepository(database.NewDBProvider(testDB.DB, nil)), nil, nil)
+	handler := NewPortfolioHandler(useCase, testDB.Logger)
+
+	router := gin.New()
+	router.Use(middlewares...)
+	router.POST("/api/v1/portfolios", handler.CreatePortfolio)
+	router.GET("/api/v1/portfolios", handler.GetUserPortfolios)
+	router.GET("/api/v1/portfolios/:id", handler.GetPortfolio)
+	router.GET("/api/v1/portfolios/:id/summary", handler.GetPortfolioSummary)
+	router.POST("/api/v1/portfolios/:id/holdings", handler.AddHolding)
+	router.PUT("/api/v1/portfolios/:id/holdings", handler.UpdateHoldings)
+	router.DELETE("/api/v1/portfolios/:id/holdings", handler.ClearHoldings)
+	router.PUT("/api/v1/portfolios/:id/holdings/:holdingId", handler.UpdateHolding)
+	router.DELETE("/api/v1/portfolios/:id/holdings/:holdingId", handler.RemoveHolding)
+	router.POST("/api/v1/portfolios/:id/holdings/:holdingId/transactions", handler.RecordTransaction)
+	router.POST("/api/v1/portfolios/:id/holdings/:holdingId/sell", handler.SellHolding)
+	return router, testDB.DB
+}
+
+// asUser authenticates every request as userID, as APIKeyAuth does
+func asUser(userID string) gin.HandlerFunc {
+	return func(c *gin.Context) { c.Set(middleware.UserIDKey, userID) }
+}
+
+func putHoldings(t *testing.T, router *gin.Engine, portfolioID, body string) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/portfolios/"+portfolioID+"/holdings", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return w.Code, response
+}
+
+// storedAmounts returns the amount of each holding by ID
+func storedAmounts(t *testing.T, db *gorm.DB) map[uint]float64 {
+	var holdings []models.PortfolioHolding
+	require.NoError(t, db.Order("id").Find(&holdings).Error)
+
+	amounts := make(map[uint]float64, len(holdings))
+	for _, holding := range holdings {
+		amounts[holding.ID] = holding.Amount
+	}
+	return amounts
+}
+
+func TestPortfolioHandler_UpdateHoldings(t *testing.T) {
+	router, db := newPortfolioRouter(t, asUser("alice"))
+
+	code, response := putHoldings(t, router, "1", `[
+		{"holding_id": 1, "amount": 1.5, "average_price": 32000},
+		{"holding_id": 2, "amount": 12, "average_price": 2100}
+	]`)
+
+	require.Equal(t, http.StatusOK, code, response)
+	assert.Equal(t, float64(2), response["data"].(map[string]interface{})["updated"])
+	assert.Equal(t, map[uint]float64{1: 1.5, 2: 12, 3: 100}, storedAmounts(t, db))
+
+	var btc models.PortfolioHolding
+	require.NoError(t, db.First(&btc, 1).Error)
+	assert.Equal(t, 32000.0, btc.AveragePrice)
+	assert.Equal(t, "BTC", btc.Symbol)
+}
+
+func TestPortfolioHandler_UpdatesResetLots(t *testing.T) {
+	buy := func(router *gin.Engine, holdingID string) map[string]interface{} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/portfolios/1/holdings/"+holdingID+"/transactions",
+			strings.NewReader(`{"side": "buy", "quantity": 1, "price": 3000}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, http.StatusCreated, w.Code, response)
+		return response["data"].(map[string]interface{})["holding"].(map[string]interface{})
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"batch update", http.MethodPut, "/api/v1/portfolios/1/holdings", `[{"holding_id": 2, "amount": 4, "average_price": 2500}]`},
+		{"single update", http.MethodPut, "/api/v1/portfolios/1/holdings/2", `{"holding_id": 2, "amount": 4, "average_price": 2500}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, db := newPortfolioRouter(t, asUser("alice"))
+			require.NoError(t, db.Create(&models.HoldingLot{HoldingID: 2, Quantity: 10, Remaining: 10, Price: 2000, AcquiredAt: time.Now()}).Error)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			// The next buy builds on the updated position, not on the lots from before it
+			holding := buy(router, "2")
+			assert.InDelta(t, 5.0, holding["amount"], 1e-9)
+			assert.InDelta(t, 2600.0, holding["average_price"], 1e-9)
+			assert.Equal(t, "ETH", holding["symbol"])
+
+			var lots []models.HoldingLot
+			require.NoError(t, db.Where("holding_id = ?", 2).Order("id").Find(&lots).Error)
+			require.Len(t, lots, 2)
+			assert.Equal(t, 4.0, lots[0].Remaining)
+			assert.Equal(t, 2500.0, lots[0].Price)
+		})
+	}
+}
+
+func TestPortfolioHandler_UpdateHoldings_NoneAppliedOnFailure(t *testing.T) {
+	unchanged := map[uint]float64{1: 1, 2: 10, 3: 100}
+
+	t.Run("invalid entry", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+
+		code, response := putHoldings(t, router, "1", `[
+			{"holding_id": 1, "amount": 2, "average_price": 32000},
+			{"holding_id": 2, "amount": -1, "average_price": 2100}
+		]`)
+
+		assert.Equal(t, http.StatusBadRequest, code, response)
+		assert.Equal(t, unchanged, storedAmounts(t, db))
+	})
+
+	t.Run("holding from another portfolio", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+
+		code, response := putHoldings(t, router, "1", `[
+			{"holding_id": 1, "amount": 2, "average_price": 32000},
+			{"holding_id": 3, "amount": 200, "average_price": 40}
+		]`)
+
+		assert.Equal(t, http.StatusNotFound, code, response)
+		assert.Equal(t, unchanged, storedAmounts(t, db))
+	})
+
+	t.Run("duplicate holding", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+
+		code, response := putHoldings(t, router, "1", `[
+			{"holding_id": 1, "amount": 2, "average_price": 32000},
+			{"holding_id": 1, "amount": 3, "average_price": 33000}
+		]`)
+
+		assert.Equal(t, http.StatusBadRequest, code, response)
+		assert.Equal(t, unchanged, storedAmounts(t, db))
+	})
+}
+
+func TestPortfolioHandler_GetUserPortfoliosUsesAPIKeyUser(t *testing.T) {
+	list := func(router *gin.Engine, query string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/portfolios"+query, nil))
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	// A user_id query parameter no longer selects another user's portfolios
+	router, _ := newPortfolioRouter(t, asUser("alice"))
+	status, response := list(router, "?user_id=bob")
+	require.Equal(t, http.StatusOK, status, response)
+	portfolios := response["data"].([]interface{})
+	require.Len(t, portfolios, 1)
+	assert.Equal(t, "Main", portfolios[0].(map[string]interface{})["name"])
+
+	// Without an API key user there is nobody to list portfolios for
+	router, _ = newPortfolioRouter(t)
+	status, response = list(router, "?user_id=alice")
+	assert.Equal(t, http.StatusUnauthorized, status, response)
+}
+
+func TestPortfolioHandler_MissingRecordsAreNotFound(t *testing.T) {
+	router, _ := newPortfolioRouter(t, asUser("alice"))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"Get portfolio", http.MethodGet, "/api/v1/portfolios/99", ""},
+		{"Add holding", http.MethodPost, "/api/v1/portfolios/99/holdings", `{"portfolio_id": 99, "symbol": "BTC", "amount": 1, "average_price": 30000}`},
+		{"Remove holding", http.MethodDelete, "/api/v1/portfolios/1/holdings/99", ""},
+		{"Update another portfolio's holding", http.MethodPut, "/api/v1/portfolios/1/holdings/3", `{"holding_id": 3, "amount": 1, "average_price": 1}`},
+		{"Remove another portfolio's holding", http.MethodDelete, "/api/v1/portfolios/1/holdings/3", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, http.StatusNotFound, w.Code, response)
+			assert.Equal(t, "NOT_FOUND", response["error"].(map[string]interface{})["type"])
+		})
+	}
+}
+
+func TestPortfolioHandler_CreatePortfolioOwnedByAPIKeyUser(t *testing.T) {
+	router, db := newPortfolioRouter(t, asUser("alice"))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/portfolios", strings.NewReader(`{"name": "Cold storage", "user_id": "bob"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created models.Portfolio
+	require.NoError(t, db.Where("name = ?", "Cold storage").First(&created).Error)
+	assert.Equal(t, "alice", created.UserID, "the body cannot pick the owner")
+}
+
+func TestPortfolioHandler_OtherUsersPortfoliosAreForbidden(t *testing.T) {
+	router, db := newPortfolioRouter(t, asUser("bob"))
+	unchanged := map[uint]float64{1: 1, 2: 10, 3: 100}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"Get portfolio", http.MethodGet, "/api/v1/portfolios/1", ""},
+		{"Get summary", http.MethodGet, "/api/v1/portfolios/1/summary", ""},
+		{"Add holding", http.MethodPost, "/api/v1/portfolios/1/holdings", `{"portfolio_id": 1, "symbol": "BTC", "amount": 1, "average_price": 30000}`},
+		{"Update holdings", http.MethodPut, "/api/v1/portfolios/1/holdings", `[{"holding_id": 1, "amount": 5, "average_price": 1}]`},
+		{"Update holding", http.MethodPut, "/api/v1/portfolios/1/holdings/1", `{"holding_id": 1, "amount": 5, "average_price": 1}`},
+		{"Remove holding", http.MethodDelete, "/api/v1/portfolios/1/holdings/1", ""},
+		{"Record transaction", http.MethodPost, "/api/v1/portfolios/1/holdings/1/transactions", `{"side": "sell", "quantity": 1, "price": 40000}`},
+		{"Sell holding", http.MethodPost, "/api/v1/portfolios/1/holdings/1/sell", `{"quantity": 1, "price": 40000}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, http.StatusForbidden, w.Code, response)
+			assert.Equal(t, unchanged, storedAmounts(t, db))
+		})
+	}
+}
+
+func TestPortfolioHandler_ClearHoldings(t *testing.T) {
+	clearHoldings := func(router *gin.Engine, path string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+	totalValue := func(db *gorm.DB, portfolioID uint) float64 {
+		var portfolio models.Portfolio
+		require.NoError(t, db.First(&portfolio, portfolioID).Error)
+		return portfolio.TotalValue
+	}
+
+	t.Run("removes all holdings and resets the total value", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+		for _, holdingID := range []uint{1, 3} {
+			require.NoError(t, db.Create(&models.HoldingLot{HoldingID: holdingID, Quantity: 1, Remaining: 1, Price: 100, AcquiredAt: time.Now()}).Error)
+			require.NoError(t, db.Create(&models.HoldingTransaction{HoldingID: holdingID, Side: "buy", Quantity: 1, Price: 100, ExecutedAt: time.Now()}).Error)
+		}
+
+		code, response := clearHoldings(router, "/api/v1/portfolios/1/holdings")
+
+		require.Equal(t, http.StatusOK, code, response)
+		assert.Equal(t, float64(2), response["data"].(map[string]interface{})["removed"])
+		assert.Equal(t, map[uint]float64{3: 100}, storedAmounts(t, db), "other portfolios are untouched")
+		assert.Equal(t, 0.0, totalValue(db, 1))
+		assert.Equal(t, 5000.0, totalValue(db, 2))
+
+		var archived int64
+		require.NoError(t, db.Model(&models.ArchivedHolding{}).Count(&archived).Error)
+		assert.Zero(t, archived)
+
+		var lots, transactions []uint
+		require.NoError(t, db.Model(&models.HoldingLot{}).Pluck("holding_id", &lots).Error)
+		require.NoError(t, db.Model(&models.HoldingTransaction{}).Pluck("holding_id", &transactions).Error)
+		assert.Equal(t, []uint{3}, lots, "cleared holdings' lots are removed with them")
+		assert.Equal(t, []uint{3}, transactions, "cleared holdings' transactions are removed with them")
+	})
+
+	t.Run("archives holdings when asked", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+
+		code, response := clearHoldings(router, "/api/v1/portfolios/1/holdings?archive=true")
+
+		require.Equal(t, http.StatusOK, code, response)
+		assert.Equal(t, map[uint]float64{3: 100}, storedAmounts(t, db))
+
+		var archived []models.ArchivedHolding
+		require.NoError(t, db.Order("holding_id").Find(&archived).Error)
+		require.Len(t, archived, 2)
+		assert.Equal(t, uint(1), archived[0].HoldingID)
+		assert.Equal(t, "BTC", archived[0].Symbol)
+		assert.Equal(t, 30000.0, archived[0].AveragePrice)
+		assert.Equal(t, "ETH", archived[1].Symbol)
+		assert.Equal(t, 10.0, archived[1].Amount)
+	})
+
+	t.Run("another user's portfolio is refused", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+
+		code, response := clearHoldings(router, "/api/v1/portfolios/2/holdings")
+
+		assert.Equal(t, http.StatusForbidden, code, response)
+		assert.Equal(t, map[uint]float64{1: 1, 2: 10, 3: 100}, storedAmounts(t, db))
+		assert.Equal(t, 5000.0, totalValue(db, 2))
+	})
+
+	t.Run("unknown portfolio", func(t *testing.T) {
+		router, _ := newPortfolioRouter(t, asUser("alice"))
+
+		code, response := clearHoldings(router, "/api/v1/portfolios/9/holdings")
+
+		assert.Equal(t, http.StatusNotFound, code, response)
+	})
+
+	t.Run("invalid archive flag", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+
+		code, _ := clearHoldings(router, "/api/v1/portfolios/1/holdings?archive=maybe")
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, map[uint]float64{1: 1, 2: 10, 3: 100}, storedAmounts(t, db))
+	})
+}
+
+func sellHolding(t *testing.T, router *gin.Engine, portfolioID, holdingID, body string) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	path := "/api/v1/portfolios/" + portfolioID + "/holdings/" + holdingID + "/sell"
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return w.Code, response
+}
+
+func TestPortfolioHandler_SellHolding(t *testing.T) {
+	t.Run("partial sale", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+
+		code, response := sellHolding(t, router, "1", "2", `{"quantity": 4, "price": 2500}`)
+
+		require.Equal(t, http.StatusCreated, code, response)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, false, data["removed"])
+		assert.Equal(t, 2000.0, data["transaction"].(map[string]interface{})["realized_pnl"])
+		assert.Equal(t, map[uint]float64{1: 1, 2: 6, 3: 100}, storedAmounts(t, db))
+
+		var transactions int64
+		require.NoError(t, db.Model(&models.HoldingTransaction{}).Where("holding_id = ?", 2).Count(&transactions).Error)
+		assert.Equal(t, int64(1), transactions)
+	})
+
+	t.Run("full sale archives and removes the holding", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+		require.NoError(t, db.Create(&models.HoldingLot{HoldingID: 1, Quantity: 1, Remaining: 1, Price: 30000, AcquiredAt: time.Now()}).Error)
+
+		code, response := sellHolding(t, router, "1", "1", `{"quantity": 1, "price": 40000}`)
+
+		require.Equal(t, http.StatusCreated, code, response)
+		assert.Equal(t, true, response["data"].(map[string]interface{})["removed"])
+		assert.Equal(t, map[uint]float64{2: 10, 3: 100}, storedAmounts(t, db))
+
+		var archived models.ArchivedHolding
+		require.NoError(t, db.Where("holding_id = ?", 1).First(&archived).Error)
+		assert.Equal(t, 10000.0, archived.RealizedPnL)
+
+		var lots, transactions int64
+		require.NoError(t, db.Model(&models.HoldingLot{}).Where("holding_id = ?", 1).Count(&lots).Error)
+		require.NoError(t, db.Model(&models.HoldingTransaction{}).Where("holding_id = ?", 1).Count(&transactions).Error)
+		assert.Zero(t, lots, "lots must not outlive their holding")
+		assert.Zero(t, transactions, "transactions must not outlive their holding")
+	})
+
+	t.Run("rejected sales change nothing", func(t *testing.T) {
+		router, db := newPortfolioRouter(t, asUser("alice"))
+		unchanged := map[uint]float64{1: 1, 2: 10, 3: 100}
+
+		code, response := sellHolding(t, router, "1", "1", `{"quantity": 2, "price": 40000}`)
+		assert.Equal(t, http.StatusBadRequest, code, response)
+
+		code, response = sellHolding(t, router, "1", "3", `{"quantity": 1, "price": 60}`)
+		assert.Equal(t, http.StatusNotFound, code, response)
+
+		code, response = sellHolding(t, router, "1", "99", `{"quantity": 1, "price": 60}`)
+		assert.Equal(t, http.StatusNotFound, code, response)
+
+		code, response = sellHolding(t, router, "1", "1", `{"quantity": 0, "price": 40000}`)
+		assert.Equal(t, http.StatusBadRequest, code, response)
+
+		assert.Equal(t, unchanged, storedAmounts(t, db))
+	})
+}
+
+func TestPortfolioHandler_RequestBodyErrors(t *testing.T) {
+	limited, db := newPortfolioRouter(t, asUser("alice"), middleware.MaxBodyBytes(256))
+	unchanged := map[uint]float64{1: 1, 2: 10, 3: 100}
+
+	t.Run("oversized body", func(t *testing.T) {
+		body := `[` + strings.Repeat(`{"holding_id": 1, "amount": 2, "average_price": 32000},`, 10) + `{"holding_id": 2, "amount": 1, "average_price": 1}]`
+
+		code, response := putHoldings(t, limited, "1", body)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, code, response)
+		assert.Equal(t, "PAYLOAD_TOO_LARGE", response["error"].(map[string]interface{})["type"])
+		assert.Equal(t, unchanged, storedAmounts(t, db))
+	})
+
+	t.Run("oversized body without a content length", func(t *testing.T) {
+		body := `[` + strings.Repeat(`{"holding_id": 1, "amount": 2, "average_price": 32000},`, 10) + `{"holding_id": 2, "amount": 1, "average_price": 1}]`
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/portfolios/1/holdings", strings.NewReader(body))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+		assert.Equal(t, unchanged, storedAmounts(t, db))
+	})
+
+	t.Run("malformed body reports the failing fields", func(t *testing.T) {
+		code, response := putHoldings(t, limited, "1", `[{"holding_id": 1, "average_price": -5}]`)
+
+		require.Equal(t, http.StatusBadRequest, code, response)
+		errBody := response["error"].(map[string]interface{})
+		assert.Equal(t, "VALIDATION_ERROR", errBody["type"])
+		assert.Equal(t, "amount: is required; average_price: must be greater than 0", errBody["details"])
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		code, response := putHoldings(t, limited, "1", `[{"holding_id": "one"}]`)
+
+		require.Equal(t, http.StatusBadRequest, code, response)
+		assert.Equal(t, "Invalid request format", response["error"].(map[string]interface{})["message"])
+	})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// providerUptimeWindow is the period provider uptime is reported over
+const providerUptimeWindow = 24 * time.Hour
+
+// ProviderHealthHandler serves the recorded health of external data providers
+type ProviderHealthHandler struct {
+	repo   repositories.ProviderHealthRepository
+	now    func() time.Time
+	logger logger.Logger
+}
+
+// NewProviderHealthHandler creates a new provider health handler
+func NewProviderHealthHandler(repo repositories.ProviderHealthRepository, logger logger.Logger) *ProviderHealthHandler {
+	return &ProviderHealthHandler{
+		repo:   repo,
+		now:    time.Now,
+		logger: logger.With("handler", "provider_health"),
+	}
+}
+
+// RegisterRoutes registers the provider health route behind the given middleware
+func (h *ProviderHealthHandler) RegisterRoutes(router *gin.RouterGroup, middleware ...gin.HandlerFunc) {
+	admin := router.Group("/admin", middleware...)
+	{
+		admin.GET("/providers/health", h.GetProviderHealth)
+	}
+}
+
+// GetProviderHealth returns each provider's latest health check and its uptime over the last 24h
+func (h *ProviderHealthHandler) GetProviderHealth(c *gin.Context) {
+	if h.repo == nil {
+		respondUnavailable(c, "Provider health history")
+		return
+	}
+
+	since := h.now().Add(-providerUptimeWindow)
+	checks, err := h.repo.GetSince(c.Request.Context(), since)
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, dto.ProviderHealthResponse{
+		WindowHours: providerUptimeWindow.Hours(),
+		Since:       since,
+		Providers:   entities.SummarizeProviderHealth(checks, since),
+	}, nil)
+}
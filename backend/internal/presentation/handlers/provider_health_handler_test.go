@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/application/dto"
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/database"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderHealthHandler_Uptime(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+	testDB.CreateTables(t, testutil.ProviderHealthTableDDL)
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	check := func(provider string, healthy bool, age time.Duration, latencyMs float64) entities.ProviderHealthCheck {
+		c := entities.ProviderHealthCheck{Provider: provider, Healthy: healthy, LatencyMs: latencyMs, CheckedAt: now.Add(-age)}
+		if !healthy {
+			c.Error = provider + " unavailable"
+		}
+		return c
+	}
+
+	repo := database.NewProviderHealthRepository(database.NewDBProvider(testDB.DB, nil), testDB.Logger)
+	require.NoError(t, repo.Record(context.Background(), []entities.ProviderHealthCheck{
+		// Older than the window, so it counts toward neither uptime nor the latest result
+		check("coinmarketcap", false, 30*time.Hour, 9000),
+		// coinmarketcap: 3 of 4 passed, currently healthy but slowing down
+		check("coinmarketcap", true, 20*time.Hour, 100),
+		check("coinmarketcap", false, 15*time.Hour, 300),
+		check("coinmarketcap", true, 10*time.Hour, 200),
+		check("coinmarketcap", true, time.Hour, 800),
+		// tradingview: 1 of 3 passed, currently failing
+		check("tradingview", true, 20*time.Hour, 50),
+		check("tradingview", false, 10*time.Hour, 50),
+		check("tradingview", false, time.Hour, 50),
+	}))
+
+	handler := NewProviderHealthHandler(repo, testDB.Logger)
+	handler.now = func() time.Time { return now }
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/v1"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/providers/health", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool                       `json:"success"`
+		Data    dto.ProviderHealthResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, 24.0, response.Data.WindowHours)
+	require.Len(t, response.Data.Providers, 2)
+
+	cmc := response.Data.Providers[0]
+	assert.Equal(t, "coinmarketcap", cmc.Provider)
+	assert.True(t, cmc.Healthy)
+	assert.Equal(t, 4, cmc.Checks)
+	assert.Equal(t, 1, cmc.FailedChecks)
+	assert.InDelta(t, 75.0, cmc.UptimePercent, 1e-9)
+	assert.True(t, now.Add(-time.Hour).Equal(cmc.LastCheckedAt))
+	assert.Equal(t, 800.0, cmc.LastLatencyMs)
+	assert.InDelta(t, 350.0, cmc.AvgLatencyMs, 1e-9)
+	assert.Equal(t, 800.0, cmc.MaxLatencyMs)
+
+	tv := response.Data.Providers[1]
+	assert.Equal(t, "tradingview", tv.Provider)
+	assert.False(t, tv.Healthy)
+	assert.Equal(t, "tradingview unavailable", tv.LastError)
+	assert.InDelta(t, 100.0/3.0, tv.UptimePercent, 1e-9)
+}
+
+// failingProviderHealthRepo fails every read with err
+type failingProviderHealthRepo struct {
+	err error
+}
+
+func (r failingProviderHealthRepo) Record(context.Context, []entities.ProviderHealthCheck) error {
+	return r.err
+}
+
+func (r failingProviderHealthRepo) GetSince(context.Context, time.Time) ([]entities.ProviderHealthCheck, error) {
+	return nil, r.err
+}
+
+func (r failingProviderHealthRepo) DeleteBefore(context.Context, time.Time) (int64, error) {
+	return 0, r.err
+}
+
+func TestProviderHealthHandler_ErrorType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+	}{
+		{"validation", errors.Validation("Invalid window"), http.StatusBadRequest, string(errors.ErrorTypeValidation)},
+		{"internal", errors.Internal("Database unavailable", nil), http.StatusInternalServerError, string(errors.ErrorTypeInternal)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			NewProviderHealthHandler(failingProviderHealthRepo{err: tt.err}, testDB.Logger).RegisterRoutes(router.Group("/api/v1"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/providers/health", nil))
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			var response struct {
+				Success bool `json:"success"`
+				Error   struct {
+					Type string `json:"type"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			assert.Equal(t, tt.wantType, response.Error.Type)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProviderRawHandler lets operators see the exact payload a data provider returns, to debug
+// indicators that look off
+type ProviderRawHandler struct {
+	proxy  *external.RawProxy
+	logger logger.Logger
+}
+
+// NewProviderRawHandler creates a new raw provider response handler
+func NewProviderRawHandler(proxy *external.RawProxy, logger logger.Logger) *ProviderRawHandler {
+	return &ProviderRawHandler{
+		proxy:  proxy,
+		logger: logger.With("handler", "provider_raw"),
+	}
+}
+
+// RegisterRoutes registers the raw provider response route behind the given middleware
+func (h *ProviderRawHandler) RegisterRoutes(router *gin.RouterGroup, middleware ...gin.HandlerFunc) {
+	admin := router.Group("/admin", middleware...)
+	{
+		admin.GET("/providers/:name/raw", h.GetRawResponse)
+	}
+}
+
+// GetRawResponse calls the whitelisted ?endpoint= path of the named provider and returns
+// the upstream status and body as received. Upstream error statuses are returned as data, not
+// as errors of this endpoint.
+func (h *ProviderRawHandler) GetRawResponse(c *gin.Context) {
+	if h.proxy == nil {
+		respondUnavailable(c, "Raw provider responses")
+		return
+	}
+
+	// A missing endpoint fails the whitelist like any other, listing the allowed ones
+	response, err := h.proxy.Fetch(c.Request.Context(), c.Param("name"), c.Query("endpoint"))
+	if err != nil {
+		respondError(c, h.logger, err)
+		return
+	}
+
+	RespondOK(c, response, nil)
+}
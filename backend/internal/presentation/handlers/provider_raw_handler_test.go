@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProviderRawRouter serves the raw provider route over a single "binance" provider
+// backed by upstream, requiring the X-Admin header in place of API key auth
+func newProviderRawRouter(t *testing.T, upstream *httptest.Server) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+	proxy := external.NewRawProxy(map[string]external.RawProvider{
+		"binance": {
+			BaseURL: upstream.URL,
+			Header:  http.Header{"X-Api-Key": {"secret"}},
+			Endpoints: map[string]url.Values{
+				"/api/v3/ticker/price": {"symbol": {"BTCUSDT"}},
+				"/redirect":            nil,
+			},
+		},
+	}, log)
+
+	requireAdmin := func(c *gin.Context) {
+		if c.GetHeader("X-Admin") == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+		}
+	}
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	NewProviderHealthHandler(nil, log).RegisterRoutes(api, requireAdmin)
+	NewProviderRawHandler(proxy, log).RegisterRoutes(api, requireAdmin)
+	return router
+}
+
+func getRaw(router *gin.Engine, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Admin", "yes")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestProviderRawHandler_WhitelistedEndpoint(t *testing.T) {
+	var seen *http.Request
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"symbol":"BTCUSDT","price":"67712.34000000"}`))
+	}))
+	defer upstream.Close()
+	router := newProviderRawRouter(t, upstream)
+
+	w := getRaw(router, "/api/v1/admin/providers/binance/raw?endpoint=/api/v3/ticker/price")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Data external.RawResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusOK, response.Data.StatusCode)
+	assert.Equal(t, `{"symbol":"BTCUSDT","price":"67712.34000000"}`, response.Data.Body)
+	assert.Equal(t, "application/json", response.Data.ContentType)
+	assert.False(t, response.Data.Truncated)
+
+	require.NotNil(t, seen)
+	assert.Equal(t, "/api/v3/ticker/price", seen.URL.Path)
+	assert.Equal(t, "symbol=BTCUSDT", seen.URL.RawQuery, "the whitelist fixes the query")
+	assert.Equal(t, "secret", seen.Header.Get("X-Api-Key"))
+	assert.NotContains(t, w.Body.String(), "secret", "provider credentials are not echoed")
+}
+
+func TestProviderRawHandler_UpstreamErrorsAreReturnedAsData(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code":-1003,"msg":"Too many requests"}`))
+	}))
+	defer upstream.Close()
+	router := newProviderRawRouter(t, upstream)
+
+	w := getRaw(router, "/api/v1/admin/providers/binance/raw?endpoint=/api/v3/ticker/price")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Data external.RawResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusTooManyRequests, response.Data.StatusCode)
+	assert.Contains(t, response.Data.Body, "Too many requests")
+
+	// Redirects are reported, never followed
+	w = getRaw(router, "/api/v1/admin/providers/binance/raw?endpoint=/redirect")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusFound, response.Data.StatusCode)
+}
+
+func TestProviderRawHandler_RejectsNonWhitelistedRequests(t *testing.T) {
+	var calls int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer upstream.Close()
+	router := newProviderRawRouter(t, upstream)
+
+	tests := map[string]struct {
+		target string
+		status int
+	}{
+		"Other path":       {"/api/v1/admin/providers/binance/raw?endpoint=/api/v3/account", http.StatusBadRequest},
+		"Path traversal":   {"/api/v1/admin/providers/binance/raw?endpoint=/api/v3/ticker/price/../../account", http.StatusBadRequest},
+		"Extra query":      {"/api/v1/admin/providers/binance/raw?endpoint=" + url.QueryEscape("/api/v3/ticker/price?symbol=ETHUSDT"), http.StatusBadRequest},
+		"Absolute URL":     {"/api/v1/admin/providers/binance/raw?endpoint=" + url.QueryEscape("http://169.254.169.254/latest/meta-data/"), http.StatusBadRequest},
+		"Host injection":   {"/api/v1/admin/providers/binance/raw?endpoint=" + url.QueryEscape("@evil.example/api/v3/ticker/price"), http.StatusBadRequest},
+		"Missing endpoint": {"/api/v1/admin/providers/binance/raw", http.StatusBadRequest},
+		"Unknown provider": {"/api/v1/admin/providers/internal/raw?endpoint=/api/v3/ticker/price", http.StatusNotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := getRaw(router, tt.target)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+
+	t.Run("Rejections list the allowed endpoints", func(t *testing.T) {
+		w := getRaw(router, "/api/v1/admin/providers/binance/raw?endpoint=/api/v3/account")
+		assert.Contains(t, w.Body.String(), "/api/v3/ticker/price")
+	})
+
+	t.Run("Admin auth is required", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/providers/binance/raw?endpoint=/api/v3/ticker/price", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	assert.Zero(t, atomic.LoadInt32(&calls), "rejected requests never reach the upstream")
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondRateLimited answers 429 with a Retry-After header when err carries an upstream
+// rate limit error, and reports whether it did
+func respondRateLimited(c *gin.Context, err error) bool {
+	resetTime, ok := errors.RateLimitResetTime(err)
+	if !ok {
+		return false
+	}
+
+	retryAfter := int(math.Ceil(time.Until(resetTime).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"success": false,
+		"error": gin.H{
+			"type":        errors.ErrorTypeRateLimit,
+			"message":     "Upstream data provider rate limit exceeded",
+			"retry_after": retryAfter,
+		},
+	})
+	return true
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// legacyQueryParam opts a request into the response shapes used before the success envelope
+const legacyQueryParam = "legacy"
+
+// RespondOK answers 200 with the standard success envelope {success, data, meta}.
+// meta carries anything that describes the response rather than being part of it,
+// such as counts or messages; a nil meta is written as an empty object.
+func RespondOK(c *gin.Context, data interface{}, meta gin.H) {
+	respond(c, http.StatusOK, data, meta)
+}
+
+// RespondCreated answers 201 with the standard success envelope
+func RespondCreated(c *gin.Context, data interface{}, meta gin.H) {
+	respond(c, http.StatusCreated, data, meta)
+}
+
+// respondError logs err and answers with its status code and the error envelope
+// {success: false, error: {type, message, details}}. An upstream rate limit answers 429
+// with Retry-After; errors that are not AppErrors are reported as a generic internal error.
+func respondError(c *gin.Context, log logger.Logger, err error) {
+	log.WithContext(c.Request.Context()).Error("Request failed", "error", err, "path", c.Request.URL.Path)
+
+	if respondRateLimited(c, err) {
+		return
+	}
+
+	errorBody := gin.H{
+		"type":    "INTERNAL_ERROR",
+		"message": "An internal error occurred",
+	}
+	if appErr, ok := err.(*errors.AppError); ok {
+		errorBody = gin.H{
+			"type":    appErr.Type,
+			"message": appErr.Message,
+		}
+		if appErr.Details != "" {
+			errorBody["details"] = appErr.Details
+		}
+	}
+
+	c.JSON(errors.GetStatusCode(err), gin.H{
+		"success": false,
+		"error":   errorBody,
+	})
+}
+
+// respondUnavailable answers 503 when what, such as "Annotation storage", is not configured
+func respondUnavailable(c *gin.Context, what string) {
+	c.JSON(http.StatusServiceUnavailable, gin.H{
+		"success": false,
+		"error": gin.H{
+			"type":    "SERVICE_UNAVAILABLE",
+			"message": what + " not available",
+		},
+	})
+}
+
+// respond writes the success envelope, or for ?legacy=true the older flat shape where
+// meta keys sit next to data and a nil data is left out
+func respond(c *gin.Context, status int, data interface{}, meta gin.H) {
+	if legacyResponse(c) {
+		body := gin.H{"success": true}
+		if data != nil {
+			body["data"] = data
+		}
+		for key, value := range meta {
+			body[key] = value
+		}
+		c.JSON(status, body)
+		return
+	}
+
+	if meta == nil {
+		meta = gin.H{}
+	}
+	c.JSON(status, gin.H{
+		"success": true,
+		"data":    data,
+		"meta":    meta,
+	})
+}
+
+// legacyResponse reports whether the client asked for pre-envelope response shapes
+func legacyResponse(c *gin.Context) bool {
+	legacy, _ := strconv.ParseBool(c.Query(legacyQueryParam))
+	return legacy
+}
+
+// Pagination describes one page of a list response. Next is the offset of the following
+// page, or null on the last page.
+type Pagination struct {
+	Total  int  `json:"total"`
+	Limit  int  `json:"limit"`
+	Offset int  `json:"offset"`
+	Next   *int `json:"next"`
+}
+
+// newPagination describes the page of total items starting at offset
+func newPagination(total, limit, offset int) Pagination {
+	pagination := Pagination{Total: total, Limit: limit, Offset: offset}
+	if next := offset + limit; next < total {
+		pagination.Next = &next
+	}
+	return pagination
+}
+
+// bounds returns the slice bounds of the page within the full list
+func (p Pagination) bounds() (start, end int) {
+	start = p.Offset
+	if start > p.Total {
+		start = p.Total
+	}
+	end = start + p.Limit
+	if end > p.Total {
+		end = p.Total
+	}
+	return start, end
+}
+
+// parsePagination reads ?limit= and ?offset=. limit defaults to defaultLimit and must be
+// between 1 and maxLimit; offset defaults to 0.
+func parsePagination(c *gin.Context, defaultLimit, maxLimit int) (limit, offset int, err error) {
+	return parsePaginationParam(c, "limit", defaultLimit, maxLimit)
+}
+
+// parsePaginationParam is parsePagination with the page size read from ?<param>= instead of
+// ?limit=, for endpoints where limit already means something else
+func parsePaginationParam(c *gin.Context, param string, defaultLimit, maxLimit int) (limit, offset int, err error) {
+	limit = defaultLimit
+	if raw := c.Query(param); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, errors.Validation(fmt.Sprintf("Invalid '%s' parameter", param),
+				fmt.Sprintf("%s must be between 1 and %d", param, maxLimit))
+		}
+	}
+	if raw := c.Query("offset"); raw != "" {
+		offset, err = strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.Validation("Invalid 'offset' parameter", "offset must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
+
+// RespondPage answers 200 with the success envelope plus the page's pagination, which
+// sits next to data and meta (or next to data in the legacy shape)
+func RespondPage(c *gin.Context, data interface{}, pagination Pagination, meta gin.H) {
+	if legacyResponse(c) {
+		body := gin.H{"success": true, "data": data, "pagination": pagination}
+		for key, value := range meta {
+			body[key] = value
+		}
+		c.JSON(http.StatusOK, body)
+		return
+	}
+
+	if meta == nil {
+		meta = gin.H{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       data,
+		"meta":       meta,
+		"pagination": pagination,
+	})
+}
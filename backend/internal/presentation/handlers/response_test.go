@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/infrastructure/config"
+	"crypto-indicator-dashboard/internal/testutil"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newEnvelopeRouter serves a sample of indicator, chart, market and portfolio routes
+func newEnvelopeRouter(t *testing.T) *gin.Engine {
+	router, _ := newPortfolioRouter(t, asUser("alice"))
+
+	log := logger.New("test")
+	NewIndicatorHandler(&config.Dependencies{
+		Logger: log,
+		Cache:  testutil.NewMockCacheService(),
+	}).RegisterRoutes(router.Group("/api/v1"))
+
+	market := &testutil.MockMarketDataService{}
+	market.On("GetCryptoPrices", mock.Anything, []string{"BTC"}).Return(map[string]*entities.CryptoPrice{
+		"BTC": {Symbol: "BTC", Price: 67000},
+	}, []string{}, nil)
+	NewMarketDataHandler(market, nil, nil, nil, nil, 0, log).RegisterRoutes(router.Group("/api/v1"))
+
+	return router
+}
+
+func serveEnvelope(t *testing.T, router *gin.Engine, method, target, body string) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+	return w.Code, response
+}
+
+func TestRespondOK_Envelope(t *testing.T) {
+	router := newEnvelopeRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		meta   map[string]interface{}
+	}{
+		{name: "Indicator", method: http.MethodGet, target: "/api/v1/indicators/mvrv"},
+		{name: "Chart", method: http.MethodGet, target: "/api/v1/charts/dominance"},
+		{name: "Market prices", method: http.MethodGet, target: "/api/v1/market/prices?symbols=btc",
+			meta: map[string]interface{}{"count": 1.0, "resolved": []interface{}{"BTC"}, "unresolved": []interface{}{}}},
+		{name: "Portfolio update", method: http.MethodPut, target: "/api/v1/portfolios/1/holdings",
+			body: `[{"holding_id": 1, "amount": 2, "average_price": 30000}]`,
+			meta: map[string]interface{}{"message": "Holdings updated successfully"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, response := serveEnvelope(t, router, tt.method, tt.target, tt.body)
+
+			require.Equal(t, http.StatusOK, code, response)
+			assert.Len(t, response, 3, "envelope should only carry success, data and meta")
+			assert.Equal(t, true, response["success"])
+			assert.IsType(t, map[string]interface{}{}, response["data"])
+
+			meta, ok := response["meta"].(map[string]interface{})
+			require.True(t, ok, "meta should always be an object")
+			if tt.meta == nil {
+				assert.Empty(t, meta)
+			} else {
+				assert.Equal(t, tt.meta, meta)
+			}
+		})
+	}
+}
+
+func TestRespondOK_Legacy(t *testing.T) {
+	router := newEnvelopeRouter(t)
+
+	t.Run("Meta is flattened next to data", func(t *testing.T) {
+		code, response := serveEnvelope(t, router, http.MethodGet, "/api/v1/market/prices?symbols=btc&legacy=true", "")
+
+		require.Equal(t, http.StatusOK, code, response)
+		assert.NotContains(t, response, "meta")
+		assert.Contains(t, response["data"], "BTC")
+		assert.Equal(t, 1.0, response["count"])
+		assert.Equal(t, []interface{}{"BTC"}, response["resolved"])
+	})
+
+	t.Run("Charts are bare objects", func(t *testing.T) {
+		code, response := serveEnvelope(t, router, http.MethodGet, "/api/v1/charts/dominance?legacy=true", "")
+
+		require.Equal(t, http.StatusOK, code, response)
+		assert.NotContains(t, response, "success")
+		assert.Contains(t, response, "values")
+		assert.Contains(t, response, "levels")
+	})
+
+	t.Run("Messages sit next to data", func(t *testing.T) {
+		code, response := serveEnvelope(t, router, http.MethodPut, "/api/v1/portfolios/1/holdings?legacy=true",
+			`[{"holding_id": 1, "amount": 2, "average_price": 30000}]`)
+
+		require.Equal(t, http.StatusOK, code, response)
+		assert.Equal(t, true, response["success"])
+		assert.Equal(t, "Holdings updated successfully", response["message"])
+		assert.Equal(t, map[string]interface{}{"updated": 1.0}, response["data"])
+	})
+
+	t.Run("Only a true flag opts in", func(t *testing.T) {
+		_, response := serveEnvelope(t, router, http.MethodGet, "/api/v1/charts/dominance?legacy=nope", "")
+
+		assert.Contains(t, response, "meta")
+		assert.Contains(t, response["data"], "values")
+	})
+}
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("test")
+
+	router := gin.New()
+	router.GET("/validation", func(c *gin.Context) {
+		respondError(c, log, errors.Validation("Invalid 'limit' parameter", "limit must be positive"))
+	})
+	router.GET("/plain", func(c *gin.Context) {
+		respondError(c, log, fmt.Errorf("connection reset"))
+	})
+	router.GET("/unavailable", func(c *gin.Context) {
+		respondUnavailable(c, "Annotation storage")
+	})
+
+	code, response := serveEnvelope(t, router, http.MethodGet, "/validation", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, map[string]interface{}{
+		"type":    string(errors.ErrorTypeValidation),
+		"message": "Invalid 'limit' parameter",
+		"details": "limit must be positive",
+	}, response["error"])
+
+	// Errors that are not AppErrors don't leak their text
+	code, response = serveEnvelope(t, router, http.MethodGet, "/plain", "")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, map[string]interface{}{
+		"type":    "INTERNAL_ERROR",
+		"message": "An internal error occurred",
+	}, response["error"])
+
+	code, response = serveEnvelope(t, router, http.MethodGet, "/unavailable", "")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, false, response["success"])
+	assert.Equal(t, map[string]interface{}{
+		"type":    "SERVICE_UNAVAILABLE",
+		"message": "Annotation storage not available",
+	}, response["error"])
+}
+
+func TestNewPagination(t *testing.T) {
+	middle := newPagination(10, 3, 3)
+	require.NotNil(t, middle.Next)
+	assert.Equal(t, 6, *middle.Next)
+	start, end := middle.bounds()
+	assert.Equal(t, [2]int{3, 6}, [2]int{start, end})
+
+	last := newPagination(10, 3, 9)
+	assert.Nil(t, last.Next)
+	start, end = last.bounds()
+	assert.Equal(t, [2]int{9, 10}, [2]int{start, end})
+
+	// A page that ends exactly on the last item has no next page
+	assert.Nil(t, newPagination(9, 3, 6).Next)
+
+	beyond := newPagination(4, 3, 8)
+	assert.Nil(t, beyond.Next)
+	start, end = beyond.bounds()
+	assert.Equal(t, [2]int{4, 4}, [2]int{start, end})
+}
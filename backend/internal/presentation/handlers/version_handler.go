@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"crypto-indicator-dashboard/pkg/buildinfo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionHandler reports which build is running
+type VersionHandler struct{}
+
+// NewVersionHandler creates a new version handler
+func NewVersionHandler() *VersionHandler {
+	return &VersionHandler{}
+}
+
+// RegisterRoutes registers the /version route
+func (h *VersionHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/version", h.GetVersion)
+}
+
+// GetVersion handles GET /version
+func (h *VersionHandler) GetVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, buildinfo.Get())
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"crypto-indicator-dashboard/pkg/buildinfo"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getVersion(t *testing.T) buildinfo.Info {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewVersionHandler().RegisterRoutes(router)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/version", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var info buildinfo.Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	return info
+}
+
+func TestVersionHandler_GetVersion(t *testing.T) {
+	version, commit, buildTime := buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime
+	defer func() {
+		buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime = version, commit, buildTime
+	}()
+
+	t.Run("Injected values", func(t *testing.T) {
+		buildinfo.Version = "v2.1.0"
+		buildinfo.Commit = "abc1234"
+		buildinfo.BuildTime = "2024-05-01T12:00:00Z"
+
+		assert.Equal(t, buildinfo.Info{
+			Version:   "v2.1.0",
+			Commit:    "abc1234",
+			BuildTime: "2024-05-01T12:00:00Z",
+			GoVersion: runtime.Version(),
+		}, getVersion(t))
+	})
+
+	t.Run("Defaults to dev when unset", func(t *testing.T) {
+		buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime = "", "", ""
+
+		info := getVersion(t)
+		assert.Equal(t, "dev", info.Version)
+		assert.Equal(t, "dev", info.Commit)
+		assert.Equal(t, "dev", info.BuildTime)
+	})
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// APIKeyHeader is the HTTP header carrying the caller's API key
+	APIKeyHeader = "X-API-Key"
+	// UserIDKey is the gin context key holding the authenticated user ID
+	UserIDKey = "user_id"
+	// apiKeyKey is the gin context key holding the authenticated API key
+	apiKeyKey = "api_key"
+)
+
+type userIDContextKey struct{}
+
+// APIKeyAuth creates a middleware that authenticates requests by API key and
+// attaches the owning user ID to the gin and request contexts
+func APIKeyAuth(repo repositories.APIKeyRepository, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if repo == nil {
+			abortWithError(c, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Authentication is not available")
+			return
+		}
+
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			abortUnauthorized(c, "API key is required")
+			return
+		}
+
+		ctx := c.Request.Context()
+		apiKey, err := repo.GetByHash(ctx, entities.HashAPIKey(key))
+		if err != nil {
+			if !errors.IsType(err, errors.ErrorTypeNotFound) {
+				log.WithContext(ctx).Error("Failed to look up API key", "error", err)
+			}
+			abortUnauthorized(c, "Invalid API key")
+			return
+		}
+
+		if !apiKey.IsValid(time.Now()) {
+			log.WithContext(ctx).Warn("Rejected inactive or expired API key", "key_id", apiKey.ID, "user_id", apiKey.UserID)
+			abortUnauthorized(c, "Invalid API key")
+			return
+		}
+
+		if err := repo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+			log.WithContext(ctx).Warn("Failed to record API key usage", "key_id", apiKey.ID, "error", err)
+		}
+
+		c.Set(UserIDKey, apiKey.UserID)
+		c.Set(apiKeyKey, apiKey)
+		c.Request = c.Request.WithContext(context.WithValue(ctx, userIDContextKey{}, apiKey.UserID))
+
+		c.Next()
+	}
+}
+
+// RequireScope creates a middleware that rejects requests whose API key lacks scope.
+// It must run after APIKeyAuth.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, ok := c.Get(apiKeyKey)
+		if !ok {
+			abortUnauthorized(c, "API key is required")
+			return
+		}
+
+		if !apiKey.(*entities.APIKey).HasScope(scope) {
+			abortWithError(c, http.StatusForbidden, string(errors.ErrorTypeForbidden), "API key lacks the "+scope+" scope")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetUserID returns the authenticated user ID stored on the gin context
+func GetUserID(c *gin.Context) (string, bool) {
+	userID := c.GetString(UserIDKey)
+	return userID, userID != ""
+}
+
+// UserIDFromContext returns the authenticated user ID stored on a request context
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDContextKey{}).(string)
+	return userID, ok && userID != ""
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	abortWithError(c, http.StatusUnauthorized, string(errors.ErrorTypeUnauthorized), message)
+}
+
+func abortWithError(c *gin.Context, status int, errorType, message string) {
+	c.JSON(status, gin.H{
+		"success": false,
+		"error": gin.H{
+			"type":    errorType,
+			"message": message,
+		},
+	})
+	c.Abort()
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
+	"crypto-indicator-dashboard/pkg/errors"
+	"crypto-indicator-dashboard/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIKeyRepository is an in-memory APIKeyRepository keyed by hash
+type fakeAPIKeyRepository struct {
+	keys map[string]*entities.APIKey
+}
+
+func newFakeAPIKeyRepository() *fakeAPIKeyRepository {
+	return &fakeAPIKeyRepository{keys: make(map[string]*entities.APIKey)}
+}
+
+func (r *fakeAPIKeyRepository) Create(ctx context.Context, apiKey *entities.APIKey) error {
+	apiKey.ID = uint(len(r.keys) + 1)
+	r.keys[apiKey.KeyHash] = apiKey
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	apiKey, ok := r.keys[keyHash]
+	if !ok {
+		return nil, errors.NotFound("api key")
+	}
+	return apiKey, nil
+}
+
+func (r *fakeAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint) error {
+	return nil
+}
+
+func setupAPIKeyRouter(t *testing.T) (*gin.Engine, repositories.APIKeyRepository) {
+	repo := newFakeAPIKeyRepository()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/protected", APIKeyAuth(repo, logger.New("test")), func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		ctxUserID, _ := UserIDFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "context_user_id": ctxUserID})
+	})
+	return router, repo
+}
+
+func createAPIKey(t *testing.T, repo repositories.APIKeyRepository, key, userID string, active bool, expiresAt *time.Time) {
+	apiKey := &entities.APIKey{
+		UserID:    userID,
+		Name:      "test",
+		KeyHash:   entities.HashAPIKey(key),
+		Active:    active,
+		ExpiresAt: expiresAt,
+	}
+	require.NoError(t, repo.Create(context.Background(), apiKey))
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	router, repo := setupAPIKeyRouter(t)
+
+	past := time.Now().Add(-time.Hour)
+	createAPIKey(t, repo, "valid-key", "user-1", true, nil)
+	createAPIKey(t, repo, "expired-key", "user-2", true, &past)
+	createAPIKey(t, repo, "revoked-key", "user-3", false, nil)
+
+	tests := []struct {
+		name           string
+		key            string
+		expectedStatus int
+	}{
+		{name: "Valid key", key: "valid-key", expectedStatus: http.StatusOK},
+		{name: "Invalid key", key: "unknown-key", expectedStatus: http.StatusUnauthorized},
+		{name: "Missing key", key: "", expectedStatus: http.StatusUnauthorized},
+		{name: "Expired key", key: "expired-key", expectedStatus: http.StatusUnauthorized},
+		{name: "Revoked key", key: "revoked-key", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Contains(t, w.Body.String(), `"type":"UNAUTHORIZED"`)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_UserIDAvailableDownstream(t *testing.T) {
+	router, repo := setupAPIKeyRouter(t)
+	createAPIKey(t, repo, "valid-key", "user-42", true, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(APIKeyHeader, "valid-key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"user_id":"user-42","context_user_id":"user-42"}`, w.Body.String())
+}
+
+func TestAPIKeyAuth_NilRepository(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/protected", APIKeyAuth(nil, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(APIKeyHeader, "any-key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestRequireScope(t *testing.T) {
+	repo := newFakeAPIKeyRepository()
+	createAPIKey(t, repo, "user-key", "user-1", true, nil)
+	require.NoError(t, repo.Create(context.Background(), &entities.APIKey{
+		UserID:  "admin-1",
+		KeyHash: entities.HashAPIKey("admin-key"),
+		Scopes:  "read, " + entities.APIKeyScopeAdmin,
+		Active:  true,
+	}))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin", APIKeyAuth(repo, logger.New("test")), RequireScope(entities.APIKeyScopeAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/unauthenticated", RequireScope(entities.APIKeyScopeAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		key            string
+		expectedStatus int
+	}{
+		{name: "Admin key", path: "/admin", key: "admin-key", expectedStatus: http.StatusOK},
+		{name: "Key without scope", path: "/admin", key: "user-key", expectedStatus: http.StatusForbidden},
+		{name: "Missing key", path: "/admin", expectedStatus: http.StatusUnauthorized},
+		{name: "Without APIKeyAuth", path: "/unauthenticated", key: "admin-key", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				assert.Contains(t, w.Body.String(), `"type":"FORBIDDEN"`)
+			}
+		})
+	}
+}
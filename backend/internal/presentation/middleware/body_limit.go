@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"crypto-indicator-dashboard/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodyBytes creates a middleware that limits POST, PUT and PATCH request bodies to n bytes.
+// Requests declaring a larger Content-Length are rejected with 413 up front; bodies without
+// one are cut off at n bytes, which fails binding with http.MaxBytesError. A non-positive n
+// disables the limit.
+func MaxBodyBytes(n int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			c.Next()
+			return
+		}
+		if n <= 0 {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > n {
+			abortWithError(c, http.StatusRequestEntityTooLarge, string(errors.ErrorTypePayloadTooLarge),
+				fmt.Sprintf("Request body exceeds %d bytes", n))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, n)
+		c.Next()
+	}
+}
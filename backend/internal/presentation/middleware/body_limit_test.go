@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBodyLimitRouter(limit int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MaxBodyBytes(limit))
+
+	echo := func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.String(http.StatusOK, "%d", len(body))
+	}
+	router.POST("/echo", echo)
+	router.GET("/echo", echo)
+	return router
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	router := newBodyLimitRouter(16)
+
+	t.Run("Body within the limit passes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"a":1}`)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "7", w.Body.String())
+	})
+
+	t.Run("Declared oversized body is rejected before the handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(strings.Repeat("x", 17))))
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, false, response["success"])
+		assert.Equal(t, "PAYLOAD_TOO_LARGE", response["error"].(map[string]interface{})["type"])
+	})
+
+	t.Run("Undeclared oversized body is cut off", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(strings.Repeat("x", 64)))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("GET requests are not limited", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/echo", strings.NewReader(strings.Repeat("x", 64))))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
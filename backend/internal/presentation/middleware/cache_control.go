@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheRule lets browsers and CDNs cache successful GET responses of routes under Prefix
+// for MaxAge. Prefix is matched against the route pattern, so /api/v1/charts covers
+// /api/v1/charts/:indicator.
+type CacheRule struct {
+	Prefix string
+	MaxAge time.Duration
+}
+
+// CacheControl creates a middleware that sets Cache-Control: public, max-age on 200 and 304
+// responses to GET and HEAD requests. The rule with the longest matching prefix applies,
+// replacing any Cache-Control the handler set; rules with a non-positive MaxAge and routes
+// without a rule are left alone. Errors are never marked cacheable.
+func CacheControl(rules ...CacheRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		maxAge, ok := cacheMaxAge(rules, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheControlWriter{
+			ResponseWriter: c.Writer,
+			value:          fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())),
+		}
+		c.Next()
+	}
+}
+
+// cacheMaxAge returns the max age of the longest rule prefix matching route
+func cacheMaxAge(rules []CacheRule, route string) (time.Duration, bool) {
+	if route == "" {
+		return 0, false
+	}
+
+	var best *CacheRule
+	for i := range rules {
+		rule := &rules[i]
+		if route != rule.Prefix && !strings.HasPrefix(route, strings.TrimSuffix(rule.Prefix, "/")+"/") {
+			continue
+		}
+		if best == nil || len(rule.Prefix) > len(best.Prefix) {
+			best = rule
+		}
+	}
+	if best == nil || best.MaxAge <= 0 {
+		return 0, false
+	}
+	return best.MaxAge, true
+}
+
+// cacheControlWriter sets Cache-Control as the status is written, once it is known the
+// response succeeded
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code == http.StatusOK || code == http.StatusNotModified {
+		w.Header().Set("Cache-Control", w.value)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.WriteHeader(w.Status())
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCacheControlRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CacheControl(
+		CacheRule{Prefix: "/api/v1/indicators", MaxAge: time.Minute},
+		CacheRule{Prefix: "/api/v1/indicators/live", MaxAge: 0},
+		CacheRule{Prefix: "/api/v1/charts", MaxAge: 5 * time.Minute},
+		CacheRule{Prefix: "/swagger", MaxAge: time.Hour},
+	))
+
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) }
+	router.GET("/api/v1/indicators/mvrv", ok)
+	router.GET("/api/v1/indicators/live", ok)
+	router.POST("/api/v1/indicators/bulk", ok)
+	router.GET("/api/v1/indicators/broken", func(c *gin.Context) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"success": false})
+	})
+	router.GET("/api/v1/indicators/etag", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.Header("Cache-Control", "no-cache")
+		c.Status(http.StatusNotModified)
+	})
+	router.GET("/api/v1/charts/:indicator", ok)
+	router.GET("/swagger/doc.json", func(c *gin.Context) { c.Data(http.StatusOK, "application/json", []byte(`{}`)) })
+	router.GET("/api/v1/indicatorsx", ok)
+	router.GET("/api/v1/portfolios", ok)
+	return router
+}
+
+func TestCacheControl(t *testing.T) {
+	router := newCacheControlRouter()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   string
+	}{
+		{"Indicator", http.MethodGet, "/api/v1/indicators/mvrv", "public, max-age=60"},
+		{"Chart route pattern", http.MethodGet, "/api/v1/charts/mvrv", "public, max-age=300"},
+		{"API docs", http.MethodGet, "/swagger/doc.json", "public, max-age=3600"},
+		{"Not modified replaces no-cache", http.MethodGet, "/api/v1/indicators/etag", "public, max-age=60"},
+		{"Longer prefix disables caching", http.MethodGet, "/api/v1/indicators/live", ""},
+		{"Error is not cacheable", http.MethodGet, "/api/v1/indicators/broken", ""},
+		{"Writes are not cacheable", http.MethodPost, "/api/v1/indicators/bulk", ""},
+		{"Prefix matches whole segments", http.MethodGet, "/api/v1/indicatorsx", ""},
+		{"Route without a rule", http.MethodGet, "/api/v1/portfolios", ""},
+		{"Unknown route", http.MethodGet, "/api/v1/indicators/mvrv/unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.want, w.Header().Get("Cache-Control"))
+		})
+	}
+}
@@ -31,6 +31,7 @@ func CORS(cfg *config.Config) gin.HandlerFunc {
 			"Authorization",
 			"X-Requested-With",
 			"X-Request-ID",
+			"X-API-Key",
 		},
 		ExposeHeaders: []string{
 			"Content-Length",
@@ -11,6 +11,7 @@ func RequestLogging(logger logger.Logger) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		// Custom log format
 		logger.Info("HTTP Request",
+			"request_id", param.Keys[RequestIDKey],
 			"timestamp", param.TimeStamp.Format(time.RFC3339),
 			"status", param.StatusCode,
 			"latency", param.Latency,
@@ -27,7 +28,7 @@ func RequestLogging(logger logger.Logger) gin.HandlerFunc {
 // ErrorLogging creates an error logging middleware
 func ErrorLogging(logger logger.Logger) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		logger.Error("Panic recovered",
+		logger.WithContext(c.Request.Context()).Error("Panic recovered",
 			"error", recovered,
 			"path", c.Request.URL.Path,
 			"method", c.Request.Method,
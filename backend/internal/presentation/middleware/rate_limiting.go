@@ -37,9 +37,20 @@ func NewRateLimiter(requestsPerMinute int, logger logger.Logger) *RateLimiter {
 	return rl
 }
 
-// RateLimit returns a rate limiting middleware
-func (rl *RateLimiter) RateLimit() gin.HandlerFunc {
+// RateLimit returns a rate limiting middleware. Requests for any of exemptPaths, such as
+// health and metrics probes, pass through without counting against the client's limit.
+func (rl *RateLimiter) RateLimit(exemptPaths ...string) gin.HandlerFunc {
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, path := range exemptPaths {
+		exempt[path] = true
+	}
+
 	return func(c *gin.Context) {
+		if exempt[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
 		clientIP := c.ClientIP()
 		
 		if !rl.allow(clientIP) {
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_ExemptPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const limit = 5
+
+	router := gin.New()
+	router.Use(NewRateLimiter(limit, logger.New("test")).RateLimit("/health", "/metrics"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/health", ok)
+	router.GET("/api/v1/indicators/mvrv", ok)
+
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	// Exempt probes neither get throttled nor use up the client's budget
+	for i := 0; i < limit*4; i++ {
+		assert.Equal(t, http.StatusOK, get("/health"), "health request %d", i+1)
+	}
+
+	for i := 0; i < limit; i++ {
+		assert.Equal(t, http.StatusOK, get("/api/v1/indicators/mvrv"), "API request %d", i+1)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get("/api/v1/indicators/mvrv"))
+
+	for i := 0; i < limit*2; i++ {
+		assert.Equal(t, http.StatusOK, get("/health"), "health request %d after the limit", i+1)
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"crypto-indicator-dashboard/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader is the HTTP header carrying the request correlation ID
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey is the gin context key holding the request correlation ID
+	RequestIDKey = "request_id"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID creates a middleware that accepts or generates a request ID and
+// propagates it through the gin context, the request context and the response
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = generateRequestID()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the request ID stored on the gin context
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(RequestIDKey)
+}
+
+// generateRequestID creates a random 128-bit hex request ID
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"crypto-indicator-dashboard/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRequestIDRouter(log logger.Logger) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(RequestLogging(log))
+	router.GET("/test", func(c *gin.Context) {
+		// Simulate a downstream component logging with the request context
+		log.WithContext(c.Request.Context()).Info("Downstream call")
+		c.JSON(http.StatusOK, gin.H{"success": true, "request_id": GetRequestID(c)})
+	})
+	return router
+}
+
+func findLogLine(t *testing.T, output, msg string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, msg) {
+			return line
+		}
+	}
+	t.Fatalf("log line %q not found in output:\n%s", msg, output)
+	return ""
+}
+
+func TestRequestID_PropagatesProvidedID(t *testing.T) {
+	var buf bytes.Buffer
+	router := setupRequestIDRouter(logger.NewWithOutput("test", &buf))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set(RequestIDHeader, "req-12345")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "req-12345", w.Header().Get(RequestIDHeader))
+	assert.Contains(t, w.Body.String(), `"request_id":"req-12345"`)
+
+	output := buf.String()
+	assert.Contains(t, findLogLine(t, output, "HTTP Request"), "request_id=req-12345")
+	assert.Contains(t, findLogLine(t, output, "Downstream call"), "request_id=req-12345")
+}
+
+func TestRequestID_GeneratesIDWhenMissing(t *testing.T) {
+	var buf bytes.Buffer
+	router := setupRequestIDRouter(logger.NewWithOutput("test", &buf))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	requestID := w.Header().Get(RequestIDHeader)
+	require.Len(t, requestID, 32)
+
+	output := buf.String()
+	assert.Contains(t, findLogLine(t, output, "HTTP Request"), "request_id="+requestID)
+	assert.Contains(t, findLogLine(t, output, "Downstream call"), "request_id="+requestID)
+}
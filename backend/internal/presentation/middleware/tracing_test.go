@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crypto-indicator-dashboard/internal/infrastructure/external"
+	"crypto-indicator-dashboard/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useInMemoryTracing records spans synchronously for the duration of the test
+func useInMemoryTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return exporter
+}
+
+func TestTracing_NestsExternalClientSpans(t *testing.T) {
+	exporter := useInMemoryTracing(t)
+
+	var upstreamTraceparent string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamTraceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"symbol":"BTCUSDT","price":"67712.34"}`))
+	}))
+	defer upstream.Close()
+
+	binance := external.NewBinanceClientWithBaseURL(upstream.URL, logger.New("test"))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(Tracing())
+	router.GET("/prices/:symbol", func(c *gin.Context) {
+		price, err := binance.GetPrice(c.Request.Context(), c.Param("symbol"))
+		if err != nil {
+			c.Status(http.StatusBadGateway)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"price": price})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/prices/BTCUSDT", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2, "one server span and one upstream client span")
+
+	var server, client tracetest.SpanStub
+	for _, span := range spans {
+		switch span.SpanKind {
+		case trace.SpanKindServer:
+			server = span
+		case trace.SpanKindClient:
+			client = span
+		}
+	}
+
+	assert.Equal(t, "GET /prices/:symbol", server.Name)
+	assert.False(t, server.Parent.IsValid(), "the request span is the trace root")
+	assert.Equal(t, server.SpanContext.TraceID(), client.SpanContext.TraceID())
+	assert.Equal(t, server.SpanContext.SpanID(), client.Parent.SpanID(), "the upstream call nests under the request")
+
+	// The upstream sees the client span as the caller
+	require.NotEmpty(t, upstreamTraceparent)
+	assert.Contains(t, upstreamTraceparent, client.SpanContext.TraceID().String())
+	assert.Contains(t, upstreamTraceparent, client.SpanContext.SpanID().String())
+}
+
+func TestTracing_ContinuesIncomingTrace(t *testing.T) {
+	exporter := useInMemoryTracing(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Tracing())
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	assert.Equal(t, "Error", spans[0].Status.Code.String(), "server errors mark the span as failed")
+}
@@ -3,6 +3,7 @@ package testutil
 import (
 	"context"
 	"crypto-indicator-dashboard/internal/domain/entities"
+	"crypto-indicator-dashboard/internal/domain/repositories"
 	"crypto-indicator-dashboard/internal/infrastructure/external"
 	"time"
 
@@ -93,6 +94,12 @@ func (m *MockCacheService) Clear(ctx context.Context) error {
 	return args.Error(0)
 }
 
+// DeletePattern removes all values whose keys match a pattern
+func (m *MockCacheService) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	args := m.Called(ctx, pattern)
+	return args.Int(0), args.Error(1)
+}
+
 // Keys returns all keys matching a pattern
 func (m *MockCacheService) Keys(ctx context.Context, pattern string) ([]string, error) {
 	args := m.Called(ctx, pattern)
@@ -157,11 +164,34 @@ func (m *MockIndicatorRepository) GetLatest(ctx context.Context, name string) (*
 	return args.Get(0).(*entities.Indicator), args.Error(1)
 }
 
+func (m *MockIndicatorRepository) GetNearest(ctx context.Context, name string, at time.Time) (*entities.Indicator, error) {
+	args := m.Called(ctx, name, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Indicator), args.Error(1)
+}
+
 func (m *MockIndicatorRepository) GetLatestByType(ctx context.Context, indicatorType string) ([]entities.Indicator, error) {
 	args := m.Called(ctx, indicatorType)
 	return args.Get(0).([]entities.Indicator), args.Error(1)
 }
 
+func (m *MockIndicatorRepository) GetLatestForTypes(ctx context.Context, types []string) ([]entities.Indicator, error) {
+	args := m.Called(ctx, types)
+	return args.Get(0).([]entities.Indicator), args.Error(1)
+}
+
+func (m *MockIndicatorRepository) GetLatestHighConfidence(ctx context.Context, indicatorType string, minConfidence float64) ([]entities.Indicator, error) {
+	args := m.Called(ctx, indicatorType, minConfidence)
+	return args.Get(0).([]entities.Indicator), args.Error(1)
+}
+
+func (m *MockIndicatorRepository) GetLatestByTypeInRange(ctx context.Context, indicatorType string, from, to time.Time, limit int) ([]entities.Indicator, error) {
+	args := m.Called(ctx, indicatorType, from, to, limit)
+	return args.Get(0).([]entities.Indicator), args.Error(1)
+}
+
 func (m *MockIndicatorRepository) GetHistoricalData(ctx context.Context, name string, from, to time.Time) ([]entities.Indicator, error) {
 	args := m.Called(ctx, name, from, to)
 	return args.Get(0).([]entities.Indicator), args.Error(1)
@@ -182,9 +212,9 @@ func (m *MockIndicatorRepository) BulkCreate(ctx context.Context, indicators []e
 	return args.Error(0)
 }
 
-func (m *MockIndicatorRepository) CleanupOldData(ctx context.Context, olderThan time.Time) error {
+func (m *MockIndicatorRepository) CleanupOldData(ctx context.Context, olderThan time.Time) (int64, error) {
 	args := m.Called(ctx, olderThan)
-	return args.Error(0)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 // MockMarketDataRepository is a mock implementation of MarketDataRepository
@@ -249,43 +279,253 @@ func (m *MockMarketDataRepository) GetLatestMarketMetrics(ctx context.Context) (
 	return args.Get(0).(*entities.MarketMetrics), args.Error(1)
 }
 
+// MockPortfolioRepository is a mock implementation of PortfolioRepository
+type MockPortfolioRepository struct {
+	mock.Mock
+}
+
+func (m *MockPortfolioRepository) Create(ctx context.Context, portfolio *entities.Portfolio) error {
+	args := m.Called(ctx, portfolio)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) GetByID(ctx context.Context, id uint) (*entities.Portfolio, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Portfolio), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetByUserID(ctx context.Context, userID string) ([]entities.Portfolio, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]entities.Portfolio), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) Update(ctx context.Context, portfolio *entities.Portfolio) error {
+	args := m.Called(ctx, portfolio)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) AddHolding(ctx context.Context, portfolioID uint, holding *entities.PortfolioHolding) error {
+	args := m.Called(ctx, portfolioID, holding)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) UpdateHolding(ctx context.Context, holding *entities.PortfolioHolding) error {
+	args := m.Called(ctx, holding)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) UpdateHoldings(ctx context.Context, portfolioID uint, holdings []entities.PortfolioHolding) error {
+	args := m.Called(ctx, portfolioID, holdings)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) RemoveHolding(ctx context.Context, holdingID uint) error {
+	args := m.Called(ctx, holdingID)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) ClearHoldings(ctx context.Context, portfolioID uint, archive bool) (int, error) {
+	args := m.Called(ctx, portfolioID, archive)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetHolding(ctx context.Context, holdingID uint) (*entities.PortfolioHolding, error) {
+	args := m.Called(ctx, holdingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PortfolioHolding), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetHoldings(ctx context.Context, portfolioID uint) ([]entities.PortfolioHolding, error) {
+	args := m.Called(ctx, portfolioID)
+	return args.Get(0).([]entities.PortfolioHolding), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetActivePortfolioIDs(ctx context.Context) ([]uint, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetLots(ctx context.Context, holdingID uint) ([]entities.HoldingLot, error) {
+	args := m.Called(ctx, holdingID)
+	return args.Get(0).([]entities.HoldingLot), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) RecordTransaction(ctx context.Context, holdingID uint, apply repositories.HoldingTransactionFunc) error {
+	args := m.Called(ctx, holdingID, apply)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) CalculateTotalValue(ctx context.Context, portfolioID uint) (float64, error) {
+	args := m.Called(ctx, portfolioID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetPortfolioSummary(ctx context.Context, portfolioID uint) (*entities.PortfolioSummary, error) {
+	args := m.Called(ctx, portfolioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PortfolioSummary), args.Error(1)
+}
+
+// MockMarketDataService is a mock implementation of MarketDataService
+type MockMarketDataService struct {
+	mock.Mock
+}
+
+func (m *MockMarketDataService) GetCryptoPrices(ctx context.Context, symbols []string) (map[string]*entities.CryptoPrice, []string, error) {
+	args := m.Called(ctx, symbols)
+	var prices map[string]*entities.CryptoPrice
+	if args.Get(0) != nil {
+		prices = args.Get(0).(map[string]*entities.CryptoPrice)
+	}
+	var unresolved []string
+	if args.Get(1) != nil {
+		unresolved = args.Get(1).([]string)
+	}
+	return prices, unresolved, args.Error(2)
+}
+
+func (m *MockMarketDataService) RefreshMarketMetrics(ctx context.Context) (*entities.MarketMetrics, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MarketMetrics), args.Error(1)
+}
+
+func (m *MockMarketDataService) GetLatestMarketMetrics(ctx context.Context) (*entities.MarketMetrics, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MarketMetrics), args.Error(1)
+}
+
+func (m *MockMarketDataService) GetBitcoinDominance(ctx context.Context) (*entities.BitcoinDominance, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BitcoinDominance), args.Error(1)
+}
+
+func (m *MockMarketDataService) GetMultipleCryptoPrices(ctx context.Context) (map[string]*entities.CryptoPrice, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*entities.CryptoPrice), args.Error(1)
+}
+
+func (m *MockMarketDataService) GetTopCryptoPrices(ctx context.Context, count int) (map[string]*entities.CryptoPrice, error) {
+	args := m.Called(ctx, count)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*entities.CryptoPrice), args.Error(1)
+}
+
+func (m *MockMarketDataService) RefreshAllMarketData(ctx context.Context) (*entities.MarketDataRefresh, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.MarketDataRefresh), args.Error(1)
+}
+
+func (m *MockMarketDataService) HealthCheck(ctx context.Context) map[string]entities.ProviderHealthResult {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(map[string]entities.ProviderHealthResult)
+}
+
 // MockCoinCapClient is a mock implementation of CoinCap client
 type MockCoinCapClient struct {
 	mock.Mock
 }
 
-func (m *MockCoinCapClient) GetAssets(limit int) (*external.AssetsResponse, error) {
-	args := m.Called(limit)
+func (m *MockCoinCapClient) GetAssets(ctx context.Context, limit int) (*external.AssetsResponse, error) {
+	args := m.Called(ctx, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*external.AssetsResponse), args.Error(1)
 }
 
-func (m *MockCoinCapClient) GetAsset(assetID string) (*external.AssetResponse, error) {
-	args := m.Called(assetID)
+func (m *MockCoinCapClient) GetAsset(ctx context.Context, assetID string) (*external.AssetResponse, error) {
+	args := m.Called(ctx, assetID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*external.AssetResponse), args.Error(1)
 }
 
-func (m *MockCoinCapClient) GetBitcoinPrice() (float64, error) {
-	args := m.Called()
+func (m *MockCoinCapClient) GetMarkets(ctx context.Context, assetID string, limit int) (*external.MarketsResponse, error) {
+	args := m.Called(ctx, assetID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.MarketsResponse), args.Error(1)
+}
+
+func (m *MockCoinCapClient) GetBitcoinPrice(ctx context.Context) (float64, error) {
+	args := m.Called(ctx)
 	return args.Get(0).(float64), args.Error(1)
 }
 
-func (m *MockCoinCapClient) GetGlobalMarketData() (map[string]interface{}, error) {
-	args := m.Called()
+func (m *MockCoinCapClient) GetGlobalMarketData(ctx context.Context) (map[string]interface{}, error) {
+	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(map[string]interface{}), args.Error(1)
 }
 
-func (m *MockCoinCapClient) HealthCheck() error {
-	args := m.Called()
-	return args.Error(0)
+func (m *MockCoinCapClient) HealthCheck(ctx context.Context) (time.Duration, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+// MockCoinMarketCapClient is a mock implementation of the CoinMarketCap listings client
+type MockCoinMarketCapClient struct {
+	mock.Mock
+}
+
+func (m *MockCoinMarketCapClient) GetListingsLatest(ctx context.Context, limit int, convert string) (*external.ListingsLatestResponse, error) {
+	args := m.Called(ctx, limit, convert)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.ListingsLatestResponse), args.Error(1)
+}
+
+func (m *MockCoinMarketCapClient) GetCryptocurrencyMap(ctx context.Context, symbols []string) (*external.CryptocurrencyMapResponse, error) {
+	args := m.Called(ctx, symbols)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.CryptocurrencyMapResponse), args.Error(1)
+}
+
+func (m *MockCoinMarketCapClient) GetCryptocurrencyInfo(ctx context.Context, ids []int) (*external.CryptocurrencyInfoResponse, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.CryptocurrencyInfoResponse), args.Error(1)
 }
 
 // TestData provides common test data for tests
@@ -399,6 +639,12 @@ func (m *MockInfrastructureCacheService) Delete(ctx context.Context, key string)
 	return args.Error(0)
 }
 
+// DeletePattern removes values whose keys match a pattern
+func (m *MockInfrastructureCacheService) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	args := m.Called(ctx, pattern)
+	return args.Int(0), args.Error(1)
+}
+
 // Exists checks if a key exists
 func (m *MockInfrastructureCacheService) Exists(ctx context.Context, key string) (bool, error) {
 	args := m.Called(ctx, key)
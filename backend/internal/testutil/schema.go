@@ -0,0 +1,165 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Table DDL shared by tests. The tables are created manually to avoid GORM auto-migration
+// conflicts: the SQLite driver in use declares autoincrement keys twice.
+const (
+	IndicatorsTableDDL = `
+	CREATE TABLE IF NOT EXISTS indicators (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		type TEXT NOT NULL,
+		value REAL,
+		string_value TEXT,
+		change TEXT,
+		risk_level TEXT,
+		status TEXT,
+		description TEXT,
+		source TEXT,
+		confidence REAL,
+		metadata TEXT,
+		timestamp DATETIME,
+		version INTEGER NOT NULL DEFAULT 1,
+		calc_version INTEGER NOT NULL DEFAULT 1,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`
+
+	PortfoliosTableDDL = `
+	CREATE TABLE IF NOT EXISTS portfolios (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		total_value REAL,
+		risk_level TEXT,
+		last_updated DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`
+
+	PortfolioHoldingsTableDDL = `
+	CREATE TABLE IF NOT EXISTS portfolio_holdings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		portfolio_id INTEGER NOT NULL,
+		symbol TEXT NOT NULL,
+		amount REAL NOT NULL,
+		average_price REAL,
+		current_price REAL,
+		value REAL,
+		pn_l REAL,
+		pn_l_percent REAL,
+		realized_pn_l REAL DEFAULT 0,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`
+
+	ArchivedHoldingsTableDDL = `
+	CREATE TABLE IF NOT EXISTS archived_holdings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		holding_id INTEGER NOT NULL,
+		portfolio_id INTEGER NOT NULL,
+		symbol TEXT NOT NULL,
+		amount REAL NOT NULL,
+		average_price REAL,
+		current_price REAL,
+		value REAL,
+		realized_pn_l REAL,
+		held_since DATETIME,
+		archived_at DATETIME NOT NULL
+	)`
+
+	HoldingLotsTableDDL = `
+	CREATE TABLE IF NOT EXISTS holding_lots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		holding_id INTEGER NOT NULL,
+		quantity REAL NOT NULL,
+		remaining REAL NOT NULL,
+		price REAL NOT NULL,
+		acquired_at DATETIME NOT NULL,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`
+
+	HoldingTransactionsTableDDL = `
+	CREATE TABLE IF NOT EXISTS holding_transactions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		holding_id INTEGER NOT NULL,
+		side TEXT NOT NULL,
+		quantity REAL NOT NULL,
+		price REAL NOT NULL,
+		realized_pn_l REAL,
+		executed_at DATETIME NOT NULL,
+		created_at DATETIME
+	)`
+
+	AnnotationsTableDDL = `
+	CREATE TABLE IF NOT EXISTS indicator_annotations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		timestamp DATETIME NOT NULL,
+		label TEXT NOT NULL,
+		type TEXT NOT NULL,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`
+
+	ProviderHealthTableDDL = `
+	CREATE TABLE IF NOT EXISTS provider_health_checks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		provider TEXT NOT NULL,
+		healthy NUMERIC,
+		error TEXT,
+		latency_ms REAL,
+		checked_at DATETIME NOT NULL
+	)`
+
+	PriceDataTableDDL = `
+	CREATE TABLE IF NOT EXISTS price_data (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		timestamp DATETIME NOT NULL,
+		asset_symbol TEXT NOT NULL,
+		price_usd REAL NOT NULL,
+		market_cap REAL,
+		volume_24h REAL,
+		data_source TEXT NOT NULL,
+		reliability_score REAL,
+		created_at DATETIME
+	)`
+
+	IndicatorConfigsTableDDL = `
+	CREATE TABLE IF NOT EXISTS indicator_configs (
+		name TEXT PRIMARY KEY,
+		description TEXT,
+		bands TEXT NOT NULL,
+		updated_at DATETIME
+	)`
+
+	CompositeWeightsTableDDL = `
+	CREATE TABLE IF NOT EXISTS composite_weight_configs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		weights TEXT NOT NULL,
+		created_at DATETIME
+	)`
+)
+
+// PortfolioTablesDDL creates every table a portfolio and its holdings are stored in
+var PortfolioTablesDDL = []string{
+	PortfoliosTableDDL,
+	PortfolioHoldingsTableDDL,
+	ArchivedHoldingsTableDDL,
+	HoldingLotsTableDDL,
+	HoldingTransactionsTableDDL,
+}
+
+// CreateTables runs table DDL such as IndicatorsTableDDL against the test database
+func (tdb *TestDB) CreateTables(t *testing.T, ddl ...string) {
+	t.Helper()
+
+	for _, statement := range ddl {
+		require.NoError(t, tdb.DB.Exec(statement).Error, "Failed to create test table")
+	}
+}
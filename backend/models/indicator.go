@@ -2,8 +2,6 @@ package models
 
 import (
 	"time"
-	"gorm.io/gorm"
-	"crypto-indicator-dashboard/internal/domain/entities"
 )
 
 // Indicator represents a market indicator
@@ -19,21 +17,14 @@ type Indicator struct {
 	Description string    `json:"description"`
 	Source      string    `json:"source"`
 	Timestamp   time.Time `json:"timestamp" gorm:"not null;index"`
+	Version     uint      `json:"version" gorm:"not null;default:1"`
+	// CalcVersion is added with a default of 1, so values stored before
+	// calculations were versioned count as version 1
+	CalcVersion uint      `json:"calc_version" gorm:"not null;default:1;index"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-// PriceData represents historical price data
-type PriceData struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	Symbol    string    `json:"symbol" gorm:"not null;index"`
-	Price     float64   `json:"price" gorm:"not null"`
-	Volume    float64   `json:"volume"`
-	MarketCap float64   `json:"market_cap"`
-	Timestamp time.Time `json:"timestamp" gorm:"not null;index"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
 // OnChainData represents blockchain-specific metrics
 type OnChainData struct {
 	ID               uint      `json:"id" gorm:"primarykey"`
@@ -86,10 +77,50 @@ type PortfolioHolding struct {
 	Value        float64 `json:"value"`
 	PnL          float64 `json:"pnl"`
 	PnLPercent   float64 `json:"pnl_percent"`
+	RealizedPnL  float64 `json:"realized_pnl" gorm:"default:0"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// ArchivedHolding is a copy of a holding removed when its portfolio was cleared with archiving
+type ArchivedHolding struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	HoldingID    uint      `json:"holding_id" gorm:"not null"`
+	PortfolioID  uint      `json:"portfolio_id" gorm:"not null;index"`
+	Symbol       string    `json:"symbol" gorm:"not null"`
+	Amount       float64   `json:"amount" gorm:"not null"`
+	AveragePrice float64   `json:"average_price"`
+	CurrentPrice float64   `json:"current_price"`
+	Value        float64   `json:"value"`
+	RealizedPnL  float64   `json:"realized_pnl"`
+	HeldSince    time.Time `json:"held_since"`
+	ArchivedAt   time.Time `json:"archived_at" gorm:"not null"`
+}
+
+// HoldingLot represents the quantity acquired by a single buy of a holding
+type HoldingLot struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	HoldingID  uint      `json:"holding_id" gorm:"not null;index"`
+	Quantity   float64   `json:"quantity" gorm:"not null"`
+	Remaining  float64   `json:"remaining" gorm:"not null"`
+	Price      float64   `json:"price" gorm:"not null"`
+	AcquiredAt time.Time `json:"acquired_at" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// HoldingTransaction represents a buy or sell recorded against a holding
+type HoldingTransaction struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	HoldingID   uint      `json:"holding_id" gorm:"not null;index"`
+	Side        string    `json:"side" gorm:"not null"` // buy, sell
+	Quantity    float64   `json:"quantity" gorm:"not null"`
+	Price       float64   `json:"price" gorm:"not null"`
+	RealizedPnL float64   `json:"realized_pnl"`
+	ExecutedAt  time.Time `json:"executed_at" gorm:"not null;index"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // MarketCycle represents market cycle analysis
 type MarketCycle struct {
 	ID               uint      `json:"id" gorm:"primarykey"`
@@ -167,27 +198,3 @@ type DCASimulation struct {
 	AvgFearGreedAtPurchase int  `json:"avg_fear_greed_at_purchase"`
 	CreatedAt         time.Time `json:"created_at"`
 }
-
-// AutoMigrate runs database migrations
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		// Legacy models
-		&Indicator{},
-		&PriceData{},
-		&OnChainData{},
-		&MacroData{},
-		&Portfolio{},
-		&PortfolioHolding{},
-		&MarketCycle{},
-		&DCAStrategy{},
-		&DCAPurchase{},
-		&DCASimulation{},
-		// New architecture entities
-		&entities.CryptoPrice{},
-		&entities.BitcoinDominance{},
-		&entities.MarketMetrics{},
-		&entities.PriceAlert{},
-		&entities.TradingPair{},
-		&entities.MarketData{},
-	)
-}
\ No newline at end of file
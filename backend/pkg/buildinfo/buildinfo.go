@@ -0,0 +1,45 @@
+// Package buildinfo exposes version details stamped into the binary at build time:
+//
+//	go build -ldflags "\
+//	  -X crypto-indicator-dashboard/pkg/buildinfo.Version=v2.1.0 \
+//	  -X crypto-indicator-dashboard/pkg/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X crypto-indicator-dashboard/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
+//	  -o crypto-indicator-dashboard ./cmd/server
+package buildinfo
+
+import "runtime"
+
+// Set via -ldflags -X; left empty by plain `go build` and `go run`
+var (
+	Version   string
+	Commit    string
+	BuildTime string
+)
+
+// unset is reported for any value not provided at build time
+const unset = "dev"
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build info, reporting "dev" for values that weren't injected
+func Get() Info {
+	return Info{
+		Version:   orUnset(Version),
+		Commit:    orUnset(Commit),
+		BuildTime: orUnset(BuildTime),
+		GoVersion: runtime.Version(),
+	}
+}
+
+func orUnset(value string) string {
+	if value == "" {
+		return unset
+	}
+	return value
+}
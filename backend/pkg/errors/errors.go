@@ -9,15 +9,16 @@ import (
 type ErrorType string
 
 const (
-	ErrorTypeValidation    ErrorType = "VALIDATION_ERROR"
-	ErrorTypeNotFound      ErrorType = "NOT_FOUND"
-	ErrorTypeUnauthorized  ErrorType = "UNAUTHORIZED"
-	ErrorTypeForbidden     ErrorType = "FORBIDDEN"
-	ErrorTypeConflict      ErrorType = "CONFLICT"
-	ErrorTypeInternal      ErrorType = "INTERNAL_ERROR"
-	ErrorTypeExternal      ErrorType = "EXTERNAL_SERVICE_ERROR"
-	ErrorTypeRateLimit     ErrorType = "RATE_LIMIT_ERROR"
-	ErrorTypeTimeout       ErrorType = "TIMEOUT_ERROR"
+	ErrorTypeValidation      ErrorType = "VALIDATION_ERROR"
+	ErrorTypeNotFound        ErrorType = "NOT_FOUND"
+	ErrorTypeUnauthorized    ErrorType = "UNAUTHORIZED"
+	ErrorTypeForbidden       ErrorType = "FORBIDDEN"
+	ErrorTypeConflict        ErrorType = "CONFLICT"
+	ErrorTypeInternal        ErrorType = "INTERNAL_ERROR"
+	ErrorTypeExternal        ErrorType = "EXTERNAL_SERVICE_ERROR"
+	ErrorTypeRateLimit       ErrorType = "RATE_LIMIT_ERROR"
+	ErrorTypeTimeout         ErrorType = "TIMEOUT_ERROR"
+	ErrorTypePayloadTooLarge ErrorType = "PAYLOAD_TOO_LARGE"
 )
 
 // AppError represents an application error
@@ -148,6 +149,15 @@ func Timeout(operation string) *AppError {
 	}
 }
 
+// PayloadTooLarge creates an error for a request body over the size limit
+func PayloadTooLarge(message string) *AppError {
+	return &AppError{
+		Type:       ErrorTypePayloadTooLarge,
+		Message:    message,
+		StatusCode: http.StatusRequestEntityTooLarge,
+	}
+}
+
 // getStatusCode returns the HTTP status code for an error type
 func getStatusCode(errorType ErrorType) int {
 	switch errorType {
@@ -167,6 +177,8 @@ func getStatusCode(errorType ErrorType) int {
 		return http.StatusTooManyRequests
 	case ErrorTypeTimeout:
 		return http.StatusRequestTimeout
+	case ErrorTypePayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
 	case ErrorTypeInternal:
 		return http.StatusInternalServerError
 	default:
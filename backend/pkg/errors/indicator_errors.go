@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -173,6 +174,19 @@ func NewRateLimitError(service string, resetTime time.Time) *IndicatorError {
 	}
 }
 
+// RateLimitResetTime returns the reset time of a rate limit error anywhere in err's chain
+func RateLimitResetTime(err error) (time.Time, bool) {
+	var indErr *IndicatorError
+	if !stderrors.As(err, &indErr) || indErr.Code != ErrCodeRateLimit {
+		return time.Time{}, false
+	}
+	reset, ok := indErr.Details["reset_time"].(int64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(reset, 0), true
+}
+
 // Timeout errors
 func NewTimeoutError(component, operation string, duration time.Duration) *IndicatorError {
 	return &IndicatorError{
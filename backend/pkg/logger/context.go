@@ -0,0 +1,25 @@
+package logger
+
+import "context"
+
+// contextKey is an unexported type for context keys owned by this package
+type contextKey string
+
+// requestIDKey is the context key holding the request correlation ID
+const requestIDKey contextKey = "request_id"
+
+// ContextWithRequestID returns a copy of ctx carrying the given request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+		return requestID
+	}
+	return ""
+}
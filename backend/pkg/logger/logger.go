@@ -3,8 +3,10 @@ package logger
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 	"gorm.io/gorm/logger"
 )
@@ -24,22 +26,64 @@ type slogLogger struct {
 	logger *slog.Logger
 }
 
+// Log formats supported by the logger
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Options configures the logger level, format and output
+type Options struct {
+	Level  string // debug, info, warn or error
+	Format string // json or text
+	Output io.Writer
+}
+
+// DefaultOptions returns the logger options for the given environment
+func DefaultOptions(environment string) Options {
+	if environment == "production" {
+		// JSON at info level for production
+		return Options{Level: "info", Format: FormatJSON, Output: os.Stdout}
+	}
+	// Text at debug level for development
+	return Options{Level: "debug", Format: FormatText, Output: os.Stdout}
+}
+
 // New creates a new logger instance
 func New(environment string) Logger {
+	return NewWithOptions(DefaultOptions(environment))
+}
+
+// NewWithOutput creates a new logger instance writing to the given writer
+func NewWithOutput(environment string, w io.Writer) Logger {
+	opts := DefaultOptions(environment)
+	opts.Output = w
+	return NewWithOptions(opts)
+}
+
+// NewWithOptions creates a new logger instance from explicit options.
+// Invalid levels fall back to info and unknown formats to text.
+func NewWithOptions(opts Options) Logger {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		level = slog.LevelInfo
+	}
+
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:     level,
+		AddSource: true,
+	}
+
 	var handler slog.Handler
-	
-	if environment == "production" {
-		// JSON handler for production
-		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-			AddSource: true,
-		})
+	if strings.ToLower(opts.Format) == FormatJSON {
+		handler = slog.NewJSONHandler(output, handlerOpts)
 	} else {
-		// Text handler for development
-		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-			AddSource: true,
-		})
+		handler = slog.NewTextHandler(output, handlerOpts)
 	}
 	
 	return &slogLogger{
@@ -47,6 +91,32 @@ func New(environment string) Logger {
 	}
 }
 
+// ParseLevel converts a level name to a slog level
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("invalid log level: %q", level)
+	}
+}
+
+// ValidateFormat checks that the format is one supported by the logger
+func ValidateFormat(format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatJSON, FormatText:
+		return nil
+	default:
+		return fmt.Errorf("invalid log format: %q", format)
+	}
+}
+
 // Debug logs a debug message
 func (l *slogLogger) Debug(msg string, args ...interface{}) {
 	l.logger.Debug(msg, args...)
@@ -74,9 +144,11 @@ func (l *slogLogger) With(args ...interface{}) Logger {
 	}
 }
 
-// WithContext adds context to the logger
+// WithContext adds context values such as the request ID to the logger
 func (l *slogLogger) WithContext(ctx context.Context) Logger {
-	// Extract trace ID or other context values if needed
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return l.With("request_id", requestID)
+	}
 	return l
 }
 
@@ -99,33 +171,34 @@ func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
 
 // Info logs info messages (for gorm.logger.Interface)
 func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
-	l.logger.Info(fmt.Sprintf(msg, data...))
+	l.logger.WithContext(ctx).Info(fmt.Sprintf(msg, data...))
 }
 
 // Warn logs warning messages (for gorm.logger.Interface)
 func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
-	l.logger.Warn(fmt.Sprintf(msg, data...))
+	l.logger.WithContext(ctx).Warn(fmt.Sprintf(msg, data...))
 }
 
 // Error logs error messages (for gorm.logger.Interface)
 func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
-	l.logger.Error(fmt.Sprintf(msg, data...))
+	l.logger.WithContext(ctx).Error(fmt.Sprintf(msg, data...))
 }
 
 // Trace logs SQL queries (for gorm.logger.Interface)
 func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
 	elapsed := time.Since(begin)
 	sql, rows := fc()
+	log := l.logger.WithContext(ctx)
 	
 	if err != nil {
-		l.logger.Error("SQL Error",
+		log.Error("SQL Error",
 			"error", err,
 			"elapsed", elapsed,
 			"rows", rows,
 			"sql", sql,
 		)
 	} else {
-		l.logger.Debug("SQL Query",
+		log.Debug("SQL Query",
 			"elapsed", elapsed,
 			"rows", rows,
 			"sql", sql,
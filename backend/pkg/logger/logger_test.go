@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithOptions_LevelFiltering(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected []string
+		dropped  []string
+	}{
+		{level: "debug", expected: []string{"debug-msg", "info-msg", "warn-msg", "error-msg"}},
+		{level: "info", expected: []string{"info-msg", "warn-msg", "error-msg"}, dropped: []string{"debug-msg"}},
+		{level: "warn", expected: []string{"warn-msg", "error-msg"}, dropped: []string{"debug-msg", "info-msg"}},
+		{level: "error", expected: []string{"error-msg"}, dropped: []string{"debug-msg", "info-msg", "warn-msg"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := NewWithOptions(Options{Level: tt.level, Format: FormatText, Output: &buf})
+
+			log.Debug("debug-msg")
+			log.Info("info-msg")
+			log.Warn("warn-msg")
+			log.Error("error-msg")
+
+			output := buf.String()
+			for _, msg := range tt.expected {
+				assert.Contains(t, output, msg)
+			}
+			for _, msg := range tt.dropped {
+				assert.NotContains(t, output, msg)
+			}
+		})
+	}
+}
+
+func TestNewWithOptions_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(Options{Level: "debug", Format: FormatJSON, Output: &buf})
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	log.With("component", "test").Info("first message", "count", 3)
+	log.WithContext(ctx).Warn("second message")
+
+	scanner := bufio.NewScanner(&buf)
+	var entries []map[string]interface{}
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), "line should be valid JSON: %s", scanner.Text())
+		entries = append(entries, entry)
+	}
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first message", entries[0]["msg"])
+	assert.Equal(t, "INFO", entries[0]["level"])
+	assert.Equal(t, "test", entries[0]["component"])
+	assert.Equal(t, float64(3), entries[0]["count"])
+	assert.Equal(t, "second message", entries[1]["msg"])
+	assert.Equal(t, "req-1", entries[1]["request_id"])
+}
+
+func TestNewWithOptions_Defaults(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(Options{Level: "bogus", Format: "bogus", Output: &buf})
+
+	log.Debug("hidden")
+	log.Info("shown")
+
+	output := buf.String()
+	assert.NotContains(t, output, "hidden")
+	assert.Contains(t, output, "msg=shown")
+	assert.False(t, strings.HasPrefix(output, "{"))
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, level := range []string{"debug", "INFO", "warn", "warning", " error "} {
+		_, err := ParseLevel(level)
+		assert.NoError(t, err, level)
+	}
+
+	_, err := ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestValidateFormat(t *testing.T) {
+	assert.NoError(t, ValidateFormat("json"))
+	assert.NoError(t, ValidateFormat("TEXT"))
+	assert.Error(t, ValidateFormat("xml"))
+}
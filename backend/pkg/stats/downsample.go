@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// Downsampling methods supported by Downsample
+const (
+	DownsampleLast = "last"
+	DownsampleAvg  = "avg"
+	DownsampleOHLC = "ohlc"
+)
+
+// Point is a single time series value; Timestamp is in Unix milliseconds like the chart payloads
+type Point struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+	OHLC      *OHLC   `json:"ohlc,omitempty"`
+}
+
+// OHLC summarizes the values that fell into one bucket
+type OHLC struct {
+	Open  float64 `json:"open"`
+	High  float64 `json:"high"`
+	Low   float64 `json:"low"`
+	Close float64 `json:"close"`
+}
+
+// Downsample reduces time-ordered points to at most targetCount by grouping consecutive points
+// into equal-sized buckets. Buckets are aligned to the end of the series, so the latest point
+// always closes the final bucket and each output point carries its bucket's last timestamp.
+//
+// last keeps each bucket's final value, avg its mean, and ohlc reports the close as Value
+// with the bucket's open/high/low/close attached. Series already within targetCount are
+// returned unchanged.
+func Downsample(points []Point, targetCount int, method string) ([]Point, error) {
+	switch method {
+	case DownsampleLast, DownsampleAvg, DownsampleOHLC:
+	default:
+		return nil, fmt.Errorf("unsupported downsampling method: %q", method)
+	}
+	if targetCount < 1 {
+		return nil, fmt.Errorf("target count must be positive, got %d", targetCount)
+	}
+
+	if len(points) <= targetCount {
+		result := make([]Point, len(points))
+		copy(result, points)
+		return result, nil
+	}
+
+	size := int(math.Ceil(float64(len(points)) / float64(targetCount)))
+	buckets := int(math.Ceil(float64(len(points)) / float64(size)))
+
+	result := make([]Point, buckets)
+	end := len(points)
+	for i := buckets - 1; i >= 0; i-- {
+		start := end - size
+		if start < 0 {
+			start = 0
+		}
+		result[i] = aggregateBucket(points[start:end], method)
+		end = start
+	}
+	return result, nil
+}
+
+// aggregateBucket collapses one non-empty bucket into a single point
+func aggregateBucket(bucket []Point, method string) Point {
+	last := bucket[len(bucket)-1]
+	point := Point{Timestamp: last.Timestamp, Value: last.Value}
+
+	switch method {
+	case DownsampleAvg:
+		var sum float64
+		for _, p := range bucket {
+			sum += p.Value
+		}
+		point.Value = sum / float64(len(bucket))
+	case DownsampleOHLC:
+		ohlc := &OHLC{Open: bucket[0].Value, High: bucket[0].Value, Low: bucket[0].Value, Close: last.Value}
+		for _, p := range bucket[1:] {
+			ohlc.High = math.Max(ohlc.High, p.Value)
+			ohlc.Low = math.Min(ohlc.Low, p.Value)
+		}
+		point.OHLC = ohlc
+	}
+	return point
+}
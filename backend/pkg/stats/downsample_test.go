@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hourlyPoints builds n hourly points with values 0..n-1
+func hourlyPoints(n int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{Timestamp: int64(i) * 3600 * 1000, Value: float64(i)}
+	}
+	return points
+}
+
+func TestDownsample_ThousandToHundred(t *testing.T) {
+	points := hourlyPoints(1000)
+	latest := points[len(points)-1]
+
+	for _, method := range []string{DownsampleLast, DownsampleAvg, DownsampleOHLC} {
+		t.Run(method, func(t *testing.T) {
+			result, err := Downsample(points, 100, method)
+
+			require.NoError(t, err)
+			assert.Len(t, result, 100)
+			assert.Equal(t, latest.Timestamp, result[len(result)-1].Timestamp, "latest point should close the final bucket")
+			for i := 1; i < len(result); i++ {
+				assert.Greater(t, result[i].Timestamp, result[i-1].Timestamp)
+			}
+		})
+	}
+
+	result, err := Downsample(points, 100, DownsampleLast)
+	require.NoError(t, err)
+	assert.Equal(t, latest, result[len(result)-1])
+	assert.Equal(t, 9.0, result[0].Value)
+
+	// Buckets of ten: the first holds 0..9 and the last 990..999
+	result, err = Downsample(points, 100, DownsampleAvg)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.5, result[0].Value, 1e-9)
+	assert.InDelta(t, 994.5, result[99].Value, 1e-9)
+
+	result, err = Downsample(points, 100, DownsampleOHLC)
+	require.NoError(t, err)
+	assert.Equal(t, &OHLC{Open: 990, High: 999, Low: 990, Close: 999}, result[99].OHLC)
+	assert.Equal(t, 999.0, result[99].Value)
+}
+
+func TestDownsample_UnevenBucketsAlignToEnd(t *testing.T) {
+	// 10 points into 4 buckets of 3: the short bucket is the oldest
+	result, err := Downsample(hourlyPoints(10), 4, DownsampleAvg)
+
+	require.NoError(t, err)
+	require.Len(t, result, 4)
+	assert.InDelta(t, 0.0, result[0].Value, 1e-9)
+	assert.InDelta(t, 2.0, result[1].Value, 1e-9)
+	assert.InDelta(t, 8.0, result[3].Value, 1e-9)
+	assert.Equal(t, int64(9*3600*1000), result[3].Timestamp)
+}
+
+func TestDownsample_WithinTarget(t *testing.T) {
+	points := hourlyPoints(30)
+
+	result, err := Downsample(points, 100, DownsampleLast)
+
+	require.NoError(t, err)
+	assert.Equal(t, points, result)
+}
+
+func TestDownsample_InvalidArguments(t *testing.T) {
+	_, err := Downsample(hourlyPoints(10), 5, "median")
+	assert.Error(t, err)
+
+	_, err = Downsample(hourlyPoints(10), 0, DownsampleLast)
+	assert.Error(t, err)
+}
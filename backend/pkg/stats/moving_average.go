@@ -0,0 +1,46 @@
+package stats
+
+// SimpleMovingAverage returns the trailing simple moving average of series over window points.
+//
+// The result has one value per input point. Points before a full window is available
+// average everything seen so far, so windows longer than the series still yield a
+// (partial) average rather than gaps.
+func SimpleMovingAverage(series []float64, window int) []float64 {
+	averages := make([]float64, len(series))
+	if window < 1 {
+		return averages
+	}
+
+	var sum float64
+	for i, v := range series {
+		sum += v
+		if i >= window {
+			sum -= series[i-window]
+		}
+		count := window
+		if i+1 < window {
+			count = i + 1
+		}
+		averages[i] = sum / float64(count)
+	}
+	return averages
+}
+
+// ExponentialMovingAverage returns the exponential moving average of series for the given
+// span, with smoothing factor 2/(span+1).
+//
+// The average is seeded with the first point, so series shorter than the span still yield
+// one value per point. Spans below 1 return zeros, as SimpleMovingAverage does.
+func ExponentialMovingAverage(series []float64, span int) []float64 {
+	averages := make([]float64, len(series))
+	if span < 1 || len(series) == 0 {
+		return averages
+	}
+
+	alpha := 2 / float64(span+1)
+	averages[0] = series[0]
+	for i := 1; i < len(series); i++ {
+		averages[i] = alpha*series[i] + (1-alpha)*averages[i-1]
+	}
+	return averages
+}
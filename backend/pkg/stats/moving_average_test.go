@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimpleMovingAverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		series   []float64
+		window   int
+		expected []float64
+	}{
+		{
+			name:     "Window of three",
+			series:   []float64{1, 2, 3, 4, 5, 6},
+			window:   3,
+			expected: []float64{1, 1.5, 2, 3, 4, 5},
+		},
+		{
+			name:     "Window of one returns the series",
+			series:   []float64{4, -2, 7},
+			window:   1,
+			expected: []float64{4, -2, 7},
+		},
+		{
+			name:     "Window longer than the series gives partial averages",
+			series:   []float64{2, 4, 6},
+			window:   30,
+			expected: []float64{2, 3, 4},
+		},
+		{
+			name:     "Invalid window",
+			series:   []float64{1, 2},
+			window:   0,
+			expected: []float64{0, 0},
+		},
+		{
+			name:     "Empty series",
+			series:   []float64{},
+			window:   7,
+			expected: []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SimpleMovingAverage(tt.series, tt.window)
+
+			require.Len(t, result, len(tt.expected))
+			for i := range tt.expected {
+				assert.InDelta(t, tt.expected[i], result[i], 1e-9, "index %d", i)
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		series   []float64
+		span     int
+		expected []float64
+	}{
+		{
+			// alpha = 2/(3+1) = 0.5: each value moves halfway from the previous average
+			name:     "Span of three",
+			series:   []float64{10, 12, 8, 14, 10},
+			span:     3,
+			expected: []float64{10, 11, 9.5, 11.75, 10.875},
+		},
+		{
+			// alpha = 2/(4+1) = 0.4
+			name:     "Span of four",
+			series:   []float64{1, 2, 3, 4},
+			span:     4,
+			expected: []float64{1, 1.4, 2.04, 2.824},
+		},
+		{
+			name:     "Span of one returns the series",
+			series:   []float64{4, -2, 7},
+			span:     1,
+			expected: []float64{4, -2, 7},
+		},
+		{
+			// alpha = 2/(14+1) = 0.1333...
+			name:     "Span longer than the series",
+			series:   []float64{30, 45},
+			span:     14,
+			expected: []float64{30, 32},
+		},
+		{
+			name:     "Invalid span",
+			series:   []float64{1, 2},
+			span:     0,
+			expected: []float64{0, 0},
+		},
+		{
+			name:     "Empty series",
+			series:   []float64{},
+			span:     14,
+			expected: []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExponentialMovingAverage(tt.series, tt.span)
+
+			require.Len(t, result, len(tt.expected))
+			for i := range tt.expected {
+				assert.InDelta(t, tt.expected[i], result[i], 1e-9, "index %d", i)
+			}
+		})
+	}
+}
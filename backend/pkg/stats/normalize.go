@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// Normalization methods supported by Normalize
+const (
+	MethodMinMax = "minmax"
+	MethodZScore = "zscore"
+)
+
+// Normalize rescales a series so indicators with different scales can share a chart.
+//
+// minmax maps values to [0, 1]; a constant series maps to 0.5.
+// zscore maps values to standard deviations from the mean; a constant series maps to 0.
+func Normalize(series []float64, method string) ([]float64, error) {
+	switch method {
+	case MethodMinMax:
+		return normalizeMinMax(series), nil
+	case MethodZScore:
+		return normalizeZScore(series), nil
+	default:
+		return nil, fmt.Errorf("unsupported normalization method: %q", method)
+	}
+}
+
+// normalizeMinMax rescales values linearly into the [0, 1] range
+func normalizeMinMax(series []float64) []float64 {
+	normalized := make([]float64, len(series))
+	if len(series) == 0 {
+		return normalized
+	}
+
+	lowest, highest := series[0], series[0]
+	for _, v := range series[1:] {
+		lowest = math.Min(lowest, v)
+		highest = math.Max(highest, v)
+	}
+
+	spread := highest - lowest
+	for i, v := range series {
+		if spread == 0 {
+			normalized[i] = 0.5
+			continue
+		}
+		normalized[i] = (v - lowest) / spread
+	}
+	return normalized
+}
+
+// normalizeZScore converts values to their population z-scores
+func normalizeZScore(series []float64) []float64 {
+	normalized := make([]float64, len(series))
+	if len(series) == 0 {
+		return normalized
+	}
+
+	var mean float64
+	for _, v := range series {
+		mean += v
+	}
+	mean /= float64(len(series))
+
+	var variance float64
+	for _, v := range series {
+		variance += (v - mean) * (v - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(series)))
+
+	for i, v := range series {
+		if stdDev == 0 {
+			normalized[i] = 0
+			continue
+		}
+		normalized[i] = (v - mean) / stdDev
+	}
+	return normalized
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		series   []float64
+		method   string
+		expected []float64
+	}{
+		{
+			name:     "MinMax",
+			series:   []float64{10, 20, 30, 40, 50},
+			method:   MethodMinMax,
+			expected: []float64{0, 0.25, 0.5, 0.75, 1},
+		},
+		{
+			name:     "MinMax with negative values",
+			series:   []float64{-2, 0, 2},
+			method:   MethodMinMax,
+			expected: []float64{0, 0.5, 1},
+		},
+		{
+			name:     "MinMax constant series",
+			series:   []float64{7, 7, 7},
+			method:   MethodMinMax,
+			expected: []float64{0.5, 0.5, 0.5},
+		},
+		{
+			name:     "ZScore",
+			series:   []float64{2, 4, 4, 4, 5, 5, 7, 9},
+			method:   MethodZScore,
+			expected: []float64{-1.5, -0.5, -0.5, -0.5, 0, 0, 1, 2},
+		},
+		{
+			name:     "ZScore constant series",
+			series:   []float64{3, 3, 3, 3},
+			method:   MethodZScore,
+			expected: []float64{0, 0, 0, 0},
+		},
+		{
+			name:     "Single value",
+			series:   []float64{42},
+			method:   MethodMinMax,
+			expected: []float64{0.5},
+		},
+		{
+			name:     "Empty series",
+			series:   []float64{},
+			method:   MethodZScore,
+			expected: []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := Normalize(tt.series, tt.method)
+
+			require.NoError(t, err)
+			require.Len(t, normalized, len(tt.expected))
+			for i := range tt.expected {
+				assert.False(t, math.IsNaN(normalized[i]) || math.IsInf(normalized[i], 0))
+				assert.InDelta(t, tt.expected[i], normalized[i], 1e-9)
+			}
+		})
+	}
+}
+
+func TestNormalize_UnsupportedMethod(t *testing.T) {
+	_, err := Normalize([]float64{1, 2, 3}, "log")
+	assert.Error(t, err)
+}
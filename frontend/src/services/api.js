@@ -88,7 +88,8 @@ class ApiService {
       if (!response.ok) {
         throw new Error(`HTTP error! status: ${response.status}`)
       }
-      return await response.json()
+      const body = await response.json()
+      return body.data
     } catch (error) {
       console.error(`Error fetching chart data for ${indicator}:`, error)
       throw error